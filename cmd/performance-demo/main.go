@@ -279,6 +279,10 @@ func (f *SimpleFork) Reset() error {
 	return nil
 }
 
+func (f *SimpleFork) ResetToBlock(blockNumber uint64) error {
+	return nil
+}
+
 func (f *SimpleFork) Close() error {
 	return nil
 }
@@ -2,7 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -16,6 +18,10 @@ type Handlers struct {
 	strategyEngine interfaces.StrategyEngine
 	metricsCollector interfaces.MetricsCollector
 	shutdownManager interfaces.ShutdownManager
+	positionTracker interfaces.PositionTracker
+	riskEngine interfaces.RiskEngine
+	progression interfaces.ProgressionController
+	paperLedger interfaces.PaperLedger
 }
 
 // NewHandlers creates a new handlers instance
@@ -138,6 +144,218 @@ func (h *Handlers) GetLatencyMetrics(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(latencyMetrics)
 }
 
+// GetPositions returns our token positions, marked to market, with their totals
+func (h *Handlers) GetPositions(w http.ResponseWriter, r *http.Request) {
+	if h.positionTracker == nil {
+		http.Error(w, "Position tracking is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.positionTracker.Summary())
+}
+
+// GetRiskLimits returns the global and per-strategy risk limits
+func (h *Handlers) GetRiskLimits(w http.ResponseWriter, r *http.Request) {
+	if h.riskEngine == nil {
+		http.Error(w, "Risk engine is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.riskEngine.Limits())
+}
+
+// UpdateRiskLimits replaces the risk limits; they apply from the next submission
+func (h *Handlers) UpdateRiskLimits(w http.ResponseWriter, r *http.Request) {
+	if h.riskEngine == nil {
+		http.Error(w, "Risk engine is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	var config interfaces.RiskConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if err := h.riskEngine.UpdateLimits(&config); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid risk limits: %v", err), http.StatusBadRequest)
+		return
+	}
+	if user, ok := r.Context().Value("user").(*interfaces.APIUser); ok {
+		log.Printf("Risk limits updated by %s", user.ID)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
+// GetRiskRejections returns the most recent bundles the risk engine rejected, and why
+func (h *Handlers) GetRiskRejections(w http.ResponseWriter, r *http.Request) {
+	if h.riskEngine == nil {
+		http.Error(w, "Risk engine is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	limit := 100 // default
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	rejections := h.riskEngine.Rejections(limit)
+	response := map[string]interface{}{
+		"rejections":  rejections,
+		"total_count": len(rejections),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetRiskExposure returns the volume, gas, profit and in-flight bundles the risk
+// limits are measured against
+func (h *Handlers) GetRiskExposure(w http.ResponseWriter, r *http.Request) {
+	if h.riskEngine == nil {
+		http.Error(w, "Risk engine is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.riskEngine.Exposure())
+}
+
+// GetExecutionProgression returns each strategy's execution mode and the status of
+// the requirements for advancing it
+func (h *Handlers) GetExecutionProgression(w http.ResponseWriter, r *http.Request) {
+	if h.progression == nil {
+		http.Error(w, "Execution progression is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	progressions, err := h.progression.Progressions(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to evaluate execution progression: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"auto_progression": h.progression.AutoProgression(),
+		"strategies":       progressions,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetStrategyProgression returns a strategy's execution mode and the status of the
+// requirements for advancing it
+func (h *Handlers) GetStrategyProgression(w http.ResponseWriter, r *http.Request) {
+	if h.progression == nil {
+		http.Error(w, "Execution progression is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	strategy := interfaces.StrategyType(mux.Vars(r)["strategy"])
+	progression, err := h.progression.Evaluate(r.Context(), strategy)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to evaluate execution progression: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(progression)
+}
+
+// SetAutoProgression turns automatic execution mode changes on or off
+func (h *Handlers) SetAutoProgression(w http.ResponseWriter, r *http.Request) {
+	if h.progression == nil {
+		http.Error(w, "Execution progression is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	var request struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid JSON: %v", err), http.StatusBadRequest)
+		return
+	}
+	if request.Enabled == nil {
+		http.Error(w, "enabled is required", http.StatusBadRequest)
+		return
+	}
+	h.progression.SetAutoProgression(*request.Enabled)
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]bool{"auto_progression": *request.Enabled})
+}
+
+// GetPaperAccounts returns every strategy's paper account, with its virtual
+// balances and PnL curve
+func (h *Handlers) GetPaperAccounts(w http.ResponseWriter, r *http.Request) {
+	if h.paperLedger == nil {
+		http.Error(w, "Paper ledger is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := map[string]interface{}{
+		"accounts": h.paperLedger.Accounts(),
+		"pending":  h.paperLedger.Pending(),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetPaperAccount returns a strategy's paper account and its most recent fills
+func (h *Handlers) GetPaperAccount(w http.ResponseWriter, r *http.Request) {
+	if h.paperLedger == nil {
+		http.Error(w, "Paper ledger is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	strategy := interfaces.StrategyType(mux.Vars(r)["strategy"])
+	account, exists := h.paperLedger.Account(strategy)
+	if !exists {
+		http.Error(w, fmt.Sprintf("No paper account for strategy %s", strategy), http.StatusNotFound)
+		return
+	}
+
+	limit := 100 // default
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	response := map[string]interface{}{
+		"account": account,
+		"fills":   h.paperLedger.Fills(strategy, limit),
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ResetPaperAccount restores a strategy's starting paper balances
+func (h *Handlers) ResetPaperAccount(w http.ResponseWriter, r *http.Request) {
+	if h.paperLedger == nil {
+		http.Error(w, "Paper ledger is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	strategy := interfaces.StrategyType(mux.Vars(r)["strategy"])
+	h.paperLedger.Reset(strategy)
+	if user, ok := r.Context().Value("user").(*interfaces.APIUser); ok {
+		log.Printf("Paper account of %s reset by %s", strategy, user.ID)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"status": "success"})
+}
+
 // GetStrategies returns active strategies and their configurations
 func (h *Handlers) GetStrategies(w http.ResponseWriter, r *http.Request) {
 	activeStrategies := h.strategyEngine.GetActiveStrategies()
@@ -145,12 +363,28 @@ func (h *Handlers) GetStrategies(w http.ResponseWriter, r *http.Request) {
 	response := map[string]interface{}{
 		"active_strategies": activeStrategies,
 		"total_count":      len(activeStrategies),
+		"strategies":       h.strategyEngine.GetStrategyStates(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetStrategy returns a strategy's enabled state, settings and config schema
+func (h *Handlers) GetStrategy(w http.ResponseWriter, r *http.Request) {
+	strategy := interfaces.StrategyType(mux.Vars(r)["strategy"])
+
+	for _, state := range h.strategyEngine.GetStrategyStates() {
+		if state.Name == strategy {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(state)
+			return
+		}
+	}
+
+	http.Error(w, fmt.Sprintf("Unknown strategy: %s", strategy), http.StatusNotFound)
+}
+
 // UpdateStrategyConfig updates configuration for a specific strategy
 func (h *Handlers) UpdateStrategyConfig(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -165,7 +399,7 @@ func (h *Handlers) UpdateStrategyConfig(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := h.strategyEngine.UpdateStrategyConfig(strategy, config); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to update strategy config: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Failed to update strategy config: %v", err), strategyErrorStatus(err))
 		return
 	}
 
@@ -181,7 +415,7 @@ func (h *Handlers) EnableStrategy(w http.ResponseWriter, r *http.Request) {
 	strategy := interfaces.StrategyType(strategyStr)
 	
 	if err := h.strategyEngine.EnableStrategy(strategy); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to enable strategy: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Failed to enable strategy: %v", err), strategyErrorStatus(err))
 		return
 	}
 
@@ -197,7 +431,7 @@ func (h *Handlers) DisableStrategy(w http.ResponseWriter, r *http.Request) {
 	strategy := interfaces.StrategyType(strategyStr)
 	
 	if err := h.strategyEngine.DisableStrategy(strategy); err != nil {
-		http.Error(w, fmt.Sprintf("Failed to disable strategy: %v", err), http.StatusInternalServerError)
+		http.Error(w, fmt.Sprintf("Failed to disable strategy: %v", err), strategyErrorStatus(err))
 		return
 	}
 
@@ -205,6 +439,18 @@ func (h *Handlers) DisableStrategy(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(map[string]string{"status": "disabled"})
 }
 
+// strategyErrorStatus returns the HTTP status for a strategy engine error
+func strategyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, interfaces.ErrUnknownStrategy):
+		return http.StatusNotFound
+	case errors.Is(err, interfaces.ErrInvalidStrategyConfig):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // GetPrometheusMetrics returns metrics in Prometheus format
 func (h *Handlers) GetPrometheusMetrics(w http.ResponseWriter, r *http.Request) {
 	metrics, err := h.metricsCollector.GetPrometheusMetrics()
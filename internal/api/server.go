@@ -99,6 +99,27 @@ func (s *Server) Stop(ctx context.Context) error {
 	return nil
 }
 
+// SetPositionTracker sets the tracker served at /api/v1/positions
+func (s *Server) SetPositionTracker(tracker interfaces.PositionTracker) {
+	s.handlers.positionTracker = tracker
+}
+
+// SetRiskEngine sets the risk engine whose limits are served and updated at
+// /api/v1/risk
+func (s *Server) SetRiskEngine(risk interfaces.RiskEngine) {
+	s.handlers.riskEngine = risk
+}
+
+// SetProgressionController sets the controller served at /api/v1/execution/progression
+func (s *Server) SetProgressionController(progression interfaces.ProgressionController) {
+	s.handlers.progression = progression
+}
+
+// SetPaperLedger sets the ledger whose paper accounts are served at /api/v1/paper
+func (s *Server) SetPaperLedger(ledger interfaces.PaperLedger) {
+	s.handlers.paperLedger = ledger
+}
+
 // GetRouter returns the HTTP router
 func (s *Server) GetRouter() http.Handler {
 	return s.server.Handler
@@ -142,8 +163,25 @@ func (s *Server) setupServer() {
 	api.HandleFunc("/metrics/profitability", s.handlers.GetMetrics).Methods("GET")
 	api.HandleFunc("/metrics/latency/{operation}", s.handlers.GetLatencyMetrics).Methods("GET")
 	
+	// Positions
+	api.HandleFunc("/positions", s.handlers.GetPositions).Methods("GET")
+	
+	// Risk limits (read access)
+	api.HandleFunc("/risk/limits", s.handlers.GetRiskLimits).Methods("GET")
+	api.HandleFunc("/risk/rejections", s.handlers.GetRiskRejections).Methods("GET")
+	api.HandleFunc("/risk/exposure", s.handlers.GetRiskExposure).Methods("GET")
+	
+	// Execution mode progression
+	api.HandleFunc("/execution/progression", s.handlers.GetExecutionProgression).Methods("GET")
+	api.HandleFunc("/execution/progression/{strategy}", s.handlers.GetStrategyProgression).Methods("GET")
+	
+	// Paper trading
+	api.HandleFunc("/paper/accounts", s.handlers.GetPaperAccounts).Methods("GET")
+	api.HandleFunc("/paper/accounts/{strategy}", s.handlers.GetPaperAccount).Methods("GET")
+	
 	// Strategies (read access)
 	api.HandleFunc("/strategies", s.handlers.GetStrategies).Methods("GET")
+	api.HandleFunc("/strategies/{strategy}", s.handlers.GetStrategy).Methods("GET")
 	
 	// Strategy management (operator+ access)
 	operatorRoutes := api.PathPrefix("").Subrouter()
@@ -151,6 +189,9 @@ func (s *Server) setupServer() {
 	operatorRoutes.HandleFunc("/strategies/{strategy}/config", s.handlers.UpdateStrategyConfig).Methods("PUT")
 	operatorRoutes.HandleFunc("/strategies/{strategy}/enable", s.handlers.EnableStrategy).Methods("POST")
 	operatorRoutes.HandleFunc("/strategies/{strategy}/disable", s.handlers.DisableStrategy).Methods("POST")
+	operatorRoutes.HandleFunc("/risk/limits", s.handlers.UpdateRiskLimits).Methods("PUT")
+	operatorRoutes.HandleFunc("/execution/progression/auto", s.handlers.SetAutoProgression).Methods("PUT")
+	operatorRoutes.HandleFunc("/paper/accounts/{strategy}/reset", s.handlers.ResetPaperAccount).Methods("POST")
 	
 	// Admin routes
 	adminRoutes := api.PathPrefix("/admin").Subrouter()
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/mev-engine/l2-mev-strategy-engine/internal/config"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
@@ -47,6 +51,11 @@ func (m *MockStrategyEngine) UpdateStrategyConfig(strategy interfaces.StrategyTy
 	return args.Error(0)
 }
 
+func (m *MockStrategyEngine) GetStrategyStates() []*interfaces.StrategyState {
+	args := m.Called()
+	return args.Get(0).([]*interfaces.StrategyState)
+}
+
 type MockMetricsCollector struct {
 	mock.Mock
 }
@@ -125,6 +134,132 @@ func (m *MockShutdownManager) SetManualOverride(enabled bool) error {
 	return args.Error(0)
 }
 
+type MockPositionTracker struct {
+	mock.Mock
+}
+
+func (m *MockPositionTracker) AvailableCapital(token common.Address) *big.Int {
+	args := m.Called(token)
+	return args.Get(0).(*big.Int)
+}
+
+func (m *MockPositionTracker) Apply(result *interfaces.InclusionResult) {
+	m.Called(result)
+}
+
+func (m *MockPositionTracker) Reconcile(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockPositionTracker) Positions() []*interfaces.Position {
+	args := m.Called()
+	return args.Get(0).([]*interfaces.Position)
+}
+
+func (m *MockPositionTracker) Summary() *interfaces.PositionSummary {
+	args := m.Called()
+	return args.Get(0).(*interfaces.PositionSummary)
+}
+
+type MockRiskEngine struct {
+	mock.Mock
+}
+
+func (m *MockRiskEngine) Approve(ctx context.Context, opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction) error {
+	args := m.Called(ctx, opportunity, bundle)
+	return args.Error(0)
+}
+
+func (m *MockRiskEngine) Cancel(opportunityID string) {
+	m.Called(opportunityID)
+}
+
+func (m *MockRiskEngine) Settle(trade *interfaces.TradeResult) {
+	m.Called(trade)
+}
+
+func (m *MockRiskEngine) Limits() *interfaces.RiskConfig {
+	args := m.Called()
+	return args.Get(0).(*interfaces.RiskConfig)
+}
+
+func (m *MockRiskEngine) UpdateLimits(config *interfaces.RiskConfig) error {
+	args := m.Called(config)
+	return args.Error(0)
+}
+
+func (m *MockRiskEngine) Rejections(limit int) []*interfaces.RiskRejection {
+	args := m.Called(limit)
+	return args.Get(0).([]*interfaces.RiskRejection)
+}
+
+func (m *MockRiskEngine) Exposure() *interfaces.RiskExposure {
+	args := m.Called()
+	return args.Get(0).(*interfaces.RiskExposure)
+}
+
+type MockProgressionController struct {
+	mock.Mock
+}
+
+func (m *MockProgressionController) Evaluate(ctx context.Context, strategy interfaces.StrategyType) (*interfaces.ExecutionProgression, error) {
+	args := m.Called(ctx, strategy)
+	return args.Get(0).(*interfaces.ExecutionProgression), args.Error(1)
+}
+
+func (m *MockProgressionController) Progressions(ctx context.Context) ([]*interfaces.ExecutionProgression, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*interfaces.ExecutionProgression), args.Error(1)
+}
+
+func (m *MockProgressionController) AutoProgression() bool {
+	args := m.Called()
+	return args.Bool(0)
+}
+
+func (m *MockProgressionController) SetAutoProgression(enabled bool) {
+	m.Called(enabled)
+}
+
+type MockPaperLedger struct {
+	mock.Mock
+}
+
+func (m *MockPaperLedger) Track(ctx context.Context, opportunity *interfaces.MEVOpportunity, trade *interfaces.TradeResult) error {
+	args := m.Called(ctx, opportunity, trade)
+	return args.Error(0)
+}
+
+func (m *MockPaperLedger) Check(ctx context.Context) ([]*interfaces.PaperFill, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*interfaces.PaperFill), args.Error(1)
+}
+
+func (m *MockPaperLedger) Pending() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockPaperLedger) Accounts() []*interfaces.PaperAccount {
+	args := m.Called()
+	return args.Get(0).([]*interfaces.PaperAccount)
+}
+
+func (m *MockPaperLedger) Account(strategy interfaces.StrategyType) (*interfaces.PaperAccount, bool) {
+	args := m.Called(strategy)
+	return args.Get(0).(*interfaces.PaperAccount), args.Bool(1)
+}
+
+func (m *MockPaperLedger) Fills(strategy interfaces.StrategyType, limit int) []*interfaces.PaperFill {
+	args := m.Called(strategy, limit)
+	return args.Get(0).([]*interfaces.PaperFill)
+}
+
+func (m *MockPaperLedger) Reset(strategy interfaces.StrategyType) {
+	m.Called(strategy)
+}
+
 // Test setup helper
 func setupTestServer(t *testing.T) (*Server, *MockStrategyEngine, *MockMetricsCollector, *MockShutdownManager) {
 	cfg := &config.Config{
@@ -271,6 +406,219 @@ func TestGetMetrics(t *testing.T) {
 	mockMetrics.AssertExpectations(t)
 }
 
+func TestGetPositions(t *testing.T) {
+	server, _, _, _ := setupTestServer(t)
+	apiKey := getTestAPIKey(server.authService)
+
+	// Without a tracker the endpoint is unavailable
+	req := httptest.NewRequest("GET", "/api/v1/positions", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	dai := common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb")
+	position := &interfaces.Position{Token: weth, Amount: big.NewInt(2e18), ValueETH: big.NewInt(2e18), ValueUSD: 6000}
+	residual := &interfaces.Position{Token: dai, Amount: big.NewInt(5e18), Residual: true}
+	tracker := &MockPositionTracker{}
+	tracker.On("Summary").Return(&interfaces.PositionSummary{
+		TotalPositions:   2,
+		TotalValueETH:    big.NewInt(2e18),
+		TotalValueUSD:    6000,
+		PositionsByToken: map[string]*big.Int{weth.Hex(): big.NewInt(2e18), dai.Hex(): big.NewInt(5e18)},
+		LargestPosition:  position,
+		Residual:         []*interfaces.Position{residual},
+		Positions:        []*interfaces.Position{position, residual},
+	})
+	server.SetPositionTracker(tracker)
+
+	req = httptest.NewRequest("GET", "/api/v1/positions", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response interfaces.PositionSummary
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.TotalPositions)
+	assert.Equal(t, big.NewInt(2e18), response.TotalValueETH)
+	assert.Equal(t, weth, response.LargestPosition.Token)
+	require.Len(t, response.Residual, 1)
+	assert.Equal(t, dai, response.Residual[0].Token)
+	assert.Len(t, response.Positions, 2)
+
+	tracker.AssertExpectations(t)
+}
+
+func TestRiskLimits(t *testing.T) {
+	server, _, _, _ := setupTestServer(t)
+	apiKey := getTestAPIKey(server.authService)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+		return w
+	}
+
+	// Without a risk engine the endpoints are unavailable
+	assert.Equal(t, http.StatusServiceUnavailable, serve("GET", "/api/v1/risk/limits", "").Code)
+
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	limits := &interfaces.RiskConfig{
+		Global: interfaces.RiskLimits{MaxNotional: big.NewInt(1e18), MaxInFlight: 4},
+		Strategies: map[interfaces.StrategyType]interfaces.RiskLimits{
+			interfaces.StrategySandwich: {AllowedTokens: []common.Address{weth}},
+		},
+	}
+	rejection := &interfaces.RiskRejection{OpportunityID: "opp_1", Strategy: interfaces.StrategySandwich, Scope: "global", Limit: "max_notional", Reason: "notional 2 exceeds 1"}
+	risk := &MockRiskEngine{}
+	risk.On("Limits").Return(limits)
+	risk.On("UpdateLimits", limits).Return(nil)
+	risk.On("UpdateLimits", mock.Anything).Return(errors.New("max_in_flight cannot be negative"))
+	risk.On("Rejections", 5).Return([]*interfaces.RiskRejection{rejection})
+	server.SetRiskEngine(risk)
+
+	w := serve("GET", "/api/v1/risk/limits", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var config interfaces.RiskConfig
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &config))
+	assert.Equal(t, *limits, config)
+
+	// Limits round-trip through the update
+	body, err := json.Marshal(limits)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, serve("PUT", "/api/v1/risk/limits", string(body)).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("PUT", "/api/v1/risk/limits", `{"global": {"max_in_flight": -1}}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("PUT", "/api/v1/risk/limits", `not json`).Code)
+
+	w = serve("GET", "/api/v1/risk/rejections?limit=5", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Rejections []*interfaces.RiskRejection `json:"rejections"`
+		TotalCount int                         `json:"total_count"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 1, response.TotalCount)
+	assert.Equal(t, "max_notional", response.Rejections[0].Limit)
+
+	risk.AssertExpectations(t)
+}
+
+func TestExecutionProgression(t *testing.T) {
+	server, _, _, _ := setupTestServer(t)
+	apiKey := getTestAPIKey(server.authService)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+		return w
+	}
+
+	// Without a controller the endpoints are unavailable
+	assert.Equal(t, http.StatusServiceUnavailable, serve("GET", "/api/v1/execution/progression", "").Code)
+
+	progression := &interfaces.ExecutionProgression{
+		Strategy:    interfaces.StrategyBackrun,
+		CurrentMode: interfaces.ExecutionModeSimulation,
+		NextMode:    interfaces.ExecutionModeHybrid,
+		Requirements: []*interfaces.ProgressionRequirement{
+			{Name: "min_samples", Current: 40, Required: 100, Unit: "trades"},
+			{Name: "success_rate", Current: 95, Required: 90, Unit: "%", Met: true},
+		},
+	}
+	controller := &MockProgressionController{}
+	controller.On("Progressions", mock.Anything).Return([]*interfaces.ExecutionProgression{progression}, nil)
+	controller.On("Evaluate", mock.Anything, interfaces.StrategyBackrun).Return(progression, nil)
+	controller.On("AutoProgression").Return(false)
+	controller.On("SetAutoProgression", true).Return()
+	server.SetProgressionController(controller)
+
+	w := serve("GET", "/api/v1/execution/progression", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		AutoProgression bool                               `json:"auto_progression"`
+		Strategies      []*interfaces.ExecutionProgression `json:"strategies"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response.AutoProgression)
+	require.Len(t, response.Strategies, 1)
+	assert.Equal(t, progression.Requirements, response.Strategies[0].Requirements)
+
+	w = serve("GET", "/api/v1/execution/progression/backrun", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var single interfaces.ExecutionProgression
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &single))
+	assert.Equal(t, interfaces.ExecutionModeHybrid, single.NextMode)
+
+	assert.Equal(t, http.StatusOK, serve("PUT", "/api/v1/execution/progression/auto", `{"enabled": true}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve("PUT", "/api/v1/execution/progression/auto", `{}`).Code)
+
+	controller.AssertExpectations(t)
+}
+
+func TestPaperAccounts(t *testing.T) {
+	server, _, _, _ := setupTestServer(t)
+	apiKey := getTestAPIKey(server.authService)
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+		w := httptest.NewRecorder()
+		server.GetRouter().ServeHTTP(w, req)
+		return w
+	}
+
+	// Without a ledger the endpoints are unavailable
+	assert.Equal(t, http.StatusServiceUnavailable, serve("GET", "/api/v1/paper/accounts").Code)
+
+	account := &interfaces.PaperAccount{
+		Strategy:    interfaces.StrategyBackrun,
+		Balances:    map[common.Address]*big.Int{{}: big.NewInt(1e18)},
+		PnL:         big.NewInt(5e15),
+		MaxDrawdown: big.NewInt(1e15),
+		Fills:       3,
+	}
+	fill := &interfaces.PaperFill{TradeID: "trade_1", Strategy: interfaces.StrategyBackrun, InclusionBlock: 101, SlippageBps: 250}
+	ledger := &MockPaperLedger{}
+	ledger.On("Accounts").Return([]*interfaces.PaperAccount{account})
+	ledger.On("Pending").Return(2)
+	ledger.On("Account", interfaces.StrategyBackrun).Return(account, true)
+	ledger.On("Account", interfaces.StrategySandwich).Return((*interfaces.PaperAccount)(nil), false)
+	ledger.On("Fills", interfaces.StrategyBackrun, 10).Return([]*interfaces.PaperFill{fill})
+	ledger.On("Reset", interfaces.StrategyBackrun).Return()
+	server.SetPaperLedger(ledger)
+
+	w := serve("GET", "/api/v1/paper/accounts")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Accounts []*interfaces.PaperAccount `json:"accounts"`
+		Pending  int                        `json:"pending"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Accounts, 1)
+	assert.Equal(t, big.NewInt(5e15), response.Accounts[0].PnL)
+	assert.Equal(t, big.NewInt(1e18), response.Accounts[0].Balances[common.Address{}])
+	assert.Equal(t, 2, response.Pending)
+
+	w = serve("GET", "/api/v1/paper/accounts/backrun?limit=10")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var single struct {
+		Account *interfaces.PaperAccount `json:"account"`
+		Fills   []*interfaces.PaperFill  `json:"fills"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &single))
+	assert.Equal(t, 3, single.Account.Fills)
+	require.Len(t, single.Fills, 1)
+	assert.Equal(t, int64(250), single.Fills[0].SlippageBps)
+
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/api/v1/paper/accounts/sandwich").Code)
+	assert.Equal(t, http.StatusOK, serve("POST", "/api/v1/paper/accounts/backrun/reset").Code)
+
+	ledger.AssertExpectations(t)
+}
+
 func TestStrategyManagement(t *testing.T) {
 	server, mockStrategy, _, _ := setupTestServer(t)
 
@@ -319,6 +667,61 @@ func TestStrategyManagement(t *testing.T) {
 	mockStrategy.AssertExpectations(t)
 }
 
+func TestStrategyState(t *testing.T) {
+	server, mockStrategy, _, _ := setupTestServer(t)
+
+	apiKey := getTestAPIKey(server.authService)
+
+	mockStrategy.On("GetActiveStrategies").Return([]interfaces.StrategyType{interfaces.StrategySandwich})
+	mockStrategy.On("GetStrategyStates").Return([]*interfaces.StrategyState{{
+		Name:    interfaces.StrategySandwich,
+		Enabled: true,
+		Inputs:  []interfaces.StrategyInputKind{interfaces.InputTransaction},
+		Config:  map[string]interface{}{"max_slippage": 0.02},
+	}})
+
+	req := httptest.NewRequest("GET", "/api/v1/strategies", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w := httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Strategies []*interfaces.StrategyState `json:"strategies"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Strategies, 1)
+	assert.Equal(t, 0.02, response.Strategies[0].Config["max_slippage"])
+
+	req = httptest.NewRequest("GET", "/api/v1/strategies/sandwich", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/api/v1/strategies/jit", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// Engine errors map to client errors
+	mockStrategy.On("EnableStrategy", interfaces.StrategyJIT).Return(fmt.Errorf("%w: jit", interfaces.ErrUnknownStrategy))
+	req = httptest.NewRequest("POST", "/api/v1/strategies/jit/enable", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	mockStrategy.On("UpdateStrategyConfig", interfaces.StrategySandwich, mock.Anything).Return(fmt.Errorf("%w: unknown setting", interfaces.ErrInvalidStrategyConfig))
+	req = httptest.NewRequest("PUT", "/api/v1/strategies/sandwich/config", bytes.NewBufferString(`{"max_slipage": 0.01}`))
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	server.GetRouter().ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestAuthentication(t *testing.T) {
 	server, _, _, _ := setupTestServer(t)
 
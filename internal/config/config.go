@@ -16,6 +16,14 @@ type Config struct {
 	Queue      QueueConfig      `mapstructure:"queue"`
 	Monitoring MonitoringConfig `mapstructure:"monitoring"`
 	Database   DatabaseConfig   `mapstructure:"database"`
+	Pools      PoolsConfig      `mapstructure:"pools"`
+	Events     EventsConfig     `mapstructure:"events"`
+	Pricing    PricingConfig    `mapstructure:"pricing"`
+	Execution  ExecutionConfig  `mapstructure:"execution"`
+
+	// StrategySettings holds the raw strategies section keyed by strategy name, for
+	// strategy plugins to read their settings from
+	StrategySettings map[string]map[string]interface{} `mapstructure:"-"`
 }
 
 // ServerConfig contains server configuration
@@ -54,6 +62,12 @@ type StrategiesConfig struct {
 	Backrun   BackrunStrategyConfig   `mapstructure:"backrun"`
 	Frontrun  FrontrunStrategyConfig  `mapstructure:"frontrun"`
 	TimeBandit TimeBanditStrategyConfig `mapstructure:"time_bandit"`
+	Liquidation LiquidationStrategyConfig `mapstructure:"liquidation"`
+	JIT        JITStrategyConfig        `mapstructure:"jit"`
+	OracleBackrun OracleBackrunStrategyConfig `mapstructure:"oracle_backrun"`
+	Sniping    SnipingStrategyConfig    `mapstructure:"sniping"`
+	CrossLayer CrossLayerStrategyConfig `mapstructure:"cross_layer"`
+	TokenSafety TokenSafetyConfig       `mapstructure:"token_safety"`
 }
 
 // SandwichStrategyConfig contains sandwich strategy configuration
@@ -89,6 +103,110 @@ type TimeBanditStrategyConfig struct {
 	MaxBundleSize      int    `mapstructure:"max_bundle_size"`
 	MinProfitThreshold string `mapstructure:"min_profit_threshold"`
 	MaxDependencyDepth int    `mapstructure:"max_dependency_depth"`
+	LookbackBlocks     int    `mapstructure:"lookback_blocks"`
+	MaxOrderings       int    `mapstructure:"max_orderings"`
+	HistoryCapacity    int    `mapstructure:"history_capacity"`
+}
+
+// LiquidationStrategyConfig contains liquidation strategy configuration
+type LiquidationStrategyConfig struct {
+	Enabled         bool                  `mapstructure:"enabled"`
+	MinProfitUSD    float64               `mapstructure:"min_profit_usd"`
+	MaxSlippageBps  uint16                `mapstructure:"max_slippage_bps"`
+	FlashLoanPool   string                `mapstructure:"flash_loan_pool"` // Aave V3 pool used for flashLoanSimple
+	FlashLoanFeeBps uint16                `mapstructure:"flash_loan_fee_bps"`
+	GasLimit        uint64                `mapstructure:"gas_limit"`
+	Markets         []LendingMarketConfig `mapstructure:"markets"`
+}
+
+// JITStrategyConfig contains just-in-time liquidity strategy configuration
+type JITStrategyConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	MinSwapAmount      string `mapstructure:"min_swap_amount"`
+	MaxCapital         string `mapstructure:"max_capital"`
+	TickRangeWidth     int32  `mapstructure:"tick_range_width"` // In tick spacings
+	InventoryRiskBps   uint16 `mapstructure:"inventory_risk_bps"`
+	GasLimit           uint64 `mapstructure:"gas_limit"`
+	MinProfitThreshold string `mapstructure:"min_profit_threshold"`
+}
+
+// OracleBackrunStrategyConfig contains oracle-update backrun strategy configuration
+type OracleBackrunStrategyConfig struct {
+	Enabled            bool               `mapstructure:"enabled"`
+	PythContract       string             `mapstructure:"pyth_contract"`
+	MinPriceGapBps     uint16             `mapstructure:"min_price_gap_bps"` // Pool price against the new oracle price, after the pool fee
+	MaxTradeSize       string             `mapstructure:"max_trade_size"`
+	MinProfitThreshold string             `mapstructure:"min_profit_threshold"`
+	Feeds              []OracleFeedConfig `mapstructure:"feeds"`
+}
+
+// SnipingStrategyConfig contains new-pool sniping strategy configuration
+type SnipingStrategyConfig struct {
+	Enabled            bool              `mapstructure:"enabled"`
+	Factories          map[string]string `mapstructure:"factories"` // Factory address to protocol name, as in ParseProtocol
+	QuoteTokens        []string          `mapstructure:"quote_tokens"`
+	MinLiquidity       string            `mapstructure:"min_liquidity"`       // Quote side, in wei of ETH
+	MaxBuyAmount       string            `mapstructure:"max_buy_amount"`      // In wei of ETH
+	ExpectedBuyVolume  string            `mapstructure:"expected_buy_volume"` // Follow-on buys the exit sells into, in wei of ETH
+	MaxTaxBps          uint16            `mapstructure:"max_tax_bps"`
+	MinProfitThreshold string            `mapstructure:"min_profit_threshold"`
+}
+
+// CrossLayerStrategyConfig contains L1/L2 bridge arbitrage configuration
+type CrossLayerStrategyConfig struct {
+	Enabled            bool          `mapstructure:"enabled"`
+	L1RPCURL           string        `mapstructure:"l1_rpc_url"` // Ethereum node read for L1 pool state and withdrawal proving
+	Account            string        `mapstructure:"account"`    // Receives bridged funds and sends prove and finalize transactions
+	MinPriceGap        string        `mapstructure:"min_price_gap"`
+	MinAmount          string        `mapstructure:"min_amount"`
+	MaxAmount          string        `mapstructure:"max_amount"`
+	BridgeFee          string        `mapstructure:"bridge_fee"`
+	MinProfitThreshold string        `mapstructure:"min_profit_threshold"`
+	DepositDelay       time.Duration `mapstructure:"deposit_delay"`
+	ProvingDelay       time.Duration `mapstructure:"proving_delay"`    // Until a dispute game covers the withdrawal
+	ChallengeWindow    time.Duration `mapstructure:"challenge_window"` // From proving until finalization
+	CapitalCostBps     uint32        `mapstructure:"capital_cost_bps"` // Annual
+	SettlementGasCost  string        `mapstructure:"settlement_gas_cost"`
+}
+
+// TokenSafetyConfig contains the fork round trip used to screen tokens
+type TokenSafetyConfig struct {
+	Probe           string        `mapstructure:"probe"`          // Fork account that buys and sells
+	TransferProbe   string        `mapstructure:"transfer_probe"` // Receives a transfer to detect blacklists
+	ProbeAmount     string        `mapstructure:"probe_amount"`
+	MaxTxMultiplier int64         `mapstructure:"max_tx_multiplier"`
+	MaxTaxBps       uint16        `mapstructure:"max_tax_bps"`
+	GasLimit        uint64        `mapstructure:"gas_limit"`
+	CacheTTL        time.Duration `mapstructure:"cache_ttl"`
+}
+
+// OracleFeedConfig maps a Chainlink aggregator or Pyth price ID to the token it prices
+type OracleFeedConfig struct {
+	Source   string `mapstructure:"source"`    // chainlink or pyth
+	Address  string `mapstructure:"address"`   // Chainlink aggregator (not the proxy)
+	PriceID  string `mapstructure:"price_id"`  // Pyth price feed ID
+	Token    string `mapstructure:"token"`
+	Decimals uint8  `mapstructure:"decimals"`  // Chainlink answer decimals
+}
+
+// LendingMarketConfig describes a lending market watched for liquidations
+type LendingMarketConfig struct {
+	Protocol  string               `mapstructure:"protocol"`   // aave_v3, compound_v3 or moonwell
+	Address   string               `mapstructure:"address"`    // Aave pool, Comet or Moonwell comptroller
+	BaseAsset string               `mapstructure:"base_asset"` // Compound V3 only: token of the borrowable base asset
+	Assets    []LendingAssetConfig `mapstructure:"assets"`
+}
+
+// LendingAssetConfig describes an asset listed on a lending market
+type LendingAssetConfig struct {
+	Token                string `mapstructure:"token"`
+	Symbol               string `mapstructure:"symbol"`
+	Decimals             uint8  `mapstructure:"decimals"`
+	Market               string `mapstructure:"market"`     // Moonwell only: the asset's mToken
+	PriceFeed            string `mapstructure:"price_feed"` // Chainlink aggregator; empty prices through the pool-derived oracle
+	PriceFeedDecimals    uint8  `mapstructure:"price_feed_decimals"`
+	LiquidationThreshold uint16 `mapstructure:"liquidation_threshold"` // Basis points
+	LiquidationBonus     uint16 `mapstructure:"liquidation_bonus"`     // Basis points
 }
 
 // QueueConfig contains transaction queue configuration
@@ -118,6 +236,186 @@ type DatabaseConfig struct {
 	MaxIdleConns int    `mapstructure:"max_idle_conns"`
 }
 
+// PoolsConfig contains pool registry configuration
+type PoolsConfig struct {
+	MinTVL       string          `mapstructure:"min_tvl"`
+	BatchSize    uint64          `mapstructure:"batch_size"`
+	SyncInterval time.Duration   `mapstructure:"sync_interval"`
+	Factories    []FactoryConfig `mapstructure:"factories"`
+}
+
+// FactoryConfig identifies a pool factory indexed by the pool registry
+type FactoryConfig struct {
+	Protocol   string `mapstructure:"protocol"`
+	Address    string `mapstructure:"address"`
+	StartBlock uint64 `mapstructure:"start_block"`
+}
+
+// EventsConfig contains event decoding configuration
+type EventsConfig struct {
+	ABIDir    string `mapstructure:"abi_dir"`    // Directory of <protocol>_<contract>.json ABI files; empty uses built-in ABIs only
+	WatchABIs bool   `mapstructure:"watch_abis"` // Reload ABIs when files in ABIDir change
+}
+
+// PricingConfig contains token registry and price oracle configuration
+type PricingConfig struct {
+	MinLiquidity string        `mapstructure:"min_liquidity"` // Wei of ETH a pool needs on its priced side to set a price
+	MaxHops      int           `mapstructure:"max_hops"`
+	Tokens       []TokenConfig `mapstructure:"tokens"` // Registered on top of the built-in Base tokens
+}
+
+// ExecutionConfig contains transaction building and execution configuration
+type ExecutionConfig struct {
+	Mode               string            `mapstructure:"mode"`                 // simulation, hybrid or live
+	MinProfitThreshold string            `mapstructure:"min_profit_threshold"` // Net profit in wei of ETH a hybrid re-simulation must still show
+	MaxGasPrice        string            `mapstructure:"max_gas_price"`        // In wei; empty for no limit
+	Searcher           string            `mapstructure:"searcher"`             // Account that sends our transactions
+	Executor           string            `mapstructure:"executor"`             // Contract that holds inventory and runs swap routes
+	Deadline           time.Duration     `mapstructure:"deadline"`
+	MaxSlippageBps     uint16            `mapstructure:"max_slippage_bps"`
+	BaseGas            uint64            `mapstructure:"base_gas"`
+	GasPerHop          uint64            `mapstructure:"gas_per_hop"`
+	Signer             SignerConfig      `mapstructure:"signer"`
+	Nonces             NonceConfig       `mapstructure:"nonces"`
+	Relays             []RelayConfig     `mapstructure:"relays"`
+	PrivateTxMaxBlocks uint64            `mapstructure:"private_tx_max_blocks"`
+	Inclusion          InclusionConfig   `mapstructure:"inclusion"`
+	Positions          PositionsConfig   `mapstructure:"positions"`
+	Risk               RiskConfig        `mapstructure:"risk"`
+	Progression        ProgressionConfig `mapstructure:"progression"`
+	Funding            FundingConfig     `mapstructure:"funding"`
+	Paper              PaperConfig       `mapstructure:"paper"`
+}
+
+// PaperConfig contains the virtual balances paper trades are filled against and
+// the latency they're modeled to land after
+type PaperConfig struct {
+	Enabled           bool                         `mapstructure:"enabled"`
+	StartingBalances  map[string]string            `mapstructure:"starting_balances"`  // Raw amounts by token address; the zero address is ETH
+	Strategies        map[string]map[string]string `mapstructure:"strategies"`         // Replace the starting balances
+	SubmissionLatency time.Duration                `mapstructure:"submission_latency"` // From paper trading to reaching the sequencer
+	BlockTime         time.Duration                `mapstructure:"block_time"`
+	CurveSize         int                          `mapstructure:"curve_size"`   // PnL points kept per strategy
+	FillHistory       int                          `mapstructure:"fill_history"` // Fills kept per strategy
+	PollInterval      time.Duration                `mapstructure:"poll_interval"`
+}
+
+// FundingConfig contains the flash loan sources that fund executor calls our
+// inventory doesn't cover
+type FundingConfig struct {
+	Enabled        bool                    `mapstructure:"enabled"`
+	MaxPoolsQuoted int                     `mapstructure:"max_pools_quoted"` // Uniswap V3 pools checked per token, lowest fee first
+	Sources        []FlashLoanSourceConfig `mapstructure:"sources"`          // Ties in fee and gas go to the earlier source
+}
+
+// FlashLoanSourceConfig contains one place to borrow from
+type FlashLoanSourceConfig struct {
+	Source      string `mapstructure:"source"` // balancer_v2, aave_v3, uniswap_v3 or morpho_blue
+	Lender      string `mapstructure:"lender"` // Unused for uniswap_v3, whose pools come from the pool registry
+	FeeBps      uint16 `mapstructure:"fee_bps"`
+	GasOverhead uint64 `mapstructure:"gas_overhead"`
+}
+
+// ProgressionConfig contains the requirements strategies must meet to advance from
+// simulation to hybrid to live execution
+type ProgressionConfig struct {
+	AutoProgression    bool                               `mapstructure:"auto_progression"` // Advance and demote strategies automatically
+	MaxMode            string                             `mapstructure:"max_mode"`         // Highest mode auto progression advances to
+	Window             time.Duration                      `mapstructure:"window"`           // Trades older than this aren't measured
+	EvaluationInterval time.Duration                      `mapstructure:"evaluation_interval"`
+	Hybrid             ProgressionThresholdsConfig        `mapstructure:"hybrid"` // Measured over simulated trades
+	Live               ProgressionThresholdsConfig        `mapstructure:"live"`   // Measured over hybrid trades
+	Strategies         map[string]ProgressionStagesConfig `mapstructure:"strategies"`
+}
+
+// ProgressionStagesConfig overrides a strategy's requirements for hybrid and live
+type ProgressionStagesConfig struct {
+	Hybrid ProgressionThresholdsConfig `mapstructure:"hybrid"`
+	Live   ProgressionThresholdsConfig `mapstructure:"live"`
+}
+
+// ProgressionThresholdsConfig contains the requirements for entering a mode. A
+// strategy that stops meeting them is demoted.
+type ProgressionThresholdsConfig struct {
+	MinSamples     int           `mapstructure:"min_samples"`
+	MinSuccessRate float64       `mapstructure:"min_success_rate"` // 0-1
+	MinAccuracy    float64       `mapstructure:"min_accuracy"`     // Prediction accuracy, 0-1
+	MaxDrawdown    string        `mapstructure:"max_drawdown"`     // In wei of ETH
+	MinTimeInMode  time.Duration `mapstructure:"min_time_in_mode"`
+}
+
+// RiskConfig contains the pre-trade risk limits bundles are checked against before
+// submission; they can be changed at runtime through the API
+type RiskConfig struct {
+	Global     RiskLimitsConfig            `mapstructure:"global"`
+	Strategies map[string]RiskLimitsConfig `mapstructure:"strategies"` // Apply on top of the global limits
+}
+
+// RiskLimitsConfig contains one scope's risk limits. Amounts are in wei of ETH;
+// empty amounts and a zero max_in_flight are unlimited.
+type RiskLimitsConfig struct {
+	MaxNotional    string   `mapstructure:"max_notional"`     // Largest input of a single trade
+	MaxDailyVolume string   `mapstructure:"max_daily_volume"` // Notional submitted over the last 24 hours
+	MaxGasPerHour  string   `mapstructure:"max_gas_per_hour"`
+	MaxDailyLoss   string   `mapstructure:"max_daily_loss"` // Net realized loss over the last 24 hours
+	MaxInFlight    int      `mapstructure:"max_in_flight"`  // Bundles submitted but not yet final
+	AllowedTokens  []string `mapstructure:"allowed_tokens"` // If set, bundles may only trade these tokens
+	DeniedTokens   []string `mapstructure:"denied_tokens"`
+}
+
+// PositionsConfig contains searcher and executor inventory tracking configuration
+type PositionsConfig struct {
+	InventoryTokens   []string      `mapstructure:"inventory_tokens"`  // Tokens we mean to hold; the zero address is ETH
+	ResidualDust      string        `mapstructure:"residual_dust"`     // In wei of ETH; smaller residual positions aren't flagged
+	ReconcileInterval time.Duration `mapstructure:"reconcile_interval"`
+}
+
+// InclusionConfig contains submitted bundle monitoring configuration
+type InclusionConfig struct {
+	ExpiryBlocks uint64        `mapstructure:"expiry_blocks"` // Blocks after submission before a bundle that hasn't landed expires
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// RelayConfig describes an endpoint bundles are submitted to
+type RelayConfig struct {
+	Name         string            `mapstructure:"name"`
+	URL          string            `mapstructure:"url"`
+	Method       string            `mapstructure:"method"`  // eth_sendBundle, eth_sendPrivateTransaction or eth_sendRawTransaction
+	Headers      map[string]string `mapstructure:"headers"` // e.g. authorization
+	SignPayload  bool              `mapstructure:"sign_payload"`
+	Timeout      time.Duration     `mapstructure:"timeout"`
+	MaxRetries   int               `mapstructure:"max_retries"`
+	RetryBackoff time.Duration     `mapstructure:"retry_backoff"`
+}
+
+// NonceConfig contains searcher nonce management configuration
+type NonceConfig struct {
+	CancelGasLimit        uint64 `mapstructure:"cancel_gas_limit"`
+	CancelGasPriceBumpPct uint64 `mapstructure:"cancel_gas_price_bump_pct"` // Over the suggested gas price, so cancels replace stuck transactions
+}
+
+// SignerConfig selects the key that signs our transactions. Secrets are read from
+// environment variables rather than the config file.
+type SignerConfig struct {
+	Backend       string            `mapstructure:"backend"`        // keystore, env or remote
+	KeystorePath  string            `mapstructure:"keystore_path"`  // Encrypted geth keystore file
+	PassphraseEnv string            `mapstructure:"passphrase_env"` // Variable holding the keystore passphrase
+	KeyEnv        string            `mapstructure:"key_env"`        // Variable holding a raw hex key, for development
+	RemoteURL     string            `mapstructure:"remote_url"`     // Web3signer-style JSON-RPC signer
+	Headers       map[string]string `mapstructure:"headers"`        // Sent to the remote signer, e.g. authorization
+	Timeout       time.Duration     `mapstructure:"timeout"`
+}
+
+// TokenConfig describes a token registered with the token registry
+type TokenConfig struct {
+	Address       string `mapstructure:"address"`
+	Symbol        string `mapstructure:"symbol"`
+	Decimals      uint8  `mapstructure:"decimals"`
+	FeeOnTransfer bool   `mapstructure:"fee_on_transfer"`
+	Rebasing      bool   `mapstructure:"rebasing"`
+	Stablecoin    bool   `mapstructure:"stablecoin"`
+}
+
 // Load loads configuration from file and environment variables
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -142,10 +440,22 @@ func Load() (*Config, error) {
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	config.StrategySettings = strategySettings(viper.GetStringMap("strategies"))
 
 	return &config, nil
 }
 
+// strategySettings splits the strategies section into each strategy's settings
+func strategySettings(section map[string]interface{}) map[string]map[string]interface{} {
+	settings := make(map[string]map[string]interface{}, len(section))
+	for name, value := range section {
+		if strategy, ok := value.(map[string]interface{}); ok {
+			settings[name] = strategy
+		}
+	}
+	return settings
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	// Server defaults
@@ -193,6 +503,57 @@ func setDefaults() {
 	viper.SetDefault("strategies.time_bandit.max_bundle_size", 5)
 	viper.SetDefault("strategies.time_bandit.min_profit_threshold", "200000000000000000") // 0.2 ETH
 	viper.SetDefault("strategies.time_bandit.max_dependency_depth", 3)
+	viper.SetDefault("strategies.time_bandit.lookback_blocks", 2)
+	viper.SetDefault("strategies.time_bandit.max_orderings", 32)
+	viper.SetDefault("strategies.time_bandit.history_capacity", 128)
+
+	viper.SetDefault("strategies.liquidation.enabled", false)
+	viper.SetDefault("strategies.liquidation.min_profit_usd", 50.0)
+	viper.SetDefault("strategies.liquidation.max_slippage_bps", 50)
+	viper.SetDefault("strategies.liquidation.flash_loan_pool", "0xA238Dd80C259a72e81d7e4664a9801593F98d1c5") // Aave V3 Pool on Base
+	viper.SetDefault("strategies.liquidation.flash_loan_fee_bps", 5)
+	viper.SetDefault("strategies.liquidation.gas_limit", 800000)
+
+	viper.SetDefault("strategies.jit.enabled", false)
+	viper.SetDefault("strategies.jit.min_swap_amount", "20000000000000000000") // 20 ETH
+	viper.SetDefault("strategies.jit.max_capital", "100000000000000000000") // 100 ETH
+	viper.SetDefault("strategies.jit.tick_range_width", 1)
+	viper.SetDefault("strategies.jit.inventory_risk_bps", 10)
+	viper.SetDefault("strategies.jit.gas_limit", 450000)
+	viper.SetDefault("strategies.jit.min_profit_threshold", "5000000000000000") // 0.005 ETH
+
+	viper.SetDefault("strategies.oracle_backrun.enabled", false)
+	viper.SetDefault("strategies.oracle_backrun.pyth_contract", "0x8250f4aF4B972684F7b336503E2D6dFeDeB1487a") // Pyth on Base
+	viper.SetDefault("strategies.oracle_backrun.min_price_gap_bps", 10)
+	viper.SetDefault("strategies.oracle_backrun.max_trade_size", "50000000000000000000") // 50 ETH
+	viper.SetDefault("strategies.oracle_backrun.min_profit_threshold", "2000000000000000") // 0.002 ETH
+	viper.SetDefault("strategies.oracle_backrun.gas_limit", 250000)
+
+	viper.SetDefault("strategies.sniping.enabled", false)
+	viper.SetDefault("strategies.sniping.min_liquidity", "2000000000000000000") // 2 ETH
+	viper.SetDefault("strategies.sniping.max_buy_amount", "500000000000000000") // 0.5 ETH
+	viper.SetDefault("strategies.sniping.expected_buy_volume", "3000000000000000000") // 3 ETH
+	viper.SetDefault("strategies.sniping.max_tax_bps", 500)
+	viper.SetDefault("strategies.sniping.gas_limit", 300000)
+	viper.SetDefault("strategies.sniping.min_profit_threshold", "20000000000000000") // 0.02 ETH
+
+	viper.SetDefault("strategies.cross_layer.enabled", false)
+	viper.SetDefault("strategies.cross_layer.min_price_gap", "1000000000000000") // 0.001 ETH per 1e18 raw token units
+	viper.SetDefault("strategies.cross_layer.min_amount", "1000000000000000000") // 1 ETH
+	viper.SetDefault("strategies.cross_layer.max_amount", "100000000000000000000") // 100 ETH
+	viper.SetDefault("strategies.cross_layer.bridge_fee", "0")
+	viper.SetDefault("strategies.cross_layer.min_profit_threshold", "10000000000000000") // 0.01 ETH
+	viper.SetDefault("strategies.cross_layer.deposit_delay", "3m")
+	viper.SetDefault("strategies.cross_layer.proving_delay", "1h")
+	viper.SetDefault("strategies.cross_layer.challenge_window", "168h")
+	viper.SetDefault("strategies.cross_layer.capital_cost_bps", 500)
+	viper.SetDefault("strategies.cross_layer.settlement_gas_cost", "14000000000000000") // ~700k L1 gas at 20 gwei
+
+	viper.SetDefault("strategies.token_safety.probe_amount", "10000000000000000") // 0.01 ETH
+	viper.SetDefault("strategies.token_safety.max_tx_multiplier", 100)
+	viper.SetDefault("strategies.token_safety.max_tax_bps", 1000)
+	viper.SetDefault("strategies.token_safety.gas_limit", 500000)
+	viper.SetDefault("strategies.token_safety.cache_ttl", "10m")
 
 	// Queue defaults
 	viper.SetDefault("queue.max_size", 10000)
@@ -212,4 +573,73 @@ func setDefaults() {
 	viper.SetDefault("database.redis_url", "redis://localhost:6379")
 	viper.SetDefault("database.max_open_conns", 25)
 	viper.SetDefault("database.max_idle_conns", 5)
+
+	// Pool registry defaults
+	viper.SetDefault("pools.min_tvl", "10000000000000000000") // 10 ETH
+	viper.SetDefault("pools.batch_size", 10000)
+	viper.SetDefault("pools.sync_interval", "30s")
+
+	// Event decoding defaults
+	viper.SetDefault("events.abi_dir", "")
+	viper.SetDefault("events.watch_abis", true)
+
+	// Pricing defaults
+	viper.SetDefault("pricing.min_liquidity", "1000000000000000000") // 1 ETH
+	viper.SetDefault("pricing.max_hops", 2)
+
+	// Execution defaults
+	viper.SetDefault("execution.mode", "simulation")
+	viper.SetDefault("execution.min_profit_threshold", "0")
+	viper.SetDefault("execution.deadline", "30s")
+	viper.SetDefault("execution.max_slippage_bps", 50)
+	viper.SetDefault("execution.base_gas", 80000)
+	viper.SetDefault("execution.gas_per_hop", 130000)
+	viper.SetDefault("execution.signer.backend", "keystore")
+	viper.SetDefault("execution.signer.passphrase_env", "MEV_SIGNER_PASSPHRASE")
+	viper.SetDefault("execution.signer.key_env", "MEV_SIGNER_KEY")
+	viper.SetDefault("execution.signer.timeout", "5s")
+	viper.SetDefault("execution.nonces.cancel_gas_limit", 21000)
+	viper.SetDefault("execution.nonces.cancel_gas_price_bump_pct", 20)
+	viper.SetDefault("execution.private_tx_max_blocks", 25)
+	viper.SetDefault("execution.inclusion.expiry_blocks", 25)
+	viper.SetDefault("execution.inclusion.poll_interval", "2s")
+	viper.SetDefault("execution.positions.inventory_tokens", []string{
+		"0x0000000000000000000000000000000000000000",
+		"0x4200000000000000000000000000000000000006",
+		"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913",
+	})
+	viper.SetDefault("execution.positions.residual_dust", "100000000000000") // 0.0001 ETH
+	viper.SetDefault("execution.positions.reconcile_interval", "1m")
+	viper.SetDefault("execution.risk.global.max_notional", "10000000000000000000")      // 10 ETH
+	viper.SetDefault("execution.risk.global.max_daily_volume", "500000000000000000000") // 500 ETH
+	viper.SetDefault("execution.risk.global.max_gas_per_hour", "50000000000000000")     // 0.05 ETH
+	viper.SetDefault("execution.risk.global.max_daily_loss", "1000000000000000000")     // 1 ETH
+	viper.SetDefault("execution.risk.global.max_in_flight", 8)
+	viper.SetDefault("execution.progression.auto_progression", false)
+	viper.SetDefault("execution.progression.max_mode", "live")
+	viper.SetDefault("execution.progression.window", "168h")
+	viper.SetDefault("execution.progression.evaluation_interval", "5m")
+	viper.SetDefault("execution.progression.hybrid.min_samples", 100)
+	viper.SetDefault("execution.progression.hybrid.min_success_rate", 0.9)
+	viper.SetDefault("execution.progression.hybrid.min_accuracy", 0.8)
+	viper.SetDefault("execution.progression.hybrid.max_drawdown", "50000000000000000") // 0.05 ETH
+	viper.SetDefault("execution.progression.hybrid.min_time_in_mode", "24h")
+	viper.SetDefault("execution.progression.live.min_samples", 200)
+	viper.SetDefault("execution.progression.live.min_success_rate", 0.8)
+	viper.SetDefault("execution.progression.live.min_accuracy", 0.85)
+	viper.SetDefault("execution.progression.live.max_drawdown", "100000000000000000") // 0.1 ETH
+	viper.SetDefault("execution.progression.live.min_time_in_mode", "72h")
+	viper.SetDefault("execution.funding.enabled", false)
+	viper.SetDefault("execution.funding.max_pools_quoted", 3)
+	viper.SetDefault("execution.paper.enabled", true)
+	viper.SetDefault("execution.paper.starting_balances", map[string]string{
+		"0x0000000000000000000000000000000000000000": "1000000000000000000",  // 1 ETH
+		"0x4200000000000000000000000000000000000006": "10000000000000000000", // 10 WETH
+		"0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913": "25000000000",          // 25,000 USDC
+	})
+	viper.SetDefault("execution.paper.submission_latency", "200ms")
+	viper.SetDefault("execution.paper.block_time", "2s")
+	viper.SetDefault("execution.paper.curve_size", 1000)
+	viper.SetDefault("execution.paper.fill_history", 1000)
+	viper.SetDefault("execution.paper.poll_interval", "1s")
 }
\ No newline at end of file
@@ -0,0 +1,101 @@
+package bridge
+
+// L1StandardBridge deposits
+const l1StandardBridgeABI = `[
+	{"inputs":[
+		{"name":"_to","type":"address"},
+		{"name":"_minGasLimit","type":"uint32"},
+		{"name":"_extraData","type":"bytes"}
+	],"name":"depositETHTo","outputs":[],"stateMutability":"payable","type":"function"},
+	{"inputs":[
+		{"name":"_l1Token","type":"address"},
+		{"name":"_l2Token","type":"address"},
+		{"name":"_to","type":"address"},
+		{"name":"_amount","type":"uint256"},
+		{"name":"_minGasLimit","type":"uint32"},
+		{"name":"_extraData","type":"bytes"}
+	],"name":"depositERC20To","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+// L2StandardBridge withdrawals
+const l2StandardBridgeABI = `[
+	{"inputs":[
+		{"name":"_l2Token","type":"address"},
+		{"name":"_to","type":"address"},
+		{"name":"_amount","type":"uint256"},
+		{"name":"_minGasLimit","type":"uint32"},
+		{"name":"_extraData","type":"bytes"}
+	],"name":"withdrawTo","outputs":[],"stateMutability":"payable","type":"function"}
+]`
+
+// L2ToL1MessagePasser records every withdrawal with this event
+const l2ToL1MessagePasserABI = `[
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"nonce","type":"uint256"},
+		{"indexed":true,"name":"sender","type":"address"},
+		{"indexed":true,"name":"target","type":"address"},
+		{"indexed":false,"name":"value","type":"uint256"},
+		{"indexed":false,"name":"gasLimit","type":"uint256"},
+		{"indexed":false,"name":"data","type":"bytes"},
+		{"indexed":false,"name":"withdrawalHash","type":"bytes32"}
+	],"name":"MessagePassed","type":"event"}
+]`
+
+// OptimismPortal with fault proofs
+const optimismPortalABI = `[
+	{"inputs":[
+		{"components":[
+			{"name":"nonce","type":"uint256"},
+			{"name":"sender","type":"address"},
+			{"name":"target","type":"address"},
+			{"name":"value","type":"uint256"},
+			{"name":"gasLimit","type":"uint256"},
+			{"name":"data","type":"bytes"}
+		],"name":"_tx","type":"tuple"},
+		{"name":"_disputeGameIndex","type":"uint256"},
+		{"components":[
+			{"name":"version","type":"bytes32"},
+			{"name":"stateRoot","type":"bytes32"},
+			{"name":"messagePasserStorageRoot","type":"bytes32"},
+			{"name":"latestBlockhash","type":"bytes32"}
+		],"name":"_outputRootProof","type":"tuple"},
+		{"name":"_withdrawalProof","type":"bytes[]"}
+	],"name":"proveWithdrawalTransaction","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[
+		{"components":[
+			{"name":"nonce","type":"uint256"},
+			{"name":"sender","type":"address"},
+			{"name":"target","type":"address"},
+			{"name":"value","type":"uint256"},
+			{"name":"gasLimit","type":"uint256"},
+			{"name":"data","type":"bytes"}
+		],"name":"_tx","type":"tuple"}
+	],"name":"finalizeWithdrawalTransaction","outputs":[],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[
+		{"name":"","type":"bytes32"},
+		{"name":"","type":"address"}
+	],"name":"provenWithdrawals","outputs":[
+		{"name":"disputeGameProxy","type":"address"},
+		{"name":"timestamp","type":"uint64"}
+	],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"","type":"bytes32"}],"name":"finalizedWithdrawals","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"proofMaturityDelaySeconds","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"respectedGameType","outputs":[{"name":"","type":"uint32"}],"stateMutability":"view","type":"function"}
+]`
+
+// DisputeGameFactory game lookups
+const disputeGameFactoryABI = `[
+	{"inputs":[],"name":"gameCount","outputs":[{"name":"gameCount_","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"_index","type":"uint256"}],"name":"gameAtIndex","outputs":[
+		{"name":"gameType_","type":"uint32"},
+		{"name":"timestamp_","type":"uint64"},
+		{"name":"proxy_","type":"address"}
+	],"stateMutability":"view","type":"function"}
+]`
+
+// FaultDisputeGame state read to pick a game to prove against
+const faultDisputeGameABI = `[
+	{"inputs":[],"name":"l2BlockNumber","outputs":[{"name":"l2BlockNumber_","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"rootClaim","outputs":[{"name":"rootClaim_","type":"bytes32"}],"stateMutability":"pure","type":"function"},
+	{"inputs":[],"name":"status","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}
+]`
@@ -0,0 +1,222 @@
+// Package bridge encodes OP Stack standard bridge deposits and withdrawals and
+// follows withdrawals through proving and finalization on L1. Withdrawals are
+// proven against fault dispute games, as on Base since the fault proof upgrade.
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+)
+
+// Base mainnet bridge deployments
+var (
+	BaseL1StandardBridge   = common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35")
+	BaseOptimismPortal     = common.HexToAddress("0x49048044D57e1C92A77f79988d21Fa8fAF74E97e")
+	BaseDisputeGameFactory = common.HexToAddress("0x43edB88C4B80fDD2AdFF2412A7BebF9dF42cB40e")
+
+	// Predeploys, at the same address on every OP Stack chain
+	L2StandardBridge    = common.HexToAddress("0x4200000000000000000000000000000000000010")
+	L2ToL1MessagePasser = common.HexToAddress("0x4200000000000000000000000000000000000016")
+	// LegacyERC20ETH stands in for ETH in L2StandardBridge withdrawals
+	LegacyERC20ETH = common.HexToAddress("0xDeadDeAddeAddEAddeadDEaDDEAdDeaDDeAD0000")
+)
+
+var (
+	l1StandardBridge    = events.MustParseABI(l1StandardBridgeABI)
+	l2StandardBridge    = events.MustParseABI(l2StandardBridgeABI)
+	l2ToL1MessagePasser = events.MustParseABI(l2ToL1MessagePasserABI)
+	optimismPortal      = events.MustParseABI(optimismPortalABI)
+	disputeGameFactory  = events.MustParseABI(disputeGameFactoryABI)
+	faultDisputeGame    = events.MustParseABI(faultDisputeGameABI)
+
+	// withdrawalArguments are hashed into a withdrawal hash, as Hashing.hashWithdrawal does
+	withdrawalArguments = func() abi.Arguments {
+		uint256Type, _ := abi.NewType("uint256", "", nil)
+		addressType, _ := abi.NewType("address", "", nil)
+		bytesType, _ := abi.NewType("bytes", "", nil)
+		return abi.Arguments{
+			{Type: uint256Type}, {Type: addressType}, {Type: addressType},
+			{Type: uint256Type}, {Type: uint256Type}, {Type: bytesType},
+		}
+	}()
+)
+
+// DefaultBaseConfig returns the Base mainnet bridge contracts and the standard
+// bridge token pairs. The account must be set before building transactions.
+func DefaultBaseConfig() *interfaces.BridgeConfig {
+	return &interfaces.BridgeConfig{
+		L1StandardBridge:   BaseL1StandardBridge,
+		L2StandardBridge:   L2StandardBridge,
+		OptimismPortal:     BaseOptimismPortal,
+		DisputeGameFactory: BaseDisputeGameFactory,
+		L1Tokens:           pricing.BaseBridgedTokens(),
+		MinGasLimit:        200000,
+		MaxGameSearch:      100,
+		L1GasLimit:         500000,
+		L2GasLimit:         200000,
+	}
+}
+
+// EncodeDeposit encodes an L1StandardBridge deposit of an L2 token's L1 counterpart
+// to an L2 recipient. It returns the calldata and the ETH to send with it; the L2
+// WETH is deposited as ETH.
+func EncodeDeposit(config *interfaces.BridgeConfig, l2Token, to common.Address, amount *big.Int) ([]byte, *big.Int, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("deposit amount must be positive")
+	}
+
+	if l2Token == pricing.BaseWETH {
+		data, err := l1StandardBridge.Pack("depositETHTo", to, config.MinGasLimit, []byte{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode ETH deposit: %w", err)
+		}
+		return data, new(big.Int).Set(amount), nil
+	}
+
+	l1Token, exists := config.L1Tokens[l2Token]
+	if !exists {
+		return nil, nil, fmt.Errorf("token %s has no L1 counterpart", l2Token.Hex())
+	}
+	data, err := l1StandardBridge.Pack("depositERC20To", l1Token, l2Token, to, amount, config.MinGasLimit, []byte{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode ERC-20 deposit: %w", err)
+	}
+	return data, big.NewInt(0), nil
+}
+
+// EncodeWithdrawal encodes an L2StandardBridge withdrawal to an L1 recipient. It
+// returns the calldata and the ETH to send with it; the L2 WETH is withdrawn as ETH,
+// which must be unwrapped first.
+func EncodeWithdrawal(config *interfaces.BridgeConfig, l2Token, to common.Address, amount *big.Int) ([]byte, *big.Int, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, nil, fmt.Errorf("withdrawal amount must be positive")
+	}
+
+	token, value := l2Token, big.NewInt(0)
+	if l2Token == pricing.BaseWETH {
+		token, value = LegacyERC20ETH, new(big.Int).Set(amount)
+	} else if _, exists := config.L1Tokens[l2Token]; !exists {
+		return nil, nil, fmt.Errorf("token %s has no L1 counterpart", l2Token.Hex())
+	}
+
+	data, err := l2StandardBridge.Pack("withdrawTo", token, to, amount, config.MinGasLimit, []byte{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode withdrawal: %w", err)
+	}
+	return data, value, nil
+}
+
+// withdrawalTuple and outputRootProofTuple mirror the portal's Types structs for ABI encoding
+type withdrawalTuple struct {
+	Nonce    *big.Int
+	Sender   common.Address
+	Target   common.Address
+	Value    *big.Int
+	GasLimit *big.Int
+	Data     []byte
+}
+
+type outputRootProofTuple struct {
+	Version                  [32]byte
+	StateRoot                [32]byte
+	MessagePasserStorageRoot [32]byte
+	LatestBlockhash          [32]byte
+}
+
+func toWithdrawalTuple(tx *interfaces.WithdrawalTransaction) withdrawalTuple {
+	return withdrawalTuple{
+		Nonce:    tx.Nonce,
+		Sender:   tx.Sender,
+		Target:   tx.Target,
+		Value:    tx.Value,
+		GasLimit: tx.GasLimit,
+		Data:     tx.Data,
+	}
+}
+
+// EncodeProveWithdrawal encodes OptimismPortal.proveWithdrawalTransaction
+func EncodeProveWithdrawal(tx *interfaces.WithdrawalTransaction, gameIndex *big.Int, proof *interfaces.OutputRootProof, withdrawalProof [][]byte) ([]byte, error) {
+	outputRootProof := outputRootProofTuple{
+		Version:                  proof.Version,
+		StateRoot:                proof.StateRoot,
+		MessagePasserStorageRoot: proof.MessagePasserStorageRoot,
+		LatestBlockhash:          proof.LatestBlockhash,
+	}
+	data, err := optimismPortal.Pack("proveWithdrawalTransaction", toWithdrawalTuple(tx), gameIndex, outputRootProof, withdrawalProof)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdrawal proof: %w", err)
+	}
+	return data, nil
+}
+
+// EncodeFinalizeWithdrawal encodes OptimismPortal.finalizeWithdrawalTransaction
+func EncodeFinalizeWithdrawal(tx *interfaces.WithdrawalTransaction) ([]byte, error) {
+	data, err := optimismPortal.Pack("finalizeWithdrawalTransaction", toWithdrawalTuple(tx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode withdrawal finalization: %w", err)
+	}
+	return data, nil
+}
+
+// HashWithdrawal returns the hash the portal keys a withdrawal by
+func HashWithdrawal(tx *interfaces.WithdrawalTransaction) (common.Hash, error) {
+	encoded, err := withdrawalArguments.Pack(tx.Nonce, tx.Sender, tx.Target, tx.Value, tx.GasLimit, tx.Data)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode withdrawal: %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// HashOutputRoot returns the output root committed to by an output root proof
+func HashOutputRoot(proof *interfaces.OutputRootProof) common.Hash {
+	return crypto.Keccak256Hash(
+		proof.Version.Bytes(),
+		proof.StateRoot.Bytes(),
+		proof.MessagePasserStorageRoot.Bytes(),
+		proof.LatestBlockhash.Bytes(),
+	)
+}
+
+// DecodeMessagePassed decodes an L2ToL1MessagePasser MessagePassed log into the
+// withdrawal it records and the withdrawal hash
+func DecodeMessagePassed(log *ethtypes.Log) (*interfaces.WithdrawalTransaction, common.Hash, bool) {
+	event := l2ToL1MessagePasser.Events["MessagePassed"]
+	if log.Address != L2ToL1MessagePasser || len(log.Topics) != 4 || log.Topics[0] != event.ID {
+		return nil, common.Hash{}, false
+	}
+
+	values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil || len(values) != 4 {
+		return nil, common.Hash{}, false
+	}
+	value, _ := values[0].(*big.Int)
+	gasLimit, _ := values[1].(*big.Int)
+	data, _ := values[2].([]byte)
+	hash, _ := values[3].([32]byte)
+	if value == nil || gasLimit == nil {
+		return nil, common.Hash{}, false
+	}
+
+	return &interfaces.WithdrawalTransaction{
+		Nonce:    new(big.Int).SetBytes(log.Topics[1].Bytes()),
+		Sender:   common.BytesToAddress(log.Topics[2].Bytes()),
+		Target:   common.BytesToAddress(log.Topics[3].Bytes()),
+		Value:    value,
+		GasLimit: gasLimit,
+		Data:     data,
+	}, common.Hash(hash), true
+}
+
+// withdrawalStorageSlot is the L2ToL1MessagePasser sentMessages slot of a
+// withdrawal; sentMessages is the contract's first storage variable
+func withdrawalStorageSlot(withdrawalHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash(withdrawalHash.Bytes(), common.Hash{}.Bytes())
+}
@@ -0,0 +1,158 @@
+package bridge
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var recipient = common.HexToAddress("0x5ec0000000000000000000000000000000000001")
+
+func TestEncodeDeposit(t *testing.T) {
+	config := DefaultBaseConfig()
+
+	tests := []struct {
+		name          string
+		token         common.Address
+		method        string
+		expectedValue *big.Int
+		expectedArgs  []interface{}
+	}{
+		{
+			name:          "WETH deposits ETH",
+			token:         pricing.BaseWETH,
+			method:        "depositETHTo",
+			expectedValue: big.NewInt(1e18),
+			expectedArgs:  []interface{}{recipient, uint32(200000), []byte{}},
+		},
+		{
+			name:          "ERC-20 deposits the L1 token",
+			token:         pricing.BaseUSDbC,
+			method:        "depositERC20To",
+			expectedValue: big.NewInt(0),
+			expectedArgs:  []interface{}{pricing.EthereumUSDC, pricing.BaseUSDbC, recipient, big.NewInt(1e18), uint32(200000), []byte{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, value, err := EncodeDeposit(config, tt.token, recipient, big.NewInt(1e18))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedValue, value)
+
+			method := l1StandardBridge.Methods[tt.method]
+			assert.Equal(t, method.ID, data[:4])
+			args, err := method.Inputs.Unpack(data[4:])
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedArgs, args)
+		})
+	}
+
+	_, _, err := EncodeDeposit(config, common.HexToAddress("0x70c3"), recipient, big.NewInt(1))
+	assert.Error(t, err, "tokens without an L1 counterpart can't be deposited")
+	_, _, err = EncodeDeposit(config, pricing.BaseWETH, recipient, big.NewInt(0))
+	assert.Error(t, err)
+}
+
+func TestEncodeWithdrawal(t *testing.T) {
+	config := DefaultBaseConfig()
+	method := l2StandardBridge.Methods["withdrawTo"]
+
+	data, value, err := EncodeWithdrawal(config, pricing.BaseWETH, recipient, big.NewInt(5e17))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5e17), value)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, LegacyERC20ETH, args[0], "ETH is withdrawn as the legacy ETH token")
+	assert.Equal(t, recipient, args[1])
+
+	data, value, err = EncodeWithdrawal(config, pricing.BaseCbETH, recipient, big.NewInt(5e17))
+	require.NoError(t, err)
+	assert.Zero(t, value.Sign())
+	args, err = method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, pricing.BaseCbETH, args[0])
+
+	_, _, err = EncodeWithdrawal(config, common.HexToAddress("0x70c3"), recipient, big.NewInt(1))
+	assert.Error(t, err)
+}
+
+// testWithdrawal is a withdrawal relayed between the cross-domain messengers
+func testWithdrawal() *interfaces.WithdrawalTransaction {
+	nonce := new(big.Int).Lsh(big.NewInt(1), 240) // Message version 1
+	nonce.Add(nonce, big.NewInt(77))
+	return &interfaces.WithdrawalTransaction{
+		Nonce:    nonce,
+		Sender:   common.HexToAddress("0x4200000000000000000000000000000000000007"),
+		Target:   common.HexToAddress("0x866E82a600A1414e583f7F13623F1aC5d58b0Afa"),
+		Value:    big.NewInt(1e18),
+		GasLimit: big.NewInt(287000),
+		Data:     []byte{0xd7, 0x64, 0xad, 0x0b},
+	}
+}
+
+// messagePassedLog is the log the L2ToL1MessagePasser emits for a withdrawal
+func messagePassedLog(t *testing.T, tx *interfaces.WithdrawalTransaction) *ethtypes.Log {
+	t.Helper()
+
+	hash, err := HashWithdrawal(tx)
+	require.NoError(t, err)
+	event := l2ToL1MessagePasser.Events["MessagePassed"]
+	data, err := event.Inputs.NonIndexed().Pack(tx.Value, tx.GasLimit, tx.Data, [32]byte(hash))
+	require.NoError(t, err)
+
+	return &ethtypes.Log{
+		Address: L2ToL1MessagePasser,
+		Topics: []common.Hash{
+			event.ID,
+			common.BigToHash(tx.Nonce),
+			common.BytesToHash(tx.Sender.Bytes()),
+			common.BytesToHash(tx.Target.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func TestDecodeMessagePassed(t *testing.T) {
+	withdrawal := testWithdrawal()
+	log := messagePassedLog(t, withdrawal)
+
+	decoded, hash, ok := DecodeMessagePassed(log)
+	require.True(t, ok)
+	assert.Equal(t, withdrawal, decoded)
+	expected, err := HashWithdrawal(withdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, expected, hash)
+
+	log.Address = common.HexToAddress("0x1")
+	_, _, ok = DecodeMessagePassed(log)
+	assert.False(t, ok, "only the message passer records withdrawals")
+}
+
+func TestEncodeProveAndFinalize(t *testing.T) {
+	withdrawal := testWithdrawal()
+	proof := &interfaces.OutputRootProof{
+		StateRoot:                common.HexToHash("0x5a"),
+		MessagePasserStorageRoot: common.HexToHash("0x5b"),
+		LatestBlockhash:          common.HexToHash("0x5c"),
+	}
+
+	data, err := EncodeProveWithdrawal(withdrawal, big.NewInt(12), proof, [][]byte{{0x01}, {0x02, 0x03}})
+	require.NoError(t, err)
+	method := optimismPortal.Methods["proveWithdrawalTransaction"]
+	assert.Equal(t, method.ID, data[:4])
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(12), args[1])
+	assert.Equal(t, [][]byte{{0x01}, {0x02, 0x03}}, args[3])
+
+	data, err = EncodeFinalizeWithdrawal(withdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, optimismPortal.Methods["finalizeWithdrawalTransaction"].ID, data[:4])
+}
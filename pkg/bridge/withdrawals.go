@@ -0,0 +1,353 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// Fault dispute game statuses
+const (
+	gameInProgress     uint8 = 0
+	gameChallengerWins uint8 = 1
+	gameDefenderWins   uint8 = 2
+)
+
+// L1Client is the subset of an L1 client needed to read the portal and dispute games
+type L1Client interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// L2Client is the subset of an L2 RPC client needed to build proofs. Receipts,
+// blocks and proofs are decoded from the raw JSON, so OP Stack fields and
+// transaction types don't get in the way.
+type L2Client interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// rpcBlockHeader is the part of an L2 block an output root commits to
+type rpcBlockHeader struct {
+	Hash      common.Hash `json:"hash"`
+	StateRoot common.Hash `json:"stateRoot"`
+}
+
+// rpcAccountProof is an eth_getProof result
+type rpcAccountProof struct {
+	StorageHash  common.Hash `json:"storageHash"`
+	StorageProof []struct {
+		Key   hexutil.Bytes   `json:"key"`
+		Value *hexutil.Big    `json:"value"`
+		Proof []hexutil.Bytes `json:"proof"`
+	} `json:"storageProof"`
+}
+
+// disputeGame is a game from the factory that can back a proof
+type disputeGame struct {
+	index         *big.Int
+	proxy         common.Address
+	l2BlockNumber uint64
+}
+
+// withdrawalProver implements the WithdrawalProver interface
+type withdrawalProver struct {
+	config *interfaces.BridgeConfig
+	l1     L1Client
+	l2     L2Client
+	now    func() time.Time
+}
+
+// NewWithdrawalProver creates a prover for withdrawals made through the
+// L2ToL1MessagePasser, proven and finalized by the configured account
+func NewWithdrawalProver(config *interfaces.BridgeConfig, l1 L1Client, l2 L2Client) (interfaces.WithdrawalProver, error) {
+	if config == nil {
+		config = DefaultBaseConfig()
+	}
+	if config.MaxGameSearch <= 0 {
+		config.MaxGameSearch = 100
+	}
+	if l1 == nil || l2 == nil {
+		return nil, fmt.Errorf("L1 and L2 clients are required")
+	}
+
+	return &withdrawalProver{
+		config: config,
+		l1:     l1,
+		l2:     l2,
+		now:    time.Now,
+	}, nil
+}
+
+// GetWithdrawal reads the withdrawal initiated by an L2 transaction and its stage
+func (p *withdrawalProver) GetWithdrawal(ctx context.Context, l2TxHash common.Hash) (*interfaces.Withdrawal, error) {
+	var receipt *ethtypes.Receipt
+	if err := p.l2.CallContext(ctx, &receipt, "eth_getTransactionReceipt", l2TxHash); err != nil {
+		return nil, fmt.Errorf("failed to get receipt: %w", err)
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("transaction %s not found", l2TxHash.Hex())
+	}
+
+	withdrawal := &interfaces.Withdrawal{
+		L2TxHash:      l2TxHash,
+		L2BlockNumber: receipt.BlockNumber.Uint64(),
+	}
+	for _, log := range receipt.Logs {
+		if tx, hash, ok := DecodeMessagePassed(log); ok {
+			withdrawal.Transaction, withdrawal.Hash = tx, hash
+			break
+		}
+	}
+	if withdrawal.Transaction == nil {
+		return nil, fmt.Errorf("transaction %s initiated no withdrawal", l2TxHash.Hex())
+	}
+	if hash, err := HashWithdrawal(withdrawal.Transaction); err != nil || hash != withdrawal.Hash {
+		return nil, fmt.Errorf("withdrawal hash in transaction %s does not match its message", l2TxHash.Hex())
+	}
+
+	if err := p.refreshStatus(ctx, withdrawal); err != nil {
+		return nil, err
+	}
+	return withdrawal, nil
+}
+
+// refreshStatus sets a withdrawal's stage from the portal and dispute games
+func (p *withdrawalProver) refreshStatus(ctx context.Context, withdrawal *interfaces.Withdrawal) error {
+	out, err := p.call(ctx, optimismPortal, p.config.OptimismPortal, "finalizedWithdrawals", withdrawal.Hash)
+	if err != nil {
+		return err
+	}
+	if finalized, _ := out[0].(bool); finalized {
+		withdrawal.Status = interfaces.WithdrawalFinalized
+		return nil
+	}
+
+	out, err = p.call(ctx, optimismPortal, p.config.OptimismPortal, "provenWithdrawals", withdrawal.Hash, p.config.Account)
+	if err != nil {
+		return err
+	}
+	game, _ := out[0].(common.Address)
+	provenAt, _ := out[1].(uint64)
+
+	if provenAt > 0 {
+		status, err := p.gameStatus(ctx, game)
+		if err != nil {
+			return err
+		}
+		// A proof against a game the challenger won has to be made again
+		if status != gameChallengerWins {
+			out, err := p.call(ctx, optimismPortal, p.config.OptimismPortal, "proofMaturityDelaySeconds")
+			if err != nil {
+				return err
+			}
+			maturity, _ := out[0].(*big.Int)
+
+			withdrawal.ProvenAt = time.Unix(int64(provenAt), 0)
+			withdrawal.FinalizableAt = withdrawal.ProvenAt.Add(time.Duration(maturity.Int64()) * time.Second)
+			withdrawal.Status = interfaces.WithdrawalProven
+			if status == gameDefenderWins && !p.now().Before(withdrawal.FinalizableAt) {
+				withdrawal.Status = interfaces.WithdrawalReadyToFinalize
+			}
+			return nil
+		}
+	}
+
+	withdrawal.ProvenAt, withdrawal.FinalizableAt = time.Time{}, time.Time{}
+	if _, found, err := p.findGame(ctx, withdrawal.L2BlockNumber); err != nil {
+		return err
+	} else if found {
+		withdrawal.Status = interfaces.WithdrawalReadyToProve
+	} else {
+		withdrawal.Status = interfaces.WithdrawalWaitingToProve
+	}
+	return nil
+}
+
+// BuildProveTransaction builds the OptimismPortal proof against the latest dispute
+// game covering the withdrawal's block
+func (p *withdrawalProver) BuildProveTransaction(ctx context.Context, withdrawal *interfaces.Withdrawal) (*types.Transaction, error) {
+	if withdrawal == nil || withdrawal.Transaction == nil {
+		return nil, fmt.Errorf("withdrawal is required")
+	}
+
+	game, found, err := p.findGame(ctx, withdrawal.L2BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no dispute game covers L2 block %d yet", withdrawal.L2BlockNumber)
+	}
+
+	tag := hexutil.EncodeUint64(game.l2BlockNumber)
+	var header *rpcBlockHeader
+	if err := p.l2.CallContext(ctx, &header, "eth_getBlockByNumber", tag, false); err != nil {
+		return nil, fmt.Errorf("failed to get L2 block %d: %w", game.l2BlockNumber, err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("L2 block %d not found", game.l2BlockNumber)
+	}
+
+	slot := withdrawalStorageSlot(withdrawal.Hash)
+	var proof rpcAccountProof
+	if err := p.l2.CallContext(ctx, &proof, "eth_getProof", L2ToL1MessagePasser, []string{slot.Hex()}, tag); err != nil {
+		return nil, fmt.Errorf("failed to get message passer proof: %w", err)
+	}
+	if len(proof.StorageProof) != 1 || proof.StorageProof[0].Value == nil || proof.StorageProof[0].Value.ToInt().Sign() == 0 {
+		return nil, fmt.Errorf("withdrawal %s is not in the message passer at L2 block %d", withdrawal.Hash.Hex(), game.l2BlockNumber)
+	}
+
+	outputRootProof := &interfaces.OutputRootProof{
+		StateRoot:                header.StateRoot,
+		MessagePasserStorageRoot: proof.StorageHash,
+		LatestBlockhash:          header.Hash,
+	}
+	out, err := p.call(ctx, faultDisputeGame, game.proxy, "rootClaim")
+	if err != nil {
+		return nil, err
+	}
+	if rootClaim, _ := out[0].([32]byte); common.Hash(rootClaim) != HashOutputRoot(outputRootProof) {
+		return nil, fmt.Errorf("output root of L2 block %d does not match dispute game %s", game.l2BlockNumber, game.proxy.Hex())
+	}
+
+	withdrawalProof := make([][]byte, len(proof.StorageProof[0].Proof))
+	for i, node := range proof.StorageProof[0].Proof {
+		withdrawalProof[i] = node
+	}
+
+	data, err := EncodeProveWithdrawal(withdrawal.Transaction, game.index, outputRootProof, withdrawalProof)
+	if err != nil {
+		return nil, err
+	}
+	return p.l1Transaction(ctx, data)
+}
+
+// BuildFinalizeTransaction builds the OptimismPortal finalization once the proof
+// has matured through the challenge window
+func (p *withdrawalProver) BuildFinalizeTransaction(ctx context.Context, withdrawal *interfaces.Withdrawal) (*types.Transaction, error) {
+	if withdrawal == nil || withdrawal.Transaction == nil {
+		return nil, fmt.Errorf("withdrawal is required")
+	}
+	if err := p.refreshStatus(ctx, withdrawal); err != nil {
+		return nil, err
+	}
+	if withdrawal.Status != interfaces.WithdrawalReadyToFinalize {
+		return nil, fmt.Errorf("withdrawal %s cannot be finalized while %s", withdrawal.Hash.Hex(), withdrawal.Status)
+	}
+
+	data, err := EncodeFinalizeWithdrawal(withdrawal.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return p.l1Transaction(ctx, data)
+}
+
+// findGame returns the latest game of the portal's respected type that covers an
+// L2 block and has not been lost by its proposer
+func (p *withdrawalProver) findGame(ctx context.Context, l2BlockNumber uint64) (*disputeGame, bool, error) {
+	out, err := p.call(ctx, optimismPortal, p.config.OptimismPortal, "respectedGameType")
+	if err != nil {
+		return nil, false, err
+	}
+	respected, _ := out[0].(uint32)
+
+	out, err = p.call(ctx, disputeGameFactory, p.config.DisputeGameFactory, "gameCount")
+	if err != nil {
+		return nil, false, err
+	}
+	count, _ := out[0].(*big.Int)
+
+	index := new(big.Int).Set(count)
+	for scanned := 0; scanned < p.config.MaxGameSearch && index.Sign() > 0; scanned++ {
+		index.Sub(index, big.NewInt(1))
+
+		out, err := p.call(ctx, disputeGameFactory, p.config.DisputeGameFactory, "gameAtIndex", index)
+		if err != nil {
+			return nil, false, err
+		}
+		gameType, _ := out[0].(uint32)
+		proxy, _ := out[2].(common.Address)
+		if gameType != respected {
+			continue
+		}
+
+		out, err = p.call(ctx, faultDisputeGame, proxy, "l2BlockNumber")
+		if err != nil {
+			return nil, false, err
+		}
+		gameBlock, _ := out[0].(*big.Int)
+		if gameBlock.Uint64() < l2BlockNumber {
+			break // Earlier games only cover earlier blocks
+		}
+
+		status, err := p.gameStatus(ctx, proxy)
+		if err != nil {
+			return nil, false, err
+		}
+		if status == gameChallengerWins {
+			continue
+		}
+
+		return &disputeGame{index: new(big.Int).Set(index), proxy: proxy, l2BlockNumber: gameBlock.Uint64()}, true, nil
+	}
+	return nil, false, nil
+}
+
+// gameStatus reads a dispute game's status
+func (p *withdrawalProver) gameStatus(ctx context.Context, game common.Address) (uint8, error) {
+	out, err := p.call(ctx, faultDisputeGame, game, "status")
+	if err != nil {
+		return gameInProgress, err
+	}
+	status, _ := out[0].(uint8)
+	return status, nil
+}
+
+// l1Transaction wraps portal calldata in a transaction from the configured account
+func (p *withdrawalProver) l1Transaction(ctx context.Context, data []byte) (*types.Transaction, error) {
+	gasPrice, err := p.l1.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 gas price: %w", err)
+	}
+
+	portal := p.config.OptimismPortal
+	return &types.Transaction{
+		From:     p.config.Account,
+		To:       &portal,
+		Value:    big.NewInt(0),
+		GasPrice: gasPrice,
+		GasLimit: p.config.L1GasLimit,
+		Nonce:    0, // Will be set by transaction manager
+		Data:     data,
+	}, nil
+}
+
+// call invokes a view method on an L1 contract and unpacks its outputs
+func (p *withdrawalProver) call(ctx context.Context, contractABI *abi.ABI, address common.Address, method string, args ...interface{}) ([]interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	result, err := p.l1.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on %s: %w", method, address.Hex(), err)
+	}
+
+	out, err := contractABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s from %s: %w", method, address.Hex(), err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty %s result from %s", method, address.Hex())
+	}
+	return out, nil
+}
@@ -0,0 +1,332 @@
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var prover = common.HexToAddress("0x9000000000000000000000000000000000000001")
+
+// fakeGame is a dispute game the fake L1 serves
+type fakeGame struct {
+	gameType  uint32
+	proxy     common.Address
+	l2Block   uint64
+	status    uint8
+	rootClaim common.Hash
+}
+
+// fakeL1 answers portal, factory and game calls from in-memory state
+type fakeL1 struct {
+	games     []*fakeGame
+	provenAt  uint64
+	provenBy  common.Address // Game the proof was made against
+	finalized bool
+	maturity  int64
+}
+
+func (l *fakeL1) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	for _, contract := range []*abi.ABI{optimismPortal, disputeGameFactory, faultDisputeGame} {
+		method, err := contract.MethodById(call.Data[:4])
+		if err != nil {
+			continue
+		}
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		return l.answer(method, *call.To, args)
+	}
+	return nil, fmt.Errorf("unknown selector %x", call.Data[:4])
+}
+
+func (l *fakeL1) answer(method *abi.Method, to common.Address, args []interface{}) ([]byte, error) {
+	switch method.Name {
+	case "respectedGameType":
+		return method.Outputs.Pack(uint32(0))
+	case "finalizedWithdrawals":
+		return method.Outputs.Pack(l.finalized)
+	case "provenWithdrawals":
+		if args[1].(common.Address) != prover {
+			return method.Outputs.Pack(common.Address{}, uint64(0))
+		}
+		return method.Outputs.Pack(l.provenBy, l.provenAt)
+	case "proofMaturityDelaySeconds":
+		return method.Outputs.Pack(big.NewInt(l.maturity))
+	case "gameCount":
+		return method.Outputs.Pack(big.NewInt(int64(len(l.games))))
+	case "gameAtIndex":
+		game := l.games[args[0].(*big.Int).Int64()]
+		return method.Outputs.Pack(game.gameType, uint64(0), game.proxy)
+	}
+
+	for _, game := range l.games {
+		if game.proxy != to {
+			continue
+		}
+		switch method.Name {
+		case "l2BlockNumber":
+			return method.Outputs.Pack(new(big.Int).SetUint64(game.l2Block))
+		case "status":
+			return method.Outputs.Pack(game.status)
+		case "rootClaim":
+			return method.Outputs.Pack([32]byte(game.rootClaim))
+		}
+	}
+	return nil, fmt.Errorf("unexpected call %s on %s", method.Name, to.Hex())
+}
+
+func (l *fakeL1) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(20e9), nil
+}
+
+// fakeL2 answers JSON-RPC calls with canned results, as a node would
+type fakeL2 struct {
+	responses map[string]interface{}
+	calls     [][]interface{}
+}
+
+func (l *fakeL2) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	l.calls = append(l.calls, append([]interface{}{method}, args...))
+	response, exists := l.responses[method]
+	if !exists {
+		return fmt.Errorf("method %s not supported", method)
+	}
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, result)
+}
+
+// withdrawalChains builds an L1 and L2 pair for a withdrawal made in L2 block 1000,
+// with dispute games up to L2 block 1200
+func withdrawalChains(t *testing.T) (*fakeL1, *fakeL2, *interfaces.WithdrawalTransaction) {
+	t.Helper()
+
+	tx := testWithdrawal()
+	receipt := &ethtypes.Receipt{
+		Status:      ethtypes.ReceiptStatusSuccessful,
+		GasUsed:     120000,
+		Logs:        []*ethtypes.Log{messagePassedLog(t, tx)},
+		TxHash:      common.HexToHash("0xa1"),
+		BlockNumber: big.NewInt(1000),
+	}
+
+	header := map[string]interface{}{
+		"hash":      common.HexToHash("0xb1"),
+		"stateRoot": common.HexToHash("0xb2"),
+	}
+	proof := map[string]interface{}{
+		"storageHash": common.HexToHash("0xb3"),
+		"storageProof": []map[string]interface{}{{
+			"key":   "0x01",
+			"value": "0x1",
+			"proof": []string{"0xf871a0", "0xe219a0"},
+		}},
+	}
+	rootClaim := HashOutputRoot(&interfaces.OutputRootProof{
+		StateRoot:                common.HexToHash("0xb2"),
+		MessagePasserStorageRoot: common.HexToHash("0xb3"),
+		LatestBlockhash:          common.HexToHash("0xb1"),
+	})
+
+	l1 := &fakeL1{
+		maturity: int64((7 * 24 * time.Hour).Seconds()),
+		games: []*fakeGame{
+			{proxy: common.HexToAddress("0x6a0"), l2Block: 900, status: gameDefenderWins},
+			{gameType: 1, proxy: common.HexToAddress("0x6a1"), l2Block: 1300}, // Not the respected type
+			{proxy: common.HexToAddress("0x6a2"), l2Block: 1200, rootClaim: rootClaim},
+		},
+	}
+	l2 := &fakeL2{responses: map[string]interface{}{
+		"eth_getTransactionReceipt": receipt,
+		"eth_getBlockByNumber":      header,
+		"eth_getProof":              proof,
+	}}
+	return l1, l2, tx
+}
+
+func newTestProver(t *testing.T, l1 *fakeL1, l2 *fakeL2, now time.Time) *withdrawalProver {
+	t.Helper()
+
+	config := DefaultBaseConfig()
+	config.Account = prover
+	p, err := NewWithdrawalProver(config, l1, l2)
+	require.NoError(t, err)
+	wp := p.(*withdrawalProver)
+	wp.now = func() time.Time { return now }
+	return wp
+}
+
+func TestWithdrawalProver_Prove(t *testing.T) {
+	l1, l2, tx := withdrawalChains(t)
+	p := newTestProver(t, l1, l2, time.Now())
+
+	withdrawal, err := p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+	require.NoError(t, err)
+	assert.Equal(t, tx, withdrawal.Transaction)
+	assert.Equal(t, uint64(1000), withdrawal.L2BlockNumber)
+	assert.Equal(t, interfaces.WithdrawalReadyToProve, withdrawal.Status)
+
+	proveTx, err := p.BuildProveTransaction(context.Background(), withdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, BaseOptimismPortal, *proveTx.To)
+	assert.Equal(t, prover, proveTx.From)
+	assert.Equal(t, big.NewInt(20e9), proveTx.GasPrice)
+
+	// Proven against the latest respected game, with the proof at its block
+	args, err := optimismPortal.Methods["proveWithdrawalTransaction"].Inputs.Unpack(proveTx.Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), args[1])
+	assert.Equal(t, [][]byte{{0xf8, 0x71, 0xa0}, {0xe2, 0x19, 0xa0}}, args[3])
+
+	slot := withdrawalStorageSlot(withdrawal.Hash)
+	assert.Contains(t, l2.calls, []interface{}{"eth_getProof", L2ToL1MessagePasser, []string{slot.Hex()}, hexutil.EncodeUint64(1200)})
+}
+
+func TestWithdrawalProver_ProveRejectsMismatchedOutputRoot(t *testing.T) {
+	l1, l2, _ := withdrawalChains(t)
+	l1.games[2].rootClaim = common.HexToHash("0xbad")
+	p := newTestProver(t, l1, l2, time.Now())
+
+	withdrawal, err := p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+	require.NoError(t, err)
+	_, err = p.BuildProveTransaction(context.Background(), withdrawal)
+	assert.ErrorContains(t, err, "does not match dispute game")
+}
+
+func TestWithdrawalProver_Status(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	day := uint64((24 * time.Hour).Seconds())
+
+	tests := []struct {
+		name     string
+		setup    func(l1 *fakeL1)
+		expected interfaces.WithdrawalStatus
+	}{
+		{
+			name: "no game covers the block",
+			setup: func(l1 *fakeL1) {
+				l1.games = l1.games[:2]
+			},
+			expected: interfaces.WithdrawalWaitingToProve,
+		},
+		{
+			name: "lost games are skipped",
+			setup: func(l1 *fakeL1) {
+				l1.games[2].status = gameChallengerWins
+			},
+			expected: interfaces.WithdrawalWaitingToProve,
+		},
+		{
+			name: "in the challenge window",
+			setup: func(l1 *fakeL1) {
+				l1.provenAt, l1.provenBy = uint64(now.Unix())-3*day, l1.games[2].proxy
+				l1.games[2].status = gameDefenderWins
+			},
+			expected: interfaces.WithdrawalProven,
+		},
+		{
+			name: "matured but the game is unresolved",
+			setup: func(l1 *fakeL1) {
+				l1.provenAt, l1.provenBy = uint64(now.Unix())-8*day, l1.games[2].proxy
+			},
+			expected: interfaces.WithdrawalProven,
+		},
+		{
+			name: "proof against a lost game must be redone",
+			setup: func(l1 *fakeL1) {
+				l1.provenAt, l1.provenBy = uint64(now.Unix())-8*day, l1.games[2].proxy
+				l1.games[2].status = gameChallengerWins
+				l1.games = append(l1.games, &fakeGame{proxy: common.HexToAddress("0x6a3"), l2Block: 1250})
+			},
+			expected: interfaces.WithdrawalReadyToProve,
+		},
+		{
+			name: "matured",
+			setup: func(l1 *fakeL1) {
+				l1.provenAt, l1.provenBy = uint64(now.Unix())-8*day, l1.games[2].proxy
+				l1.games[2].status = gameDefenderWins
+			},
+			expected: interfaces.WithdrawalReadyToFinalize,
+		},
+		{
+			name: "finalized",
+			setup: func(l1 *fakeL1) {
+				l1.finalized = true
+			},
+			expected: interfaces.WithdrawalFinalized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l1, l2, _ := withdrawalChains(t)
+			tt.setup(l1)
+			p := newTestProver(t, l1, l2, now)
+
+			withdrawal, err := p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, withdrawal.Status)
+			if tt.expected == interfaces.WithdrawalProven || tt.expected == interfaces.WithdrawalReadyToFinalize {
+				assert.Equal(t, withdrawal.ProvenAt.Add(7*24*time.Hour), withdrawal.FinalizableAt)
+			}
+		})
+	}
+}
+
+func TestWithdrawalProver_Finalize(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	l1, l2, tx := withdrawalChains(t)
+	l1.provenAt, l1.provenBy = uint64(now.Add(-3*24*time.Hour).Unix()), l1.games[2].proxy
+	l1.games[2].status = gameDefenderWins
+	p := newTestProver(t, l1, l2, now)
+
+	withdrawal, err := p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+	require.NoError(t, err)
+	_, err = p.BuildFinalizeTransaction(context.Background(), withdrawal)
+	assert.Error(t, err, "the challenge window has not passed")
+
+	p.now = func() time.Time { return now.Add(5 * 24 * time.Hour) }
+	finalizeTx, err := p.BuildFinalizeTransaction(context.Background(), withdrawal)
+	require.NoError(t, err)
+	assert.Equal(t, BaseOptimismPortal, *finalizeTx.To)
+	args, err := optimismPortal.Methods["finalizeWithdrawalTransaction"].Inputs.Unpack(finalizeTx.Data[4:])
+	require.NoError(t, err)
+	require.Len(t, args, 1)
+	assert.Equal(t, tx.Nonce, args[0].(struct {
+		Nonce    *big.Int       `json:"nonce"`
+		Sender   common.Address `json:"sender"`
+		Target   common.Address `json:"target"`
+		Value    *big.Int       `json:"value"`
+		GasLimit *big.Int       `json:"gasLimit"`
+		Data     []byte         `json:"data"`
+	}).Nonce)
+}
+
+func TestWithdrawalProver_GetWithdrawalErrors(t *testing.T) {
+	l1, l2, _ := withdrawalChains(t)
+	p := newTestProver(t, l1, l2, time.Now())
+
+	l2.responses["eth_getTransactionReceipt"] = nil
+	_, err := p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+	assert.ErrorContains(t, err, "not found")
+
+	l2.responses["eth_getTransactionReceipt"] = &ethtypes.Receipt{BlockNumber: big.NewInt(1000), Logs: []*ethtypes.Log{}}
+	_, err = p.GetWithdrawal(context.Background(), common.HexToHash("0xa1"))
+	assert.ErrorContains(t, err, "initiated no withdrawal")
+}
@@ -0,0 +1,281 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/processing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// StrategyRecord is a strategy's persisted enabled state and settings
+type StrategyRecord struct {
+	Strategy  interfaces.StrategyType
+	Enabled   bool
+	Config    map[string]interface{}
+	UpdatedAt time.Time
+}
+
+// Store persists strategy state across restarts
+type Store interface {
+	LoadStrategies(ctx context.Context) ([]*StrategyRecord, error)
+	SaveStrategy(ctx context.Context, record *StrategyRecord) error
+}
+
+// Config holds configuration for the strategy engine
+type Config struct {
+	Processor    *processing.ConcurrentStrategyConfig
+	Settings     map[string]map[string]interface{} // The strategies config section, applied before persisted state
+	StoreTimeout time.Duration
+}
+
+// DefaultConfig returns the default strategy engine configuration
+func DefaultConfig() *Config {
+	return &Config{
+		Processor:    processing.DefaultConcurrentStrategyConfig(),
+		StoreTimeout: 5 * time.Second,
+	}
+}
+
+// Engine implements the StrategyEngine interface over the strategies in a registry.
+// Enabled state and settings changes are validated, applied and persisted together.
+type Engine struct {
+	config    *Config
+	registry  interfaces.StrategyRegistry
+	processor *processing.ConcurrentStrategyProcessor
+	store     Store
+	mu        sync.Mutex // Serializes state changes
+}
+
+// NewStrategyEngine creates a strategy engine. Without a store, state changes last
+// until restart.
+func NewStrategyEngine(config *Config, registry interfaces.StrategyRegistry, store Store, latencyMonitor interfaces.LatencyMonitor) *Engine {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Engine{
+		config:    config,
+		registry:  registry,
+		processor: processing.NewConcurrentStrategyProcessor(config.Processor, registry, latencyMonitor),
+		store:     store,
+	}
+}
+
+// Processor returns the processor dispatching to the engine's strategies
+func (e *Engine) Processor() *processing.ConcurrentStrategyProcessor {
+	return e.processor
+}
+
+// Load applies the configured settings and then the persisted state. Registered
+// strategies without persisted state are saved with their current settings.
+func (e *Engine) Load(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.processor.Configure(e.config.Settings); err != nil {
+		return err
+	}
+	if e.store == nil {
+		return nil
+	}
+
+	records, err := e.store.LoadStrategies(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load strategy state: %w", err)
+	}
+
+	persisted := make(map[interfaces.StrategyType]bool, len(records))
+	settings := make(map[string]map[string]interface{}, len(records))
+	for _, record := range records {
+		persisted[record.Strategy] = true
+		strategySettings := make(map[string]interface{}, len(record.Config)+1)
+		for key, value := range record.Config {
+			strategySettings[key] = value
+		}
+		strategySettings["enabled"] = record.Enabled
+		settings[string(record.Strategy)] = strategySettings
+	}
+	if err := e.processor.Configure(settings); err != nil {
+		return err
+	}
+
+	for _, strategy := range e.strategies() {
+		if !persisted[strategy.Name()] {
+			if err := e.save(ctx, strategy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AnalyzeTransaction runs the enabled strategies over a transaction
+func (e *Engine) AnalyzeTransaction(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) ([]*interfaces.MEVOpportunity, error) {
+	return e.processor.DetectStrategiesConcurrently(ctx, tx, simResult)
+}
+
+// GetActiveStrategies returns the enabled strategies
+func (e *Engine) GetActiveStrategies() []interfaces.StrategyType {
+	return e.processor.GetActiveStrategies()
+}
+
+// EnableStrategy turns on a strategy and persists it
+func (e *Engine) EnableStrategy(strategy interfaces.StrategyType) error {
+	return e.setEnabled(strategy, true)
+}
+
+// DisableStrategy turns off a strategy and persists it
+func (e *Engine) DisableStrategy(strategy interfaces.StrategyType) error {
+	return e.setEnabled(strategy, false)
+}
+
+// UpdateStrategyConfig applies settings, keyed as in the strategy's config schema,
+// and persists them. Invalid settings, or a failure to persist, change nothing.
+func (e *Engine) UpdateStrategyConfig(strategyType interfaces.StrategyType, config interface{}) error {
+	settings, ok := config.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%w: settings must be an object", interfaces.ErrInvalidStrategyConfig)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	strategy, err := e.strategy(strategyType)
+	if err != nil {
+		return err
+	}
+
+	previous := currentConfig(strategy)
+	if err := strategy.Configure(settings); err != nil {
+		return fmt.Errorf("%w: %v", interfaces.ErrInvalidStrategyConfig, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.StoreTimeout)
+	defer cancel()
+
+	if err := e.save(ctx, strategy); err != nil {
+		if revertErr := strategy.Configure(previous); revertErr != nil {
+			return fmt.Errorf("%w (reverting settings: %v)", err, revertErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// GetStrategyStates returns every registered strategy's state, in registration order
+func (e *Engine) GetStrategyStates() []*interfaces.StrategyState {
+	active := make(map[interfaces.StrategyType]bool)
+	for _, strategy := range e.processor.GetActiveStrategies() {
+		active[strategy] = true
+	}
+
+	strategies := e.strategies()
+	states := make([]*interfaces.StrategyState, 0, len(strategies))
+	for _, strategy := range strategies {
+		states = append(states, &interfaces.StrategyState{
+			Name:    strategy.Name(),
+			Enabled: active[strategy.Name()],
+			Inputs:  strategy.Inputs(),
+			Config:  currentConfig(strategy),
+			Schema:  strategy.ConfigSchema(),
+		})
+	}
+	return states
+}
+
+// setEnabled turns a strategy on or off, reverting if the change can't be persisted
+func (e *Engine) setEnabled(strategyType interfaces.StrategyType, enabled bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	strategy, err := e.strategy(strategyType)
+	if err != nil {
+		return err
+	}
+
+	wasEnabled := e.isEnabled(strategyType)
+	if err := e.toggle(strategyType, enabled); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.config.StoreTimeout)
+	defer cancel()
+
+	if err := e.save(ctx, strategy); err != nil {
+		if revertErr := e.toggle(strategyType, wasEnabled); revertErr != nil {
+			return fmt.Errorf("%w (reverting: %v)", err, revertErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// toggle turns a strategy on or off in the processor
+func (e *Engine) toggle(strategy interfaces.StrategyType, enabled bool) error {
+	if enabled {
+		return e.processor.EnableStrategy(strategy)
+	}
+	return e.processor.DisableStrategy(strategy)
+}
+
+// save persists a strategy's current state
+func (e *Engine) save(ctx context.Context, strategy interfaces.Strategy) error {
+	if e.store == nil {
+		return nil
+	}
+
+	record := &StrategyRecord{
+		Strategy:  strategy.Name(),
+		Enabled:   e.isEnabled(strategy.Name()),
+		Config:    currentConfig(strategy),
+		UpdatedAt: time.Now(),
+	}
+	if err := e.store.SaveStrategy(ctx, record); err != nil {
+		return fmt.Errorf("failed to persist strategy %s: %w", strategy.Name(), err)
+	}
+	return nil
+}
+
+// strategies returns the registered strategies
+func (e *Engine) strategies() []interfaces.Strategy {
+	if e.registry == nil {
+		return nil
+	}
+	return e.registry.List()
+}
+
+// strategy returns a registered strategy by name
+func (e *Engine) strategy(name interfaces.StrategyType) (interfaces.Strategy, error) {
+	if e.registry != nil {
+		if strategy, exists := e.registry.Get(name); exists {
+			return strategy, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", interfaces.ErrUnknownStrategy, name)
+}
+
+// isEnabled reports whether a strategy is active in the processor
+func (e *Engine) isEnabled(name interfaces.StrategyType) bool {
+	for _, strategy := range e.processor.GetActiveStrategies() {
+		if strategy == name {
+			return true
+		}
+	}
+	return false
+}
+
+// currentConfig returns a strategy's current settings. Unset settings are left out.
+func currentConfig(strategy interfaces.Strategy) map[string]interface{} {
+	fields := strategy.ConfigSchema().Fields
+	config := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if field.Default != nil {
+			config[field.Name] = field.Default
+		}
+	}
+	return config
+}
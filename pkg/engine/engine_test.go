@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/strategy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore keeps strategy records in memory and can be made to fail
+type memoryStore struct {
+	records map[interfaces.StrategyType]*StrategyRecord
+	fail    bool
+}
+
+func newMemoryStore(records ...*StrategyRecord) *memoryStore {
+	store := &memoryStore{records: make(map[interfaces.StrategyType]*StrategyRecord)}
+	for _, record := range records {
+		store.records[record.Strategy] = record
+	}
+	return store
+}
+
+func (m *memoryStore) LoadStrategies(ctx context.Context) ([]*StrategyRecord, error) {
+	var records []*StrategyRecord
+	for _, record := range m.records {
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (m *memoryStore) SaveStrategy(ctx context.Context, record *StrategyRecord) error {
+	if m.fail {
+		return errors.New("connection refused")
+	}
+	m.records[record.Strategy] = record
+	return nil
+}
+
+// newEngine creates an engine over the sandwich and frontrun detectors
+func newEngine(t *testing.T, config *Config, store Store) (*Engine, interfaces.SandwichDetector) {
+	t.Helper()
+
+	sandwich := strategy.NewSandwichDetector(nil)
+	registry, err := strategy.NewStrategyRegistry(
+		strategy.NewSandwichStrategy(sandwich),
+		strategy.NewFrontrunStrategy(strategy.NewFrontrunDetector(nil)),
+	)
+	require.NoError(t, err)
+	return NewStrategyEngine(config, registry, store, nil), sandwich
+}
+
+func TestEngine_Load(t *testing.T) {
+	store := newMemoryStore(&StrategyRecord{
+		Strategy: interfaces.StrategySandwich,
+		Enabled:  false,
+		Config: map[string]interface{}{
+			"max_slippage":    0.05,
+			"min_tx_value":    "5000000000000000000", // Not in the schema
+			"gas_premium_pct": 0.1,
+		},
+	})
+
+	config := DefaultConfig()
+	config.Settings = map[string]map[string]interface{}{
+		"sandwich": {"enabled": true, "max_slippage": 0.03, "min_profit_threshold": "200000000000000000"},
+	}
+	engine, sandwich := newEngine(t, config, store)
+	require.NoError(t, engine.Load(context.Background()))
+
+	// Persisted state wins over the config file
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyFrontrun}, engine.GetActiveStrategies())
+	assert.Equal(t, 0.05, sandwich.GetConfiguration().MaxSlippage)
+	assert.Equal(t, big.NewInt(2e17), sandwich.GetConfiguration().MinProfitThreshold)
+
+	// Strategies without persisted state are saved
+	require.Contains(t, store.records, interfaces.StrategyFrontrun)
+	assert.True(t, store.records[interfaces.StrategyFrontrun].Enabled)
+	assert.NotEmpty(t, store.records[interfaces.StrategyFrontrun].Config)
+}
+
+func TestEngine_EnableStrategy(t *testing.T) {
+	store := newMemoryStore()
+	engine, _ := newEngine(t, nil, store)
+	require.NoError(t, engine.Load(context.Background()))
+
+	require.NoError(t, engine.DisableStrategy(interfaces.StrategySandwich))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyFrontrun}, engine.GetActiveStrategies())
+	assert.False(t, store.records[interfaces.StrategySandwich].Enabled)
+
+	// A change that can't be persisted is reverted
+	store.fail = true
+	assert.Error(t, engine.EnableStrategy(interfaces.StrategySandwich))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyFrontrun}, engine.GetActiveStrategies())
+
+	err := engine.EnableStrategy(interfaces.StrategyJIT)
+	assert.ErrorIs(t, err, interfaces.ErrUnknownStrategy)
+}
+
+func TestEngine_UpdateStrategyConfig(t *testing.T) {
+	store := newMemoryStore()
+	engine, sandwich := newEngine(t, nil, store)
+	require.NoError(t, engine.Load(context.Background()))
+
+	require.NoError(t, engine.UpdateStrategyConfig(interfaces.StrategySandwich, map[string]interface{}{
+		"max_slippage":         0.04,
+		"min_profit_threshold": "300000000000000000",
+	}))
+	assert.Equal(t, 0.04, sandwich.GetConfiguration().MaxSlippage)
+	assert.Equal(t, "300000000000000000", store.records[interfaces.StrategySandwich].Config["min_profit_threshold"])
+
+	tests := []struct {
+		name     string
+		strategy interfaces.StrategyType
+		config   interface{}
+		expected error
+	}{
+		{"unknown setting", interfaces.StrategySandwich, map[string]interface{}{"max_slipage": 0.01}, interfaces.ErrInvalidStrategyConfig},
+		{"bad value", interfaces.StrategySandwich, map[string]interface{}{"max_slippage": 0.01, "min_swap_amount": "ten"}, interfaces.ErrInvalidStrategyConfig},
+		{"not an object", interfaces.StrategySandwich, []interface{}{0.01}, interfaces.ErrInvalidStrategyConfig},
+		{"unknown strategy", interfaces.StrategyJIT, map[string]interface{}{}, interfaces.ErrUnknownStrategy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := engine.UpdateStrategyConfig(tt.strategy, tt.config)
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Equal(t, 0.04, sandwich.GetConfiguration().MaxSlippage)
+		})
+	}
+
+	// Settings that can't be persisted are reverted
+	store.fail = true
+	assert.Error(t, engine.UpdateStrategyConfig(interfaces.StrategySandwich, map[string]interface{}{"max_slippage": 0.01}))
+	assert.Equal(t, 0.04, sandwich.GetConfiguration().MaxSlippage)
+	assert.Equal(t, big.NewInt(3e17), sandwich.GetConfiguration().MinProfitThreshold)
+
+	states := engine.GetStrategyStates()
+	require.Len(t, states, 2)
+	assert.Equal(t, interfaces.StrategySandwich, states[0].Name)
+	assert.True(t, states[0].Enabled)
+	assert.Equal(t, 0.04, states[0].Config["max_slippage"])
+	assert.Equal(t, []interfaces.StrategyInputKind{interfaces.InputTransaction}, states[0].Inputs)
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// PostgresStore implements Store on top of the strategy_configs table in scripts/init.sql
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new Postgres-backed strategy store
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+// LoadStrategies loads every persisted strategy
+func (s *PostgresStore) LoadStrategies(ctx context.Context) ([]*StrategyRecord, error) {
+	query := `
+		SELECT strategy_name, config, enabled, updated_at
+		FROM strategy_configs
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query strategy configs: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*StrategyRecord
+	for rows.Next() {
+		var (
+			name      string
+			config    []byte
+			enabled   bool
+			updatedAt sql.NullTime
+		)
+		if err := rows.Scan(&name, &config, &enabled, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan strategy config: %w", err)
+		}
+
+		record := &StrategyRecord{
+			Strategy:  interfaces.StrategyType(name),
+			Enabled:   enabled,
+			UpdatedAt: updatedAt.Time,
+		}
+		if err := json.Unmarshal(config, &record.Config); err != nil {
+			return nil, fmt.Errorf("invalid config for strategy %s: %w", name, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// SaveStrategy upserts a strategy's enabled state and settings
+func (s *PostgresStore) SaveStrategy(ctx context.Context, record *StrategyRecord) error {
+	config, err := json.Marshal(record.Config)
+	if err != nil {
+		return fmt.Errorf("failed to encode config for strategy %s: %w", record.Strategy, err)
+	}
+
+	updatedAt := record.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO strategy_configs (strategy_name, config, enabled, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (strategy_name) DO UPDATE SET
+			config = EXCLUDED.config,
+			enabled = EXCLUDED.enabled,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, string(record.Strategy), string(config), record.Enabled, updatedAt); err != nil {
+		return fmt.Errorf("failed to save strategy %s: %w", record.Strategy, err)
+	}
+	return nil
+}
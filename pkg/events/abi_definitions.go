@@ -13,6 +13,8 @@ func (m *ABIManagerImpl) getUniswapV2ABI(contractType interfaces.ContractType) (
 		return uniswapV2PairABI, nil
 	case interfaces.ContractTypeRouter:
 		return uniswapV2RouterABI, nil
+	case interfaces.ContractTypeFactory:
+		return uniswapV2FactoryABI, nil
 	default:
 		return "", fmt.Errorf("unsupported Uniswap V2 contract type: %s", contractType.String())
 	}
@@ -25,6 +27,8 @@ func (m *ABIManagerImpl) getUniswapV3ABI(contractType interfaces.ContractType) (
 		return uniswapV3PoolABI, nil
 	case interfaces.ContractTypeRouter:
 		return uniswapV3RouterABI, nil
+	case interfaces.ContractTypeFactory:
+		return uniswapV3FactoryABI, nil
 	default:
 		return "", fmt.Errorf("unsupported Uniswap V3 contract type: %s", contractType.String())
 	}
@@ -37,6 +41,8 @@ func (m *ABIManagerImpl) getAerodromeABI(contractType interfaces.ContractType) (
 		return aerodromePairABI, nil
 	case interfaces.ContractTypeRouter:
 		return aerodromeRouterABI, nil
+	case interfaces.ContractTypeFactory:
+		return aerodromeFactoryABI, nil
 	default:
 		return "", fmt.Errorf("unsupported Aerodrome contract type: %s", contractType.String())
 	}
@@ -52,7 +58,7 @@ func (m *ABIManagerImpl) getBaseBridgeABI(contractType interfaces.ContractType)
 	}
 }
 
-// Uniswap V2 Pair ABI (focused on Swap event)
+// Uniswap V2 Pair ABI (swap and liquidity events)
 const uniswapV2PairABI = `[
 	{
 		"anonymous": false,
@@ -88,6 +94,15 @@ const uniswapV2PairABI = `[
 		"name": "Burn",
 		"type": "event"
 	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "reserve0", "type": "uint112"},
+			{"indexed": false, "name": "reserve1", "type": "uint112"}
+		],
+		"name": "Sync",
+		"type": "event"
+	},
 	{
 		"constant": true,
 		"inputs": [],
@@ -120,7 +135,7 @@ const uniswapV2RouterABI = `[
 	}
 ]`
 
-// Uniswap V3 Pool ABI (focused on Swap event)
+// Uniswap V3 Pool ABI (swap, position and flash events)
 const uniswapV3PoolABI = `[
 	{
 		"anonymous": false,
@@ -139,6 +154,7 @@ const uniswapV3PoolABI = `[
 	{
 		"anonymous": false,
 		"inputs": [
+			{"indexed": false, "name": "sender", "type": "address"},
 			{"indexed": true, "name": "owner", "type": "address"},
 			{"indexed": true, "name": "tickLower", "type": "int24"},
 			{"indexed": true, "name": "tickUpper", "type": "int24"},
@@ -149,6 +165,45 @@ const uniswapV3PoolABI = `[
 		"name": "Mint",
 		"type": "event"
 	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": true, "name": "tickLower", "type": "int24"},
+			{"indexed": true, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "amount", "type": "uint128"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Burn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": false, "name": "recipient", "type": "address"},
+			{"indexed": true, "name": "tickLower", "type": "int24"},
+			{"indexed": true, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "amount0", "type": "uint128"},
+			{"indexed": false, "name": "amount1", "type": "uint128"}
+		],
+		"name": "Collect",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"},
+			{"indexed": false, "name": "paid0", "type": "uint256"},
+			{"indexed": false, "name": "paid1", "type": "uint256"}
+		],
+		"name": "Flash",
+		"type": "event"
+	},
 	{
 		"constant": true,
 		"inputs": [],
@@ -197,6 +252,37 @@ const uniswapV3RouterABI = `[
 	}
 ]`
 
+// Uniswap V2 Factory ABI (focused on PairCreated event)
+const uniswapV2FactoryABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "token0", "type": "address"},
+			{"indexed": true, "name": "token1", "type": "address"},
+			{"indexed": false, "name": "pair", "type": "address"},
+			{"indexed": false, "name": "", "type": "uint256"}
+		],
+		"name": "PairCreated",
+		"type": "event"
+	}
+]`
+
+// Uniswap V3 Factory ABI (focused on PoolCreated event)
+const uniswapV3FactoryABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "token0", "type": "address"},
+			{"indexed": true, "name": "token1", "type": "address"},
+			{"indexed": true, "name": "fee", "type": "uint24"},
+			{"indexed": false, "name": "tickSpacing", "type": "int24"},
+			{"indexed": false, "name": "pool", "type": "address"}
+		],
+		"name": "PoolCreated",
+		"type": "event"
+	}
+]`
+
 // Aerodrome Pair ABI (similar to Uniswap V2 but with some differences)
 const aerodromePairABI = `[
 	{
@@ -222,6 +308,47 @@ const aerodromePairABI = `[
 		"name": "Mint",
 		"type": "event"
 	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Burn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "reserve0", "type": "uint256"},
+			{"indexed": false, "name": "reserve1", "type": "uint256"}
+		],
+		"name": "Sync",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Fees",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Claim",
+		"type": "event"
+	},
 	{
 		"constant": true,
 		"inputs": [],
@@ -269,6 +396,22 @@ const aerodromeRouterABI = `[
 	}
 ]`
 
+// Aerodrome PoolFactory ABI (focused on PoolCreated event)
+const aerodromeFactoryABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "token0", "type": "address"},
+			{"indexed": true, "name": "token1", "type": "address"},
+			{"indexed": true, "name": "stable", "type": "bool"},
+			{"indexed": false, "name": "pool", "type": "address"},
+			{"indexed": false, "name": "", "type": "uint256"}
+		],
+		"name": "PoolCreated",
+		"type": "event"
+	}
+]`
+
 // Base Bridge ABI (simplified)
 const baseBridgeABI = `[
 	{
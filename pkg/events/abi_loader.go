@@ -0,0 +1,304 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fsnotify/fsnotify"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+const (
+	abiFileExt        = ".json"
+	maxIndexedInputs  = 3 // Topics available to a non-anonymous event after its signature
+	abiReloadDebounce = 200 * time.Millisecond
+)
+
+// diskABI is an ABI read from the configured ABI directory
+type diskABI struct {
+	protocol     interfaces.Protocol
+	contractType interfaces.ContractType
+	path         string
+	json         string
+	parsed       *abi.ABI
+}
+
+// NewABIManagerWithDir creates an ABI manager that also loads ABIs from dir.
+// Files are named after the protocol and contract type they define, e.g.
+// uniswap_v3_pool.json, and take precedence over the built-in definitions.
+func NewABIManagerWithDir(dir string) (*ABIManagerImpl, error) {
+	manager := NewABIManager()
+	manager.abiDir = dir
+
+	if err := manager.ReloadABIs(); err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+// ABIDir returns the directory ABIs are loaded from, if any
+func (m *ABIManagerImpl) ABIDir() string {
+	return m.abiDir
+}
+
+// ReloadABIs re-reads every ABI file in the ABI directory. Files are parsed and
+// validated before anything is swapped in, so a bad file leaves the previously
+// loaded ABIs untouched. ABIs whose files were removed revert to their built-in
+// definitions.
+func (m *ABIManagerImpl) ReloadABIs() error {
+	if m.abiDir == "" {
+		return fmt.Errorf("no ABI directory configured")
+	}
+
+	m.reloadMu.Lock()
+	defer m.reloadMu.Unlock()
+
+	m.mu.RLock()
+	fileNames := m.abiFileNames()
+	m.mu.RUnlock()
+
+	loaded, err := m.readABIDir(fileNames)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Built-in events must keep their signatures, or the decoders relying on them would break
+	for _, override := range loaded {
+		builtinJSON, err := m.getBuiltinABIJSON(override.protocol, override.contractType)
+		if err != nil {
+			continue // New contract type, nothing to stay compatible with
+		}
+		builtinABI, err := abi.JSON(strings.NewReader(builtinJSON))
+		if err != nil {
+			return fmt.Errorf("failed to parse built-in ABI for %s %s: %w", override.protocol.String(), override.contractType.String(), err)
+		}
+		if err := validateABIOverride(&builtinABI, override.parsed); err != nil {
+			return fmt.Errorf("ABI file %s does not match %s %s: %w", override.path, override.protocol.String(), override.contractType.String(), err)
+		}
+	}
+
+	removed := make([]*diskABI, 0)
+	for key, previous := range m.disk {
+		if _, exists := loaded[key]; !exists {
+			removed = append(removed, previous)
+		}
+	}
+
+	m.disk = loaded
+	for key, override := range loaded {
+		m.abis[key] = override.parsed
+		m.rawABIs[key] = []byte(override.json)
+		if m.protocols[override.protocol] == nil {
+			m.protocols[override.protocol] = make(map[interfaces.ContractType]string)
+		}
+		if _, exists := m.protocols[override.protocol][override.contractType]; !exists {
+			m.protocols[override.protocol][override.contractType] = abiFileName(override.protocol, override.contractType)
+		}
+	}
+
+	for _, previous := range removed {
+		key := m.getABIKey(previous.protocol, previous.contractType)
+		delete(m.abis, key)
+		delete(m.rawABIs, key)
+
+		builtinJSON, err := m.getBuiltinABIJSON(previous.protocol, previous.contractType)
+		if err != nil {
+			delete(m.protocols[previous.protocol], previous.contractType)
+			continue
+		}
+		builtinABI, err := abi.JSON(strings.NewReader(builtinJSON))
+		if err != nil {
+			delete(m.protocols[previous.protocol], previous.contractType)
+			continue
+		}
+		m.abis[key] = &builtinABI
+		m.rawABIs[key] = []byte(builtinJSON)
+	}
+
+	return nil
+}
+
+// WatchABIDir reloads the ABI directory whenever a file in it changes, until ctx
+// is cancelled. Bursts of changes are coalesced into a single reload; reload
+// failures are logged and the previously loaded ABIs stay in use.
+func (m *ABIManagerImpl) WatchABIDir(ctx context.Context) error {
+	if m.abiDir == "" {
+		return fmt.Errorf("no ABI directory configured")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create ABI watcher: %w", err)
+	}
+	if err := watcher.Add(m.abiDir); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch ABI directory %s: %w", m.abiDir, err)
+	}
+
+	reload := time.AfterFunc(abiReloadDebounce, func() {
+		if err := m.ReloadABIs(); err != nil {
+			log.Printf("Failed to reload ABIs from %s: %v", m.abiDir, err)
+			return
+		}
+		log.Printf("Reloaded ABIs from %s", m.abiDir)
+	})
+	reload.Stop()
+
+	go func() {
+		defer watcher.Close()
+		defer reload.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) == abiFileExt {
+					reload.Reset(abiReloadDebounce)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("ABI watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// abiFileNames maps every ABI file name the directory may hold to the protocol
+// and contract type it defines. Callers must hold m.mu.
+func (m *ABIManagerImpl) abiFileNames() map[string]*diskABI {
+	fileNames := make(map[string]*diskABI)
+	for _, protocol := range interfaces.Protocols() {
+		for _, contractType := range interfaces.ContractTypes() {
+			name := abiFileName(protocol, contractType)
+			if mapped, exists := m.protocols[protocol][contractType]; exists {
+				name = mapped
+			}
+			fileNames[name+abiFileExt] = &diskABI{protocol: protocol, contractType: contractType}
+		}
+	}
+	return fileNames
+}
+
+// readABIDir parses and validates every ABI file in the ABI directory
+func (m *ABIManagerImpl) readABIDir(fileNames map[string]*diskABI) (map[string]*diskABI, error) {
+	dir := m.abiDir
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ABI directory %s: %w", dir, err)
+	}
+
+	loaded := make(map[string]*diskABI)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != abiFileExt {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		target, exists := fileNames[entry.Name()]
+		if !exists {
+			return nil, fmt.Errorf("ABI file %s does not name a known protocol and contract type", path)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ABI file %s: %w", path, err)
+		}
+
+		parsedABI, err := abi.JSON(strings.NewReader(string(data)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI file %s: %w", path, err)
+		}
+		if err := validateABIEvents(&parsedABI); err != nil {
+			return nil, fmt.Errorf("invalid ABI file %s: %w", path, err)
+		}
+
+		loaded[m.getABIKey(target.protocol, target.contractType)] = &diskABI{
+			protocol:     target.protocol,
+			contractType: target.contractType,
+			path:         path,
+			json:         string(data),
+			parsed:       &parsedABI,
+		}
+	}
+
+	return loaded, nil
+}
+
+// validateABIEvents checks that every event could actually be emitted as a log
+func validateABIEvents(parsedABI *abi.ABI) error {
+	for _, event := range parsedABI.Events {
+		limit := maxIndexedInputs
+		if event.Anonymous {
+			limit++
+		}
+
+		indexed := 0
+		for _, input := range event.Inputs {
+			if input.Indexed {
+				indexed++
+			}
+		}
+		if indexed > limit {
+			return fmt.Errorf("event %s has %d indexed inputs, at most %d are allowed", event.Sig, indexed, limit)
+		}
+	}
+	return nil
+}
+
+// validateABIOverride checks that an override keeps every event of the ABI it
+// replaces, with the same signature and the same inputs indexed
+func validateABIOverride(builtin, override *abi.ABI) error {
+	for name, event := range builtin.Events {
+		replacement, exists := override.Events[name]
+		if !exists {
+			return fmt.Errorf("missing event %s", event.Sig)
+		}
+		if replacement.ID != event.ID {
+			return fmt.Errorf("event %s changed signature to %s", event.Sig, replacement.Sig)
+		}
+		for i, input := range event.Inputs {
+			if replacement.Inputs[i].Indexed != input.Indexed {
+				return fmt.Errorf("event %s changed indexing of %s", event.Sig, input.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// abiFileName returns the snake_case file stem for a protocol and contract type,
+// e.g. sushi_swap_v3_pool
+func abiFileName(protocol interfaces.Protocol, contractType interfaces.ContractType) string {
+	return toSnakeCase(protocol.String()) + "_" + toSnakeCase(contractType.String())
+}
+
+// toSnakeCase converts a CamelCase name such as PancakeSwapV3 to pancake_swap_v3
+func toSnakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
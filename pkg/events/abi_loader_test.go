@@ -0,0 +1,167 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// Aerodrome Slipstream pools use the Uniswap V3 Swap layout
+	testAerodromePoolABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"sender","type":"address"},{"indexed":true,"name":"recipient","type":"address"},{"indexed":false,"name":"amount0","type":"int256"},{"indexed":false,"name":"amount1","type":"int256"},{"indexed":false,"name":"sqrtPriceX96","type":"uint160"},{"indexed":false,"name":"liquidity","type":"uint128"},{"indexed":false,"name":"tick","type":"int24"}],"name":"Swap","type":"event"}]`
+	// Bridge ABI with an extra event alongside the built-in ones
+	testBridgeExtraEvent = `{"anonymous":false,"inputs":[{"indexed":true,"name":"withdrawalHash","type":"bytes32"}],"name":"WithdrawalProven","type":"event"}`
+	// Swap with four indexed inputs can never be emitted
+	testTooManyIndexedABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"a","type":"address"},{"indexed":true,"name":"b","type":"address"},{"indexed":true,"name":"c","type":"address"},{"indexed":true,"name":"d","type":"address"}],"name":"Swap","type":"event"}]`
+	// Uniswap V2 Sync with a changed signature
+	testAlteredPairABI = `[{"anonymous":false,"inputs":[{"indexed":false,"name":"reserve0","type":"uint256"},{"indexed":false,"name":"reserve1","type":"uint256"}],"name":"Sync","type":"event"}]`
+)
+
+func writeABIFile(t *testing.T, dir, name, abiJSON string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(abiJSON), 0o644))
+}
+
+// bridgeABIWithExtraEvent returns the built-in bridge ABI with one more event
+func bridgeABIWithExtraEvent(t *testing.T) string {
+	t.Helper()
+	builtin, err := NewABIManager().getBaseBridgeABI(interfaces.ContractTypeBridge)
+	require.NoError(t, err)
+	return builtin[:len(builtin)-1] + "," + testBridgeExtraEvent + "]"
+}
+
+func TestNewABIManagerWithDir(t *testing.T) {
+	dir := t.TempDir()
+	writeABIFile(t, dir, "aerodrome_pool.json", testAerodromePoolABI)
+	writeABIFile(t, dir, "base_bridge.json", bridgeABIWithExtraEvent(t))
+	writeABIFile(t, dir, "README.md", "not an ABI")
+
+	manager, err := NewABIManagerWithDir(dir)
+	require.NoError(t, err)
+	assert.Equal(t, dir, manager.ABIDir())
+
+	// A new contract type becomes available without a built-in definition
+	abiJSON, err := manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+	require.NoError(t, err)
+	assert.JSONEq(t, testAerodromePoolABI, string(abiJSON))
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolAerodrome, "Swap(address,address,int256,int256,uint160,uint128,int24)"))
+
+	// Built-in ABIs can be extended
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolBaseBridge, "WithdrawalProven(bytes32)"))
+	_, err = manager.GetEventSignature(interfaces.ProtocolBaseBridge, "WithdrawalProven")
+	assert.NoError(t, err)
+
+	// Untouched ABIs are still the built-in ones
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolUniswapV2, "Sync(uint112,uint112)"))
+}
+
+func TestNewABIManagerWithDir_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		abiJSON  string
+	}{
+		{"unknown file name", "uniswap_v9_pool.json", testAerodromePoolABI},
+		{"malformed JSON", "aerodrome_pool.json", "not json"},
+		{"too many indexed inputs", "aerodrome_pool.json", testTooManyIndexedABI},
+		{"changed built-in signature", "uniswap_v2_pair.json", testAlteredPairABI},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeABIFile(t, dir, tt.fileName, tt.abiJSON)
+
+			_, err := NewABIManagerWithDir(dir)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewABIManagerWithDir(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestReloadABIs(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := NewABIManagerWithDir(dir)
+	require.NoError(t, err)
+
+	_, err = manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+	assert.Error(t, err)
+
+	// New files are picked up
+	writeABIFile(t, dir, "aerodrome_pool.json", testAerodromePoolABI)
+	writeABIFile(t, dir, "base_bridge.json", bridgeABIWithExtraEvent(t))
+	require.NoError(t, manager.ReloadABIs())
+	_, err = manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+	assert.NoError(t, err)
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolBaseBridge, "WithdrawalProven(bytes32)"))
+
+	// A bad file fails the whole reload and leaves the loaded ABIs in place
+	writeABIFile(t, dir, "uniswap_v2_pair.json", testAlteredPairABI)
+	require.NoError(t, os.Remove(filepath.Join(dir, "aerodrome_pool.json")))
+	assert.Error(t, manager.ReloadABIs())
+	_, err = manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+	assert.NoError(t, err)
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolUniswapV2, "Sync(uint112,uint112)"))
+
+	// Removed files revert to the built-in definitions
+	require.NoError(t, os.Remove(filepath.Join(dir, "uniswap_v2_pair.json")))
+	require.NoError(t, os.Remove(filepath.Join(dir, "base_bridge.json")))
+	require.NoError(t, manager.ReloadABIs())
+	_, err = manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+	assert.Error(t, err)
+	assert.False(t, manager.IsEventSupported(interfaces.ProtocolBaseBridge, "WithdrawalProven(bytes32)"))
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolBaseBridge, "DepositFinalized(address,address,uint256,bytes)"))
+}
+
+func TestReloadABIs_KeepsDiskOverAdapterABI(t *testing.T) {
+	dir := t.TempDir()
+	pairABI := `[{"anonymous":false,"inputs":[{"indexed":false,"name":"reserve0","type":"uint112"},{"indexed":false,"name":"reserve1","type":"uint112"}],"name":"Sync","type":"event"},` + testBridgeExtraEvent + `]`
+	writeABIFile(t, dir, "sushi_swap_v2_pair.json", pairABI)
+
+	manager, err := NewABIManagerWithDir(dir)
+	require.NoError(t, err)
+
+	err = manager.RegisterProtocolABIs(interfaces.ProtocolSushiSwapV2, map[interfaces.ContractType]string{
+		interfaces.ContractTypePair: `[{"anonymous":false,"inputs":[{"indexed":false,"name":"reserve0","type":"uint112"},{"indexed":false,"name":"reserve1","type":"uint112"}],"name":"Sync","type":"event"}]`,
+	})
+	require.NoError(t, err)
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolSushiSwapV2, "WithdrawalProven(bytes32)"))
+
+	// Adapter ABIs the disk file doesn't cover are rejected
+	err = manager.RegisterProtocolABIs(interfaces.ProtocolSushiSwapV2, map[interfaces.ContractType]string{
+		interfaces.ContractTypePair: testAerodromePoolABI,
+	})
+	assert.Error(t, err)
+}
+
+func TestWatchABIDir(t *testing.T) {
+	dir := t.TempDir()
+	manager, err := NewABIManagerWithDir(dir)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, manager.WatchABIDir(ctx))
+
+	writeABIFile(t, dir, "aerodrome_pool.json", testAerodromePoolABI)
+	assert.Eventually(t, func() bool {
+		_, err := manager.GetABI(interfaces.ProtocolAerodrome, interfaces.ContractTypePool)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.Error(t, NewABIManager().WatchABIDir(ctx))
+}
+
+func TestABIFileName(t *testing.T) {
+	assert.Equal(t, "uniswap_v3_pool", abiFileName(interfaces.ProtocolUniswapV3, interfaces.ContractTypePool))
+	assert.Equal(t, "pancake_swap_v3_router", abiFileName(interfaces.ProtocolPancakeSwapV3, interfaces.ContractTypeRouter))
+	assert.Equal(t, "uniswap_v4_pool_manager", abiFileName(interfaces.ProtocolUniswapV4, interfaces.ContractTypePoolManager))
+}
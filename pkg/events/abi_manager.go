@@ -1,7 +1,6 @@
 package events
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -14,7 +13,12 @@ import (
 // ABIManagerImpl implements the ABIManager interface
 type ABIManagerImpl struct {
 	abis      map[string]*abi.ABI
+	rawABIs   map[string][]byte
+	custom    map[string]string   // ABI JSON registered by protocol adapters
+	disk      map[string]*diskABI // ABIs loaded from abiDir, which take precedence
+	abiDir    string
 	mu        sync.RWMutex
+	reloadMu  sync.Mutex // Serializes directory reloads
 	protocols map[interfaces.Protocol]map[interfaces.ContractType]string
 }
 
@@ -22,6 +26,9 @@ type ABIManagerImpl struct {
 func NewABIManager() *ABIManagerImpl {
 	manager := &ABIManagerImpl{
 		abis:      make(map[string]*abi.ABI),
+		rawABIs:   make(map[string][]byte),
+		custom:    make(map[string]string),
+		disk:      make(map[string]*diskABI),
 		protocols: make(map[interfaces.Protocol]map[interfaces.ContractType]string),
 	}
 	
@@ -55,6 +62,7 @@ func (m *ABIManagerImpl) LoadABI(protocol interfaces.Protocol, contractType inte
 	}
 	
 	m.abis[key] = &parsedABI
+	m.rawABIs[key] = []byte(abiJSON)
 	return nil
 }
 
@@ -64,12 +72,13 @@ func (m *ABIManagerImpl) GetABI(protocol interfaces.Protocol, contractType inter
 	defer m.mu.RUnlock()
 	
 	key := m.getABIKey(protocol, contractType)
-	abiObj, exists := m.abis[key]
+	rawABI, exists := m.rawABIs[key]
 	if !exists {
 		return nil, fmt.Errorf("ABI not found for %s %s", protocol.String(), contractType.String())
 	}
 	
-	return json.Marshal(abiObj)
+	// Return the original JSON; abi.ABI does not marshal back into ABI JSON
+	return rawABI, nil
 }
 
 // IsEventSupported checks if an event signature is supported for a protocol
@@ -122,18 +131,21 @@ func (m *ABIManagerImpl) getABIKey(protocol interfaces.Protocol, contractType in
 // initializeProtocolMappings sets up the mapping between protocols and their contract types
 func (m *ABIManagerImpl) initializeProtocolMappings() {
 	m.protocols[interfaces.ProtocolUniswapV2] = map[interfaces.ContractType]string{
-		interfaces.ContractTypePair:   "uniswap_v2_pair",
-		interfaces.ContractTypeRouter: "uniswap_v2_router",
+		interfaces.ContractTypePair:    "uniswap_v2_pair",
+		interfaces.ContractTypeRouter:  "uniswap_v2_router",
+		interfaces.ContractTypeFactory: "uniswap_v2_factory",
 	}
 	
 	m.protocols[interfaces.ProtocolUniswapV3] = map[interfaces.ContractType]string{
-		interfaces.ContractTypePool:   "uniswap_v3_pool",
-		interfaces.ContractTypeRouter: "uniswap_v3_router",
+		interfaces.ContractTypePool:    "uniswap_v3_pool",
+		interfaces.ContractTypeRouter:  "uniswap_v3_router",
+		interfaces.ContractTypeFactory: "uniswap_v3_factory",
 	}
 	
 	m.protocols[interfaces.ProtocolAerodrome] = map[interfaces.ContractType]string{
-		interfaces.ContractTypePair:   "aerodrome_pair",
-		interfaces.ContractTypeRouter: "aerodrome_router",
+		interfaces.ContractTypePair:    "aerodrome_pair",
+		interfaces.ContractTypeRouter:  "aerodrome_router",
+		interfaces.ContractTypeFactory: "aerodrome_factory",
 	}
 	
 	m.protocols[interfaces.ProtocolBaseBridge] = map[interfaces.ContractType]string{
@@ -141,26 +153,70 @@ func (m *ABIManagerImpl) initializeProtocolMappings() {
 	}
 }
 
-// loadDefaultABIs loads the default ABIs for all supported protocols
-func (m *ABIManagerImpl) loadDefaultABIs() {
-	protocols := []interfaces.Protocol{
-		interfaces.ProtocolUniswapV2,
-		interfaces.ProtocolUniswapV3,
-		interfaces.ProtocolAerodrome,
-		interfaces.ProtocolBaseBridge,
+// RegisterProtocolABIs registers and loads the ABIs of a protocol that is not built in
+func (m *ABIManagerImpl) RegisterProtocolABIs(protocol interfaces.Protocol, abis map[interfaces.ContractType]string) error {
+	parsedABIs := make(map[interfaces.ContractType]*abi.ABI, len(abis))
+	for contractType, abiJSON := range abis {
+		parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+		if err != nil {
+			return fmt.Errorf("failed to parse ABI for %s %s: %w", protocol.String(), contractType.String(), err)
+		}
+		parsedABIs[contractType] = &parsedABI
 	}
 	
-	for _, protocol := range protocols {
-		if contractTypes, exists := m.protocols[protocol]; exists {
-			for contractType := range contractTypes {
-				_ = m.LoadABI(protocol, contractType) // Ignore errors for now
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	// An ABI loaded from disk stays in place, provided it still covers the registered events
+	for contractType, parsedABI := range parsedABIs {
+		if override, exists := m.disk[m.getABIKey(protocol, contractType)]; exists {
+			if err := validateABIOverride(parsedABI, override.parsed); err != nil {
+				return fmt.Errorf("ABI file %s does not match %s %s: %w", override.path, protocol.String(), contractType.String(), err)
 			}
 		}
 	}
+	
+	if m.protocols[protocol] == nil {
+		m.protocols[protocol] = make(map[interfaces.ContractType]string)
+	}
+	for contractType, abiJSON := range abis {
+		key := m.getABIKey(protocol, contractType)
+		m.custom[key] = abiJSON
+		m.protocols[protocol][contractType] = abiFileName(protocol, contractType)
+		if _, exists := m.disk[key]; exists {
+			continue
+		}
+		m.abis[key] = parsedABIs[contractType]
+		m.rawABIs[key] = []byte(abiJSON)
+	}
+	
+	return nil
+}
+
+// loadDefaultABIs loads the default ABIs for all supported protocols
+func (m *ABIManagerImpl) loadDefaultABIs() {
+	for protocol, contractTypes := range m.protocols {
+		for contractType := range contractTypes {
+			_ = m.LoadABI(protocol, contractType) // Ignore errors for now
+		}
+	}
 }
 
 // getABIJSON returns the ABI JSON string for a protocol and contract type
 func (m *ABIManagerImpl) getABIJSON(protocol interfaces.Protocol, contractType interfaces.ContractType) (string, error) {
+	if override, exists := m.disk[m.getABIKey(protocol, contractType)]; exists {
+		return override.json, nil
+	}
+	
+	return m.getBuiltinABIJSON(protocol, contractType)
+}
+
+// getBuiltinABIJSON returns the ABI JSON compiled in or registered by an adapter
+func (m *ABIManagerImpl) getBuiltinABIJSON(protocol interfaces.Protocol, contractType interfaces.ContractType) (string, error) {
+	if abiJSON, exists := m.custom[m.getABIKey(protocol, contractType)]; exists {
+		return abiJSON, nil
+	}
+	
 	switch protocol {
 	case interfaces.ProtocolUniswapV2:
 		return m.getUniswapV2ABI(contractType)
@@ -154,6 +154,32 @@ func TestGetABIKey(t *testing.T) {
 	assert.Contains(t, key2, "UniswapV3")
 }
 
+func TestRegisterProtocolABIs(t *testing.T) {
+	manager := NewABIManager()
+	
+	pairABI := `[{"anonymous":false,"inputs":[{"indexed":false,"name":"reserve0","type":"uint112"},{"indexed":false,"name":"reserve1","type":"uint112"}],"name":"Sync","type":"event"}]`
+	
+	err := manager.RegisterProtocolABIs(interfaces.ProtocolSushiSwapV2, map[interfaces.ContractType]string{
+		interfaces.ContractTypePair: pairABI,
+	})
+	require.NoError(t, err)
+	
+	abiJSON, err := manager.GetABI(interfaces.ProtocolSushiSwapV2, interfaces.ContractTypePair)
+	require.NoError(t, err)
+	assert.JSONEq(t, pairABI, string(abiJSON))
+	
+	signature, err := manager.GetEventSignature(interfaces.ProtocolSushiSwapV2, "Sync")
+	require.NoError(t, err)
+	assert.True(t, manager.IsEventSupported(interfaces.ProtocolSushiSwapV2, "Sync(uint112,uint112)"))
+	assert.NotEqual(t, common.Hash{}, signature)
+	
+	// Invalid ABIs are rejected
+	err = manager.RegisterProtocolABIs(interfaces.ProtocolCurve, map[interfaces.ContractType]string{
+		interfaces.ContractTypePool: "not json",
+	})
+	assert.Error(t, err)
+}
+
 func TestGetUniswapV2ABI(t *testing.T) {
 	manager := NewABIManager()
 	
@@ -14,6 +14,7 @@ import (
 // EventParserImpl implements the EventParser interface
 type EventParserImpl struct {
 	abiManager interfaces.ABIManager
+	adapters   interfaces.ProtocolAdapterRegistry
 }
 
 // TokenInfo holds token information extracted from pool contracts
@@ -30,6 +31,14 @@ func NewEventParser(abiManager interfaces.ABIManager) *EventParserImpl {
 	}
 }
 
+// NewEventParserWithAdapters creates an event parser that also decodes logs from protocol adapters
+func NewEventParserWithAdapters(abiManager interfaces.ABIManager, adapters interfaces.ProtocolAdapterRegistry) *EventParserImpl {
+	return &EventParserImpl{
+		abiManager: abiManager,
+		adapters:   adapters,
+	}
+}
+
 // ParseEventLogs parses multiple event logs and returns parsed events
 func (p *EventParserImpl) ParseEventLogs(ctx context.Context, logs []*ethtypes.Log) ([]*interfaces.ParsedEvent, error) {
 	var parsedEvents []*interfaces.ParsedEvent
@@ -97,12 +106,20 @@ func (p *EventParserImpl) DecodeBridgeEvent(ctx context.Context, log *ethtypes.L
 
 // GetSupportedProtocols returns the list of supported protocols
 func (p *EventParserImpl) GetSupportedProtocols() []interfaces.Protocol {
-	return []interfaces.Protocol{
+	protocols := []interfaces.Protocol{
 		interfaces.ProtocolUniswapV2,
 		interfaces.ProtocolUniswapV3,
 		interfaces.ProtocolAerodrome,
 		interfaces.ProtocolBaseBridge,
 	}
+	
+	if p.adapters != nil {
+		for _, adapter := range p.adapters.Adapters() {
+			protocols = append(protocols, adapter.Protocol())
+		}
+	}
+	
+	return protocols
 }
 
 // parseEventLog parses a single event log
@@ -113,6 +130,14 @@ func (p *EventParserImpl) parseEventLog(ctx context.Context, log *ethtypes.Log)
 	
 	eventSignature := log.Topics[0]
 	
+	// Adapters take precedence: fork venues share signatures with the built-in
+	// protocols and are only claimed when the pool is known to the registry
+	if p.adapters != nil {
+		if adapterEvent, err := p.adapters.DecodeLog(log); err == nil && adapterEvent != nil {
+			return adapterEvent, nil
+		}
+	}
+	
 	// Try to parse as swap event first
 	swapEvent, err := p.DecodeSwapEvent(ctx, log)
 	if err == nil && swapEvent != nil {
@@ -128,6 +153,12 @@ func (p *EventParserImpl) parseEventLog(ctx context.Context, log *ethtypes.Log)
 		}, nil
 	}
 	
+	// Try to parse as liquidity, reserve or fee event
+	poolEvent, err := p.decodePoolEvent(ctx, log)
+	if err == nil && poolEvent != nil {
+		return poolEvent, nil
+	}
+	
 	// Try to parse as bridge event
 	bridgeEvent, err := p.DecodeBridgeEvent(ctx, log)
 	if err == nil && bridgeEvent != nil {
@@ -184,15 +215,10 @@ func (p *EventParserImpl) decodeSwapEventForProtocol(ctx context.Context, log *e
 		return nil, fmt.Errorf("event signature mismatch for %s Swap event", protocol.String())
 	}
 	
-	// Decode the event
-	decoded := make(map[string]interface{})
-	if err := parsedABI.UnpackIntoMap(decoded, "Swap", log.Data); err != nil {
-		return nil, fmt.Errorf("failed to unpack Swap event data: %w", err)
-	}
-	
-	// Parse indexed topics
-	if err := abi.ParseTopicsIntoMap(decoded, swapEvent.Inputs, log.Topics[1:]); err != nil {
-		return nil, fmt.Errorf("failed to parse indexed topics: %w", err)
+	// Decode the event data and indexed topics
+	decoded, err := UnpackEventLog(&parsedABI, swapEvent, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Swap event: %w", err)
 	}
 	
 	builtSwapEvent, err := p.buildSwapEvent(protocol, log.Address, decoded)
@@ -364,16 +390,10 @@ func (p *EventParserImpl) decodeBridgeEventForProtocol(ctx context.Context, log
 		return nil, fmt.Errorf("bridge event not found for signature %s", eventSignature.Hex())
 	}
 	
-	// Decode the event
-	decoded := make(map[string]interface{})
-	if err := parsedABI.UnpackIntoMap(decoded, eventName, log.Data); err != nil {
-		return nil, fmt.Errorf("failed to unpack bridge event data: %w", err)
-	}
-	
-	// Parse indexed topics
-	event := parsedABI.Events[eventName]
-	if err := abi.ParseTopicsIntoMap(decoded, event.Inputs, log.Topics[1:]); err != nil {
-		return nil, fmt.Errorf("failed to parse indexed topics: %w", err)
+	// Decode the event data and indexed topics
+	decoded, err := UnpackEventLog(&parsedABI, parsedABI.Events[eventName], log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode bridge event: %w", err)
 	}
 	
 	bridgeEvent := &interfaces.BridgeEvent{
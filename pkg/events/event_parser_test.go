@@ -263,6 +263,12 @@ func TestEventTypeString(t *testing.T) {
 		{interfaces.EventTypeWithdraw, "Withdraw"},
 		{interfaces.EventTypeMint, "Mint"},
 		{interfaces.EventTypeBurn, "Burn"},
+		{interfaces.EventTypeSync, "Sync"},
+		{interfaces.EventTypeCollect, "Collect"},
+		{interfaces.EventTypeFlash, "Flash"},
+		{interfaces.EventTypeFees, "Fees"},
+		{interfaces.EventTypeClaim, "Claim"},
+		{interfaces.EventTypeInitialize, "Initialize"},
 		{interfaces.EventTypeUnknown, "Unknown"},
 	}
 	
@@ -282,6 +288,12 @@ func TestProtocolString(t *testing.T) {
 		{interfaces.ProtocolUniswapV3, "UniswapV3"},
 		{interfaces.ProtocolAerodrome, "Aerodrome"},
 		{interfaces.ProtocolBaseBridge, "BaseBridge"},
+		{interfaces.ProtocolUniswapV4, "UniswapV4"},
+		{interfaces.ProtocolPancakeSwapV3, "PancakeSwapV3"},
+		{interfaces.ProtocolSushiSwapV2, "SushiSwapV2"},
+		{interfaces.ProtocolSushiSwapV3, "SushiSwapV3"},
+		{interfaces.ProtocolBaseSwap, "BaseSwap"},
+		{interfaces.ProtocolCurve, "Curve"},
 		{interfaces.ProtocolUnknown, "Unknown"},
 	}
 	
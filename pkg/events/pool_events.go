@@ -0,0 +1,243 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// poolEventSources lists the pool contracts checked for non-swap events, in order.
+// Aerodrome's Mint has the same signature as Uniswap V2's, so those logs are
+// attributed to Uniswap V2; callers that need the exact venue should resolve the
+// pool address through the pool registry.
+var poolEventSources = []struct {
+	protocol     interfaces.Protocol
+	contractType interfaces.ContractType
+}{
+	{interfaces.ProtocolUniswapV2, interfaces.ContractTypePair},
+	{interfaces.ProtocolUniswapV3, interfaces.ContractTypePool},
+	{interfaces.ProtocolAerodrome, interfaces.ContractTypePair},
+}
+
+// decodePoolEvent decodes liquidity, reserve, flash and fee events emitted by DEX pools
+func (p *EventParserImpl) decodePoolEvent(ctx context.Context, log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	eventSignature := log.Topics[0]
+
+	for _, source := range poolEventSources {
+		parsedABI, err := p.getParsedABI(source.protocol, source.contractType)
+		if err != nil {
+			continue
+		}
+
+		event, err := parsedABI.EventByID(eventSignature)
+		if err != nil || event.Name == "Swap" {
+			continue
+		}
+
+		decoded, err := UnpackEventLog(parsedABI, *event, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s %s event: %w", source.protocol.String(), event.Name, err)
+		}
+
+		parsedEvent := &interfaces.ParsedEvent{
+			Protocol:    source.protocol,
+			Address:     log.Address,
+			TxHash:      log.TxHash,
+			BlockNumber: log.BlockNumber,
+			LogIndex:    log.Index,
+			RawLog:      log,
+		}
+
+		if err := BuildPoolEvent(parsedEvent, event.Name, decoded); err != nil {
+			return nil, err
+		}
+
+		return parsedEvent, nil
+	}
+
+	return nil, fmt.Errorf("pool event not found for signature %s", eventSignature.Hex())
+}
+
+// BuildPoolEvent populates the typed payload of a parsed pool event from decoded
+// Uniswap V2/V3 or Aerodrome style event fields
+func BuildPoolEvent(parsedEvent *interfaces.ParsedEvent, eventName string, decoded map[string]interface{}) error {
+	protocol := parsedEvent.Protocol
+	pool := parsedEvent.Address
+
+	switch eventName {
+	case "Mint", "Burn":
+		parsedEvent.EventType = interfaces.EventTypeMint
+		if eventName == "Burn" {
+			parsedEvent.EventType = interfaces.EventTypeBurn
+		}
+
+		liquidityEvent := &interfaces.LiquidityEvent{
+			Protocol:  protocol,
+			Pool:      pool,
+			Sender:    DecodedAddress(decoded, "sender"),
+			Owner:     DecodedAddress(decoded, "owner"),
+			Recipient: DecodedAddress(decoded, "to"),
+			TickLower: DecodedBigInt(decoded, "tickLower"),
+			TickUpper: DecodedBigInt(decoded, "tickUpper"),
+			Liquidity: DecodedBigInt(decoded, "amount"),
+			Amount0:   DecodedBigInt(decoded, "amount0"),
+			Amount1:   DecodedBigInt(decoded, "amount1"),
+		}
+		if liquidityEvent.Amount0 == nil || liquidityEvent.Amount1 == nil {
+			return fmt.Errorf("missing amount data in %s event", eventName)
+		}
+		parsedEvent.LiquidityEvent = liquidityEvent
+
+	case "Sync":
+		syncEvent := &interfaces.SyncEvent{
+			Protocol: protocol,
+			Pool:     pool,
+			Reserve0: DecodedBigInt(decoded, "reserve0"),
+			Reserve1: DecodedBigInt(decoded, "reserve1"),
+		}
+		if syncEvent.Reserve0 == nil || syncEvent.Reserve1 == nil {
+			return fmt.Errorf("missing reserve data in Sync event")
+		}
+		parsedEvent.EventType = interfaces.EventTypeSync
+		parsedEvent.SyncEvent = syncEvent
+
+	case "Collect":
+		collectEvent := &interfaces.CollectEvent{
+			Protocol:  protocol,
+			Pool:      pool,
+			Owner:     DecodedAddress(decoded, "owner"),
+			Recipient: DecodedAddress(decoded, "recipient"),
+			TickLower: DecodedBigInt(decoded, "tickLower"),
+			TickUpper: DecodedBigInt(decoded, "tickUpper"),
+			Amount0:   DecodedBigInt(decoded, "amount0"),
+			Amount1:   DecodedBigInt(decoded, "amount1"),
+		}
+		if collectEvent.Amount0 == nil || collectEvent.Amount1 == nil {
+			return fmt.Errorf("missing amount data in Collect event")
+		}
+		parsedEvent.EventType = interfaces.EventTypeCollect
+		parsedEvent.CollectEvent = collectEvent
+
+	case "Flash":
+		flashEvent := &interfaces.FlashEvent{
+			Protocol:  protocol,
+			Pool:      pool,
+			Sender:    DecodedAddress(decoded, "sender"),
+			Recipient: DecodedAddress(decoded, "recipient"),
+			Amount0:   DecodedBigInt(decoded, "amount0"),
+			Amount1:   DecodedBigInt(decoded, "amount1"),
+			Paid0:     DecodedBigInt(decoded, "paid0"),
+			Paid1:     DecodedBigInt(decoded, "paid1"),
+		}
+		if flashEvent.Amount0 == nil || flashEvent.Amount1 == nil {
+			return fmt.Errorf("missing amount data in Flash event")
+		}
+		parsedEvent.EventType = interfaces.EventTypeFlash
+		parsedEvent.FlashEvent = flashEvent
+
+	case "Fees", "Claim":
+		feeEvent := &interfaces.FeeEvent{
+			Protocol:  protocol,
+			Pool:      pool,
+			Sender:    DecodedAddress(decoded, "sender"),
+			Recipient: DecodedAddress(decoded, "recipient"),
+			Amount0:   DecodedBigInt(decoded, "amount0"),
+			Amount1:   DecodedBigInt(decoded, "amount1"),
+		}
+		if feeEvent.Amount0 == nil || feeEvent.Amount1 == nil {
+			return fmt.Errorf("missing amount data in %s event", eventName)
+		}
+		parsedEvent.EventType = interfaces.EventTypeFees
+		if eventName == "Claim" {
+			parsedEvent.EventType = interfaces.EventTypeClaim
+		}
+		parsedEvent.FeeEvent = feeEvent
+
+	default:
+		return fmt.Errorf("unsupported pool event: %s", eventName)
+	}
+
+	return nil
+}
+
+// getParsedABI loads and parses the ABI for a protocol contract
+func (p *EventParserImpl) getParsedABI(protocol interfaces.Protocol, contractType interfaces.ContractType) (*abi.ABI, error) {
+	abiBytes, err := p.abiManager.GetABI(protocol, contractType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ABI for %s: %w", protocol.String(), err)
+	}
+
+	var parsedABI abi.ABI
+	if err := parsedABI.UnmarshalJSON(abiBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal ABI: %w", err)
+	}
+
+	return &parsedABI, nil
+}
+
+// UnpackEventLog decodes both the data section and the indexed topics of a log.
+// abi.ParseTopicsIntoMap only accepts indexed arguments, so the non-indexed
+// inputs have to be filtered out before the topics are parsed.
+func UnpackEventLog(parsedABI *abi.ABI, event abi.Event, log *ethtypes.Log) (map[string]interface{}, error) {
+	decoded := make(map[string]interface{})
+	if len(log.Data) > 0 {
+		if err := parsedABI.UnpackIntoMap(decoded, event.Name, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to unpack event data: %w", err)
+		}
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+
+	if len(log.Topics) < len(indexed)+1 {
+		return nil, fmt.Errorf("expected %d indexed topics, got %d", len(indexed), len(log.Topics)-1)
+	}
+
+	if err := abi.ParseTopicsIntoMap(decoded, indexed, log.Topics[1:len(indexed)+1]); err != nil {
+		return nil, fmt.Errorf("failed to parse indexed topics: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// MustParseABI parses an ABI built into the engine, panicking if it's invalid;
+// such ABIs are fixed at compile time
+func MustParseABI(abiJSON string) *abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic("invalid built-in ABI: " + err.Error())
+	}
+	return &parsed
+}
+
+// DecodedAddress returns an address field from decoded event data, or the zero address
+func DecodedAddress(decoded map[string]interface{}, name string) common.Address {
+	address, _ := decoded[name].(common.Address)
+	return address
+}
+
+// DecodedBigInt returns an integer field from decoded event data, or nil
+func DecodedBigInt(decoded map[string]interface{}, name string) *big.Int {
+	value, _ := decoded[name].(*big.Int)
+	return value
+}
+
+// DecodedBool returns a boolean field from decoded event data, or false
+func DecodedBool(decoded map[string]interface{}, name string) bool {
+	value, _ := decoded[name].(bool)
+	return value
+}
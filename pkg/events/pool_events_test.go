@@ -0,0 +1,250 @@
+package events
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPool      = common.HexToAddress("0x1234567890123456789012345678901234567890")
+	testSender    = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testRecipient = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+// newLoadedParser returns a parser with every pool ABI loaded
+func newLoadedParser(t *testing.T) *EventParserImpl {
+	abiManager := NewABIManager()
+	for _, source := range poolEventSources {
+		require.NoError(t, abiManager.LoadABI(source.protocol, source.contractType))
+	}
+	return NewEventParser(abiManager)
+}
+
+// encodeTestLog builds a correctly encoded log for the named event
+func encodeTestLog(t *testing.T, parser *EventParserImpl, protocol interfaces.Protocol, contractType interfaces.ContractType, eventName string, indexed []interface{}, data ...interface{}) *ethtypes.Log {
+	parsedABI, err := parser.getParsedABI(protocol, contractType)
+	require.NoError(t, err)
+
+	event, exists := parsedABI.Events[eventName]
+	require.True(t, exists, "event %s not in %s ABI", eventName, protocol.String())
+
+	encoded, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+
+	// abi.MakeTopics drops the sign of negative integers, so topics are encoded here
+	topics := []common.Hash{event.ID}
+	for _, value := range indexed {
+		switch v := value.(type) {
+		case common.Address:
+			topics = append(topics, common.BytesToHash(v.Bytes()))
+		case *big.Int:
+			topics = append(topics, common.BytesToHash(math.U256Bytes(new(big.Int).Set(v))))
+		default:
+			t.Fatalf("unsupported indexed value %T", value)
+		}
+	}
+
+	return &ethtypes.Log{
+		Address:     testPool,
+		Topics:      topics,
+		Data:        encoded,
+		TxHash:      common.HexToHash("0xabc"),
+		BlockNumber: 12345,
+		Index:       3,
+	}
+}
+
+func TestDecodeSwapEvent_EncodedUniswapV2(t *testing.T) {
+	parser := newLoadedParser(t)
+
+	log := encodeTestLog(t, parser, interfaces.ProtocolUniswapV2, interfaces.ContractTypePair, "Swap",
+		[]interface{}{testSender, testRecipient},
+		big.NewInt(1000), big.NewInt(0), big.NewInt(0), big.NewInt(2000))
+
+	swapEvent, err := parser.DecodeSwapEvent(context.Background(), log)
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ProtocolUniswapV2, swapEvent.Protocol)
+	assert.Equal(t, testSender, swapEvent.Sender)
+	assert.Equal(t, testRecipient, swapEvent.Recipient)
+	assert.Equal(t, big.NewInt(1000), swapEvent.AmountIn)
+	assert.Equal(t, big.NewInt(2000), swapEvent.AmountOut)
+}
+
+func TestParseEventLogs_PoolEvents(t *testing.T) {
+	parser := newLoadedParser(t)
+	tickLower := big.NewInt(-887220)
+	tickUpper := big.NewInt(887220)
+
+	tests := []struct {
+		name         string
+		log          *ethtypes.Log
+		protocol     interfaces.Protocol
+		eventType    interfaces.EventType
+		verifyResult func(t *testing.T, event *interfaces.ParsedEvent)
+	}{
+		{
+			name: "UniswapV2 Mint",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV2, interfaces.ContractTypePair, "Mint",
+				[]interface{}{testSender}, big.NewInt(10), big.NewInt(20)),
+			protocol:  interfaces.ProtocolUniswapV2,
+			eventType: interfaces.EventTypeMint,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.LiquidityEvent)
+				assert.Equal(t, testSender, event.LiquidityEvent.Sender)
+				assert.Equal(t, big.NewInt(10), event.LiquidityEvent.Amount0)
+				assert.Equal(t, big.NewInt(20), event.LiquidityEvent.Amount1)
+			},
+		},
+		{
+			name: "UniswapV2 Burn",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV2, interfaces.ContractTypePair, "Burn",
+				[]interface{}{testSender, testRecipient}, big.NewInt(10), big.NewInt(20)),
+			protocol:  interfaces.ProtocolUniswapV2,
+			eventType: interfaces.EventTypeBurn,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.LiquidityEvent)
+				assert.Equal(t, testRecipient, event.LiquidityEvent.Recipient)
+				assert.Equal(t, big.NewInt(20), event.LiquidityEvent.Amount1)
+			},
+		},
+		{
+			name: "UniswapV2 Sync",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV2, interfaces.ContractTypePair, "Sync",
+				nil, big.NewInt(5000), big.NewInt(7000)),
+			protocol:  interfaces.ProtocolUniswapV2,
+			eventType: interfaces.EventTypeSync,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.SyncEvent)
+				assert.Equal(t, big.NewInt(5000), event.SyncEvent.Reserve0)
+				assert.Equal(t, big.NewInt(7000), event.SyncEvent.Reserve1)
+			},
+		},
+		{
+			name: "UniswapV3 Mint",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV3, interfaces.ContractTypePool, "Mint",
+				[]interface{}{testRecipient, tickLower, tickUpper},
+				testSender, big.NewInt(1e6), big.NewInt(10), big.NewInt(20)),
+			protocol:  interfaces.ProtocolUniswapV3,
+			eventType: interfaces.EventTypeMint,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.LiquidityEvent)
+				assert.Equal(t, testSender, event.LiquidityEvent.Sender)
+				assert.Equal(t, testRecipient, event.LiquidityEvent.Owner)
+				assert.Equal(t, tickLower, event.LiquidityEvent.TickLower)
+				assert.Equal(t, tickUpper, event.LiquidityEvent.TickUpper)
+				assert.Equal(t, big.NewInt(1e6), event.LiquidityEvent.Liquidity)
+			},
+		},
+		{
+			name: "UniswapV3 Burn",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV3, interfaces.ContractTypePool, "Burn",
+				[]interface{}{testRecipient, tickLower, tickUpper},
+				big.NewInt(1e6), big.NewInt(10), big.NewInt(20)),
+			protocol:  interfaces.ProtocolUniswapV3,
+			eventType: interfaces.EventTypeBurn,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.LiquidityEvent)
+				assert.Equal(t, testRecipient, event.LiquidityEvent.Owner)
+				assert.Equal(t, big.NewInt(1e6), event.LiquidityEvent.Liquidity)
+			},
+		},
+		{
+			name: "UniswapV3 Collect",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV3, interfaces.ContractTypePool, "Collect",
+				[]interface{}{testSender, tickLower, tickUpper},
+				testRecipient, big.NewInt(3), big.NewInt(4)),
+			protocol:  interfaces.ProtocolUniswapV3,
+			eventType: interfaces.EventTypeCollect,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.CollectEvent)
+				assert.Equal(t, testSender, event.CollectEvent.Owner)
+				assert.Equal(t, testRecipient, event.CollectEvent.Recipient)
+				assert.Equal(t, big.NewInt(3), event.CollectEvent.Amount0)
+			},
+		},
+		{
+			name: "UniswapV3 Flash",
+			log: encodeTestLog(t, parser, interfaces.ProtocolUniswapV3, interfaces.ContractTypePool, "Flash",
+				[]interface{}{testSender, testRecipient},
+				big.NewInt(100), big.NewInt(0), big.NewInt(1), big.NewInt(0)),
+			protocol:  interfaces.ProtocolUniswapV3,
+			eventType: interfaces.EventTypeFlash,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.FlashEvent)
+				assert.Equal(t, big.NewInt(100), event.FlashEvent.Amount0)
+				assert.Equal(t, big.NewInt(1), event.FlashEvent.Paid0)
+			},
+		},
+		{
+			name: "Aerodrome Sync",
+			log: encodeTestLog(t, parser, interfaces.ProtocolAerodrome, interfaces.ContractTypePair, "Sync",
+				nil, big.NewInt(5000), big.NewInt(7000)),
+			protocol:  interfaces.ProtocolAerodrome,
+			eventType: interfaces.EventTypeSync,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.SyncEvent)
+				assert.Equal(t, big.NewInt(7000), event.SyncEvent.Reserve1)
+			},
+		},
+		{
+			name: "Aerodrome Fees",
+			log: encodeTestLog(t, parser, interfaces.ProtocolAerodrome, interfaces.ContractTypePair, "Fees",
+				[]interface{}{testSender}, big.NewInt(3), big.NewInt(4)),
+			protocol:  interfaces.ProtocolAerodrome,
+			eventType: interfaces.EventTypeFees,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.FeeEvent)
+				assert.Equal(t, testSender, event.FeeEvent.Sender)
+				assert.Equal(t, big.NewInt(4), event.FeeEvent.Amount1)
+			},
+		},
+		{
+			name: "Aerodrome Claim",
+			log: encodeTestLog(t, parser, interfaces.ProtocolAerodrome, interfaces.ContractTypePair, "Claim",
+				[]interface{}{testSender, testRecipient}, big.NewInt(3), big.NewInt(4)),
+			protocol:  interfaces.ProtocolAerodrome,
+			eventType: interfaces.EventTypeClaim,
+			verifyResult: func(t *testing.T, event *interfaces.ParsedEvent) {
+				require.NotNil(t, event.FeeEvent)
+				assert.Equal(t, testRecipient, event.FeeEvent.Recipient)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := parser.ParseEventLogs(context.Background(), []*ethtypes.Log{tt.log})
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+
+			event := events[0]
+			assert.Equal(t, tt.protocol, event.Protocol)
+			assert.Equal(t, tt.eventType, event.EventType)
+			assert.Equal(t, testPool, event.Address)
+			assert.Equal(t, uint64(12345), event.BlockNumber)
+			assert.Nil(t, event.SwapEvent)
+			tt.verifyResult(t, event)
+		})
+	}
+}
+
+func TestDecodePoolEvent_TruncatedTopics(t *testing.T) {
+	parser := newLoadedParser(t)
+
+	log := encodeTestLog(t, parser, interfaces.ProtocolUniswapV3, interfaces.ContractTypePool, "Flash",
+		[]interface{}{testSender, testRecipient},
+		big.NewInt(100), big.NewInt(0), big.NewInt(1), big.NewInt(0))
+	log.Topics = log.Topics[:2]
+
+	parsedEvent, err := parser.decodePoolEvent(context.Background(), log)
+	assert.Error(t, err)
+	assert.Nil(t, parsedEvent)
+}
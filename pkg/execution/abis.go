@@ -0,0 +1,129 @@
+package execution
+
+import "github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+
+// executorABI is our executor contract. execute makes each call from the contract
+// in order, then reverts if the block is past the deadline or the calls left it
+// with less than minAmountOut of tokenOut more than it started with, counting
+// amountIn of tokenIn as spent. flashExecute borrows amount of token from a lender
+// (0 Balancer Vault, 1 Aave V3 pool, 2 Uniswap V3 pool, 3 Morpho), makes the
+// execute call encoded in data from the lender's callback and repays the loan,
+// reverting if the lender charges more than maxFee. The contract pays Uniswap V3
+// mint callbacks from its balance, so it can mint positions it owns.
+const executorABI = `[
+	{
+		"inputs": [
+			{"name": "tokenIn", "type": "address"},
+			{"name": "amountIn", "type": "uint256"},
+			{"name": "tokenOut", "type": "address"},
+			{"name": "minAmountOut", "type": "uint256"},
+			{"name": "deadline", "type": "uint256"},
+			{
+				"name": "calls",
+				"type": "tuple[]",
+				"components": [
+					{"name": "target", "type": "address"},
+					{"name": "value", "type": "uint256"},
+					{"name": "data", "type": "bytes"}
+				]
+			}
+		],
+		"name": "execute",
+		"outputs": [{"name": "amountOut", "type": "uint256"}],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "source", "type": "uint8"},
+			{"name": "lender", "type": "address"},
+			{"name": "token", "type": "address"},
+			{"name": "amount", "type": "uint256"},
+			{"name": "maxFee", "type": "uint256"},
+			{"name": "data", "type": "bytes"}
+		],
+		"name": "flashExecute",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// aavePoolABI is the Aave V3 pool's reserve data, flattened; the aToken holds the
+// reserve's lendable liquidity
+const aavePoolABI = `[
+	{
+		"inputs": [{"name": "asset", "type": "address"}],
+		"name": "getReserveData",
+		"outputs": [
+			{"name": "configuration", "type": "uint256"},
+			{"name": "liquidityIndex", "type": "uint128"},
+			{"name": "currentLiquidityRate", "type": "uint128"},
+			{"name": "variableBorrowIndex", "type": "uint128"},
+			{"name": "currentVariableBorrowRate", "type": "uint128"},
+			{"name": "currentStableBorrowRate", "type": "uint128"},
+			{"name": "lastUpdateTimestamp", "type": "uint40"},
+			{"name": "id", "type": "uint16"},
+			{"name": "aTokenAddress", "type": "address"},
+			{"name": "stableDebtTokenAddress", "type": "address"},
+			{"name": "variableDebtTokenAddress", "type": "address"},
+			{"name": "interestRateStrategyAddress", "type": "address"},
+			{"name": "accruedToTreasury", "type": "uint128"},
+			{"name": "unbacked", "type": "uint128"},
+			{"name": "isolationModeTotalDebt", "type": "uint128"}
+		],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+// erc20ABI is the ERC-20 approval the executor grants routers, the balance
+// profits are measured from and the transfers positions are tracked from
+const erc20ABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "value", "type": "uint256"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"inputs": [{"name": "account", "type": "address"}],
+		"name": "balanceOf",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "spender", "type": "address"},
+			{"name": "amount", "type": "uint256"}
+		],
+		"name": "approve",
+		"outputs": [{"name": "", "type": "bool"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// gasPriceOracleABI is the OP Stack GasPriceOracle predeploy's L1 data fee for an
+// unsigned transaction's RLP encoding
+const gasPriceOracleABI = `[
+	{
+		"inputs": [{"name": "_data", "type": "bytes"}],
+		"name": "getL1Fee",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "view",
+		"type": "function"
+	}
+]`
+
+var (
+	executorContract = events.MustParseABI(executorABI)
+	erc20            = events.MustParseABI(erc20ABI)
+	aavePool         = events.MustParseABI(aavePoolABI)
+	gasPriceOracle   = events.MustParseABI(gasPriceOracleABI)
+)
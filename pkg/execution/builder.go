@@ -0,0 +1,435 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// ChainClient reads the contract state and account nonces transactions are built on
+type ChainClient interface {
+	pricing.ContractCaller
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// executorCall is one call the executor contract makes, as its ABI tuple
+type executorCall struct {
+	Target common.Address
+	Value  *big.Int
+	Data   []byte
+}
+
+// DefaultTransactionBuilderConfig returns the default transaction builder configuration
+func DefaultTransactionBuilderConfig() *interfaces.TransactionBuilderConfig {
+	return &interfaces.TransactionBuilderConfig{
+		Deadline:       30 * time.Second,
+		MaxSlippageBps: 50,
+		BaseGas:        80000,
+		GasPerHop:      130000,
+	}
+}
+
+// transactionBuilder implements the TransactionBuilder interface
+type transactionBuilder struct {
+	config      *interfaces.TransactionBuilderConfig
+	adapters    interfaces.ProtocolAdapterRegistry
+	pools       interfaces.PoolRegistry
+	chain       ChainClient
+	forkManager interfaces.ForkManager
+}
+
+// NewTransactionBuilder creates a transaction builder. Pools are quoted from chain
+// state; hops without a protocol are looked up in the pool registry, which may be
+// nil. Simulate needs a fork manager.
+func NewTransactionBuilder(config *interfaces.TransactionBuilderConfig, adapters interfaces.ProtocolAdapterRegistry, pools interfaces.PoolRegistry, chain ChainClient, forkManager interfaces.ForkManager) interfaces.TransactionBuilder {
+	if config == nil {
+		config = DefaultTransactionBuilderConfig()
+	}
+	return &transactionBuilder{
+		config:      config,
+		adapters:    adapters,
+		pools:       pools,
+		chain:       chain,
+		forkManager: forkManager,
+	}
+}
+
+// Searcher returns the account that sends built transactions
+func (b *transactionBuilder) Searcher() common.Address {
+	return b.config.Searcher
+}
+
+// Executor returns the contract built transactions call
+func (b *transactionBuilder) Executor() common.Address {
+	return b.config.Executor
+}
+
+// BuildSwaps encodes each route as a call to the executor contract. A route without
+// an amount sells what the previous route is quoted to return, so a sandwich's back
+// leg sells the front leg's output.
+func (b *transactionBuilder) BuildSwaps(ctx context.Context, routes []*interfaces.SwapRoute, chainID *big.Int) ([]*types.Transaction, error) {
+	if len(routes) == 0 {
+		return nil, errors.New("no routes to build")
+	}
+	nonce, err := b.pendingNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := big.NewInt(time.Now().Add(b.config.Deadline).Unix())
+	transactions := make([]*types.Transaction, 0, len(routes))
+	var previousOut *big.Int
+	for i, route := range routes {
+		if route == nil || len(route.Hops) == 0 {
+			return nil, fmt.Errorf("route %d has no hops", i)
+		}
+
+		amountIn := route.AmountIn
+		if amountIn == nil {
+			if previousOut == nil {
+				return nil, fmt.Errorf("route %d has no amount and follows no route", i)
+			}
+			amountIn = previousOut
+		}
+
+		data, value, quotedOut, err := b.encodeRoute(ctx, route, amountIn, deadline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build route %d: %w", i, err)
+		}
+		previousOut = quotedOut
+
+		gasLimit := b.config.BaseGas + b.config.GasPerHop*uint64(len(route.Hops))
+		transactions = append(transactions, b.transaction(nonce+uint64(i), data, value, route.GasPrice, gasLimit, chainID))
+	}
+
+	return transactions, nil
+}
+
+// BuildCalls encodes each batch as a call to the executor contract, approving the
+// spender first and selling through the batch's route last. A batch with a flash
+// loan is wrapped in flashExecute, so its calls run with the loan and must leave
+// enough to repay it.
+func (b *transactionBuilder) BuildCalls(ctx context.Context, batches []*interfaces.CallBatch, chainID *big.Int) ([]*types.Transaction, error) {
+	if len(batches) == 0 {
+		return nil, errors.New("no calls to build")
+	}
+	nonce, err := b.pendingNonce(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := big.NewInt(time.Now().Add(b.config.Deadline).Unix())
+	transactions := make([]*types.Transaction, 0, len(batches))
+	for i, batch := range batches {
+		if batch == nil || len(batch.Calls) == 0 {
+			return nil, fmt.Errorf("batch %d has no calls", i)
+		}
+
+		data, value, err := b.encodeBatch(ctx, batch, deadline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build batch %d: %w", i, err)
+		}
+
+		gasLimit := b.config.BaseGas + batch.GasLimit
+		if batch.Sell != nil {
+			gasLimit += b.config.GasPerHop * uint64(len(batch.Sell.Hops))
+		}
+		if batch.FlashLoan != nil {
+			gasLimit += batch.FlashLoan.GasOverhead
+		}
+		transactions = append(transactions, b.transaction(nonce+uint64(i), data, value, batch.GasPrice, gasLimit, chainID))
+	}
+
+	return transactions, nil
+}
+
+// pendingNonce checks the builder is configured and reads the searcher's pending nonce
+func (b *transactionBuilder) pendingNonce(ctx context.Context) (uint64, error) {
+	if b.config.Searcher == (common.Address{}) || b.config.Executor == (common.Address{}) {
+		return 0, errors.New("searcher and executor addresses must be configured")
+	}
+	nonce, err := b.chain.PendingNonceAt(ctx, b.config.Searcher)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read nonce of %s: %w", b.config.Searcher.Hex(), err)
+	}
+	return nonce, nil
+}
+
+// transaction is a call to the executor contract from the searcher
+func (b *transactionBuilder) transaction(nonce uint64, data []byte, value, gasPrice *big.Int, gasLimit uint64, chainID *big.Int) *types.Transaction {
+	executor := b.config.Executor
+	return &types.Transaction{
+		From:      b.config.Searcher,
+		To:        &executor,
+		Value:     value,
+		GasPrice:  new(big.Int).Set(bigOrZero(gasPrice)),
+		GasLimit:  gasLimit,
+		Nonce:     nonce,
+		Data:      data,
+		Timestamp: time.Now(),
+		ChainID:   chainID,
+	}
+}
+
+// encodeBatch encodes a batch's execute call, wrapped in flashExecute if it borrows,
+// and returns it with the ETH its calls send
+func (b *transactionBuilder) encodeBatch(ctx context.Context, batch *interfaces.CallBatch, deadline *big.Int) ([]byte, *big.Int, error) {
+	amountIn := bigOrZero(batch.AmountIn)
+	calls := make([]executorCall, 0, len(batch.Calls)+1)
+	value := new(big.Int)
+
+	if batch.Spender != (common.Address{}) {
+		approve, err := erc20.Pack("approve", batch.Spender, amountIn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode approval: %w", err)
+		}
+		calls = append(calls, executorCall{Target: batch.TokenIn, Value: big.NewInt(0), Data: approve})
+	}
+	for _, call := range batch.Calls {
+		callValue := bigOrZero(call.Value)
+		calls = append(calls, executorCall{Target: call.To, Value: callValue, Data: call.Data})
+		value.Add(value, callValue)
+	}
+	if batch.Sell != nil {
+		if batch.Sell.AmountIn == nil || len(batch.Sell.Hops) == 0 {
+			return nil, nil, errors.New("the sale after the calls needs hops and an amount")
+		}
+		sellCalls, sellValue, _, _, _, err := b.routeCalls(ctx, batch.Sell, batch.Sell.AmountIn, deadline)
+		if err != nil {
+			return nil, nil, err
+		}
+		calls = append(calls, sellCalls...)
+		value.Add(value, sellValue)
+	}
+
+	data, err := executorContract.Pack("execute", batch.TokenIn, amountIn, batch.TokenOut, bigOrZero(batch.MinAmountOut), deadline, calls)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode executor call: %w", err)
+	}
+	if batch.FlashLoan == nil {
+		return data, value, nil
+	}
+
+	// flashExecute isn't payable, so borrowed calls can't send ETH
+	loan := batch.FlashLoan
+	source, known := flashLoanSources[interfaces.FlashLoanSource(loan.Provider)]
+	if !known {
+		return nil, nil, fmt.Errorf("unknown flash loan source %q", loan.Provider)
+	}
+	if value.Sign() != 0 {
+		return nil, nil, errors.New("calls inside a flash loan can't send ETH")
+	}
+	data, err = executorContract.Pack("flashExecute", source, loan.Pool, loan.Asset, loan.Amount, bigOrZero(loan.Fee), data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode flashExecute: %w", err)
+	}
+	return data, value, nil
+}
+
+// encodeRoute quotes each hop and encodes the executor call approving and swapping
+// through them. It returns the calldata, the ETH the swaps need and the quoted output.
+func (b *transactionBuilder) encodeRoute(ctx context.Context, route *interfaces.SwapRoute, amountIn *big.Int, deadline *big.Int) ([]byte, *big.Int, *big.Int, error) {
+	calls, value, routeIn, routeOut, amountOut, err := b.routeCalls(ctx, route, amountIn, deadline)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	data, err := executorContract.Pack("execute", routeIn, amountIn, routeOut, b.minAmountOut(route, amountOut), deadline, calls)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to encode executor call: %w", err)
+	}
+	return data, value, amountOut, nil
+}
+
+// routeCalls quotes each hop on its pool's state and returns the calls approving and
+// swapping through them, the ETH they need, the route's input and output tokens and
+// the quoted output
+func (b *transactionBuilder) routeCalls(ctx context.Context, route *interfaces.SwapRoute, amountIn *big.Int, deadline *big.Int) ([]executorCall, *big.Int, common.Address, common.Address, *big.Int, error) {
+	executor := b.config.Executor
+	calls := make([]executorCall, 0, 2*len(route.Hops))
+	value := new(big.Int)
+	fail := func(err error) ([]executorCall, *big.Int, common.Address, common.Address, *big.Int, error) {
+		return nil, nil, common.Address{}, common.Address{}, nil, err
+	}
+
+	var routeIn, tokenIn common.Address
+	amount := amountIn
+	for i, hop := range route.Hops {
+		adapter, pool, err := b.hopPool(ctx, hop)
+		if err != nil {
+			return fail(err)
+		}
+
+		if hop.TokenIn != (common.Address{}) {
+			tokenIn = hop.TokenIn
+		}
+		if tokenIn == (common.Address{}) {
+			return fail(fmt.Errorf("hop %d through %s has no input token", i, hop.Pool.Hex()))
+		}
+		tokenOut, err := otherToken(pool, tokenIn, hop.TokenOut)
+		if err != nil {
+			return fail(err)
+		}
+		if i == 0 {
+			routeIn = tokenIn
+		}
+
+		out, err := adapter.GetAmountOut(pool, tokenIn, tokenOut, amount)
+		if err != nil {
+			return fail(fmt.Errorf("failed to quote %s: %w", hop.Pool.Hex(), err))
+		}
+
+		// Intermediate hops take no minimum; the route's minimum covers the whole path
+		minOut := big.NewInt(0)
+		if i == len(route.Hops)-1 {
+			minOut = b.minAmountOut(route, out)
+		}
+		swap, err := adapter.EncodeSwap(&interfaces.SwapParams{
+			Pool:         pool,
+			TokenIn:      tokenIn,
+			TokenOut:     tokenOut,
+			AmountIn:     amount,
+			AmountOutMin: minOut,
+			Recipient:    executor,
+			Deadline:     deadline,
+		})
+		if err != nil {
+			return fail(fmt.Errorf("failed to encode swap through %s: %w", hop.Pool.Hex(), err))
+		}
+
+		swapValue := swap.Value
+		if swapValue == nil || swapValue.Sign() == 0 {
+			approve, err := erc20.Pack("approve", swap.To, amount)
+			if err != nil {
+				return fail(fmt.Errorf("failed to encode approval: %w", err))
+			}
+			calls = append(calls, executorCall{Target: tokenIn, Value: big.NewInt(0), Data: approve})
+			swapValue = big.NewInt(0)
+		}
+		calls = append(calls, executorCall{Target: swap.To, Value: swapValue, Data: swap.Data})
+		value.Add(value, swapValue)
+
+		tokenIn, amount = tokenOut, out
+	}
+
+	return calls, value, routeIn, tokenIn, amount, nil
+}
+
+// hopPool resolves a hop's adapter and reads its pool state, unless the hop carries it
+func (b *transactionBuilder) hopPool(ctx context.Context, hop interfaces.SwapHop) (interfaces.ProtocolAdapter, *interfaces.PoolState, error) {
+	protocol := hop.Protocol
+	if protocol == interfaces.ProtocolUnknown && hop.State != nil {
+		protocol = hop.State.Protocol
+	}
+	if protocol == interfaces.ProtocolUnknown && b.pools != nil {
+		if info, exists := b.pools.GetPool(hop.Pool); exists {
+			protocol = info.Protocol
+		}
+	}
+	if protocol == interfaces.ProtocolUnknown {
+		return nil, nil, fmt.Errorf("unknown pool %s", hop.Pool.Hex())
+	}
+
+	adapter, exists := b.adapters.Adapter(protocol)
+	if !exists {
+		return nil, nil, fmt.Errorf("no adapter for %s", protocol)
+	}
+
+	if hop.State != nil {
+		return adapter, hop.State, nil
+	}
+	pool, err := pricing.ReadPoolState(ctx, b.chain, hop.Pool, protocol)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read pool %s: %w", hop.Pool.Hex(), err)
+	}
+	return adapter, pool, nil
+}
+
+// minAmountOut returns a route's minimum output, defaulting to the quote less the
+// slippage tolerance
+func (b *transactionBuilder) minAmountOut(route *interfaces.SwapRoute, quoted *big.Int) *big.Int {
+	if route.MinAmountOut != nil {
+		return route.MinAmountOut
+	}
+	minOut := new(big.Int).Mul(quoted, big.NewInt(int64(10000-int(b.config.MaxSlippageBps))))
+	return minOut.Div(minOut, big.NewInt(10000))
+}
+
+// Simulate executes a bundle in order on one fork and fails on the first revert
+func (b *transactionBuilder) Simulate(ctx context.Context, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	if b.forkManager == nil {
+		return nil, errors.New("no fork manager to simulate on")
+	}
+
+	fork, err := b.forkManager.GetAvailableFork(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork: %w", err)
+	}
+	defer b.forkManager.ReleaseFork(fork)
+	return simulateOn(ctx, fork, bundle)
+}
+
+// SimulateAt executes a bundle in order on a fork pinned to the state after a block,
+// and fails on the first revert. The fork is moved back to the head once done.
+func (b *transactionBuilder) SimulateAt(ctx context.Context, bundle []*types.Transaction, blockNumber uint64) ([]*interfaces.SimulationResult, error) {
+	if b.forkManager == nil {
+		return nil, errors.New("no fork manager to simulate on")
+	}
+
+	fork, err := b.forkManager.GetAvailableFork(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork: %w", err)
+	}
+	defer func() {
+		_ = fork.Reset()
+		_ = b.forkManager.ReleaseFork(fork)
+	}()
+	if err := fork.ResetToBlock(blockNumber); err != nil {
+		return nil, fmt.Errorf("failed to pin fork to block %d: %w", blockNumber, err)
+	}
+	return simulateOn(ctx, fork, bundle)
+}
+
+// simulateOn executes a bundle in order on a fork and fails on the first revert
+func simulateOn(ctx context.Context, fork interfaces.Fork, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	results := make([]*interfaces.SimulationResult, 0, len(bundle))
+	for i, tx := range bundle {
+		result, err := fork.ExecuteTransaction(ctx, tx)
+		if err != nil {
+			return results, fmt.Errorf("failed to execute transaction %d: %w", i, err)
+		}
+		results = append(results, result)
+		if !result.Success {
+			return results, fmt.Errorf("transaction %d reverted: %v", i, result.Error)
+		}
+	}
+	return results, nil
+}
+
+// otherToken checks a hop's tokens against its pool, defaulting the output to the
+// pool's other token
+func otherToken(pool *interfaces.PoolState, tokenIn, tokenOut common.Address) (common.Address, error) {
+	if pool.TokenIndex(tokenIn) < 0 {
+		return common.Address{}, fmt.Errorf("pool %s doesn't trade %s", pool.Address.Hex(), tokenIn.Hex())
+	}
+	if tokenOut != (common.Address{}) {
+		if pool.TokenIndex(tokenOut) < 0 {
+			return common.Address{}, fmt.Errorf("pool %s doesn't trade %s", pool.Address.Hex(), tokenOut.Hex())
+		}
+		return tokenOut, nil
+	}
+	if len(pool.Tokens) != 2 {
+		return common.Address{}, fmt.Errorf("pool %s has %d tokens; the output token is required", pool.Address.Hex(), len(pool.Tokens))
+	}
+	if pool.Tokens[0] == tokenIn {
+		return pool.Tokens[1], nil
+	}
+	return pool.Tokens[0], nil
+}
@@ -0,0 +1,464 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSearcher = common.HexToAddress("0x5ea4c4e45ea4c4e45ea4c4e45ea4c4e45ea4c4e4")
+	testExecutor = common.HexToAddress("0xe4ec07004e4ec07004e4ec07004e4ec07004e4ec")
+	testRouter   = common.HexToAddress("0x6BDED42c6DA8FBf0d2bA55B2fa120C5e0c8D7891")
+	testWETH     = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	testUSDC     = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	testDAI      = common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb")
+	testPoolA    = common.HexToAddress("0x000000000000000000000000000000000000a001") // WETH/USDC
+	testPoolB    = common.HexToAddress("0x000000000000000000000000000000000000b002") // USDC/DAI
+)
+
+// v2PoolABI answers the getters ReadPoolState calls on V2 pairs
+var v2PoolABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"name": "token0", "type": "function", "inputs": [], "outputs": [{"type": "address"}]},
+		{"name": "token1", "type": "function", "inputs": [], "outputs": [{"type": "address"}]},
+		{"name": "getReserves", "type": "function", "inputs": [], "outputs": [{"type": "uint112"}, {"type": "uint112"}, {"type": "uint32"}]}
+	]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+type fakePair struct {
+	token0, token1     common.Address
+	reserve0, reserve1 *big.Int
+}
+
+// fakeChain serves V2 pair state and the searcher's pending nonce
+type fakeChain struct {
+	pairs map[common.Address]*fakePair
+	nonce uint64
+}
+
+func (f *fakeChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	pair, exists := f.pairs[*call.To]
+	if !exists {
+		return nil, errors.New("execution reverted")
+	}
+	method, err := v2PoolABI.MethodById(call.Data)
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "token0":
+		return method.Outputs.Pack(pair.token0)
+	case "token1":
+		return method.Outputs.Pack(pair.token1)
+	default:
+		return method.Outputs.Pack(pair.reserve0, pair.reserve1, uint32(0))
+	}
+}
+
+func (f *fakeChain) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	if account != testSearcher {
+		return 0, nil
+	}
+	return f.nonce, nil
+}
+
+// fakeFork accepts executor calls from the searcher in nonce order with a live deadline
+type fakeFork struct {
+	nonce    uint64
+	executed []*types.Transaction
+	pinned   []uint64 // Blocks it was reset to
+	resets   int
+}
+
+func (f *fakeFork) ExecuteTransaction(ctx context.Context, tx *types.Transaction) (*interfaces.SimulationResult, error) {
+	f.executed = append(f.executed, tx)
+	if tx.From != testSearcher {
+		return &interfaces.SimulationResult{Success: true}, nil
+	}
+
+	fail := func(format string, args ...interface{}) (*interfaces.SimulationResult, error) {
+		return &interfaces.SimulationResult{Error: fmt.Errorf(format, args...)}, nil
+	}
+	if tx.Nonce != f.nonce {
+		return fail("nonce too high: %d, expected %d", tx.Nonce, f.nonce)
+	}
+	f.nonce++
+	if tx.To == nil || *tx.To != testExecutor {
+		return fail("not an executor call")
+	}
+	args, err := executorContract.Methods["execute"].Inputs.Unpack(tx.Data[4:])
+	if err != nil {
+		return fail("bad calldata: %v", err)
+	}
+	if args[4].(*big.Int).Int64() < time.Now().Unix() {
+		return fail("deadline passed")
+	}
+	return &interfaces.SimulationResult{Success: true, GasUsed: tx.GasLimit / 2}, nil
+}
+
+func (f *fakeFork) GetID() string                                       { return "fake" }
+func (f *fakeFork) GetBlockNumber() (*big.Int, error)                   { return big.NewInt(1), nil }
+func (f *fakeFork) GetBalance(address common.Address) (*big.Int, error) { return big.NewInt(0), nil }
+func (f *fakeFork) Reset() error                                        { f.resets++; return nil }
+func (f *fakeFork) Close() error                                        { return nil }
+func (f *fakeFork) IsHealthy() bool                                     { return true }
+
+func (f *fakeFork) ResetToBlock(blockNumber uint64) error {
+	f.pinned = append(f.pinned, blockNumber)
+	return nil
+}
+
+type fakeForkManager struct {
+	fork     *fakeFork
+	released int
+}
+
+func (m *fakeForkManager) CreateFork(ctx context.Context, forkURL string) (interfaces.Fork, error) {
+	return m.fork, nil
+}
+func (m *fakeForkManager) GetAvailableFork(ctx context.Context) (interfaces.Fork, error) {
+	return m.fork, nil
+}
+func (m *fakeForkManager) ReleaseFork(fork interfaces.Fork) error {
+	m.released++
+	return nil
+}
+func (m *fakeForkManager) CleanupForks() error { return nil }
+func (m *fakeForkManager) GetForkPoolStats() interfaces.ForkPoolStats {
+	return interfaces.ForkPoolStats{}
+}
+
+// newTestBuilder creates a builder over a WETH/USDC and a USDC/DAI SushiSwap pair
+func newTestBuilder(t *testing.T, nonce uint64) (interfaces.TransactionBuilder, *fakeForkManager) {
+	t.Helper()
+
+	adapters := protocols.NewRegistry(nil)
+	require.NoError(t, adapters.Register(protocols.NewSushiSwapV2Adapter(testRouter)))
+
+	chain := &fakeChain{
+		nonce: nonce,
+		pairs: map[common.Address]*fakePair{
+			testPoolA: {token0: testWETH, token1: testUSDC, reserve0: units(1000, 18), reserve1: units(3000000, 6)},
+			testPoolB: {token0: testUSDC, token1: testDAI, reserve0: units(5000000, 6), reserve1: units(5000000, 18)},
+		},
+	}
+	forks := &fakeForkManager{fork: &fakeFork{nonce: nonce}}
+
+	config := DefaultTransactionBuilderConfig()
+	config.Searcher = testSearcher
+	config.Executor = testExecutor
+	return NewTransactionBuilder(config, adapters, nil, chain, forks), forks
+}
+
+// units scales a whole token amount to raw units
+func units(amount int64, decimals int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))
+}
+
+// v2Quote is the SushiSwap V2 output for a swap against reserves
+func v2Quote(amountIn, reserveIn, reserveOut *big.Int) *big.Int {
+	in := new(big.Int).Mul(amountIn, big.NewInt(997))
+	out := new(big.Int).Mul(in, reserveOut)
+	return out.Div(out, new(big.Int).Add(new(big.Int).Mul(reserveIn, big.NewInt(1000)), in))
+}
+
+// unpackExecute decodes an executor call
+func unpackExecute(t *testing.T, data []byte) []interface{} {
+	t.Helper()
+	method, err := executorContract.MethodById(data)
+	require.NoError(t, err)
+	require.Equal(t, "execute", method.Name)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	return args
+}
+
+func TestTransactionBuilder_BuildSwaps(t *testing.T) {
+	builder, _ := newTestBuilder(t, 7)
+	amountIn := big.NewInt(1e18)
+	routes := []*interfaces.SwapRoute{
+		{
+			Hops: []interfaces.SwapHop{
+				{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH},
+				{Pool: testPoolB, Protocol: interfaces.ProtocolSushiSwapV2, TokenOut: testDAI},
+			},
+			AmountIn: amountIn,
+			GasPrice: big.NewInt(2e9),
+		},
+		{
+			Hops:         []interfaces.SwapHop{{Pool: testPoolB, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testDAI, TokenOut: testUSDC}},
+			MinAmountOut: big.NewInt(1),
+			GasPrice:     big.NewInt(1e9),
+		},
+	}
+
+	txs, err := builder.BuildSwaps(context.Background(), routes, big.NewInt(8453))
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	for i, tx := range txs {
+		assert.Equal(t, testSearcher, tx.From)
+		assert.Equal(t, testExecutor, *tx.To)
+		assert.Equal(t, uint64(7+i), tx.Nonce, "nonces follow the pending nonce")
+		assert.Equal(t, big.NewInt(8453), tx.ChainID)
+	}
+	assert.Equal(t, big.NewInt(2e9), txs[0].GasPrice)
+	assert.Equal(t, uint64(80000+2*130000), txs[0].GasLimit)
+
+	usdcOut := v2Quote(amountIn, units(1000, 18), units(3000000, 6))
+	daiOut := v2Quote(usdcOut, units(5000000, 6), units(5000000, 18))
+
+	args := unpackExecute(t, txs[0].Data)
+	assert.Equal(t, testWETH, args[0])
+	assert.Equal(t, amountIn, args[1])
+	assert.Equal(t, testDAI, args[2])
+	// Without a minimum, the quote less 50 bps
+	expectedMin := new(big.Int).Div(new(big.Int).Mul(daiOut, big.NewInt(9950)), big.NewInt(10000))
+	assert.Equal(t, expectedMin, args[3])
+	assert.Greater(t, args[4].(*big.Int).Int64(), time.Now().Unix())
+
+	// Each hop approves the router for its input, then swaps into the executor
+	calls := args[5].([]struct {
+		Target common.Address `json:"target"`
+		Value  *big.Int       `json:"value"`
+		Data   []byte         `json:"data"`
+	})
+	require.Len(t, calls, 4)
+	assert.Equal(t, []common.Address{testWETH, testRouter, testUSDC, testRouter},
+		[]common.Address{calls[0].Target, calls[1].Target, calls[2].Target, calls[3].Target})
+	approval, err := erc20.Methods["approve"].Inputs.Unpack(calls[2].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{testRouter, usdcOut}, approval)
+
+	// The second route sells the first route's quoted output
+	args = unpackExecute(t, txs[1].Data)
+	assert.Equal(t, testDAI, args[0])
+	assert.Equal(t, daiOut, args[1])
+	assert.Equal(t, big.NewInt(1), args[3])
+}
+
+func TestTransactionBuilder_BuildSwapsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		routes []*interfaces.SwapRoute
+		errMsg string
+	}{
+		{
+			name:   "no routes",
+			errMsg: "no routes",
+		},
+		{
+			name:   "first route without an amount",
+			routes: []*interfaces.SwapRoute{{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH}}}},
+			errMsg: "follows no route",
+		},
+		{
+			name:   "pool without a known protocol",
+			routes: []*interfaces.SwapRoute{{Hops: []interfaces.SwapHop{{Pool: testPoolA, TokenIn: testWETH}}, AmountIn: big.NewInt(1e18)}},
+			errMsg: "unknown pool",
+		},
+		{
+			name:   "token the pool doesn't trade",
+			routes: []*interfaces.SwapRoute{{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testDAI}}, AmountIn: big.NewInt(1e18)}},
+			errMsg: "doesn't trade",
+		},
+	}
+
+	builder, _ := newTestBuilder(t, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := builder.BuildSwaps(context.Background(), tt.routes, big.NewInt(8453))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+
+	unconfigured := NewTransactionBuilder(nil, protocols.NewRegistry(nil), nil, &fakeChain{}, nil)
+	_, err := unconfigured.BuildSwaps(context.Background(), tests[1].routes, big.NewInt(8453))
+	assert.ErrorContains(t, err, "must be configured")
+}
+
+func TestTransactionBuilder_BuildSwapsWithPoolState(t *testing.T) {
+	builder, _ := newTestBuilder(t, 0)
+	launched := common.HexToAddress("0x000000000000000000000000000000000000c003")
+	state := &interfaces.PoolState{
+		Protocol: interfaces.ProtocolSushiSwapV2,
+		Address:  launched,
+		Tokens:   []common.Address{testWETH, testDAI},
+		Reserves: []*big.Int{units(10, 18), units(30000, 18)},
+	}
+
+	// A pool the chain doesn't have yet is quoted on the state the hop carries
+	txs, err := builder.BuildSwaps(context.Background(), []*interfaces.SwapRoute{{
+		Hops:     []interfaces.SwapHop{{Pool: launched, TokenIn: testWETH, State: state}},
+		AmountIn: big.NewInt(1e17),
+	}}, big.NewInt(8453))
+	require.NoError(t, err)
+
+	args := unpackExecute(t, txs[0].Data)
+	assert.Equal(t, testDAI, args[2])
+	quoted := v2Quote(big.NewInt(1e17), units(10, 18), units(30000, 18))
+	assert.Equal(t, new(big.Int).Div(new(big.Int).Mul(quoted, big.NewInt(9950)), big.NewInt(10000)), args[3])
+}
+
+func TestTransactionBuilder_BuildCalls(t *testing.T) {
+	builder, _ := newTestBuilder(t, 4)
+	market := common.HexToAddress("0x00000000000000000000000000000000000a4e00")
+	repay := units(3000, 6)
+	seized := big.NewInt(1e18)
+	loan := &interfaces.FlashLoanPlan{
+		Provider:    string(interfaces.FlashLoanAaveV3),
+		Pool:        market,
+		Asset:       testUSDC,
+		Amount:      repay,
+		Fee:         big.NewInt(1500000),
+		GasOverhead: 110000,
+	}
+
+	txs, err := builder.BuildCalls(context.Background(), []*interfaces.CallBatch{
+		{
+			Calls:        []*interfaces.SwapCalldata{{To: market, Data: []byte{0x01}}},
+			Spender:      market,
+			Sell:         &interfaces.SwapRoute{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH}}, AmountIn: seized},
+			TokenIn:      testUSDC,
+			AmountIn:     repay,
+			TokenOut:     testUSDC,
+			MinAmountOut: new(big.Int).Add(repay, loan.Fee),
+			FlashLoan:    loan,
+			GasLimit:     500000,
+			GasPrice:     big.NewInt(1e9),
+		},
+		{
+			Calls:    []*interfaces.SwapCalldata{{To: market, Data: []byte{0x02}, Value: big.NewInt(5)}},
+			GasLimit: 100000,
+		},
+	}, big.NewInt(8453))
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, []uint64{4, 5}, []uint64{txs[0].Nonce, txs[1].Nonce})
+	for _, tx := range txs {
+		assert.Equal(t, testSearcher, tx.From)
+		assert.Equal(t, testExecutor, *tx.To)
+		assert.Equal(t, big.NewInt(8453), tx.ChainID)
+	}
+	assert.Equal(t, uint64(80000+500000+130000+110000), txs[0].GasLimit)
+	assert.Equal(t, big.NewInt(5), txs[1].Value)
+	assert.Equal(t, big.NewInt(0), txs[1].GasPrice)
+
+	// The loan wraps the calls, which approve the market, call it and sell the seized WETH
+	values, err := executorContract.Methods["flashExecute"].Inputs.Unpack(txs[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{uint8(1), market, testUSDC, repay, loan.Fee}, values[:5])
+	args := unpackExecute(t, values[5].([]byte))
+	assert.Equal(t, []interface{}{testUSDC, repay, testUSDC, new(big.Int).Add(repay, loan.Fee)}, args[:4])
+	calls := args[5].([]struct {
+		Target common.Address `json:"target"`
+		Value  *big.Int       `json:"value"`
+		Data   []byte         `json:"data"`
+	})
+	require.Len(t, calls, 4)
+	assert.Equal(t, []common.Address{testUSDC, market, testWETH, testRouter},
+		[]common.Address{calls[0].Target, calls[1].Target, calls[2].Target, calls[3].Target})
+	approval, err := erc20.Methods["approve"].Inputs.Unpack(calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{market, repay}, approval)
+
+	// Batches without bounds check nothing
+	args = unpackExecute(t, txs[1].Data)
+	assert.Equal(t, []interface{}{common.Address{}, common.Address{}}, []interface{}{args[0], args[2]})
+	assert.Zero(t, args[1].(*big.Int).Sign())
+	assert.Zero(t, args[3].(*big.Int).Sign())
+}
+
+func TestTransactionBuilder_BuildCallsErrors(t *testing.T) {
+	call := []*interfaces.SwapCalldata{{To: testPoolA, Data: []byte{0x01}}}
+	loan := &interfaces.FlashLoanPlan{Provider: string(interfaces.FlashLoanBalancer), Pool: BaseBalancerVault, Asset: testWETH, Amount: big.NewInt(1)}
+	tests := []struct {
+		name    string
+		batches []*interfaces.CallBatch
+		errMsg  string
+	}{
+		{name: "no batches", errMsg: "no calls"},
+		{name: "batch without calls", batches: []*interfaces.CallBatch{{}}, errMsg: "has no calls"},
+		{
+			name:    "sale without an amount",
+			batches: []*interfaces.CallBatch{{Calls: call, Sell: &interfaces.SwapRoute{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH}}}}},
+			errMsg:  "needs hops and an amount",
+		},
+		{
+			name:    "unknown lender",
+			batches: []*interfaces.CallBatch{{Calls: call, FlashLoan: &interfaces.FlashLoanPlan{Provider: "compound"}}},
+			errMsg:  "unknown flash loan source",
+		},
+		{
+			name:    "ETH sent inside a loan",
+			batches: []*interfaces.CallBatch{{Calls: []*interfaces.SwapCalldata{{To: testPoolA, Value: big.NewInt(1)}}, FlashLoan: loan}},
+			errMsg:  "can't send ETH",
+		},
+	}
+
+	builder, _ := newTestBuilder(t, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := builder.BuildCalls(context.Background(), tt.batches, big.NewInt(8453))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestTransactionBuilder_Simulate(t *testing.T) {
+	builder, forks := newTestBuilder(t, 3)
+	swaps, err := builder.BuildSwaps(context.Background(), []*interfaces.SwapRoute{
+		{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH}}, AmountIn: big.NewInt(1e18)},
+		{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testUSDC}}, MinAmountOut: big.NewInt(1)},
+	}, big.NewInt(8453))
+	require.NoError(t, err)
+
+	target := &types.Transaction{From: common.HexToAddress("0x7a7a")}
+	results, err := builder.Simulate(context.Background(), []*types.Transaction{swaps[0], target, swaps[1]})
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, 1, forks.released)
+
+	// Replaying the bundle reuses its nonces, so the fork rejects the first leg
+	results, err = builder.Simulate(context.Background(), swaps)
+	assert.ErrorContains(t, err, "transaction 0 reverted")
+	assert.Len(t, results, 1)
+	assert.Equal(t, 2, forks.released)
+	assert.Empty(t, forks.fork.pinned)
+}
+
+func TestTransactionBuilder_SimulateAt(t *testing.T) {
+	builder, forks := newTestBuilder(t, 3)
+	swaps, err := builder.BuildSwaps(context.Background(), []*interfaces.SwapRoute{
+		{Hops: []interfaces.SwapHop{{Pool: testPoolA, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: testWETH}}, AmountIn: big.NewInt(1e18)},
+	}, big.NewInt(8453))
+	require.NoError(t, err)
+
+	// The fork is pinned for the simulation and moved back to the head after
+	results, err := builder.(interfaces.PinnedBundleSimulator).SimulateAt(context.Background(), swaps, 102)
+	require.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, []uint64{102}, forks.fork.pinned)
+	assert.Equal(t, 1, forks.fork.resets)
+	assert.Equal(t, 1, forks.released)
+}
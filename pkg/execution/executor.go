@@ -0,0 +1,593 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// DefaultExecutionConfig returns the default executor configuration, which only paper trades
+func DefaultExecutionConfig() *interfaces.ExecutionConfig {
+	return &interfaces.ExecutionConfig{
+		Mode:               interfaces.ExecutionModeSimulation,
+		MinProfitThreshold: big.NewInt(0),
+	}
+}
+
+// TradeExecutor implements the Executor interface. Paper trades fill at the expected
+// profit less the simulated gas; hybrid trades are re-simulated on the latest state
+// and submitted only if the profit they transfer to the profit holder still clears
+// the threshold; live trades are submitted directly.
+// Submitted trades carry the expected profit until their inclusion is confirmed.
+// Every trade is recorded with the metrics collector and every execution with the
+// replay logger.
+type TradeExecutor struct {
+	config      *interfaces.ExecutionConfig
+	simulator   interfaces.BundleSimulator
+	submitter   interfaces.TransactionSubmitter
+	metrics     interfaces.MetricsCollector
+	logger      interfaces.TransactionLogger
+	priceOracle interfaces.PriceOracle
+	nonces      interfaces.NonceManager
+	monitor     interfaces.InclusionMonitor
+	risk        interfaces.RiskEngine
+	funding     interfaces.FundingPlanner
+	ledger      interfaces.PaperLedger
+	modes       map[interfaces.StrategyType]interfaces.ExecutionMode // Strategies' own modes
+	mu          sync.RWMutex
+}
+
+// NewTradeExecutor creates an executor. Paper trading without a simulator fills at the
+// opportunity's estimates; hybrid mode needs a simulator and a submitter, live mode a
+// submitter. The metrics collector and logger may be nil.
+func NewTradeExecutor(config *interfaces.ExecutionConfig, simulator interfaces.BundleSimulator, submitter interfaces.TransactionSubmitter, metrics interfaces.MetricsCollector, logger interfaces.TransactionLogger) *TradeExecutor {
+	if config == nil {
+		config = DefaultExecutionConfig()
+	}
+	return &TradeExecutor{
+		config:    config,
+		simulator: simulator,
+		submitter: submitter,
+		metrics:   metrics,
+		logger:    logger,
+	}
+}
+
+// SetPriceOracle sets the oracle that values trade profits in ETH and USD
+func (e *TradeExecutor) SetPriceOracle(oracle interfaces.PriceOracle) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.priceOracle = oracle
+}
+
+// SetNonceManager sets the nonce manager that numbers the searcher's transactions
+// before they are re-simulated or submitted. Detectors leave nonces for it to set.
+func (e *TradeExecutor) SetNonceManager(nonces interfaces.NonceManager) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.nonces = nonces
+}
+
+// SetInclusionMonitor sets the monitor submitted bundles are tracked with. The
+// monitor records their realized trades, so submissions are then only logged.
+func (e *TradeExecutor) SetInclusionMonitor(monitor interfaces.InclusionMonitor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.monitor = monitor
+}
+
+// SetRiskEngine sets the risk engine every bundle must be approved by before it is
+// submitted. Bundles that aren't monitored are settled with it once submitted.
+func (e *TradeExecutor) SetRiskEngine(risk interfaces.RiskEngine) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.risk = risk
+}
+
+// SetFundingPlanner sets the planner that wraps opportunities in flash loans before
+// they're executed in any mode, so paper trades also pay the fees
+func (e *TradeExecutor) SetFundingPlanner(funding interfaces.FundingPlanner) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.funding = funding
+}
+
+// SetPaperLedger sets the ledger paper trades are filled in at the block they
+// would have landed in. The ledger records their realized trades, so paper trades
+// are then only logged.
+func (e *TradeExecutor) SetPaperLedger(ledger interfaces.PaperLedger) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ledger = ledger
+}
+
+// Mode returns the current execution mode
+func (e *TradeExecutor) Mode() interfaces.ExecutionMode {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.config.Mode
+}
+
+// SetMode switches the execution mode of strategies without their own
+func (e *TradeExecutor) SetMode(mode interfaces.ExecutionMode) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.config.Mode = mode
+	return nil
+}
+
+// StrategyMode returns the mode a strategy's opportunities run in
+func (e *TradeExecutor) StrategyMode(strategy interfaces.StrategyType) interfaces.ExecutionMode {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if mode, ok := e.modes[strategy]; ok {
+		return mode
+	}
+	return e.config.Mode
+}
+
+// SetStrategyMode switches a strategy to its own execution mode
+func (e *TradeExecutor) SetStrategyMode(strategy interfaces.StrategyType, mode interfaces.ExecutionMode) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.modes == nil {
+		e.modes = make(map[interfaces.StrategyType]interfaces.ExecutionMode)
+	}
+	e.modes[strategy] = mode
+	return nil
+}
+
+// validateMode rejects unknown execution modes
+func validateMode(mode interfaces.ExecutionMode) error {
+	switch mode {
+	case interfaces.ExecutionModeSimulation, interfaces.ExecutionModeHybrid, interfaces.ExecutionModeLive:
+		return nil
+	default:
+		return fmt.Errorf("unknown execution mode %q", mode)
+	}
+}
+
+// Execute runs an opportunity in its strategy's mode. Reverts, unprofitable
+// re-simulations and submission failures are reported in the result; the error is
+// for opportunities or modes that can't be executed at all.
+func (e *TradeExecutor) Execute(ctx context.Context, opportunity *interfaces.MEVOpportunity) (*interfaces.ExecutionResult, error) {
+	if opportunity == nil {
+		return nil, errors.New("opportunity cannot be nil")
+	}
+	if len(opportunity.ExecutionTxs) == 0 {
+		return nil, fmt.Errorf("opportunity %s has no execution transactions", opportunity.ID)
+	}
+
+	mode := e.StrategyMode(opportunity.Strategy)
+	if mode != interfaces.ExecutionModeSimulation && e.submitter == nil {
+		return nil, fmt.Errorf("%s execution needs a transaction submitter", mode)
+	}
+	if mode == interfaces.ExecutionModeHybrid && e.simulator == nil {
+		return nil, errors.New("hybrid execution needs a bundle simulator")
+	}
+
+	start := time.Now()
+	result := &interfaces.ExecutionResult{
+		OpportunityID: opportunity.ID,
+		Mode:          mode,
+	}
+
+	var (
+		trade    *interfaces.TradeResult
+		ledgered bool
+	)
+	err := e.fund(ctx, opportunity)
+	if err == nil {
+		switch mode {
+		case interfaces.ExecutionModeSimulation:
+			trade, err = e.paperTrade(ctx, opportunity, result)
+			if err == nil {
+				ledgered = e.queuePaperFill(ctx, opportunity, trade)
+			}
+		default:
+			trade, err = e.executeWithNonces(ctx, opportunity, result)
+		}
+	}
+
+	result.ExecutionTime = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+	}
+	if trade != nil {
+		trade.ExecutionTime = result.ExecutionTime
+		result.Trade = trade
+		result.Success = trade.Success
+		result.RealizedProfit = trade.ActualProfit
+		result.ActualGasCost = trade.GasCost
+		result.ActualNetProfit = trade.NetProfitWei()
+		if trade.Success {
+			opportunity.Status = interfaces.StatusExecuted
+		} else {
+			opportunity.Status = interfaces.StatusFailed
+		}
+	}
+
+	e.record(ctx, opportunity, trade, result.Submitted, ledgered)
+	return result, nil
+}
+
+// queuePaperFill queues a paper trade with the paper ledger, if there is one, and
+// reports whether it was
+func (e *TradeExecutor) queuePaperFill(ctx context.Context, opportunity *interfaces.MEVOpportunity, trade *interfaces.TradeResult) bool {
+	e.mu.RLock()
+	ledger := e.ledger
+	e.mu.RUnlock()
+
+	if ledger == nil {
+		return false
+	}
+	if err := ledger.Track(ctx, opportunity, trade); err != nil {
+		log.Printf("Failed to queue paper fill of %s: %v", opportunity.ID, err)
+		return false
+	}
+	return true
+}
+
+// fund wraps the opportunity in flash loans if there is a funding planner
+func (e *TradeExecutor) fund(ctx context.Context, opportunity *interfaces.MEVOpportunity) error {
+	e.mu.RLock()
+	funding := e.funding
+	e.mu.RUnlock()
+
+	if funding == nil {
+		return nil
+	}
+	if _, err := funding.Fund(ctx, opportunity); err != nil {
+		return fmt.Errorf("funding failed: %w", err)
+	}
+	return nil
+}
+
+// paperTrade simulates the bundle and fills it at the expected profit
+func (e *TradeExecutor) paperTrade(ctx context.Context, opportunity *interfaces.MEVOpportunity, result *interfaces.ExecutionResult) (*interfaces.TradeResult, error) {
+	gasCost := opportunity.GasCost
+	if e.simulator != nil {
+		simulations, err := e.simulator.Simulate(ctx, opportunity.ExecutionTxs)
+		result.Simulations = simulations
+		if err != nil {
+			// A reverted bundle isn't included, so it costs nothing
+			return e.newTrade(opportunity, interfaces.ExecutionModeSimulation, false, big.NewInt(0), err), err
+		}
+		gasCost = simulatedGasCost(opportunity.ExecutionTxs, simulations, opportunity.TargetTx)
+	}
+	return e.newTrade(opportunity, interfaces.ExecutionModeSimulation, true, gasCost, nil), nil
+}
+
+// executeWithNonces numbers the bundle from the nonce manager, if there is one, and
+// re-simulates or submits it. Nonces of bundles that aren't sent are released, and
+// a failed submission resyncs the nonce manager. Gaps left behind are cancelled.
+func (e *TradeExecutor) executeWithNonces(ctx context.Context, opportunity *interfaces.MEVOpportunity, result *interfaces.ExecutionResult) (*interfaces.TradeResult, error) {
+	e.mu.RLock()
+	nonces, monitor, risk := e.nonces, e.monitor, e.risk
+	e.mu.RUnlock()
+
+	bundle := opportunity.ExecutionTxs
+	var reservation *interfaces.NonceReservation
+	if nonces != nil {
+		var err error
+		bundle, reservation, err = assignNonces(ctx, nonces, bundle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		trade *interfaces.TradeResult
+		err   error
+	)
+	if result.Mode == interfaces.ExecutionModeHybrid {
+		trade, err = e.hybridTrade(ctx, opportunity, bundle, result)
+	} else {
+		trade, err = e.submit(ctx, opportunity, bundle, result, opportunity.GasCost)
+	}
+
+	tracked := false
+	if result.Submitted && monitor != nil {
+		err := monitor.Track(ctx, &interfaces.TrackedBundle{
+			Opportunity:  opportunity,
+			Trade:        trade,
+			Transactions: result.SubmittedTxs,
+			TxHashes:     result.TxHashes,
+			Nonces:       reservation,
+		})
+		if err != nil {
+			log.Printf("Failed to track bundle of %s: %v", opportunity.ID, err)
+		}
+		tracked = err == nil
+	}
+	if result.Submitted && risk != nil && !tracked {
+		// Nothing will report the realized trade, so settle at the expected profit
+		risk.Settle(trade)
+	}
+	if reservation != nil && !result.Submitted {
+		// A failed submission may mean the chain moved past our nonces
+		releaseNonces(ctx, nonces, reservation, trade != nil)
+	}
+	return trade, err
+}
+
+// hybridTrade re-simulates the bundle, target included, and submits it only if the
+// profit it transfers still clears the profit threshold
+func (e *TradeExecutor) hybridTrade(ctx context.Context, opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction, result *interfaces.ExecutionResult) (*interfaces.TradeResult, error) {
+	simulations, err := e.simulator.Simulate(ctx, bundle)
+	result.Simulations = simulations
+	if err != nil {
+		return nil, fmt.Errorf("re-simulation failed: %w", err)
+	}
+
+	gasCost := simulatedGasCost(bundle, simulations, opportunity.TargetTx)
+	profit, err := e.simulatedProfit(opportunity, bundle, simulations)
+	if err != nil {
+		return nil, fmt.Errorf("re-simulated profit can't be measured: %w", err)
+	}
+	estimate := e.newTrade(opportunity, interfaces.ExecutionModeHybrid, true, gasCost, nil)
+	e.reprice(estimate, profit)
+	netProfit := estimate.NetProfitWei()
+	if netProfit == nil {
+		return nil, fmt.Errorf("re-simulated profit in %s can't be priced", opportunity.ProfitToken.Hex())
+	}
+	if threshold := e.config.MinProfitThreshold; threshold != nil && netProfit.Cmp(threshold) < 0 {
+		return nil, fmt.Errorf("no longer profitable: re-simulated net profit %s is below %s", netProfit, threshold)
+	}
+
+	return e.submit(ctx, opportunity, bundle, result, gasCost)
+}
+
+// simulatedProfit is the net transfer of the profit token to the profit holder in a
+// bundle's simulated logs. ETH has no logs, so profits in ETH are the holder's net
+// token transfers valued by the price oracle.
+func (e *TradeExecutor) simulatedProfit(opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction, simulations []*interfaces.SimulationResult) (*big.Int, error) {
+	holder := profitHolder(e.config.Executor, bundle, opportunity.TargetTx)
+	e.mu.RLock()
+	oracle := e.priceOracle
+	e.mu.RUnlock()
+	return profitIn(opportunity.ProfitToken, bundleTransfers(holder, simulations), oracle)
+}
+
+// profitIn returns the net transfers of the profit token or, for ETH, of every
+// token, valued in ETH with the oracle
+func profitIn(token common.Address, transfers map[common.Address]*big.Int, oracle interfaces.PriceOracle) (*big.Int, error) {
+	if token != (common.Address{}) {
+		return new(big.Int).Set(bigOrZero(transfers[token])), nil
+	}
+
+	profit := new(big.Int)
+	for token, delta := range transfers {
+		if oracle == nil {
+			return nil, fmt.Errorf("no price oracle to value %s in ETH", token.Hex())
+		}
+		value, err := oracle.ValueETH(token, new(big.Int).Abs(delta))
+		if err != nil {
+			return nil, err
+		}
+		if delta.Sign() < 0 {
+			value.Neg(value)
+		}
+		profit.Add(profit, value)
+	}
+	return profit, nil
+}
+
+// reprice replaces a trade's profit with what it realized
+func (e *TradeExecutor) reprice(trade *interfaces.TradeResult, profit *big.Int) {
+	trade.ActualProfit = profit
+
+	e.mu.RLock()
+	oracle := e.priceOracle
+	e.mu.RUnlock()
+	priceTrade(trade, oracle)
+}
+
+// priceTrade sets a trade's net profit, in wei of ETH, and its value in USD. A
+// profit in ETH nets its gas directly; one in another token is valued by the
+// oracle, and the trade has no net profit if the oracle can't price it.
+func priceTrade(trade *interfaces.TradeResult, oracle interfaces.PriceOracle) {
+	trade.NetProfit, trade.NetProfitETH, trade.NetProfitUSD = nil, nil, 0
+	if trade.ProfitToken == (common.Address{}) {
+		trade.NetProfit = new(big.Int).Sub(bigOrZero(trade.ActualProfit), bigOrZero(trade.GasCost))
+	}
+	if oracle != nil {
+		// Trades that can't be priced are kept; NetProfitWei reports them as unpriced
+		_ = oracle.NormalizeTrade(trade)
+	}
+	if trade.NetProfit == nil && trade.NetProfitETH != nil {
+		trade.NetProfit = new(big.Int).Set(trade.NetProfitETH)
+	}
+}
+
+// submit sends the bundle if the risk engine approves it, recording a failed
+// submission as a failed trade
+func (e *TradeExecutor) submit(ctx context.Context, opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction, result *interfaces.ExecutionResult, gasCost *big.Int) (*interfaces.TradeResult, error) {
+	e.mu.RLock()
+	risk := e.risk
+	e.mu.RUnlock()
+
+	mode := result.Mode
+	if maxGasPrice := e.config.MaxGasPrice; maxGasPrice != nil {
+		for _, tx := range bundle {
+			if isTarget(opportunity.TargetTx, tx) {
+				continue
+			}
+			if tx.GasPrice != nil && tx.GasPrice.Cmp(maxGasPrice) > 0 {
+				return nil, fmt.Errorf("gas price %s exceeds the maximum of %s", tx.GasPrice, maxGasPrice)
+			}
+		}
+	}
+
+	if risk != nil {
+		if err := risk.Approve(ctx, opportunity, bundle); err != nil {
+			return nil, err
+		}
+	}
+
+	hashes, err := e.submitter.Submit(ctx, bundle)
+	if err != nil {
+		if risk != nil {
+			risk.Cancel(opportunity.ID)
+		}
+		err = fmt.Errorf("submission failed: %w", err)
+		return e.newTrade(opportunity, mode, false, big.NewInt(0), err), err
+	}
+
+	result.Submitted = true
+	result.SubmittedTxs = bundle
+	result.TxHashes = hashes
+	trade := e.newTrade(opportunity, mode, true, gasCost, nil)
+	for i, hash := range hashes {
+		if i < len(bundle) && !isTarget(opportunity.TargetTx, bundle[i]) {
+			trade.TransactionHash = hash.Hex()
+			break
+		}
+	}
+	return trade, nil
+}
+
+// newTrade creates a trade result. Successful trades realize the expected profit;
+// failed ones realize nothing.
+func (e *TradeExecutor) newTrade(opportunity *interfaces.MEVOpportunity, mode interfaces.ExecutionMode, success bool, gasCost *big.Int, err error) *interfaces.TradeResult {
+	if gasCost == nil {
+		gasCost = big.NewInt(0)
+	}
+
+	actualProfit := big.NewInt(0)
+	if success && opportunity.ExpectedProfit != nil {
+		actualProfit = new(big.Int).Set(opportunity.ExpectedProfit)
+	}
+
+	trade := &interfaces.TradeResult{
+		ID:             fmt.Sprintf("trade_%s_%d", opportunity.ID, time.Now().UnixNano()),
+		Strategy:       opportunity.Strategy,
+		OpportunityID:  opportunity.ID,
+		ExecutedAt:     time.Now(),
+		Success:        success,
+		ActualProfit:   actualProfit,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		ProfitToken:    opportunity.ProfitToken,
+		Mode:           mode,
+	}
+	if err != nil {
+		trade.ErrorMessage = err.Error()
+	}
+
+	e.mu.RLock()
+	oracle := e.priceOracle
+	e.mu.RUnlock()
+	priceTrade(trade, oracle)
+	return trade
+}
+
+// record feeds a trade to the metrics collector and the execution to the replay
+// logger. Submitted trades are left to the inclusion monitor, if there is one, and
+// ledgered paper trades to the paper ledger, to record once realized. The trade has
+// already happened, so failures are only logged.
+func (e *TradeExecutor) record(ctx context.Context, opportunity *interfaces.MEVOpportunity, trade *interfaces.TradeResult, submitted, ledgered bool) {
+	e.mu.RLock()
+	monitored := submitted && e.monitor != nil
+	e.mu.RUnlock()
+
+	if trade != nil && e.metrics != nil && !monitored && !ledgered {
+		if err := e.metrics.RecordTrade(ctx, trade); err != nil {
+			log.Printf("Failed to record trade %s: %v", trade.ID, err)
+		}
+	}
+	if e.logger != nil {
+		if err := e.logger.LogOpportunity(ctx, opportunity, trade); err != nil {
+			log.Printf("Failed to log execution of %s: %v", opportunity.ID, err)
+		}
+	}
+}
+
+// simulatedGasCost prices the gas each of our simulated transactions used at its gas
+// price. The target's gas is paid by its sender.
+func simulatedGasCost(bundle []*types.Transaction, simulations []*interfaces.SimulationResult, target string) *big.Int {
+	cost := new(big.Int)
+	for i, simulation := range simulations {
+		if simulation == nil || (i < len(bundle) && isTarget(target, bundle[i])) {
+			continue
+		}
+		gasPrice := simulation.GasPrice
+		if gasPrice == nil && i < len(bundle) {
+			gasPrice = bundle[i].GasPrice
+		}
+		if gasPrice == nil {
+			continue
+		}
+		cost.Add(cost, new(big.Int).Mul(new(big.Int).SetUint64(simulation.GasUsed), gasPrice))
+	}
+	return cost
+}
+
+// isTarget reports whether a bundle transaction is the opportunity's target, which
+// strategies place in the bundle but another account sends
+func isTarget(target string, tx *types.Transaction) bool {
+	return tx != nil && target != "" && strings.EqualFold(tx.Hash, target)
+}
+
+// profitHolder returns the configured profit holder, or the sender of the bundle's
+// first transaction that isn't the target
+func profitHolder(holder common.Address, bundle []*types.Transaction, target string) common.Address {
+	if holder != (common.Address{}) {
+		return holder
+	}
+	for _, tx := range bundle {
+		if tx != nil && !isTarget(target, tx) {
+			return tx.From
+		}
+	}
+	return common.Address{}
+}
+
+// bundleTransfers nets the ERC-20 transfers to and from the holder in a bundle's
+// simulated logs, by token
+func bundleTransfers(holder common.Address, simulations []*interfaces.SimulationResult) map[common.Address]*big.Int {
+	transfers := make(map[common.Address]*big.Int)
+	for _, simulation := range simulations {
+		if simulation != nil {
+			addTransfers(transfers, holder, simulation.Logs)
+		}
+	}
+	return transfers
+}
+
+// addTransfers adds the ERC-20 transfers to and from the holder in logs to the
+// net transfers by token
+func addTransfers(transfers map[common.Address]*big.Int, holder common.Address, logs []*ethtypes.Log) {
+	for _, entry := range logs {
+		from, to, value, ok := decodeTransfer(entry)
+		if !ok || from == to || (from != holder && to != holder) {
+			continue
+		}
+		if transfers[entry.Address] == nil {
+			transfers[entry.Address] = new(big.Int)
+		}
+		if to == holder {
+			transfers[entry.Address].Add(transfers[entry.Address], value)
+		} else {
+			transfers[entry.Address].Sub(transfers[entry.Address], value)
+		}
+	}
+}
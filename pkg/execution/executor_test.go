@@ -0,0 +1,335 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/metrics"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSimulator returns a fixed gas use for every transaction and the given logs on
+// the last, or fails
+type fakeSimulator struct {
+	gasUsed uint64
+	logs    []*ethtypes.Log
+	err     error
+	calls   int
+	bundles [][]*types.Transaction
+}
+
+func (s *fakeSimulator) Simulate(ctx context.Context, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	s.calls++
+	s.bundles = append(s.bundles, bundle)
+	if s.err != nil {
+		return nil, s.err
+	}
+	results := make([]*interfaces.SimulationResult, len(bundle))
+	for i := range bundle {
+		results[i] = &interfaces.SimulationResult{Success: true, GasUsed: s.gasUsed}
+	}
+	if len(results) > 0 {
+		results[len(results)-1].Logs = s.logs
+	}
+	return results, nil
+}
+
+// fakeSubmitter records submitted bundles
+type fakeSubmitter struct {
+	err     error
+	bundles [][]*types.Transaction
+}
+
+func (s *fakeSubmitter) Submit(ctx context.Context, bundle []*types.Transaction) ([]common.Hash, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.bundles = append(s.bundles, bundle)
+	hashes := make([]common.Hash, len(bundle))
+	for i := range bundle {
+		hashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	return hashes, nil
+}
+
+// fakeTransactionLogger records logged executions and realized results
+type fakeTransactionLogger struct {
+	opportunities []*interfaces.MEVOpportunity
+	trades        []*interfaces.TradeResult
+	updates       map[string]*interfaces.TradeResult
+}
+
+func (l *fakeTransactionLogger) LogOpportunity(ctx context.Context, opportunity *interfaces.MEVOpportunity, tradeResult *interfaces.TradeResult) error {
+	l.opportunities = append(l.opportunities, opportunity)
+	l.trades = append(l.trades, tradeResult)
+	return nil
+}
+
+func (l *fakeTransactionLogger) GetLogsByStrategy(ctx context.Context, strategy interfaces.StrategyType, limit int) ([]*interfaces.HistoricalTransactionLog, error) {
+	return nil, nil
+}
+
+func (l *fakeTransactionLogger) GetLogsByTimeRange(ctx context.Context, start, end time.Time) ([]*interfaces.HistoricalTransactionLog, error) {
+	return nil, nil
+}
+
+func (l *fakeTransactionLogger) UpdateLogWithActualResult(ctx context.Context, logID string, actualResult *interfaces.TradeResult) error {
+	if l.updates == nil {
+		l.updates = make(map[string]*interfaces.TradeResult)
+	}
+	l.updates[logID] = actualResult
+	return nil
+}
+
+// testOpportunity is a two-transaction bundle expected to make 0.01 ETH at 1 gwei
+func testOpportunity() *interfaces.MEVOpportunity {
+	gasPrice := big.NewInt(1e9)
+	return &interfaces.MEVOpportunity{
+		ID:             "opp_1",
+		Strategy:       interfaces.StrategyBackrun,
+		ExpectedProfit: big.NewInt(1e16),
+		GasCost:        big.NewInt(5e14),
+		Status:         interfaces.StatusProfitable,
+		ExecutionTxs: []*types.Transaction{
+			{From: testSearcher, To: &testExecutor, GasPrice: gasPrice, GasLimit: 300000, Nonce: 1},
+			{From: testSearcher, To: &testExecutor, GasPrice: gasPrice, GasLimit: 300000, Nonce: 2},
+		},
+	}
+}
+
+func TestTradeExecutor_Execute(t *testing.T) {
+	tests := []struct {
+		name          string
+		mode          interfaces.ExecutionMode
+		minProfit     *big.Int
+		simulateErr   error
+		submitErr     error
+		wantSuccess   bool
+		wantSubmitted bool
+		wantTrade     bool
+		wantGasCost   *big.Int
+		wantStatus    interfaces.OpportunityStatus
+	}{
+		{
+			name:        "paper trade fills at simulated gas",
+			mode:        interfaces.ExecutionModeSimulation,
+			wantSuccess: true,
+			wantTrade:   true,
+			wantGasCost: big.NewInt(2 * 100000 * 1e9),
+			wantStatus:  interfaces.StatusExecuted,
+		},
+		{
+			name:        "paper trade reverts",
+			mode:        interfaces.ExecutionModeSimulation,
+			simulateErr: errors.New("transaction 1 reverted"),
+			wantTrade:   true,
+			wantGasCost: big.NewInt(0),
+			wantStatus:  interfaces.StatusFailed,
+		},
+		{
+			name:          "hybrid submits when still profitable",
+			mode:          interfaces.ExecutionModeHybrid,
+			minProfit:     big.NewInt(1e15),
+			wantSuccess:   true,
+			wantSubmitted: true,
+			wantTrade:     true,
+			wantGasCost:   big.NewInt(2 * 100000 * 1e9),
+			wantStatus:    interfaces.StatusExecuted,
+		},
+		{
+			name:       "hybrid skips when no longer profitable",
+			mode:       interfaces.ExecutionModeHybrid,
+			minProfit:  big.NewInt(1e17),
+			wantStatus: interfaces.StatusProfitable,
+		},
+		{
+			name:        "hybrid skips when re-simulation reverts",
+			mode:        interfaces.ExecutionModeHybrid,
+			simulateErr: errors.New("transaction 0 reverted"),
+			wantStatus:  interfaces.StatusProfitable,
+		},
+		{
+			name:          "live submits directly",
+			mode:          interfaces.ExecutionModeLive,
+			wantSuccess:   true,
+			wantSubmitted: true,
+			wantTrade:     true,
+			wantGasCost:   big.NewInt(5e14),
+			wantStatus:    interfaces.StatusExecuted,
+		},
+		{
+			name:        "live submission fails",
+			mode:        interfaces.ExecutionModeLive,
+			submitErr:   errors.New("relay unavailable"),
+			wantTrade:   true,
+			wantGasCost: big.NewInt(0),
+			wantStatus:  interfaces.StatusFailed,
+		},
+	}
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The bundle moves 0.01 WETH of profit to the executor contract
+			simulator := &fakeSimulator{gasUsed: 100000, logs: roundTrip(units(5, 18), big.NewInt(1e16)), err: tt.simulateErr}
+			submitter := &fakeSubmitter{err: tt.submitErr}
+			collector := metrics.NewCollectorWithRegistry(nil, prometheus.NewRegistry())
+			logger := &fakeTransactionLogger{}
+
+			config := DefaultExecutionConfig()
+			config.Mode = tt.mode
+			config.Executor = testExecutor
+			if tt.minProfit != nil {
+				config.MinProfitThreshold = tt.minProfit
+			}
+			executor := NewTradeExecutor(config, simulator, submitter, collector, logger)
+			executor.SetPriceOracle(oracle)
+
+			opportunity := testOpportunity()
+			result, err := executor.Execute(context.Background(), opportunity)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.mode, result.Mode)
+			assert.Equal(t, tt.wantSuccess, result.Success)
+			assert.Equal(t, tt.wantSubmitted, result.Submitted)
+			assert.Equal(t, tt.wantStatus, opportunity.Status)
+			if tt.wantSuccess {
+				assert.Empty(t, result.Error)
+			} else {
+				assert.NotEmpty(t, result.Error)
+			}
+			if tt.wantSubmitted {
+				require.Len(t, submitter.bundles, 1)
+				assert.Equal(t, opportunity.ExecutionTxs, submitter.bundles[0])
+				assert.Len(t, result.TxHashes, 2)
+				assert.Equal(t, result.TxHashes[0].Hex(), result.Trade.TransactionHash)
+			} else {
+				assert.Empty(t, submitter.bundles)
+			}
+
+			// Every execution reaches the replay logger; only trades reach the metrics
+			require.Len(t, logger.opportunities, 1)
+			assert.Same(t, opportunity, logger.opportunities[0])
+			performance, err := collector.GetPerformanceMetrics()
+			require.NoError(t, err)
+			if !tt.wantTrade {
+				assert.Nil(t, result.Trade)
+				assert.Nil(t, logger.trades[0])
+				assert.Zero(t, performance.TransactionsProcessed)
+				return
+			}
+
+			require.NotNil(t, result.Trade)
+			assert.Same(t, result.Trade, logger.trades[0])
+			assert.Equal(t, uint64(1), performance.TransactionsProcessed)
+			assert.Equal(t, tt.mode, result.Trade.Mode)
+			assert.Equal(t, opportunity.ID, result.Trade.OpportunityID)
+			assert.Equal(t, 0, tt.wantGasCost.Cmp(result.ActualGasCost), "gas cost %s", result.ActualGasCost)
+			wantProfit := big.NewInt(0)
+			if tt.wantSuccess {
+				wantProfit = opportunity.ExpectedProfit
+			}
+			assert.Equal(t, 0, wantProfit.Cmp(result.RealizedProfit))
+			assert.Equal(t, 0, new(big.Int).Sub(wantProfit, tt.wantGasCost).Cmp(result.ActualNetProfit))
+		})
+	}
+}
+
+func TestTradeExecutor_ExecuteErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        interfaces.ExecutionMode
+		simulator   interfaces.BundleSimulator
+		submitter   interfaces.TransactionSubmitter
+		opportunity *interfaces.MEVOpportunity
+	}{
+		{
+			name:      "nil opportunity",
+			mode:      interfaces.ExecutionModeSimulation,
+			simulator: &fakeSimulator{},
+		},
+		{
+			name:        "no execution transactions",
+			mode:        interfaces.ExecutionModeSimulation,
+			simulator:   &fakeSimulator{},
+			opportunity: &interfaces.MEVOpportunity{ID: "empty"},
+		},
+		{
+			name:        "live without submitter",
+			mode:        interfaces.ExecutionModeLive,
+			opportunity: testOpportunity(),
+		},
+		{
+			name:        "hybrid without simulator",
+			mode:        interfaces.ExecutionModeHybrid,
+			submitter:   &fakeSubmitter{},
+			opportunity: testOpportunity(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultExecutionConfig()
+			config.Mode = tt.mode
+			executor := NewTradeExecutor(config, tt.simulator, tt.submitter, nil, nil)
+
+			_, err := executor.Execute(context.Background(), tt.opportunity)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTradeExecutor_MaxGasPrice(t *testing.T) {
+	submitter := &fakeSubmitter{}
+	config := DefaultExecutionConfig()
+	config.Mode = interfaces.ExecutionModeLive
+	config.MaxGasPrice = big.NewInt(5e8)
+	executor := NewTradeExecutor(config, nil, submitter, nil, nil)
+
+	opportunity := testOpportunity()
+	result, err := executor.Execute(context.Background(), opportunity)
+	require.NoError(t, err)
+
+	assert.False(t, result.Submitted)
+	assert.Nil(t, result.Trade)
+	assert.Contains(t, result.Error, "exceeds the maximum")
+	assert.Empty(t, submitter.bundles)
+}
+
+func TestTradeExecutor_PaperTradeWithoutSimulator(t *testing.T) {
+	executor := NewTradeExecutor(nil, nil, nil, nil, nil)
+
+	opportunity := testOpportunity()
+	result, err := executor.Execute(context.Background(), opportunity)
+	require.NoError(t, err)
+
+	assert.True(t, result.Success)
+	assert.False(t, result.Submitted)
+	assert.Equal(t, 0, opportunity.GasCost.Cmp(result.ActualGasCost))
+	assert.Equal(t, interfaces.ExecutionModeSimulation, result.Trade.Mode)
+}
+
+func TestTradeExecutor_SetMode(t *testing.T) {
+	executor := NewTradeExecutor(nil, nil, nil, nil, nil)
+	assert.Equal(t, interfaces.ExecutionModeSimulation, executor.Mode())
+
+	require.NoError(t, executor.SetMode(interfaces.ExecutionModeLive))
+	assert.Equal(t, interfaces.ExecutionModeLive, executor.Mode())
+
+	assert.Error(t, executor.SetMode("yolo"))
+	assert.Equal(t, interfaces.ExecutionModeLive, executor.Mode())
+}
@@ -0,0 +1,418 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/lending"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// Base mainnet flash loan lenders besides the Aave V3 pool
+var (
+	BaseBalancerVault = common.HexToAddress("0xBA12222222228d8Ba445958a75a0704d566BF2C8")
+	BaseMorphoBlue    = common.HexToAddress("0xBBBBBbbBBb9cC5e90e3b3Af64bdAF62C37EEFFCb")
+)
+
+// flashLoanSources numbers the sources as flashExecute does
+var flashLoanSources = map[interfaces.FlashLoanSource]uint8{
+	interfaces.FlashLoanBalancer:  0,
+	interfaces.FlashLoanAaveV3:    1,
+	interfaces.FlashLoanUniswapV3: 2,
+	interfaces.FlashLoanMorpho:    3,
+}
+
+// DefaultFundingPlannerConfig returns the default funding planner configuration:
+// the fee-free Balancer Vault and Morpho first, then Uniswap V3 pools at their fee
+// tier and Aave V3 at 5 bps
+func DefaultFundingPlannerConfig() *interfaces.FundingPlannerConfig {
+	return &interfaces.FundingPlannerConfig{
+		Sources: []interfaces.FlashLoanSourceConfig{
+			{Source: interfaces.FlashLoanBalancer, Lender: BaseBalancerVault, GasOverhead: 80000},
+			{Source: interfaces.FlashLoanMorpho, Lender: BaseMorphoBlue, GasOverhead: 60000},
+			{Source: interfaces.FlashLoanUniswapV3, GasOverhead: 70000},
+			{Source: interfaces.FlashLoanAaveV3, Lender: lending.BaseAaveV3Pool, FeeBps: 5, GasOverhead: 110000},
+		},
+		MaxPoolsQuoted: 3,
+	}
+}
+
+// FundingPlannerImpl implements the FundingPlanner interface. Liquidity is the
+// token balance a lender can lend from: the Vault's, Morpho's or the pool's own,
+// and for Aave the reserve's aToken's. Only executor calls that end holding the
+// token they spend can be funded, since the loan is repaid in the same call.
+type FundingPlannerImpl struct {
+	config      *interfaces.FundingPlannerConfig
+	chain       pricing.ContractCaller
+	pools       interfaces.PoolRegistry
+	priceOracle interfaces.PriceOracle
+	capital     interfaces.CapitalSource
+	aTokens     map[common.Address]common.Address // Aave reserves' aTokens by asset
+	mu          sync.RWMutex
+}
+
+// NewFundingPlanner creates a planner that reads lenders' liquidity from chain.
+// Uniswap V3 pools come from the pool registry, which may be nil to skip them.
+func NewFundingPlanner(config *interfaces.FundingPlannerConfig, chain pricing.ContractCaller, pools interfaces.PoolRegistry) (*FundingPlannerImpl, error) {
+	if config == nil {
+		config = DefaultFundingPlannerConfig()
+	}
+	if chain == nil {
+		return nil, errors.New("funding planner needs a chain to read liquidity from")
+	}
+	for _, source := range config.Sources {
+		if _, ok := flashLoanSources[source.Source]; !ok {
+			return nil, fmt.Errorf("unknown flash loan source %q", source.Source)
+		}
+		if source.Source != interfaces.FlashLoanUniswapV3 && source.Lender == (common.Address{}) {
+			return nil, fmt.Errorf("flash loan source %s has no lender", source.Source)
+		}
+		if source.FeeBps > 10000 {
+			return nil, fmt.Errorf("flash loan source %s fee of %d bps is over 100%%", source.Source, source.FeeBps)
+		}
+	}
+
+	return &FundingPlannerImpl{
+		config:  config,
+		chain:   chain,
+		pools:   pools,
+		aTokens: make(map[common.Address]common.Address),
+	}, nil
+}
+
+// SetPriceOracle sets the oracle fees in another token than the profit are
+// converted with, and funded opportunities are renormalized with
+func (p *FundingPlannerImpl) SetPriceOracle(oracle interfaces.PriceOracle) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.priceOracle = oracle
+}
+
+// SetCapitalSource sets the inventory checked before funding. Calls our inventory
+// covers are then left unfunded; without it every call is funded.
+func (p *FundingPlannerImpl) SetCapitalSource(capital interfaces.CapitalSource) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.capital = capital
+}
+
+// Quote returns the sources with enough liquidity to lend amount of token, by fee
+// then gas overhead
+func (p *FundingPlannerImpl) Quote(ctx context.Context, token common.Address, amount *big.Int, exclude []common.Address) ([]*interfaces.FlashLoanPlan, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return nil, errors.New("flash loan amount must be positive")
+	}
+	if token == (common.Address{}) {
+		return nil, errors.New("ETH can't be flash borrowed; borrow WETH")
+	}
+
+	var (
+		plans   []*interfaces.FlashLoanPlan
+		lastErr error
+	)
+	for _, source := range p.config.Sources {
+		if source.Source == interfaces.FlashLoanUniswapV3 {
+			uniswapPlans, err := p.quoteUniswapV3(ctx, source, token, amount, exclude)
+			if err != nil {
+				lastErr = err
+			}
+			plans = append(plans, uniswapPlans...)
+			continue
+		}
+		if containsAddress(exclude, source.Lender) {
+			continue
+		}
+
+		holder := source.Lender
+		if source.Source == interfaces.FlashLoanAaveV3 {
+			aToken, err := p.aToken(ctx, source.Lender, token)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			holder = aToken
+		}
+		available, err := tokenBalanceAt(ctx, p.chain, token, holder, nil)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", source.Source, err)
+			continue
+		}
+		if available.Cmp(amount) < 0 {
+			continue
+		}
+		plans = append(plans, newFlashLoanPlan(source, source.Lender, token, amount, feeOf(amount, uint64(source.FeeBps), 10000), available))
+	}
+
+	if len(plans) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("no flash loan source can lend %s of %s: %w", amount, token.Hex(), lastErr)
+		}
+		return nil, fmt.Errorf("no flash loan source can lend %s of %s", amount, token.Hex())
+	}
+	sort.SliceStable(plans, func(i, j int) bool {
+		if c := plans[i].Fee.Cmp(plans[j].Fee); c != 0 {
+			return c < 0
+		}
+		return plans[i].GasOverhead < plans[j].GasOverhead
+	})
+	return plans, nil
+}
+
+// quoteUniswapV3 quotes the registry's Uniswap V3 pools of a token, lowest fee tier
+// and then deepest first, up to MaxPoolsQuoted of them
+func (p *FundingPlannerImpl) quoteUniswapV3(ctx context.Context, source interfaces.FlashLoanSourceConfig, token common.Address, amount *big.Int, exclude []common.Address) ([]*interfaces.FlashLoanPlan, error) {
+	if p.pools == nil {
+		return nil, nil
+	}
+	pools := p.pools.FindPools(&interfaces.PoolFilter{
+		Token:    &token,
+		Protocol: interfaces.ProtocolUniswapV3,
+		Exclude:  exclude,
+	})
+	sort.SliceStable(pools, func(i, j int) bool {
+		if pools[i].Fee != pools[j].Fee {
+			return pools[i].Fee < pools[j].Fee
+		}
+		if pools[i].TVL == nil || pools[j].TVL == nil {
+			return pools[j].TVL == nil && pools[i].TVL != nil
+		}
+		return pools[i].TVL.Cmp(pools[j].TVL) > 0
+	})
+	if p.config.MaxPoolsQuoted > 0 && len(pools) > p.config.MaxPoolsQuoted {
+		pools = pools[:p.config.MaxPoolsQuoted]
+	}
+
+	var (
+		plans   []*interfaces.FlashLoanPlan
+		lastErr error
+	)
+	for _, pool := range pools {
+		available, err := tokenBalanceAt(ctx, p.chain, token, pool.Address, nil)
+		if err != nil {
+			lastErr = fmt.Errorf("%s: %w", source.Source, err)
+			continue
+		}
+		if available.Cmp(amount) < 0 {
+			continue
+		}
+		plans = append(plans, newFlashLoanPlan(source, pool.Address, token, amount, feeOf(amount, uint64(pool.Fee), 1000000), available))
+	}
+	return plans, lastErr
+}
+
+// aToken returns the aToken of an Aave V3 reserve, which holds its liquidity
+func (p *FundingPlannerImpl) aToken(ctx context.Context, pool, asset common.Address) (common.Address, error) {
+	p.mu.RLock()
+	aToken, ok := p.aTokens[asset]
+	p.mu.RUnlock()
+	if ok {
+		return aToken, nil
+	}
+
+	data, err := aavePool.Pack("getReserveData", asset)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to encode getReserveData: %w", err)
+	}
+	output, err := p.chain.CallContract(ctx, ethereum.CallMsg{To: &pool, Data: data}, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to read Aave reserve of %s: %w", asset.Hex(), err)
+	}
+	values, err := aavePool.Unpack("getReserveData", output)
+	if err != nil || len(values) < 9 {
+		return common.Address{}, fmt.Errorf("failed to decode Aave reserve of %s: %v", asset.Hex(), err)
+	}
+	aToken, ok = values[8].(common.Address)
+	if !ok || aToken == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("%s is not listed on Aave", asset.Hex())
+	}
+
+	p.mu.Lock()
+	p.aTokens[asset] = aToken
+	p.mu.Unlock()
+	return aToken, nil
+}
+
+// Fund wraps each executor call our inventory doesn't cover in a flash loan of its
+// input from the cheapest source, skipping the pools the opportunity trades in.
+// Without a capital source, or while the inventory isn't known, nothing is known
+// to be missing and nothing is borrowed. Calls that can't be flash funded, because
+// they don't return their input token or no source can lend it, are left as they
+// are. The fees come out of the expected and net profit, converted to the profit
+// token by the price oracle if they differ, and the extra gas is added to the gas
+// cost. The opportunity is only changed if the loans leave it profitable.
+func (p *FundingPlannerImpl) Fund(ctx context.Context, opportunity *interfaces.MEVOpportunity) ([]*interfaces.FlashLoanPlan, error) {
+	if opportunity == nil {
+		return nil, errors.New("opportunity cannot be nil")
+	}
+	p.mu.RLock()
+	oracle, capital := p.priceOracle, p.capital
+	p.mu.RUnlock()
+	if capital == nil {
+		return nil, nil
+	}
+
+	txs := make([]*types.Transaction, len(opportunity.ExecutionTxs))
+	copy(txs, opportunity.ExecutionTxs)
+	var (
+		plans    []*interfaces.FlashLoanPlan
+		fees     = big.NewInt(0) // In the profit token
+		extraGas = big.NewInt(0)
+	)
+	for i, tx := range opportunity.ExecutionTxs {
+		if isTarget(opportunity.TargetTx, tx) {
+			continue
+		}
+		tokenIn, amountIn, tokenOut, ok := decodeExecute(tx.Data)
+		if !ok || tx.To == nil || amountIn.Sign() == 0 {
+			continue
+		}
+		if available := capital.AvailableCapital(tokenIn); available == nil || available.Cmp(amountIn) >= 0 {
+			continue
+		}
+		// A loan of the input can only be repaid from a call that ends in it
+		if tokenOut != tokenIn {
+			continue
+		}
+
+		quotes, err := p.Quote(ctx, tokenIn, amountIn, opportunity.Pools)
+		if err != nil {
+			log.Printf("Transaction %d of %s can't be flash funded: %v", i, opportunity.ID, err)
+			continue
+		}
+		plan := quotes[0]
+		data, err := executorContract.Pack("flashExecute", flashLoanSources[interfaces.FlashLoanSource(plan.Provider)], plan.Pool, plan.Asset, plan.Amount, plan.Fee, tx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode flashExecute: %w", err)
+		}
+		fee, err := feeInProfitToken(oracle, opportunity, plan.Asset, plan.Fee)
+		if err != nil {
+			return nil, err
+		}
+
+		funded := *tx
+		funded.Data = data
+		funded.GasLimit += plan.GasOverhead
+		txs[i] = &funded
+		plans = append(plans, plan)
+		fees.Add(fees, fee)
+		if tx.GasPrice != nil {
+			extraGas.Add(extraGas, new(big.Int).Mul(new(big.Int).SetUint64(plan.GasOverhead), tx.GasPrice))
+		}
+	}
+	if len(plans) == 0 {
+		return nil, nil
+	}
+
+	expectedProfit := new(big.Int).Sub(bigOrZero(opportunity.ExpectedProfit), fees)
+	if expectedProfit.Sign() <= 0 {
+		return nil, fmt.Errorf("flash loan fees of %s exceed the expected profit of %s", fees, opportunity.ID)
+	}
+
+	opportunity.ExecutionTxs = txs
+	opportunity.ExpectedProfit = expectedProfit
+	opportunity.GasCost = new(big.Int).Add(bigOrZero(opportunity.GasCost), extraGas)
+	if opportunity.NetProfit != nil {
+		netProfit := new(big.Int).Sub(opportunity.NetProfit, fees)
+		if opportunity.ProfitToken == (common.Address{}) {
+			netProfit.Sub(netProfit, extraGas)
+		}
+		opportunity.NetProfit = netProfit
+	}
+	if opportunity.Metadata == nil {
+		opportunity.Metadata = make(map[string]interface{})
+	}
+	opportunity.Metadata["flash_loans"] = plans
+
+	// Normalized profits are stale now; without an oracle they're left unpriced
+	opportunity.NetProfitETH, opportunity.NetProfitUSD = nil, 0
+	if oracle != nil {
+		_ = oracle.NormalizeOpportunity(opportunity)
+	}
+	return plans, nil
+}
+
+// feeInProfitToken converts a flash loan fee to the opportunity's profit token,
+// rounding up, at the ratio of the expected profit's ETH value
+func feeInProfitToken(oracle interfaces.PriceOracle, opportunity *interfaces.MEVOpportunity, token common.Address, fee *big.Int) (*big.Int, error) {
+	if token == opportunity.ProfitToken || fee.Sign() == 0 {
+		return fee, nil
+	}
+	if oracle == nil {
+		return nil, fmt.Errorf("a fee in %s can't be charged to profit in %s without a price oracle", token.Hex(), opportunity.ProfitToken.Hex())
+	}
+	if opportunity.ExpectedProfit == nil || opportunity.ExpectedProfit.Sign() <= 0 {
+		return nil, fmt.Errorf("opportunity %s has no expected profit to charge a flash loan fee to", opportunity.ID)
+	}
+
+	feeETH, err := oracle.ValueETH(token, fee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price flash loan fee: %w", err)
+	}
+	profitETH, err := oracle.ValueETH(opportunity.ProfitToken, opportunity.ExpectedProfit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to price profit of %s: %w", opportunity.ID, err)
+	}
+	if profitETH.Sign() <= 0 {
+		return nil, fmt.Errorf("profit of %s is worth nothing in ETH", opportunity.ID)
+	}
+	return ceilDiv(new(big.Int).Mul(feeETH, opportunity.ExpectedProfit), profitETH), nil
+}
+
+// newFlashLoanPlan describes borrowing amount of token from a lender
+func newFlashLoanPlan(source interfaces.FlashLoanSourceConfig, lender, token common.Address, amount, fee, available *big.Int) *interfaces.FlashLoanPlan {
+	return &interfaces.FlashLoanPlan{
+		Provider:    string(source.Source),
+		Pool:        lender,
+		Asset:       token,
+		Amount:      new(big.Int).Set(amount),
+		Fee:         fee,
+		Available:   available,
+		GasOverhead: source.GasOverhead,
+	}
+}
+
+// feeOf returns amount * rate / scale, rounded up as lenders charge it
+func feeOf(amount *big.Int, rate, scale uint64) *big.Int {
+	return ceilDiv(new(big.Int).Mul(amount, new(big.Int).SetUint64(rate)), new(big.Int).SetUint64(scale))
+}
+
+// ceilDiv divides non-negative x by positive y, rounding up
+func ceilDiv(x, y *big.Int) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(x, y, new(big.Int))
+	if remainder.Sign() > 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+// bigOrZero returns x, or zero for nil
+func bigOrZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
+// decodeFlashExecute returns the execute call a flashExecute call wraps
+func decodeFlashExecute(data []byte) ([]byte, bool) {
+	method := executorContract.Methods["flashExecute"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, false
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil || len(values) != 6 {
+		return nil, false
+	}
+	inner, ok := values[5].([]byte)
+	return inner, ok
+}
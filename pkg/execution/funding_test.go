@@ -0,0 +1,278 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/lending"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testAWETH      = common.HexToAddress("0xD4a0e0b9149BCee3C920d2E00b5dE09138fd8bb7")
+	testFlashPool1 = common.HexToAddress("0x0000000000000000000000000000000000f1a501")
+	testFlashPool2 = common.HexToAddress("0x0000000000000000000000000000000000f1a502")
+	testFlashPool3 = common.HexToAddress("0x0000000000000000000000000000000000f1a503")
+)
+
+// fakeLenderChain serves lenders' token balances and Aave reserves' aTokens
+type fakeLenderChain struct {
+	balances map[common.Address]map[common.Address]*big.Int // token, holder
+	aTokens  map[common.Address]common.Address
+}
+
+func newFakeLenderChain() *fakeLenderChain {
+	return &fakeLenderChain{
+		balances: make(map[common.Address]map[common.Address]*big.Int),
+		aTokens:  map[common.Address]common.Address{testWETH: testAWETH},
+	}
+}
+
+func (f *fakeLenderChain) setBalance(token, holder common.Address, balance *big.Int) {
+	if f.balances[token] == nil {
+		f.balances[token] = make(map[common.Address]*big.Int)
+	}
+	f.balances[token][holder] = balance
+}
+
+func (f *fakeLenderChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if method := aavePool.Methods["getReserveData"]; bytes.Equal(call.Data[:4], method.ID) {
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		if err != nil {
+			return nil, err
+		}
+		zero := big.NewInt(0)
+		return method.Outputs.Pack(zero, zero, zero, zero, zero, zero, zero, uint16(0), f.aTokens[args[0].(common.Address)],
+			common.Address{}, common.Address{}, common.Address{}, zero, zero, zero)
+	}
+
+	args, err := erc20.Methods["balanceOf"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	balance, exists := f.balances[*call.To][args[0].(common.Address)]
+	if !exists {
+		balance = big.NewInt(0)
+	}
+	return erc20.Methods["balanceOf"].Outputs.Pack(balance)
+}
+
+// fakeFlashPools serves FindPools from a fixed set of pools
+type fakeFlashPools struct {
+	interfaces.PoolRegistry
+	pools []*interfaces.PoolInfo
+}
+
+func (f *fakeFlashPools) FindPools(filter *interfaces.PoolFilter) []*interfaces.PoolInfo {
+	var pools []*interfaces.PoolInfo
+	for _, pool := range f.pools {
+		if pool.HasToken(*filter.Token) && pool.Protocol == filter.Protocol && !containsAddress(filter.Exclude, pool.Address) {
+			pools = append(pools, pool)
+		}
+	}
+	return pools
+}
+
+// cyclicOpportunity is a WETH backrun whose one executor call starts and ends
+// with amountIn of WETH, expected to make 0.01 WETH at 1 gwei
+func cyclicOpportunity(t *testing.T, amountIn *big.Int) *interfaces.MEVOpportunity {
+	data, err := executorContract.Pack("execute", testWETH, amountIn, testWETH, big.NewInt(0), big.NewInt(0), []struct {
+		Target common.Address
+		Value  *big.Int
+		Data   []byte
+	}{})
+	require.NoError(t, err)
+
+	opportunity := testOpportunity()
+	opportunity.ProfitToken = testWETH
+	opportunity.NetProfit = big.NewInt(95e14)
+	opportunity.Pools = []common.Address{testFlashPool1}
+	opportunity.ExecutionTxs = []*types.Transaction{
+		{From: testSearcher, To: &testExecutor, GasPrice: big.NewInt(1e9), GasLimit: 600000, Data: data},
+	}
+	return opportunity
+}
+
+// newTestFundingPlanner creates a planner over chain with WETH/USDC pools at the
+// 0.01%, 0.05% and 0.3% fee tiers
+func newTestFundingPlanner(t *testing.T, config *interfaces.FundingPlannerConfig, chain *fakeLenderChain) *FundingPlannerImpl {
+	pools := &fakeFlashPools{pools: []*interfaces.PoolInfo{
+		{Address: testFlashPool3, Protocol: interfaces.ProtocolUniswapV3, Token0: testWETH, Token1: testUSDC, Fee: 3000},
+		{Address: testFlashPool2, Protocol: interfaces.ProtocolUniswapV3, Token0: testWETH, Token1: testUSDC, Fee: 500},
+		{Address: testFlashPool1, Protocol: interfaces.ProtocolUniswapV3, Token0: testWETH, Token1: testUSDC, Fee: 100},
+	}}
+	planner, err := NewFundingPlanner(config, chain, pools)
+	require.NoError(t, err)
+	return planner
+}
+
+func TestFundingPlanner_Quote(t *testing.T) {
+	ctx := context.Background()
+	amount := units(10, 18)
+	chain := newFakeLenderChain()
+	chain.setBalance(testWETH, BaseBalancerVault, big.NewInt(5e18))
+	chain.setBalance(testWETH, testAWETH, units(100, 18))
+	chain.setBalance(testWETH, testFlashPool1, units(20, 18))
+	chain.setBalance(testWETH, testFlashPool2, units(20, 18))
+	chain.setBalance(testWETH, testFlashPool3, units(50, 18))
+	planner := newTestFundingPlanner(t, nil, chain)
+
+	// The Vault and Morpho are short; the 0.05% pool ties Aave's 5 bps on fee and
+	// wins on gas
+	plans, err := planner.Quote(ctx, testWETH, amount, []common.Address{testFlashPool1})
+	require.NoError(t, err)
+	require.Len(t, plans, 3)
+	assert.Equal(t, string(interfaces.FlashLoanUniswapV3), plans[0].Provider)
+	assert.Equal(t, testFlashPool2, plans[0].Pool)
+	assert.Equal(t, big.NewInt(5e15), plans[0].Fee)
+	assert.Equal(t, string(interfaces.FlashLoanAaveV3), plans[1].Provider)
+	assert.Equal(t, lending.BaseAaveV3Pool, plans[1].Pool)
+	assert.Equal(t, big.NewInt(5e15), plans[1].Fee)
+	assert.Equal(t, units(100, 18), plans[1].Available)
+	assert.Equal(t, testFlashPool3, plans[2].Pool)
+	assert.Equal(t, big.NewInt(3e16), plans[2].Fee)
+
+	// A Vault with enough liquidity lends for free
+	chain.setBalance(testWETH, BaseBalancerVault, units(20, 18))
+	plans, err = planner.Quote(ctx, testWETH, amount, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(interfaces.FlashLoanBalancer), plans[0].Provider)
+	assert.Equal(t, 0, plans[0].Fee.Sign())
+
+	_, err = planner.Quote(ctx, testWETH, units(1000, 18), nil)
+	assert.ErrorContains(t, err, "no flash loan source")
+	_, err = planner.Quote(ctx, testUSDC, big.NewInt(1e6), nil)
+	assert.ErrorContains(t, err, "not listed on Aave")
+	_, err = planner.Quote(ctx, common.Address{}, amount, nil)
+	assert.Error(t, err)
+}
+
+func TestFundingPlanner_Fund(t *testing.T) {
+	ctx := context.Background()
+	aaveOnly := &interfaces.FundingPlannerConfig{Sources: []interfaces.FlashLoanSourceConfig{
+		{Source: interfaces.FlashLoanAaveV3, Lender: lending.BaseAaveV3Pool, FeeBps: 5, GasOverhead: 110000},
+	}}
+	chain := newFakeLenderChain()
+	chain.setBalance(testWETH, testAWETH, units(100, 18))
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	planner := newTestFundingPlanner(t, aaveOnly, chain)
+	planner.SetPriceOracle(oracle)
+	planner.SetCapitalSource(fixedCapital{big.NewInt(0)})
+	opportunity := cyclicOpportunity(t, units(10, 18))
+	original := opportunity.ExecutionTxs[0]
+
+	plans, err := planner.Fund(ctx, opportunity)
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, big.NewInt(5e15), plans[0].Fee)
+	assert.Equal(t, big.NewInt(5e15), opportunity.ExpectedProfit)
+	assert.Equal(t, big.NewInt(45e14), opportunity.NetProfit)
+	assert.Equal(t, big.NewInt(5e14+11e13), opportunity.GasCost)
+	assert.Equal(t, big.NewInt(5e15-5e14-11e13), opportunity.NetProfitETH)
+	assert.Equal(t, plans, opportunity.Metadata["flash_loans"])
+
+	// The executor call is wrapped, and the risk engine still sees its input
+	funded := opportunity.ExecutionTxs[0]
+	assert.NotSame(t, original, funded)
+	assert.Equal(t, uint64(710000), funded.GasLimit)
+	inner, ok := decodeFlashExecute(funded.Data)
+	require.True(t, ok)
+	assert.Equal(t, original.Data, inner)
+	values, err := executorContract.Methods["flashExecute"].Inputs.Unpack(funded.Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, uint8(1), values[0])
+	assert.Equal(t, lending.BaseAaveV3Pool, values[1])
+	assert.Equal(t, big.NewInt(5e15), values[4])
+	tokenIn, amountIn, _, ok := decodeExecute(funded.Data)
+	require.True(t, ok)
+	assert.Equal(t, testWETH, tokenIn)
+	assert.Equal(t, units(10, 18), amountIn)
+
+	// Calls that can't be flash funded are left for the inventory to cover
+	tests := []struct {
+		name      string
+		cyclic    bool
+		amount    *big.Int
+		capital   *big.Int
+		noCapital bool
+		wantErr   string
+	}{
+		{name: "covered by inventory", cyclic: true, amount: units(10, 18), capital: units(20, 18)},
+		{name: "no capital source", cyclic: true, amount: units(10, 18), noCapital: true},
+		{name: "inventory not yet known", cyclic: true, amount: units(10, 18)},
+		{name: "not repayable", amount: units(10, 18), capital: big.NewInt(0)},
+		{name: "no liquidity", cyclic: true, amount: units(1000, 18), capital: big.NewInt(0)},
+		{name: "fee over profit", cyclic: true, amount: units(100, 18), capital: big.NewInt(0), wantErr: "exceed the expected profit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			planner := newTestFundingPlanner(t, aaveOnly, chain)
+			if !tt.noCapital {
+				planner.SetCapitalSource(fixedCapital{tt.capital})
+			}
+			opportunity := riskOpportunity(t, "opp_1", testDAI, tt.amount)
+			if tt.cyclic {
+				opportunity = cyclicOpportunity(t, tt.amount)
+			}
+			before := opportunity.ExecutionTxs[0]
+
+			plans, err := planner.Fund(ctx, opportunity)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Empty(t, plans)
+			assert.Same(t, before, opportunity.ExecutionTxs[0])
+			assert.Equal(t, big.NewInt(1e16), opportunity.ExpectedProfit)
+		})
+	}
+}
+
+// fixedCapital reports the same available capital of every token
+type fixedCapital struct {
+	amount *big.Int
+}
+
+func (c fixedCapital) AvailableCapital(token common.Address) *big.Int {
+	return c.amount
+}
+
+func TestTradeExecutor_FundingPlanner(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeLenderChain()
+	chain.setBalance(testWETH, BaseBalancerVault, units(100, 18))
+	executor := NewTradeExecutor(nil, nil, &fakeSubmitter{}, nil, nil)
+	planner := newTestFundingPlanner(t, nil, chain)
+	planner.SetCapitalSource(fixedCapital{big.NewInt(0)})
+	executor.SetFundingPlanner(planner)
+
+	// Paper trades pay for the loan's gas
+	result, err := executor.Execute(ctx, cyclicOpportunity(t, units(10, 18)))
+	require.NoError(t, err)
+	require.NotNil(t, result.Trade)
+	assert.True(t, result.Success)
+	assert.Equal(t, big.NewInt(5e14+8e13), result.Trade.GasCost)
+
+	// Calls that can't repay a loan go out unfunded
+	opportunity := riskOpportunity(t, "opp_2", testWETH, units(10, 18))
+	unfunded := opportunity.ExecutionTxs[0]
+	result, err = executor.Execute(ctx, opportunity)
+	require.NoError(t, err)
+	require.NotNil(t, result.Trade)
+	assert.True(t, result.Success)
+	assert.Same(t, unfunded, opportunity.ExecutionTxs[0])
+}
@@ -0,0 +1,587 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+)
+
+// BalanceChain reads ETH and ERC-20 balances
+type BalanceChain interface {
+	pricing.ContractCaller
+	BlockNumber(ctx context.Context) (uint64, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// InclusionChain reads the blocks, receipts and balances inclusion is judged from
+type InclusionChain interface {
+	BalanceChain
+	// TransactionReceipt returns ethereum.NotFound for transactions not yet included
+	TransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// L1FeeChain reads the L1 data fee a transaction paid. OP Stack receipts report
+// it as l1Fee, which go-ethereum's Receipt doesn't decode; without it the L1 fee
+// is taken as zero.
+type L1FeeChain interface {
+	L1Fee(ctx context.Context, hash common.Hash) (*big.Int, error)
+}
+
+// OPStackChain is an InclusionChain over an OP Stack node that reads L1 fees
+type OPStackChain struct {
+	*ethclient.Client
+}
+
+// NewOPStackChain creates an OP Stack chain over an RPC client
+func NewOPStackChain(client *ethclient.Client) *OPStackChain {
+	return &OPStackChain{Client: client}
+}
+
+// L1Fee returns the l1Fee of a transaction's receipt, or zero if it has none
+func (c *OPStackChain) L1Fee(ctx context.Context, hash common.Hash) (*big.Int, error) {
+	var receipt struct {
+		L1Fee *hexutil.Big `json:"l1Fee"`
+	}
+	if err := c.Client.Client().CallContext(ctx, &receipt, "eth_getTransactionReceipt", hash); err != nil {
+		return nil, err
+	}
+	if receipt.L1Fee == nil {
+		return big.NewInt(0), nil
+	}
+	return receipt.L1Fee.ToInt(), nil
+}
+
+// DefaultInclusionMonitorConfig returns the default inclusion monitor configuration
+func DefaultInclusionMonitorConfig() *interfaces.InclusionMonitorConfig {
+	return &interfaces.InclusionMonitorConfig{
+		ExpiryBlocks: 25,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// trackedState is a tracked bundle and what is known of its transactions
+type trackedState struct {
+	bundle    *interfaces.TrackedBundle
+	submitted uint64 // Head when the bundle was tracked
+	receipts  []*ethtypes.Receipt
+	replaced  []bool
+}
+
+// InclusionMonitorImpl implements the InclusionMonitor interface. Realized profit
+// is what the bundle's own receipts transferred to the profit holder, so other
+// activity in the same blocks doesn't count; gas is the L2 fees of our receipts
+// plus their L1 data fees.
+type InclusionMonitorImpl struct {
+	config      *interfaces.InclusionMonitorConfig
+	chain       InclusionChain
+	metrics     interfaces.MetricsCollector
+	logger      interfaces.TransactionLogger
+	nonces      interfaces.NonceManager
+	priceOracle interfaces.PriceOracle
+	positions   interfaces.PositionTracker
+	risk        interfaces.RiskEngine
+	tracked     []*trackedState
+	running     bool
+	stopChan    chan struct{}
+	mu          sync.Mutex
+	checking    sync.Mutex // Held through a check, which updates tracked state
+}
+
+// NewInclusionMonitor creates an inclusion monitor. Realized trades are recorded with
+// the metrics collector and written back to the replay log; either may be nil, as
+// may the nonce manager that expired bundles' nonces are released to.
+func NewInclusionMonitor(config *interfaces.InclusionMonitorConfig, chain InclusionChain, metrics interfaces.MetricsCollector, logger interfaces.TransactionLogger, nonces interfaces.NonceManager) *InclusionMonitorImpl {
+	if config == nil {
+		config = DefaultInclusionMonitorConfig()
+	}
+	return &InclusionMonitorImpl{
+		config:   config,
+		chain:    chain,
+		metrics:  metrics,
+		logger:   logger,
+		nonces:   nonces,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// SetPriceOracle sets the oracle that values realized profits in ETH and USD
+func (m *InclusionMonitorImpl) SetPriceOracle(oracle interfaces.PriceOracle) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.priceOracle = oracle
+}
+
+// SetPositionTracker sets the tracker final bundles' balance changes are applied to
+func (m *InclusionMonitorImpl) SetPositionTracker(positions interfaces.PositionTracker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.positions = positions
+}
+
+// SetRiskEngine sets the risk engine final bundles are settled with
+func (m *InclusionMonitorImpl) SetRiskEngine(risk interfaces.RiskEngine) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.risk = risk
+}
+
+// Track starts watching a submitted bundle from the current head
+func (m *InclusionMonitorImpl) Track(ctx context.Context, bundle *interfaces.TrackedBundle) error {
+	if bundle == nil || bundle.Trade == nil {
+		return errors.New("tracked bundle needs its submitted trade")
+	}
+	if len(bundle.Transactions) == 0 || len(bundle.Transactions) != len(bundle.TxHashes) {
+		return fmt.Errorf("bundle has %d transactions and %d hashes", len(bundle.Transactions), len(bundle.TxHashes))
+	}
+	head, err := m.chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read block number: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tracked = append(m.tracked, &trackedState{
+		bundle:    bundle,
+		submitted: head,
+		receipts:  make([]*ethtypes.Receipt, len(bundle.TxHashes)),
+		replaced:  make([]bool, len(bundle.TxHashes)),
+	})
+	return nil
+}
+
+// Pending returns how many bundles are still being watched
+func (m *InclusionMonitorImpl) Pending() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.tracked)
+}
+
+// Start checks the tracked bundles every poll interval until stopped
+func (m *InclusionMonitorImpl) Start(ctx context.Context) error {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return fmt.Errorf("inclusion monitor is already running")
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	go m.poll(ctx)
+	return nil
+}
+
+// Stop stops the inclusion monitor
+func (m *InclusionMonitorImpl) Stop() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.running {
+		return fmt.Errorf("inclusion monitor is not running")
+	}
+	close(m.stopChan)
+	m.running = false
+	return nil
+}
+
+// poll runs Check every poll interval
+func (m *InclusionMonitorImpl) poll(ctx context.Context) {
+	ticker := time.NewTicker(m.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := m.Check(ctx); err != nil {
+				log.Printf("Inclusion check failed: %v", err)
+			}
+		case <-m.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check looks up the tracked bundles' transactions at the current head. A bundle
+// is final once each transaction has landed or had its nonce used by another, or
+// when it expires. Final bundles are reconciled, recorded and no longer tracked.
+func (m *InclusionMonitorImpl) Check(ctx context.Context) ([]*interfaces.InclusionResult, error) {
+	m.checking.Lock()
+	defer m.checking.Unlock()
+
+	head, err := m.chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block number: %w", err)
+	}
+
+	m.mu.Lock()
+	tracked := append([]*trackedState(nil), m.tracked...)
+	m.mu.Unlock()
+
+	var (
+		results []*interfaces.InclusionResult
+		final   = make(map[*trackedState]bool)
+		errs    []error
+		nonces  = make(map[common.Address]uint64)
+	)
+	for _, state := range tracked {
+		pending, err := m.lookUp(ctx, state, nonces)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if pending && head < state.submitted+m.config.ExpiryBlocks {
+			continue
+		}
+
+		result, err := m.reconcile(ctx, state)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		m.record(ctx, result)
+		results = append(results, result)
+		final[state] = true
+	}
+
+	if len(final) > 0 {
+		m.mu.Lock()
+		remaining := m.tracked[:0]
+		for _, state := range m.tracked {
+			if !final[state] {
+				remaining = append(remaining, state)
+			}
+		}
+		m.tracked = remaining
+		m.mu.Unlock()
+	}
+	return results, errors.Join(errs...)
+}
+
+// lookUp fetches receipts of the bundle's outstanding transactions and reports
+// whether any are still pending. A transaction without a receipt whose nonce the
+// sender has used was replaced.
+func (m *InclusionMonitorImpl) lookUp(ctx context.Context, state *trackedState, nonces map[common.Address]uint64) (bool, error) {
+	pending := false
+	for i, hash := range state.bundle.TxHashes {
+		if state.receipts[i] != nil || state.replaced[i] {
+			continue
+		}
+
+		receipt, err := m.receipt(ctx, hash)
+		if err != nil {
+			return false, err
+		}
+		if receipt != nil {
+			state.receipts[i] = receipt
+			continue
+		}
+
+		tx := state.bundle.Transactions[i]
+		nonce, cached := nonces[tx.From]
+		if !cached {
+			nonce, err = m.chain.NonceAt(ctx, tx.From, nil)
+			if err != nil {
+				return false, fmt.Errorf("failed to read nonce of %s: %w", tx.From.Hex(), err)
+			}
+			nonces[tx.From] = nonce
+		}
+		if nonce <= tx.Nonce {
+			pending = true
+			continue
+		}
+
+		// The nonce may have been used by this transaction since the receipt lookup
+		receipt, err = m.receipt(ctx, hash)
+		if err != nil {
+			return false, err
+		}
+		if receipt != nil {
+			state.receipts[i] = receipt
+		} else {
+			state.replaced[i] = true
+		}
+	}
+	return pending, nil
+}
+
+// receipt returns a transaction's receipt, or nil if it hasn't been included
+func (m *InclusionMonitorImpl) receipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, err := m.chain.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read receipt of %s: %w", hash.Hex(), err)
+	}
+	return receipt, nil
+}
+
+// reconcile decides a final bundle's status and computes what it realized
+func (m *InclusionMonitorImpl) reconcile(ctx context.Context, state *trackedState) (*interfaces.InclusionResult, error) {
+	bundle := state.bundle
+	result := &interfaces.InclusionResult{
+		Bundle: bundle,
+		Status: interfaces.InclusionIncluded,
+		L1Fee:  big.NewInt(0),
+	}
+
+	var target string
+	if bundle.Opportunity != nil {
+		target = bundle.Opportunity.TargetTx
+	}
+	var (
+		firstBlock, lastBlock uint64
+		lastOwn               string // Hash of our last landed transaction
+	)
+	for i, receipt := range state.receipts {
+		if receipt == nil {
+			if state.replaced[i] && result.Status == interfaces.InclusionIncluded {
+				result.Status = interfaces.InclusionReplaced
+			} else if !state.replaced[i] && result.Status != interfaces.InclusionReverted {
+				result.Status = interfaces.InclusionExpired
+			}
+			continue
+		}
+
+		result.Receipts = append(result.Receipts, receipt)
+		if !isTarget(target, bundle.Transactions[i]) {
+			lastOwn = receipt.TxHash.Hex()
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			result.Status = interfaces.InclusionReverted
+		}
+		block := receipt.BlockNumber.Uint64()
+		if firstBlock == 0 || block < firstBlock {
+			firstBlock = block
+		}
+		if block > lastBlock {
+			lastBlock = block
+		}
+	}
+	result.BlockNumber = lastBlock
+
+	trade := *bundle.Trade
+	trade.Success = result.Status == interfaces.InclusionIncluded
+	trade.ExecutionTime = time.Since(bundle.Trade.ExecutedAt)
+	trade.ActualProfit = big.NewInt(0)
+	trade.GasCost = big.NewInt(0)
+	trade.ErrorMessage = ""
+	if !trade.Success {
+		trade.ErrorMessage = fmt.Sprintf("bundle %s", result.Status)
+	}
+	if len(result.Receipts) > 0 {
+		if lastOwn != "" {
+			trade.TransactionHash = lastOwn
+		}
+		if err := m.realized(ctx, state, firstBlock, lastBlock, result, &trade); err != nil {
+			return nil, fmt.Errorf("failed to reconcile trade %s: %w", trade.ID, err)
+		}
+	}
+
+	m.mu.Lock()
+	oracle := m.priceOracle
+	m.mu.Unlock()
+	priceTrade(&trade, oracle)
+	result.Trade = &trade
+	return result, nil
+}
+
+// realized computes a landed bundle's profit, gas cost and L1 data fee from the
+// receipts of its own transactions. Profit is what the holder's Transfer logs net
+// in the profit token; the L1 fee is read from receipts on chains that report it.
+// The holder's ETH and profit token balances from before the first landed block
+// to the end of the last are reconciled against the logs.
+func (m *InclusionMonitorImpl) realized(ctx context.Context, state *trackedState, firstBlock, lastBlock uint64, result *interfaces.InclusionResult, trade *interfaces.TradeResult) error {
+	bundle := state.bundle
+	var target string
+	if bundle.Opportunity != nil {
+		target = bundle.Opportunity.TargetTx
+	}
+	holder := profitHolder(m.config.Executor, bundle.Transactions, target)
+	feeChain, _ := m.chain.(L1FeeChain)
+
+	transfers := make(map[common.Address]*big.Int)
+	l2Fee := new(big.Int)
+	l1Fee := new(big.Int)
+	spent := new(big.Int) // ETH our fees and value took from the holder, less value sent to it
+	for i, receipt := range state.receipts {
+		if receipt == nil {
+			continue
+		}
+		tx := bundle.Transactions[i]
+		addTransfers(transfers, holder, receipt.Logs)
+		if isTarget(target, tx) {
+			continue // Its sender pays its fees
+		}
+
+		fee := new(big.Int)
+		gasPrice := receipt.EffectiveGasPrice
+		if gasPrice == nil {
+			gasPrice = tx.GasPrice
+		}
+		if gasPrice != nil {
+			fee.Mul(new(big.Int).SetUint64(receipt.GasUsed), gasPrice)
+			l2Fee.Add(l2Fee, fee)
+		}
+		if feeChain != nil {
+			txL1Fee, err := feeChain.L1Fee(ctx, receipt.TxHash)
+			if err != nil {
+				return fmt.Errorf("failed to read L1 fee of %s: %w", receipt.TxHash.Hex(), err)
+			}
+			l1Fee.Add(l1Fee, txL1Fee)
+			fee.Add(fee, txL1Fee)
+		}
+
+		value := bigOrZero(tx.Value)
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			value = big.NewInt(0) // A revert returns the value
+		}
+		switch {
+		case tx.From == holder:
+			spent.Add(spent, fee).Add(spent, value)
+		case tx.To != nil && *tx.To == holder:
+			spent.Sub(spent, value)
+		}
+	}
+
+	m.mu.Lock()
+	oracle := m.priceOracle
+	m.mu.Unlock()
+	profit, err := profitIn(bundle.Trade.ProfitToken, transfers, oracle)
+	if err != nil {
+		return err
+	}
+
+	// Native ETH moves without Transfer logs, so the holder's ETH should change only
+	// by what the bundle spent and its profit token by what the logs net
+	before := new(big.Int).SetUint64(firstBlock - 1)
+	after := new(big.Int).SetUint64(lastBlock)
+	deltas := make(map[common.Address]*big.Int)
+	expected := map[common.Address]*big.Int{common.Address{}: new(big.Int).Neg(spent)}
+	if token := bundle.Trade.ProfitToken; token != (common.Address{}) {
+		expected[token] = bigOrZero(transfers[token])
+	}
+	for token, want := range expected {
+		delta, err := m.balanceDelta(ctx, token, holder, before, after)
+		if err != nil {
+			return err
+		}
+		deltas[token] = delta
+		if delta.Cmp(want) != 0 {
+			log.Printf("Trade %s: %s balance of %s changed by %s over blocks %d-%d, its logs and fees account for %s",
+				trade.ID, tokenName(token), holder.Hex(), delta, firstBlock, lastBlock, want)
+		}
+	}
+
+	trade.ActualProfit = profit
+	trade.GasCost = new(big.Int).Add(l2Fee, l1Fee)
+	result.L1Fee = l1Fee
+	result.BalanceDeltas = deltas
+	return nil
+}
+
+// balanceDelta returns how an account's balance of a token, or ETH for the zero
+// address, changed between two blocks
+func (m *InclusionMonitorImpl) balanceDelta(ctx context.Context, token, account common.Address, before, after *big.Int) (*big.Int, error) {
+	balanceBefore, err := balanceAt(ctx, m.chain, token, account, before)
+	if err != nil {
+		return nil, err
+	}
+	balanceAfter, err := balanceAt(ctx, m.chain, token, account, after)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Sub(balanceAfter, balanceBefore), nil
+}
+
+// tokenName names a token for logs, with ETH for the zero address
+func tokenName(token common.Address) string {
+	if token == (common.Address{}) {
+		return "ETH"
+	}
+	return token.Hex()
+}
+
+// balanceAt reads an account's balance of a token, or ETH for the zero address, at
+// a block or, for a nil block, the latest one
+func balanceAt(ctx context.Context, chain BalanceChain, token, account common.Address, block *big.Int) (*big.Int, error) {
+	if token == (common.Address{}) {
+		balance, err := chain.BalanceAt(ctx, account, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read balance of %s at block %s: %w", account.Hex(), block, err)
+		}
+		return balance, nil
+	}
+	return tokenBalanceAt(ctx, chain, token, account, block)
+}
+
+// tokenBalanceAt reads an account's ERC-20 balance at a block or, for a nil block,
+// the latest one
+func tokenBalanceAt(ctx context.Context, chain pricing.ContractCaller, token, account common.Address, block *big.Int) (*big.Int, error) {
+	data, err := erc20.Pack("balanceOf", account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+	}
+	output, err := chain.CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s balance of %s at block %s: %w", token.Hex(), account.Hex(), block, err)
+	}
+	values, err := erc20.Unpack("balanceOf", output)
+	if err != nil || len(values) != 1 {
+		return nil, fmt.Errorf("failed to decode %s balance of %s: %v", token.Hex(), account.Hex(), err)
+	}
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected balanceOf result %T", values[0])
+	}
+	return balance, nil
+}
+
+// record reports a realized trade, writes it back to the replay log, updates our
+// positions, settles it with the risk engine and releases the nonces of bundles
+// that didn't land, cancelling any that later bundles wait on
+func (m *InclusionMonitorImpl) record(ctx context.Context, result *interfaces.InclusionResult) {
+	m.mu.Lock()
+	positions, risk := m.positions, m.risk
+	m.mu.Unlock()
+	if positions != nil {
+		positions.Apply(result)
+	}
+	if risk != nil {
+		risk.Settle(result.Trade)
+	}
+
+	trade := result.Trade
+	if m.metrics != nil {
+		if err := m.metrics.RecordTrade(ctx, trade); err != nil {
+			log.Printf("Failed to record trade %s: %v", trade.ID, err)
+		}
+	}
+	if m.logger != nil {
+		if err := m.logger.UpdateLogWithActualResult(ctx, trade.ID, trade); err != nil {
+			log.Printf("Failed to update log of trade %s: %v", trade.ID, err)
+		}
+	}
+
+	if m.nonces == nil {
+		return
+	}
+	if result.Status == interfaces.InclusionExpired || result.Status == interfaces.InclusionReplaced {
+		// Nonces the bundle didn't use become gaps; the resync drops the ones it did
+		releaseNonces(ctx, m.nonces, result.Bundle.Nonces, true)
+	}
+}
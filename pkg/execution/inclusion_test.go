@@ -0,0 +1,437 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/metrics"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeInclusionChain serves receipts, their L1 fees, nonces and per-block balances
+type fakeInclusionChain struct {
+	head     uint64
+	receipts map[common.Hash]*ethtypes.Receipt
+	l1Fees   map[common.Hash]*big.Int
+	nonces   map[common.Address]uint64
+	balances map[common.Address]map[common.Address]map[uint64]*big.Int // token, account, block
+}
+
+func newFakeInclusionChain(head uint64) *fakeInclusionChain {
+	return &fakeInclusionChain{
+		head:     head,
+		receipts: make(map[common.Hash]*ethtypes.Receipt),
+		l1Fees:   make(map[common.Hash]*big.Int),
+		nonces:   make(map[common.Address]uint64),
+		balances: make(map[common.Address]map[common.Address]map[uint64]*big.Int),
+	}
+}
+
+// setBalance sets an account's balance of a token, or ETH for the zero address, at a block
+func (f *fakeInclusionChain) setBalance(token, account common.Address, block uint64, balance *big.Int) {
+	if f.balances[token] == nil {
+		f.balances[token] = make(map[common.Address]map[uint64]*big.Int)
+	}
+	if f.balances[token][account] == nil {
+		f.balances[token][account] = make(map[uint64]*big.Int)
+	}
+	f.balances[token][account][block] = balance
+}
+
+func (f *fakeInclusionChain) balance(token, account common.Address, block *big.Int) (*big.Int, error) {
+	balance, exists := f.balances[token][account][block.Uint64()]
+	if !exists {
+		return nil, errors.New("missing trie node")
+	}
+	return balance, nil
+}
+
+func (f *fakeInclusionChain) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	args, err := erc20.Methods["balanceOf"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	balance, err := f.balance(*call.To, args[0].(common.Address), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return erc20.Methods["balanceOf"].Outputs.Pack(balance)
+}
+
+func (f *fakeInclusionChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeInclusionChain) TransactionReceipt(ctx context.Context, hash common.Hash) (*ethtypes.Receipt, error) {
+	receipt, exists := f.receipts[hash]
+	if !exists {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+func (f *fakeInclusionChain) L1Fee(ctx context.Context, hash common.Hash) (*big.Int, error) {
+	if fee, exists := f.l1Fees[hash]; exists {
+		return fee, nil
+	}
+	return big.NewInt(0), nil
+}
+
+func (f *fakeInclusionChain) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return f.nonces[account], nil
+}
+
+func (f *fakeInclusionChain) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return f.balance(common.Address{}, account, blockNumber)
+}
+
+// trackedTestBundle is a submitted two-transaction bundle at nonces 10 and 11,
+// expected to make 0.01 of the profit token
+func trackedTestBundle(profitToken common.Address, value *big.Int) *interfaces.TrackedBundle {
+	opportunity := testOpportunity()
+	opportunity.ProfitToken = profitToken
+	transactions := make([]*types.Transaction, 2)
+	for i := range transactions {
+		tx := *opportunity.ExecutionTxs[i]
+		tx.Nonce = uint64(10 + i)
+		tx.Value = big.NewInt(0)
+		transactions[i] = &tx
+	}
+	transactions[0].Value = value
+
+	return &interfaces.TrackedBundle{
+		Opportunity: opportunity,
+		Trade: &interfaces.TradeResult{
+			ID:             "trade_opp_1",
+			Strategy:       opportunity.Strategy,
+			OpportunityID:  opportunity.ID,
+			ExecutedAt:     time.Now(),
+			Success:        true,
+			ActualProfit:   opportunity.ExpectedProfit,
+			ExpectedProfit: opportunity.ExpectedProfit,
+			GasCost:        opportunity.GasCost,
+			ProfitToken:    profitToken,
+			Mode:           interfaces.ExecutionModeLive,
+		},
+		Transactions: transactions,
+		TxHashes:     []common.Hash{{0x01}, {0x02}},
+	}
+}
+
+// land adds receipts for a bundle's transactions in a block, each using 100k gas at 1 gwei
+func land(chain *fakeInclusionChain, bundle *interfaces.TrackedBundle, block uint64, statuses ...uint64) {
+	for i, hash := range bundle.TxHashes {
+		chain.receipts[hash] = &ethtypes.Receipt{
+			Status:            statuses[i],
+			TxHash:            hash,
+			GasUsed:           100000,
+			EffectiveGasPrice: big.NewInt(1e9),
+			BlockNumber:       new(big.Int).SetUint64(block),
+		}
+	}
+	chain.nonces[bundle.Transactions[0].From] = 12
+}
+
+func TestInclusionMonitor_Included(t *testing.T) {
+	l2Fee := big.NewInt(2 * 100000 * 1e9)
+	l1Fee := big.NewInt(3e13)
+	gasCost := new(big.Int).Add(l2Fee, l1Fee)
+	profit := big.NewInt(8e15)
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		profitToken   common.Address
+		value         *big.Int
+		logs          []*ethtypes.Log
+		wantNetProfit *big.Int // In wei of ETH
+	}{
+		{
+			name:          "ETH profit held by the executor",
+			value:         big.NewInt(1e17),
+			logs:          roundTrip(units(5, 18), profit),
+			wantNetProfit: new(big.Int).Sub(profit, gasCost),
+		},
+		{
+			// The oracle has no USDC price, so the USDC profit and ETH gas don't net
+			name:        "token profit held by the executor",
+			profitToken: testUSDC,
+			value:       big.NewInt(0),
+			logs: []*ethtypes.Log{
+				transferLog(testUSDC, testExecutor, testFlashPool1, units(1000, 6)),
+				transferLog(testUSDC, testFlashPool1, testExecutor, new(big.Int).Add(units(1000, 6), profit)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := newFakeInclusionChain(100)
+			bundle := trackedTestBundle(tt.profitToken, tt.value)
+
+			collector := metrics.NewCollectorWithRegistry(nil, prometheus.NewRegistry())
+			logger := &fakeTransactionLogger{}
+			monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{Executor: testExecutor, ExpiryBlocks: 5}, chain, collector, logger, nil)
+			monitor.SetPriceOracle(oracle)
+			require.NoError(t, monitor.Track(context.Background(), bundle))
+
+			// Nothing has landed yet
+			results, err := monitor.Check(context.Background())
+			require.NoError(t, err)
+			assert.Empty(t, results)
+			assert.Equal(t, 1, monitor.Pending())
+
+			// The executor's balance moves by more than the bundle made, which
+			// doesn't count
+			chain.head = 101
+			land(chain, bundle, 101, 1, 1)
+			chain.receipts[bundle.TxHashes[1]].Logs = tt.logs
+			chain.l1Fees[bundle.TxHashes[0]] = big.NewInt(1e13)
+			chain.l1Fees[bundle.TxHashes[1]] = big.NewInt(2e13)
+			chain.setBalance(common.Address{}, testExecutor, 100, big.NewInt(1e18))
+			chain.setBalance(common.Address{}, testExecutor, 101, big.NewInt(1e18))
+			chain.setBalance(tt.profitToken, testExecutor, 100, big.NewInt(5e18))
+			chain.setBalance(tt.profitToken, testExecutor, 101, big.NewInt(9e18))
+			results, err = monitor.Check(context.Background())
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+			assert.Zero(t, monitor.Pending())
+
+			result := results[0]
+			assert.Equal(t, interfaces.InclusionIncluded, result.Status)
+			assert.Equal(t, uint64(101), result.BlockNumber)
+			assert.Len(t, result.Receipts, 2)
+			assert.Equal(t, l1Fee, result.L1Fee)
+			assert.Equal(t, big.NewInt(4e18), result.BalanceDeltas[tt.profitToken])
+
+			trade := result.Trade
+			assert.True(t, trade.Success)
+			assert.Equal(t, "trade_opp_1", trade.ID)
+			assert.Equal(t, profit, trade.ActualProfit)
+			assert.Equal(t, bundle.Trade.ExpectedProfit, trade.ExpectedProfit)
+			assert.Equal(t, gasCost, trade.GasCost)
+			assert.Equal(t, tt.wantNetProfit, trade.NetProfit)
+			assert.Equal(t, common.Hash{0x02}.Hex(), trade.TransactionHash)
+
+			// The realized trade is recorded and written back to the replay log
+			assert.Same(t, trade, logger.updates["trade_opp_1"])
+			performance, err := collector.GetPerformanceMetrics()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), performance.TransactionsProcessed)
+		})
+	}
+}
+
+func TestInclusionMonitor_TargetInBundle(t *testing.T) {
+	chain := newFakeInclusionChain(100)
+	bundle := trackedTestBundle(testUSDC, big.NewInt(0))
+	victim := common.HexToAddress("0x00000000000000000000000000000000000f1c71")
+	target := bundle.Transactions[1]
+	target.Hash = bundle.TxHashes[1].Hex()
+	target.From = victim
+	bundle.Opportunity.TargetTx = target.Hash
+
+	monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{ExpiryBlocks: 5}, chain, nil, nil, nil)
+	require.NoError(t, monitor.Track(context.Background(), bundle))
+
+	// The target's swap pays the victim and its sender pays its fees
+	land(chain, bundle, 101, 1, 1)
+	chain.receipts[bundle.TxHashes[0]].Logs = []*ethtypes.Log{transferLog(testUSDC, testFlashPool1, testSearcher, units(5, 6))}
+	chain.receipts[bundle.TxHashes[1]].Logs = []*ethtypes.Log{transferLog(testUSDC, testFlashPool1, victim, units(900, 6))}
+	chain.l1Fees[bundle.TxHashes[0]] = big.NewInt(1e13)
+	chain.l1Fees[bundle.TxHashes[1]] = big.NewInt(2e13)
+	chain.head = 101
+
+	// The searcher holds the profit and its balances agree with the logs and fees
+	gasCost := big.NewInt(100000*1e9 + 1e13)
+	chain.setBalance(common.Address{}, testSearcher, 100, big.NewInt(1e18))
+	chain.setBalance(common.Address{}, testSearcher, 101, new(big.Int).Sub(big.NewInt(1e18), gasCost))
+	chain.setBalance(testUSDC, testSearcher, 100, big.NewInt(0))
+	chain.setBalance(testUSDC, testSearcher, 101, units(5, 6))
+
+	results, err := monitor.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, big.NewInt(1e13), results[0].L1Fee)
+	assert.Equal(t, map[common.Address]*big.Int{
+		{}:       new(big.Int).Neg(gasCost),
+		testUSDC: units(5, 6),
+	}, results[0].BalanceDeltas)
+	assert.Equal(t, units(5, 6), results[0].Trade.ActualProfit)
+	assert.Equal(t, gasCost, results[0].Trade.GasCost)
+	assert.Equal(t, common.Hash{0x01}.Hex(), results[0].Trade.TransactionHash)
+}
+
+func TestInclusionMonitor_Failures(t *testing.T) {
+	tests := []struct {
+		name         string
+		setup        func(chain *fakeInclusionChain, bundle *interfaces.TrackedBundle)
+		head         uint64
+		wantStatus   interfaces.InclusionStatus
+		wantGasCost  *big.Int
+		wantNextFrom uint64 // First nonce the nonce manager hands out afterwards
+	}{
+		{
+			name: "reverted",
+			setup: func(chain *fakeInclusionChain, bundle *interfaces.TrackedBundle) {
+				land(chain, bundle, 101, 1, 0)
+				chain.setBalance(common.Address{}, testExecutor, 100, big.NewInt(1e18))
+				chain.setBalance(common.Address{}, testExecutor, 101, big.NewInt(1e18))
+			},
+			head:         101,
+			wantStatus:   interfaces.InclusionReverted,
+			wantGasCost:  big.NewInt(2 * 100000 * 1e9),
+			wantNextFrom: 12,
+		},
+		{
+			name: "replaced by a cancel",
+			setup: func(chain *fakeInclusionChain, bundle *interfaces.TrackedBundle) {
+				chain.nonces[testSearcher] = 12
+			},
+			head:         101,
+			wantStatus:   interfaces.InclusionReplaced,
+			wantGasCost:  big.NewInt(0),
+			wantNextFrom: 12,
+		},
+		{
+			name: "expired",
+			setup: func(chain *fakeInclusionChain, bundle *interfaces.TrackedBundle) {
+				chain.nonces[testSearcher] = 10
+			},
+			head:         105,
+			wantStatus:   interfaces.InclusionExpired,
+			wantGasCost:  big.NewInt(0),
+			wantNextFrom: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			chain := newFakeInclusionChain(100)
+			source := &fakeNonceSource{pending: 10}
+			nonces := NewNonceManager(nil, testSearcher, source, nil)
+			reservation, err := nonces.Reserve(ctx, 2)
+			require.NoError(t, err)
+
+			bundle := trackedTestBundle(common.Address{}, big.NewInt(0))
+			bundle.Nonces = reservation
+			logger := &fakeTransactionLogger{}
+			monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{Executor: testExecutor, ExpiryBlocks: 5}, chain, nil, logger, nonces)
+			require.NoError(t, monitor.Track(context.Background(), bundle))
+
+			// Pending at submission
+			results, err := monitor.Check(ctx)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+
+			tt.setup(chain, bundle)
+			source.setPending(chain.nonces[testSearcher])
+			chain.head = tt.head
+			results, err = monitor.Check(ctx)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			assert.Equal(t, tt.wantStatus, results[0].Status)
+			trade := results[0].Trade
+			assert.False(t, trade.Success)
+			assert.Contains(t, trade.ErrorMessage, string(tt.wantStatus))
+			assert.Zero(t, trade.ActualProfit.Sign())
+			assert.Equal(t, tt.wantGasCost, trade.GasCost)
+			assert.Same(t, trade, logger.updates[trade.ID])
+
+			next, err := nonces.Reserve(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNextFrom, next.First)
+		})
+	}
+}
+
+func TestInclusionMonitor_ExpiresFromSubmission(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeInclusionChain(100)
+	chain.nonces[testSearcher] = 10
+	monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{ExpiryBlocks: 5}, chain, nil, nil, nil)
+	require.NoError(t, monitor.Track(ctx, trackedTestBundle(common.Address{}, big.NewInt(0))))
+
+	// The first check comes late, but expiry counts from the submission block
+	chain.head = 104
+	results, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	chain.head = 105
+	results, err = monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, interfaces.InclusionExpired, results[0].Status)
+}
+
+func TestInclusionMonitor_CancelsGaps(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeInclusionChain(100)
+	submitter := &fakeSubmitter{}
+	nonces := NewNonceManager(nil, testSearcher, &fakeNonceSource{pending: 10}, submitter)
+	reservation, err := nonces.Reserve(ctx, 2) // 10, 11
+	require.NoError(t, err)
+	_, err = nonces.Reserve(ctx, 1) // 12, a later bundle still in flight
+	require.NoError(t, err)
+
+	bundle := trackedTestBundle(common.Address{}, big.NewInt(0))
+	bundle.Nonces = reservation
+	monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{ExpiryBlocks: 5}, chain, nil, nil, nonces)
+	require.NoError(t, monitor.Track(context.Background(), bundle))
+	chain.nonces[testSearcher] = 10
+	_, err = monitor.Check(ctx)
+	require.NoError(t, err)
+
+	// The expired bundle's nonces are cancelled so the later one can land
+	chain.head = 105
+	results, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, interfaces.InclusionExpired, results[0].Status)
+	require.Len(t, submitter.bundles, 2)
+	assert.Equal(t, uint64(10), submitter.bundles[0][0].Nonce)
+	assert.Equal(t, uint64(11), submitter.bundles[1][0].Nonce)
+	assert.Empty(t, nonces.Gaps())
+}
+
+func TestTradeExecutor_InclusionMonitor(t *testing.T) {
+	collector := metrics.NewCollectorWithRegistry(nil, prometheus.NewRegistry())
+	logger := &fakeTransactionLogger{}
+	monitor := NewInclusionMonitor(nil, newFakeInclusionChain(100), collector, logger, nil)
+
+	config := DefaultExecutionConfig()
+	config.Mode = interfaces.ExecutionModeLive
+	executor := NewTradeExecutor(config, nil, &fakeSubmitter{}, collector, logger)
+	executor.SetInclusionMonitor(monitor)
+
+	result, err := executor.Execute(context.Background(), testOpportunity())
+	require.NoError(t, err)
+	require.True(t, result.Submitted)
+
+	// The submission is logged, but only the realized trade will reach the metrics
+	assert.Len(t, logger.opportunities, 1)
+	assert.Equal(t, 1, monitor.Pending())
+	performance, err := collector.GetPerformanceMetrics()
+	require.NoError(t, err)
+	assert.Zero(t, performance.TransactionsProcessed)
+
+	assert.Error(t, monitor.Track(context.Background(), &interfaces.TrackedBundle{}))
+}
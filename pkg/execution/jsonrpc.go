@@ -0,0 +1,132 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// jsonRPCRequest is a JSON-RPC 2.0 request
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      uint64        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// jsonRPCResponse is a JSON-RPC 2.0 response
+type jsonRPCResponse struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *jsonRPCError   `json:"error"`
+}
+
+// jsonRPCError is the error object of a failed JSON-RPC call
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *jsonRPCError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// httpStatusError is a JSON-RPC call rejected at the HTTP level
+type httpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("status %d: %s", e.StatusCode, e.Body)
+}
+
+// jsonRPCClient makes JSON-RPC calls over HTTP
+type jsonRPCClient struct {
+	url           string
+	headers       map[string]string
+	client        *http.Client
+	payloadSigner Signer // Signs request bodies for relays that authenticate searchers
+	nextID        atomic.Uint64
+}
+
+// newJSONRPCClient creates a client that sends headers, such as authorization,
+// with every call
+func newJSONRPCClient(url string, headers map[string]string, timeout time.Duration) *jsonRPCClient {
+	return &jsonRPCClient{
+		url:     url,
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+// call makes a JSON-RPC call and decodes its result into result
+func (c *jsonRPCClient) call(ctx context.Context, result interface{}, method string, params ...interface{}) error {
+	if params == nil {
+		params = []interface{}{}
+	}
+	body, err := json.Marshal(&jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      c.nextID.Add(1),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", method, err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range c.headers {
+		request.Header.Set(name, value)
+	}
+	if c.payloadSigner != nil {
+		signature, err := c.payloadSigner.SignBundlePayload(ctx, body)
+		if err != nil {
+			return fmt.Errorf("failed to sign %s request: %w", method, err)
+		}
+		request.Header.Set("X-Flashbots-Signature", c.payloadSigner.Address().Hex()+":"+hexutil.Encode(signature))
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", method, err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", method, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s request failed: %w", method, &httpStatusError{
+			StatusCode: response.StatusCode,
+			Body:       string(bytes.TrimSpace(responseBody)),
+		})
+	}
+
+	var decoded jsonRPCResponse
+	if err := json.Unmarshal(responseBody, &decoded); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, err)
+	}
+	if decoded.Error != nil {
+		return fmt.Errorf("%s failed: %w", method, decoded.Error)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(decoded.Result, result); err != nil {
+		return fmt.Errorf("failed to decode %s result: %w", method, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// GasPriceOracleAddress is the OP Stack GasPriceOracle predeploy
+var GasPriceOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+
+// L1FeeEstimator estimates the L1 data fee a transaction would pay on top of its L2 gas
+type L1FeeEstimator interface {
+	EstimateL1Fee(ctx context.Context, tx *types.Transaction, blockNumber uint64) (*big.Int, error)
+}
+
+// GasPriceOracle estimates L1 data fees with the GasPriceOracle predeploy's
+// getL1Fee, which pads the unsigned encoding it's given for the signature
+type GasPriceOracle struct {
+	caller pricing.ContractCaller
+}
+
+// NewGasPriceOracle creates an L1 fee estimator over a contract caller
+func NewGasPriceOracle(caller pricing.ContractCaller) *GasPriceOracle {
+	return &GasPriceOracle{caller: caller}
+}
+
+// EstimateL1Fee returns the L1 data fee of a transaction on the state after a block
+func (o *GasPriceOracle) EstimateL1Fee(ctx context.Context, tx *types.Transaction, blockNumber uint64) (*big.Int, error) {
+	encoded, err := ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    tx.Nonce,
+		GasPrice: bigOrZero(tx.GasPrice),
+		Gas:      tx.GasLimit,
+		To:       tx.To,
+		Value:    bigOrZero(tx.Value),
+		Data:     tx.Data,
+	}).MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	data, err := gasPriceOracle.Pack("getL1Fee", encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getL1Fee: %w", err)
+	}
+
+	address := GasPriceOracleAddress
+	output, err := o.caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call getL1Fee at block %d: %w", blockNumber, err)
+	}
+	values, err := gasPriceOracle.Unpack("getL1Fee", output)
+	if err != nil || len(values) != 1 {
+		return nil, fmt.Errorf("failed to decode getL1Fee: %v", err)
+	}
+	fee, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getL1Fee result %T", values[0])
+	}
+	return fee, nil
+}
@@ -0,0 +1,62 @@
+package execution
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGasPriceOracle answers getL1Fee with a fee per byte of the encoding it's given
+type fakeGasPriceOracle struct {
+	feePerByte int64
+	calls      []ethereum.CallMsg
+	blocks     []*big.Int
+}
+
+func (f *fakeGasPriceOracle) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.calls = append(f.calls, call)
+	f.blocks = append(f.blocks, blockNumber)
+	args, err := gasPriceOracle.Methods["getL1Fee"].Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	fee := big.NewInt(f.feePerByte * int64(len(args[0].([]byte))))
+	return gasPriceOracle.Methods["getL1Fee"].Outputs.Pack(fee)
+}
+
+func TestGasPriceOracle_EstimateL1Fee(t *testing.T) {
+	caller := &fakeGasPriceOracle{feePerByte: 1e9}
+	oracle := NewGasPriceOracle(caller)
+	to := common.HexToAddress("0x00000000000000000000000000000000000e0ec7")
+	tx := &types.Transaction{
+		To:       &to,
+		Nonce:    7,
+		GasPrice: big.NewInt(1e9),
+		GasLimit: 300000,
+		Data:     make([]byte, 100),
+	}
+
+	fee, err := oracle.EstimateL1Fee(context.Background(), tx, 101)
+	require.NoError(t, err)
+
+	encoded, err := ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    7,
+		GasPrice: big.NewInt(1e9),
+		Gas:      300000,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Data:     make([]byte, 100),
+	}).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e9*int64(len(encoded))), fee)
+	require.Len(t, caller.calls, 1)
+	assert.Equal(t, GasPriceOracleAddress, *caller.calls[0].To)
+	assert.Equal(t, big.NewInt(101), caller.blocks[0])
+}
@@ -0,0 +1,301 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// NonceSource reads the chain state the nonce manager syncs from
+type NonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// DefaultNonceManagerConfig returns the default nonce manager configuration
+func DefaultNonceManagerConfig() *interfaces.NonceManagerConfig {
+	return &interfaces.NonceManagerConfig{
+		CancelGasLimit:        21000,
+		CancelGasPriceBumpPct: 20,
+	}
+}
+
+// nonceManager implements the NonceManager interface. It tracks the account's
+// pending nonce as of the last sync, the next nonce to hand out and the nonces held
+// in between; any other nonce in that range is a gap.
+type nonceManager struct {
+	config    *interfaces.NonceManagerConfig
+	account   common.Address
+	source    NonceSource
+	submitter interfaces.TransactionSubmitter
+	synced    bool
+	pending   uint64
+	next      uint64
+	held      map[uint64]bool
+	mu        sync.Mutex
+}
+
+// NewNonceManager creates a nonce manager for a searcher account. It syncs with the
+// chain on the first reservation. FillGaps needs a submitter.
+func NewNonceManager(config *interfaces.NonceManagerConfig, account common.Address, source NonceSource, submitter interfaces.TransactionSubmitter) interfaces.NonceManager {
+	if config == nil {
+		config = DefaultNonceManagerConfig()
+	}
+	return &nonceManager{
+		config:    config,
+		account:   account,
+		source:    source,
+		submitter: submitter,
+		held:      make(map[uint64]bool),
+	}
+}
+
+// Account returns the searcher account
+func (m *nonceManager) Account() common.Address {
+	return m.account
+}
+
+// Reserve reserves count consecutive nonces after the last one handed out
+func (m *nonceManager) Reserve(ctx context.Context, count int) (*interfaces.NonceReservation, error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("invalid nonce count %d", count)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.synced {
+		if err := m.resync(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reservation := &interfaces.NonceReservation{Account: m.account, First: m.next, Count: count}
+	for i := 0; i < count; i++ {
+		m.held[reservation.Nonce(i)] = true
+	}
+	m.next += uint64(count)
+	return reservation, nil
+}
+
+// Release frees an abandoned reservation. Releasing the latest reservations hands
+// their nonces out again; releasing earlier ones leaves gaps.
+func (m *nonceManager) Release(reservation *interfaces.NonceReservation) {
+	if reservation == nil || reservation.Account != m.account {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := 0; i < reservation.Count; i++ {
+		delete(m.held, reservation.Nonce(i))
+	}
+	m.trim()
+}
+
+// Resync reloads the pending nonce, after startup or a failed submission
+func (m *nonceManager) Resync(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resync(ctx)
+}
+
+// resync reloads the pending nonce. Nonces below it have been used; nonces above
+// it that are still held stay held, since bundles sent to private relays don't show
+// up as pending.
+func (m *nonceManager) resync(ctx context.Context) error {
+	pending, err := m.source.PendingNonceAt(ctx, m.account)
+	if err != nil {
+		return fmt.Errorf("failed to read pending nonce of %s: %w", m.account.Hex(), err)
+	}
+
+	m.synced = true
+	m.pending = pending
+	for nonce := range m.held {
+		if nonce < pending {
+			delete(m.held, nonce)
+		}
+	}
+	if m.next < pending {
+		m.next = pending
+	}
+	m.trim()
+	return nil
+}
+
+// trim rolls the next nonce back past unheld nonces at the top of the range
+func (m *nonceManager) trim() {
+	for m.next > m.pending && !m.held[m.next-1] {
+		m.next--
+	}
+}
+
+// Gaps returns the unheld nonces between the pending nonce and the next nonce
+func (m *nonceManager) Gaps() []uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.gaps()
+}
+
+func (m *nonceManager) gaps() []uint64 {
+	var gaps []uint64
+	for nonce := m.pending; nonce < m.next; nonce++ {
+		if !m.held[nonce] {
+			gaps = append(gaps, nonce)
+		}
+	}
+	return gaps
+}
+
+// FillGaps resyncs and submits a zero-value transfer to the account itself at each
+// gap, so the held nonces above it can be included. Each cancel holds its nonce
+// until the pending nonce passes it; cancels that fail to submit stay gaps.
+func (m *nonceManager) FillGaps(ctx context.Context) ([]*types.Transaction, error) {
+	if m.submitter == nil {
+		return nil, errors.New("no submitter to send cancel transactions")
+	}
+
+	m.mu.Lock()
+	if err := m.resync(ctx); err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	gaps := m.gaps()
+	for _, nonce := range gaps {
+		m.held[nonce] = true
+	}
+	m.mu.Unlock()
+
+	if len(gaps) == 0 {
+		return nil, nil
+	}
+
+	cancels, err := m.cancelTransactions(ctx, gaps)
+	if err != nil {
+		m.unhold(gaps)
+		return nil, err
+	}
+
+	var (
+		sent   []*types.Transaction
+		failed []uint64
+		errs   []error
+	)
+	for _, cancel := range cancels {
+		if _, err := m.submitter.Submit(ctx, []*types.Transaction{cancel}); err != nil {
+			failed = append(failed, cancel.Nonce)
+			errs = append(errs, fmt.Errorf("failed to cancel nonce %d: %w", cancel.Nonce, err))
+			continue
+		}
+		sent = append(sent, cancel)
+	}
+	m.unhold(failed)
+	return sent, errors.Join(errs...)
+}
+
+// cancelTransactions builds cancels for nonces at a bumped gas price
+func (m *nonceManager) cancelTransactions(ctx context.Context, nonces []uint64) ([]*types.Transaction, error) {
+	chainID, err := m.source.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain ID: %w", err)
+	}
+	gasPrice, err := m.source.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gas price: %w", err)
+	}
+	gasPrice = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(100+m.config.CancelGasPriceBumpPct))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+
+	account := m.account
+	cancels := make([]*types.Transaction, len(nonces))
+	for i, nonce := range nonces {
+		cancels[i] = &types.Transaction{
+			From:      account,
+			To:        &account,
+			Value:     big.NewInt(0),
+			GasPrice:  new(big.Int).Set(gasPrice),
+			GasLimit:  m.config.CancelGasLimit,
+			Nonce:     nonce,
+			ChainID:   chainID,
+			Timestamp: time.Now(),
+		}
+	}
+	return cancels, nil
+}
+
+// unhold turns nonces held for cancels back into gaps
+func (m *nonceManager) unhold(nonces []uint64) {
+	if len(nonces) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, nonce := range nonces {
+		delete(m.held, nonce)
+	}
+	m.trim()
+}
+
+// releaseNonces releases an abandoned reservation, resyncing after if asked. Gaps
+// left below nonces still held would stall the bundles holding them, so they are
+// filled with cancels.
+func releaseNonces(ctx context.Context, nonces interfaces.NonceManager, reservation *interfaces.NonceReservation, resync bool) {
+	nonces.Release(reservation)
+	if resync {
+		if err := nonces.Resync(ctx); err != nil {
+			log.Printf("Failed to resync nonces of %s: %v", nonces.Account().Hex(), err)
+		}
+	}
+	if len(nonces.Gaps()) == 0 {
+		return
+	}
+	if _, err := nonces.FillGaps(ctx); err != nil {
+		log.Printf("Failed to fill nonce gaps of %s: %v", nonces.Account().Hex(), err)
+	}
+}
+
+// assignNonces renumbers a bundle's transactions from the account with a
+// reservation, returning renumbered copies and the reservation. Bundles without
+// transactions from the account reserve nothing.
+func assignNonces(ctx context.Context, nonces interfaces.NonceManager, bundle []*types.Transaction) ([]*types.Transaction, *interfaces.NonceReservation, error) {
+	account := nonces.Account()
+	count := 0
+	for _, tx := range bundle {
+		if tx.From == account {
+			count++
+		}
+	}
+	if count == 0 {
+		return bundle, nil, nil
+	}
+
+	reservation, err := nonces.Reserve(ctx, count)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reserve nonces: %w", err)
+	}
+
+	numbered := make([]*types.Transaction, len(bundle))
+	next := 0
+	for i, tx := range bundle {
+		if tx.From != account {
+			numbered[i] = tx
+			continue
+		}
+		copied := *tx
+		copied.Nonce = reservation.Nonce(next)
+		numbered[i] = &copied
+		next++
+	}
+	return numbered, reservation, nil
+}
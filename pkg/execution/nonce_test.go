@@ -0,0 +1,233 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNonceSource serves a settable pending nonce
+type fakeNonceSource struct {
+	pending uint64
+	err     error
+	reads   int
+	mu      sync.Mutex
+}
+
+func (f *fakeNonceSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reads++
+	return f.pending, f.err
+}
+
+func (f *fakeNonceSource) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(1e9), nil
+}
+
+func (f *fakeNonceSource) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(8453), nil
+}
+
+func (f *fakeNonceSource) setPending(pending uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pending = pending
+}
+
+func TestNonceManager_ReserveConcurrently(t *testing.T) {
+	source := &fakeNonceSource{pending: 40}
+	manager := NewNonceManager(nil, testSearcher, source, nil)
+
+	const workers = 50
+	reservations := make(chan *interfaces.NonceReservation, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reservation, err := manager.Reserve(context.Background(), 2)
+			assert.NoError(t, err)
+			reservations <- reservation
+		}()
+	}
+	wg.Wait()
+	close(reservations)
+
+	var nonces []uint64
+	for reservation := range reservations {
+		assert.Equal(t, testSearcher, reservation.Account)
+		nonces = append(nonces, reservation.Nonce(0), reservation.Nonce(1))
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	for i, nonce := range nonces {
+		assert.Equal(t, uint64(40+i), nonce)
+	}
+	assert.Equal(t, 1, source.reads, "syncs once on startup")
+	assert.Empty(t, manager.Gaps())
+}
+
+func TestNonceManager_Release(t *testing.T) {
+	ctx := context.Background()
+	manager := NewNonceManager(nil, testSearcher, &fakeNonceSource{pending: 10}, nil)
+
+	first, err := manager.Reserve(ctx, 2) // 10, 11
+	require.NoError(t, err)
+	second, err := manager.Reserve(ctx, 1) // 12
+	require.NoError(t, err)
+	third, err := manager.Reserve(ctx, 2) // 13, 14
+	require.NoError(t, err)
+
+	// The latest reservation is handed out again
+	manager.Release(third)
+	assert.Empty(t, manager.Gaps())
+	again, err := manager.Reserve(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(13), again.First)
+
+	// An earlier one leaves a gap the later reservations wait on
+	manager.Release(first)
+	assert.Equal(t, []uint64{10, 11}, manager.Gaps())
+
+	// Releasing everything above the gap closes it
+	manager.Release(again)
+	manager.Release(second)
+	assert.Empty(t, manager.Gaps())
+	next, err := manager.Reserve(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), next.First)
+}
+
+func TestNonceManager_Resync(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeNonceSource{pending: 5}
+	manager := NewNonceManager(nil, testSearcher, source, nil)
+
+	included, err := manager.Reserve(ctx, 2) // 5, 6
+	require.NoError(t, err)
+	lost, err := manager.Reserve(ctx, 1) // 7
+	require.NoError(t, err)
+	_, err = manager.Reserve(ctx, 1) // 8, sent to a private relay
+	require.NoError(t, err)
+	manager.Release(lost)
+
+	// The first bundle lands; the bundle at 8 is still held above the gap
+	source.setPending(included.First + 2)
+	require.NoError(t, manager.Resync(ctx))
+	assert.Equal(t, []uint64{7}, manager.Gaps())
+
+	// Another sender used the account; reservations continue past its nonces
+	source.setPending(20)
+	require.NoError(t, manager.Resync(ctx))
+	assert.Empty(t, manager.Gaps())
+	next, err := manager.Reserve(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), next.First)
+
+	source.err = errors.New("connection refused")
+	assert.Error(t, manager.Resync(ctx))
+}
+
+func TestNonceManager_FillGaps(t *testing.T) {
+	ctx := context.Background()
+	submitter := &fakeSubmitter{}
+	manager := NewNonceManager(nil, testSearcher, &fakeNonceSource{pending: 3}, submitter)
+
+	abandoned, err := manager.Reserve(ctx, 2) // 3, 4
+	require.NoError(t, err)
+	_, err = manager.Reserve(ctx, 1) // 5
+	require.NoError(t, err)
+	manager.Release(abandoned)
+
+	cancels, err := manager.FillGaps(ctx)
+	require.NoError(t, err)
+	require.Len(t, cancels, 2)
+	require.Len(t, submitter.bundles, 2)
+	for i, cancel := range cancels {
+		assert.Equal(t, uint64(3+i), cancel.Nonce)
+		assert.Equal(t, testSearcher, cancel.From)
+		assert.Equal(t, testSearcher, *cancel.To)
+		assert.Zero(t, cancel.Value.Sign())
+		assert.Equal(t, uint64(21000), cancel.GasLimit)
+		assert.Equal(t, big.NewInt(1.2e9), cancel.GasPrice)
+		assert.Equal(t, big.NewInt(8453), cancel.ChainID)
+		assert.Equal(t, []*types.Transaction{cancel}, submitter.bundles[i])
+	}
+	assert.Empty(t, manager.Gaps())
+
+	// Cancels that can't be submitted leave their gaps
+	failing := NewNonceManager(nil, testSearcher, &fakeNonceSource{pending: 3}, &fakeSubmitter{err: errors.New("nonce too low")})
+	abandoned, err = failing.Reserve(ctx, 1)
+	require.NoError(t, err)
+	_, err = failing.Reserve(ctx, 1)
+	require.NoError(t, err)
+	failing.Release(abandoned)
+
+	cancels, err = failing.FillGaps(ctx)
+	assert.Error(t, err)
+	assert.Empty(t, cancels)
+	assert.Equal(t, []uint64{3}, failing.Gaps())
+}
+
+func TestTradeExecutor_NonceManager(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        interfaces.ExecutionMode
+		minProfit   *big.Int
+		submitErr   error
+		wantHeld    bool
+		wantResyncs int
+	}{
+		{name: "live submission holds nonces", mode: interfaces.ExecutionModeLive, wantHeld: true},
+		{name: "failed submission releases and resyncs", mode: interfaces.ExecutionModeLive, submitErr: errors.New("relay unavailable"), wantResyncs: 1},
+		{name: "unprofitable hybrid releases", mode: interfaces.ExecutionModeHybrid, minProfit: big.NewInt(1e17)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			source := &fakeNonceSource{pending: 30}
+			nonces := NewNonceManager(nil, testSearcher, source, nil)
+			submitter := &fakeSubmitter{err: tt.submitErr}
+
+			config := DefaultExecutionConfig()
+			config.Mode = tt.mode
+			if tt.minProfit != nil {
+				config.MinProfitThreshold = tt.minProfit
+			}
+			executor := NewTradeExecutor(config, &fakeSimulator{gasUsed: 100000}, submitter, nil, nil)
+			executor.SetNonceManager(nonces)
+
+			opportunity := testOpportunity()
+			result, err := executor.Execute(ctx, opportunity)
+			require.NoError(t, err)
+
+			// The opportunity's own transactions are left as the detector built them
+			assert.Equal(t, uint64(1), opportunity.ExecutionTxs[0].Nonce)
+			if tt.wantHeld {
+				require.Len(t, submitter.bundles, 1)
+				assert.Equal(t, uint64(30), submitter.bundles[0][0].Nonce)
+				assert.Equal(t, uint64(31), submitter.bundles[0][1].Nonce)
+				assert.Equal(t, submitter.bundles[0], result.SubmittedTxs)
+			}
+
+			next, err := nonces.Reserve(ctx, 1)
+			require.NoError(t, err)
+			if tt.wantHeld {
+				assert.Equal(t, uint64(32), next.First)
+			} else {
+				assert.Equal(t, uint64(30), next.First)
+			}
+			assert.Equal(t, 1+tt.wantResyncs, source.reads)
+		})
+	}
+}
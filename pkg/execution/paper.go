@@ -0,0 +1,568 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// DefaultPaperLedgerConfig returns the default paper ledger configuration: each
+// strategy starts with 1 ETH, 10 WETH and 25,000 USDC, and bundles reach the
+// sequencer 200ms after they're paper traded
+func DefaultPaperLedgerConfig() *interfaces.PaperLedgerConfig {
+	return &interfaces.PaperLedgerConfig{
+		StartingBalances: map[common.Address]*big.Int{
+			{}:               big.NewInt(1e18),
+			pricing.BaseWETH: new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)),
+			pricing.BaseUSDC: big.NewInt(25000e6),
+		},
+		SubmissionLatency: 200 * time.Millisecond,
+		BlockTime:         2 * time.Second,
+		CurveSize:         1000,
+		FillHistory:       1000,
+		PollInterval:      time.Second,
+	}
+}
+
+// paperState is a paper trade waiting for its inclusion block
+type paperState struct {
+	trade  *interfaces.TradeResult
+	bundle []*types.Transaction
+	target string // Hash of the opportunity's target in the bundle, if any
+	fill   *interfaces.PaperFill
+}
+
+// paperBook is a strategy's account and its fills, oldest first
+type paperBook struct {
+	account *interfaces.PaperAccount
+	fills   []*interfaces.PaperFill
+}
+
+// PaperLedgerImpl implements the PaperLedger interface. A paper trade is modeled
+// to land in the first block after it would have reached the sequencer, and is
+// re-simulated on the state that block started from once the chain gets there;
+// its realized profit is the net transfer of the profit token to the profit
+// holder in the simulation's logs, or of every token valued in ETH for profits in
+// ETH. Gas is the simulated L2 gas plus, with an L1 fee estimator, the L1 data
+// fee; a bundle that reverts still pays for the transactions that ran. Fills that
+// can't be re-simulated or valued are unrepriced: they're counted but not
+// realized. Virtual balances take the holder's net transfers of every token, and
+// gas.
+type PaperLedgerImpl struct {
+	config      *interfaces.PaperLedgerConfig
+	chain       BlockSource
+	simulator   interfaces.PinnedBundleSimulator
+	metrics     interfaces.MetricsCollector
+	logger      interfaces.TransactionLogger
+	priceOracle interfaces.PriceOracle
+	l1Fees      L1FeeEstimator
+	pending     []*paperState
+	books       map[interfaces.StrategyType]*paperBook
+	running     bool
+	stopChan    chan struct{}
+	mu          sync.Mutex
+	checking    sync.Mutex // Held through a check, so fills apply in order
+}
+
+// NewPaperLedger creates a paper ledger. Realized paper trades are recorded with
+// the metrics collector and written back to the replay log; either may be nil, as
+// may the simulator.
+func NewPaperLedger(config *interfaces.PaperLedgerConfig, chain BlockSource, simulator interfaces.PinnedBundleSimulator, metrics interfaces.MetricsCollector, logger interfaces.TransactionLogger) *PaperLedgerImpl {
+	if config == nil {
+		config = DefaultPaperLedgerConfig()
+	}
+	return &PaperLedgerImpl{
+		config:    config,
+		chain:     chain,
+		simulator: simulator,
+		metrics:   metrics,
+		logger:    logger,
+		books:     make(map[interfaces.StrategyType]*paperBook),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// SetPriceOracle sets the oracle that values fills' net profit in ETH
+func (l *PaperLedgerImpl) SetPriceOracle(oracle interfaces.PriceOracle) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.priceOracle = oracle
+}
+
+// SetL1FeeEstimator sets the estimator fills' L1 data fees are charged with.
+// Without one only L2 gas is charged.
+func (l *PaperLedgerImpl) SetL1FeeEstimator(estimator L1FeeEstimator) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l1Fees = estimator
+}
+
+// Track queues a paper trade for the block after its modeled submission latency
+func (l *PaperLedgerImpl) Track(ctx context.Context, opportunity *interfaces.MEVOpportunity, trade *interfaces.TradeResult) error {
+	if opportunity == nil || trade == nil {
+		return errors.New("paper fill needs its opportunity and trade")
+	}
+	head, err := l.chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read block number: %w", err)
+	}
+
+	blocks := uint64(1)
+	if l.config.BlockTime > 0 {
+		blocks += uint64(l.config.SubmissionLatency / l.config.BlockTime)
+	}
+	detectedAt := opportunity.CreatedAt
+	if detectedAt.IsZero() {
+		detectedAt = trade.ExecutedAt
+	}
+	fill := &interfaces.PaperFill{
+		TradeID:        trade.ID,
+		OpportunityID:  opportunity.ID,
+		Strategy:       trade.Strategy,
+		DetectedAt:     detectedAt,
+		DetectedBlock:  head,
+		InclusionBlock: head + blocks,
+		Latency:        trade.ExecutedAt.Sub(detectedAt) + time.Duration(blocks)*l.config.BlockTime,
+		ProfitToken:    trade.ProfitToken,
+		ExpectedProfit: new(big.Int).Set(bigOrZero(trade.ExpectedProfit)),
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pending = append(l.pending, &paperState{
+		trade:  trade,
+		bundle: append([]*types.Transaction(nil), opportunity.ExecutionTxs...),
+		target: opportunity.TargetTx,
+		fill:   fill,
+	})
+	return nil
+}
+
+// Pending returns how many paper trades are waiting for their inclusion block
+func (l *PaperLedgerImpl) Pending() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.pending)
+}
+
+// Start checks the queued paper trades every poll interval until stopped
+func (l *PaperLedgerImpl) Start(ctx context.Context) error {
+	l.mu.Lock()
+	if l.running {
+		l.mu.Unlock()
+		return fmt.Errorf("paper ledger is already running")
+	}
+	l.running = true
+	l.mu.Unlock()
+
+	go l.poll(ctx)
+	return nil
+}
+
+// Stop stops the paper ledger
+func (l *PaperLedgerImpl) Stop() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.running {
+		return fmt.Errorf("paper ledger is not running")
+	}
+	close(l.stopChan)
+	l.running = false
+	return nil
+}
+
+// poll runs Check every poll interval
+func (l *PaperLedgerImpl) poll(ctx context.Context) {
+	ticker := time.NewTicker(l.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := l.Check(ctx); err != nil {
+				log.Printf("Paper fill check failed: %v", err)
+			}
+		case <-l.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check fills the queued paper trades whose inclusion block the chain has reached,
+// in the order they were traded. Only repriced and failed fills are recorded as
+// realized trades.
+func (l *PaperLedgerImpl) Check(ctx context.Context) ([]*interfaces.PaperFill, error) {
+	l.checking.Lock()
+	defer l.checking.Unlock()
+
+	head, err := l.chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block number: %w", err)
+	}
+
+	l.mu.Lock()
+	pending := append([]*paperState(nil), l.pending...)
+	l.mu.Unlock()
+
+	var (
+		fills  []*interfaces.PaperFill
+		filled = make(map[*paperState]bool)
+	)
+	for _, state := range pending {
+		if state.fill.InclusionBlock > head {
+			continue
+		}
+		if trade := l.fill(ctx, state); trade != nil {
+			l.record(ctx, trade)
+		}
+		fills = append(fills, state.fill)
+		filled[state] = true
+	}
+
+	if len(filled) > 0 {
+		l.mu.Lock()
+		remaining := l.pending[:0]
+		for _, state := range l.pending {
+			if !filled[state] {
+				remaining = append(remaining, state)
+			}
+		}
+		l.pending = remaining
+		l.mu.Unlock()
+	}
+	return fills, nil
+}
+
+// fill re-simulates a paper trade at its inclusion block, applies it to its
+// strategy's account and returns the realized trade, or nil if it's unrepriced
+func (l *PaperLedgerImpl) fill(ctx context.Context, state *paperState) *interfaces.TradeResult {
+	fill := state.fill
+	fill.FilledAt = time.Now()
+	fill.RealizedProfit = new(big.Int)
+	fill.GasCost = new(big.Int)
+
+	l.mu.Lock()
+	balances := copyPaperAccount(l.book(fill.Strategy).account).Balances
+	oracle := l.priceOracle
+	l.mu.Unlock()
+
+	var (
+		transfers map[common.Address]*big.Int
+		err       error
+	)
+	shortfall := paperShortfall(state.bundle, state.target, balances)
+	if shortfall != "" {
+		err = errors.New(shortfall)
+	} else if l.simulator != nil {
+		var simulations []*interfaces.SimulationResult
+		simulations, err = l.simulator.SimulateAt(ctx, state.bundle, fill.InclusionBlock-1)
+		// Transactions up to a revert still land and pay for their gas
+		gasCost, feeErr := l.gasCost(ctx, state, simulations)
+		fill.GasCost = gasCost
+		if err != nil {
+			err = fmt.Errorf("reverted at block %d: %w", fill.InclusionBlock, err)
+		} else {
+			fill.Success = true
+			transfers = bundleTransfers(profitHolder(l.config.Executor, state.bundle, state.target), simulations)
+			if profit, valueErr := profitIn(fill.ProfitToken, transfers, oracle); valueErr == nil && feeErr == nil {
+				fill.RealizedProfit = profit
+				fill.Repriced = true
+			}
+		}
+	} else {
+		fill.Success = true
+	}
+	if err != nil {
+		fill.Error = err.Error()
+	}
+
+	var trade *interfaces.TradeResult
+	if fill.Success && !fill.Repriced {
+		// Nothing was measured, so nothing is realized
+		fill.RealizedProfit, fill.GasCost = nil, nil
+	} else {
+		trade = realizedTrade(state, oracle)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	book := l.book(fill.Strategy)
+	l.apply(book, fill, transfers, shortfall != "")
+	book.fills = append(book.fills, fill)
+	if l.config.FillHistory > 0 && len(book.fills) > l.config.FillHistory {
+		book.fills = book.fills[len(book.fills)-l.config.FillHistory:]
+	}
+	return trade
+}
+
+// gasCost returns the L2 gas and estimated L1 data fees of our simulated
+// transactions. If an L1 fee can't be estimated it returns the L2 gas and the error.
+func (l *PaperLedgerImpl) gasCost(ctx context.Context, state *paperState, simulations []*interfaces.SimulationResult) (*big.Int, error) {
+	cost := simulatedGasCost(state.bundle, simulations, state.target)
+	l.mu.Lock()
+	estimator := l.l1Fees
+	l.mu.Unlock()
+	if estimator == nil {
+		return cost, nil
+	}
+
+	l1Fee := new(big.Int)
+	for i, simulation := range simulations {
+		if simulation == nil || i >= len(state.bundle) || isTarget(state.target, state.bundle[i]) {
+			continue
+		}
+		fee, err := estimator.EstimateL1Fee(ctx, state.bundle[i], state.fill.InclusionBlock-1)
+		if err != nil {
+			return cost, fmt.Errorf("failed to estimate L1 fee: %w", err)
+		}
+		l1Fee.Add(l1Fee, fee)
+	}
+	return cost.Add(cost, l1Fee), nil
+}
+
+// realizedTrade sets a measured fill's slippage and net profit in ETH, and returns
+// its trade as realized
+func realizedTrade(state *paperState, oracle interfaces.PriceOracle) *interfaces.TradeResult {
+	fill := state.fill
+	fill.Slippage = new(big.Int).Sub(fill.ExpectedProfit, fill.RealizedProfit)
+	if fill.ExpectedProfit.Sign() > 0 {
+		bps := new(big.Int).Mul(fill.Slippage, big.NewInt(10000))
+		fill.SlippageBps = bps.Quo(bps, fill.ExpectedProfit).Int64()
+	}
+
+	trade := *state.trade
+	trade.Success = fill.Success
+	trade.ExecutionTime = fill.Latency
+	trade.ActualProfit = new(big.Int).Set(fill.RealizedProfit)
+	trade.GasCost = new(big.Int).Set(fill.GasCost)
+	trade.ErrorMessage = fill.Error
+	priceTrade(&trade, oracle)
+	if netProfit := trade.NetProfitWei(); netProfit != nil {
+		fill.NetProfitETH = new(big.Int).Set(netProfit)
+	}
+	return &trade
+}
+
+// apply moves a fill's tokens and gas through an account's balances and extends its
+// PnL curve. Rejected and unrepriced fills are only counted.
+func (l *PaperLedgerImpl) apply(book *paperBook, fill *interfaces.PaperFill, transfers map[common.Address]*big.Int, rejected bool) {
+	account := book.account
+	account.Fills++
+	if rejected {
+		account.RejectedFills++
+		return
+	}
+	if fill.Success && !fill.Repriced {
+		account.UnrepricedFills++
+		return
+	}
+	if !fill.Success {
+		account.FailedFills++
+	}
+
+	if fill.Success {
+		for token, delta := range transfers {
+			addBalance(account.Balances, token, delta)
+		}
+	}
+	addBalance(account.Balances, common.Address{}, new(big.Int).Neg(fill.GasCost))
+
+	if fill.NetProfitETH == nil {
+		account.UnpricedFills++
+		return
+	}
+	account.PnL = new(big.Int).Add(account.PnL, fill.NetProfitETH)
+	if account.PnL.Cmp(account.PeakPnL) > 0 {
+		account.PeakPnL = new(big.Int).Set(account.PnL)
+	}
+	account.Drawdown = new(big.Int).Sub(account.PeakPnL, account.PnL)
+	if account.Drawdown.Cmp(account.MaxDrawdown) > 0 {
+		account.MaxDrawdown = new(big.Int).Set(account.Drawdown)
+	}
+	account.Curve = append(account.Curve, &interfaces.PaperCurvePoint{
+		Time:     fill.FilledAt,
+		Block:    fill.InclusionBlock,
+		PnL:      account.PnL,
+		Drawdown: account.Drawdown,
+	})
+	if l.config.CurveSize > 0 && len(account.Curve) > l.config.CurveSize {
+		account.Curve = account.Curve[len(account.Curve)-l.config.CurveSize:]
+	}
+}
+
+// book returns a strategy's book, opening its account at the starting balances. The
+// caller holds mu.
+func (l *PaperLedgerImpl) book(strategy interfaces.StrategyType) *paperBook {
+	book, exists := l.books[strategy]
+	if !exists {
+		book = &paperBook{account: l.newAccount(strategy)}
+		l.books[strategy] = book
+	}
+	return book
+}
+
+// newAccount opens a strategy's account at its starting balances
+func (l *PaperLedgerImpl) newAccount(strategy interfaces.StrategyType) *interfaces.PaperAccount {
+	starting, exists := l.config.Strategies[strategy]
+	if !exists {
+		starting = l.config.StartingBalances
+	}
+	balances := make(map[common.Address]*big.Int, len(starting))
+	for token, amount := range starting {
+		balances[token] = new(big.Int).Set(amount)
+	}
+	return &interfaces.PaperAccount{
+		Strategy:    strategy,
+		Balances:    balances,
+		PnL:         new(big.Int),
+		PeakPnL:     new(big.Int),
+		Drawdown:    new(big.Int),
+		MaxDrawdown: new(big.Int),
+		StartedAt:   time.Now(),
+	}
+}
+
+// record reports a realized paper trade and writes it back to the replay log
+func (l *PaperLedgerImpl) record(ctx context.Context, trade *interfaces.TradeResult) {
+	if l.metrics != nil {
+		if err := l.metrics.RecordTrade(ctx, trade); err != nil {
+			log.Printf("Failed to record trade %s: %v", trade.ID, err)
+		}
+	}
+	if l.logger != nil {
+		if err := l.logger.UpdateLogWithActualResult(ctx, trade.ID, trade); err != nil {
+			log.Printf("Failed to update log of trade %s: %v", trade.ID, err)
+		}
+	}
+}
+
+// Accounts returns every strategy's account, by strategy
+func (l *PaperLedgerImpl) Accounts() []*interfaces.PaperAccount {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	accounts := make([]*interfaces.PaperAccount, 0, len(l.books))
+	for _, book := range l.books {
+		accounts = append(accounts, copyPaperAccount(book.account))
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Strategy < accounts[j].Strategy
+	})
+	return accounts
+}
+
+// Account returns a strategy's account, if it has paper traded
+func (l *PaperLedgerImpl) Account(strategy interfaces.StrategyType) (*interfaces.PaperAccount, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	book, exists := l.books[strategy]
+	if !exists {
+		return nil, false
+	}
+	return copyPaperAccount(book.account), true
+}
+
+// Fills returns a strategy's most recent fills, newest first; a limit of zero
+// returns all that are kept
+func (l *PaperLedgerImpl) Fills(strategy interfaces.StrategyType, limit int) []*interfaces.PaperFill {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	book, exists := l.books[strategy]
+	if !exists {
+		return nil
+	}
+	count := len(book.fills)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	fills := make([]*interfaces.PaperFill, 0, count)
+	for i := len(book.fills) - 1; i >= 0 && len(fills) < count; i-- {
+		fills = append(fills, book.fills[i])
+	}
+	return fills
+}
+
+// Reset reopens a strategy's account at its starting balances. Trades still
+// waiting for their inclusion block fill into the new account.
+func (l *PaperLedgerImpl) Reset(strategy interfaces.StrategyType) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.books[strategy] = &paperBook{account: l.newAccount(strategy)}
+}
+
+// paperShortfall describes the first token a bundle's executor calls and value
+// spend more of than the balances hold, or returns "" if they're covered. Calls
+// funded by flash loans, and the target, spend nothing of ours.
+func paperShortfall(bundle []*types.Transaction, target string, balances map[common.Address]*big.Int) string {
+	spent := make(map[common.Address]*big.Int)
+	add := func(token common.Address, amount *big.Int) {
+		if spent[token] == nil {
+			spent[token] = new(big.Int)
+		}
+		spent[token].Add(spent[token], amount)
+	}
+	for _, tx := range bundle {
+		if isTarget(target, tx) {
+			continue
+		}
+		if tx.Value != nil && tx.Value.Sign() > 0 {
+			add(common.Address{}, tx.Value)
+		}
+		if _, ok := decodeFlashExecute(tx.Data); ok {
+			continue
+		}
+		if tokenIn, amountIn, _, ok := decodeExecute(tx.Data); ok && tokenIn != (common.Address{}) {
+			add(tokenIn, amountIn)
+		}
+	}
+
+	tokens := make([]common.Address, 0, len(spent))
+	for token := range spent {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].Hex() < tokens[j].Hex()
+	})
+	for _, token := range tokens {
+		held := bigOrZero(balances[token])
+		if held.Cmp(spent[token]) < 0 {
+			return fmt.Sprintf("bundle needs %s of %s, account holds %s", spent[token], token.Hex(), held)
+		}
+	}
+	return ""
+}
+
+// addBalance adds delta to a balance, which may start out missing
+func addBalance(balances map[common.Address]*big.Int, token common.Address, delta *big.Int) {
+	if balances[token] == nil {
+		balances[token] = new(big.Int)
+	}
+	balances[token] = new(big.Int).Add(balances[token], delta)
+}
+
+// copyPaperAccount copies an account so callers can't change the ledger's. Curve
+// points aren't changed once added, so they're shared.
+func copyPaperAccount(account *interfaces.PaperAccount) *interfaces.PaperAccount {
+	copied := *account
+	copied.Balances = make(map[common.Address]*big.Int, len(account.Balances))
+	for token, amount := range account.Balances {
+		copied.Balances[token] = new(big.Int).Set(amount)
+	}
+	copied.Curve = append([]*interfaces.PaperCurvePoint(nil), account.Curve...)
+	return &copied
+}
@@ -0,0 +1,237 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFillSimulator returns a fixed gas use for every transaction and the given
+// logs on the first, recording the blocks it's pinned to. A reverting bundle's
+// last transaction reverts.
+type fakeFillSimulator struct {
+	gasUsed uint64
+	logs    []*ethtypes.Log
+	revert  bool
+	pinned  []uint64
+}
+
+func (s *fakeFillSimulator) SimulateAt(ctx context.Context, bundle []*types.Transaction, blockNumber uint64) ([]*interfaces.SimulationResult, error) {
+	s.pinned = append(s.pinned, blockNumber)
+	return s.Simulate(ctx, bundle)
+}
+
+func (s *fakeFillSimulator) Simulate(ctx context.Context, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	results := make([]*interfaces.SimulationResult, len(bundle))
+	for i := range bundle {
+		results[i] = &interfaces.SimulationResult{Success: true, GasUsed: s.gasUsed}
+	}
+	results[0].Logs = s.logs
+	if s.revert {
+		results[len(results)-1].Success = false
+		return results, fmt.Errorf("transaction %d reverted", len(results)-1)
+	}
+	return results, nil
+}
+
+// fakeL1FeeEstimator charges every transaction the same L1 fee, recording the
+// blocks it estimates at
+type fakeL1FeeEstimator struct {
+	fee    *big.Int
+	blocks []uint64
+}
+
+func (e *fakeL1FeeEstimator) EstimateL1Fee(ctx context.Context, tx *types.Transaction, blockNumber uint64) (*big.Int, error) {
+	e.blocks = append(e.blocks, blockNumber)
+	return e.fee, nil
+}
+
+// roundTrip is the executor selling amountIn of WETH and getting amountIn plus
+// profit back
+func roundTrip(amountIn, profit *big.Int) []*ethtypes.Log {
+	return []*ethtypes.Log{
+		transferLog(testWETH, testExecutor, testFlashPool1, amountIn),
+		transferLog(testWETH, testFlashPool1, testExecutor, new(big.Int).Add(amountIn, profit)),
+	}
+}
+
+func TestPaperLedger_Fill(t *testing.T) {
+	ctx := context.Background()
+	head := fakeBlockSource(100)
+	simulator := &fakeFillSimulator{gasUsed: 100000}
+	logger := &fakeTransactionLogger{}
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	ledger := NewPaperLedger(&interfaces.PaperLedgerConfig{
+		Executor:          testExecutor,
+		StartingBalances:  map[common.Address]*big.Int{{}: big.NewInt(1e18), testWETH: units(10, 18)},
+		SubmissionLatency: 200 * time.Millisecond,
+		BlockTime:         2 * time.Second,
+	}, &head, simulator, nil, logger)
+	ledger.SetPriceOracle(oracle)
+	executor := NewTradeExecutor(nil, simulator, nil, nil, logger)
+	executor.SetPaperLedger(ledger)
+
+	// The trade is paper traded at block 100 and fills at the next block
+	result, err := executor.Execute(ctx, cyclicOpportunity(t, units(5, 18)))
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, 1, ledger.Pending())
+	fills, err := ledger.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fills)
+
+	// By then the route only makes 0.006 WETH, on the state block 101 starts from
+	// rather than the head's
+	head = 101
+	simulator.logs = roundTrip(units(5, 18), big.NewInt(6e15))
+	fills, err = ledger.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+	assert.Equal(t, []uint64{100}, simulator.pinned)
+	fill := fills[0]
+	assert.True(t, fill.Success)
+	assert.True(t, fill.Repriced)
+	assert.Equal(t, uint64(100), fill.DetectedBlock)
+	assert.Equal(t, uint64(101), fill.InclusionBlock)
+	assert.GreaterOrEqual(t, fill.Latency, 2*time.Second)
+	assert.Equal(t, big.NewInt(6e15), fill.RealizedProfit)
+	assert.Equal(t, big.NewInt(4e15), fill.Slippage)
+	assert.Equal(t, int64(4000), fill.SlippageBps)
+	assert.Equal(t, big.NewInt(1e14), fill.GasCost)
+	assert.Equal(t, big.NewInt(6e15-1e14), fill.NetProfitETH)
+	assert.Equal(t, 0, ledger.Pending())
+
+	// The realized trade replaces the paper trade's expected profit in the log
+	update := logger.updates[result.Trade.ID]
+	require.NotNil(t, update)
+	assert.Equal(t, big.NewInt(6e15), update.ActualProfit)
+	assert.Equal(t, interfaces.ExecutionModeSimulation, update.Mode)
+
+	account, ok := ledger.Account(interfaces.StrategyBackrun)
+	require.True(t, ok)
+	assert.Equal(t, new(big.Int).Add(units(10, 18), big.NewInt(6e15)), account.Balances[testWETH])
+	assert.Equal(t, big.NewInt(1e18-1e14), account.Balances[common.Address{}])
+	assert.Equal(t, big.NewInt(59e14), account.PnL)
+
+	// A losing fill draws the PnL down from its peak
+	_, err = executor.Execute(ctx, cyclicOpportunity(t, units(5, 18)))
+	require.NoError(t, err)
+	head = 102
+	simulator.logs = roundTrip(units(5, 18), big.NewInt(-2e15))
+	fills, err = ledger.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+	assert.Equal(t, int64(12000), fills[0].SlippageBps)
+
+	// More input than the account holds is rejected, and a revert still pays its
+	// L2 gas and L1 data fee
+	l1Fees := &fakeL1FeeEstimator{fee: big.NewInt(1e13)}
+	ledger.SetL1FeeEstimator(l1Fees)
+	_, err = executor.Execute(ctx, cyclicOpportunity(t, units(20, 18)))
+	require.NoError(t, err)
+	_, err = executor.Execute(ctx, cyclicOpportunity(t, units(5, 18)))
+	require.NoError(t, err)
+	head = 103
+	simulator.revert = true
+	fills, err = ledger.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, fills, 2)
+	assert.Contains(t, fills[0].Error, "account holds")
+	assert.Contains(t, fills[1].Error, "reverted at block 103")
+	assert.Equal(t, big.NewInt(1e14+1e13), fills[1].GasCost)
+	assert.Equal(t, big.NewInt(-(1e14 + 1e13)), fills[1].NetProfitETH)
+	assert.Equal(t, []uint64{102}, l1Fees.blocks)
+
+	account, _ = ledger.Account(interfaces.StrategyBackrun)
+	assert.Equal(t, 4, account.Fills)
+	assert.Equal(t, 1, account.RejectedFills)
+	assert.Equal(t, 1, account.FailedFills)
+	assert.Equal(t, big.NewInt(59e14-21e14-11e13), account.PnL)
+	assert.Equal(t, big.NewInt(59e14), account.PeakPnL)
+	assert.Equal(t, big.NewInt(21e14+11e13), account.MaxDrawdown)
+	require.Len(t, account.Curve, 3)
+	assert.Equal(t, big.NewInt(21e14), account.Curve[1].Drawdown)
+	assert.Equal(t, big.NewInt(21e14+11e13), account.Curve[2].Drawdown)
+	assert.Equal(t, new(big.Int).Add(units(10, 18), big.NewInt(4e15)), account.Balances[testWETH])
+	assert.Equal(t, big.NewInt(1e18-2e14-11e13), account.Balances[common.Address{}])
+
+	recent := ledger.Fills(interfaces.StrategyBackrun, 2)
+	require.Len(t, recent, 2)
+	assert.Same(t, fills[1], recent[0])
+	assert.Len(t, ledger.Accounts(), 1)
+
+	ledger.Reset(interfaces.StrategyBackrun)
+	account, _ = ledger.Account(interfaces.StrategyBackrun)
+	assert.Equal(t, 0, account.Fills)
+	assert.Equal(t, 0, account.PnL.Sign())
+	assert.Equal(t, units(10, 18), account.Balances[testWETH])
+	assert.Empty(t, ledger.Fills(interfaces.StrategyBackrun, 0))
+}
+
+func TestPaperLedger_WithoutSimulator(t *testing.T) {
+	ctx := context.Background()
+	head := fakeBlockSource(100)
+	config := DefaultPaperLedgerConfig()
+	config.SubmissionLatency = 5 * time.Second
+	config.Strategies = map[interfaces.StrategyType]map[common.Address]*big.Int{
+		interfaces.StrategySandwich: {{}: big.NewInt(1e17)},
+	}
+	logger := &fakeTransactionLogger{}
+	ledger := NewPaperLedger(config, &head, nil, nil, logger)
+
+	opportunity := testOpportunity()
+	opportunity.Strategy = interfaces.StrategySandwich
+	trade := &interfaces.TradeResult{
+		ID:             "trade_1",
+		Strategy:       interfaces.StrategySandwich,
+		ExecutedAt:     time.Now(),
+		Success:        true,
+		ExpectedProfit: big.NewInt(1e16),
+		GasCost:        big.NewInt(5e14),
+		Mode:           interfaces.ExecutionModeSimulation,
+	}
+	require.NoError(t, ledger.Track(ctx, opportunity, trade))
+
+	// 5s of latency is two 2s blocks late, so it lands in the third
+	head = 102
+	fills, err := ledger.Check(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, fills)
+	head = 103
+	fills, err = ledger.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, fills, 1)
+
+	// Nothing re-simulated it, so it's counted but not realized
+	assert.True(t, fills[0].Success)
+	assert.False(t, fills[0].Repriced)
+	assert.Nil(t, fills[0].RealizedProfit)
+	assert.Nil(t, fills[0].Slippage)
+	assert.Nil(t, fills[0].NetProfitETH)
+	assert.Empty(t, logger.updates)
+
+	account, ok := ledger.Account(interfaces.StrategySandwich)
+	require.True(t, ok)
+	assert.Equal(t, 1, account.Fills)
+	assert.Equal(t, 1, account.UnrepricedFills)
+	assert.Equal(t, big.NewInt(1e17), account.Balances[common.Address{}])
+	assert.Nil(t, account.Balances[pricing.BaseWETH], "the strategy's own starting balances replace the defaults")
+	assert.Zero(t, account.PnL.Sign())
+	assert.Empty(t, account.Curve)
+	_, ok = ledger.Account(interfaces.StrategyBackrun)
+	assert.False(t, ok)
+}
@@ -0,0 +1,410 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+)
+
+// DefaultPositionTrackerConfig returns the default position tracker configuration,
+// holding ETH, WETH and USDC as inventory
+func DefaultPositionTrackerConfig() *interfaces.PositionTrackerConfig {
+	return &interfaces.PositionTrackerConfig{
+		InventoryTokens:   []common.Address{{}, pricing.BaseWETH, pricing.BaseUSDC},
+		ResidualDust:      big.NewInt(1e14), // 0.0001 ETH
+		ReconcileInterval: time.Minute,
+	}
+}
+
+// heldBalance is a tracked balance of one token
+type heldBalance struct {
+	amount   *big.Int
+	openedAt time.Time
+	strategy interfaces.StrategyType
+}
+
+// PositionTrackerImpl implements the PositionTracker interface. Balances follow
+// the Transfer logs, gas and value of our landed bundles, and are replaced by the
+// chain's balances on each reconciliation. Receipts from blocks a reconciliation
+// already read are skipped so they aren't counted twice.
+type PositionTrackerImpl struct {
+	config          *interfaces.PositionTrackerConfig
+	chain           BalanceChain
+	priceOracle     interfaces.PriceOracle
+	inventory       map[common.Address]bool
+	balances        map[common.Address]map[common.Address]*heldBalance // By account, then token
+	synced          bool
+	reconciledBlock uint64
+	reconciledAt    time.Time
+	running         bool
+	stopChan        chan struct{}
+	mu              sync.RWMutex
+	reconciling     sync.Mutex
+}
+
+// NewPositionTracker creates a tracker for the configured searcher and executor.
+// Available capital is unknown until the first reconciliation.
+func NewPositionTracker(config *interfaces.PositionTrackerConfig, chain BalanceChain) *PositionTrackerImpl {
+	if config == nil {
+		config = DefaultPositionTrackerConfig()
+	}
+
+	inventory := make(map[common.Address]bool, len(config.InventoryTokens))
+	for _, token := range config.InventoryTokens {
+		inventory[token] = true
+	}
+	balances := make(map[common.Address]map[common.Address]*heldBalance)
+	balances[config.Searcher] = make(map[common.Address]*heldBalance)
+	if config.Executor != (common.Address{}) {
+		balances[config.Executor] = make(map[common.Address]*heldBalance)
+	}
+
+	return &PositionTrackerImpl{
+		config:    config,
+		chain:     chain,
+		inventory: inventory,
+		balances:  balances,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// SetPriceOracle sets the oracle positions are marked to market with
+func (t *PositionTrackerImpl) SetPriceOracle(oracle interfaces.PriceOracle) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.priceOracle = oracle
+}
+
+// AvailableCapital returns the trading account's balance of a token: the executor's
+// if one is configured, otherwise the searcher's
+func (t *PositionTrackerImpl) AvailableCapital(token common.Address) *big.Int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !t.synced {
+		return nil
+	}
+	held, exists := t.balances[t.holder()][token]
+	if !exists || held.amount.Sign() < 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(held.amount)
+}
+
+// Apply updates balances from a final bundle. Each landed transaction costs its
+// sender the L2 fee; successful ones also move their value and the tokens in their
+// Transfer logs. The searcher pays the bundle's L1 data fee.
+func (t *PositionTrackerImpl) Apply(result *interfaces.InclusionResult) {
+	if result == nil || result.Bundle == nil {
+		return
+	}
+	var strategy interfaces.StrategyType
+	if result.Trade != nil {
+		strategy = result.Trade.Strategy
+	}
+	now := time.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	applied := false
+	for i, receipt := range result.Receipts {
+		if receipt == nil || receipt.BlockNumber == nil || i >= len(result.Bundle.Transactions) {
+			continue
+		}
+		if t.synced && receipt.BlockNumber.Uint64() <= t.reconciledBlock {
+			continue // Already in the reconciled balances
+		}
+		applied = true
+
+		tx := result.Bundle.Transactions[i]
+		if receipt.EffectiveGasPrice != nil {
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+			t.add(tx.From, common.Address{}, fee.Neg(fee), strategy, now)
+		}
+		if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+			continue
+		}
+		if tx.To != nil && tx.Value != nil && tx.Value.Sign() > 0 {
+			t.add(tx.From, common.Address{}, new(big.Int).Neg(tx.Value), strategy, now)
+			t.add(*tx.To, common.Address{}, tx.Value, strategy, now)
+		}
+		for _, entry := range receipt.Logs {
+			from, to, value, ok := decodeTransfer(entry)
+			if !ok {
+				continue
+			}
+			t.add(from, entry.Address, new(big.Int).Neg(value), strategy, now)
+			t.add(to, entry.Address, value, strategy, now)
+		}
+	}
+	if applied && result.L1Fee != nil && result.L1Fee.Sign() > 0 {
+		t.add(t.config.Searcher, common.Address{}, new(big.Int).Neg(result.L1Fee), strategy, now)
+	}
+}
+
+// Reconcile replaces tracked balances with the chain's at the latest block, for the
+// inventory tokens and every token we have held
+func (t *PositionTrackerImpl) Reconcile(ctx context.Context) error {
+	t.reconciling.Lock()
+	defer t.reconciling.Unlock()
+
+	head, err := t.chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read block number: %w", err)
+	}
+	block := new(big.Int).SetUint64(head)
+
+	t.mu.RLock()
+	tokens := make(map[common.Address]map[common.Address]bool, len(t.balances))
+	for account, held := range t.balances {
+		tokens[account] = make(map[common.Address]bool, len(t.inventory)+len(held))
+		for token := range t.inventory {
+			tokens[account][token] = true
+		}
+		for token := range held {
+			tokens[account][token] = true
+		}
+	}
+	t.mu.RUnlock()
+
+	read := make(map[common.Address]map[common.Address]*big.Int, len(tokens))
+	var errs []error
+	for account, accountTokens := range tokens {
+		read[account] = make(map[common.Address]*big.Int, len(accountTokens))
+		for token := range accountTokens {
+			balance, err := balanceAt(ctx, t.chain, token, account, block)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			read[account][token] = balance
+		}
+	}
+
+	now := time.Now()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for account, balances := range read {
+		for token, balance := range balances {
+			current := t.amount(account, token)
+			t.add(account, token, new(big.Int).Sub(balance, current), "", now)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to reconcile positions: %w", errors.Join(errs...))
+	}
+	t.synced = true
+	t.reconciledBlock = head
+	t.reconciledAt = now
+	return nil
+}
+
+// Positions returns the non-zero balances, most valuable first. Positions that can't
+// be priced come last.
+func (t *PositionTrackerImpl) Positions() []*interfaces.Position {
+	t.mu.RLock()
+	oracle := t.priceOracle
+	positions := make([]*interfaces.Position, 0)
+	for account, held := range t.balances {
+		for token, balance := range held {
+			if balance.amount.Sign() == 0 {
+				continue
+			}
+			positions = append(positions, &interfaces.Position{
+				Account:  account,
+				Token:    token,
+				Amount:   new(big.Int).Set(balance.amount),
+				OpenedAt: balance.openedAt,
+				Strategy: balance.strategy,
+			})
+		}
+	}
+	t.mu.RUnlock()
+
+	for _, position := range positions {
+		if oracle != nil {
+			if value, err := oracle.ValueETH(position.Token, position.Amount); err == nil {
+				position.ValueETH = value
+			}
+			if value, err := oracle.ValueUSD(position.Token, position.Amount); err == nil {
+				position.ValueUSD = value
+			}
+		}
+		position.Residual = t.residual(position)
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := positions[i], positions[j]
+		if (a.ValueETH == nil) != (b.ValueETH == nil) {
+			return a.ValueETH != nil
+		}
+		if a.ValueETH != nil {
+			if cmp := a.ValueETH.Cmp(b.ValueETH); cmp != 0 {
+				return cmp > 0
+			}
+		}
+		if a.Account != b.Account {
+			return a.Account.Hex() < b.Account.Hex()
+		}
+		return a.Token.Hex() < b.Token.Hex()
+	})
+	return positions
+}
+
+// Summary totals the positions and lists the residual ones
+func (t *PositionTrackerImpl) Summary() *interfaces.PositionSummary {
+	positions := t.Positions()
+
+	summary := &interfaces.PositionSummary{
+		TotalPositions:   len(positions),
+		TotalValueETH:    new(big.Int),
+		PositionsByToken: make(map[string]*big.Int),
+		Residual:         make([]*interfaces.Position, 0),
+		Positions:        positions,
+	}
+	for _, position := range positions {
+		if position.ValueETH != nil {
+			summary.TotalValueETH.Add(summary.TotalValueETH, position.ValueETH)
+			if summary.LargestPosition == nil {
+				summary.LargestPosition = position
+			}
+		}
+		summary.TotalValueUSD += position.ValueUSD
+
+		token := position.Token.Hex()
+		if summary.PositionsByToken[token] == nil {
+			summary.PositionsByToken[token] = new(big.Int)
+		}
+		summary.PositionsByToken[token].Add(summary.PositionsByToken[token], position.Amount)
+
+		if position.Residual {
+			summary.Residual = append(summary.Residual, position)
+		}
+	}
+
+	t.mu.RLock()
+	summary.ReconciledAt = t.reconciledAt
+	t.mu.RUnlock()
+	return summary
+}
+
+// Start reconciles now and every reconcile interval until stopped
+func (t *PositionTrackerImpl) Start(ctx context.Context) error {
+	t.mu.Lock()
+	if t.running {
+		t.mu.Unlock()
+		return fmt.Errorf("position tracker is already running")
+	}
+	t.running = true
+	t.mu.Unlock()
+
+	go t.poll(ctx)
+	return nil
+}
+
+// Stop stops the position tracker
+func (t *PositionTrackerImpl) Stop() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.running {
+		return fmt.Errorf("position tracker is not running")
+	}
+	close(t.stopChan)
+	t.running = false
+	return nil
+}
+
+// poll runs Reconcile every reconcile interval
+func (t *PositionTrackerImpl) poll(ctx context.Context) {
+	if err := t.Reconcile(ctx); err != nil {
+		log.Printf("Position reconciliation failed: %v", err)
+	}
+
+	ticker := time.NewTicker(t.config.ReconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Reconcile(ctx); err != nil {
+				log.Printf("Position reconciliation failed: %v", err)
+			}
+		case <-t.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// holder returns the account that holds the trading inventory
+func (t *PositionTrackerImpl) holder() common.Address {
+	if t.config.Executor != (common.Address{}) {
+		return t.config.Executor
+	}
+	return t.config.Searcher
+}
+
+// amount returns an account's tracked balance of a token. Callers must hold t.mu.
+func (t *PositionTrackerImpl) amount(account, token common.Address) *big.Int {
+	if held, exists := t.balances[account][token]; exists {
+		return held.amount
+	}
+	return new(big.Int)
+}
+
+// add changes a tracked account's balance of a token, noting when and by which
+// strategy a position was opened. Untracked accounts are ignored. Callers must
+// hold t.mu.
+func (t *PositionTrackerImpl) add(account, token common.Address, delta *big.Int, strategy interfaces.StrategyType, now time.Time) {
+	balances, tracked := t.balances[account]
+	if !tracked || delta.Sign() == 0 {
+		return
+	}
+	held, exists := balances[token]
+	if !exists {
+		held = &heldBalance{amount: new(big.Int)}
+		balances[token] = held
+	}
+
+	opened := held.amount.Sign() <= 0
+	held.amount.Add(held.amount, delta)
+	if opened && held.amount.Sign() > 0 {
+		held.openedAt = now
+		held.strategy = strategy
+		if strategy != "" && !t.inventory[token] {
+			log.Printf("%s trade left %s with a residual %s position", strategy, account.Hex(), token.Hex())
+		}
+	}
+}
+
+// residual reports whether a position is outside our inventory tokens and worth
+// flagging. Unpriced positions are always flagged.
+func (t *PositionTrackerImpl) residual(position *interfaces.Position) bool {
+	if t.inventory[position.Token] || position.Amount.Sign() <= 0 {
+		return false
+	}
+	return position.ValueETH == nil || t.config.ResidualDust == nil || position.ValueETH.Cmp(t.config.ResidualDust) >= 0
+}
+
+// decodeTransfer decodes an ERC-20 Transfer log
+func decodeTransfer(entry *ethtypes.Log) (common.Address, common.Address, *big.Int, bool) {
+	if len(entry.Topics) != 3 || entry.Topics[0] != erc20.Events["Transfer"].ID || len(entry.Data) != 32 {
+		return common.Address{}, common.Address{}, nil, false
+	}
+	from := common.BytesToAddress(entry.Topics[1].Bytes())
+	to := common.BytesToAddress(entry.Topics[2].Bytes())
+	return from, to, new(big.Int).SetBytes(entry.Data), true
+}
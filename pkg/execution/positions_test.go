@@ -0,0 +1,235 @@
+package execution
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPool = common.HexToAddress("0x000000000000000000000000000000000000c003")
+
+// transferLog is an ERC-20 Transfer log
+func transferLog(token, from, to common.Address, value *big.Int) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: token,
+		Topics:  []common.Hash{erc20.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.LeftPadBytes(value.Bytes(), 32),
+	}
+}
+
+// newTestPositionTracker creates a tracker over a chain where, at block 100, the
+// searcher holds 1 ETH and the executor 5 WETH and 1000 USDC
+func newTestPositionTracker() (*PositionTrackerImpl, *fakeInclusionChain) {
+	chain := newFakeInclusionChain(100)
+	for _, account := range []common.Address{testSearcher, testExecutor} {
+		for _, token := range []common.Address{{}, testWETH, testUSDC} {
+			chain.setBalance(token, account, 100, big.NewInt(0))
+		}
+	}
+	chain.setBalance(common.Address{}, testSearcher, 100, big.NewInt(1e18))
+	chain.setBalance(testWETH, testExecutor, 100, big.NewInt(5e18))
+	chain.setBalance(testUSDC, testExecutor, 100, big.NewInt(1000e6))
+
+	config := DefaultPositionTrackerConfig()
+	config.Searcher = testSearcher
+	config.Executor = testExecutor
+	return NewPositionTracker(config, chain), chain
+}
+
+// testInclusionResult is a backrun that landed in a block: the searcher sends
+// 0.01 ETH to the executor, which sells 1 WETH and receives 2000 DAI
+func testInclusionResult(block int64) *interfaces.InclusionResult {
+	return &interfaces.InclusionResult{
+		Bundle: &interfaces.TrackedBundle{
+			Transactions: []*types.Transaction{{From: testSearcher, To: &testExecutor, Value: big.NewInt(1e16)}},
+			TxHashes:     []common.Hash{{0x01}},
+		},
+		Status:      interfaces.InclusionIncluded,
+		BlockNumber: uint64(block),
+		Receipts: []*ethtypes.Receipt{{
+			Status:            ethtypes.ReceiptStatusSuccessful,
+			GasUsed:           100000,
+			EffectiveGasPrice: big.NewInt(1e9),
+			BlockNumber:       big.NewInt(block),
+			Logs: []*ethtypes.Log{
+				transferLog(testWETH, testExecutor, testPool, big.NewInt(1e18)),
+				transferLog(testDAI, testPool, testExecutor, new(big.Int).Mul(big.NewInt(2000), big.NewInt(1e18))),
+			},
+		}},
+		L1Fee: big.NewInt(3e13),
+		Trade: &interfaces.TradeResult{ID: "trade_1", Strategy: interfaces.StrategyBackrun},
+	}
+}
+
+// positionAmounts maps account and token to the amount of each position
+func positionAmounts(positions []*interfaces.Position) map[common.Address]map[common.Address]*big.Int {
+	amounts := make(map[common.Address]map[common.Address]*big.Int)
+	for _, position := range positions {
+		if amounts[position.Account] == nil {
+			amounts[position.Account] = make(map[common.Address]*big.Int)
+		}
+		amounts[position.Account][position.Token] = position.Amount
+	}
+	return amounts
+}
+
+func TestPositionTracker_ReconcileAndApply(t *testing.T) {
+	ctx := context.Background()
+	tracker, chain := newTestPositionTracker()
+
+	// Capital is unknown until the first reconciliation
+	assert.Nil(t, tracker.AvailableCapital(testWETH))
+	require.NoError(t, tracker.Reconcile(ctx))
+	assert.Equal(t, big.NewInt(5e18), tracker.AvailableCapital(testWETH))
+	assert.Equal(t, big.NewInt(0), tracker.AvailableCapital(testDAI))
+
+	// The bundle landed after the reconciled block
+	tracker.Apply(testInclusionResult(101))
+	dai := new(big.Int).Mul(big.NewInt(2000), big.NewInt(1e18))
+	amounts := positionAmounts(tracker.Positions())
+	assert.Equal(t, big.NewInt(1e18-1e14-1e16-3e13), amounts[testSearcher][common.Address{}])
+	assert.Equal(t, big.NewInt(1e16), amounts[testExecutor][common.Address{}])
+	assert.Equal(t, big.NewInt(4e18), amounts[testExecutor][testWETH])
+	assert.Equal(t, big.NewInt(1000e6), amounts[testExecutor][testUSDC])
+	assert.Equal(t, dai, amounts[testExecutor][testDAI])
+	assert.Equal(t, big.NewInt(4e18), tracker.AvailableCapital(testWETH))
+
+	// The DAI is outside our inventory, and unpriced without an oracle
+	var residual *interfaces.Position
+	for _, position := range tracker.Positions() {
+		if position.Residual {
+			require.Nil(t, residual)
+			residual = position
+		}
+	}
+	require.NotNil(t, residual)
+	assert.Equal(t, testDAI, residual.Token)
+	assert.Equal(t, interfaces.StrategyBackrun, residual.Strategy)
+	assert.False(t, residual.OpenedAt.IsZero())
+
+	// Reconciling at block 101 picks up drift and keeps when the position opened
+	chain.head = 101
+	for _, account := range []common.Address{testSearcher, testExecutor} {
+		for _, token := range []common.Address{{}, testWETH, testUSDC, testDAI} {
+			chain.setBalance(token, account, 101, big.NewInt(0))
+		}
+	}
+	chain.setBalance(common.Address{}, testSearcher, 101, big.NewInt(9e17))
+	chain.setBalance(testWETH, testExecutor, 101, big.NewInt(4e18))
+	chain.setBalance(testDAI, testExecutor, 101, new(big.Int).Mul(big.NewInt(1500), big.NewInt(1e18)))
+	require.NoError(t, tracker.Reconcile(ctx))
+
+	amounts = positionAmounts(tracker.Positions())
+	assert.Equal(t, big.NewInt(9e17), amounts[testSearcher][common.Address{}])
+	assert.Nil(t, amounts[testExecutor][common.Address{}])
+	assert.Nil(t, amounts[testExecutor][testUSDC])
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(1500), big.NewInt(1e18)), amounts[testExecutor][testDAI])
+	for _, position := range tracker.Positions() {
+		if position.Token == testDAI {
+			assert.Equal(t, residual.OpenedAt, position.OpenedAt)
+		}
+	}
+
+	// Receipts from blocks already reconciled aren't counted again
+	tracker.Apply(testInclusionResult(101))
+	assert.Equal(t, amounts, positionAmounts(tracker.Positions()))
+}
+
+func TestPositionTracker_Summary(t *testing.T) {
+	ctx := context.Background()
+	tracker, chain := newTestPositionTracker()
+	chain.setBalance(testWETH, testSearcher, 100, big.NewInt(0))
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+	for _, state := range []*interfaces.PoolState{
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  testPoolA,
+			Tokens:   []common.Address{testWETH, testUSDC},
+			Reserves: []*big.Int{new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), big.NewInt(300000e6)}, // $3000 per WETH
+		},
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  testPoolB,
+			Tokens:   []common.Address{testUSDC, pricing.BaseAERO},
+			Reserves: []*big.Int{big.NewInt(1000000e6), new(big.Int).Mul(big.NewInt(2000000), big.NewInt(1e18))}, // $0.50 per AERO
+		},
+	} {
+		require.NoError(t, oracle.UpdatePool(state))
+	}
+	tracker.SetPriceOracle(oracle)
+	require.NoError(t, tracker.Reconcile(ctx))
+
+	// A trade leaves unpriced DAI and a little AERO, which is dust
+	result := testInclusionResult(101)
+	result.Receipts[0].Logs = append(result.Receipts[0].Logs, transferLog(pricing.BaseAERO, testPool, testExecutor, big.NewInt(1e12)))
+	tracker.Apply(result)
+
+	summary := tracker.Summary()
+	assert.Equal(t, 6, summary.TotalPositions)
+	require.Len(t, summary.Positions, 6)
+	assert.Equal(t, testWETH, summary.LargestPosition.Token)
+	assert.Equal(t, big.NewInt(4e18), summary.PositionsByToken[testWETH.Hex()])
+	assert.Equal(t, big.NewInt(1e18-1e14-3e13), summary.PositionsByToken[common.Address{}.Hex()])
+
+	// Priced positions come first, most valuable first; the unpriced DAI comes last
+	last := summary.Positions[len(summary.Positions)-1]
+	assert.Equal(t, testDAI, last.Token)
+	assert.Nil(t, last.ValueETH)
+	for i := 1; i < len(summary.Positions)-1; i++ {
+		assert.True(t, summary.Positions[i-1].ValueETH.Cmp(summary.Positions[i].ValueETH) >= 0)
+	}
+
+	// USDC is worth about a third of an ETH at $3000
+	var usdc *interfaces.Position
+	for _, position := range summary.Positions {
+		if position.Token == testUSDC {
+			usdc = position
+		}
+	}
+	require.NotNil(t, usdc)
+	assert.InDelta(t, 1000, usdc.ValueUSD, 1)
+	assert.InDelta(t, 3.33e17, float64(usdc.ValueETH.Int64()), 1e15)
+
+	require.Len(t, summary.Residual, 1)
+	assert.Equal(t, testDAI, summary.Residual[0].Token)
+	assert.Greater(t, summary.TotalValueUSD, 15000.0)
+	assert.False(t, summary.ReconciledAt.IsZero())
+}
+
+func TestInclusionMonitor_AppliesPositions(t *testing.T) {
+	ctx := context.Background()
+	tracker, chain := newTestPositionTracker()
+	require.NoError(t, tracker.Reconcile(ctx))
+
+	bundle := trackedTestBundle(common.Address{}, big.NewInt(0))
+	chain.setBalance(common.Address{}, testSearcher, 101, big.NewInt(1e18-2e14))
+	chain.setBalance(common.Address{}, testExecutor, 101, big.NewInt(0))
+
+	monitor := NewInclusionMonitor(&interfaces.InclusionMonitorConfig{Executor: testExecutor, ExpiryBlocks: 5}, chain, nil, nil, nil)
+	monitor.SetPositionTracker(tracker)
+	require.NoError(t, monitor.Track(context.Background(), bundle))
+	_, err := monitor.Check(ctx)
+	require.NoError(t, err)
+
+	chain.head = 101
+	land(chain, bundle, 101, 1, 1)
+	results, err := monitor.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	// Both transactions' gas came out of the searcher's ETH
+	amounts := positionAmounts(tracker.Positions())
+	assert.Equal(t, big.NewInt(1e18-2e14), amounts[testSearcher][common.Address{}])
+}
@@ -0,0 +1,529 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// DefaultProgressionConfig returns the default progression requirements. Auto
+// progression is off, so modes only change when an operator changes them.
+func DefaultProgressionConfig() *interfaces.ProgressionConfig {
+	return &interfaces.ProgressionConfig{
+		MaxMode:            interfaces.ExecutionModeLive,
+		Window:             7 * 24 * time.Hour,
+		EvaluationInterval: 5 * time.Minute,
+		Default: interfaces.ProgressionStages{
+			Hybrid: interfaces.ProgressionThresholds{
+				MinSamples:     100,
+				MinSuccessRate: 0.9,
+				MinAccuracy:    0.8,
+				MaxDrawdown:    big.NewInt(5e16), // 0.05 ETH
+				MinTimeInMode:  24 * time.Hour,
+			},
+			Live: interfaces.ProgressionThresholds{
+				MinSamples:     200,
+				MinSuccessRate: 0.8,
+				MinAccuracy:    0.85,
+				MaxDrawdown:    big.NewInt(1e17), // 0.1 ETH
+				MinTimeInMode:  72 * time.Hour,
+			},
+		},
+	}
+}
+
+// modeOrder is the order strategies progress through the execution modes
+var modeOrder = []interfaces.ExecutionMode{
+	interfaces.ExecutionModeSimulation,
+	interfaces.ExecutionModeHybrid,
+	interfaces.ExecutionModeLive,
+}
+
+// modeState is when a strategy entered the mode it was last seen in
+type modeState struct {
+	mode  interfaces.ExecutionMode
+	since time.Time
+}
+
+// progressionStats are a strategy's trades in its current mode
+type progressionStats struct {
+	samples   int
+	successes int
+	drawdown  *big.Int
+	accuracy  float64
+	validated bool // The accuracy came from the performance validator
+}
+
+// ProgressionControllerImpl implements the ProgressionController interface. A
+// strategy's trades are read back from the replay log: those it made in its
+// current mode, since it entered the mode and within the window, are measured
+// against the thresholds of the next mode. A strategy in hybrid or live mode that
+// no longer meets the thresholds it entered on, once it has enough samples, or
+// whose drawdown exceeds them, is demoted one mode.
+type ProgressionControllerImpl struct {
+	config     *interfaces.ProgressionConfig
+	executor   interfaces.Executor
+	logger     interfaces.TransactionLogger
+	validator  interfaces.PerformanceValidator
+	alerts     interfaces.AlertManager
+	states     map[interfaces.StrategyType]*modeState
+	running    bool
+	stopChan   chan struct{}
+	mu         sync.Mutex
+	evaluating sync.Mutex // Held through an evaluation, which may change modes
+}
+
+// NewProgressionController creates a progression controller for the executor's
+// strategies. Without a performance validator, accuracy requirements are never
+// met; the alert manager transitions are reported to may be nil.
+func NewProgressionController(config *interfaces.ProgressionConfig, executor interfaces.Executor, logger interfaces.TransactionLogger, validator interfaces.PerformanceValidator, alerts interfaces.AlertManager) *ProgressionControllerImpl {
+	if config == nil {
+		config = DefaultProgressionConfig()
+	}
+	return &ProgressionControllerImpl{
+		config:    config,
+		executor:  executor,
+		logger:    logger,
+		validator: validator,
+		alerts:    alerts,
+		states:    make(map[interfaces.StrategyType]*modeState),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// AutoProgression reports whether evaluations change modes
+func (c *ProgressionControllerImpl) AutoProgression() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.config.AutoProgression
+}
+
+// SetAutoProgression turns automatic mode changes on or off
+func (c *ProgressionControllerImpl) SetAutoProgression(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.config.AutoProgression = enabled
+}
+
+// Start evaluates every strategy each evaluation interval
+func (c *ProgressionControllerImpl) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.running {
+		return errors.New("progression controller is already running")
+	}
+	c.running = true
+	c.stopChan = make(chan struct{})
+	go c.poll(ctx)
+	return nil
+}
+
+// Stop stops evaluating
+func (c *ProgressionControllerImpl) Stop() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.running {
+		return nil
+	}
+	c.running = false
+	close(c.stopChan)
+	return nil
+}
+
+func (c *ProgressionControllerImpl) poll(ctx context.Context) {
+	ticker := time.NewTicker(c.config.EvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.stopChan:
+			return
+		case <-ticker.C:
+			if _, err := c.Progress(ctx); err != nil {
+				log.Printf("Failed to evaluate execution progression: %v", err)
+			}
+		}
+	}
+}
+
+// Evaluate measures a strategy against the requirements of its next mode without
+// changing its mode
+func (c *ProgressionControllerImpl) Evaluate(ctx context.Context, strategy interfaces.StrategyType) (*interfaces.ExecutionProgression, error) {
+	c.evaluating.Lock()
+	defer c.evaluating.Unlock()
+
+	now := time.Now()
+	logs, err := c.logger.GetLogsByTimeRange(ctx, now.Add(-c.config.Window), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade logs: %w", err)
+	}
+	return c.evaluate(ctx, strategy, logs, now, false)
+}
+
+// Progressions evaluates every strategy with trades in the window, configured
+// requirements or a mode already seen, in name order, without changing modes
+func (c *ProgressionControllerImpl) Progressions(ctx context.Context) ([]*interfaces.ExecutionProgression, error) {
+	return c.progressions(ctx, false)
+}
+
+// Progress evaluates every strategy like Progressions and, with auto progression
+// on, demotes or advances them. The controller runs it each evaluation interval.
+func (c *ProgressionControllerImpl) Progress(ctx context.Context) ([]*interfaces.ExecutionProgression, error) {
+	return c.progressions(ctx, true)
+}
+
+// progressions evaluates every strategy, changing modes if transition is set
+func (c *ProgressionControllerImpl) progressions(ctx context.Context, transition bool) ([]*interfaces.ExecutionProgression, error) {
+	c.evaluating.Lock()
+	defer c.evaluating.Unlock()
+
+	now := time.Now()
+	logs, err := c.logger.GetLogsByTimeRange(ctx, now.Add(-c.config.Window), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade logs: %w", err)
+	}
+
+	seen := make(map[interfaces.StrategyType]bool)
+	for strategy := range c.config.Strategies {
+		seen[strategy] = true
+	}
+	for _, entry := range logs {
+		seen[entry.Strategy] = true
+	}
+	c.mu.Lock()
+	for strategy := range c.states {
+		seen[strategy] = true
+	}
+	c.mu.Unlock()
+
+	strategies := make([]interfaces.StrategyType, 0, len(seen))
+	for strategy := range seen {
+		strategies = append(strategies, strategy)
+	}
+	sort.Slice(strategies, func(i, j int) bool { return strategies[i] < strategies[j] })
+
+	progressions := make([]*interfaces.ExecutionProgression, 0, len(strategies))
+	for _, strategy := range strategies {
+		progression, err := c.evaluate(ctx, strategy, logs, now, transition)
+		if err != nil {
+			return nil, err
+		}
+		progressions = append(progressions, progression)
+	}
+	return progressions, nil
+}
+
+// evaluate measures a strategy's trades among the logs and, if transition is set
+// and auto progression on, demotes or advances it
+func (c *ProgressionControllerImpl) evaluate(ctx context.Context, strategy interfaces.StrategyType, logs []*interfaces.HistoricalTransactionLog, now time.Time, transition bool) (*interfaces.ExecutionProgression, error) {
+	mode := c.executor.StrategyMode(strategy)
+	state := c.state(strategy, mode, logs, now)
+	stages := c.stages(strategy)
+
+	stats, err := c.measure(ctx, strategy, mode, state.since, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	inMode := now.Sub(state.since)
+	progression := &interfaces.ExecutionProgression{
+		Strategy:          strategy,
+		CurrentMode:       mode,
+		TimeInCurrentMode: inMode.Hours() / 24,
+		ModeSince:         state.since,
+		EvaluatedAt:       now,
+	}
+
+	// A strategy is held to the thresholds it entered its mode on
+	var demoteReason string
+	if current, ok := thresholdsFor(stages, mode); ok {
+		demoteReason = breached(current, stats)
+		progression.ShouldDemote = demoteReason != ""
+	}
+
+	next := nextMode(mode)
+	if next != "" && modeRank(next) <= modeRank(c.maxMode()) {
+		required, _ := thresholdsFor(stages, next)
+		progression.NextMode = next
+		progression.Requirements = requirements(required, stats, inMode, true)
+		progression.CanAdvance = !progression.ShouldDemote
+		for _, requirement := range progression.Requirements {
+			progression.CanAdvance = progression.CanAdvance && requirement.Met
+		}
+	} else if current, ok := thresholdsFor(stages, mode); ok {
+		progression.Requirements = requirements(current, stats, inMode, false)
+	}
+
+	if !transition || !c.AutoProgression() {
+		return progression, nil
+	}
+	switch {
+	case progression.ShouldDemote:
+		progression.Transition, err = c.transition(ctx, strategy, mode, previousMode(mode), demoteReason, now)
+	case progression.CanAdvance:
+		progression.Transition, err = c.transition(ctx, strategy, mode, next, "all requirements met", now)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if progression.Transition != nil {
+		progression.CurrentMode = progression.Transition.To
+		progression.ModeSince = now
+		progression.TimeInCurrentMode = 0
+	}
+	return progression, nil
+}
+
+// measure counts a strategy's trades in a mode since it entered it, their
+// successes and drawdown, and its prediction accuracy
+func (c *ProgressionControllerImpl) measure(ctx context.Context, strategy interfaces.StrategyType, mode interfaces.ExecutionMode, since time.Time, logs []*interfaces.HistoricalTransactionLog) (*progressionStats, error) {
+	var trades []*interfaces.TradeResult
+	for _, entry := range logs {
+		trade := entry.ActualTradeResult
+		if entry.Strategy != strategy || trade == nil || trade.Mode != mode || trade.ExecutedAt.Before(since) {
+			continue
+		}
+		trades = append(trades, trade)
+	}
+	sort.Slice(trades, func(i, j int) bool { return trades[i].ExecutedAt.Before(trades[j].ExecutedAt) })
+
+	stats := &progressionStats{samples: len(trades), drawdown: new(big.Int)}
+	cumulative, peak := new(big.Int), new(big.Int)
+	for _, trade := range trades {
+		if trade.Success {
+			stats.successes++
+		}
+		netProfit := trade.NetProfitWei()
+		if netProfit == nil {
+			continue
+		}
+		cumulative.Add(cumulative, netProfit)
+		if cumulative.Cmp(peak) > 0 {
+			peak.Set(cumulative)
+		}
+		if drawdown := new(big.Int).Sub(peak, cumulative); drawdown.Cmp(stats.drawdown) > 0 {
+			stats.drawdown = drawdown
+		}
+	}
+
+	if c.validator != nil {
+		validation, err := c.validator.ValidateStrategy(ctx, strategy, c.config.Window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate %s: %w", strategy, err)
+		}
+		stats.accuracy = validation.OverallAccuracy
+		stats.validated = true
+	}
+	return stats, nil
+}
+
+// transition switches a strategy's mode and reports it
+func (c *ProgressionControllerImpl) transition(ctx context.Context, strategy interfaces.StrategyType, from, to interfaces.ExecutionMode, reason string, now time.Time) (*interfaces.ModeTransition, error) {
+	if err := c.executor.SetStrategyMode(strategy, to); err != nil {
+		return nil, fmt.Errorf("failed to switch %s to %s: %w", strategy, to, err)
+	}
+	c.state(strategy, to, nil, now)
+
+	transition := &interfaces.ModeTransition{Strategy: strategy, From: from, To: to, Reason: reason, At: now}
+	verb, severity := "advanced", interfaces.AlertSeverityInfo
+	if modeRank(to) < modeRank(from) {
+		verb, severity = "demoted", interfaces.AlertSeverityWarning
+	}
+	message := fmt.Sprintf("%s %s from %s to %s: %s", strategy, verb, from, to, reason)
+	log.Printf("Execution mode of %s", message)
+
+	if c.alerts != nil {
+		alert := &interfaces.Alert{
+			Type:     interfaces.AlertTypeExecutionMode,
+			Severity: severity,
+			Message:  fmt.Sprintf("Strategy %s", message),
+			Details: map[string]interface{}{
+				"strategy": strategy,
+				"from":     from,
+				"to":       to,
+				"reason":   reason,
+			},
+		}
+		if err := c.alerts.SendAlert(ctx, alert); err != nil {
+			log.Printf("Failed to send execution mode alert for %s: %v", strategy, err)
+		}
+	}
+	return transition, nil
+}
+
+// state returns when a strategy entered its mode, restarting the clock if the mode
+// changed since it was last seen. A strategy not seen before is taken to have been
+// in its mode since its first logged trade in it.
+func (c *ProgressionControllerImpl) state(strategy interfaces.StrategyType, mode interfaces.ExecutionMode, logs []*interfaces.HistoricalTransactionLog, now time.Time) *modeState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.states[strategy]
+	if ok && state.mode == mode {
+		return state
+	}
+
+	state = &modeState{mode: mode, since: now}
+	if !ok {
+		for _, entry := range logs {
+			trade := entry.ActualTradeResult
+			if entry.Strategy == strategy && trade != nil && trade.Mode == mode && trade.ExecutedAt.Before(state.since) {
+				state.since = trade.ExecutedAt
+			}
+		}
+	}
+	c.states[strategy] = state
+	return state
+}
+
+// stages returns a strategy's thresholds
+func (c *ProgressionControllerImpl) stages(strategy interfaces.StrategyType) interfaces.ProgressionStages {
+	if stages, ok := c.config.Strategies[strategy]; ok {
+		return stages
+	}
+	return c.config.Default
+}
+
+func (c *ProgressionControllerImpl) maxMode() interfaces.ExecutionMode {
+	if c.config.MaxMode == "" {
+		return interfaces.ExecutionModeLive
+	}
+	return c.config.MaxMode
+}
+
+// requirements lists the thresholds that gate, measured against the stats. Time in
+// mode only gates advancing.
+func requirements(thresholds interfaces.ProgressionThresholds, stats *progressionStats, inMode time.Duration, advancing bool) []*interfaces.ProgressionRequirement {
+	var list []*interfaces.ProgressionRequirement
+	if thresholds.MinSamples > 0 {
+		list = append(list, &interfaces.ProgressionRequirement{
+			Name:        "min_samples",
+			Current:     float64(stats.samples),
+			Required:    float64(thresholds.MinSamples),
+			Unit:        "trades",
+			Met:         stats.samples >= thresholds.MinSamples,
+			Description: "Trades made in the current mode within the window",
+		})
+	}
+	if thresholds.MinSuccessRate > 0 {
+		rate := successRate(stats)
+		list = append(list, &interfaces.ProgressionRequirement{
+			Name:        "success_rate",
+			Current:     rate * 100,
+			Required:    thresholds.MinSuccessRate * 100,
+			Unit:        "%",
+			Met:         stats.samples > 0 && rate >= thresholds.MinSuccessRate,
+			Description: "Share of the current mode's trades that succeeded",
+		})
+	}
+	if thresholds.MinAccuracy > 0 {
+		description := "Prediction accuracy of expected against actual results"
+		if !stats.validated {
+			description = "Prediction accuracy; no performance validator is configured"
+		}
+		list = append(list, &interfaces.ProgressionRequirement{
+			Name:        "prediction_accuracy",
+			Current:     stats.accuracy * 100,
+			Required:    thresholds.MinAccuracy * 100,
+			Unit:        "%",
+			Met:         stats.validated && stats.accuracy >= thresholds.MinAccuracy,
+			Description: description,
+		})
+	}
+	if thresholds.MaxDrawdown != nil {
+		list = append(list, &interfaces.ProgressionRequirement{
+			Name:        "max_drawdown",
+			Current:     weiToETH(stats.drawdown),
+			Required:    weiToETH(thresholds.MaxDrawdown),
+			Unit:        "ETH",
+			Met:         stats.drawdown.Cmp(thresholds.MaxDrawdown) <= 0,
+			Description: "Largest fall in cumulative net profit in the current mode",
+		})
+	}
+	if advancing && thresholds.MinTimeInMode > 0 {
+		list = append(list, &interfaces.ProgressionRequirement{
+			Name:        "time_in_mode",
+			Current:     inMode.Hours(),
+			Required:    thresholds.MinTimeInMode.Hours(),
+			Unit:        "hours",
+			Met:         inMode >= thresholds.MinTimeInMode,
+			Description: "Time since the strategy entered the current mode",
+		})
+	}
+	return list
+}
+
+// breached returns why a strategy no longer meets the thresholds of its mode, or
+// an empty string. Rates are only judged once there are enough samples.
+func breached(thresholds interfaces.ProgressionThresholds, stats *progressionStats) string {
+	var reasons []string
+	if thresholds.MaxDrawdown != nil && stats.drawdown.Cmp(thresholds.MaxDrawdown) > 0 {
+		reasons = append(reasons, fmt.Sprintf("drawdown of %s exceeds %s", stats.drawdown, thresholds.MaxDrawdown))
+	}
+	if stats.samples > 0 && stats.samples >= thresholds.MinSamples {
+		if rate := successRate(stats); thresholds.MinSuccessRate > 0 && rate < thresholds.MinSuccessRate {
+			reasons = append(reasons, fmt.Sprintf("success rate %.1f%% is below %.1f%%", rate*100, thresholds.MinSuccessRate*100))
+		}
+		if stats.validated && thresholds.MinAccuracy > 0 && stats.accuracy < thresholds.MinAccuracy {
+			reasons = append(reasons, fmt.Sprintf("prediction accuracy %.1f%% is below %.1f%%", stats.accuracy*100, thresholds.MinAccuracy*100))
+		}
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func successRate(stats *progressionStats) float64 {
+	if stats.samples == 0 {
+		return 0
+	}
+	return float64(stats.successes) / float64(stats.samples)
+}
+
+// thresholdsFor returns the thresholds for entering a mode; simulation has none
+func thresholdsFor(stages interfaces.ProgressionStages, mode interfaces.ExecutionMode) (interfaces.ProgressionThresholds, bool) {
+	switch mode {
+	case interfaces.ExecutionModeHybrid:
+		return stages.Hybrid, true
+	case interfaces.ExecutionModeLive:
+		return stages.Live, true
+	default:
+		return interfaces.ProgressionThresholds{}, false
+	}
+}
+
+func modeRank(mode interfaces.ExecutionMode) int {
+	for i, m := range modeOrder {
+		if m == mode {
+			return i
+		}
+	}
+	return -1
+}
+
+func nextMode(mode interfaces.ExecutionMode) interfaces.ExecutionMode {
+	if rank := modeRank(mode); rank >= 0 && rank+1 < len(modeOrder) {
+		return modeOrder[rank+1]
+	}
+	return ""
+}
+
+func previousMode(mode interfaces.ExecutionMode) interfaces.ExecutionMode {
+	if rank := modeRank(mode); rank > 0 {
+		return modeOrder[rank-1]
+	}
+	return interfaces.ExecutionModeSimulation
+}
+
+// weiToETH converts wei to a float number of ETH for display
+func weiToETH(wei *big.Int) float64 {
+	eth, _ := new(big.Float).Quo(new(big.Float).SetInt(wei), big.NewFloat(1e18)).Float64()
+	return eth
+}
@@ -0,0 +1,238 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTradeLog serves historical logs by time range
+type fakeTradeLog struct {
+	fakeTransactionLogger
+	logs []*interfaces.HistoricalTransactionLog
+}
+
+func (l *fakeTradeLog) GetLogsByTimeRange(ctx context.Context, start, end time.Time) ([]*interfaces.HistoricalTransactionLog, error) {
+	var logs []*interfaces.HistoricalTransactionLog
+	for _, entry := range l.logs {
+		if !entry.CreatedAt.Before(start) && !entry.CreatedAt.After(end) {
+			logs = append(logs, entry)
+		}
+	}
+	return logs, nil
+}
+
+// add logs count trades of a strategy in a mode, each netting netProfit wei of ETH.
+// Trades are logged in order over the last few seconds.
+func (l *fakeTradeLog) add(strategy interfaces.StrategyType, mode interfaces.ExecutionMode, count int, success bool, netProfit int64) {
+	for i := 0; i < count; i++ {
+		at := time.Now().Add(time.Duration(len(l.logs)-1000) * time.Millisecond)
+		l.logs = append(l.logs, &interfaces.HistoricalTransactionLog{
+			ID:        fmt.Sprintf("log_%d", len(l.logs)),
+			Strategy:  strategy,
+			CreatedAt: at,
+			ActualTradeResult: &interfaces.TradeResult{
+				Strategy:   strategy,
+				ExecutedAt: at,
+				Success:    success,
+				NetProfit:  big.NewInt(netProfit),
+				Mode:       mode,
+			},
+		})
+	}
+}
+
+// fakeValidator reports a fixed prediction accuracy
+type fakeValidator struct {
+	accuracy float64
+}
+
+func (v *fakeValidator) ValidateStrategy(ctx context.Context, strategy interfaces.StrategyType, timeWindow time.Duration) (*interfaces.StrategyValidationResult, error) {
+	return &interfaces.StrategyValidationResult{Strategy: strategy, OverallAccuracy: v.accuracy}, nil
+}
+
+func (v *fakeValidator) CheckThresholdChanges(ctx context.Context, oldThresholds, newThresholds map[interfaces.StrategyType]*interfaces.ProfitThreshold) (*interfaces.ThresholdValidationResult, error) {
+	return nil, nil
+}
+
+func (v *fakeValidator) GeneratePerformanceReport(ctx context.Context, strategy interfaces.StrategyType) (*interfaces.PerformanceReport, error) {
+	return nil, nil
+}
+
+// fakeAlertManager records sent alerts
+type fakeAlertManager struct {
+	alerts []*interfaces.Alert
+}
+
+func (m *fakeAlertManager) SendAlert(ctx context.Context, alert *interfaces.Alert) error {
+	m.alerts = append(m.alerts, alert)
+	return nil
+}
+
+func (m *fakeAlertManager) RegisterAlertRule(rule *interfaces.AlertRule) error { return nil }
+
+func (m *fakeAlertManager) GetActiveAlerts() ([]*interfaces.Alert, error) { return m.alerts, nil }
+
+func (m *fakeAlertManager) AcknowledgeAlert(alertID string) error { return nil }
+
+// testProgressionConfig needs 10 trades at 80% success and 70% accuracy, within a
+// 0.01 ETH drawdown, after an hour in a mode
+func testProgressionConfig() *interfaces.ProgressionConfig {
+	thresholds := interfaces.ProgressionThresholds{
+		MinSamples:     10,
+		MinSuccessRate: 0.8,
+		MinAccuracy:    0.7,
+		MaxDrawdown:    big.NewInt(1e16),
+		MinTimeInMode:  time.Hour,
+	}
+	config := DefaultProgressionConfig()
+	config.AutoProgression = true
+	config.Default = interfaces.ProgressionStages{Hybrid: thresholds, Live: thresholds}
+	return config
+}
+
+// requirementsByName indexes a progression's requirements
+func requirementsByName(progression *interfaces.ExecutionProgression) map[string]*interfaces.ProgressionRequirement {
+	byName := make(map[string]*interfaces.ProgressionRequirement)
+	for _, requirement := range progression.Requirements {
+		byName[requirement.Name] = requirement
+	}
+	return byName
+}
+
+func TestProgressionController_Advance(t *testing.T) {
+	ctx := context.Background()
+	executor := NewTradeExecutor(nil, nil, &fakeSubmitter{}, nil, nil)
+	trades := &fakeTradeLog{}
+	alerts := &fakeAlertManager{}
+	controller := NewProgressionController(testProgressionConfig(), executor, trades, &fakeValidator{accuracy: 0.9}, alerts)
+
+	// Too few trades, too recently
+	trades.add(interfaces.StrategyBackrun, interfaces.ExecutionModeSimulation, 5, true, 1e15)
+	progression, err := controller.Evaluate(ctx, interfaces.StrategyBackrun)
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ExecutionModeSimulation, progression.CurrentMode)
+	assert.Equal(t, interfaces.ExecutionModeHybrid, progression.NextMode)
+	assert.False(t, progression.CanAdvance)
+	requirements := requirementsByName(progression)
+	require.Len(t, requirements, 5)
+	assert.False(t, requirements["min_samples"].Met)
+	assert.Equal(t, 5.0, requirements["min_samples"].Current)
+	assert.True(t, requirements["success_rate"].Met)
+	assert.True(t, requirements["prediction_accuracy"].Met)
+	assert.True(t, requirements["max_drawdown"].Met)
+	assert.False(t, requirements["time_in_mode"].Met)
+	assert.Empty(t, alerts.alerts)
+
+	// Enough trades after an hour in simulation can advance, but evaluating
+	// doesn't change the mode
+	controller.states[interfaces.StrategyBackrun].since = time.Now().Add(-2 * time.Hour)
+	trades.add(interfaces.StrategyBackrun, interfaces.ExecutionModeSimulation, 5, true, 1e15)
+	progression, err = controller.Evaluate(ctx, interfaces.StrategyBackrun)
+	require.NoError(t, err)
+	assert.True(t, progression.CanAdvance)
+	assert.Nil(t, progression.Transition)
+	assert.Equal(t, interfaces.ExecutionModeSimulation, executor.StrategyMode(interfaces.StrategyBackrun))
+	assert.Empty(t, alerts.alerts)
+
+	// Progressing advances it to hybrid
+	progressions, err := controller.Progress(ctx)
+	require.NoError(t, err)
+	require.Len(t, progressions, 1)
+	progression = progressions[0]
+	assert.True(t, progression.CanAdvance)
+	require.NotNil(t, progression.Transition)
+	assert.Equal(t, interfaces.ExecutionModeHybrid, progression.Transition.To)
+	assert.Equal(t, interfaces.ExecutionModeHybrid, executor.StrategyMode(interfaces.StrategyBackrun))
+	assert.Equal(t, interfaces.ExecutionModeSimulation, executor.StrategyMode(interfaces.StrategySandwich))
+	require.Len(t, alerts.alerts, 1)
+	assert.Equal(t, interfaces.AlertTypeExecutionMode, alerts.alerts[0].Type)
+	assert.Equal(t, interfaces.AlertSeverityInfo, alerts.alerts[0].Severity)
+
+	// Simulated trades don't count toward live
+	progression, err = controller.Evaluate(ctx, interfaces.StrategyBackrun)
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ExecutionModeLive, progression.NextMode)
+	assert.Equal(t, 0.0, requirementsByName(progression)["min_samples"].Current)
+	assert.False(t, progression.ShouldDemote)
+	assert.Nil(t, progression.Transition)
+}
+
+func TestProgressionController_Demote(t *testing.T) {
+	ctx := context.Background()
+	executor := NewTradeExecutor(nil, nil, &fakeSubmitter{}, nil, nil)
+	require.NoError(t, executor.SetStrategyMode(interfaces.StrategyBackrun, interfaces.ExecutionModeLive))
+	require.NoError(t, executor.SetStrategyMode(interfaces.StrategySandwich, interfaces.ExecutionModeHybrid))
+	trades := &fakeTradeLog{}
+	alerts := &fakeAlertManager{}
+	controller := NewProgressionController(testProgressionConfig(), executor, trades, &fakeValidator{accuracy: 0.9}, alerts)
+	controller.SetAutoProgression(false)
+
+	// Live backruns are in drawdown; hybrid sandwiches mostly fail
+	since := time.Now().Add(-time.Minute)
+	controller.states[interfaces.StrategyBackrun] = &modeState{mode: interfaces.ExecutionModeLive, since: since}
+	controller.states[interfaces.StrategySandwich] = &modeState{mode: interfaces.ExecutionModeHybrid, since: since}
+	trades.add(interfaces.StrategyBackrun, interfaces.ExecutionModeLive, 2, true, 2e16)
+	trades.add(interfaces.StrategyBackrun, interfaces.ExecutionModeLive, 3, true, -1e16)
+	trades.add(interfaces.StrategySandwich, interfaces.ExecutionModeHybrid, 4, true, 1e15)
+	trades.add(interfaces.StrategySandwich, interfaces.ExecutionModeHybrid, 6, false, 0)
+
+	// Without auto progression the demotions are only reported
+	progressions, err := controller.Progressions(ctx)
+	require.NoError(t, err)
+	require.Len(t, progressions, 2)
+	backrun, sandwich := progressions[0], progressions[1]
+	assert.Equal(t, interfaces.StrategyBackrun, backrun.Strategy)
+	assert.True(t, backrun.ShouldDemote)
+	assert.Empty(t, backrun.NextMode)
+	assert.InDelta(t, 0.03, requirementsByName(backrun)["max_drawdown"].Current, 1e-9)
+	assert.True(t, sandwich.ShouldDemote)
+	assert.False(t, sandwich.CanAdvance)
+	assert.Equal(t, interfaces.ExecutionModeLive, executor.StrategyMode(interfaces.StrategyBackrun))
+	assert.Empty(t, alerts.alerts)
+
+	controller.SetAutoProgression(true)
+	progressions, err = controller.Progressions(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, progressions[0].Transition)
+	assert.Equal(t, interfaces.ExecutionModeLive, executor.StrategyMode(interfaces.StrategyBackrun))
+
+	progressions, err = controller.Progress(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ExecutionModeHybrid, executor.StrategyMode(interfaces.StrategyBackrun))
+	assert.Equal(t, interfaces.ExecutionModeSimulation, executor.StrategyMode(interfaces.StrategySandwich))
+	require.NotNil(t, progressions[0].Transition)
+	assert.Contains(t, progressions[0].Transition.Reason, "drawdown")
+	assert.Contains(t, progressions[1].Transition.Reason, "success rate")
+	require.Len(t, alerts.alerts, 2)
+	assert.Equal(t, interfaces.AlertSeverityWarning, alerts.alerts[0].Severity)
+}
+
+func TestProgressionController_MaxMode(t *testing.T) {
+	ctx := context.Background()
+	executor := NewTradeExecutor(nil, nil, &fakeSubmitter{}, nil, nil)
+	require.NoError(t, executor.SetStrategyMode(interfaces.StrategyBackrun, interfaces.ExecutionModeHybrid))
+	trades := &fakeTradeLog{}
+	config := testProgressionConfig()
+	config.MaxMode = interfaces.ExecutionModeHybrid
+	controller := NewProgressionController(config, executor, trades, nil, nil)
+
+	controller.states[interfaces.StrategyBackrun] = &modeState{mode: interfaces.ExecutionModeHybrid, since: time.Now().Add(-2 * time.Hour)}
+	trades.add(interfaces.StrategyBackrun, interfaces.ExecutionModeHybrid, 10, true, 1e15)
+	progression, err := controller.Evaluate(ctx, interfaces.StrategyBackrun)
+	require.NoError(t, err)
+
+	// Hybrid is as far as auto progression goes; without a validator accuracy is unmet
+	assert.Empty(t, progression.NextMode)
+	assert.False(t, progression.CanAdvance)
+	assert.False(t, progression.ShouldDemote)
+	assert.False(t, requirementsByName(progression)["prediction_accuracy"].Met)
+	assert.Nil(t, progression.Transition)
+	assert.Equal(t, interfaces.ExecutionModeHybrid, executor.StrategyMode(interfaces.StrategyBackrun))
+}
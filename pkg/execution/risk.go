@@ -0,0 +1,490 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+const (
+	riskAuditSize = 1000 // Rejections kept for the API
+	riskScope     = "global"
+)
+
+// DefaultRiskConfig returns risk limits that don't limit anything
+func DefaultRiskConfig() *interfaces.RiskConfig {
+	return &interfaces.RiskConfig{}
+}
+
+// riskEvent is an approved bundle's contribution to the trailing windows
+type riskEvent struct {
+	at       time.Time
+	strategy interfaces.StrategyType
+	notional *big.Int
+	gas      *big.Int // Estimated until settled
+	pnl      *big.Int // Nil until settled, or if the trade couldn't be priced
+	settled  bool
+}
+
+// riskAssessment is what a bundle would add to our exposure
+type riskAssessment struct {
+	notional *big.Int // Nil if an input couldn't be priced
+	unpriced common.Address
+	gas      *big.Int
+	tokens   []common.Address
+}
+
+// RiskEngineImpl implements the RiskEngine interface. A bundle's notional is its
+// largest input, the ETH a transaction sends plus the input of an executor call,
+// valued in ETH; its gas is its gas limits at their gas prices until the trade
+// settles. Volume and losses are summed over the last 24 hours and gas over the
+// last hour, by approval time.
+type RiskEngineImpl struct {
+	config      *interfaces.RiskConfig
+	priceOracle interfaces.PriceOracle
+	inFlight    map[string]*riskEvent // By opportunity ID
+	events      []*riskEvent
+	rejections  []*interfaces.RiskRejection // Ring of the latest rejections
+	rejected    int
+	mu          sync.Mutex
+}
+
+// NewRiskEngine creates a risk engine
+func NewRiskEngine(config *interfaces.RiskConfig) (*RiskEngineImpl, error) {
+	if config == nil {
+		config = DefaultRiskConfig()
+	}
+	if err := validateRiskConfig(config); err != nil {
+		return nil, err
+	}
+	return &RiskEngineImpl{
+		config:   copyRiskConfig(config),
+		inFlight: make(map[string]*riskEvent),
+	}, nil
+}
+
+// SetPriceOracle sets the oracle that values trade inputs in ETH. Without one, only
+// ETH inputs can be priced, and bundles with other inputs fail notional limits.
+func (r *RiskEngineImpl) SetPriceOracle(oracle interfaces.PriceOracle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.priceOracle = oracle
+}
+
+// Approve checks a bundle against the limits and counts it as in flight
+func (r *RiskEngineImpl) Approve(ctx context.Context, opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction) error {
+	if opportunity == nil {
+		return errors.New("opportunity cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	assessment := r.assess(opportunity, bundle)
+	now := time.Now()
+	r.prune(now)
+
+	// The strategy's limits apply to its own usage, on top of the global ones
+	scopes := []interfaces.StrategyType{""}
+	if _, ok := r.config.Strategies[opportunity.Strategy]; ok {
+		scopes = append(scopes, opportunity.Strategy)
+	}
+	for _, strategy := range scopes {
+		limits, name := r.config.Global, riskScope
+		if strategy != "" {
+			limits, name = r.config.Strategies[strategy], string(strategy)
+		}
+		limit, reason := r.check(&limits, assessment, r.usage(now, strategy))
+		if limit == "" {
+			continue
+		}
+		rejection := &interfaces.RiskRejection{
+			Time:          now,
+			OpportunityID: opportunity.ID,
+			Strategy:      opportunity.Strategy,
+			Scope:         name,
+			Limit:         limit,
+			Reason:        reason,
+		}
+		r.audit(rejection)
+		return rejection
+	}
+
+	if previous, ok := r.inFlight[opportunity.ID]; ok {
+		// Approved again without being submitted; only the latest attempt counts
+		r.remove(previous)
+	}
+	notional := assessment.notional
+	if notional == nil {
+		notional = new(big.Int)
+	}
+	event := &riskEvent{at: now, strategy: opportunity.Strategy, notional: notional, gas: assessment.gas}
+	r.events = append(r.events, event)
+	r.inFlight[opportunity.ID] = event
+	return nil
+}
+
+// Cancel releases an approved bundle that wasn't submitted, so it counts toward
+// none of the limits
+func (r *RiskEngineImpl) Cancel(opportunityID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event, ok := r.inFlight[opportunityID]; ok {
+		delete(r.inFlight, opportunityID)
+		r.remove(event)
+	}
+}
+
+// Settle releases an approved bundle, replacing its estimated gas with the trade's
+// and counting its net profit. Trades that weren't approved are ignored.
+func (r *RiskEngineImpl) Settle(trade *interfaces.TradeResult) {
+	if trade == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	event, ok := r.inFlight[trade.OpportunityID]
+	if !ok {
+		return
+	}
+	delete(r.inFlight, trade.OpportunityID)
+	event.settled = true
+	if trade.GasCost != nil {
+		event.gas = new(big.Int).Set(trade.GasCost)
+	}
+	if pnl := trade.NetProfitWei(); pnl != nil {
+		event.pnl = new(big.Int).Set(pnl)
+	}
+}
+
+// Limits returns a copy of the current limits
+func (r *RiskEngineImpl) Limits() *interfaces.RiskConfig {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return copyRiskConfig(r.config)
+}
+
+// UpdateLimits validates and replaces the limits
+func (r *RiskEngineImpl) UpdateLimits(config *interfaces.RiskConfig) error {
+	if config == nil {
+		return errors.New("risk config cannot be nil")
+	}
+	if err := validateRiskConfig(config); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.config = copyRiskConfig(config)
+	log.Printf("Risk limits updated: global and %d strategies", len(config.Strategies))
+	return nil
+}
+
+// Rejections returns up to limit of the most recent rejections, newest first
+func (r *RiskEngineImpl) Rejections(limit int) []*interfaces.RiskRejection {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if limit <= 0 || limit > len(r.rejections) {
+		limit = len(r.rejections)
+	}
+	rejections := make([]*interfaces.RiskRejection, 0, limit)
+	for i := 0; i < limit; i++ {
+		// The ring's newest entry is just before the next write
+		index := (r.rejected - 1 - i) % riskAuditSize
+		rejection := *r.rejections[index]
+		rejections = append(rejections, &rejection)
+	}
+	return rejections
+}
+
+// Exposure returns the current usage, globally and for each strategy with usage
+func (r *RiskEngineImpl) Exposure() *interfaces.RiskExposure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.prune(now)
+	exposure := &interfaces.RiskExposure{
+		Global:     r.usage(now, ""),
+		Strategies: make(map[interfaces.StrategyType]*interfaces.RiskUsage),
+		Rejections: r.rejected,
+	}
+	for _, event := range r.events {
+		if _, ok := exposure.Strategies[event.strategy]; !ok {
+			exposure.Strategies[event.strategy] = r.usage(now, event.strategy)
+		}
+	}
+	return exposure
+}
+
+// assess values what a bundle would add to our exposure. The target's gas and
+// value are its sender's.
+func (r *RiskEngineImpl) assess(opportunity *interfaces.MEVOpportunity, bundle []*types.Transaction) *riskAssessment {
+	assessment := &riskAssessment{notional: new(big.Int), gas: new(big.Int)}
+	if opportunity.ProfitToken != (common.Address{}) {
+		assessment.tokens = append(assessment.tokens, opportunity.ProfitToken)
+	}
+
+	for _, tx := range bundle {
+		if tx == nil || isTarget(opportunity.TargetTx, tx) {
+			continue
+		}
+		if tx.GasPrice != nil {
+			gas := new(big.Int).SetUint64(tx.GasLimit)
+			assessment.gas.Add(assessment.gas, gas.Mul(gas, tx.GasPrice))
+		}
+
+		input := new(big.Int)
+		if tx.Value != nil {
+			input.Set(tx.Value)
+		}
+		if tokenIn, amountIn, tokenOut, ok := decodeExecute(tx.Data); ok {
+			assessment.tokens = append(assessment.tokens, tokenIn, tokenOut)
+			value, err := r.valueETH(tokenIn, amountIn)
+			if err != nil {
+				assessment.notional = nil
+				assessment.unpriced = tokenIn
+				continue
+			}
+			input.Add(input, value)
+		}
+		if assessment.notional != nil && input.Cmp(assessment.notional) > 0 {
+			assessment.notional = input
+		}
+	}
+	return assessment
+}
+
+// valueETH values an amount of a token in wei of ETH
+func (r *RiskEngineImpl) valueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	if amount == nil || amount.Sign() == 0 {
+		return new(big.Int), nil
+	}
+	if r.priceOracle == nil {
+		if token == (common.Address{}) {
+			return new(big.Int).Set(amount), nil
+		}
+		return nil, errors.New("no price oracle")
+	}
+	return r.priceOracle.ValueETH(token, amount)
+}
+
+// check returns the first limit the assessed bundle would break, and why
+func (r *RiskEngineImpl) check(limits *interfaces.RiskLimits, assessment *riskAssessment, usage *interfaces.RiskUsage) (string, string) {
+	for _, token := range assessment.tokens {
+		if containsAddress(limits.DeniedTokens, token) {
+			return "denied_tokens", fmt.Sprintf("token %s is denied", token.Hex())
+		}
+		if len(limits.AllowedTokens) > 0 && !containsAddress(limits.AllowedTokens, token) {
+			return "allowed_tokens", fmt.Sprintf("token %s is not allowed", token.Hex())
+		}
+	}
+
+	if limits.MaxDailyLoss != nil {
+		loss := new(big.Int).Neg(usage.DailyPnL)
+		if loss.Cmp(limits.MaxDailyLoss) > 0 {
+			return "max_daily_loss", fmt.Sprintf("daily loss of %s exceeds %s", loss, limits.MaxDailyLoss)
+		}
+	}
+	if limits.MaxNotional != nil || limits.MaxDailyVolume != nil {
+		if assessment.notional == nil {
+			limit := "max_notional"
+			if limits.MaxNotional == nil {
+				limit = "max_daily_volume"
+			}
+			return limit, fmt.Sprintf("input in %s can't be priced", assessment.unpriced.Hex())
+		}
+	}
+	if limits.MaxNotional != nil && assessment.notional.Cmp(limits.MaxNotional) > 0 {
+		return "max_notional", fmt.Sprintf("notional %s exceeds %s", assessment.notional, limits.MaxNotional)
+	}
+	if limits.MaxDailyVolume != nil {
+		volume := new(big.Int).Add(usage.DailyVolume, assessment.notional)
+		if volume.Cmp(limits.MaxDailyVolume) > 0 {
+			return "max_daily_volume", fmt.Sprintf("daily volume would reach %s, over %s", volume, limits.MaxDailyVolume)
+		}
+	}
+	if limits.MaxGasPerHour != nil {
+		gas := new(big.Int).Add(usage.HourlyGas, assessment.gas)
+		if gas.Cmp(limits.MaxGasPerHour) > 0 {
+			return "max_gas_per_hour", fmt.Sprintf("hourly gas would reach %s, over %s", gas, limits.MaxGasPerHour)
+		}
+	}
+	if limits.MaxInFlight > 0 && usage.InFlight >= limits.MaxInFlight {
+		return "max_in_flight", fmt.Sprintf("%d bundles already in flight", usage.InFlight)
+	}
+	return "", ""
+}
+
+// usage sums the windows for a strategy, or for all strategies if it is empty
+func (r *RiskEngineImpl) usage(now time.Time, strategy interfaces.StrategyType) *interfaces.RiskUsage {
+	usage := &interfaces.RiskUsage{
+		DailyVolume: new(big.Int),
+		HourlyGas:   new(big.Int),
+		DailyPnL:    new(big.Int),
+	}
+	dayAgo, hourAgo := now.Add(-24*time.Hour), now.Add(-time.Hour)
+	for _, event := range r.events {
+		if strategy != "" && event.strategy != strategy || !event.at.After(dayAgo) {
+			continue
+		}
+		usage.DailyVolume.Add(usage.DailyVolume, event.notional)
+		if event.at.After(hourAgo) {
+			usage.HourlyGas.Add(usage.HourlyGas, event.gas)
+		}
+		if event.pnl != nil {
+			usage.DailyPnL.Add(usage.DailyPnL, event.pnl)
+		}
+	}
+	for _, event := range r.inFlight {
+		if strategy == "" || event.strategy == strategy {
+			usage.InFlight++
+		}
+	}
+	return usage
+}
+
+// prune drops settled events older than a day. Bundles still in flight are kept,
+// as they count toward the in-flight limit however old they are.
+func (r *RiskEngineImpl) prune(now time.Time) {
+	dayAgo := now.Add(-24 * time.Hour)
+	kept := r.events[:0]
+	for _, event := range r.events {
+		if event.at.After(dayAgo) || !event.settled {
+			kept = append(kept, event)
+		}
+	}
+	for i := len(kept); i < len(r.events); i++ {
+		r.events[i] = nil
+	}
+	r.events = kept
+}
+
+// remove drops an event from the windows
+func (r *RiskEngineImpl) remove(target *riskEvent) {
+	for i, event := range r.events {
+		if event == target {
+			r.events = append(r.events[:i], r.events[i+1:]...)
+			return
+		}
+	}
+}
+
+// audit keeps and logs a rejection
+func (r *RiskEngineImpl) audit(rejection *interfaces.RiskRejection) {
+	if len(r.rejections) < riskAuditSize {
+		r.rejections = append(r.rejections, rejection)
+	} else {
+		r.rejections[r.rejected%riskAuditSize] = rejection
+	}
+	r.rejected++
+	log.Printf("Risk engine rejected %s (%s): %v", rejection.OpportunityID, rejection.Strategy, rejection)
+}
+
+// decodeExecute returns the input and output tokens of a call to our executor
+func decodeExecute(data []byte) (common.Address, *big.Int, common.Address, bool) {
+	if inner, ok := decodeFlashExecute(data); ok {
+		data = inner
+	}
+	method := executorContract.Methods["execute"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return common.Address{}, nil, common.Address{}, false
+	}
+	values, err := method.Inputs.Unpack(data[4:])
+	if err != nil || len(values) < 3 {
+		return common.Address{}, nil, common.Address{}, false
+	}
+	tokenIn, ok1 := values[0].(common.Address)
+	amountIn, ok2 := values[1].(*big.Int)
+	tokenOut, ok3 := values[2].(common.Address)
+	if !ok1 || !ok2 || !ok3 {
+		return common.Address{}, nil, common.Address{}, false
+	}
+	return tokenIn, amountIn, tokenOut, true
+}
+
+// validateRiskConfig rejects negative limits and tokens both allowed and denied
+func validateRiskConfig(config *interfaces.RiskConfig) error {
+	if err := validateRiskLimits(&config.Global); err != nil {
+		return fmt.Errorf("global: %w", err)
+	}
+	for strategy, limits := range config.Strategies {
+		limits := limits
+		if err := validateRiskLimits(&limits); err != nil {
+			return fmt.Errorf("%s: %w", strategy, err)
+		}
+	}
+	return nil
+}
+
+func validateRiskLimits(limits *interfaces.RiskLimits) error {
+	for name, amount := range map[string]*big.Int{
+		"max_notional":     limits.MaxNotional,
+		"max_daily_volume": limits.MaxDailyVolume,
+		"max_gas_per_hour": limits.MaxGasPerHour,
+		"max_daily_loss":   limits.MaxDailyLoss,
+	} {
+		if amount != nil && amount.Sign() < 0 {
+			return fmt.Errorf("%s cannot be negative", name)
+		}
+	}
+	if limits.MaxInFlight < 0 {
+		return errors.New("max_in_flight cannot be negative")
+	}
+	for _, token := range limits.AllowedTokens {
+		if containsAddress(limits.DeniedTokens, token) {
+			return fmt.Errorf("token %s is both allowed and denied", token.Hex())
+		}
+	}
+	return nil
+}
+
+// copyRiskConfig deep-copies limits so callers can't change them in place
+func copyRiskConfig(config *interfaces.RiskConfig) *interfaces.RiskConfig {
+	copied := &interfaces.RiskConfig{Global: copyRiskLimits(config.Global)}
+	if len(config.Strategies) > 0 {
+		copied.Strategies = make(map[interfaces.StrategyType]interfaces.RiskLimits, len(config.Strategies))
+		for strategy, limits := range config.Strategies {
+			copied.Strategies[strategy] = copyRiskLimits(limits)
+		}
+	}
+	return copied
+}
+
+func copyRiskLimits(limits interfaces.RiskLimits) interfaces.RiskLimits {
+	copyInt := func(value *big.Int) *big.Int {
+		if value == nil {
+			return nil
+		}
+		return new(big.Int).Set(value)
+	}
+	return interfaces.RiskLimits{
+		MaxNotional:    copyInt(limits.MaxNotional),
+		MaxDailyVolume: copyInt(limits.MaxDailyVolume),
+		MaxGasPerHour:  copyInt(limits.MaxGasPerHour),
+		MaxDailyLoss:   copyInt(limits.MaxDailyLoss),
+		MaxInFlight:    limits.MaxInFlight,
+		AllowedTokens:  append([]common.Address(nil), limits.AllowedTokens...),
+		DeniedTokens:   append([]common.Address(nil), limits.DeniedTokens...),
+	}
+}
+
+func containsAddress(addresses []common.Address, target common.Address) bool {
+	for _, address := range addresses {
+		if address == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// riskOpportunity is a backrun whose one executor call sells amountIn of tokenIn
+// for USDC, at 600k gas and 1 gwei
+func riskOpportunity(t *testing.T, id string, tokenIn common.Address, amountIn *big.Int) *interfaces.MEVOpportunity {
+	data, err := executorContract.Pack("execute", tokenIn, amountIn, testUSDC, big.NewInt(0), big.NewInt(0), []struct {
+		Target common.Address
+		Value  *big.Int
+		Data   []byte
+	}{})
+	require.NoError(t, err)
+
+	opportunity := testOpportunity()
+	opportunity.ID = id
+	opportunity.ExecutionTxs = []*types.Transaction{
+		{From: testSearcher, To: &testExecutor, GasPrice: big.NewInt(1e9), GasLimit: 600000, Data: data},
+	}
+	return opportunity
+}
+
+// newTestRiskEngine creates a risk engine that prices WETH
+func newTestRiskEngine(t *testing.T, config *interfaces.RiskConfig) *RiskEngineImpl {
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	risk, err := NewRiskEngine(config)
+	require.NoError(t, err)
+	risk.SetPriceOracle(oracle)
+	return risk
+}
+
+func TestRiskEngine_Approve(t *testing.T) {
+	oneETH := big.NewInt(1e18)
+
+	tests := []struct {
+		name    string
+		config  *interfaces.RiskConfig
+		tokenIn common.Address
+		scope   string
+		limit   string
+	}{
+		{name: "no limits", config: nil, tokenIn: testDAI},
+		{
+			name:    "within notional",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxNotional: oneETH}},
+			tokenIn: testWETH,
+		},
+		{
+			name:    "over notional",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxNotional: big.NewInt(5e17)}},
+			tokenIn: testWETH,
+			scope:   "global",
+			limit:   "max_notional",
+		},
+		{
+			name:    "unpriced notional",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxDailyVolume: oneETH}},
+			tokenIn: testDAI,
+			scope:   "global",
+			limit:   "max_daily_volume",
+		},
+		{
+			name:    "over hourly gas",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxGasPerHour: big.NewInt(5e14)}},
+			tokenIn: testWETH,
+			scope:   "global",
+			limit:   "max_gas_per_hour",
+		},
+		{
+			name:    "denied token",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{DeniedTokens: []common.Address{testUSDC}}},
+			tokenIn: testWETH,
+			scope:   "global",
+			limit:   "denied_tokens",
+		},
+		{
+			name:    "token not allowed",
+			config:  &interfaces.RiskConfig{Global: interfaces.RiskLimits{AllowedTokens: []common.Address{testWETH}}},
+			tokenIn: testWETH,
+			scope:   "global",
+			limit:   "allowed_tokens",
+		},
+		{
+			name: "strategy limit",
+			config: &interfaces.RiskConfig{
+				Global:     interfaces.RiskLimits{MaxNotional: big.NewInt(2e18)},
+				Strategies: map[interfaces.StrategyType]interfaces.RiskLimits{interfaces.StrategyBackrun: {MaxNotional: big.NewInt(5e17)}},
+			},
+			tokenIn: testWETH,
+			scope:   "backrun",
+			limit:   "max_notional",
+		},
+		{
+			name: "other strategy's limit",
+			config: &interfaces.RiskConfig{
+				Strategies: map[interfaces.StrategyType]interfaces.RiskLimits{interfaces.StrategySandwich: {MaxNotional: big.NewInt(5e17)}},
+			},
+			tokenIn: testWETH,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := newTestRiskEngine(t, tt.config)
+			opportunity := riskOpportunity(t, "opp_1", tt.tokenIn, big.NewInt(1e18))
+			err := risk.Approve(context.Background(), opportunity, opportunity.ExecutionTxs)
+
+			if tt.limit == "" {
+				require.NoError(t, err)
+				assert.Equal(t, 1, risk.Exposure().Global.InFlight)
+				assert.Empty(t, risk.Rejections(0))
+				return
+			}
+			var rejection *interfaces.RiskRejection
+			require.True(t, errors.As(err, &rejection))
+			assert.Equal(t, tt.scope, rejection.Scope)
+			assert.Equal(t, tt.limit, rejection.Limit)
+			assert.Equal(t, "opp_1", rejection.OpportunityID)
+			assert.NotEmpty(t, rejection.Reason)
+			assert.Equal(t, []*interfaces.RiskRejection{rejection}, risk.Rejections(10))
+			assert.Equal(t, 0, risk.Exposure().Global.InFlight)
+		})
+	}
+}
+
+func TestRiskEngine_Windows(t *testing.T) {
+	ctx := context.Background()
+	risk := newTestRiskEngine(t, &interfaces.RiskConfig{Global: interfaces.RiskLimits{
+		MaxDailyVolume: big.NewInt(25e17),
+		MaxDailyLoss:   big.NewInt(1e16),
+		MaxInFlight:    1,
+	}})
+	approve := func(id string) error {
+		opportunity := riskOpportunity(t, id, testWETH, big.NewInt(1e18))
+		return risk.Approve(ctx, opportunity, opportunity.ExecutionTxs)
+	}
+	limitOf := func(err error) string {
+		var rejection *interfaces.RiskRejection
+		require.True(t, errors.As(err, &rejection))
+		return rejection.Limit
+	}
+
+	// One bundle may be in flight; cancelling it doesn't count its volume
+	require.NoError(t, approve("opp_1"))
+	assert.Equal(t, "max_in_flight", limitOf(approve("opp_2")))
+	risk.Cancel("opp_1")
+	assert.Equal(t, 0, risk.Exposure().Global.DailyVolume.Sign())
+
+	// A settled trade counts its actual gas and profit
+	require.NoError(t, approve("opp_2"))
+	risk.Settle(&interfaces.TradeResult{OpportunityID: "opp_2", GasCost: big.NewInt(4e14), NetProfit: big.NewInt(-4e15)})
+	exposure := risk.Exposure()
+	assert.Equal(t, big.NewInt(1e18), exposure.Global.DailyVolume)
+	assert.Equal(t, big.NewInt(4e14), exposure.Global.HourlyGas)
+	assert.Equal(t, big.NewInt(-4e15), exposure.Strategies[interfaces.StrategyBackrun].DailyPnL)
+	assert.Equal(t, 0, exposure.Global.InFlight)
+
+	require.NoError(t, approve("opp_3"))
+	risk.Settle(&interfaces.TradeResult{OpportunityID: "opp_3", GasCost: big.NewInt(4e14), NetProfit: big.NewInt(-7e15)})
+	assert.Equal(t, "max_daily_loss", limitOf(approve("opp_4")))
+
+	// Raising the loss limit leaves the volume limit to stop the next trade
+	limits := risk.Limits()
+	limits.Global.MaxDailyLoss = big.NewInt(1e17)
+	require.NoError(t, risk.UpdateLimits(limits))
+	assert.Equal(t, "max_daily_volume", limitOf(approve("opp_5")))
+
+	rejections := risk.Rejections(0)
+	require.Len(t, rejections, 3)
+	assert.Equal(t, "opp_5", rejections[0].OpportunityID)
+	assert.Equal(t, "opp_2", rejections[2].OpportunityID)
+	assert.Equal(t, 3, risk.Exposure().Rejections)
+}
+
+func TestRiskEngine_ZeroDailyLoss(t *testing.T) {
+	ctx := context.Background()
+	risk := newTestRiskEngine(t, &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxDailyLoss: big.NewInt(0)}})
+
+	// A zero limit trades until there is a loss
+	opportunity := riskOpportunity(t, "opp_1", testWETH, big.NewInt(1e18))
+	require.NoError(t, risk.Approve(ctx, opportunity, opportunity.ExecutionTxs))
+	risk.Settle(&interfaces.TradeResult{OpportunityID: "opp_1", GasCost: big.NewInt(4e14), NetProfit: big.NewInt(-4e14)})
+
+	opportunity = riskOpportunity(t, "opp_2", testWETH, big.NewInt(1e18))
+	err := risk.Approve(ctx, opportunity, opportunity.ExecutionTxs)
+	var rejection *interfaces.RiskRejection
+	require.True(t, errors.As(err, &rejection))
+	assert.Equal(t, "max_daily_loss", rejection.Limit)
+}
+
+func TestRiskEngine_UpdateLimits(t *testing.T) {
+	risk := newTestRiskEngine(t, nil)
+
+	limits := risk.Limits()
+	limits.Global.MaxNotional = big.NewInt(1e18)
+	assert.Nil(t, risk.Limits().Global.MaxNotional, "limits are copied")
+
+	require.NoError(t, risk.UpdateLimits(limits))
+	limits.Global.MaxNotional.SetInt64(1)
+	assert.Equal(t, big.NewInt(1e18), risk.Limits().Global.MaxNotional)
+
+	invalid := []*interfaces.RiskConfig{
+		nil,
+		{Global: interfaces.RiskLimits{MaxGasPerHour: big.NewInt(-1)}},
+		{Global: interfaces.RiskLimits{MaxInFlight: -1}},
+		{Strategies: map[interfaces.StrategyType]interfaces.RiskLimits{
+			interfaces.StrategyBackrun: {AllowedTokens: []common.Address{testWETH}, DeniedTokens: []common.Address{testWETH}},
+		}},
+	}
+	for _, config := range invalid {
+		assert.Error(t, risk.UpdateLimits(config))
+	}
+	assert.Equal(t, big.NewInt(1e18), risk.Limits().Global.MaxNotional)
+}
+
+func TestTradeExecutor_RiskEngine(t *testing.T) {
+	ctx := context.Background()
+	submitter := &fakeSubmitter{}
+	config := DefaultExecutionConfig()
+	config.Mode = interfaces.ExecutionModeLive
+	executor := NewTradeExecutor(config, nil, submitter, nil, nil)
+	risk := newTestRiskEngine(t, &interfaces.RiskConfig{Global: interfaces.RiskLimits{MaxNotional: big.NewInt(1e18)}})
+	executor.SetRiskEngine(risk)
+
+	// Over the limit, nothing is submitted
+	result, err := executor.Execute(ctx, riskOpportunity(t, "opp_1", testWETH, big.NewInt(2e18)))
+	require.NoError(t, err)
+	assert.False(t, result.Submitted)
+	assert.Nil(t, result.Trade)
+	assert.Contains(t, result.Error, "max_notional")
+	assert.Empty(t, submitter.bundles)
+	assert.Len(t, risk.Rejections(0), 1)
+
+	// Without an inclusion monitor, a submitted bundle settles straight away
+	result, err = executor.Execute(ctx, riskOpportunity(t, "opp_2", testWETH, big.NewInt(1e18)))
+	require.NoError(t, err)
+	assert.True(t, result.Submitted)
+	exposure := risk.Exposure()
+	assert.Equal(t, 0, exposure.Global.InFlight)
+	assert.Equal(t, big.NewInt(1e18), exposure.Global.DailyVolume)
+
+	// A failed submission is cancelled
+	submitter.err = errors.New("relay down")
+	result, err = executor.Execute(ctx, riskOpportunity(t, "opp_3", testWETH, big.NewInt(1e18)))
+	require.NoError(t, err)
+	assert.False(t, result.Submitted)
+	exposure = risk.Exposure()
+	assert.Equal(t, 0, exposure.Global.InFlight)
+	assert.Equal(t, big.NewInt(1e18), exposure.Global.DailyVolume)
+}
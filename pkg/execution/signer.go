@@ -0,0 +1,298 @@
+package execution
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// Signer signs transactions and relay payloads for one account
+type Signer interface {
+	// Address returns the account the signer signs for
+	Address() common.Address
+	// SignTransaction signs a transaction for its chain ID
+	SignTransaction(ctx context.Context, tx *types.Transaction) (*ethtypes.Transaction, error)
+	// SignBundlePayload signs a relay request body the way Flashbots-style relays
+	// authenticate searchers: an EIP-191 personal signature over the hex keccak256
+	// hash of the body, with a recovery ID of 0 or 1
+	SignBundlePayload(ctx context.Context, body []byte) ([]byte, error)
+}
+
+// Signer backends
+const (
+	SignerBackendKeystore = "keystore" // Encrypted geth keystore file
+	SignerBackendEnv      = "env"      // Raw hex key in an environment variable, for development
+	SignerBackendRemote   = "remote"   // Web3signer-style JSON-RPC signer; the key never enters the process
+)
+
+// SignerConfig holds configuration for a signer
+type SignerConfig struct {
+	Backend       string
+	KeystorePath  string            // Keystore backend
+	PassphraseEnv string            // Keystore backend: variable holding the passphrase
+	KeyEnv        string            // Env backend: variable holding the hex private key
+	RemoteURL     string            // Remote backend
+	Address       common.Address    // Remote backend: account to sign with
+	Headers       map[string]string // Remote backend: sent with every request, e.g. authorization
+	Timeout       time.Duration     // Remote backend
+}
+
+// DefaultSignerConfig returns the default signer configuration
+func DefaultSignerConfig() *SignerConfig {
+	return &SignerConfig{
+		Backend:       SignerBackendKeystore,
+		PassphraseEnv: "MEV_SIGNER_PASSPHRASE",
+		KeyEnv:        "MEV_SIGNER_KEY",
+		Timeout:       5 * time.Second,
+	}
+}
+
+// NewSigner creates the signer the config selects
+func NewSigner(config *SignerConfig) (Signer, error) {
+	if config == nil {
+		config = DefaultSignerConfig()
+	}
+
+	switch config.Backend {
+	case SignerBackendKeystore:
+		passphrase := ""
+		if config.PassphraseEnv != "" {
+			passphrase = os.Getenv(config.PassphraseEnv)
+		}
+		return NewKeystoreSigner(config.KeystorePath, passphrase)
+	case SignerBackendEnv:
+		return NewEnvSigner(config.KeyEnv)
+	case SignerBackendRemote:
+		return NewRemoteSigner(config)
+	default:
+		return nil, fmt.Errorf("unknown signer backend %q", config.Backend)
+	}
+}
+
+// keySigner signs with a private key held in memory
+type keySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+}
+
+// NewKeySigner creates a signer for a private key
+func NewKeySigner(key *ecdsa.PrivateKey) Signer {
+	return &keySigner{
+		key:     key,
+		address: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewKeystoreSigner decrypts a geth keystore file
+func NewKeystoreSigner(path, passphrase string) (Signer, error) {
+	if path == "" {
+		return nil, errors.New("keystore path must be configured")
+	}
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// NewEnvSigner reads a hex private key from an environment variable. Keys in the
+// environment are visible to anything that can inspect the process; use it for
+// development only.
+func NewEnvSigner(variable string) (Signer, error) {
+	if variable == "" {
+		return nil, errors.New("key environment variable must be configured")
+	}
+	value, exists := os.LookupEnv(variable)
+	if !exists || value == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", variable)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(value), "0x"))
+	if err != nil {
+		// The error could echo the key, so it's left out
+		return nil, fmt.Errorf("environment variable %s doesn't hold a valid private key", variable)
+	}
+	return NewKeySigner(key), nil
+}
+
+// Address returns the key's account
+func (s *keySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction signs a transaction with the key
+func (s *keySigner) SignTransaction(ctx context.Context, tx *types.Transaction) (*ethtypes.Transaction, error) {
+	unsigned, err := unsignedTransaction(s.address, tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethtypes.SignTx(unsigned, ethtypes.LatestSignerForChainID(tx.ChainID), s.key)
+}
+
+// SignBundlePayload signs a relay request body with the key
+func (s *keySigner) SignBundlePayload(ctx context.Context, body []byte) ([]byte, error) {
+	return crypto.Sign(bundlePayloadHash(body), s.key)
+}
+
+// remoteSigner signs through a JSON-RPC signer such as web3signer, using the
+// eth_signTransaction and eth_sign methods
+type remoteSigner struct {
+	address common.Address
+	client  *jsonRPCClient
+}
+
+// NewRemoteSigner creates a signer backed by a remote JSON-RPC signer. Remote signers
+// hold many keys, so the account must be configured.
+func NewRemoteSigner(config *SignerConfig) (Signer, error) {
+	if config == nil || config.RemoteURL == "" {
+		return nil, errors.New("remote signer URL must be configured")
+	}
+	if config.Address == (common.Address{}) {
+		return nil, errors.New("remote signer address must be configured")
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = DefaultSignerConfig().Timeout
+	}
+	return &remoteSigner{
+		address: config.Address,
+		client:  newJSONRPCClient(config.RemoteURL, config.Headers, timeout),
+	}, nil
+}
+
+// remoteTransaction is a transaction in eth_signTransaction form
+type remoteTransaction struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to,omitempty"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Data     hexutil.Bytes   `json:"data"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	ChainID  *hexutil.Big    `json:"chainId"`
+}
+
+// Address returns the configured account
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTransaction has the remote signer sign a transaction and checks it signed
+// what was asked, for the configured account
+func (s *remoteSigner) SignTransaction(ctx context.Context, tx *types.Transaction) (*ethtypes.Transaction, error) {
+	unsigned, err := unsignedTransaction(s.address, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw hexutil.Bytes
+	err = s.client.call(ctx, &raw, "eth_signTransaction", &remoteTransaction{
+		From:     s.address,
+		To:       unsigned.To(),
+		Gas:      hexutil.Uint64(unsigned.Gas()),
+		GasPrice: (*hexutil.Big)(unsigned.GasPrice()),
+		Value:    (*hexutil.Big)(unsigned.Value()),
+		Data:     unsigned.Data(),
+		Nonce:    hexutil.Uint64(unsigned.Nonce()),
+		ChainID:  (*hexutil.Big)(tx.ChainID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signed := new(ethtypes.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid transaction: %w", err)
+	}
+	signer := ethtypes.LatestSignerForChainID(tx.ChainID)
+	if signer.Hash(signed) != signer.Hash(unsigned) {
+		return nil, errors.New("remote signer signed a different transaction")
+	}
+	sender, err := ethtypes.Sender(signer, signed)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("remote signer signed for %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+	return signed, nil
+}
+
+// SignBundlePayload has the remote signer sign a relay request body with eth_sign,
+// which applies the EIP-191 prefix itself
+func (s *remoteSigner) SignBundlePayload(ctx context.Context, body []byte) ([]byte, error) {
+	message := []byte(crypto.Keccak256Hash(body).Hex())
+
+	var signature hexutil.Bytes
+	if err := s.client.call(ctx, &signature, "eth_sign", s.address, hexutil.Bytes(message)); err != nil {
+		return nil, err
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer returned a %d-byte signature", len(signature))
+	}
+
+	// eth_sign returns a recovery ID of 27 or 28
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(bundlePayloadHash(body), signature)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned an invalid signature: %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*publicKey); signer != s.address {
+		return nil, fmt.Errorf("remote signer signed for %s instead of %s", signer.Hex(), s.address.Hex())
+	}
+	return signature, nil
+}
+
+// unsignedTransaction converts a transaction for signing by account. Transactions
+// are legacy, EIP-155 protected ones, as the forks and builder produce.
+func unsignedTransaction(account common.Address, tx *types.Transaction) (*ethtypes.Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("transaction cannot be nil")
+	}
+	if tx.ChainID == nil || tx.ChainID.Sign() == 0 {
+		return nil, errors.New("transaction has no chain ID")
+	}
+	if tx.From != (common.Address{}) && tx.From != account {
+		return nil, fmt.Errorf("transaction is from %s, not the signer %s", tx.From.Hex(), account.Hex())
+	}
+
+	value := tx.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gasPrice := tx.GasPrice
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+	return ethtypes.NewTx(&ethtypes.LegacyTx{
+		Nonce:    tx.Nonce,
+		GasPrice: gasPrice,
+		Gas:      tx.GasLimit,
+		To:       tx.To,
+		Value:    value,
+		Data:     tx.Data,
+	}), nil
+}
+
+// bundlePayloadHash is the digest SignBundlePayload signs
+func bundlePayloadHash(body []byte) []byte {
+	return accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex()))
+}
@@ -0,0 +1,317 @@
+package execution
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKeyHex = "b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"
+
+func testKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA(testKeyHex)
+	require.NoError(t, err)
+	return key
+}
+
+func testSignerTransaction(from common.Address) *types.Transaction {
+	return &types.Transaction{
+		From:     from,
+		To:       &testExecutor,
+		Value:    big.NewInt(1000),
+		GasPrice: big.NewInt(1e9),
+		GasLimit: 210000,
+		Nonce:    7,
+		Data:     []byte{0xde, 0xad, 0xbe, 0xef},
+		ChainID:  big.NewInt(8453),
+	}
+}
+
+// fakeRemoteSigner is a web3signer-style JSON-RPC signer holding one key
+type fakeRemoteSigner struct {
+	key           *ecdsa.PrivateKey
+	token         string
+	wrongChainID  bool
+	requestMethod []string
+}
+
+func (f *fakeRemoteSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request struct {
+		ID     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.requestMethod = append(f.requestMethod, request.Method)
+
+	respond := func(result interface{}, rpcErr *jsonRPCError) {
+		response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+		if rpcErr != nil {
+			response["error"] = rpcErr
+		} else {
+			response["result"] = result
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}
+	address := crypto.PubkeyToAddress(f.key.PublicKey)
+
+	switch request.Method {
+	case "eth_signTransaction":
+		var tx remoteTransaction
+		if err := json.Unmarshal(request.Params[0], &tx); err != nil {
+			respond(nil, &jsonRPCError{Code: -32602, Message: err.Error()})
+			return
+		}
+		if tx.From != address {
+			respond(nil, &jsonRPCError{Code: -32000, Message: "unknown account"})
+			return
+		}
+		chainID := tx.ChainID.ToInt()
+		if f.wrongChainID {
+			chainID = big.NewInt(1)
+		}
+		signed, err := ethtypes.SignNewTx(f.key, ethtypes.LatestSignerForChainID(chainID), &ethtypes.LegacyTx{
+			Nonce:    uint64(tx.Nonce),
+			GasPrice: tx.GasPrice.ToInt(),
+			Gas:      uint64(tx.Gas),
+			To:       tx.To,
+			Value:    tx.Value.ToInt(),
+			Data:     tx.Data,
+		})
+		if err != nil {
+			respond(nil, &jsonRPCError{Code: -32000, Message: err.Error()})
+			return
+		}
+		raw, _ := signed.MarshalBinary()
+		respond(hexutil.Bytes(raw), nil)
+	case "eth_sign":
+		var account common.Address
+		var data hexutil.Bytes
+		_ = json.Unmarshal(request.Params[0], &account)
+		_ = json.Unmarshal(request.Params[1], &data)
+		if account != address {
+			respond(nil, &jsonRPCError{Code: -32000, Message: "unknown account"})
+			return
+		}
+		signature, err := crypto.Sign(accounts.TextHash(data), f.key)
+		if err != nil {
+			respond(nil, &jsonRPCError{Code: -32000, Message: err.Error()})
+			return
+		}
+		signature[crypto.RecoveryIDOffset] += 27
+		respond(hexutil.Bytes(signature), nil)
+	default:
+		respond(nil, &jsonRPCError{Code: -32601, Message: "method not found"})
+	}
+}
+
+// assertSigned checks a signed transaction matches the original and recovers to from
+func assertSigned(t *testing.T, tx *types.Transaction, signed *ethtypes.Transaction, from common.Address) {
+	t.Helper()
+	sender, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainID), signed)
+	require.NoError(t, err)
+	assert.Equal(t, from, sender)
+	assert.Equal(t, tx.Nonce, signed.Nonce())
+	assert.Equal(t, *tx.To, *signed.To())
+	assert.Equal(t, tx.Data, signed.Data())
+	assert.Equal(t, 0, tx.Value.Cmp(signed.Value()))
+	assert.Equal(t, 0, tx.ChainID.Cmp(signed.ChainId()))
+}
+
+// assertPayloadSigned checks a bundle payload signature recovers to from
+func assertPayloadSigned(t *testing.T, body, signature []byte, from common.Address) {
+	t.Helper()
+	require.Len(t, signature, crypto.SignatureLength)
+	assert.Less(t, signature[crypto.RecoveryIDOffset], byte(2))
+	hash := accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex()))
+	publicKey, err := crypto.SigToPub(hash, signature)
+	require.NoError(t, err)
+	assert.Equal(t, from, crypto.PubkeyToAddress(*publicKey))
+}
+
+func TestKeySigner(t *testing.T) {
+	key := testKey(t)
+	signer := NewKeySigner(key)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	assert.Equal(t, address, signer.Address())
+
+	tx := testSignerTransaction(address)
+	signed, err := signer.SignTransaction(context.Background(), tx)
+	require.NoError(t, err)
+	assertSigned(t, tx, signed, address)
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[]}`)
+	signature, err := signer.SignBundlePayload(context.Background(), body)
+	require.NoError(t, err)
+	assertPayloadSigned(t, body, signature, address)
+}
+
+func TestKeySigner_SignTransactionErrors(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+
+	tests := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{name: "nil transaction"},
+		{name: "no chain ID", tx: &types.Transaction{To: &testExecutor}},
+		{name: "another sender", tx: testSignerTransaction(testSearcher)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := signer.SignTransaction(context.Background(), tt.tx)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key := testKey(t)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    crypto.PubkeyToAddress(key.PublicKey),
+		PrivateKey: key,
+	}, "correct horse", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "searcher.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0o600))
+
+	signer, err := NewKeystoreSigner(path, "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+
+	_, err = NewKeystoreSigner(path, "battery staple")
+	assert.Error(t, err)
+	_, err = NewKeystoreSigner(filepath.Join(t.TempDir(), "missing.json"), "correct horse")
+	assert.Error(t, err)
+
+	t.Setenv("TEST_SIGNER_PASSPHRASE", "correct horse")
+	signer, err = NewSigner(&SignerConfig{
+		Backend:       SignerBackendKeystore,
+		KeystorePath:  path,
+		PassphraseEnv: "TEST_SIGNER_PASSPHRASE",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Address())
+}
+
+func TestEnvSigner(t *testing.T) {
+	address := crypto.PubkeyToAddress(testKey(t).PublicKey)
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr bool
+	}{
+		{name: "bare hex", value: testKeyHex},
+		{name: "prefixed hex", value: "0x" + testKeyHex},
+		{name: "not a key", value: "0x1234", wantErr: true},
+		{name: "unset", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TEST_SIGNER_KEY", tt.value)
+
+			signer, err := NewSigner(&SignerConfig{Backend: SignerBackendEnv, KeyEnv: "TEST_SIGNER_KEY"})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, address, signer.Address())
+		})
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key := testKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	fake := &fakeRemoteSigner{key: key, token: "secret"}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	signer, err := NewSigner(&SignerConfig{
+		Backend:   SignerBackendRemote,
+		RemoteURL: server.URL,
+		Address:   address,
+		Headers:   map[string]string{"Authorization": "Bearer secret"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, address, signer.Address())
+
+	tx := testSignerTransaction(address)
+	signed, err := signer.SignTransaction(context.Background(), tx)
+	require.NoError(t, err)
+	assertSigned(t, tx, signed, address)
+
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[]}`)
+	signature, err := signer.SignBundlePayload(context.Background(), body)
+	require.NoError(t, err)
+	assertPayloadSigned(t, body, signature, address)
+
+	assert.Equal(t, []string{"eth_signTransaction", "eth_sign"}, fake.requestMethod)
+}
+
+func TestRemoteSigner_Errors(t *testing.T) {
+	key := testKey(t)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	tests := []struct {
+		name    string
+		token   string
+		address common.Address
+		wrongID bool
+	}{
+		{name: "unauthorized", token: "wrong", address: address},
+		{name: "unknown account", token: "secret", address: testSearcher},
+		{name: "signed for another chain", token: "secret", address: address, wrongID: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(&fakeRemoteSigner{key: key, token: "secret", wrongChainID: tt.wrongID})
+			defer server.Close()
+
+			signer, err := NewRemoteSigner(&SignerConfig{
+				RemoteURL: server.URL,
+				Address:   tt.address,
+				Headers:   map[string]string{"Authorization": "Bearer " + tt.token},
+			})
+			require.NoError(t, err)
+
+			_, err = signer.SignTransaction(context.Background(), testSignerTransaction(tt.address))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewRemoteSigner(&SignerConfig{RemoteURL: "http://localhost:9000"})
+	assert.Error(t, err)
+	_, err = NewSigner(&SignerConfig{Backend: "hsm"})
+	assert.Error(t, err)
+}
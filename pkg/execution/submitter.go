@@ -0,0 +1,313 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// BlockSource reads the chain head bundles are targeted from
+type BlockSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// DefaultSubmitterConfig returns the default relay submitter configuration, with no relays
+func DefaultSubmitterConfig() *interfaces.SubmitterConfig {
+	return &interfaces.SubmitterConfig{
+		PrivateTxMaxBlocks: 25,
+		HistorySize:        1000,
+	}
+}
+
+// relayEndpoint is a configured relay and its client
+type relayEndpoint struct {
+	config interfaces.RelayConfig
+	client *jsonRPCClient
+}
+
+// RelaySubmitter implements the TransactionSubmitter interface by signing bundles
+// and broadcasting them to every configured relay in parallel. A submission succeeds
+// if any relay accepts it.
+type RelaySubmitter struct {
+	config  *interfaces.SubmitterConfig
+	relays  []*relayEndpoint
+	signer  Signer
+	blocks  BlockSource
+	metrics interfaces.MetricsCollector
+	history []*interfaces.Submission
+	mu      sync.RWMutex
+}
+
+// NewRelaySubmitter creates a submitter for the configured relays. Bundle and
+// private transaction relays need a block source; the metrics collector may be nil.
+func NewRelaySubmitter(config *interfaces.SubmitterConfig, signer Signer, blocks BlockSource, metrics interfaces.MetricsCollector) (*RelaySubmitter, error) {
+	if config == nil {
+		config = DefaultSubmitterConfig()
+	}
+	if len(config.Relays) == 0 {
+		return nil, errors.New("at least one relay must be configured")
+	}
+	if signer == nil {
+		return nil, errors.New("a signer is required")
+	}
+
+	relays := make([]*relayEndpoint, 0, len(config.Relays))
+	for _, relayConfig := range config.Relays {
+		if relayConfig.URL == "" {
+			return nil, fmt.Errorf("relay %q has no URL", relayConfig.Name)
+		}
+		switch relayConfig.Method {
+		case interfaces.RelayMethodBundle, interfaces.RelayMethodPrivateTransaction:
+			if blocks == nil {
+				return nil, fmt.Errorf("relay %q needs a block source for %s", relayConfig.Name, relayConfig.Method)
+			}
+		case interfaces.RelayMethodRawTransaction:
+		default:
+			return nil, fmt.Errorf("relay %q has unknown method %q", relayConfig.Name, relayConfig.Method)
+		}
+
+		// Attempts are bounded by their own timeout context
+		client := newJSONRPCClient(relayConfig.URL, relayConfig.Headers, 0)
+		if relayConfig.SignPayload {
+			client.payloadSigner = signer
+		}
+		relays = append(relays, &relayEndpoint{config: relayConfig, client: client})
+	}
+
+	return &RelaySubmitter{
+		config:  config,
+		relays:  relays,
+		signer:  signer,
+		blocks:  blocks,
+		metrics: metrics,
+	}, nil
+}
+
+// signedBundle is a bundle encoded for the relays
+type signedBundle struct {
+	raw     []hexutil.Bytes // Every transaction in order, for bundle relays
+	own     []hexutil.Bytes // Ours in order, for relays taking single transactions
+	missing error           // Set if another account's transaction can't be bundled
+}
+
+// Submit signs a bundle and broadcasts it, returning its transaction hashes
+func (s *RelaySubmitter) Submit(ctx context.Context, bundle []*types.Transaction) ([]common.Hash, error) {
+	submission, err := s.SubmitBundle(ctx, bundle)
+	if err != nil {
+		return nil, err
+	}
+	return submission.TxHashes, nil
+}
+
+// SubmitBundle signs a bundle and broadcasts it, returning how each relay handled it.
+// Bundles target the next block. It fails if no relay accepts the bundle.
+func (s *RelaySubmitter) SubmitBundle(ctx context.Context, bundle []*types.Transaction) (*interfaces.Submission, error) {
+	if len(bundle) == 0 {
+		return nil, errors.New("bundle is empty")
+	}
+
+	signed := &signedBundle{raw: make([]hexutil.Bytes, len(bundle))}
+	hashes := make([]common.Hash, len(bundle))
+	for i, tx := range bundle {
+		if tx.From != s.signer.Address() {
+			// Another account's transaction, like a sandwich's target, goes out as it
+			// was signed. It's already in the mempool, so only bundles carry it.
+			hashes[i] = common.HexToHash(tx.Hash)
+			if len(tx.Raw) == 0 {
+				signed.missing = fmt.Errorf("transaction %d is from %s and has no signed encoding", i, tx.From.Hex())
+				continue
+			}
+			var decoded ethtypes.Transaction
+			if err := decoded.UnmarshalBinary(tx.Raw); err != nil {
+				return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+			}
+			signed.raw[i] = tx.Raw
+			hashes[i] = decoded.Hash()
+			continue
+		}
+
+		ours, err := s.signer.SignTransaction(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign transaction %d: %w", i, err)
+		}
+		encoded, err := ours.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode transaction %d: %w", i, err)
+		}
+		signed.raw[i] = encoded
+		signed.own = append(signed.own, encoded)
+		hashes[i] = ours.Hash()
+	}
+
+	submission := &interfaces.Submission{
+		TxHashes:    hashes,
+		SubmittedAt: time.Now(),
+		Relays:      make([]*interfaces.RelaySubmission, len(s.relays)),
+	}
+	if s.blocks != nil {
+		head, err := s.blocks.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read block number: %w", err)
+		}
+		submission.BlockNumber = head + 1
+	}
+
+	var wg sync.WaitGroup
+	for i, relay := range s.relays {
+		wg.Add(1)
+		go func(i int, relay *relayEndpoint) {
+			defer wg.Done()
+			submission.Relays[i] = s.submitToRelay(ctx, relay, signed, submission.BlockNumber)
+		}(i, relay)
+	}
+	wg.Wait()
+
+	s.record(ctx, submission)
+	if !submission.Accepted() {
+		errs := make([]error, 0, len(submission.Relays))
+		for _, result := range submission.Relays {
+			errs = append(errs, fmt.Errorf("%s: %s", result.Relay, result.Error))
+		}
+		return submission, fmt.Errorf("no relay accepted the bundle: %w", errors.Join(errs...))
+	}
+	return submission, nil
+}
+
+// Submissions returns the most recent submissions, newest last
+func (s *RelaySubmitter) Submissions(limit int) []*interfaces.Submission {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	start := 0
+	if limit > 0 && len(s.history) > limit {
+		start = len(s.history) - limit
+	}
+	submissions := make([]*interfaces.Submission, len(s.history)-start)
+	copy(submissions, s.history[start:])
+	return submissions
+}
+
+// submitToRelay sends signed transactions to one relay, retrying failures the relay
+// may recover from
+func (s *RelaySubmitter) submitToRelay(ctx context.Context, relay *relayEndpoint, signed *signedBundle, blockNumber uint64) *interfaces.RelaySubmission {
+	result := &interfaces.RelaySubmission{
+		Relay:  relay.config.Name,
+		Method: relay.config.Method,
+	}
+	if relay.config.Method == interfaces.RelayMethodBundle && signed.missing != nil {
+		result.Error = signed.missing.Error()
+		return result
+	}
+	start := time.Now()
+	backoff := relay.config.RetryBackoff
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		result.Attempts++
+		err = s.send(ctx, relay, signed, blockNumber)
+		if err == nil || !retryable(err) || attempt >= relay.config.MaxRetries {
+			break
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		backoff *= 2
+	}
+
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Accepted = true
+	return result
+}
+
+// send makes one attempt at sending signed transactions with the relay's method.
+// Methods taking single transactions send ours in bundle order.
+func (s *RelaySubmitter) send(ctx context.Context, relay *relayEndpoint, signed *signedBundle, blockNumber uint64) error {
+	if relay.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, relay.config.Timeout)
+		defer cancel()
+	}
+
+	switch relay.config.Method {
+	case interfaces.RelayMethodBundle:
+		return relay.client.call(ctx, nil, string(relay.config.Method), map[string]interface{}{
+			"txs":         signed.raw,
+			"blockNumber": hexutil.Uint64(blockNumber),
+		})
+	case interfaces.RelayMethodPrivateTransaction:
+		maxBlock := hexutil.Uint64(blockNumber + s.config.PrivateTxMaxBlocks)
+		for i, tx := range signed.own {
+			err := relay.client.call(ctx, nil, string(relay.config.Method), map[string]interface{}{
+				"tx":             tx,
+				"maxBlockNumber": maxBlock,
+			})
+			if err != nil {
+				return fmt.Errorf("transaction %d: %w", i, err)
+			}
+		}
+		return nil
+	default:
+		for i, tx := range signed.own {
+			if err := relay.client.call(ctx, nil, string(relay.config.Method), tx); err != nil {
+				return fmt.Errorf("transaction %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+}
+
+// record keeps a submission in the history and reports accepting relays' latencies
+func (s *RelaySubmitter) record(ctx context.Context, submission *interfaces.Submission) {
+	s.mu.Lock()
+	s.history = append(s.history, submission)
+	if s.config.HistorySize > 0 && len(s.history) > s.config.HistorySize {
+		s.history = s.history[len(s.history)-s.config.HistorySize:]
+	}
+	s.mu.Unlock()
+
+	if s.metrics == nil {
+		return
+	}
+	for _, result := range submission.Relays {
+		if result.Accepted {
+			_ = s.metrics.RecordLatency(ctx, "relay_submit_"+result.Relay, result.Latency)
+		}
+	}
+}
+
+// retryable reports whether a relay error may clear up on retry. JSON-RPC errors
+// and client errors are the relay rejecting the bundle.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var rpcErr *jsonRPCError
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
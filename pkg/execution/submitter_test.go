@@ -0,0 +1,372 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/metrics"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBlockSource uint64
+
+func (f fakeBlockSource) BlockNumber(ctx context.Context) (uint64, error) {
+	return uint64(f), nil
+}
+
+type relayRequest struct {
+	Method    string
+	Params    []json.RawMessage
+	Headers   http.Header
+	Signer    common.Address // Recovered from X-Flashbots-Signature
+	Signature bool
+}
+
+// fakeRelay is a JSON-RPC relay that can fail, reject or stall
+type fakeRelay struct {
+	failures int           // Requests answered with 503 before accepting
+	reject   bool          // Answer with a JSON-RPC error
+	delay    time.Duration // Before answering
+	requests []relayRequest
+	mu       sync.Mutex
+}
+
+func (f *fakeRelay) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var request struct {
+		ID     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	recorded := relayRequest{Method: request.Method, Params: request.Params, Headers: r.Header.Clone()}
+	if header := r.Header.Get("X-Flashbots-Signature"); header != "" {
+		parts := strings.SplitN(header, ":", 2)
+		signature, err := hexutil.Decode(parts[1])
+		if err == nil {
+			hash := accounts.TextHash([]byte(crypto.Keccak256Hash(body).Hex()))
+			if publicKey, err := crypto.SigToPub(hash, signature); err == nil && crypto.PubkeyToAddress(*publicKey) == common.HexToAddress(parts[0]) {
+				recorded.Signer = common.HexToAddress(parts[0])
+				recorded.Signature = true
+			}
+		}
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, recorded)
+	failing := f.failures > 0
+	if failing {
+		f.failures--
+	}
+	f.mu.Unlock()
+
+	if f.delay > 0 {
+		select {
+		case <-time.After(f.delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if failing {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+		return
+	}
+
+	response := map[string]interface{}{"jsonrpc": "2.0", "id": request.ID}
+	if f.reject {
+		response["error"] = map[string]interface{}{"code": -32000, "message": "nonce too low"}
+	} else {
+		response["result"] = map[string]string{"bundleHash": common.Hash{1}.Hex()}
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func (f *fakeRelay) received() []relayRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]relayRequest(nil), f.requests...)
+}
+
+func testBundle(from common.Address) []*types.Transaction {
+	first := testSignerTransaction(from)
+	second := testSignerTransaction(from)
+	second.Nonce = first.Nonce + 1
+	return []*types.Transaction{first, second}
+}
+
+// decodeRaw decodes the signed transactions a relay received
+func decodeRaw(t *testing.T, raw []hexutil.Bytes) []common.Hash {
+	hashes := make([]common.Hash, len(raw))
+	for i, encoded := range raw {
+		tx := new(ethtypes.Transaction)
+		require.NoError(t, tx.UnmarshalBinary(encoded))
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
+
+func TestRelaySubmitter_SubmitBundle(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+	builder := &fakeRelay{}
+	private := &fakeRelay{}
+	sequencer := &fakeRelay{}
+	servers := map[string]*httptest.Server{}
+	for name, relay := range map[string]*fakeRelay{"builder": builder, "private": private, "sequencer": sequencer} {
+		servers[name] = httptest.NewServer(relay)
+		defer servers[name].Close()
+	}
+
+	collector := metrics.NewCollectorWithRegistry(nil, prometheus.NewRegistry())
+	submitter, err := NewRelaySubmitter(&interfaces.SubmitterConfig{
+		Relays: []interfaces.RelayConfig{
+			{Name: "builder", URL: servers["builder"].URL, Method: interfaces.RelayMethodBundle, SignPayload: true, Timeout: time.Second},
+			{Name: "private", URL: servers["private"].URL, Method: interfaces.RelayMethodPrivateTransaction, Headers: map[string]string{"Authorization": "Bearer secret"}, Timeout: time.Second},
+			{Name: "sequencer", URL: servers["sequencer"].URL, Method: interfaces.RelayMethodRawTransaction, Timeout: time.Second},
+		},
+		PrivateTxMaxBlocks: 10,
+	}, signer, fakeBlockSource(100), collector)
+	require.NoError(t, err)
+
+	bundle := testBundle(signer.Address())
+	submission, err := submitter.SubmitBundle(context.Background(), bundle)
+	require.NoError(t, err)
+	assert.True(t, submission.Accepted())
+	assert.Equal(t, uint64(101), submission.BlockNumber)
+	require.Len(t, submission.TxHashes, 2)
+	for i, result := range submission.Relays {
+		assert.Equal(t, []string{"builder", "private", "sequencer"}[i], result.Relay)
+		assert.True(t, result.Accepted, result.Error)
+		assert.Equal(t, 1, result.Attempts)
+		assert.Positive(t, result.Latency)
+	}
+
+	// The builder gets the whole bundle for the next block, signed by the searcher
+	requests := builder.received()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "eth_sendBundle", requests[0].Method)
+	assert.True(t, requests[0].Signature)
+	assert.Equal(t, signer.Address(), requests[0].Signer)
+	var bundleParams struct {
+		Txs         []hexutil.Bytes `json:"txs"`
+		BlockNumber hexutil.Uint64  `json:"blockNumber"`
+	}
+	require.NoError(t, json.Unmarshal(requests[0].Params[0], &bundleParams))
+	assert.Equal(t, hexutil.Uint64(101), bundleParams.BlockNumber)
+	assert.Equal(t, submission.TxHashes, decodeRaw(t, bundleParams.Txs))
+
+	// The private relay gets each transaction in order, with its auth header
+	requests = private.received()
+	require.Len(t, requests, 2)
+	for i, request := range requests {
+		assert.Equal(t, "eth_sendPrivateTransaction", request.Method)
+		assert.Equal(t, "Bearer secret", request.Headers.Get("Authorization"))
+		assert.False(t, request.Signature)
+		var params struct {
+			Tx             hexutil.Bytes  `json:"tx"`
+			MaxBlockNumber hexutil.Uint64 `json:"maxBlockNumber"`
+		}
+		require.NoError(t, json.Unmarshal(request.Params[0], &params))
+		assert.Equal(t, hexutil.Uint64(111), params.MaxBlockNumber)
+		assert.Equal(t, submission.TxHashes[i], decodeRaw(t, []hexutil.Bytes{params.Tx})[0])
+	}
+
+	// The sequencer gets raw transactions
+	requests = sequencer.received()
+	require.Len(t, requests, 2)
+	var raw hexutil.Bytes
+	require.NoError(t, json.Unmarshal(requests[1].Params[0], &raw))
+	assert.Equal(t, submission.TxHashes[1], decodeRaw(t, []hexutil.Bytes{raw})[0])
+
+	// Submissions are kept, and accepting relays' latencies recorded
+	assert.Equal(t, []*interfaces.Submission{submission}, submitter.Submissions(10))
+	latency, err := collector.GetLatencyMetrics("relay_submit_builder", 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, latency.SampleCount)
+}
+
+// testTarget is another account's pending transaction, as the mempool stream hands
+// it over
+func testTarget(t *testing.T) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx := testSignerTransaction(crypto.PubkeyToAddress(key.PublicKey))
+	signed, err := NewKeySigner(key).SignTransaction(context.Background(), tx)
+	require.NoError(t, err)
+	tx.Hash = signed.Hash().Hex()
+	tx.Raw, err = signed.MarshalBinary()
+	require.NoError(t, err)
+	return tx
+}
+
+func TestRelaySubmitter_TargetTransaction(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+	builder := &fakeRelay{}
+	sequencer := &fakeRelay{}
+	builderServer := httptest.NewServer(builder)
+	defer builderServer.Close()
+	sequencerServer := httptest.NewServer(sequencer)
+	defer sequencerServer.Close()
+
+	submitter, err := NewRelaySubmitter(&interfaces.SubmitterConfig{
+		Relays: []interfaces.RelayConfig{
+			{Name: "builder", URL: builderServer.URL, Method: interfaces.RelayMethodBundle, Timeout: time.Second},
+			{Name: "sequencer", URL: sequencerServer.URL, Method: interfaces.RelayMethodRawTransaction, Timeout: time.Second},
+		},
+	}, signer, fakeBlockSource(100), nil)
+	require.NoError(t, err)
+
+	// The target sits between our transactions as it was signed
+	ours := testBundle(signer.Address())
+	target := testTarget(t)
+	submission, err := submitter.SubmitBundle(context.Background(), []*types.Transaction{ours[0], target, ours[1]})
+	require.NoError(t, err)
+	require.Len(t, submission.TxHashes, 3)
+	assert.Equal(t, target.Hash, submission.TxHashes[1].Hex())
+
+	requests := builder.received()
+	require.Len(t, requests, 1)
+	var bundleParams struct {
+		Txs []hexutil.Bytes `json:"txs"`
+	}
+	require.NoError(t, json.Unmarshal(requests[0].Params[0], &bundleParams))
+	assert.Equal(t, submission.TxHashes, decodeRaw(t, bundleParams.Txs))
+
+	// Single-transaction relays only get ours; the target is already in the mempool
+	assert.Len(t, sequencer.received(), 2)
+
+	// Without its signed encoding the target can't be bundled
+	target.Raw = nil
+	submission, err = submitter.SubmitBundle(context.Background(), []*types.Transaction{ours[0], target, ours[1]})
+	require.NoError(t, err)
+	assert.False(t, submission.Relays[0].Accepted)
+	assert.Equal(t, 0, submission.Relays[0].Attempts)
+	assert.Contains(t, submission.Relays[0].Error, "no signed encoding")
+	assert.True(t, submission.Relays[1].Accepted)
+	assert.Len(t, builder.received(), 1)
+}
+
+func TestRelaySubmitter_RetriesAndFailures(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+
+	tests := []struct {
+		name         string
+		relay        *fakeRelay
+		timeout      time.Duration
+		wantAccepted bool
+		wantAttempts int
+	}{
+		{name: "retries until the relay recovers", relay: &fakeRelay{failures: 2}, wantAccepted: true, wantAttempts: 3},
+		{name: "gives up after the retries", relay: &fakeRelay{failures: 5}, wantAttempts: 3},
+		{name: "doesn't retry a rejection", relay: &fakeRelay{reject: true}, wantAttempts: 1},
+		{name: "times out slow relays", relay: &fakeRelay{delay: time.Second}, timeout: 20 * time.Millisecond, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.relay)
+			defer server.Close()
+
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = time.Second
+			}
+			submitter, err := NewRelaySubmitter(&interfaces.SubmitterConfig{
+				Relays: []interfaces.RelayConfig{{
+					Name:         "relay",
+					URL:          server.URL,
+					Method:       interfaces.RelayMethodRawTransaction,
+					Timeout:      timeout,
+					MaxRetries:   2,
+					RetryBackoff: time.Millisecond,
+				}},
+			}, signer, nil, nil)
+			require.NoError(t, err)
+
+			submission, err := submitter.SubmitBundle(context.Background(), testBundle(signer.Address())[:1])
+			require.NotNil(t, submission)
+			result := submission.Relays[0]
+			assert.Equal(t, tt.wantAccepted, result.Accepted)
+			assert.Equal(t, tt.wantAttempts, result.Attempts)
+			if tt.wantAccepted {
+				assert.NoError(t, err)
+				assert.Empty(t, result.Error)
+			} else {
+				assert.Error(t, err)
+				assert.NotEmpty(t, result.Error)
+			}
+		})
+	}
+}
+
+func TestRelaySubmitter_AnyRelayAccepts(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+	down := httptest.NewServer(&fakeRelay{reject: true})
+	defer down.Close()
+	up := httptest.NewServer(&fakeRelay{})
+	defer up.Close()
+
+	submitter, err := NewRelaySubmitter(&interfaces.SubmitterConfig{
+		Relays: []interfaces.RelayConfig{
+			{Name: "down", URL: down.URL, Method: interfaces.RelayMethodRawTransaction},
+			{Name: "up", URL: up.URL, Method: interfaces.RelayMethodRawTransaction},
+		},
+	}, signer, nil, nil)
+	require.NoError(t, err)
+
+	hashes, err := submitter.Submit(context.Background(), testBundle(signer.Address()))
+	require.NoError(t, err)
+	assert.Len(t, hashes, 2)
+
+	submissions := submitter.Submissions(0)
+	require.Len(t, submissions, 1)
+	assert.False(t, submissions[0].Relays[0].Accepted)
+	assert.Contains(t, submissions[0].Relays[0].Error, "nonce too low")
+	assert.True(t, submissions[0].Relays[1].Accepted)
+}
+
+func TestNewRelaySubmitter_Errors(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+
+	tests := []struct {
+		name   string
+		relays []interfaces.RelayConfig
+		blocks BlockSource
+	}{
+		{name: "no relays"},
+		{name: "no URL", relays: []interfaces.RelayConfig{{Name: "a", Method: interfaces.RelayMethodRawTransaction}}},
+		{name: "unknown method", relays: []interfaces.RelayConfig{{Name: "a", URL: "http://relay", Method: "eth_sendMagic"}}},
+		{name: "bundle without block source", relays: []interfaces.RelayConfig{{Name: "a", URL: "http://relay", Method: interfaces.RelayMethodBundle}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRelaySubmitter(&interfaces.SubmitterConfig{Relays: tt.relays}, signer, tt.blocks, nil)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := NewRelaySubmitter(&interfaces.SubmitterConfig{
+		Relays: []interfaces.RelayConfig{{Name: "a", URL: "http://relay", Method: interfaces.RelayMethodRawTransaction}},
+	}, nil, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+// Package history keeps a buffer of recently included blocks and their receipts,
+// following the chain head through reorgs.
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// ErrUnknownParent is returned by Add when a block's parent is buffered under another
+// hash. The parent was reorged out too and has to be added first.
+var ErrUnknownParent = errors.New("block parent does not match the buffered chain")
+
+// blockHistory implements the BlockHistory interface
+type blockHistory struct {
+	config *interfaces.BlockHistoryConfig
+
+	mu         sync.RWMutex
+	blocks     []*interfaces.HistoricalBlock // Consecutive, oldest first
+	reorgs     int
+	reorgedOut int
+}
+
+// NewBlockHistory creates an empty block history buffer
+func NewBlockHistory(config *interfaces.BlockHistoryConfig) interfaces.BlockHistory {
+	if config == nil {
+		config = &interfaces.BlockHistoryConfig{
+			Capacity: 128,
+		}
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = 128
+	}
+
+	return &blockHistory{
+		config: config,
+	}
+}
+
+// Add appends a block to the buffer. A block at or below the buffered head replaces
+// the blocks from its height on; a block that skips ahead restarts the buffer.
+func (h *blockHistory) Add(block *interfaces.HistoricalBlock) ([]*interfaces.HistoricalBlock, error) {
+	if block == nil {
+		return nil, fmt.Errorf("block is required")
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.blocks) == 0 {
+		h.blocks = append(h.blocks, block)
+		return nil, nil
+	}
+
+	oldest := h.blocks[0].Number
+	latest := h.blocks[len(h.blocks)-1].Number
+
+	if block.Number < oldest {
+		return nil, fmt.Errorf("block %d is older than the buffer, which starts at %d", block.Number, oldest)
+	}
+
+	// Too far ahead to link; the caller fell behind by more than the buffer
+	if block.Number > latest+1 {
+		h.blocks = []*interfaces.HistoricalBlock{block}
+		return nil, nil
+	}
+
+	if held := h.get(block.Number); held != nil && held.Hash == block.Hash {
+		return nil, nil
+	}
+
+	keep := 0
+	if block.Number > oldest {
+		parent := h.get(block.Number - 1)
+		if parent.Hash != block.ParentHash {
+			return nil, fmt.Errorf("block %d: %w", block.Number, ErrUnknownParent)
+		}
+		keep = int(block.Number - oldest)
+	}
+
+	var reorged []*interfaces.HistoricalBlock
+	if keep < len(h.blocks) {
+		reorged = append(reorged, h.blocks[keep:]...)
+		h.reorgs++
+		h.reorgedOut += len(reorged)
+	}
+
+	h.blocks = append(h.blocks[:keep:keep], block)
+	if len(h.blocks) > h.config.Capacity {
+		h.blocks = h.blocks[len(h.blocks)-h.config.Capacity:]
+	}
+	return reorged, nil
+}
+
+// Get returns a buffered block by number
+func (h *blockHistory) Get(number uint64) (*interfaces.HistoricalBlock, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	block := h.get(number)
+	return block, block != nil
+}
+
+// Latest returns the buffered head
+func (h *blockHistory) Latest() (*interfaces.HistoricalBlock, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.blocks) == 0 {
+		return nil, false
+	}
+	return h.blocks[len(h.blocks)-1], true
+}
+
+// Recent returns up to count of the latest blocks, oldest first
+func (h *blockHistory) Recent(count int) []*interfaces.HistoricalBlock {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if count > len(h.blocks) {
+		count = len(h.blocks)
+	}
+	if count <= 0 {
+		return nil
+	}
+
+	recent := make([]*interfaces.HistoricalBlock, count)
+	copy(recent, h.blocks[len(h.blocks)-count:])
+	return recent
+}
+
+// GetStats returns buffer statistics
+func (h *blockHistory) GetStats() interfaces.BlockHistoryStats {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	stats := interfaces.BlockHistoryStats{
+		Blocks:     len(h.blocks),
+		Reorgs:     h.reorgs,
+		ReorgedOut: h.reorgedOut,
+	}
+	if len(h.blocks) > 0 {
+		stats.OldestBlock = h.blocks[0].Number
+		stats.LatestBlock = h.blocks[len(h.blocks)-1].Number
+	}
+	return stats
+}
+
+// get returns a buffered block by number; the caller holds the lock
+func (h *blockHistory) get(number uint64) *interfaces.HistoricalBlock {
+	if len(h.blocks) == 0 || number < h.blocks[0].Number {
+		return nil
+	}
+	index := number - h.blocks[0].Number
+	if index >= uint64(len(h.blocks)) {
+		return nil
+	}
+	return h.blocks[index]
+}
+
+// Sync fetches the blocks between the buffered head and the source's head, stepping
+// back through any blocks that were reorged out. At most backfill blocks are fetched
+// behind the head. It returns the number of blocks added.
+func Sync(ctx context.Context, history interfaces.BlockHistory, source interfaces.BlockSource, backfill int) (int, error) {
+	head, err := source.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get head block: %w", err)
+	}
+
+	next := uint64(0)
+	if head+1 > uint64(backfill) {
+		next = head + 1 - uint64(backfill)
+	}
+	if latest, exists := history.Latest(); exists && latest.Number+1 > next {
+		next = latest.Number + 1
+	}
+
+	added := 0
+	for next <= head {
+		if err := ctx.Err(); err != nil {
+			return added, err
+		}
+
+		block, err := source.FetchBlock(ctx, next)
+		if err != nil {
+			return added, fmt.Errorf("failed to fetch block %d: %w", next, err)
+		}
+
+		if _, err := history.Add(block); err != nil {
+			if errors.Is(err, ErrUnknownParent) && next > 0 {
+				next-- // Replace the orphaned parent first
+				continue
+			}
+			return added, err
+		}
+		added++
+		next++
+	}
+
+	return added, nil
+}
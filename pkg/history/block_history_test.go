@@ -0,0 +1,172 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// chain builds blocks on a fork of the chain; blocks on different forks get different hashes
+func chain(fork string, parent common.Hash, from, to uint64) []*interfaces.HistoricalBlock {
+	var blocks []*interfaces.HistoricalBlock
+	for number := from; number <= to; number++ {
+		block := &interfaces.HistoricalBlock{
+			Number:     number,
+			Hash:       common.BytesToHash([]byte(fmt.Sprintf("%s-%d", fork, number))),
+			ParentHash: parent,
+		}
+		blocks = append(blocks, block)
+		parent = block.Hash
+	}
+	return blocks
+}
+
+func addAll(t *testing.T, history interfaces.BlockHistory, blocks []*interfaces.HistoricalBlock) {
+	t.Helper()
+	for _, block := range blocks {
+		_, err := history.Add(block)
+		require.NoError(t, err)
+	}
+}
+
+func numbers(blocks []*interfaces.HistoricalBlock) []uint64 {
+	var result []uint64
+	for _, block := range blocks {
+		result = append(result, block.Number)
+	}
+	return result
+}
+
+func TestBlockHistory_AddAndEvict(t *testing.T) {
+	history := NewBlockHistory(&interfaces.BlockHistoryConfig{Capacity: 3})
+	_, exists := history.Latest()
+	assert.False(t, exists)
+
+	addAll(t, history, chain("a", common.Hash{}, 10, 14))
+
+	assert.Equal(t, []uint64{12, 13, 14}, numbers(history.Recent(10)))
+	assert.Equal(t, []uint64{13, 14}, numbers(history.Recent(2)))
+	_, exists = history.Get(11)
+	assert.False(t, exists)
+
+	latest, exists := history.Latest()
+	require.True(t, exists)
+	assert.Equal(t, uint64(14), latest.Number)
+
+	stats := history.GetStats()
+	assert.Equal(t, 3, stats.Blocks)
+	assert.Equal(t, uint64(12), stats.OldestBlock)
+	assert.Equal(t, uint64(14), stats.LatestBlock)
+	assert.Zero(t, stats.Reorgs)
+}
+
+func TestBlockHistory_Reorg(t *testing.T) {
+	history := NewBlockHistory(nil)
+	canonical := chain("a", common.Hash{}, 10, 14)
+	addAll(t, history, canonical)
+
+	// Re-adding a buffered block is a no-op
+	reorged, err := history.Add(canonical[4])
+	require.NoError(t, err)
+	assert.Empty(t, reorged)
+
+	// A sibling of block 13 replaces 13 and 14
+	fork := chain("b", canonical[2].Hash, 13, 13)
+	reorged, err = history.Add(fork[0])
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{13, 14}, numbers(reorged))
+
+	block, exists := history.Get(13)
+	require.True(t, exists)
+	assert.Equal(t, fork[0].Hash, block.Hash)
+	_, exists = history.Get(14)
+	assert.False(t, exists)
+
+	// A block whose parent was reorged out must wait for its parent
+	deeper := chain("c", canonical[0].Hash, 11, 14)
+	_, err = history.Add(deeper[3])
+	assert.ErrorIs(t, err, ErrUnknownParent)
+
+	stats := history.GetStats()
+	assert.Equal(t, 1, stats.Reorgs)
+	assert.Equal(t, 2, stats.ReorgedOut)
+
+	// Blocks older than the buffer are rejected
+	_, err = history.Add(chain("d", common.Hash{}, 5, 5)[0])
+	assert.Error(t, err)
+}
+
+func TestBlockHistory_Gap(t *testing.T) {
+	history := NewBlockHistory(nil)
+	addAll(t, history, chain("a", common.Hash{}, 10, 12))
+
+	_, err := history.Add(chain("a", common.Hash{}, 20, 20)[0])
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{20}, numbers(history.Recent(10)))
+}
+
+// fakeSource serves blocks from a map and can be switched to another fork
+type fakeSource struct {
+	head    uint64
+	blocks  map[uint64]*interfaces.HistoricalBlock
+	fetched []uint64
+}
+
+func newFakeSource(blocks []*interfaces.HistoricalBlock) *fakeSource {
+	source := &fakeSource{blocks: make(map[uint64]*interfaces.HistoricalBlock)}
+	source.set(blocks)
+	return source
+}
+
+func (s *fakeSource) set(blocks []*interfaces.HistoricalBlock) {
+	for _, block := range blocks {
+		s.blocks[block.Number] = block
+		if block.Number > s.head {
+			s.head = block.Number
+		}
+	}
+}
+
+func (s *fakeSource) BlockNumber(ctx context.Context) (uint64, error) {
+	return s.head, nil
+}
+
+func (s *fakeSource) FetchBlock(ctx context.Context, number uint64) (*interfaces.HistoricalBlock, error) {
+	s.fetched = append(s.fetched, number)
+	block, exists := s.blocks[number]
+	if !exists {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return block, nil
+}
+
+func TestSync(t *testing.T) {
+	canonical := chain("a", common.Hash{}, 0, 20)
+	source := newFakeSource(canonical)
+	history := NewBlockHistory(nil)
+
+	// The first sync backfills from the head
+	added, err := Sync(context.Background(), history, source, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 5, added)
+	assert.Equal(t, []uint64{16, 17, 18, 19, 20}, numbers(history.Recent(10)))
+
+	// Blocks 19 and 20 are reorged out and the chain grows to 22
+	source.set(chain("b", canonical[18].Hash, 19, 22))
+	source.fetched = nil
+
+	added, err = Sync(context.Background(), history, source, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{21, 20, 19, 20, 21, 22}, source.fetched)
+	assert.Equal(t, 4, added)
+
+	latest, _ := history.Latest()
+	assert.Equal(t, source.blocks[22].Hash, latest.Hash)
+	assert.Equal(t, []uint64{16, 17, 18, 19, 20, 21, 22}, numbers(history.Recent(10)))
+	assert.Equal(t, 1, history.GetStats().Reorgs)
+}
@@ -0,0 +1,147 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// depositTxType is the OP Stack deposit transaction type, which go-ethereum's
+// transaction decoder rejects; blocks are therefore decoded from the raw JSON
+const depositTxType = 0x7e
+
+// RPCClient is the subset of an RPC client needed to fetch blocks and receipts
+type RPCClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// rpcBlock is a block as returned by eth_getBlockByNumber with full transactions
+type rpcBlock struct {
+	Number       hexutil.Uint64   `json:"number"`
+	Hash         common.Hash      `json:"hash"`
+	ParentHash   common.Hash      `json:"parentHash"`
+	Timestamp    hexutil.Uint64   `json:"timestamp"`
+	BaseFee      *hexutil.Big     `json:"baseFeePerGas"`
+	Transactions []rpcTransaction `json:"transactions"`
+}
+
+// rpcTransaction is a mined transaction, including its recovered sender
+type rpcTransaction struct {
+	Type             hexutil.Uint64  `json:"type"`
+	Hash             common.Hash     `json:"hash"`
+	From             common.Address  `json:"from"`
+	To               *common.Address `json:"to"`
+	Value            *hexutil.Big    `json:"value"`
+	GasPrice         *hexutil.Big    `json:"gasPrice"` // Effective price once mined
+	Gas              hexutil.Uint64  `json:"gas"`
+	Nonce            hexutil.Uint64  `json:"nonce"`
+	Input            hexutil.Bytes   `json:"input"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	ChainID          *hexutil.Big    `json:"chainId"`
+}
+
+// rpcSource implements the BlockSource interface over JSON-RPC
+type rpcSource struct {
+	client  RPCClient
+	chainID *big.Int
+}
+
+// NewRPCBlockSource creates a block source that reads blocks with eth_getBlockByNumber
+// and receipts with eth_getBlockReceipts. The chain ID fills transactions that don't
+// carry one, such as deposits.
+func NewRPCBlockSource(client RPCClient, chainID *big.Int) interfaces.BlockSource {
+	if chainID == nil {
+		chainID = big.NewInt(8453) // Base mainnet
+	}
+	return &rpcSource{
+		client:  client,
+		chainID: chainID,
+	}
+}
+
+// BlockNumber returns the latest block number
+func (s *rpcSource) BlockNumber(ctx context.Context) (uint64, error) {
+	var number hexutil.Uint64
+	if err := s.client.CallContext(ctx, &number, "eth_blockNumber"); err != nil {
+		return 0, err
+	}
+	return uint64(number), nil
+}
+
+// FetchBlock reads a block with its transactions and receipts
+func (s *rpcSource) FetchBlock(ctx context.Context, number uint64) (*interfaces.HistoricalBlock, error) {
+	tag := hexutil.EncodeUint64(number)
+
+	var raw *rpcBlock
+	if err := s.client.CallContext(ctx, &raw, "eth_getBlockByNumber", tag, true); err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	if raw == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	var receipts []*ethtypes.Receipt
+	if err := s.client.CallContext(ctx, &receipts, "eth_getBlockReceipts", tag); err != nil {
+		return nil, fmt.Errorf("failed to get receipts: %w", err)
+	}
+	if len(receipts) != len(raw.Transactions) {
+		return nil, fmt.Errorf("block %d has %d transactions but %d receipts", number, len(raw.Transactions), len(receipts))
+	}
+
+	block := &interfaces.HistoricalBlock{
+		Number:       uint64(raw.Number),
+		Hash:         raw.Hash,
+		ParentHash:   raw.ParentHash,
+		Timestamp:    time.Unix(int64(raw.Timestamp), 0),
+		BaseFee:      (*big.Int)(raw.BaseFee),
+		Transactions: make([]*types.Transaction, len(raw.Transactions)),
+		Receipts:     receipts,
+	}
+
+	for i, tx := range raw.Transactions {
+		if tx.Type == depositTxType {
+			block.Deposits++
+		}
+		block.Transactions[i] = s.convertTransaction(&tx, block)
+	}
+
+	return block, nil
+}
+
+// convertTransaction converts a mined transaction to our Transaction type
+func (s *rpcSource) convertTransaction(tx *rpcTransaction, block *interfaces.HistoricalBlock) *types.Transaction {
+	chainID := s.chainID
+	if tx.ChainID != nil {
+		chainID = tx.ChainID.ToInt()
+	}
+
+	return &types.Transaction{
+		Hash:        tx.Hash.Hex(),
+		From:        tx.From,
+		To:          tx.To,
+		Value:       bigOrZero(tx.Value),
+		GasPrice:    bigOrZero(tx.GasPrice),
+		GasLimit:    uint64(tx.Gas),
+		Nonce:       uint64(tx.Nonce),
+		Data:        tx.Input,
+		Timestamp:   block.Timestamp,
+		BlockNumber: new(big.Int).SetUint64(block.Number),
+		TxIndex:     uint(tx.TransactionIndex),
+		ChainID:     chainID,
+	}
+}
+
+// bigOrZero returns a decoded quantity, or zero when it was absent
+func bigOrZero(value *hexutil.Big) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return value.ToInt()
+}
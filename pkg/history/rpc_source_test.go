@@ -0,0 +1,154 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRPCClient answers calls with canned JSON, as a node would
+type fakeRPCClient struct {
+	responses map[string]string
+	calls     [][]interface{}
+}
+
+func (c *fakeRPCClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.calls = append(c.calls, append([]interface{}{method}, args...))
+	response, exists := c.responses[method]
+	if !exists {
+		return fmt.Errorf("method %s not supported", method)
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+const testBlockJSON = `{
+	"number": "0x1000",
+	"hash": "0x00000000000000000000000000000000000000000000000000000000000000b1",
+	"parentHash": "0x00000000000000000000000000000000000000000000000000000000000000b0",
+	"timestamp": "0x6553f100",
+	"baseFeePerGas": "0x3b9aca00",
+	"transactions": [
+		{
+			"type": "0x7e",
+			"hash": "0x00000000000000000000000000000000000000000000000000000000000000d1",
+			"from": "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001",
+			"to": "0x4200000000000000000000000000000000000015",
+			"value": "0x0",
+			"gas": "0xf4240",
+			"nonce": "0x10",
+			"input": "0x440a5e20",
+			"transactionIndex": "0x0"
+		},
+		{
+			"type": "0x2",
+			"hash": "0x00000000000000000000000000000000000000000000000000000000000000d2",
+			"from": "0x1000000000000000000000000000000000000001",
+			"to": "0x2626664c2603336E57B271c5C0b26F421741e481",
+			"value": "0xde0b6b3a7640000",
+			"gasPrice": "0x3b9aca01",
+			"gas": "0x30d40",
+			"nonce": "0x5",
+			"input": "0x38ed1739",
+			"transactionIndex": "0x1",
+			"chainId": "0x2105"
+		}
+	]
+}`
+
+func receiptsJSON(t *testing.T, count int) string {
+	t.Helper()
+
+	receipts := make([]*ethtypes.Receipt, count)
+	for i := range receipts {
+		receipts[i] = &ethtypes.Receipt{
+			Status:            ethtypes.ReceiptStatusSuccessful,
+			CumulativeGasUsed: uint64(50000 * (i + 1)),
+			GasUsed:           50000,
+			Logs:              []*ethtypes.Log{},
+			TxHash:            common.BigToHash(big.NewInt(int64(0xd1 + i))),
+		}
+	}
+	encoded, err := json.Marshal(receipts)
+	require.NoError(t, err)
+	return string(encoded)
+}
+
+func TestRPCBlockSource_FetchBlock(t *testing.T) {
+	client := &fakeRPCClient{responses: map[string]string{
+		"eth_blockNumber":      `"0x1000"`,
+		"eth_getBlockByNumber": testBlockJSON,
+		"eth_getBlockReceipts": receiptsJSON(t, 2),
+	}}
+	source := NewRPCBlockSource(client, nil)
+
+	head, err := source.BlockNumber(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4096), head)
+
+	block, err := source.FetchBlock(context.Background(), 4096)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"eth_getBlockByNumber", "0x1000", true}, client.calls[1])
+
+	assert.Equal(t, uint64(4096), block.Number)
+	assert.Equal(t, common.HexToHash("0xb0"), block.ParentHash)
+	assert.Equal(t, int64(0x6553f100), block.Timestamp.Unix())
+	assert.Equal(t, big.NewInt(1e9), block.BaseFee)
+	assert.Equal(t, 1, block.Deposits)
+	require.Len(t, block.Transactions, 2)
+	require.Len(t, block.Receipts, 2)
+
+	// Deposits carry no gas price or chain ID
+	deposit := block.Transactions[0]
+	assert.Equal(t, big.NewInt(0), deposit.GasPrice)
+	assert.Equal(t, big.NewInt(8453), deposit.ChainID)
+
+	swap := block.Transactions[1]
+	assert.Equal(t, common.HexToHash("0xd2").Hex(), swap.Hash)
+	assert.Equal(t, common.HexToAddress("0x1000000000000000000000000000000000000001"), swap.From)
+	assert.Equal(t, big.NewInt(1e18), swap.Value)
+	assert.Equal(t, big.NewInt(1e9+1), swap.GasPrice)
+	assert.Equal(t, uint64(200000), swap.GasLimit)
+	assert.Equal(t, uint64(5), swap.Nonce)
+	assert.Equal(t, []byte{0x38, 0xed, 0x17, 0x39}, swap.Data)
+	assert.Equal(t, uint(1), swap.TxIndex)
+	assert.Equal(t, big.NewInt(4096), swap.BlockNumber)
+}
+
+func TestRPCBlockSource_FetchBlockErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses map[string]string
+	}{
+		{
+			name:      "missing block",
+			responses: map[string]string{"eth_getBlockByNumber": `null`},
+		},
+		{
+			name:      "receipts unavailable",
+			responses: map[string]string{"eth_getBlockByNumber": testBlockJSON},
+		},
+		{
+			name: "receipt count mismatch",
+			responses: map[string]string{
+				"eth_getBlockByNumber": testBlockJSON,
+				"eth_getBlockReceipts": receiptsJSON(t, 1),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := NewRPCBlockSource(&fakeRPCClient{responses: tt.responses}, nil)
+
+			_, err := source.FetchBlock(context.Background(), 4096)
+			assert.Error(t, err)
+		})
+	}
+}
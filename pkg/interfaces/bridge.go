@@ -0,0 +1,79 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// WithdrawalProver follows OP Stack withdrawals from their L2 transaction through
+// proving and finalization on L1
+type WithdrawalProver interface {
+	// GetWithdrawal reads the withdrawal initiated by an L2 transaction and its stage
+	GetWithdrawal(ctx context.Context, l2TxHash common.Hash) (*Withdrawal, error)
+	// BuildProveTransaction builds the OptimismPortal proof against the latest dispute
+	// game covering the withdrawal's block
+	BuildProveTransaction(ctx context.Context, withdrawal *Withdrawal) (*types.Transaction, error)
+	// BuildFinalizeTransaction builds the OptimismPortal finalization once the proof
+	// has matured through the challenge window
+	BuildFinalizeTransaction(ctx context.Context, withdrawal *Withdrawal) (*types.Transaction, error)
+}
+
+// WithdrawalStatus is the stage of an L2-to-L1 withdrawal
+type WithdrawalStatus string
+
+const (
+	WithdrawalWaitingToProve  WithdrawalStatus = "waiting_to_prove" // No dispute game covers its block yet
+	WithdrawalReadyToProve    WithdrawalStatus = "ready_to_prove"
+	WithdrawalProven          WithdrawalStatus = "proven" // In the challenge window
+	WithdrawalReadyToFinalize WithdrawalStatus = "ready_to_finalize"
+	WithdrawalFinalized       WithdrawalStatus = "finalized"
+)
+
+// WithdrawalTransaction is a message sent through the L2ToL1MessagePasser, as the
+// OptimismPortal proves and relays it
+type WithdrawalTransaction struct {
+	Nonce    *big.Int
+	Sender   common.Address
+	Target   common.Address
+	Value    *big.Int
+	GasLimit *big.Int
+	Data     []byte
+}
+
+// OutputRootProof opens an L2 output root to the message passer's storage root
+type OutputRootProof struct {
+	Version                  common.Hash
+	StateRoot                common.Hash
+	MessagePasserStorageRoot common.Hash
+	LatestBlockhash          common.Hash
+}
+
+// Withdrawal is a withdrawal and where it stands
+type Withdrawal struct {
+	Hash          common.Hash // Withdrawal hash the portal keys proofs by
+	Transaction   *WithdrawalTransaction
+	L2TxHash      common.Hash
+	L2BlockNumber uint64
+	Status        WithdrawalStatus
+	ProvenAt      time.Time // Zero until proven
+	FinalizableAt time.Time // Zero until proven
+}
+
+// BridgeConfig holds the OP Stack contracts and the account bridging for a strategy
+type BridgeConfig struct {
+	L1StandardBridge   common.Address
+	L2StandardBridge   common.Address
+	OptimismPortal     common.Address
+	DisputeGameFactory common.Address
+	// L1Tokens maps L2 tokens to their L1 counterparts; the L2 WETH bridges as ETH
+	L1Tokens      map[common.Address]common.Address
+	Account       common.Address // Receives bridged funds and submits proofs
+	MinGasLimit   uint32         // Gas for the message relayed on the other layer
+	MaxGameSearch int            // Dispute games scanned back from the latest for a proof
+	L1GasLimit    uint64         // Per deposit, prove or finalize transaction
+	L2GasLimit    uint64         // Per withdrawal transaction
+}
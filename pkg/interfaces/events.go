@@ -26,15 +26,21 @@ type ABIManager interface {
 
 // ParsedEvent represents a decoded event log
 type ParsedEvent struct {
-	Protocol    Protocol
-	EventType   EventType
-	Address     common.Address
-	TxHash      common.Hash
-	BlockNumber uint64
-	LogIndex    uint
-	SwapEvent   *SwapEvent
-	BridgeEvent *BridgeEvent
-	RawLog      *ethtypes.Log
+	Protocol        Protocol
+	EventType       EventType
+	Address         common.Address
+	TxHash          common.Hash
+	BlockNumber     uint64
+	LogIndex        uint
+	SwapEvent       *SwapEvent
+	BridgeEvent     *BridgeEvent
+	LiquidityEvent  *LiquidityEvent // Mint and Burn
+	SyncEvent       *SyncEvent
+	CollectEvent    *CollectEvent
+	FlashEvent      *FlashEvent
+	FeeEvent        *FeeEvent        // Aerodrome Fees and Claim
+	InitializeEvent *InitializeEvent // Uniswap V4 pool initialization
+	RawLog          *ethtypes.Log
 }
 
 // SwapEvent represents a decoded swap event from DEX protocols
@@ -48,9 +54,83 @@ type SwapEvent struct {
 	Sender       common.Address
 	Recipient    common.Address
 	Fee          *big.Int
-	SqrtPriceX96 *big.Int // For Uniswap V3
-	Liquidity    *big.Int // For Uniswap V3
-	Tick         *big.Int // For Uniswap V3
+	SqrtPriceX96 *big.Int    // For Uniswap V3
+	Liquidity    *big.Int    // For Uniswap V3
+	Tick         *big.Int    // For Uniswap V3
+	PoolID       common.Hash // For Uniswap V4 singleton pools
+	CoinIn       int         // For Curve, index of the sold coin
+	CoinOut      int         // For Curve, index of the bought coin
+}
+
+// LiquidityEvent represents a liquidity addition (Mint) or removal (Burn)
+type LiquidityEvent struct {
+	Protocol  Protocol
+	Pool      common.Address
+	PoolID    common.Hash // For Uniswap V4 singleton pools
+	Sender    common.Address
+	Owner     common.Address // Position owner for Uniswap V3
+	Recipient common.Address // Burn recipient for V2-style pairs
+	TickLower *big.Int       // For Uniswap V3
+	TickUpper *big.Int       // For Uniswap V3
+	Liquidity *big.Int       // For Uniswap V3
+	Amount0   *big.Int
+	Amount1   *big.Int
+}
+
+// SyncEvent represents a reserve update from a V2-style pair
+type SyncEvent struct {
+	Protocol Protocol
+	Pool     common.Address
+	Reserve0 *big.Int
+	Reserve1 *big.Int
+}
+
+// CollectEvent represents fees and withdrawn liquidity collected from a Uniswap V3 position
+type CollectEvent struct {
+	Protocol  Protocol
+	Pool      common.Address
+	Owner     common.Address
+	Recipient common.Address
+	TickLower *big.Int
+	TickUpper *big.Int
+	Amount0   *big.Int
+	Amount1   *big.Int
+}
+
+// FlashEvent represents a Uniswap V3 flash loan
+type FlashEvent struct {
+	Protocol  Protocol
+	Pool      common.Address
+	Sender    common.Address
+	Recipient common.Address
+	Amount0   *big.Int
+	Amount1   *big.Int
+	Paid0     *big.Int
+	Paid1     *big.Int
+}
+
+// FeeEvent represents Aerodrome fee accrual (Fees) or a fee claim (Claim)
+type FeeEvent struct {
+	Protocol  Protocol
+	Pool      common.Address
+	Sender    common.Address
+	Recipient common.Address // Only set for Claim
+	Amount0   *big.Int
+	Amount1   *big.Int
+}
+
+// InitializeEvent represents a Uniswap V4 pool being initialized in the PoolManager
+type InitializeEvent struct {
+	Protocol     Protocol
+	PoolManager  common.Address
+	PoolID       common.Hash
+	Currency0    common.Address
+	Currency1    common.Address
+	Fee          uint32 // Hundredths of a bip, or the dynamic fee flag
+	TickSpacing  int32
+	Hooks        common.Address
+	SqrtPriceX96 *big.Int
+	Tick         *big.Int
 }
 
 // BridgeEvent represents a bridge deposit/withdrawal event
@@ -73,6 +153,12 @@ const (
 	ProtocolUniswapV3
 	ProtocolAerodrome
 	ProtocolBaseBridge
+	ProtocolUniswapV4
+	ProtocolPancakeSwapV3
+	ProtocolSushiSwapV2
+	ProtocolSushiSwapV3
+	ProtocolBaseSwap
+	ProtocolCurve
 )
 
 func (p Protocol) String() string {
@@ -85,20 +171,60 @@ func (p Protocol) String() string {
 		return "Aerodrome"
 	case ProtocolBaseBridge:
 		return "BaseBridge"
+	case ProtocolUniswapV4:
+		return "UniswapV4"
+	case ProtocolPancakeSwapV3:
+		return "PancakeSwapV3"
+	case ProtocolSushiSwapV2:
+		return "SushiSwapV2"
+	case ProtocolSushiSwapV3:
+		return "SushiSwapV3"
+	case ProtocolBaseSwap:
+		return "BaseSwap"
+	case ProtocolCurve:
+		return "Curve"
 	default:
 		return "Unknown"
 	}
 }
 
+// Protocols returns every known protocol
+func Protocols() []Protocol {
+	return []Protocol{
+		ProtocolUniswapV2,
+		ProtocolUniswapV3,
+		ProtocolAerodrome,
+		ProtocolBaseBridge,
+		ProtocolUniswapV4,
+		ProtocolPancakeSwapV3,
+		ProtocolSushiSwapV2,
+		ProtocolSushiSwapV3,
+		ProtocolBaseSwap,
+		ProtocolCurve,
+	}
+}
+
+// ParseProtocol converts a protocol name as returned by String back to a Protocol
+func ParseProtocol(name string) Protocol {
+	for _, protocol := range Protocols() {
+		if protocol.String() == name {
+			return protocol
+		}
+	}
+	return ProtocolUnknown
+}
+
 // ContractType represents different contract types within protocols
 type ContractType int
 
 const (
-	ContractTypeUnknown ContractType = iota
-	ContractTypePair    // Uniswap V2 style pairs
-	ContractTypePool    // Uniswap V3 style pools
-	ContractTypeRouter  // Router contracts
-	ContractTypeBridge  // Bridge contracts
+	ContractTypeUnknown     ContractType = iota
+	ContractTypePair                     // Uniswap V2 style pairs
+	ContractTypePool                     // Uniswap V3 style pools
+	ContractTypeRouter                   // Router contracts
+	ContractTypeBridge                   // Bridge contracts
+	ContractTypeFactory                  // Pool/pair factory contracts
+	ContractTypePoolManager              // Singleton pool managers (Uniswap V4)
 )
 
 func (c ContractType) String() string {
@@ -111,11 +237,27 @@ func (c ContractType) String() string {
 		return "Router"
 	case ContractTypeBridge:
 		return "Bridge"
+	case ContractTypeFactory:
+		return "Factory"
+	case ContractTypePoolManager:
+		return "PoolManager"
 	default:
 		return "Unknown"
 	}
 }
 
+// ContractTypes returns every known contract type
+func ContractTypes() []ContractType {
+	return []ContractType{
+		ContractTypePair,
+		ContractTypePool,
+		ContractTypeRouter,
+		ContractTypeBridge,
+		ContractTypeFactory,
+		ContractTypePoolManager,
+	}
+}
+
 // EventType represents different types of events
 type EventType int
 
@@ -126,6 +268,12 @@ const (
 	EventTypeWithdraw
 	EventTypeMint
 	EventTypeBurn
+	EventTypeSync
+	EventTypeCollect
+	EventTypeFlash
+	EventTypeFees
+	EventTypeClaim
+	EventTypeInitialize
 )
 
 func (e EventType) String() string {
@@ -140,7 +288,19 @@ func (e EventType) String() string {
 		return "Mint"
 	case EventTypeBurn:
 		return "Burn"
+	case EventTypeSync:
+		return "Sync"
+	case EventTypeCollect:
+		return "Collect"
+	case EventTypeFlash:
+		return "Flash"
+	case EventTypeFees:
+		return "Fees"
+	case EventTypeClaim:
+		return "Claim"
+	case EventTypeInitialize:
+		return "Initialize"
 	default:
 		return "Unknown"
 	}
-}
\ No newline at end of file
+}
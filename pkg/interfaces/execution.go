@@ -0,0 +1,276 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// SwapHop is one pool of a swap route
+type SwapHop struct {
+	Pool     common.Address
+	Protocol Protocol       // ProtocolUnknown looks the pool up in the pool registry
+	TokenIn  common.Address // Zero for the previous hop's output token
+	TokenOut common.Address // Zero for the pool's other token
+	State    *PoolState     // Quoted instead of the pool's chain state, e.g. for a pool the target creates
+}
+
+// SwapRoute is a swap through one or more pools, executed as one transaction
+type SwapRoute struct {
+	Hops         []SwapHop
+	AmountIn     *big.Int // Nil to sell the quoted output of the previous route
+	MinAmountOut *big.Int // Nil for the quoted output less the builder's slippage tolerance
+	GasPrice     *big.Int
+}
+
+// CallBatch is calls our executor contract makes in order in one transaction,
+// checked against the same bounds as a swap route
+type CallBatch struct {
+	Calls        []*SwapCalldata
+	Spender      common.Address // Approved for AmountIn of TokenIn before the calls, if set
+	Sell         *SwapRoute     // Swapped after the calls, e.g. selling what they returned; needs an amount
+	TokenIn      common.Address
+	AmountIn     *big.Int // Nil for none
+	TokenOut     common.Address
+	MinAmountOut *big.Int       // Nil for none
+	FlashLoan    *FlashLoanPlan // Borrowed before the calls and repaid after them, if set
+	GasLimit     uint64         // Of the calls; the sale's hops and the loan's overhead are added
+	GasPrice     *big.Int
+}
+
+// BundleSimulator checks bundles against the latest chain state
+type BundleSimulator interface {
+	// Simulate executes a bundle in order on a fork, failing if any transaction reverts
+	Simulate(ctx context.Context, bundle []*types.Transaction) ([]*SimulationResult, error)
+}
+
+// PinnedBundleSimulator checks bundles against the chain state at a past block
+type PinnedBundleSimulator interface {
+	// SimulateAt executes a bundle in order on a fork pinned to the state after a
+	// block, failing if any transaction reverts
+	SimulateAt(ctx context.Context, bundle []*types.Transaction, blockNumber uint64) ([]*SimulationResult, error)
+}
+
+// TransactionBuilder turns swap routes into calls to our executor contract, sent
+// from the searcher account
+type TransactionBuilder interface {
+	BundleSimulator
+	// Searcher returns the account that sends built transactions
+	Searcher() common.Address
+	// Executor returns the contract built transactions call, which receives their outputs
+	Executor() common.Address
+	// BuildSwaps encodes routes as consecutive transactions, numbered from the
+	// searcher's pending nonce
+	BuildSwaps(ctx context.Context, routes []*SwapRoute, chainID *big.Int) ([]*types.Transaction, error)
+	// BuildCalls encodes call batches as consecutive transactions, numbered from the
+	// searcher's pending nonce
+	BuildCalls(ctx context.Context, batches []*CallBatch, chainID *big.Int) ([]*types.Transaction, error)
+}
+
+// TransactionSubmitter sends a bundle to the network
+type TransactionSubmitter interface {
+	Submit(ctx context.Context, bundle []*types.Transaction) ([]common.Hash, error)
+}
+
+// RelayMethod is the JSON-RPC method a relay takes transactions with
+type RelayMethod string
+
+const (
+	RelayMethodBundle             RelayMethod = "eth_sendBundle"             // Block builders; the bundle lands whole or not at all
+	RelayMethodPrivateTransaction RelayMethod = "eth_sendPrivateTransaction" // Private mempools; one call per transaction
+	RelayMethodRawTransaction     RelayMethod = "eth_sendRawTransaction"     // The sequencer or a public node; one call per transaction
+)
+
+// RelaySubmission is one relay's handling of a submission
+type RelaySubmission struct {
+	Relay    string        `json:"relay"`
+	Method   RelayMethod   `json:"method"`
+	Accepted bool          `json:"accepted"`
+	Latency  time.Duration `json:"latency"` // Until the relay accepted or the last attempt failed
+	Attempts int           `json:"attempts"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Submission is a bundle broadcast to the configured relays
+type Submission struct {
+	TxHashes    []common.Hash      `json:"txHashes"`
+	BlockNumber uint64             `json:"blockNumber"` // Block the bundle targets
+	SubmittedAt time.Time          `json:"submittedAt"`
+	Relays      []*RelaySubmission `json:"relays"`
+}
+
+// Accepted reports whether any relay accepted the submission
+func (s *Submission) Accepted() bool {
+	for _, relay := range s.Relays {
+		if relay.Accepted {
+			return true
+		}
+	}
+	return false
+}
+
+// NonceReservation is a run of consecutive nonces held for one bundle
+type NonceReservation struct {
+	Account common.Address
+	First   uint64
+	Count   int
+}
+
+// Nonce returns the reservation's i-th nonce
+func (r *NonceReservation) Nonce(i int) uint64 {
+	return r.First + uint64(i)
+}
+
+// NonceManager hands out a searcher account's nonces to concurrent bundles. Reserved
+// nonces stay held until they are released or the account's pending nonce passes them.
+type NonceManager interface {
+	Account() common.Address
+	// Reserve atomically reserves count consecutive nonces
+	Reserve(ctx context.Context, count int) (*NonceReservation, error)
+	// Release returns the nonces of an abandoned bundle. Nonces below ones still
+	// held become gaps.
+	Release(reservation *NonceReservation)
+	// Resync reloads the account's pending nonce from the chain
+	Resync(ctx context.Context) error
+	// Gaps returns the unused nonces later reservations are waiting on
+	Gaps() []uint64
+	// FillGaps resyncs and submits a cancel transaction for each gap
+	FillGaps(ctx context.Context) ([]*types.Transaction, error)
+}
+
+// InclusionStatus is what became of a submitted bundle
+type InclusionStatus string
+
+const (
+	InclusionPending  InclusionStatus = "pending"
+	InclusionIncluded InclusionStatus = "included" // Every transaction landed and succeeded
+	InclusionReverted InclusionStatus = "reverted" // A transaction landed and reverted
+	InclusionReplaced InclusionStatus = "replaced" // Another transaction used one of the bundle's nonces
+	InclusionExpired  InclusionStatus = "expired"  // A transaction didn't land in time
+)
+
+// TrackedBundle is a submitted bundle awaiting inclusion
+type TrackedBundle struct {
+	Opportunity  *MEVOpportunity
+	Trade        *TradeResult         // As recorded at submission, with the expected profit
+	Transactions []*types.Transaction // As submitted, with their final nonces
+	TxHashes     []common.Hash
+	Nonces       *NonceReservation // Released if the bundle expires; may be nil
+}
+
+// InclusionResult is the outcome of a tracked bundle
+type InclusionResult struct {
+	Bundle      *TrackedBundle
+	Status      InclusionStatus
+	BlockNumber uint64 // Last block a transaction of the bundle landed in
+	Receipts    []*ethtypes.Receipt
+	L1Fee       *big.Int     // L1 data fee of our transactions, from their receipts
+	Trade       *TradeResult // The realized trade
+	// BalanceDeltas are the profit holder's ETH (the zero address) and profit
+	// token balance changes from before the first block the bundle landed in to
+	// the end of the last, which its logs and fees are reconciled against
+	BalanceDeltas map[common.Address]*big.Int
+}
+
+// InclusionMonitor watches new blocks for submitted bundles and reconciles what
+// they realized
+type InclusionMonitor interface {
+	// Track starts watching a submitted bundle, which expires a number of blocks
+	// after the head it was submitted at
+	Track(ctx context.Context, bundle *TrackedBundle) error
+	// Check looks for the tracked bundles' transactions in the latest blocks and
+	// returns the bundles that reached a final status
+	Check(ctx context.Context) ([]*InclusionResult, error)
+}
+
+// ExecutionMode is how far the executor goes with an opportunity
+type ExecutionMode string
+
+const (
+	ExecutionModeSimulation ExecutionMode = "simulation" // Paper trading only
+	ExecutionModeHybrid     ExecutionMode = "hybrid"     // Re-simulate first, submit if still profitable
+	ExecutionModeLive       ExecutionMode = "live"       // Submit directly
+)
+
+// Executor turns opportunities into trades
+type Executor interface {
+	// Execute runs an opportunity's execution transactions, in order, as a bundle
+	Execute(ctx context.Context, opportunity *MEVOpportunity) (*ExecutionResult, error)
+	Mode() ExecutionMode
+	SetMode(mode ExecutionMode) error
+	// StrategyMode returns the mode a strategy's opportunities run in: its own, if
+	// set, or the executor's
+	StrategyMode(strategy StrategyType) ExecutionMode
+	SetStrategyMode(strategy StrategyType, mode ExecutionMode) error
+}
+
+// ExecutionConfig holds configuration for the executor
+type ExecutionConfig struct {
+	Mode               ExecutionMode
+	MinProfitThreshold *big.Int       // Net profit in wei of ETH a hybrid re-simulation must still show
+	MaxGasPrice        *big.Int       // Bundles priced above this aren't submitted; nil for no limit
+	Executor           common.Address // Holds the profits; the sender of our first transaction if unset
+}
+
+// ExecutionResult is the outcome of executing an opportunity
+type ExecutionResult struct {
+	OpportunityID   string               `json:"opportunityId"`
+	Mode            ExecutionMode        `json:"mode"`
+	Success         bool                 `json:"success"`
+	Submitted       bool                 `json:"submitted"` // Sent to the network rather than filled on paper
+	RealizedProfit  *big.Int             `json:"realizedProfit"`
+	ActualGasCost   *big.Int             `json:"actualGasCost"`
+	ActualNetProfit *big.Int             `json:"actualNetProfit"`
+	ExecutionTime   time.Duration        `json:"executionTime"`
+	Error           string               `json:"error,omitempty"`
+	SubmittedTxs    []*types.Transaction `json:"submittedTxs,omitempty"`
+	TxHashes        []common.Hash        `json:"txHashes,omitempty"`
+	Simulations     []*SimulationResult  `json:"-"`
+	Trade           *TradeResult         `json:"-"` // Recorded with the metrics collector; nil if nothing traded
+}
+
+// RelayConfig describes one endpoint bundles are submitted to
+type RelayConfig struct {
+	Name         string
+	URL          string
+	Method       RelayMethod
+	Headers      map[string]string // Sent with every request, e.g. authorization
+	SignPayload  bool              // Sign requests with the searcher key in X-Flashbots-Signature
+	Timeout      time.Duration     // Per attempt
+	MaxRetries   int               // Retries after transport errors, 429s and 5xx responses
+	RetryBackoff time.Duration     // Doubled after each retry
+}
+
+// SubmitterConfig holds configuration for the relay submitter
+type SubmitterConfig struct {
+	Relays             []RelayConfig
+	PrivateTxMaxBlocks uint64 // How many blocks relays may hold a private transaction for
+	HistorySize        int    // Submissions kept for inspection
+}
+
+// InclusionMonitorConfig holds configuration for the inclusion monitor
+type InclusionMonitorConfig struct {
+	Executor     common.Address // Holds the profits; the searcher if unset
+	ExpiryBlocks uint64         // Blocks after submission a transaction may still land in
+	PollInterval time.Duration
+}
+
+// NonceManagerConfig holds configuration for the nonce manager
+type NonceManagerConfig struct {
+	CancelGasLimit        uint64
+	CancelGasPriceBumpPct uint64 // Over the suggested gas price, so cancels replace stuck transactions
+}
+
+// TransactionBuilderConfig holds configuration for the transaction builder
+type TransactionBuilderConfig struct {
+	Searcher       common.Address // Sends built transactions
+	Executor       common.Address // Contract that holds inventory and runs routes
+	Deadline       time.Duration  // How long after building a route stays valid
+	MaxSlippageBps uint16         // Applied to quoted outputs for routes without a minimum
+	BaseGas        uint64
+	GasPerHop      uint64
+}
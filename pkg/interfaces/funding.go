@@ -0,0 +1,44 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FlashLoanSource is a protocol our executor contract can borrow from and repay
+// within one transaction
+type FlashLoanSource string
+
+const (
+	FlashLoanBalancer  FlashLoanSource = "balancer_v2" // Vault flashLoan
+	FlashLoanAaveV3    FlashLoanSource = "aave_v3"     // Pool flashLoanSimple
+	FlashLoanUniswapV3 FlashLoanSource = "uniswap_v3"  // Pool flash, at the pool's fee tier
+	FlashLoanMorpho    FlashLoanSource = "morpho_blue" // Morpho flashLoan
+)
+
+// FundingPlanner funds opportunities with flash loans instead of our inventory
+type FundingPlanner interface {
+	// Quote returns the sources with enough liquidity to lend amount of token,
+	// cheapest first. Pools in exclude, such as those the trade swaps in, aren't
+	// borrowed from.
+	Quote(ctx context.Context, token common.Address, amount *big.Int, exclude []common.Address) ([]*FlashLoanPlan, error)
+	// Fund wraps an opportunity's executor calls in flash loans of their input from
+	// the cheapest sources and takes the fees and extra gas out of its profit
+	Fund(ctx context.Context, opportunity *MEVOpportunity) ([]*FlashLoanPlan, error)
+}
+
+// FlashLoanSourceConfig describes one place to borrow from
+type FlashLoanSourceConfig struct {
+	Source      FlashLoanSource
+	Lender      common.Address // Balancer Vault, Aave V3 pool or Morpho; Uniswap V3 pools come from the pool registry
+	FeeBps      uint16         // Uniswap V3 charges its pools' fee tier instead
+	GasOverhead uint64
+}
+
+// FundingPlannerConfig holds configuration for the funding planner
+type FundingPlannerConfig struct {
+	Sources        []FlashLoanSourceConfig // Ties in fee and gas go to the earlier source
+	MaxPoolsQuoted int                     // Uniswap V3 pools checked per token, lowest fee first
+}
@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// BlockHistory buffers recently included blocks with their receipts
+type BlockHistory interface {
+	// Add appends a block. A block that does not extend the buffered chain replaces
+	// the blocks it reorgs out, which are returned.
+	Add(block *HistoricalBlock) ([]*HistoricalBlock, error)
+	Get(number uint64) (*HistoricalBlock, bool)
+	Latest() (*HistoricalBlock, bool)
+	// Recent returns up to count of the latest blocks, oldest first
+	Recent(count int) []*HistoricalBlock
+	GetStats() BlockHistoryStats
+}
+
+// BlockSource fetches included blocks with their receipts
+type BlockSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FetchBlock(ctx context.Context, number uint64) (*HistoricalBlock, error)
+}
+
+// HistoricalBlock is an included block with its receipts, in transaction order
+type HistoricalBlock struct {
+	Number       uint64
+	Hash         common.Hash
+	ParentHash   common.Hash
+	Timestamp    time.Time
+	BaseFee      *big.Int
+	Transactions []*types.Transaction
+	Receipts     []*ethtypes.Receipt
+	Deposits     int // Leading L1 deposit transactions, which the sequencer must place first
+}
+
+// BlockHistoryConfig configures the block history buffer
+type BlockHistoryConfig struct {
+	Capacity int // Blocks kept; older blocks are evicted
+}
+
+// BlockHistoryStats summarizes the buffer
+type BlockHistoryStats struct {
+	Blocks      int
+	OldestBlock uint64
+	LatestBlock uint64
+	Reorgs      int
+	ReorgedOut  int // Blocks replaced by reorgs
+}
@@ -0,0 +1,79 @@
+package interfaces
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// LendingMarket mirrors borrower positions of a lending market from its event logs
+type LendingMarket interface {
+	Protocol() LendingProtocol
+	Address() common.Address
+	Assets() []*LendingAsset
+	// ApplyLog updates positions from a market log and returns the borrowers it touched.
+	// Logs from other contracts are ignored.
+	ApplyLog(log *ethtypes.Log) ([]common.Address, error)
+	Position(borrower common.Address) (*BorrowerPosition, bool)
+	Borrowers() []common.Address
+	// SeedPosition replaces a borrower's position, e.g. from an on-chain snapshot
+	SeedPosition(position *BorrowerPosition) error
+	// CloseFactor returns the share of debt that can be repaid in one liquidation, in basis points
+	CloseFactor(healthFactor float64) uint16
+	EncodeLiquidation(params *LiquidationParams) ([]*SwapCalldata, error)
+}
+
+// LendingAsset describes a token listed on a lending market
+type LendingAsset struct {
+	Token                common.Address
+	Symbol               string
+	Decimals             uint8
+	Market               common.Address // Moonwell mToken; unused by Aave and Compound
+	PriceFeed            common.Address // Chainlink aggregator emitting AnswerUpdated; zero prices through the DEX price oracle
+	PriceFeedDecimals    uint8
+	LiquidationThreshold uint16 // Basis points of collateral value counted against debt
+	LiquidationBonus     uint16 // Basis points of extra collateral paid to the liquidator
+}
+
+// BorrowerPosition holds a borrower's balances in raw units of the underlying tokens
+type BorrowerPosition struct {
+	Protocol   LendingProtocol
+	Market     common.Address
+	Borrower   common.Address
+	Collateral map[common.Address]*big.Int
+	Debt       map[common.Address]*big.Int
+	// Incomplete is set when a balance could not be reconstructed from events,
+	// for example collateral deposited before indexing started
+	Incomplete bool
+}
+
+// LiquidationParams describes a single liquidation to encode
+type LiquidationParams struct {
+	Borrower        common.Address
+	DebtAsset       common.Address
+	CollateralAsset common.Address
+	RepayAmount     *big.Int
+	MinSeizeAmount  *big.Int
+	Liquidator      common.Address
+}
+
+// FlashLoanPlan describes the flash loan that funds an opportunity
+type FlashLoanPlan struct {
+	Provider    string         // A FlashLoanSource
+	Pool        common.Address // Contract lent from
+	Asset       common.Address
+	Amount      *big.Int
+	Fee         *big.Int
+	Available   *big.Int // Lendable liquidity when quoted; nil if not checked
+	GasOverhead uint64   // Extra gas of borrowing and repaying
+}
+
+// LendingProtocol identifies a lending protocol
+type LendingProtocol string
+
+const (
+	LendingAaveV3     LendingProtocol = "aave_v3"
+	LendingCompoundV3 LendingProtocol = "compound_v3"
+	LendingMoonwell   LendingProtocol = "moonwell"
+)
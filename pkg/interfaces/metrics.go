@@ -4,6 +4,8 @@ import (
 	"context"
 	"math/big"
 	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // MetricsCollector collects performance and profitability metrics
@@ -54,9 +56,25 @@ type TradeResult struct {
 	ExpectedProfit  *big.Int
 	GasCost         *big.Int
 	NetProfit       *big.Int
+	ProfitToken     common.Address // Token ActualProfit and ExpectedProfit are denominated in; the zero address means ETH
+	NetProfitETH    *big.Int       // Net profit in wei of ETH, filled in by the price oracle
+	NetProfitUSD    float64        // Net profit in USD, filled in by the price oracle
 	ExecutionTime   time.Duration
 	TransactionHash string
 	ErrorMessage    string
+	Mode            ExecutionMode // Paper trades are ExecutionModeSimulation
+}
+
+// NetProfitWei returns the net profit in wei of ETH, or nil when the profit is
+// in another token and has not been normalized yet
+func (t *TradeResult) NetProfitWei() *big.Int {
+	if t.NetProfitETH != nil {
+		return t.NetProfitETH
+	}
+	if t.ProfitToken == (common.Address{}) {
+		return t.NetProfit
+	}
+	return nil
 }
 
 // ProfitabilityMetrics contains profitability statistics
@@ -173,6 +191,7 @@ const (
 	AlertTypeSystem        AlertType = "system"
 	AlertTypeConnection    AlertType = "connection"
 	AlertTypeShutdown      AlertType = "shutdown"
+	AlertTypeExecutionMode AlertType = "execution_mode"
 )
 
 type AlertSeverity string
@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OracleFeed maps an on-chain price feed to the token it prices in USD
+type OracleFeed struct {
+	Source   OracleSource
+	Address  common.Address // Chainlink aggregator (not the proxy), or the Pyth contract
+	PriceID  common.Hash    // Pyth price feed ID; unused by Chainlink
+	Token    common.Address
+	Decimals uint8 // Chainlink answer decimals; Pyth updates carry their own exponent
+}
+
+// OracleUpdate is a price decoded from a pending oracle transaction
+type OracleUpdate struct {
+	Feed        *OracleFeed
+	Answer      *big.Int // Raw answer; Pyth prices are signed 64-bit values
+	Exponent    int32    // The USD price is Answer * 10^Exponent
+	PriceUSD    float64
+	PublishTime uint64 // Observation timestamp, in seconds
+}
+
+// OracleConsumers are the pools and lending markets that price off a feed's token
+type OracleConsumers struct {
+	Pools          []*PoolState
+	LendingMarkets []LendingMarket
+}
+
+// OracleSource identifies an oracle network
+type OracleSource string
+
+const (
+	OracleChainlink OracleSource = "chainlink"
+	OraclePyth      OracleSource = "pyth"
+)
@@ -0,0 +1,88 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PaperLedger fills paper trades against per-strategy virtual balances at the state
+// of the block they would have landed in, rather than the one they were simulated
+// on, so their profit and drawdown can be compared with live trading
+type PaperLedger interface {
+	// Track queues a paper trade to be filled once its modeled inclusion block is
+	// reached
+	Track(ctx context.Context, opportunity *MEVOpportunity, trade *TradeResult) error
+	// Check fills the queued trades whose inclusion block has been reached
+	Check(ctx context.Context) ([]*PaperFill, error)
+	Pending() int
+	Accounts() []*PaperAccount
+	Account(strategy StrategyType) (*PaperAccount, bool)
+	// Fills returns a strategy's most recent fills, newest first
+	Fills(strategy StrategyType, limit int) []*PaperFill
+	// Reset restores a strategy's starting balances and clears its fills and curve
+	Reset(strategy StrategyType)
+}
+
+// PaperAccount is a strategy's virtual balances and paper PnL. PnL is the sum of
+// its fills' net profit in wei of ETH, as live trades' NetProfitETH is.
+type PaperAccount struct {
+	Strategy        StrategyType                `json:"strategy"`
+	Balances        map[common.Address]*big.Int `json:"balances"` // Raw amounts; ETH is the zero address
+	PnL             *big.Int                    `json:"pnl"`
+	PeakPnL         *big.Int                    `json:"peak_pnl"`
+	Drawdown        *big.Int                    `json:"drawdown"` // Fall of PnL from its peak
+	MaxDrawdown     *big.Int                    `json:"max_drawdown"`
+	Fills           int                         `json:"fills"`
+	FailedFills     int                         `json:"failed_fills"`     // Reverted at the inclusion block
+	RejectedFills   int                         `json:"rejected_fills"`   // More input than the balances held
+	UnpricedFills   int                         `json:"unpriced_fills"`   // Not counted in PnL
+	UnrepricedFills int                         `json:"unrepriced_fills"` // Not re-simulated or valued; neither in balances nor PnL
+	Curve           []*PaperCurvePoint          `json:"curve"`
+	StartedAt       time.Time                   `json:"started_at"`
+}
+
+// PaperCurvePoint is a strategy's PnL and drawdown after a fill
+type PaperCurvePoint struct {
+	Time     time.Time `json:"time"`
+	Block    uint64    `json:"block"`
+	PnL      *big.Int  `json:"pnl"`
+	Drawdown *big.Int  `json:"drawdown"`
+}
+
+// PaperFill is a paper trade filled at its modeled inclusion block. Profits are in
+// the trade's profit token.
+type PaperFill struct {
+	TradeID        string         `json:"trade_id"`
+	OpportunityID  string         `json:"opportunity_id"`
+	Strategy       StrategyType   `json:"strategy"`
+	DetectedAt     time.Time      `json:"detected_at"`
+	DetectedBlock  uint64         `json:"detected_block"`  // Head when the trade was paper traded
+	InclusionBlock uint64         `json:"inclusion_block"` // Block it was modeled to land in
+	Latency        time.Duration  `json:"latency"`         // From detection to the inclusion block
+	Success        bool           `json:"success"`
+	Repriced       bool           `json:"repriced"` // Profit measured at the inclusion block; successful fills that weren't aren't realized
+	ProfitToken    common.Address `json:"profit_token"`
+	ExpectedProfit *big.Int       `json:"expected_profit"`
+	RealizedProfit *big.Int       `json:"realized_profit"` // Nil, as are slippage and gas cost, if unrepriced
+	Slippage       *big.Int       `json:"slippage"`        // Expected less realized
+	SlippageBps    int64          `json:"slippage_bps"`
+	GasCost        *big.Int       `json:"gas_cost"`
+	NetProfitETH   *big.Int       `json:"net_profit_eth,omitempty"` // Nil if unpriced
+	Error          string         `json:"error,omitempty"`
+	FilledAt       time.Time      `json:"filled_at"`
+}
+
+// PaperLedgerConfig holds configuration for the paper ledger
+type PaperLedgerConfig struct {
+	Executor          common.Address                               // Holds the profits; the searcher if unset
+	StartingBalances  map[common.Address]*big.Int                  // Raw amounts each strategy starts with; ETH is the zero address
+	Strategies        map[StrategyType]map[common.Address]*big.Int // Replace the starting balances
+	SubmissionLatency time.Duration                                // Modeled time from paper trading to reaching the sequencer
+	BlockTime         time.Duration
+	CurveSize         int // Points kept per strategy
+	FillHistory       int // Fills kept per strategy
+	PollInterval      time.Duration
+}
@@ -0,0 +1,107 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+var (
+	// ErrUnknownStrategy is returned for a strategy that isn't registered
+	ErrUnknownStrategy = errors.New("unknown strategy")
+	// ErrInvalidStrategyConfig is returned for settings a strategy rejects
+	ErrInvalidStrategyConfig = errors.New("invalid strategy config")
+)
+
+// StrategyInputKind is the kind of data a strategy detects opportunities in
+type StrategyInputKind string
+
+const (
+	InputTransaction StrategyInputKind = "transaction" // A pending transaction and its simulation
+	InputBatch       StrategyInputKind = "batch"       // Pending transactions considered together
+	InputBlock       StrategyInputKind = "block"       // An included block with its receipts
+	InputEvent       StrategyInputKind = "event"       // Contract logs
+)
+
+// StrategyInput is the data a strategy is dispatched with. Only the fields of its
+// kind are set.
+type StrategyInput struct {
+	Kind              StrategyInputKind
+	Transaction       *types.Transaction
+	SimulationResult  *SimulationResult
+	Transactions      []*types.Transaction
+	SimulationResults []*SimulationResult
+	Block             *HistoricalBlock
+	Logs              []*ethtypes.Log
+}
+
+// Strategy is a detection strategy plugged into the strategy processor
+type Strategy interface {
+	Name() StrategyType
+	// Inputs returns the kinds of input the strategy is dispatched with
+	Inputs() []StrategyInputKind
+	// ConfigSchema describes the settings Configure accepts, with their current values as defaults
+	ConfigSchema() *StrategyConfigSchema
+	// Configure applies settings keyed by schema field name. Either every setting is
+	// applied or, on error, none is.
+	Configure(settings map[string]interface{}) error
+	Detect(ctx context.Context, input *StrategyInput) ([]*MEVOpportunity, error)
+}
+
+// StrategyConfigType is the type of a strategy setting
+type StrategyConfigType string
+
+const (
+	ConfigTypeBool        StrategyConfigType = "bool"
+	ConfigTypeInt         StrategyConfigType = "int"
+	ConfigTypeUint        StrategyConfigType = "uint"
+	ConfigTypeFloat       StrategyConfigType = "float"
+	ConfigTypeBigInt      StrategyConfigType = "bigint" // Decimal string, usually wei
+	ConfigTypeDuration    StrategyConfigType = "duration"
+	ConfigTypeAddress     StrategyConfigType = "address"
+	ConfigTypeStringList  StrategyConfigType = "string_list"
+	ConfigTypeAddressList StrategyConfigType = "address_list"
+)
+
+// StrategyConfigField describes one strategy setting
+type StrategyConfigField struct {
+	Name    string             `json:"name"`
+	Type    StrategyConfigType `json:"type"`
+	Default interface{}        `json:"default,omitempty"`
+}
+
+// StrategyConfigSchema describes a strategy's settings, read from strategies.<name>
+type StrategyConfigSchema struct {
+	Fields []StrategyConfigField `json:"fields"`
+}
+
+// Field returns the schema field with a name
+func (s *StrategyConfigSchema) Field(name string) (StrategyConfigField, bool) {
+	for _, field := range s.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return StrategyConfigField{}, false
+}
+
+// StrategyRegistry holds the strategies the processor dispatches to
+type StrategyRegistry interface {
+	Register(strategy Strategy) error
+	Get(name StrategyType) (Strategy, bool)
+	// List returns the strategies in registration order
+	List() []Strategy
+	// Accepting returns the strategies dispatched with an input kind, in registration order
+	Accepting(kind StrategyInputKind) []Strategy
+}
+
+// StrategyState is a registered strategy's enabled state and current settings
+type StrategyState struct {
+	Name    StrategyType           `json:"name"`
+	Enabled bool                   `json:"enabled"`
+	Inputs  []StrategyInputKind    `json:"inputs"`
+	Config  map[string]interface{} `json:"config"`
+	Schema  *StrategyConfigSchema  `json:"schema"`
+}
@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// PoolRegistry indexes DEX pools discovered from factory creation events
+type PoolRegistry interface {
+	Sync(ctx context.Context) error
+	IndexLogs(ctx context.Context, logs []*ethtypes.Log) ([]*PoolInfo, error)
+	GetPool(address common.Address) (*PoolInfo, bool)
+	GetPoolsByPair(tokenA, tokenB common.Address) []*PoolInfo
+	FindPool(tokenA, tokenB common.Address, protocol Protocol, fee uint32) (*PoolInfo, bool)
+	FindPools(filter *PoolFilter) []*PoolInfo
+	UpdateLiquidity(ctx context.Context, pool common.Address, tvl *big.Int) error
+	GetStats() PoolRegistryStats
+}
+
+// PoolInfo describes a single pool or pair created by a known factory
+type PoolInfo struct {
+	Address      common.Address
+	Protocol     Protocol
+	Factory      common.Address
+	Token0       common.Address
+	Token1       common.Address
+	Fee          uint32 // In hundredths of a basis point (3000 = 0.3%)
+	TickSpacing  int32  // For Uniswap V3
+	Stable       bool   // For Aerodrome
+	CreatedBlock uint64
+	CreatedTx    common.Hash
+	TVL          *big.Int // Total value locked in wei, nil if unknown
+	UpdatedAt    time.Time
+}
+
+// HasToken reports whether the pool trades the given token
+func (p *PoolInfo) HasToken(token common.Address) bool {
+	return p.Token0 == token || p.Token1 == token
+}
+
+// PoolFilter narrows pool registry lookups
+type PoolFilter struct {
+	Token    *common.Address
+	Protocol Protocol // ProtocolUnknown matches any protocol
+	Fee      *uint32
+	MinTVL   *big.Int // nil applies the registry's configured TVL floor
+	Exclude  []common.Address
+}
+
+// PoolRegistryStats provides statistics about the pool registry
+type PoolRegistryStats struct {
+	TotalPools      int
+	LiquidPools     int
+	PoolsByProtocol map[Protocol]int
+	LastSyncedBlock map[common.Address]uint64 // keyed by factory address
+	LastSyncAt      time.Time
+}
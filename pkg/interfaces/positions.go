@@ -0,0 +1,63 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CapitalSource reports the inventory strategies may size trades with
+type CapitalSource interface {
+	// AvailableCapital returns the raw amount of a token available to trade, or nil
+	// while our inventory is not yet known
+	AvailableCapital(token common.Address) *big.Int
+}
+
+// PositionTracker maintains the token balances of our searcher account and executor
+// contract, from the receipts of our landed bundles between on-chain reconciliations
+type PositionTracker interface {
+	CapitalSource
+	// Apply updates balances from a final bundle's receipts and the value it sent
+	Apply(result *InclusionResult)
+	// Reconcile reloads balances from the chain
+	Reconcile(ctx context.Context) error
+	// Positions returns our non-zero balances, marked to market where priced
+	Positions() []*Position
+	// Summary totals the positions' values
+	Summary() *PositionSummary
+}
+
+// Position is an account's balance of a token. ETH is the zero address.
+type Position struct {
+	Account  common.Address `json:"account"`
+	Token    common.Address `json:"token"`
+	Amount   *big.Int       `json:"amount"`
+	ValueETH *big.Int       `json:"value_eth,omitempty"` // Nil when the token can't be priced
+	ValueUSD float64        `json:"value_usd"`
+	OpenedAt time.Time      `json:"opened_at"`          // When the balance last became non-zero
+	Strategy StrategyType   `json:"strategy,omitempty"` // Strategy of the trade that opened the position
+	Residual bool           `json:"residual"`           // Outside our inventory tokens, e.g. left by a partial fill
+}
+
+// PositionSummary totals our positions
+type PositionSummary struct {
+	TotalPositions   int                 `json:"total_positions"`
+	TotalValueETH    *big.Int            `json:"total_value_eth"` // Of the priced positions
+	TotalValueUSD    float64             `json:"total_value_usd"`
+	PositionsByToken map[string]*big.Int `json:"positions_by_token"` // Amount held across accounts
+	LargestPosition  *Position           `json:"largest_position,omitempty"`
+	Residual         []*Position         `json:"residual"`
+	Positions        []*Position         `json:"positions"`
+	ReconciledAt     time.Time           `json:"reconciled_at"`
+}
+
+// PositionTrackerConfig holds configuration for the position tracker
+type PositionTrackerConfig struct {
+	Searcher          common.Address
+	Executor          common.Address   // Holds the trading inventory; the searcher does if unset
+	InventoryTokens   []common.Address // Tokens we mean to hold; ETH is the zero address
+	ResidualDust      *big.Int         // Residual positions worth less, in wei of ETH, aren't flagged
+	ReconcileInterval time.Duration
+}
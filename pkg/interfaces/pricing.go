@@ -0,0 +1,54 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TokenRegistry holds the ERC-20 metadata needed to compare amounts across tokens
+type TokenRegistry interface {
+	Register(token *TokenInfo) error
+	GetToken(address common.Address) (*TokenInfo, bool)
+	GetTokenBySymbol(symbol string) (*TokenInfo, bool)
+	Resolve(ctx context.Context, address common.Address) (*TokenInfo, error)
+	Tokens() []*TokenInfo
+}
+
+// TokenInfo describes an ERC-20 token
+type TokenInfo struct {
+	Address       common.Address
+	Symbol        string
+	Decimals      uint8
+	FeeOnTransfer bool // Transfers deliver less than the amount sent
+	Rebasing      bool // Balances change without transfers, so pool reserves drift from balances
+	Stablecoin    bool // Pegged to USD; anchors USD pricing
+}
+
+// PriceOracle derives ETH and USD prices from a mirror of pool state
+type PriceOracle interface {
+	// UpdatePool stores the latest state of a pool used for pricing
+	UpdatePool(state *PoolState) error
+	// ApplyEvent refreshes a mirrored pool from a decoded Sync or Swap event
+	ApplyEvent(event *ParsedEvent) error
+	// Fork returns an independent copy of the mirror for pricing hypothetical state,
+	// such as the effect of a pending swap
+	Fork() PriceOracle
+	// Pools returns copies of the mirrored pools that trade a token
+	Pools(token common.Address) []*PoolState
+
+	// PriceETH returns the value of one whole token in wei of ETH
+	PriceETH(token common.Address) (*big.Int, error)
+	// PriceUSD returns the value of one whole token in USD
+	PriceUSD(token common.Address) (float64, error)
+	// ValueETH converts a raw token amount to wei of ETH
+	ValueETH(token common.Address, amount *big.Int) (*big.Int, error)
+	// ValueUSD converts a raw token amount to USD
+	ValueUSD(token common.Address, amount *big.Int) (float64, error)
+
+	// NormalizeOpportunity fills the ETH and USD profit fields of an opportunity
+	NormalizeOpportunity(opportunity *MEVOpportunity) error
+	// NormalizeTrade fills the ETH and USD profit fields of a trade result
+	NormalizeTrade(trade *TradeResult) error
+}
@@ -0,0 +1,82 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+)
+
+// ProgressionController decides when a strategy's execution mode can advance from
+// simulation to hybrid to live, and when it should fall back
+type ProgressionController interface {
+	// Evaluate measures a strategy's trades in its current mode against the
+	// requirements of the next mode. It doesn't change modes; with auto progression
+	// on the controller advances or demotes strategies on its own schedule.
+	Evaluate(ctx context.Context, strategy StrategyType) (*ExecutionProgression, error)
+	// Progressions evaluates every strategy with trades or configured requirements
+	// without changing modes
+	Progressions(ctx context.Context) ([]*ExecutionProgression, error)
+	AutoProgression() bool
+	SetAutoProgression(enabled bool)
+}
+
+// ExecutionProgression is a strategy's standing against the requirements of the
+// next execution mode
+type ExecutionProgression struct {
+	Strategy          StrategyType              `json:"strategy"`
+	CurrentMode       ExecutionMode             `json:"currentMode"`
+	CanAdvance        bool                      `json:"canAdvance"`
+	NextMode          ExecutionMode             `json:"nextMode,omitempty"`
+	ShouldDemote      bool                      `json:"shouldDemote"` // The current mode's own requirements are no longer met
+	Requirements      []*ProgressionRequirement `json:"requirements"`
+	TimeInCurrentMode float64                   `json:"timeInCurrentMode"` // Days
+	ModeSince         time.Time                 `json:"modeSince"`
+	Transition        *ModeTransition           `json:"transition,omitempty"` // Made by this evaluation
+	EvaluatedAt       time.Time                 `json:"evaluatedAt"`
+}
+
+// ProgressionRequirement is one gate on advancing a strategy's mode
+type ProgressionRequirement struct {
+	Name        string  `json:"name"`
+	Current     float64 `json:"current"`
+	Required    float64 `json:"required"`
+	Unit        string  `json:"unit"`
+	Met         bool    `json:"met"`
+	Description string  `json:"description"`
+}
+
+// ModeTransition is a change of a strategy's execution mode
+type ModeTransition struct {
+	Strategy StrategyType  `json:"strategy"`
+	From     ExecutionMode `json:"from"`
+	To       ExecutionMode `json:"to"`
+	Reason   string        `json:"reason"`
+	At       time.Time     `json:"at"`
+}
+
+// ProgressionThresholds gate a strategy's entry into a mode. They are measured
+// over the trades it made in the mode below, and keep being measured once it has
+// advanced; zero values don't gate.
+type ProgressionThresholds struct {
+	MinSamples     int           // Trades in the measured mode
+	MinSuccessRate float64       // Share of those trades that succeeded, 0-1
+	MinAccuracy    float64       // Prediction accuracy from the PerformanceValidator, 0-1
+	MaxDrawdown    *big.Int      // Largest fall in cumulative net profit, in wei of ETH
+	MinTimeInMode  time.Duration // Before advancing
+}
+
+// ProgressionStages holds the thresholds for entering hybrid and live mode
+type ProgressionStages struct {
+	Hybrid ProgressionThresholds
+	Live   ProgressionThresholds
+}
+
+// ProgressionConfig holds configuration for the progression controller
+type ProgressionConfig struct {
+	AutoProgression    bool
+	MaxMode            ExecutionMode // Highest mode auto progression advances to
+	Window             time.Duration // Trades older than this aren't measured
+	EvaluationInterval time.Duration
+	Default            ProgressionStages
+	Strategies         map[StrategyType]ProgressionStages // Replace the default stages
+}
@@ -0,0 +1,77 @@
+package interfaces
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ProtocolAdapter bundles everything the engine needs to support a DEX venue:
+// contract ABIs, event decoding, pool math and swap calldata encoding
+type ProtocolAdapter interface {
+	Protocol() Protocol
+	// ForkOf returns the protocol whose event layouts this venue reuses, or
+	// ProtocolUnknown when its events are unique. Logs from forks can only be
+	// attributed by pool address.
+	ForkOf() Protocol
+	ABIs() map[ContractType]string
+	DecodeLog(log *ethtypes.Log) (*ParsedEvent, error)
+	GetAmountOut(pool *PoolState, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error)
+	EncodeSwap(params *SwapParams) (*SwapCalldata, error)
+}
+
+// ProtocolAdapterRegistry dispatches logs and pool operations to protocol adapters
+type ProtocolAdapterRegistry interface {
+	Register(adapter ProtocolAdapter) error
+	Adapter(protocol Protocol) (ProtocolAdapter, bool)
+	Adapters() []ProtocolAdapter
+	RegisterPool(pool common.Address, protocol Protocol)
+	DecodeLog(log *ethtypes.Log) (*ParsedEvent, error)
+}
+
+// PoolState is the on-chain state an adapter needs to quote a swap
+type PoolState struct {
+	Protocol      Protocol
+	Address       common.Address
+	PoolID        common.Hash      // For Uniswap V4 singleton pools
+	Tokens        []common.Address // token0/token1, or Curve coins in pool order
+	Reserves      []*big.Int       // V2 reserves or Curve balances, same order as Tokens
+	Fee           uint32           // Hundredths of a bip (3000 = 0.3%)
+	SqrtPriceX96  *big.Int         // For concentrated liquidity pools
+	Liquidity     *big.Int         // For concentrated liquidity pools
+	Tick          int32            // For concentrated liquidity pools
+	TickSpacing   int32            // For concentrated liquidity pools
+	Hooks         common.Address   // For Uniswap V4
+	Amplification *big.Int         // For Curve, the A parameter
+	Rates         []*big.Int       // For Curve, 1e18-scaled decimal multipliers; nil if all coins share decimals
+}
+
+// TokenIndex returns the position of a token in the pool, or -1
+func (p *PoolState) TokenIndex(token common.Address) int {
+	for i, t := range p.Tokens {
+		if t == token {
+			return i
+		}
+	}
+	return -1
+}
+
+// SwapParams describes an exact-input swap to encode
+type SwapParams struct {
+	Pool         *PoolState
+	TokenIn      common.Address
+	TokenOut     common.Address
+	AmountIn     *big.Int
+	AmountOutMin *big.Int
+	Recipient    common.Address
+	Deadline     *big.Int // Unix timestamp, ignored by routers without deadlines
+	HookData     []byte   // For Uniswap V4
+}
+
+// SwapCalldata is an encoded call ready to be wrapped in a transaction
+type SwapCalldata struct {
+	To    common.Address
+	Data  []byte
+	Value *big.Int
+}
@@ -0,0 +1,80 @@
+package interfaces
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// RiskEngine checks bundles against exposure limits before they are submitted and
+// tracks the exposure of those it approved
+type RiskEngine interface {
+	// Approve checks a bundle against the global and strategy limits. An approved
+	// bundle counts as in flight until it is settled or cancelled; a rejected one is
+	// audited and returned as a *RiskRejection.
+	Approve(ctx context.Context, opportunity *MEVOpportunity, bundle []*types.Transaction) error
+	// Cancel releases an approved bundle that wasn't submitted
+	Cancel(opportunityID string)
+	// Settle releases an approved bundle once its trade is final, counting the
+	// trade's gas and net profit against the limits
+	Settle(trade *TradeResult)
+	// Limits returns a copy of the current limits
+	Limits() *RiskConfig
+	// UpdateLimits replaces the limits; bundles already approved are unaffected
+	UpdateLimits(config *RiskConfig) error
+	// Rejections returns up to limit of the most recent rejections, newest first
+	Rejections(limit int) []*RiskRejection
+	// Exposure returns what the limits are currently measured against
+	Exposure() *RiskExposure
+}
+
+// RiskLimits bounds the bundles submitted. Nil amounts and zero counts are unlimited;
+// amounts are in wei of ETH.
+type RiskLimits struct {
+	MaxNotional    *big.Int         `json:"max_notional,omitempty"`     // Largest input of a single trade
+	MaxDailyVolume *big.Int         `json:"max_daily_volume,omitempty"` // Notional approved over the last 24 hours
+	MaxGasPerHour  *big.Int         `json:"max_gas_per_hour,omitempty"` // Gas spent over the last hour
+	MaxDailyLoss   *big.Int         `json:"max_daily_loss,omitempty"`   // Net realized loss over the last 24 hours
+	MaxInFlight    int              `json:"max_in_flight,omitempty"`    // Bundles approved but not yet final
+	AllowedTokens  []common.Address `json:"allowed_tokens,omitempty"`   // If set, bundles may only trade these tokens
+	DeniedTokens   []common.Address `json:"denied_tokens,omitempty"`
+}
+
+// RiskConfig holds the global limits and each strategy's own, which apply on top
+type RiskConfig struct {
+	Global     RiskLimits                  `json:"global"`
+	Strategies map[StrategyType]RiskLimits `json:"strategies,omitempty"`
+}
+
+// RiskRejection is an audited refusal to submit a bundle
+type RiskRejection struct {
+	Time          time.Time    `json:"time"`
+	OpportunityID string       `json:"opportunity_id"`
+	Strategy      StrategyType `json:"strategy"`
+	Scope         string       `json:"scope"` // "global", or the strategy whose limit was hit
+	Limit         string       `json:"limit"` // JSON name of the limit, e.g. max_notional
+	Reason        string       `json:"reason"`
+}
+
+func (r *RiskRejection) Error() string {
+	return fmt.Sprintf("risk limit %s (%s): %s", r.Limit, r.Scope, r.Reason)
+}
+
+// RiskUsage is what one scope's limits are measured against
+type RiskUsage struct {
+	DailyVolume *big.Int `json:"daily_volume"`
+	HourlyGas   *big.Int `json:"hourly_gas"`
+	DailyPnL    *big.Int `json:"daily_pnl"` // Net realized profit; negative is a loss
+	InFlight    int      `json:"in_flight"`
+}
+
+// RiskExposure is the current usage, globally and by strategy
+type RiskExposure struct {
+	Global     *RiskUsage                  `json:"global"`
+	Strategies map[StrategyType]*RiskUsage `json:"strategies"`
+	Rejections int                         `json:"rejections"` // Since startup
+}
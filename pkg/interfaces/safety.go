@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// TokenSafetyChecker screens tokens for honeypots, transfer taxes, max-tx limits and
+// blacklists by trading them on a fork before a strategy commits capital
+type TokenSafetyChecker interface {
+	// CheckToken round-trips a small buy and sell of the token through a pool on a fork.
+	// Reports are cached per token.
+	CheckToken(ctx context.Context, request *TokenSafetyRequest) (*TokenSafetyReport, error)
+	// Report returns the cached report for a token, if it has not expired
+	Report(token common.Address) (*TokenSafetyReport, bool)
+	// IsFeeOnTransfer reports whether a token is known to tax transfers, either from
+	// the token registry or from an earlier check
+	IsFeeOnTransfer(token common.Address) bool
+}
+
+// TokenSafetyRequest describes the round trip to simulate
+type TokenSafetyRequest struct {
+	Token      common.Address
+	QuoteToken common.Address // Token paid for the buy and received from the sell
+	Pool       *PoolState
+	Setup      []*types.Transaction // Replayed first, such as the pending pool creation
+}
+
+// TokenSafetyReport is the outcome of a buy-then-sell round trip
+type TokenSafetyReport struct {
+	Token        common.Address
+	Pool         common.Address
+	Safe         bool
+	Honeypot     bool   // The buy or sell reverted, or the sell returned nothing
+	Blacklisted  bool   // The bought tokens could not be transferred on
+	MaxTxLimited bool   // A larger buy reverted where the probe buy succeeded
+	BuyTaxBps    uint16 // Share of bought tokens that did not arrive
+	SellTaxBps   uint16 // Share of sold tokens that did not reach the pool
+	Reason       string // Why the token is unsafe; empty when safe
+	CheckedAt    time.Time
+}
+
+// FeeOnTransfer reports whether the round trip lost tokens to a transfer tax
+func (r *TokenSafetyReport) FeeOnTransfer() bool {
+	return r.BuyTaxBps > 0 || r.SellTaxBps > 0
+}
+
+// TokenSafetyConfig configures the round trip
+type TokenSafetyConfig struct {
+	Probe           common.Address // Fork account that buys and sells; must hold ETH on the fork
+	TransferProbe   common.Address // Receives a transfer of the bought tokens to detect blacklists
+	ProbeAmount     *big.Int       // Quote tokens spent on the probe buy; WETH is wrapped from the probe's ETH
+	MaxTxMultiplier int64          // The max-tx check buys this multiple of the probe amount
+	MaxTaxBps       uint16         // Buy or sell tax above which a token is unsafe
+	GasLimit        uint64         // Per simulated transaction
+	CacheTTL        time.Duration
+}
@@ -26,6 +26,8 @@ type Fork interface {
 	GetBlockNumber() (*big.Int, error)
 	GetBalance(address common.Address) (*big.Int, error)
 	Reset() error
+	// ResetToBlock re-forks the upstream chain at a block, so replays see its post-state
+	ResetToBlock(blockNumber uint64) error
 	Close() error
 	IsHealthy() bool
 }
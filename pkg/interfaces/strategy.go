@@ -5,6 +5,8 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 )
 
@@ -15,6 +17,7 @@ type StrategyEngine interface {
 	EnableStrategy(strategy StrategyType) error
 	DisableStrategy(strategy StrategyType) error
 	UpdateStrategyConfig(strategy StrategyType, config interface{}) error
+	GetStrategyStates() []*StrategyState
 }
 
 // SandwichDetector identifies sandwich attack opportunities
@@ -23,6 +26,7 @@ type SandwichDetector interface {
 	ValidateOpportunity(ctx context.Context, opportunity *SandwichOpportunity) error
 	ConstructTransactions(ctx context.Context, opportunity *SandwichOpportunity) ([]*types.Transaction, error)
 	GetConfiguration() *SandwichConfig
+	SetConfiguration(config *SandwichConfig)
 }
 
 // BackrunDetector finds arbitrage opportunities from price gaps
@@ -31,6 +35,7 @@ type BackrunDetector interface {
 	CalculateOptimalTradeSize(ctx context.Context, opportunity *BackrunOpportunity) (*big.Int, error)
 	ValidateArbitrage(ctx context.Context, opportunity *BackrunOpportunity) error
 	GetConfiguration() *BackrunConfig
+	SetConfiguration(config *BackrunConfig)
 }
 
 // FrontrunDetector detects frontrunnable high-value transactions
@@ -39,6 +44,7 @@ type FrontrunDetector interface {
 	CalculateOptimalGasPrice(ctx context.Context, targetTx *types.Transaction) (*big.Int, error)
 	ValidateProfitability(ctx context.Context, opportunity *FrontrunOpportunity) error
 	GetConfiguration() *FrontrunConfig
+	SetConfiguration(config *FrontrunConfig)
 }
 
 // TimeBanditDetector analyzes transaction reordering opportunities
@@ -46,7 +52,11 @@ type TimeBanditDetector interface {
 	DetectOpportunity(ctx context.Context, txs []*types.Transaction, simResults []*SimulationResult) (*TimeBanditOpportunity, error)
 	FindOptimalOrdering(ctx context.Context, txs []*types.Transaction) ([]*types.Transaction, error)
 	ValidateDependencies(ctx context.Context, txs []*types.Transaction) error
+	// AnalyzeHistory replays reorderings of the latest buffered blocks on a fork pinned
+	// to their parent block and reports the value the best one would have moved
+	AnalyzeHistory(ctx context.Context) (*TimeBanditAnalysis, error)
 	GetConfiguration() *TimeBanditConfig
+	SetConfiguration(config *TimeBanditConfig)
 }
 
 // CrossLayerArbitrageDetector detects arbitrage opportunities between L1 and L2
@@ -54,7 +64,44 @@ type CrossLayerArbitrageDetector interface {
 	DetectOpportunity(ctx context.Context, bridgeEvent *BridgeEvent, l1Price, l2Price *big.Int) (*CrossLayerOpportunity, error)
 	ComparePrices(ctx context.Context, token string) (*PriceComparison, error)
 	ConstructBridgeTransaction(ctx context.Context, opportunity *CrossLayerOpportunity) (*types.Transaction, error)
+	SettleWithdrawal(ctx context.Context, l2TxHash common.Hash) (*types.Transaction, *Withdrawal, error)
 	GetConfiguration() *CrossLayerConfig
+	SetConfiguration(config *CrossLayerConfig)
+}
+
+// LiquidationDetector finds undercollateralized lending positions to liquidate
+type LiquidationDetector interface {
+	ProcessLogs(ctx context.Context, logs []*ethtypes.Log) ([]*MEVOpportunity, error)
+	DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *SimulationResult) ([]*MEVOpportunity, error)
+	HealthFactor(market, borrower common.Address) (float64, error)
+	GetConfiguration() *LiquidationConfig
+	SetConfiguration(config *LiquidationConfig)
+}
+
+// JITDetector finds large Uniswap V3 swaps worth providing just-in-time liquidity for
+type JITDetector interface {
+	DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *SimulationResult) (*JITOpportunity, error)
+	ConstructBundle(ctx context.Context, opportunity *JITOpportunity) ([]*types.Transaction, error)
+	GetConfiguration() *JITConfig
+	SetConfiguration(config *JITConfig)
+}
+
+// OracleBackrunDetector finds arbitrage left behind by pending Chainlink and Pyth price pushes
+type OracleBackrunDetector interface {
+	// DecodeUpdates returns the prices a pending transaction pushes to known feeds
+	DecodeUpdates(tx *types.Transaction) ([]*OracleUpdate, error)
+	// Consumers returns the mirrored pools and lending markets priced by an update's token
+	Consumers(update *OracleUpdate) *OracleConsumers
+	DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *SimulationResult) ([]*BackrunOpportunity, error)
+	GetConfiguration() *OracleBackrunConfig
+	SetConfiguration(config *OracleBackrunConfig)
+}
+
+// SnipingDetector finds early buys into pools receiving their first liquidity
+type SnipingDetector interface {
+	DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *SimulationResult) (*SnipeOpportunity, error)
+	GetConfiguration() *SnipingConfig
+	SetConfiguration(config *SnipingConfig)
 }
 
 // MEVOpportunity represents a detected MEV opportunity
@@ -65,13 +112,29 @@ type MEVOpportunity struct {
 	ExpectedProfit  *big.Int
 	GasCost         *big.Int
 	NetProfit       *big.Int
+	ProfitToken     common.Address // Token ExpectedProfit is denominated in; the zero address means ETH
+	NetProfitETH    *big.Int       // Net profit in wei of ETH, filled in by the price oracle
+	NetProfitUSD    float64        // Net profit in USD, filled in by the price oracle
 	Confidence      float64
 	Status          OpportunityStatus
 	CreatedAt       time.Time
 	ExecutionTxs    []*types.Transaction
+	Pools           []common.Address // Pools the opportunity trades in, for conflict resolution
 	Metadata        map[string]interface{}
 }
 
+// NetProfitWei returns the net profit in wei of ETH, or nil when the profit is
+// in another token and has not been normalized yet
+func (o *MEVOpportunity) NetProfitWei() *big.Int {
+	if o.NetProfitETH != nil {
+		return o.NetProfitETH
+	}
+	if o.ProfitToken == (common.Address{}) {
+		return o.NetProfit
+	}
+	return nil
+}
+
 // SandwichOpportunity represents a sandwich attack opportunity
 type SandwichOpportunity struct {
 	TargetTx        *types.Transaction
@@ -101,6 +164,7 @@ type BackrunOpportunity struct {
 type FrontrunOpportunity struct {
 	TargetTx       *types.Transaction
 	FrontrunTx     *types.Transaction
+	TokenIn        string // Sold by the frontrun; empty for ETH
 	ExpectedProfit *big.Int
 	GasPremium     *big.Int
 	SuccessProbability float64
@@ -114,6 +178,19 @@ type TimeBanditOpportunity struct {
 	Dependencies   map[string][]string
 }
 
+// TimeBanditAnalysis measures what reordering a window of included blocks would have
+// been worth. Values are in wei of ETH and come from replaying both orders on a fork.
+type TimeBanditAnalysis struct {
+	FromBlock          uint64
+	ToBlock            uint64
+	OriginalOrder      []*types.Transaction
+	BestOrder          []*types.Transaction        // Same as OriginalOrder when no reordering gains
+	Beneficiary        common.Address              // Sender that gains most from the best order
+	ExtractableValue   *big.Int                    // Beneficiary's gain over the original order
+	Gains              map[common.Address]*big.Int // Every sender's change under the best order
+	OrderingsSimulated int
+}
+
 // CrossLayerOpportunity represents a cross-layer arbitrage opportunity
 type CrossLayerOpportunity struct {
 	BridgeEvent    *BridgeEvent
@@ -125,6 +202,69 @@ type CrossLayerOpportunity struct {
 	ExpectedProfit *big.Int
 	BridgeTx       *types.Transaction
 	Direction      ArbitrageDirection
+	Lockup         time.Duration // Until the bridged amount is usable on the other layer
+	CapitalCost    *big.Int      // Opportunity cost of the capital over the lockup, in wei
+}
+
+// LiquidationOpportunity represents an undercollateralized position to liquidate.
+// Amounts are in raw units of their token.
+type LiquidationOpportunity struct {
+	Protocol         LendingProtocol
+	Market           common.Address
+	Borrower         common.Address
+	CollateralToken  common.Address
+	DebtToken        common.Address
+	CollateralAmount *big.Int // Collateral seized
+	DebtAmount       *big.Int // Debt repaid
+	HealthFactor     float64
+	LiquidationBonus uint16 // Basis points
+	ExpectedProfit   *big.Int // In the debt token, after slippage and the flash loan fee
+	ProfitUSD        float64
+	Calls            []*SwapCalldata
+	FlashLoan        *FlashLoanPlan
+	TriggerTx        string // Pending transaction whose price move makes the position liquidatable
+}
+
+// JITOpportunity represents liquidity minted around the current tick right
+// before a large swap and burned right after it. Token amounts are raw units;
+// profits are in wei of ETH.
+type JITOpportunity struct {
+	TargetTx       *types.Transaction
+	Pool           common.Address
+	Token0         common.Address
+	Token1         common.Address
+	Fee            uint32 // In hundredths of a basis point
+	ZeroForOne     bool
+	SwapAmountIn   *big.Int
+	TickLower      int32
+	TickUpper      int32
+	Liquidity      *big.Int
+	Amount0        *big.Int // Deposited by the mint
+	Amount1        *big.Int
+	FeesEarned     *big.Int // Share of the swap fee, in wei of ETH
+	InventoryCost  *big.Int // Loss on the swapped inventory plus the risk haircut, in wei of ETH
+	ExpectedProfit *big.Int // FeesEarned less InventoryCost, before gas
+	MintTx         *types.Transaction
+	BurnTx         *types.Transaction
+	CollectTx      *types.Transaction
+}
+
+// SnipeOpportunity represents a buy placed right after a pool's first liquidity add.
+// Token amounts are raw units; the profit is in the quote token.
+type SnipeOpportunity struct {
+	TargetTx       *types.Transaction
+	Pool           common.Address
+	Protocol       Protocol
+	Token          common.Address // The newly listed token
+	QuoteToken     common.Address
+	Created        bool     // The target transaction also creates the pool
+	Liquidity      *big.Int // Quote tokens added by the target transaction
+	AmountIn       *big.Int
+	AmountOut      *big.Int // Tokens received, after the buy tax
+	ExitAmount     *big.Int // Quote tokens from selling after the expected follow-on buys, after the sell tax
+	ExpectedProfit *big.Int // ExitAmount less AmountIn, before gas
+	Safety         *TokenSafetyReport
+	BuyTx          *types.Transaction
 }
 
 // PriceComparison represents price comparison between L1 and L2
@@ -162,6 +302,8 @@ type TimeBanditConfig struct {
 	MaxBundleSize     int
 	MinProfitThreshold *big.Int
 	MaxDependencyDepth int
+	LookbackBlocks     int // Blocks reordered together by AnalyzeHistory
+	MaxOrderings       int // Alternative orders simulated per analysis
 }
 
 type CrossLayerConfig struct {
@@ -171,6 +313,48 @@ type CrossLayerConfig struct {
 	BridgeFee         *big.Int
 	MinProfitThreshold *big.Int
 	SupportedTokens   []string
+	PriceScale        *big.Int      // amount × price ÷ PriceScale is in wei; 1000 when nil
+	DepositDelay      time.Duration // Until a deposit is credited on L2
+	ProvingDelay      time.Duration // Until a withdrawal's L2 block is covered by a dispute game
+	ChallengeWindow   time.Duration // From proving a withdrawal until it can be finalized
+	CapitalCostBps    uint32        // Annual cost of capital locked in the bridge
+	SettlementGasCost *big.Int      // L1 gas to prove and finalize a withdrawal, in wei
+}
+
+type LiquidationConfig struct {
+	MinProfitUSD      float64
+	MaxSlippageBps    uint16         // Slippage allowed swapping seized collateral back to the debt token
+	FlashLoanPool     common.Address // Aave V3 pool funding the repayment
+	FlashLoanFeeBps   uint16
+	GasLimit          uint64         // Of the liquidation calls; the builder adds the loan and the collateral sale
+	ChainID           *big.Int       // Liquidations of confirmed positions are sent on; Base mainnet if nil
+}
+
+type JITConfig struct {
+	MinSwapAmount      *big.Int // Minimum swap size, in wei of ETH
+	MaxCapital         *big.Int // Most capital to mint with, in wei of ETH
+	TickRangeWidth     int32    // Width of the minted range in tick spacings
+	InventoryRiskBps   uint16   // Haircut on the inventory the swap leaves behind
+	GasLimit           uint64   // Gas of the mint, burn and collect together
+	MinProfitThreshold *big.Int // After gas, in wei of ETH
+}
+
+type OracleBackrunConfig struct {
+	Feeds              []*OracleFeed
+	PythContract       common.Address
+	MinPriceGapBps     uint16   // Gap between the new price and the pool's, after the pool fee
+	MaxTradeSize       *big.Int // In wei of ETH
+	MinProfitThreshold *big.Int // In wei of ETH
+}
+
+type SnipingConfig struct {
+	Factories          map[common.Address]Protocol // V2-style factories whose PairCreated events mark new pools
+	QuoteTokens        []common.Address            // Tokens new pools are bought with
+	MinLiquidity       *big.Int                    // Minimum quote-side liquidity, in wei of ETH
+	MaxBuyAmount       *big.Int                    // In wei of ETH
+	ExpectedBuyVolume  *big.Int                    // Buys expected after ours before exiting, in wei of ETH
+	MaxTaxBps          uint16                      // Highest buy or sell tax accepted
+	MinProfitThreshold *big.Int                    // In wei of ETH
 }
 
 // Enums
@@ -182,6 +366,9 @@ const (
 	StrategyFrontrun     StrategyType = "frontrun"
 	StrategyTimeBandit   StrategyType = "time_bandit"
 	StrategyCrossLayer   StrategyType = "cross_layer"
+	StrategyLiquidation  StrategyType = "liquidation"
+	StrategyJIT          StrategyType = "jit"
+	StrategySniping      StrategyType = "sniping"
 )
 
 type OpportunityStatus string
@@ -0,0 +1,167 @@
+package lending
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Aave V3 allows the whole debt to be repaid below this health factor
+const aaveV3CloseFactorThreshold = 0.95
+
+var aaveV3Pool = events.MustParseABI(aaveV3PoolABI)
+
+// aaveV3Market mirrors positions of an Aave V3 pool
+type aaveV3Market struct {
+	pool   common.Address
+	assets []*interfaces.LendingAsset
+	listed map[common.Address]bool
+	book   *positionBook
+}
+
+// NewAaveV3Market creates a market for an Aave V3 pool listing the given reserves.
+// Events for reserves that aren't listed are ignored.
+func NewAaveV3Market(pool common.Address, assets []*interfaces.LendingAsset) (interfaces.LendingMarket, error) {
+	if pool == (common.Address{}) {
+		return nil, fmt.Errorf("pool address is required")
+	}
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("at least one reserve is required")
+	}
+
+	listed := make(map[common.Address]bool, len(assets))
+	for _, asset := range assets {
+		listed[asset.Token] = true
+	}
+
+	return &aaveV3Market{
+		pool:   pool,
+		assets: copyAssets(assets),
+		listed: listed,
+		book:   newPositionBook(interfaces.LendingAaveV3, pool),
+	}, nil
+}
+
+// Protocol returns aave_v3
+func (m *aaveV3Market) Protocol() interfaces.LendingProtocol {
+	return interfaces.LendingAaveV3
+}
+
+// Address returns the pool address
+func (m *aaveV3Market) Address() common.Address {
+	return m.pool
+}
+
+// Assets returns the listed reserves
+func (m *aaveV3Market) Assets() []*interfaces.LendingAsset {
+	return m.assets
+}
+
+// ApplyLog updates positions from Supply, Withdraw, Borrow, Repay, LiquidationCall
+// and collateral toggle events of the pool
+func (m *aaveV3Market) ApplyLog(log *ethtypes.Log) ([]common.Address, error) {
+	if log.Address != m.pool {
+		return nil, nil
+	}
+
+	event, decoded, err := unpackLog(aaveV3Pool, interfaces.LendingAaveV3, log)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	reserve := events.DecodedAddress(decoded, "reserve")
+	var user common.Address
+
+	switch event.Name {
+	case "Supply":
+		user = events.DecodedAddress(decoded, "onBehalfOf")
+		if m.listed[reserve] {
+			m.book.addCollateral(user, reserve, events.DecodedBigInt(decoded, "amount"))
+		}
+	case "Withdraw":
+		user = events.DecodedAddress(decoded, "user")
+		if m.listed[reserve] {
+			m.book.subCollateral(user, reserve, events.DecodedBigInt(decoded, "amount"))
+		}
+	case "Borrow":
+		user = events.DecodedAddress(decoded, "onBehalfOf")
+		if m.listed[reserve] {
+			m.book.addDebt(user, reserve, events.DecodedBigInt(decoded, "amount"))
+		}
+	case "Repay":
+		user = events.DecodedAddress(decoded, "user")
+		if m.listed[reserve] {
+			amount := events.DecodedBigInt(decoded, "amount")
+			m.book.subDebt(user, reserve, amount)
+			// Repaying with aTokens burns the borrower's collateral in the same reserve
+			if events.DecodedBool(decoded, "useATokens") {
+				m.book.subCollateral(user, reserve, amount)
+			}
+		}
+	case "LiquidationCall":
+		user = events.DecodedAddress(decoded, "user")
+		if debtAsset := events.DecodedAddress(decoded, "debtAsset"); m.listed[debtAsset] {
+			m.book.subDebt(user, debtAsset, events.DecodedBigInt(decoded, "debtToCover"))
+		}
+		if collateralAsset := events.DecodedAddress(decoded, "collateralAsset"); m.listed[collateralAsset] {
+			m.book.subCollateral(user, collateralAsset, events.DecodedBigInt(decoded, "liquidatedCollateralAmount"))
+		}
+	case "ReserveUsedAsCollateralEnabled":
+		user = events.DecodedAddress(decoded, "user")
+		m.book.setCollateralEnabled(user, reserve, true)
+	case "ReserveUsedAsCollateralDisabled":
+		user = events.DecodedAddress(decoded, "user")
+		m.book.setCollateralEnabled(user, reserve, false)
+	default:
+		return nil, nil
+	}
+
+	return []common.Address{user}, nil
+}
+
+// Position returns a borrower's position
+func (m *aaveV3Market) Position(borrower common.Address) (*interfaces.BorrowerPosition, bool) {
+	return m.book.position(borrower)
+}
+
+// Borrowers returns every borrower with outstanding debt
+func (m *aaveV3Market) Borrowers() []common.Address {
+	return m.book.borrowers()
+}
+
+// SeedPosition replaces a borrower's position
+func (m *aaveV3Market) SeedPosition(position *interfaces.BorrowerPosition) error {
+	return m.book.seed(position)
+}
+
+// CloseFactor is 50%, or 100% once the health factor falls below 0.95
+func (m *aaveV3Market) CloseFactor(healthFactor float64) uint16 {
+	if healthFactor < aaveV3CloseFactorThreshold {
+		return fullCloseFactor
+	}
+	return halfCloseFactor
+}
+
+// EncodeLiquidation encodes liquidationCall, receiving the collateral as the underlying token
+func (m *aaveV3Market) EncodeLiquidation(params *interfaces.LiquidationParams) ([]*interfaces.SwapCalldata, error) {
+	if params == nil || params.RepayAmount == nil {
+		return nil, fmt.Errorf("repay amount is required")
+	}
+
+	data, err := aaveV3Pool.Pack("liquidationCall",
+		params.CollateralAsset,
+		params.DebtAsset,
+		params.Borrower,
+		params.RepayAmount,
+		false,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode liquidationCall: %w", err)
+	}
+
+	return []*interfaces.SwapCalldata{{To: m.pool, Data: data, Value: big.NewInt(0)}}, nil
+}
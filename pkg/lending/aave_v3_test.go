@@ -0,0 +1,114 @@
+package lending
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAaveMarket(t *testing.T) interfaces.LendingMarket {
+	t.Helper()
+
+	market, err := NewAaveV3Market(BaseAaveV3Pool, []*interfaces.LendingAsset{
+		{Token: testWETH, Symbol: "WETH", Decimals: 18, LiquidationThreshold: 8300, LiquidationBonus: 500},
+		{Token: testUSDC, Symbol: "USDC", Decimals: 6, LiquidationThreshold: 7800, LiquidationBonus: 500},
+	})
+	require.NoError(t, err)
+	return market
+}
+
+func TestAaveV3Market_ApplyLog(t *testing.T) {
+	market := newTestAaveMarket(t)
+	liquidator := common.HexToAddress("0x1100000000000000000000000000000000000011")
+
+	logs := [][]interface{}{
+		{"Supply", testWETH, testBorrower, testBorrower, ether(10), uint16(0)},
+		{"Borrow", testUSDC, testBorrower, testBorrower, big.NewInt(20000_000000), uint8(2), big.NewInt(0), uint16(0)},
+		{"Withdraw", testWETH, testBorrower, testBorrower, ether(1)},
+		{"Repay", testUSDC, testBorrower, testBorrower, big.NewInt(5000_000000), false},
+		{"LiquidationCall", testWETH, testUSDC, testBorrower, big.NewInt(1000_000000), ether(1), liquidator, false},
+	}
+	for _, args := range logs {
+		touched, err := market.ApplyLog(makeLog(t, aaveV3Pool, args[0].(string), BaseAaveV3Pool, args[1:]...))
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{testBorrower}, touched, args[0])
+	}
+
+	position, exists := market.Position(testBorrower)
+	require.True(t, exists)
+	assert.False(t, position.Incomplete)
+	assert.Equal(t, ether(8), position.Collateral[testWETH])
+	assert.Equal(t, big.NewInt(14000_000000), position.Debt[testUSDC])
+	assert.Equal(t, []common.Address{testBorrower}, market.Borrowers())
+
+	// Repaying with aTokens burns collateral of the same reserve
+	_, err := market.ApplyLog(makeLog(t, aaveV3Pool, "Supply", BaseAaveV3Pool, testUSDC, testBorrower, testBorrower, big.NewInt(3000_000000), uint16(0)))
+	require.NoError(t, err)
+	_, err = market.ApplyLog(makeLog(t, aaveV3Pool, "Repay", BaseAaveV3Pool, testUSDC, testBorrower, testBorrower, big.NewInt(2000_000000), true))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.Equal(t, big.NewInt(1000_000000), position.Collateral[testUSDC])
+	assert.Equal(t, big.NewInt(12000_000000), position.Debt[testUSDC])
+
+	// Collateral switched off stops counting
+	_, err = market.ApplyLog(makeLog(t, aaveV3Pool, "ReserveUsedAsCollateralDisabled", BaseAaveV3Pool, testUSDC, testBorrower))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.NotContains(t, position.Collateral, testUSDC)
+
+	// Logs from other contracts and unlisted reserves are ignored
+	other := makeLog(t, aaveV3Pool, "Supply", common.HexToAddress("0x01"), testWETH, testBorrower, testBorrower, ether(1), uint16(0))
+	touched, err := market.ApplyLog(other)
+	require.NoError(t, err)
+	assert.Nil(t, touched)
+
+	unlisted := common.HexToAddress("0x0200000000000000000000000000000000000002")
+	_, err = market.ApplyLog(makeLog(t, aaveV3Pool, "Supply", BaseAaveV3Pool, unlisted, testBorrower, testBorrower, ether(1), uint16(0)))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.NotContains(t, position.Collateral, unlisted)
+}
+
+func TestAaveV3Market_CloseFactor(t *testing.T) {
+	market := newTestAaveMarket(t)
+
+	assert.Equal(t, uint16(5000), market.CloseFactor(0.97))
+	assert.Equal(t, uint16(10000), market.CloseFactor(0.9))
+}
+
+func TestAaveV3Market_EncodeLiquidation(t *testing.T) {
+	market := newTestAaveMarket(t)
+
+	calls, err := market.EncodeLiquidation(&interfaces.LiquidationParams{
+		Borrower:        testBorrower,
+		DebtAsset:       testUSDC,
+		CollateralAsset: testWETH,
+		RepayAmount:     big.NewInt(1000_000000),
+	})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, BaseAaveV3Pool, calls[0].To)
+
+	args, err := aaveV3Pool.Methods["liquidationCall"].Inputs.Unpack(calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, testWETH, args[0])
+	assert.Equal(t, testUSDC, args[1])
+	assert.Equal(t, testBorrower, args[2])
+	assert.Equal(t, big.NewInt(1000_000000), args[3])
+	assert.Equal(t, false, args[4])
+
+	_, err = market.EncodeLiquidation(&interfaces.LiquidationParams{})
+	assert.Error(t, err)
+}
+
+func TestNewAaveV3Market_Invalid(t *testing.T) {
+	_, err := NewAaveV3Market(common.Address{}, []*interfaces.LendingAsset{{Token: testWETH}})
+	assert.Error(t, err)
+
+	_, err = NewAaveV3Market(BaseAaveV3Pool, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,327 @@
+package lending
+
+// Aave V3 Pool position events and liquidationCall
+const aaveV3PoolABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": false, "name": "user", "type": "address"},
+			{"indexed": true, "name": "onBehalfOf", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": true, "name": "referralCode", "type": "uint16"}
+		],
+		"name": "Supply",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": true, "name": "user", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Withdraw",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": false, "name": "user", "type": "address"},
+			{"indexed": true, "name": "onBehalfOf", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "interestRateMode", "type": "uint8"},
+			{"indexed": false, "name": "borrowRate", "type": "uint256"},
+			{"indexed": true, "name": "referralCode", "type": "uint16"}
+		],
+		"name": "Borrow",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": true, "name": "user", "type": "address"},
+			{"indexed": true, "name": "repayer", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"},
+			{"indexed": false, "name": "useATokens", "type": "bool"}
+		],
+		"name": "Repay",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "collateralAsset", "type": "address"},
+			{"indexed": true, "name": "debtAsset", "type": "address"},
+			{"indexed": true, "name": "user", "type": "address"},
+			{"indexed": false, "name": "debtToCover", "type": "uint256"},
+			{"indexed": false, "name": "liquidatedCollateralAmount", "type": "uint256"},
+			{"indexed": false, "name": "liquidator", "type": "address"},
+			{"indexed": false, "name": "receiveAToken", "type": "bool"}
+		],
+		"name": "LiquidationCall",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": true, "name": "user", "type": "address"}
+		],
+		"name": "ReserveUsedAsCollateralEnabled",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "reserve", "type": "address"},
+			{"indexed": true, "name": "user", "type": "address"}
+		],
+		"name": "ReserveUsedAsCollateralDisabled",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{"name": "collateralAsset", "type": "address"},
+			{"name": "debtAsset", "type": "address"},
+			{"name": "user", "type": "address"},
+			{"name": "debtToCover", "type": "uint256"},
+			{"name": "receiveAToken", "type": "bool"}
+		],
+		"name": "liquidationCall",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// Compound V3 Comet position events, absorb and buyCollateral
+const cometABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "dst", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Supply",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "src", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Withdraw",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "dst", "type": "address"},
+			{"indexed": true, "name": "asset", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "SupplyCollateral",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "src", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": true, "name": "asset", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "WithdrawCollateral",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": true, "name": "asset", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "TransferCollateral",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "absorber", "type": "address"},
+			{"indexed": true, "name": "borrower", "type": "address"},
+			{"indexed": false, "name": "basePaidOut", "type": "uint256"},
+			{"indexed": false, "name": "usdValue", "type": "uint256"}
+		],
+		"name": "AbsorbDebt",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "absorber", "type": "address"},
+			{"indexed": true, "name": "borrower", "type": "address"},
+			{"indexed": true, "name": "asset", "type": "address"},
+			{"indexed": false, "name": "collateralAbsorbed", "type": "uint256"},
+			{"indexed": false, "name": "usdValue", "type": "uint256"}
+		],
+		"name": "AbsorbCollateral",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{"name": "absorber", "type": "address"},
+			{"name": "accounts", "type": "address[]"}
+		],
+		"name": "absorb",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "asset", "type": "address"},
+			{"name": "minAmount", "type": "uint256"},
+			{"name": "baseAmount", "type": "uint256"},
+			{"name": "recipient", "type": "address"}
+		],
+		"name": "buyCollateral",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// Moonwell mToken position events and liquidateBorrow
+const moonwellMTokenABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "minter", "type": "address"},
+			{"indexed": false, "name": "mintAmount", "type": "uint256"},
+			{"indexed": false, "name": "mintTokens", "type": "uint256"}
+		],
+		"name": "Mint",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "redeemer", "type": "address"},
+			{"indexed": false, "name": "redeemAmount", "type": "uint256"},
+			{"indexed": false, "name": "redeemTokens", "type": "uint256"}
+		],
+		"name": "Redeem",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "borrower", "type": "address"},
+			{"indexed": false, "name": "borrowAmount", "type": "uint256"},
+			{"indexed": false, "name": "accountBorrows", "type": "uint256"},
+			{"indexed": false, "name": "totalBorrows", "type": "uint256"}
+		],
+		"name": "Borrow",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "payer", "type": "address"},
+			{"indexed": false, "name": "borrower", "type": "address"},
+			{"indexed": false, "name": "repayAmount", "type": "uint256"},
+			{"indexed": false, "name": "accountBorrows", "type": "uint256"},
+			{"indexed": false, "name": "totalBorrows", "type": "uint256"}
+		],
+		"name": "RepayBorrow",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "liquidator", "type": "address"},
+			{"indexed": false, "name": "borrower", "type": "address"},
+			{"indexed": false, "name": "repayAmount", "type": "uint256"},
+			{"indexed": false, "name": "mTokenCollateral", "type": "address"},
+			{"indexed": false, "name": "seizeTokens", "type": "uint256"}
+		],
+		"name": "LiquidateBorrow",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "from", "type": "address"},
+			{"indexed": true, "name": "to", "type": "address"},
+			{"indexed": false, "name": "amount", "type": "uint256"}
+		],
+		"name": "Transfer",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{"name": "borrower", "type": "address"},
+			{"name": "repayAmount", "type": "uint256"},
+			{"name": "mTokenCollateral", "type": "address"}
+		],
+		"name": "liquidateBorrow",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// Moonwell Comptroller collateral membership events
+const moonwellComptrollerABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "mToken", "type": "address"},
+			{"indexed": false, "name": "account", "type": "address"}
+		],
+		"name": "MarketEntered",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "mToken", "type": "address"},
+			{"indexed": false, "name": "account", "type": "address"}
+		],
+		"name": "MarketExited",
+		"type": "event"
+	}
+]`
+
+// Chainlink aggregator price update event
+const chainlinkAggregatorABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "current", "type": "int256"},
+			{"indexed": true, "name": "roundId", "type": "uint256"},
+			{"indexed": false, "name": "updatedAt", "type": "uint256"}
+		],
+		"name": "AnswerUpdated",
+		"type": "event"
+	}
+]`
@@ -0,0 +1,250 @@
+package lending
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+var comet = events.MustParseABI(cometABI)
+
+// compoundV3Market mirrors positions of a Compound V3 Comet market. Accounts
+// hold a signed balance of the base asset, negative when borrowing, plus
+// collateral balances that only count against base debt.
+type compoundV3Market struct {
+	comet      common.Address
+	base       *interfaces.LendingAsset
+	assets     []*interfaces.LendingAsset
+	collateral map[common.Address]bool
+	book       *positionBook
+
+	mu      sync.Mutex
+	balance map[common.Address]*big.Int // Signed base balances
+}
+
+// NewCompoundV3Market creates a market for a Comet with the given base asset and
+// collateral assets. LiquidationBonus of a collateral asset is the discount
+// buyCollateral sells it at after absorption.
+func NewCompoundV3Market(cometAddress common.Address, base *interfaces.LendingAsset, collateral []*interfaces.LendingAsset) (interfaces.LendingMarket, error) {
+	if cometAddress == (common.Address{}) {
+		return nil, fmt.Errorf("comet address is required")
+	}
+	if base == nil {
+		return nil, fmt.Errorf("base asset is required")
+	}
+	if len(collateral) == 0 {
+		return nil, fmt.Errorf("at least one collateral asset is required")
+	}
+
+	listed := make(map[common.Address]bool, len(collateral))
+	for _, asset := range collateral {
+		if asset.Token == base.Token {
+			return nil, fmt.Errorf("base asset %s can't be collateral", base.Token.Hex())
+		}
+		listed[asset.Token] = true
+	}
+
+	assets := copyAssets(append([]*interfaces.LendingAsset{base}, collateral...))
+	return &compoundV3Market{
+		comet:      cometAddress,
+		base:       assets[0],
+		assets:     assets,
+		collateral: listed,
+		book:       newPositionBook(interfaces.LendingCompoundV3, cometAddress),
+		balance:    make(map[common.Address]*big.Int),
+	}, nil
+}
+
+// Protocol returns compound_v3
+func (m *compoundV3Market) Protocol() interfaces.LendingProtocol {
+	return interfaces.LendingCompoundV3
+}
+
+// Address returns the Comet address
+func (m *compoundV3Market) Address() common.Address {
+	return m.comet
+}
+
+// Assets returns the base asset followed by the collateral assets
+func (m *compoundV3Market) Assets() []*interfaces.LendingAsset {
+	return m.assets
+}
+
+// ApplyLog updates positions from base and collateral supply, withdraw,
+// transfer and absorb events of the Comet
+func (m *compoundV3Market) ApplyLog(log *ethtypes.Log) ([]common.Address, error) {
+	if log.Address != m.comet {
+		return nil, nil
+	}
+
+	event, decoded, err := unpackLog(comet, interfaces.LendingCompoundV3, log)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	// The absorb events carry no amount
+	amount := events.DecodedBigInt(decoded, "amount")
+	if amount == nil {
+		amount = new(big.Int)
+	}
+	asset := events.DecodedAddress(decoded, "asset")
+
+	switch event.Name {
+	case "Supply":
+		dst := events.DecodedAddress(decoded, "dst")
+		m.adjustBase(dst, amount)
+		return []common.Address{dst}, nil
+	case "Withdraw":
+		src := events.DecodedAddress(decoded, "src")
+		m.adjustBase(src, new(big.Int).Neg(amount))
+		return []common.Address{src}, nil
+	case "Transfer":
+		// Transfers from or to the zero address mirror the principal change of a
+		// Supply or Withdraw that has already been applied
+		from, to := events.DecodedAddress(decoded, "from"), events.DecodedAddress(decoded, "to")
+		if from == (common.Address{}) || to == (common.Address{}) {
+			return nil, nil
+		}
+		m.adjustBase(from, new(big.Int).Neg(amount))
+		m.adjustBase(to, amount)
+		return []common.Address{from, to}, nil
+	case "SupplyCollateral":
+		dst := events.DecodedAddress(decoded, "dst")
+		if m.collateral[asset] {
+			m.book.addCollateral(dst, asset, amount)
+		}
+		return []common.Address{dst}, nil
+	case "WithdrawCollateral":
+		src := events.DecodedAddress(decoded, "src")
+		if m.collateral[asset] {
+			m.book.subCollateral(src, asset, amount)
+		}
+		return []common.Address{src}, nil
+	case "TransferCollateral":
+		from, to := events.DecodedAddress(decoded, "from"), events.DecodedAddress(decoded, "to")
+		if m.collateral[asset] {
+			m.book.subCollateral(from, asset, amount)
+			m.book.addCollateral(to, asset, amount)
+		}
+		return []common.Address{from, to}, nil
+	case "AbsorbDebt":
+		borrower := events.DecodedAddress(decoded, "borrower")
+		m.setBase(borrower, new(big.Int))
+		return []common.Address{borrower}, nil
+	case "AbsorbCollateral":
+		borrower := events.DecodedAddress(decoded, "borrower")
+		m.book.setCollateral(borrower, asset, nil)
+		return []common.Address{borrower}, nil
+	}
+
+	return nil, nil
+}
+
+// adjustBase changes an account's signed base balance
+func (m *compoundV3Market) adjustBase(account common.Address, delta *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	balance := new(big.Int)
+	if current, exists := m.balance[account]; exists {
+		balance.Set(current)
+	}
+	m.setBaseLocked(account, balance.Add(balance, delta))
+}
+
+// setBase replaces an account's signed base balance
+func (m *compoundV3Market) setBase(account common.Address, balance *big.Int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.setBaseLocked(account, balance)
+}
+
+func (m *compoundV3Market) setBaseLocked(account common.Address, balance *big.Int) {
+	if balance.Sign() == 0 {
+		delete(m.balance, account)
+	} else {
+		m.balance[account] = balance
+	}
+
+	// Only a negative base balance is debt; supplied base is not collateral
+	debt := new(big.Int)
+	if balance.Sign() < 0 {
+		debt.Neg(balance)
+	}
+	m.book.setDebt(account, m.base.Token, debt)
+}
+
+// Position returns a borrower's position
+func (m *compoundV3Market) Position(borrower common.Address) (*interfaces.BorrowerPosition, bool) {
+	return m.book.position(borrower)
+}
+
+// Borrowers returns every account with a negative base balance
+func (m *compoundV3Market) Borrowers() []common.Address {
+	return m.book.borrowers()
+}
+
+// SeedPosition replaces a borrower's position. Debt may only be in the base asset.
+func (m *compoundV3Market) SeedPosition(position *interfaces.BorrowerPosition) error {
+	if position == nil {
+		return fmt.Errorf("position is required")
+	}
+	for token := range position.Debt {
+		if token != m.base.Token {
+			return fmt.Errorf("debt in %s is not the base asset", token.Hex())
+		}
+	}
+
+	if err := m.book.seed(position); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if debt, exists := position.Debt[m.base.Token]; exists && debt.Sign() > 0 {
+		m.balance[position.Borrower] = new(big.Int).Neg(debt)
+	} else {
+		delete(m.balance, position.Borrower)
+	}
+	return nil
+}
+
+// CloseFactor is always 100%: absorption takes over the whole position
+func (m *compoundV3Market) CloseFactor(healthFactor float64) uint16 {
+	return fullCloseFactor
+}
+
+// EncodeLiquidation encodes absorb of the borrower followed by buyCollateral of
+// the absorbed collateral at the protocol's discount
+func (m *compoundV3Market) EncodeLiquidation(params *interfaces.LiquidationParams) ([]*interfaces.SwapCalldata, error) {
+	if params == nil || params.RepayAmount == nil {
+		return nil, fmt.Errorf("repay amount is required")
+	}
+	if params.DebtAsset != m.base.Token {
+		return nil, fmt.Errorf("debt in %s is not the base asset", params.DebtAsset.Hex())
+	}
+
+	minSeize := params.MinSeizeAmount
+	if minSeize == nil {
+		minSeize = big.NewInt(0)
+	}
+
+	absorbData, err := comet.Pack("absorb", params.Liquidator, []common.Address{params.Borrower})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode absorb: %w", err)
+	}
+	buyData, err := comet.Pack("buyCollateral", params.CollateralAsset, minSeize, params.RepayAmount, params.Liquidator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode buyCollateral: %w", err)
+	}
+
+	return []*interfaces.SwapCalldata{
+		{To: m.comet, Data: absorbData, Value: big.NewInt(0)},
+		{To: m.comet, Data: buyData, Value: big.NewInt(0)},
+	}, nil
+}
@@ -0,0 +1,130 @@
+package lending
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCompoundMarket(t *testing.T) interfaces.LendingMarket {
+	t.Helper()
+
+	market, err := NewCompoundV3Market(BaseCompoundV3USDC,
+		&interfaces.LendingAsset{Token: testUSDC, Symbol: "USDC", Decimals: 6},
+		[]*interfaces.LendingAsset{{Token: testWETH, Symbol: "WETH", Decimals: 18, LiquidationThreshold: 9000, LiquidationBonus: 500}},
+	)
+	require.NoError(t, err)
+	return market
+}
+
+func TestCompoundV3Market_ApplyLog(t *testing.T) {
+	market := newTestCompoundMarket(t)
+	lender := common.HexToAddress("0x1e00000000000000000000000000000000000001")
+
+	logs := [][]interface{}{
+		{"SupplyCollateral", testBorrower, testBorrower, testWETH, ether(5)},
+		{"Withdraw", testBorrower, testBorrower, big.NewInt(8000_000000)},
+		{"Supply", testBorrower, testBorrower, big.NewInt(1000_000000)},
+	}
+	for _, args := range logs {
+		_, err := market.ApplyLog(makeLog(t, comet, args[0].(string), BaseCompoundV3USDC, args[1:]...))
+		require.NoError(t, err)
+	}
+
+	position, exists := market.Position(testBorrower)
+	require.True(t, exists)
+	assert.Equal(t, ether(5), position.Collateral[testWETH])
+	assert.Equal(t, big.NewInt(7000_000000), position.Debt[testUSDC])
+	assert.Equal(t, []common.Address{testBorrower}, market.Borrowers())
+
+	// Supplied base is not debt, and zero-address transfers mirror supplies
+	_, err := market.ApplyLog(makeLog(t, comet, "Supply", BaseCompoundV3USDC, lender, lender, big.NewInt(500_000000)))
+	require.NoError(t, err)
+	_, err = market.ApplyLog(makeLog(t, comet, "Transfer", BaseCompoundV3USDC, common.Address{}, lender, big.NewInt(500_000000)))
+	require.NoError(t, err)
+	assert.Equal(t, []common.Address{testBorrower}, market.Borrowers())
+
+	// Transferring base to the borrower repays part of the debt
+	_, err = market.ApplyLog(makeLog(t, comet, "Transfer", BaseCompoundV3USDC, lender, testBorrower, big.NewInt(500_000000)))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.Equal(t, big.NewInt(6500_000000), position.Debt[testUSDC])
+
+	// Absorption clears the debt and collateral
+	absorber := common.HexToAddress("0xab00000000000000000000000000000000000001")
+	_, err = market.ApplyLog(makeLog(t, comet, "AbsorbCollateral", BaseCompoundV3USDC, absorber, testBorrower, testWETH, ether(5), big.NewInt(0)))
+	require.NoError(t, err)
+	_, err = market.ApplyLog(makeLog(t, comet, "AbsorbDebt", BaseCompoundV3USDC, absorber, testBorrower, big.NewInt(6500_000000), big.NewInt(0)))
+	require.NoError(t, err)
+	_, exists = market.Position(testBorrower)
+	assert.False(t, exists)
+	assert.Empty(t, market.Borrowers())
+}
+
+func TestCompoundV3Market_SeedPosition(t *testing.T) {
+	market := newTestCompoundMarket(t)
+
+	require.NoError(t, market.SeedPosition(&interfaces.BorrowerPosition{
+		Borrower:   testBorrower,
+		Collateral: map[common.Address]*big.Int{testWETH: ether(1)},
+		Debt:       map[common.Address]*big.Int{testUSDC: big.NewInt(2000_000000)},
+	}))
+
+	// Later events build on the seeded base balance
+	_, err := market.ApplyLog(makeLog(t, comet, "Supply", BaseCompoundV3USDC, testBorrower, testBorrower, big.NewInt(500_000000)))
+	require.NoError(t, err)
+	position, _ := market.Position(testBorrower)
+	assert.Equal(t, big.NewInt(1500_000000), position.Debt[testUSDC])
+
+	assert.Error(t, market.SeedPosition(&interfaces.BorrowerPosition{
+		Borrower: testBorrower,
+		Debt:     map[common.Address]*big.Int{testWETH: ether(1)},
+	}))
+}
+
+func TestCompoundV3Market_EncodeLiquidation(t *testing.T) {
+	market := newTestCompoundMarket(t)
+	liquidator := common.HexToAddress("0x1100000000000000000000000000000000000011")
+
+	assert.Equal(t, uint16(10000), market.CloseFactor(0.99))
+
+	calls, err := market.EncodeLiquidation(&interfaces.LiquidationParams{
+		Borrower:        testBorrower,
+		DebtAsset:       testUSDC,
+		CollateralAsset: testWETH,
+		RepayAmount:     big.NewInt(3000_000000),
+		MinSeizeAmount:  ether(1),
+		Liquidator:      liquidator,
+	})
+	require.NoError(t, err)
+	require.Len(t, calls, 2)
+
+	absorbArgs, err := comet.Methods["absorb"].Inputs.Unpack(calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, liquidator, absorbArgs[0])
+	assert.Equal(t, []common.Address{testBorrower}, absorbArgs[1])
+
+	buyArgs, err := comet.Methods["buyCollateral"].Inputs.Unpack(calls[1].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, testWETH, buyArgs[0])
+	assert.Equal(t, ether(1), buyArgs[1])
+	assert.Equal(t, big.NewInt(3000_000000), buyArgs[2])
+	assert.Equal(t, liquidator, buyArgs[3])
+
+	_, err = market.EncodeLiquidation(&interfaces.LiquidationParams{DebtAsset: testWETH, RepayAmount: big.NewInt(1)})
+	assert.Error(t, err)
+}
+
+func TestNewCompoundV3Market_Invalid(t *testing.T) {
+	base := &interfaces.LendingAsset{Token: testUSDC}
+
+	_, err := NewCompoundV3Market(BaseCompoundV3USDC, nil, []*interfaces.LendingAsset{{Token: testWETH}})
+	assert.Error(t, err)
+
+	_, err = NewCompoundV3Market(BaseCompoundV3USDC, base, []*interfaces.LendingAsset{{Token: testUSDC}})
+	assert.Error(t, err)
+}
@@ -0,0 +1,278 @@
+// Package lending mirrors borrower positions on Base lending markets from their
+// event logs. Balances are tracked at the amounts in each event; interest
+// accrued between events is not modelled, so health factors drift slightly
+// optimistic for borrowers and are refreshed on every position change.
+// Positions are only exact when a market is indexed from its deployment block
+// or seeded from an on-chain snapshot with SeedPosition.
+package lending
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Base mainnet lending market deployments
+var (
+	BaseAaveV3Pool          = common.HexToAddress("0xA238Dd80C259a72e81d7e4664a9801593F98d1c5")
+	BaseCompoundV3USDC      = common.HexToAddress("0xb125E6687d4313864e53df431d5425969c15Eb2F")
+	BaseMoonwellComptroller = common.HexToAddress("0xfBb21d0380beE3312B33c4353c8936a0F13EF26C")
+)
+
+// Close factors in basis points
+const (
+	fullCloseFactor uint16 = 10000
+	halfCloseFactor uint16 = 5000
+)
+
+var chainlinkAggregator = events.MustParseABI(chainlinkAggregatorABI)
+
+// DecodeAnswerUpdated returns the new answer of a Chainlink AnswerUpdated log
+func DecodeAnswerUpdated(log *ethtypes.Log) (*big.Int, bool) {
+	event := chainlinkAggregator.Events["AnswerUpdated"]
+	if len(log.Topics) != 3 || log.Topics[0] != event.ID {
+		return nil, false
+	}
+
+	// int256 topics are two's complement
+	answer := new(big.Int).SetBytes(log.Topics[1].Bytes())
+	if answer.Bit(255) == 1 {
+		answer.Sub(answer, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return answer, true
+}
+
+// positionBook holds the positions of one market
+type positionBook struct {
+	protocol interfaces.LendingProtocol
+	market   common.Address
+
+	mu         sync.RWMutex
+	collateral map[common.Address]map[common.Address]*big.Int
+	debt       map[common.Address]map[common.Address]*big.Int
+	disabled   map[common.Address]map[common.Address]bool // Collateral switched off by the borrower
+	incomplete map[common.Address]bool
+}
+
+func newPositionBook(protocol interfaces.LendingProtocol, market common.Address) *positionBook {
+	return &positionBook{
+		protocol:   protocol,
+		market:     market,
+		collateral: make(map[common.Address]map[common.Address]*big.Int),
+		debt:       make(map[common.Address]map[common.Address]*big.Int),
+		disabled:   make(map[common.Address]map[common.Address]bool),
+		incomplete: make(map[common.Address]bool),
+	}
+}
+
+// addCollateral adds to a borrower's collateral balance
+func (b *positionBook) addCollateral(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.adjust(b.collateral, borrower, token, amount)
+}
+
+// subCollateral removes from a borrower's collateral balance
+func (b *positionBook) subCollateral(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if amount == nil {
+		return
+	}
+	b.adjust(b.collateral, borrower, token, new(big.Int).Neg(amount))
+}
+
+// addDebt adds to a borrower's debt balance
+func (b *positionBook) addDebt(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.adjust(b.debt, borrower, token, amount)
+}
+
+// subDebt removes from a borrower's debt balance
+func (b *positionBook) subDebt(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if amount == nil {
+		return
+	}
+	b.adjust(b.debt, borrower, token, new(big.Int).Neg(amount))
+}
+
+// setCollateral replaces a borrower's collateral balance
+func (b *positionBook) setCollateral(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.set(b.collateral, borrower, token, amount)
+}
+
+// setDebt replaces a borrower's debt balance
+func (b *positionBook) setDebt(borrower, token common.Address, amount *big.Int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.set(b.debt, borrower, token, amount)
+}
+
+// setCollateralEnabled records whether a collateral balance counts toward borrowing
+func (b *positionBook) setCollateralEnabled(borrower, token common.Address, enabled bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if enabled {
+		delete(b.disabled[borrower], token)
+		return
+	}
+	if b.disabled[borrower] == nil {
+		b.disabled[borrower] = make(map[common.Address]bool)
+	}
+	b.disabled[borrower][token] = true
+}
+
+// adjust changes a balance by a signed amount. A balance that would go negative
+// was opened before indexing started, so it is clamped and the position marked incomplete.
+func (b *positionBook) adjust(balances map[common.Address]map[common.Address]*big.Int, borrower, token common.Address, delta *big.Int) {
+	if delta == nil {
+		return
+	}
+	current := new(big.Int)
+	if balance, exists := balances[borrower][token]; exists {
+		current.Set(balance)
+	}
+	current.Add(current, delta)
+	if current.Sign() < 0 {
+		b.incomplete[borrower] = true
+		current.SetInt64(0)
+	}
+	b.set(balances, borrower, token, current)
+}
+
+func (b *positionBook) set(balances map[common.Address]map[common.Address]*big.Int, borrower, token common.Address, amount *big.Int) {
+	if amount == nil || amount.Sign() <= 0 {
+		delete(balances[borrower], token)
+		if len(balances[borrower]) == 0 {
+			delete(balances, borrower)
+		}
+		return
+	}
+	if balances[borrower] == nil {
+		balances[borrower] = make(map[common.Address]*big.Int)
+	}
+	balances[borrower][token] = new(big.Int).Set(amount)
+}
+
+// position returns a copy of a borrower's position with disabled collateral left out
+func (b *positionBook) position(borrower common.Address) (*interfaces.BorrowerPosition, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	_, hasCollateral := b.collateral[borrower]
+	_, hasDebt := b.debt[borrower]
+	if !hasCollateral && !hasDebt {
+		return nil, false
+	}
+
+	position := &interfaces.BorrowerPosition{
+		Protocol:   b.protocol,
+		Market:     b.market,
+		Borrower:   borrower,
+		Collateral: make(map[common.Address]*big.Int),
+		Debt:       make(map[common.Address]*big.Int),
+		Incomplete: b.incomplete[borrower],
+	}
+	for token, amount := range b.collateral[borrower] {
+		if !b.disabled[borrower][token] {
+			position.Collateral[token] = new(big.Int).Set(amount)
+		}
+	}
+	for token, amount := range b.debt[borrower] {
+		position.Debt[token] = new(big.Int).Set(amount)
+	}
+
+	// Debt can't be opened without collateral, so the collateral predates indexing
+	if len(position.Debt) > 0 && len(b.collateral[borrower]) == 0 {
+		position.Incomplete = true
+	}
+
+	return position, true
+}
+
+// borrowers returns every borrower with outstanding debt
+func (b *positionBook) borrowers() []common.Address {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	borrowers := make([]common.Address, 0, len(b.debt))
+	for borrower := range b.debt {
+		borrowers = append(borrowers, borrower)
+	}
+	sort.Slice(borrowers, func(i, j int) bool {
+		return borrowers[i].Hex() < borrowers[j].Hex()
+	})
+	return borrowers
+}
+
+// seed replaces a borrower's position; the seeded position is treated as complete
+func (b *positionBook) seed(position *interfaces.BorrowerPosition) error {
+	if position == nil {
+		return fmt.Errorf("position is required")
+	}
+	if position.Borrower == (common.Address{}) {
+		return fmt.Errorf("borrower address is required")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.collateral, position.Borrower)
+	delete(b.debt, position.Borrower)
+	delete(b.disabled, position.Borrower)
+	delete(b.incomplete, position.Borrower)
+	for token, amount := range position.Collateral {
+		b.set(b.collateral, position.Borrower, token, amount)
+	}
+	for token, amount := range position.Debt {
+		b.set(b.debt, position.Borrower, token, amount)
+	}
+	if position.Incomplete {
+		b.incomplete[position.Borrower] = true
+	}
+
+	return nil
+}
+
+// unpackLog finds the event matching the log's signature and decodes it. Logs
+// of events outside the ABI return a nil event.
+func unpackLog(contractABI *abi.ABI, protocol interfaces.LendingProtocol, log *ethtypes.Log) (*abi.Event, map[string]interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil, fmt.Errorf("log has no topics")
+	}
+
+	event, err := contractABI.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	decoded, err := events.UnpackEventLog(contractABI, *event, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode %s %s event: %w", protocol, event.Name, err)
+	}
+
+	return event, decoded, nil
+}
+
+// copyAssets returns copies of the configured assets
+func copyAssets(assets []*interfaces.LendingAsset) []*interfaces.LendingAsset {
+	copied := make([]*interfaces.LendingAsset, 0, len(assets))
+	for _, asset := range assets {
+		a := *asset
+		copied = append(copied, &a)
+	}
+	return copied
+}
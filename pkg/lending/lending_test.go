@@ -0,0 +1,134 @@
+package lending
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testWETH     = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	testUSDC     = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	testBorrower = common.HexToAddress("0xb000000000000000000000000000000000000001")
+)
+
+// makeLog encodes an event log with the arguments in ABI order
+func makeLog(t *testing.T, contractABI *abi.ABI, name string, address common.Address, args ...interface{}) *ethtypes.Log {
+	t.Helper()
+
+	event, exists := contractABI.Events[name]
+	require.True(t, exists, "unknown event %s", name)
+	require.Len(t, args, len(event.Inputs))
+
+	var indexed, data []interface{}
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, args[i])
+		} else {
+			data = append(data, args[i])
+		}
+	}
+
+	topics := []common.Hash{event.ID}
+	for _, arg := range indexed {
+		// MakeTopics drops the sign of negative integers
+		if n, ok := arg.(*big.Int); ok && n.Sign() < 0 {
+			topics = append(topics, common.BytesToHash(math.U256Bytes(new(big.Int).Set(n))))
+			continue
+		}
+		argTopics, err := abi.MakeTopics([]interface{}{arg})
+		require.NoError(t, err)
+		topics = append(topics, argTopics[0][0])
+	}
+
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	require.NoError(t, err)
+
+	return &ethtypes.Log{Address: address, Topics: topics, Data: packed}
+}
+
+// ether returns n whole tokens with 18 decimals
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func TestDecodeAnswerUpdated(t *testing.T) {
+	feed := common.HexToAddress("0xfeed000000000000000000000000000000000001")
+
+	tests := []struct {
+		name   string
+		answer *big.Int
+	}{
+		{"positive answer", big.NewInt(3000_00000000)},
+		{"negative answer", big.NewInt(-5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := makeLog(t, chainlinkAggregator, "AnswerUpdated", feed, tt.answer, big.NewInt(1), big.NewInt(1700000000))
+			answer, ok := DecodeAnswerUpdated(log)
+			require.True(t, ok)
+			assert.Equal(t, tt.answer, answer)
+		})
+	}
+
+	_, ok := DecodeAnswerUpdated(&ethtypes.Log{Topics: []common.Hash{{}}})
+	assert.False(t, ok)
+}
+
+func TestPositionBook(t *testing.T) {
+	book := newPositionBook(interfaces.LendingAaveV3, BaseAaveV3Pool)
+
+	book.addCollateral(testBorrower, testWETH, ether(2))
+	book.addDebt(testBorrower, testUSDC, big.NewInt(1000_000000))
+
+	position, exists := book.position(testBorrower)
+	require.True(t, exists)
+	assert.False(t, position.Incomplete)
+	assert.Equal(t, ether(2), position.Collateral[testWETH])
+	assert.Equal(t, []common.Address{testBorrower}, book.borrowers())
+
+	// Returned positions are copies
+	position.Collateral[testWETH].SetInt64(0)
+	position, _ = book.position(testBorrower)
+	assert.Equal(t, ether(2), position.Collateral[testWETH])
+
+	// Disabled collateral doesn't count
+	book.setCollateralEnabled(testBorrower, testWETH, false)
+	position, _ = book.position(testBorrower)
+	assert.Empty(t, position.Collateral)
+	book.setCollateralEnabled(testBorrower, testWETH, true)
+
+	// Withdrawing more than was indexed means history is missing
+	book.subCollateral(testBorrower, testWETH, ether(3))
+	position, _ = book.position(testBorrower)
+	assert.True(t, position.Incomplete)
+
+	// Seeding replaces the position and clears the flag
+	require.NoError(t, book.seed(&interfaces.BorrowerPosition{
+		Borrower:   testBorrower,
+		Collateral: map[common.Address]*big.Int{testWETH: ether(1)},
+	}))
+	position, _ = book.position(testBorrower)
+	assert.False(t, position.Incomplete)
+	assert.Empty(t, position.Debt)
+	assert.Empty(t, book.borrowers())
+
+	assert.Error(t, book.seed(&interfaces.BorrowerPosition{}))
+}
+
+func TestPositionBook_DebtWithoutCollateral(t *testing.T) {
+	book := newPositionBook(interfaces.LendingAaveV3, BaseAaveV3Pool)
+	book.addDebt(testBorrower, testUSDC, big.NewInt(1000_000000))
+
+	position, exists := book.position(testBorrower)
+	require.True(t, exists)
+	assert.True(t, position.Incomplete)
+}
@@ -0,0 +1,246 @@
+package lending
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+var (
+	moonwellMToken      = events.MustParseABI(moonwellMTokenABI)
+	moonwellComptroller = events.MustParseABI(moonwellComptrollerABI)
+)
+
+// moonwellMarket mirrors positions across the mTokens of a Moonwell comptroller.
+// Collateral is held as mToken balances and converted to the underlying token
+// with the exchange rate last seen in a Mint or Redeem event; balances in
+// mTokens without a known rate leave the position incomplete.
+type moonwellMarket struct {
+	comptroller common.Address
+	assets      []*interfaces.LendingAsset
+	byMToken    map[common.Address]*interfaces.LendingAsset
+	byToken     map[common.Address]*interfaces.LendingAsset
+	book        *positionBook // Collateral keyed by mToken in mToken units, debt keyed by underlying
+
+	mu    sync.RWMutex
+	rates map[common.Address]*big.Rat // Underlying per mToken
+}
+
+// NewMoonwellMarket creates a market for a Moonwell comptroller. Every asset
+// must set Market to its mToken.
+func NewMoonwellMarket(comptroller common.Address, assets []*interfaces.LendingAsset) (interfaces.LendingMarket, error) {
+	if comptroller == (common.Address{}) {
+		return nil, fmt.Errorf("comptroller address is required")
+	}
+	if len(assets) == 0 {
+		return nil, fmt.Errorf("at least one asset is required")
+	}
+
+	m := &moonwellMarket{
+		comptroller: comptroller,
+		assets:      copyAssets(assets),
+		byMToken:    make(map[common.Address]*interfaces.LendingAsset, len(assets)),
+		byToken:     make(map[common.Address]*interfaces.LendingAsset, len(assets)),
+		book:        newPositionBook(interfaces.LendingMoonwell, comptroller),
+		rates:       make(map[common.Address]*big.Rat),
+	}
+	for _, asset := range m.assets {
+		if asset.Market == (common.Address{}) {
+			return nil, fmt.Errorf("mToken address is required for %s", asset.Token.Hex())
+		}
+		m.byMToken[asset.Market] = asset
+		m.byToken[asset.Token] = asset
+	}
+
+	return m, nil
+}
+
+// Protocol returns moonwell
+func (m *moonwellMarket) Protocol() interfaces.LendingProtocol {
+	return interfaces.LendingMoonwell
+}
+
+// Address returns the comptroller address
+func (m *moonwellMarket) Address() common.Address {
+	return m.comptroller
+}
+
+// Assets returns the listed assets
+func (m *moonwellMarket) Assets() []*interfaces.LendingAsset {
+	return m.assets
+}
+
+// ApplyLog updates positions from mToken Transfer, Mint, Redeem, Borrow and
+// RepayBorrow events and comptroller market membership events
+func (m *moonwellMarket) ApplyLog(log *ethtypes.Log) ([]common.Address, error) {
+	if log.Address == m.comptroller {
+		return m.applyComptrollerLog(log)
+	}
+
+	asset, listed := m.byMToken[log.Address]
+	if !listed {
+		return nil, nil
+	}
+
+	event, decoded, err := unpackLog(moonwellMToken, interfaces.LendingMoonwell, log)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	switch event.Name {
+	case "Transfer":
+		// Mints transfer from the mToken and redemptions back to it
+		from, to := events.DecodedAddress(decoded, "from"), events.DecodedAddress(decoded, "to")
+		amount := events.DecodedBigInt(decoded, "amount")
+		var touched []common.Address
+		if from != asset.Market {
+			m.book.subCollateral(from, asset.Market, amount)
+			touched = append(touched, from)
+		}
+		if to != asset.Market {
+			m.book.addCollateral(to, asset.Market, amount)
+			touched = append(touched, to)
+		}
+		return touched, nil
+	case "Mint":
+		m.updateRate(asset.Market, events.DecodedBigInt(decoded, "mintAmount"), events.DecodedBigInt(decoded, "mintTokens"))
+		return nil, nil
+	case "Redeem":
+		m.updateRate(asset.Market, events.DecodedBigInt(decoded, "redeemAmount"), events.DecodedBigInt(decoded, "redeemTokens"))
+		return nil, nil
+	case "Borrow":
+		// accountBorrows is the borrower's total debt including accrued interest
+		borrower := events.DecodedAddress(decoded, "borrower")
+		m.book.setDebt(borrower, asset.Token, events.DecodedBigInt(decoded, "accountBorrows"))
+		return []common.Address{borrower}, nil
+	case "RepayBorrow":
+		// Also emitted by liquidateBorrow; the seized mTokens move in a Transfer
+		borrower := events.DecodedAddress(decoded, "borrower")
+		m.book.setDebt(borrower, asset.Token, events.DecodedBigInt(decoded, "accountBorrows"))
+		return []common.Address{borrower}, nil
+	}
+
+	return nil, nil
+}
+
+// applyComptrollerLog tracks which mTokens a borrower uses as collateral
+func (m *moonwellMarket) applyComptrollerLog(log *ethtypes.Log) ([]common.Address, error) {
+	event, decoded, err := unpackLog(moonwellComptroller, interfaces.LendingMoonwell, log)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	mToken := events.DecodedAddress(decoded, "mToken")
+	account := events.DecodedAddress(decoded, "account")
+	if _, listed := m.byMToken[mToken]; !listed {
+		return nil, nil
+	}
+	m.book.setCollateralEnabled(account, mToken, event.Name == "MarketEntered")
+	return []common.Address{account}, nil
+}
+
+// updateRate records the exchange rate implied by a mint or redemption
+func (m *moonwellMarket) updateRate(mToken common.Address, underlying, mTokens *big.Int) {
+	if underlying == nil || mTokens == nil || underlying.Sign() <= 0 || mTokens.Sign() <= 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rates[mToken] = new(big.Rat).SetFrac(underlying, mTokens)
+}
+
+// Position returns a borrower's position with collateral in underlying tokens.
+// Collateral the comptroller doesn't count, because the borrower exited the
+// market, is left out.
+func (m *moonwellMarket) Position(borrower common.Address) (*interfaces.BorrowerPosition, bool) {
+	position, exists := m.book.position(borrower)
+	if !exists {
+		return nil, false
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	collateral := make(map[common.Address]*big.Int, len(position.Collateral))
+	for mToken, balance := range position.Collateral {
+		rate, known := m.rates[mToken]
+		if !known {
+			position.Incomplete = true
+			continue
+		}
+		underlying := new(big.Rat).Mul(new(big.Rat).SetInt(balance), rate)
+		collateral[m.byMToken[mToken].Token] = new(big.Int).Quo(underlying.Num(), underlying.Denom())
+	}
+	position.Collateral = collateral
+
+	return position, true
+}
+
+// Borrowers returns every borrower with outstanding debt
+func (m *moonwellMarket) Borrowers() []common.Address {
+	return m.book.borrowers()
+}
+
+// SeedPosition replaces a borrower's position. Collateral is given in
+// underlying tokens, so the mToken exchange rate must already be known.
+func (m *moonwellMarket) SeedPosition(position *interfaces.BorrowerPosition) error {
+	if position == nil {
+		return fmt.Errorf("position is required")
+	}
+
+	m.mu.RLock()
+	balances := make(map[common.Address]*big.Int, len(position.Collateral))
+	for token, amount := range position.Collateral {
+		asset, listed := m.byToken[token]
+		if !listed {
+			m.mu.RUnlock()
+			return fmt.Errorf("token %s is not listed", token.Hex())
+		}
+		rate, known := m.rates[asset.Market]
+		if !known {
+			m.mu.RUnlock()
+			return fmt.Errorf("exchange rate of %s is not known yet", asset.Market.Hex())
+		}
+		mTokens := new(big.Rat).Quo(new(big.Rat).SetInt(amount), rate)
+		balances[asset.Market] = new(big.Int).Quo(mTokens.Num(), mTokens.Denom())
+	}
+	m.mu.RUnlock()
+
+	seeded := *position
+	seeded.Collateral = balances
+	return m.book.seed(&seeded)
+}
+
+// CloseFactor is the comptroller's 50%
+func (m *moonwellMarket) CloseFactor(healthFactor float64) uint16 {
+	return halfCloseFactor
+}
+
+// EncodeLiquidation encodes liquidateBorrow on the debt asset's mToken
+func (m *moonwellMarket) EncodeLiquidation(params *interfaces.LiquidationParams) ([]*interfaces.SwapCalldata, error) {
+	if params == nil || params.RepayAmount == nil {
+		return nil, fmt.Errorf("repay amount is required")
+	}
+
+	debt, listed := m.byToken[params.DebtAsset]
+	if !listed {
+		return nil, fmt.Errorf("debt asset %s is not listed", params.DebtAsset.Hex())
+	}
+	collateral, listed := m.byToken[params.CollateralAsset]
+	if !listed {
+		return nil, fmt.Errorf("collateral asset %s is not listed", params.CollateralAsset.Hex())
+	}
+
+	data, err := moonwellMToken.Pack("liquidateBorrow", params.Borrower, params.RepayAmount, collateral.Market)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode liquidateBorrow: %w", err)
+	}
+
+	return []*interfaces.SwapCalldata{{To: debt.Market, Data: data, Value: big.NewInt(0)}}, nil
+}
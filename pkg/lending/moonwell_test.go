@@ -0,0 +1,124 @@
+package lending
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testMWETH = common.HexToAddress("0x6800000000000000000000000000000000000001")
+	testMUSDC = common.HexToAddress("0x6800000000000000000000000000000000000002")
+)
+
+func newTestMoonwellMarket(t *testing.T) interfaces.LendingMarket {
+	t.Helper()
+
+	market, err := NewMoonwellMarket(BaseMoonwellComptroller, []*interfaces.LendingAsset{
+		{Token: testWETH, Symbol: "WETH", Decimals: 18, Market: testMWETH, LiquidationThreshold: 8100, LiquidationBonus: 700},
+		{Token: testUSDC, Symbol: "USDC", Decimals: 6, Market: testMUSDC, LiquidationThreshold: 8800, LiquidationBonus: 700},
+	})
+	require.NoError(t, err)
+	return market
+}
+
+func TestMoonwellMarket_ApplyLog(t *testing.T) {
+	market := newTestMoonwellMarket(t)
+
+	// Minting 2 WETH for 100 mWETH sets a rate of 0.02 WETH per mWETH
+	_, err := market.ApplyLog(makeLog(t, moonwellMToken, "Transfer", testMWETH, testMWETH, testBorrower, big.NewInt(100e8)))
+	require.NoError(t, err)
+
+	position, exists := market.Position(testBorrower)
+	require.True(t, exists)
+	assert.True(t, position.Incomplete, "no exchange rate seen yet")
+
+	_, err = market.ApplyLog(makeLog(t, moonwellMToken, "Mint", testMWETH, testBorrower, ether(2), big.NewInt(100e8)))
+	require.NoError(t, err)
+	_, err = market.ApplyLog(makeLog(t, moonwellMToken, "Borrow", testMUSDC, testBorrower, big.NewInt(3000_000000), big.NewInt(3000_000000), big.NewInt(0)))
+	require.NoError(t, err)
+
+	position, _ = market.Position(testBorrower)
+	assert.False(t, position.Incomplete)
+	assert.Equal(t, ether(2), position.Collateral[testWETH])
+	assert.Equal(t, big.NewInt(3000_000000), position.Debt[testUSDC])
+
+	// RepayBorrow carries the borrower's remaining debt
+	_, err = market.ApplyLog(makeLog(t, moonwellMToken, "RepayBorrow", testMUSDC, testBorrower, testBorrower, big.NewInt(1000_000000), big.NewInt(2010_000000), big.NewInt(0)))
+	require.NoError(t, err)
+
+	// A seizure moves mTokens to the liquidator
+	liquidator := common.HexToAddress("0x1100000000000000000000000000000000000011")
+	_, err = market.ApplyLog(makeLog(t, moonwellMToken, "Transfer", testMWETH, testBorrower, liquidator, big.NewInt(25e8)))
+	require.NoError(t, err)
+
+	position, _ = market.Position(testBorrower)
+	assert.Equal(t, big.NewInt(2010_000000), position.Debt[testUSDC])
+	assert.Equal(t, new(big.Int).Div(ether(3), big.NewInt(2)), position.Collateral[testWETH])
+
+	// Exiting the market stops the mTokens counting as collateral
+	_, err = market.ApplyLog(makeLog(t, moonwellComptroller, "MarketExited", BaseMoonwellComptroller, testMWETH, testBorrower))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.Empty(t, position.Collateral)
+
+	_, err = market.ApplyLog(makeLog(t, moonwellComptroller, "MarketEntered", BaseMoonwellComptroller, testMWETH, testBorrower))
+	require.NoError(t, err)
+	position, _ = market.Position(testBorrower)
+	assert.NotEmpty(t, position.Collateral)
+}
+
+func TestMoonwellMarket_SeedPosition(t *testing.T) {
+	market := newTestMoonwellMarket(t)
+	seed := &interfaces.BorrowerPosition{
+		Borrower:   testBorrower,
+		Collateral: map[common.Address]*big.Int{testWETH: ether(1)},
+		Debt:       map[common.Address]*big.Int{testUSDC: big.NewInt(1000_000000)},
+	}
+
+	assert.Error(t, market.SeedPosition(seed), "exchange rate unknown")
+
+	_, err := market.ApplyLog(makeLog(t, moonwellMToken, "Redeem", testMWETH, testBorrower, ether(1), big.NewInt(50e8)))
+	require.NoError(t, err)
+	require.NoError(t, market.SeedPosition(seed))
+
+	position, exists := market.Position(testBorrower)
+	require.True(t, exists)
+	assert.Equal(t, ether(1), position.Collateral[testWETH])
+}
+
+func TestMoonwellMarket_EncodeLiquidation(t *testing.T) {
+	market := newTestMoonwellMarket(t)
+
+	assert.Equal(t, uint16(5000), market.CloseFactor(0.5))
+
+	calls, err := market.EncodeLiquidation(&interfaces.LiquidationParams{
+		Borrower:        testBorrower,
+		DebtAsset:       testUSDC,
+		CollateralAsset: testWETH,
+		RepayAmount:     big.NewInt(1000_000000),
+	})
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, testMUSDC, calls[0].To)
+
+	args, err := moonwellMToken.Methods["liquidateBorrow"].Inputs.Unpack(calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, testBorrower, args[0])
+	assert.Equal(t, big.NewInt(1000_000000), args[1])
+	assert.Equal(t, testMWETH, args[2])
+
+	_, err = market.EncodeLiquidation(&interfaces.LiquidationParams{
+		DebtAsset:       common.HexToAddress("0x01"),
+		CollateralAsset: testWETH,
+		RepayAmount:     big.NewInt(1),
+	})
+	assert.Error(t, err)
+
+	_, err = NewMoonwellMarket(BaseMoonwellComptroller, []*interfaces.LendingAsset{{Token: testWETH}})
+	assert.Error(t, err, "mToken is required")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	mevtypes "github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 )
@@ -128,10 +129,29 @@ func (ts *TransactionStreamImpl) ProcessTransaction(ctx context.Context, rawTx [
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert raw transaction: %w", err)
 	}
+	tx.Raw = signedEncoding(resultBytes, tx.Hash)
 
 	return tx, nil
 }
 
+// signedEncoding re-encodes a transaction from its RPC JSON, signature included, so
+// it can be bundled as it was sent. It returns nil if the JSON doesn't carry a
+// signature that reproduces the transaction's hash.
+func signedEncoding(txJSON []byte, hash string) []byte {
+	var signed ethtypes.Transaction
+	if err := signed.UnmarshalJSON(txJSON); err != nil {
+		return nil
+	}
+	if !strings.EqualFold(signed.Hash().Hex(), hash) {
+		return nil
+	}
+	encoded, err := signed.MarshalBinary()
+	if err != nil {
+		return nil
+	}
+	return encoded
+}
+
 // convertRawTransaction converts a RawTransaction to our Transaction type
 func (ts *TransactionStreamImpl) convertRawTransaction(rawTx RawTransaction) (*mevtypes.Transaction, error) {
 	// Parse hash
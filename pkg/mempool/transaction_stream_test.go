@@ -7,6 +7,8 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	mevtypes "github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
@@ -653,4 +655,54 @@ func TestTransactionStreamIntegration(t *testing.T) {
 	
 	shouldProcessFiltered := stream.FilterTransaction(txFiltered)
 	assert.False(t, shouldProcessFiltered, "Transfer transaction should be filtered out")
-}
\ No newline at end of file
+}
+func TestProcessTransaction_SignedEncoding(t *testing.T) {
+	stream := NewTransactionStream(TransactionStreamConfig{})
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	to := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	signer := ethtypes.LatestSignerForChainID(big.NewInt(8453))
+	signed, err := ethtypes.SignNewTx(key, signer, &ethtypes.LegacyTx{
+		Nonce:    7,
+		GasPrice: big.NewInt(2e9),
+		Gas:      200000,
+		To:       &to,
+		Value:    big.NewInt(1e18),
+		Data:     common.FromHex("0x7ff36ab5"),
+	})
+	require.NoError(t, err)
+
+	// Subscriptions send the RPC form, with the sender alongside the signature
+	encoded, err := signed.MarshalJSON()
+	require.NoError(t, err)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(encoded, &result))
+	result["from"] = crypto.PubkeyToAddress(key.PublicKey).Hex()
+	response, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "eth_subscription",
+		"params":  map[string]interface{}{"subscription": "0x123456789", "result": result},
+	})
+	require.NoError(t, err)
+
+	tx, err := stream.ProcessTransaction(context.Background(), response)
+	require.NoError(t, err)
+	want, err := signed.MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, want, tx.Raw)
+
+	// Without a signature there's nothing to relay
+	tx, err = stream.ProcessTransaction(context.Background(), createEthSubscriptionResponse(RawTransaction{
+		Hash:     signed.Hash().Hex(),
+		From:     crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		To:       to.Hex(),
+		Value:    "0xde0b6b3a7640000",
+		GasPrice: "0x77359400",
+		Gas:      "0x30d40",
+		Nonce:    "0x7",
+		Input:    "0x7ff36ab5",
+	}))
+	require.NoError(t, err)
+	assert.Nil(t, tx.Raw)
+}
@@ -56,6 +56,7 @@ type PrometheusMetrics struct {
 	tradesTotal       prometheus.Counter
 	profitableTrades  prometheus.Counter
 	totalProfit       prometheus.Gauge
+	totalProfitUSD    prometheus.Gauge
 	tradeLatency      prometheus.Histogram
 	
 	// Strategy metrics
@@ -139,6 +140,10 @@ func (c *Collector) initPrometheusMetrics() {
 			Name: "mev_total_profit_wei",
 			Help: "Total profit in wei",
 		}),
+		totalProfitUSD: promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "mev_total_profit_usd",
+			Help: "Total profit in USD",
+		}),
 		tradeLatency: promauto.NewHistogram(prometheus.HistogramOpts{
 			Name:    "mev_trade_execution_duration_seconds",
 			Help:    "Trade execution duration in seconds",
@@ -201,6 +206,10 @@ func (c *Collector) initPrometheusMetricsWithRegistry(registry *prometheus.Regis
 			Name: "mev_total_profit_wei",
 			Help: "Total profit in wei",
 		}),
+		totalProfitUSD: factory.NewGauge(prometheus.GaugeOpts{
+			Name: "mev_total_profit_usd",
+			Help: "Total profit in USD",
+		}),
 		tradeLatency: factory.NewHistogram(prometheus.HistogramOpts{
 			Name:    "mev_trade_execution_duration_seconds",
 			Help:    "Trade execution duration in seconds",
@@ -260,7 +269,7 @@ func (c *Collector) RecordTrade(ctx context.Context, trade *interfaces.TradeResu
 	
 	// Update Prometheus metrics
 	c.prometheusMetrics.tradesTotal.Inc()
-	if trade.Success && trade.NetProfit.Cmp(big.NewInt(0)) > 0 {
+	if trade.Success && netProfitWei(trade).Cmp(big.NewInt(0)) > 0 {
 		c.prometheusMetrics.profitableTrades.Inc()
 	}
 	
@@ -345,7 +354,7 @@ func (c *Collector) GetTradeSuccessRate(windowSize int) (float64, error) {
 	
 	successfulTrades := 0
 	for _, trade := range windowTrades {
-		if trade.Success && trade.NetProfit.Cmp(big.NewInt(0)) > 0 {
+		if trade.Success && netProfitWei(trade).Cmp(big.NewInt(0)) > 0 {
 			successfulTrades++
 		}
 	}
@@ -393,16 +402,17 @@ func (c *Collector) GetProfitabilityMetrics(windowSize int) (*interfaces.Profita
 	profits := make([]*big.Int, 0, len(windowTrades))
 	
 	for _, trade := range windowTrades {
-		if trade.Success && trade.NetProfit.Cmp(big.NewInt(0)) > 0 {
+		netProfit := netProfitWei(trade)
+		if trade.Success && netProfit.Cmp(big.NewInt(0)) > 0 {
 			metrics.ProfitableTrades++
-			metrics.TotalProfit.Add(metrics.TotalProfit, trade.NetProfit)
+			metrics.TotalProfit.Add(metrics.TotalProfit, netProfit)
 			
-			if trade.NetProfit.Cmp(metrics.MaxProfit) > 0 {
-				metrics.MaxProfit.Set(trade.NetProfit)
+			if netProfit.Cmp(metrics.MaxProfit) > 0 {
+				metrics.MaxProfit.Set(netProfit)
 			}
 		} else {
 			metrics.LossTrades++
-			loss := new(big.Int).Abs(trade.NetProfit)
+			loss := new(big.Int).Abs(netProfit)
 			metrics.TotalLoss.Add(metrics.TotalLoss, loss)
 			
 			if loss.Cmp(metrics.MaxLoss) > 0 {
@@ -410,8 +420,8 @@ func (c *Collector) GetProfitabilityMetrics(windowSize int) (*interfaces.Profita
 			}
 		}
 		
-		profits = append(profits, new(big.Int).Set(trade.NetProfit))
-		metrics.NetProfit.Add(metrics.NetProfit, trade.NetProfit)
+		profits = append(profits, new(big.Int).Set(netProfit))
+		metrics.NetProfit.Add(metrics.NetProfit, netProfit)
 	}
 	
 	// Calculate rates
@@ -657,17 +667,20 @@ func (c *Collector) GetPrometheusMetrics() (map[string]interface{}, error) {
 	
 	profitableTrades := 0
 	totalProfit := big.NewInt(0)
+	totalProfitUSD := 0.0
 	
 	for _, trade := range c.trades {
-		if trade.Success && trade.NetProfit.Cmp(big.NewInt(0)) > 0 {
+		if trade.Success && netProfitWei(trade).Cmp(big.NewInt(0)) > 0 {
 			profitableTrades++
-			totalProfit.Add(totalProfit, trade.NetProfit)
+			totalProfit.Add(totalProfit, netProfitWei(trade))
+			totalProfitUSD += trade.NetProfitUSD
 		}
 	}
 	
 	metrics["profitable_trades_total"] = profitableTrades
 	totalProfitFloat, _ := totalProfit.Float64()
 	metrics["total_profit_wei"] = totalProfitFloat
+	metrics["total_profit_usd"] = totalProfitUSD
 	
 	// Opportunities metrics
 	opportunitiesByStrategy := make(map[string]int)
@@ -721,7 +734,7 @@ func (c *Collector) calculateLossRate(windowSize int) (float64, error) {
 	
 	lossTrades := 0
 	for _, trade := range windowTrades {
-		if !trade.Success || trade.NetProfit.Cmp(big.NewInt(0)) <= 0 {
+		if !trade.Success || netProfitWei(trade).Cmp(big.NewInt(0)) <= 0 {
 			lossTrades++
 		}
 	}
@@ -749,12 +762,22 @@ func (c *Collector) UpdateTotalProfit() {
 	defer c.mu.RUnlock()
 	
 	totalProfit := big.NewInt(0)
+	totalProfitUSD := 0.0
 	for _, trade := range c.trades {
-		if trade.NetProfit != nil {
-			totalProfit.Add(totalProfit, trade.NetProfit)
-		}
+		totalProfit.Add(totalProfit, netProfitWei(trade))
+		totalProfitUSD += trade.NetProfitUSD
 	}
 	
 	totalProfitFloat, _ := totalProfit.Float64()
 	c.prometheusMetrics.totalProfit.Set(totalProfitFloat)
+	c.prometheusMetrics.totalProfitUSD.Set(totalProfitUSD)
+}
+
+// netProfitWei returns a trade's net profit in wei of ETH. Trades in other
+// tokens that were never priced count as zero rather than mixing units.
+func netProfitWei(trade *interfaces.TradeResult) *big.Int {
+	if profit := trade.NetProfitWei(); profit != nil {
+		return profit
+	}
+	return big.NewInt(0)
 }
\ No newline at end of file
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
@@ -519,4 +520,31 @@ func TestCollector_PrometheusMetrics(t *testing.T) {
 	assert.Contains(t, rollingMetrics, "window_50")
 	assert.Contains(t, rollingMetrics, "window_100")
 	assert.Contains(t, rollingMetrics, "window_500")
-}
\ No newline at end of file
+}
+func TestCollector_NormalizedProfit(t *testing.T) {
+	collector := newTestCollector(nil)
+	ctx := context.Background()
+	usdc := common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+
+	trades := []*interfaces.TradeResult{
+		// ETH profit needs no normalization
+		{ID: "trade-1", Success: true, NetProfit: big.NewInt(1000)},
+		// Token profit priced by the oracle
+		{ID: "trade-2", Success: true, NetProfit: big.NewInt(5000000), ProfitToken: usdc, NetProfitETH: big.NewInt(2000), NetProfitUSD: 5},
+		// Token profit that was never priced is not mixed into wei totals
+		{ID: "trade-3", Success: true, NetProfit: big.NewInt(7000000), ProfitToken: usdc},
+	}
+	for _, trade := range trades {
+		require.NoError(t, collector.RecordTrade(ctx, trade))
+	}
+
+	metrics, err := collector.GetProfitabilityMetrics(10)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3000), metrics.NetProfit)
+	assert.Equal(t, 2, metrics.ProfitableTrades)
+
+	promMetrics, err := collector.GetPrometheusMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, 3000.0, promMetrics["total_profit_wei"])
+	assert.Equal(t, 5.0, promMetrics["total_profit_usd"])
+}
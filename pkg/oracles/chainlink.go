@@ -0,0 +1,92 @@
+// Package oracles decodes prices pushed to Chainlink and Pyth by pending
+// transactions, before the price reaches chain state.
+package oracles
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+)
+
+// OCR2 aggregator transmit, called by a transmitter with the signed report
+const ocr2AggregatorABI = `[
+	{
+		"inputs": [
+			{"name": "reportContext", "type": "bytes32[3]"},
+			{"name": "report", "type": "bytes"},
+			{"name": "rs", "type": "bytes32[]"},
+			{"name": "ss", "type": "bytes32[]"},
+			{"name": "rawVs", "type": "bytes32"}
+		],
+		"name": "transmit",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+var (
+	ocr2Aggregator = events.MustParseABI(ocr2AggregatorABI)
+
+	// ocr2Report is the median report layout: observationsTimestamp, rawObservers,
+	// sorted observations and juelsPerFeeCoin
+	ocr2Report = func() abi.Arguments {
+		uint32Type, _ := abi.NewType("uint32", "", nil)
+		bytes32Type, _ := abi.NewType("bytes32", "", nil)
+		int192SliceType, _ := abi.NewType("int192[]", "", nil)
+		int192Type, _ := abi.NewType("int192", "", nil)
+		return abi.Arguments{
+			{Name: "observationsTimestamp", Type: uint32Type},
+			{Name: "rawObservers", Type: bytes32Type},
+			{Name: "observations", Type: int192SliceType},
+			{Name: "juelsPerFeeCoin", Type: int192Type},
+		}
+	}()
+)
+
+// ChainlinkReport is the answer an OCR2 transmit will store
+type ChainlinkReport struct {
+	Answer                *big.Int
+	ObservationsTimestamp uint32
+}
+
+// IsChainlinkTransmit reports whether calldata calls an OCR2 aggregator's transmit
+func IsChainlinkTransmit(data []byte) bool {
+	return len(data) >= 4 && bytes.Equal(data[:4], ocr2Aggregator.Methods["transmit"].ID)
+}
+
+// DecodeChainlinkTransmit returns the median answer of the report in OCR2
+// transmit calldata, the value the aggregator stores as its latest answer
+func DecodeChainlinkTransmit(data []byte) (*ChainlinkReport, error) {
+	if !IsChainlinkTransmit(data) {
+		return nil, fmt.Errorf("not a transmit call")
+	}
+
+	args, err := ocr2Aggregator.Methods["transmit"].Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack transmit: %w", err)
+	}
+	report, ok := args[1].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("transmit has no report")
+	}
+
+	fields, err := ocr2Report.Unpack(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack report: %w", err)
+	}
+	timestamp, _ := fields[0].(uint32)
+	observations, _ := fields[2].([]*big.Int)
+	if len(observations) == 0 {
+		return nil, fmt.Errorf("report has no observations")
+	}
+
+	// Observations are sorted; the aggregator takes the middle one
+	return &ChainlinkReport{
+		Answer:                new(big.Int).Set(observations[len(observations)/2]),
+		ObservationsTimestamp: timestamp,
+	}, nil
+}
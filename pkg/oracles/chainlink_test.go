@@ -0,0 +1,50 @@
+package oracles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transmitCalldata encodes an OCR2 transmit of a report with the given observations
+func transmitCalldata(t *testing.T, timestamp uint32, observations ...int64) []byte {
+	t.Helper()
+
+	values := make([]*big.Int, len(observations))
+	for i, observation := range observations {
+		values[i] = big.NewInt(observation)
+	}
+	report, err := ocr2Report.Pack(timestamp, [32]byte{}, values, big.NewInt(0))
+	require.NoError(t, err)
+
+	data, err := ocr2Aggregator.Pack("transmit", [3][32]byte{}, report, [][32]byte{}, [][32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	return data
+}
+
+func TestDecodeChainlinkTransmit(t *testing.T) {
+	data := transmitCalldata(t, 1700000000, 299900000000, 300000000000, 300100000000)
+	assert.True(t, IsChainlinkTransmit(data))
+
+	report, err := DecodeChainlinkTransmit(data)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(300000000000), report.Answer)
+	assert.Equal(t, uint32(1700000000), report.ObservationsTimestamp)
+
+	// Negative answers survive int192 decoding
+	report, err = DecodeChainlinkTransmit(transmitCalldata(t, 1, -5, -4))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(-4), report.Answer)
+}
+
+func TestDecodeChainlinkTransmit_Invalid(t *testing.T) {
+	assert.False(t, IsChainlinkTransmit([]byte{0x01, 0x02}))
+
+	_, err := DecodeChainlinkTransmit([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.Error(t, err)
+
+	_, err = DecodeChainlinkTransmit(transmitCalldata(t, 1))
+	assert.Error(t, err, "a report without observations has no answer")
+}
@@ -0,0 +1,224 @@
+package oracles
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+)
+
+// BasePyth is the Pyth price feed contract on Base mainnet
+var BasePyth = common.HexToAddress("0x8250f4aF4B972684F7b336503E2D6dFeDeB1487a")
+
+// Pyth price update entry points
+const pythABI = `[
+	{
+		"inputs": [{"name": "updateData", "type": "bytes[]"}],
+		"name": "updatePriceFeeds",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	},
+	{
+		"inputs": [
+			{"name": "updateData", "type": "bytes[]"},
+			{"name": "priceIds", "type": "bytes32[]"},
+			{"name": "publishTimes", "type": "uint64[]"}
+		],
+		"name": "updatePriceFeedsIfNecessary",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+var pyth = events.MustParseABI(pythABI)
+
+const (
+	// accumulatorMagic opens every accumulator update ("PNAU")
+	accumulatorMagic = 0x504e4155
+	// accumulatorMajorVersion is the only supported major version
+	accumulatorMajorVersion = 1
+	// updateTypeWormholeMerkle proves messages against a Merkle root signed in a Wormhole VAA
+	updateTypeWormholeMerkle = 0
+	// messageTypePriceFeed is the only message type carrying a price
+	messageTypePriceFeed = 0
+	// merkleNodeSize is the size of a Merkle proof node
+	merkleNodeSize = 20
+)
+
+// PythPrice is a price feed message from an accumulator update
+type PythPrice struct {
+	PriceID     common.Hash
+	Price       int64
+	Conf        uint64
+	Expo        int32
+	PublishTime uint64
+	EMAPrice    int64
+	EMAConf     uint64
+}
+
+// IsPythUpdate reports whether calldata calls updatePriceFeeds or updatePriceFeedsIfNecessary
+func IsPythUpdate(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	for _, name := range []string{"updatePriceFeeds", "updatePriceFeedsIfNecessary"} {
+		if bytes.Equal(data[:4], pyth.Methods[name].ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodePythUpdate returns the price feed messages in updatePriceFeeds or
+// updatePriceFeedsIfNecessary calldata. Wormhole signatures and Merkle proofs
+// are not verified; the contract rejects updates that fail them.
+func DecodePythUpdate(data []byte) ([]*PythPrice, error) {
+	if !IsPythUpdate(data) {
+		return nil, fmt.Errorf("not a Pyth price update call")
+	}
+
+	method, err := pyth.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack %s: %w", method.Name, err)
+	}
+	updates, ok := args[0].([][]byte)
+	if !ok {
+		return nil, fmt.Errorf("%s has no update data", method.Name)
+	}
+
+	var prices []*PythPrice
+	for i, update := range updates {
+		decoded, err := decodeAccumulatorUpdate(update)
+		if err != nil {
+			return nil, fmt.Errorf("update %d: %w", i, err)
+		}
+		prices = append(prices, decoded...)
+	}
+	return prices, nil
+}
+
+// decodeAccumulatorUpdate parses an accumulator update: a header, a Wormhole VAA
+// with the Merkle root, then each message with its proof
+func decodeAccumulatorUpdate(update []byte) ([]*PythPrice, error) {
+	r := &byteReader{data: update}
+
+	if r.uint32() != accumulatorMagic {
+		return nil, fmt.Errorf("not an accumulator update")
+	}
+	if major := r.uint8(); major != accumulatorMajorVersion {
+		return nil, fmt.Errorf("unsupported accumulator version %d", major)
+	}
+	r.uint8() // Minor versions only append to the trailing header
+	r.skip(int(r.uint8()))
+	if updateType := r.uint8(); updateType != updateTypeWormholeMerkle {
+		return nil, fmt.Errorf("unsupported update type %d", updateType)
+	}
+	r.skip(int(r.uint16())) // VAA
+
+	count := int(r.uint8())
+	prices := make([]*PythPrice, 0, count)
+	for i := 0; i < count; i++ {
+		message := r.bytes(int(r.uint16()))
+		r.skip(int(r.uint8()) * merkleNodeSize)
+		if r.err != nil {
+			break
+		}
+
+		price, err := decodePriceFeedMessage(message)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		if price != nil {
+			prices = append(prices, price)
+		}
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return prices, nil
+}
+
+// decodePriceFeedMessage parses a price feed message, returning nil for other message types
+func decodePriceFeedMessage(message []byte) (*PythPrice, error) {
+	r := &byteReader{data: message}
+	if r.uint8() != messageTypePriceFeed {
+		return nil, r.err
+	}
+
+	price := &PythPrice{
+		PriceID:     common.BytesToHash(r.bytes(common.HashLength)),
+		Price:       int64(r.uint64()),
+		Conf:        r.uint64(),
+		Expo:        int32(r.uint32()),
+		PublishTime: r.uint64(),
+	}
+	r.uint64() // Previous publish time
+	price.EMAPrice = int64(r.uint64())
+	price.EMAConf = r.uint64()
+
+	if r.err != nil {
+		return nil, r.err
+	}
+	return price, nil
+}
+
+// byteReader reads big-endian fields, remembering the first out-of-bounds read
+type byteReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (r *byteReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.data) {
+		r.err = fmt.Errorf("update truncated at byte %d", r.pos)
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *byteReader) skip(n int) {
+	r.bytes(n)
+}
+
+func (r *byteReader) uint8() uint8 {
+	if b := r.bytes(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *byteReader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *byteReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *byteReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
@@ -0,0 +1,101 @@
+package oracles
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var ethUSDPriceID = common.HexToHash("0xff61491a931112ddf1bd8147cd1b641375f79f5825126d665480874634fd0ace")
+
+// priceFeedMessage encodes a Pyth price feed message
+func priceFeedMessage(id common.Hash, price int64, expo int32, publishTime uint64) []byte {
+	message := []byte{messageTypePriceFeed}
+	message = append(message, id.Bytes()...)
+	message = binary.BigEndian.AppendUint64(message, uint64(price))
+	message = binary.BigEndian.AppendUint64(message, 150000)
+	message = binary.BigEndian.AppendUint32(message, uint32(expo))
+	message = binary.BigEndian.AppendUint64(message, publishTime)
+	message = binary.BigEndian.AppendUint64(message, publishTime-1)
+	message = binary.BigEndian.AppendUint64(message, uint64(price))
+	message = binary.BigEndian.AppendUint64(message, 150000)
+	return message
+}
+
+// accumulatorUpdate wraps messages in an accumulator update with a dummy VAA and
+// two-node proofs
+func accumulatorUpdate(messages ...[]byte) []byte {
+	update := binary.BigEndian.AppendUint32(nil, accumulatorMagic)
+	update = append(update, accumulatorMajorVersion, 0)
+	update = append(update, 2, 0xaa, 0xbb) // Trailing header
+	update = append(update, updateTypeWormholeMerkle)
+	vaa := make([]byte, 100)
+	update = binary.BigEndian.AppendUint16(update, uint16(len(vaa)))
+	update = append(update, vaa...)
+
+	update = append(update, uint8(len(messages)))
+	for _, message := range messages {
+		update = binary.BigEndian.AppendUint16(update, uint16(len(message)))
+		update = append(update, message...)
+		update = append(update, 2)
+		update = append(update, make([]byte, 2*merkleNodeSize)...)
+	}
+	return update
+}
+
+func TestDecodePythUpdate(t *testing.T) {
+	btcUSDPriceID := common.HexToHash("0xe62df6c8b4a85fe1a67db44dc12de5db330f7ac66b72dc658afedf0f4a415b43")
+	update := accumulatorUpdate(
+		priceFeedMessage(ethUSDPriceID, 300012345678, -8, 1700000000),
+		priceFeedMessage(btcUSDPriceID, 6500000000000, -8, 1700000001),
+	)
+
+	data, err := pyth.Pack("updatePriceFeeds", [][]byte{update})
+	require.NoError(t, err)
+	assert.True(t, IsPythUpdate(data))
+
+	prices, err := DecodePythUpdate(data)
+	require.NoError(t, err)
+	require.Len(t, prices, 2)
+	assert.Equal(t, ethUSDPriceID, prices[0].PriceID)
+	assert.Equal(t, int64(300012345678), prices[0].Price)
+	assert.Equal(t, int32(-8), prices[0].Expo)
+	assert.Equal(t, uint64(1700000000), prices[0].PublishTime)
+	assert.Equal(t, uint64(150000), prices[0].Conf)
+	assert.Equal(t, btcUSDPriceID, prices[1].PriceID)
+
+	// updatePriceFeedsIfNecessary carries the same update data
+	data, err = pyth.Pack("updatePriceFeedsIfNecessary", [][]byte{update}, [][32]byte{ethUSDPriceID}, []uint64{1700000000})
+	require.NoError(t, err)
+	prices, err = DecodePythUpdate(data)
+	require.NoError(t, err)
+	assert.Len(t, prices, 2)
+}
+
+func TestDecodePythUpdate_Invalid(t *testing.T) {
+	update := accumulatorUpdate(priceFeedMessage(ethUSDPriceID, 300000000000, -8, 1700000000))
+
+	tests := []struct {
+		name   string
+		update []byte
+	}{
+		{"bad magic", append([]byte{0, 0, 0, 0}, update[4:]...)},
+		{"unsupported version", append(append([]byte{}, update[:4]...), append([]byte{2}, update[5:]...)...)},
+		{"truncated", update[:len(update)-10]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := pyth.Pack("updatePriceFeeds", [][]byte{tt.update})
+			require.NoError(t, err)
+
+			_, err = DecodePythUpdate(data)
+			assert.Error(t, err)
+		})
+	}
+
+	assert.False(t, IsPythUpdate([]byte{0x01}))
+}
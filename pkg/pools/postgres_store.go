@@ -0,0 +1,193 @@
+package pools
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// PostgresStore implements Store on top of the pools tables in scripts/init.sql
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new Postgres-backed pool store
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{
+		db: db,
+	}
+}
+
+// SavePools upserts pools discovered from factory events
+func (s *PostgresStore) SavePools(ctx context.Context, pools []*interfaces.PoolInfo) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO pools (
+			address, protocol, factory, token0, token1, fee, tick_spacing,
+			stable, created_block, created_tx, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (address) DO UPDATE SET
+			protocol = EXCLUDED.protocol,
+			factory = EXCLUDED.factory,
+			token0 = EXCLUDED.token0,
+			token1 = EXCLUDED.token1,
+			fee = EXCLUDED.fee,
+			tick_spacing = EXCLUDED.tick_spacing,
+			stable = EXCLUDED.stable,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	for _, pool := range pools {
+		_, err := tx.ExecContext(ctx, query,
+			pool.Address.Hex(),
+			pool.Protocol.String(),
+			pool.Factory.Hex(),
+			pool.Token0.Hex(),
+			pool.Token1.Hex(),
+			pool.Fee,
+			pool.TickSpacing,
+			pool.Stable,
+			pool.CreatedBlock,
+			pool.CreatedTx.Hex(),
+			time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save pool %s: %w", pool.Address.Hex(), err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LoadPools loads every persisted pool
+func (s *PostgresStore) LoadPools(ctx context.Context) ([]*interfaces.PoolInfo, error) {
+	query := `
+		SELECT address, protocol, factory, token0, token1, fee, tick_spacing,
+			stable, created_block, created_tx, tvl, updated_at
+		FROM pools
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pools: %w", err)
+	}
+	defer rows.Close()
+
+	var pools []*interfaces.PoolInfo
+	for rows.Next() {
+		var (
+			address, protocol, factory, token0, token1, createdTx string
+			fee                                                   uint32
+			tickSpacing                                           int32
+			stable                                                bool
+			createdBlock                                          uint64
+			tvl                                                   sql.NullString
+			updatedAt                                             time.Time
+		)
+
+		if err := rows.Scan(&address, &protocol, &factory, &token0, &token1, &fee, &tickSpacing,
+			&stable, &createdBlock, &createdTx, &tvl, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan pool: %w", err)
+		}
+
+		pool := &interfaces.PoolInfo{
+			Address:      common.HexToAddress(address),
+			Protocol:     interfaces.ParseProtocol(protocol),
+			Factory:      common.HexToAddress(factory),
+			Token0:       common.HexToAddress(token0),
+			Token1:       common.HexToAddress(token1),
+			Fee:          fee,
+			TickSpacing:  tickSpacing,
+			Stable:       stable,
+			CreatedBlock: createdBlock,
+			CreatedTx:    common.HexToHash(createdTx),
+			UpdatedAt:    updatedAt,
+		}
+
+		if tvl.Valid {
+			if value, ok := new(big.Int).SetString(tvl.String, 10); ok {
+				pool.TVL = value
+			}
+		}
+
+		pools = append(pools, pool)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pools: %w", err)
+	}
+
+	return pools, nil
+}
+
+// UpdateTVL persists the latest total value locked for a pool
+func (s *PostgresStore) UpdateTVL(ctx context.Context, pool common.Address, tvl *big.Int) error {
+	query := `UPDATE pools SET tvl = $1, updated_at = $2 WHERE address = $3`
+
+	result, err := s.db.ExecContext(ctx, query, tvl.String(), time.Now(), pool.Hex())
+	if err != nil {
+		return fmt.Errorf("failed to update TVL: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("pool %s not found", pool.Hex())
+	}
+
+	return nil
+}
+
+// SaveCheckpoint records the last block indexed for a factory
+func (s *PostgresStore) SaveCheckpoint(ctx context.Context, factory common.Address, block uint64) error {
+	query := `
+		INSERT INTO pool_registry_checkpoints (factory, last_block, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (factory) DO UPDATE SET
+			last_block = EXCLUDED.last_block,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, factory.Hex(), block, time.Now()); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// LoadCheckpoints loads the last indexed block for every factory
+func (s *PostgresStore) LoadCheckpoints(ctx context.Context) (map[common.Address]uint64, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT factory, last_block FROM pool_registry_checkpoints`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query checkpoints: %w", err)
+	}
+	defer rows.Close()
+
+	checkpoints := make(map[common.Address]uint64)
+	for rows.Next() {
+		var factory string
+		var block uint64
+		if err := rows.Scan(&factory, &block); err != nil {
+			return nil, fmt.Errorf("failed to scan checkpoint: %w", err)
+		}
+		checkpoints[common.HexToAddress(factory)] = block
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checkpoints: %w", err)
+	}
+
+	return checkpoints, nil
+}
@@ -0,0 +1,580 @@
+package pools
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// ChainReader is the subset of an Ethereum client needed to index factory events
+type ChainReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error)
+}
+
+// Store persists discovered pools and per-factory sync checkpoints
+type Store interface {
+	SavePools(ctx context.Context, pools []*interfaces.PoolInfo) error
+	LoadPools(ctx context.Context) ([]*interfaces.PoolInfo, error)
+	UpdateTVL(ctx context.Context, pool common.Address, tvl *big.Int) error
+	SaveCheckpoint(ctx context.Context, factory common.Address, block uint64) error
+	LoadCheckpoints(ctx context.Context) (map[common.Address]uint64, error)
+}
+
+// FactoryConfig identifies a pool factory to index
+type FactoryConfig struct {
+	Protocol   interfaces.Protocol
+	Address    common.Address
+	StartBlock uint64
+}
+
+// RegistryConfig holds configuration for the pool registry
+type RegistryConfig struct {
+	Factories []FactoryConfig
+	MinTVL    *big.Int // Pools below this floor are skipped by FindPools
+	BatchSize uint64   // Maximum block range per eth_getLogs request
+}
+
+// Base mainnet factory deployments
+var (
+	BaseUniswapV2Factory = common.HexToAddress("0x8909Dc15e40173Ff4699343b6eB8132c65e18eC6")
+	BaseUniswapV3Factory = common.HexToAddress("0x33128a8fC17869897dcE68Ed026d694621f6FDfD")
+	BaseAerodromeFactory = common.HexToAddress("0x420DD381b31aEf6683db6B902084cB0FFECe40Da")
+)
+
+// Fee tiers for V2-style pools expressed in V3 units (hundredths of a bip)
+const (
+	uniswapV2Fee         uint32 = 3000 // 0.30%
+	aerodromeVolatileFee uint32 = 3000 // 0.30% default
+	aerodromeStableFee   uint32 = 500  // 0.05% default
+)
+
+// DefaultRegistryConfig returns the default configuration for Base mainnet
+func DefaultRegistryConfig() *RegistryConfig {
+	return &RegistryConfig{
+		Factories: []FactoryConfig{
+			{Protocol: interfaces.ProtocolUniswapV2, Address: BaseUniswapV2Factory, StartBlock: 6601915},
+			{Protocol: interfaces.ProtocolUniswapV3, Address: BaseUniswapV3Factory, StartBlock: 1371680},
+			{Protocol: interfaces.ProtocolAerodrome, Address: BaseAerodromeFactory, StartBlock: 3200559},
+		},
+		MinTVL:    new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)), // 10 ETH
+		BatchSize: 10000,
+	}
+}
+
+// registry implements the PoolRegistry interface
+type registry struct {
+	config      *RegistryConfig
+	chain       ChainReader
+	store       Store
+	factories   map[common.Address]FactoryConfig
+	factoryABIs map[interfaces.Protocol]*abi.ABI
+
+	mu          sync.RWMutex
+	byAddress   map[common.Address]*interfaces.PoolInfo
+	byPair      map[pairKey][]*interfaces.PoolInfo
+	checkpoints map[common.Address]uint64
+	lastSyncAt  time.Time
+}
+
+// pairKey identifies a token pair independent of ordering
+type pairKey struct {
+	token0 common.Address
+	token1 common.Address
+}
+
+// NewRegistry creates a new pool registry. The chain reader and store are optional;
+// without a chain reader Sync is unavailable and without a store the registry is in-memory only.
+func NewRegistry(config *RegistryConfig, abiManager interfaces.ABIManager, chain ChainReader, store Store) (interfaces.PoolRegistry, error) {
+	if config == nil {
+		config = DefaultRegistryConfig()
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 10000
+	}
+	if abiManager == nil {
+		return nil, fmt.Errorf("ABI manager is required")
+	}
+
+	r := &registry{
+		config:      config,
+		chain:       chain,
+		store:       store,
+		factories:   make(map[common.Address]FactoryConfig),
+		factoryABIs: make(map[interfaces.Protocol]*abi.ABI),
+		byAddress:   make(map[common.Address]*interfaces.PoolInfo),
+		byPair:      make(map[pairKey][]*interfaces.PoolInfo),
+		checkpoints: make(map[common.Address]uint64),
+	}
+
+	for _, factory := range config.Factories {
+		if _, exists := r.factoryABIs[factory.Protocol]; !exists {
+			parsedABI, err := loadFactoryABI(abiManager, factory.Protocol)
+			if err != nil {
+				return nil, err
+			}
+			r.factoryABIs[factory.Protocol] = parsedABI
+		}
+		r.factories[factory.Address] = factory
+	}
+
+	if store != nil {
+		if err := r.loadFromStore(context.Background()); err != nil {
+			return nil, fmt.Errorf("failed to load pools from store: %w", err)
+		}
+	}
+
+	return r, nil
+}
+
+// Sync indexes factory creation events from each factory's checkpoint up to the chain head
+func (r *registry) Sync(ctx context.Context) error {
+	if r.chain == nil {
+		return fmt.Errorf("pool registry has no chain reader configured")
+	}
+
+	head, err := r.chain.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain head: %w", err)
+	}
+
+	for _, factory := range r.config.Factories {
+		if err := r.syncFactory(ctx, factory, head); err != nil {
+			return fmt.Errorf("failed to sync %s factory %s: %w", factory.Protocol.String(), factory.Address.Hex(), err)
+		}
+	}
+
+	r.mu.Lock()
+	r.lastSyncAt = time.Now()
+	r.mu.Unlock()
+
+	return nil
+}
+
+// IndexLogs decodes factory creation logs and adds the resulting pools to the registry
+func (r *registry) IndexLogs(ctx context.Context, logs []*ethtypes.Log) ([]*interfaces.PoolInfo, error) {
+	var discovered []*interfaces.PoolInfo
+
+	for _, log := range logs {
+		if log == nil || len(log.Topics) == 0 {
+			continue
+		}
+
+		factory, exists := r.factories[log.Address]
+		if !exists {
+			continue // Not emitted by a tracked factory
+		}
+
+		pool, err := r.decodePoolCreated(factory, log)
+		if err != nil {
+			return discovered, fmt.Errorf("failed to decode factory log in tx %s: %w", log.TxHash.Hex(), err)
+		}
+		if pool == nil {
+			continue
+		}
+
+		discovered = append(discovered, pool)
+	}
+
+	if len(discovered) == 0 {
+		return nil, nil
+	}
+
+	if r.store != nil {
+		if err := r.store.SavePools(ctx, discovered); err != nil {
+			return nil, fmt.Errorf("failed to persist pools: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	for _, pool := range discovered {
+		r.addPoolLocked(pool)
+	}
+	r.mu.Unlock()
+
+	return discovered, nil
+}
+
+// GetPool returns the pool at the given address
+func (r *registry) GetPool(address common.Address) (*interfaces.PoolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pool, exists := r.byAddress[address]
+	if !exists {
+		return nil, false
+	}
+	return copyPool(pool), true
+}
+
+// GetPoolsByPair returns all pools trading the given token pair, in either order
+func (r *registry) GetPoolsByPair(tokenA, tokenB common.Address) []*interfaces.PoolInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pools := r.byPair[newPairKey(tokenA, tokenB)]
+	result := make([]*interfaces.PoolInfo, 0, len(pools))
+	for _, pool := range pools {
+		result = append(result, copyPool(pool))
+	}
+	return result
+}
+
+// FindPool returns the pool for a token pair with the given protocol and fee tier
+func (r *registry) FindPool(tokenA, tokenB common.Address, protocol interfaces.Protocol, fee uint32) (*interfaces.PoolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, pool := range r.byPair[newPairKey(tokenA, tokenB)] {
+		if pool.Protocol == protocol && pool.Fee == fee {
+			return copyPool(pool), true
+		}
+	}
+	return nil, false
+}
+
+// FindPools returns pools matching the filter, sorted by TVL descending
+func (r *registry) FindPools(filter *interfaces.PoolFilter) []*interfaces.PoolInfo {
+	if filter == nil {
+		filter = &interfaces.PoolFilter{}
+	}
+
+	minTVL := filter.MinTVL
+	if minTVL == nil {
+		minTVL = r.config.MinTVL
+	}
+
+	excluded := make(map[common.Address]bool, len(filter.Exclude))
+	for _, address := range filter.Exclude {
+		excluded[address] = true
+	}
+
+	r.mu.RLock()
+	var result []*interfaces.PoolInfo
+	for _, pool := range r.byAddress {
+		if excluded[pool.Address] {
+			continue
+		}
+		if filter.Token != nil && !pool.HasToken(*filter.Token) {
+			continue
+		}
+		if filter.Protocol != interfaces.ProtocolUnknown && pool.Protocol != filter.Protocol {
+			continue
+		}
+		if filter.Fee != nil && pool.Fee != *filter.Fee {
+			continue
+		}
+		if !meetsTVLFloor(pool, minTVL) {
+			continue
+		}
+		result = append(result, copyPool(pool))
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		return compareTVL(result[i].TVL, result[j].TVL) > 0
+	})
+
+	return result
+}
+
+// UpdateLiquidity records the current total value locked for a pool
+func (r *registry) UpdateLiquidity(ctx context.Context, pool common.Address, tvl *big.Int) error {
+	if tvl == nil || tvl.Sign() < 0 {
+		return fmt.Errorf("invalid TVL for pool %s", pool.Hex())
+	}
+
+	r.mu.RLock()
+	_, exists := r.byAddress[pool]
+	r.mu.RUnlock()
+	if !exists {
+		return fmt.Errorf("pool %s not found in registry", pool.Hex())
+	}
+
+	if r.store != nil {
+		if err := r.store.UpdateTVL(ctx, pool, tvl); err != nil {
+			return fmt.Errorf("failed to persist TVL: %w", err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if info, exists := r.byAddress[pool]; exists {
+		info.TVL = new(big.Int).Set(tvl)
+		info.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// GetStats returns registry statistics
+func (r *registry) GetStats() interfaces.PoolRegistryStats {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	stats := interfaces.PoolRegistryStats{
+		TotalPools:      len(r.byAddress),
+		PoolsByProtocol: make(map[interfaces.Protocol]int),
+		LastSyncedBlock: make(map[common.Address]uint64, len(r.checkpoints)),
+		LastSyncAt:      r.lastSyncAt,
+	}
+
+	for _, pool := range r.byAddress {
+		stats.PoolsByProtocol[pool.Protocol]++
+		if meetsTVLFloor(pool, r.config.MinTVL) {
+			stats.LiquidPools++
+		}
+	}
+
+	for factory, block := range r.checkpoints {
+		stats.LastSyncedBlock[factory] = block
+	}
+
+	return stats
+}
+
+// syncFactory indexes a single factory in block batches and checkpoints progress
+func (r *registry) syncFactory(ctx context.Context, factory FactoryConfig, head uint64) error {
+	factoryABI := r.factoryABIs[factory.Protocol]
+	eventName := creationEventName(factory.Protocol)
+	event, exists := factoryABI.Events[eventName]
+	if !exists {
+		return fmt.Errorf("%s event not found in factory ABI", eventName)
+	}
+
+	r.mu.RLock()
+	from := factory.StartBlock
+	if checkpoint, exists := r.checkpoints[factory.Address]; exists && checkpoint+1 > from {
+		from = checkpoint + 1
+	}
+	r.mu.RUnlock()
+
+	for from <= head {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		to := from + r.config.BatchSize - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := r.chain.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{factory.Address},
+			Topics:    [][]common.Hash{{event.ID}},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to fetch logs for blocks %d-%d: %w", from, to, err)
+		}
+
+		logPtrs := make([]*ethtypes.Log, len(logs))
+		for i := range logs {
+			logPtrs[i] = &logs[i]
+		}
+
+		if _, err := r.IndexLogs(ctx, logPtrs); err != nil {
+			return err
+		}
+
+		if r.store != nil {
+			if err := r.store.SaveCheckpoint(ctx, factory.Address, to); err != nil {
+				return fmt.Errorf("failed to persist checkpoint: %w", err)
+			}
+		}
+
+		r.mu.Lock()
+		r.checkpoints[factory.Address] = to
+		r.mu.Unlock()
+
+		from = to + 1
+	}
+
+	return nil
+}
+
+// decodePoolCreated decodes a factory creation event into pool information
+func (r *registry) decodePoolCreated(factory FactoryConfig, log *ethtypes.Log) (*interfaces.PoolInfo, error) {
+	factoryABI := r.factoryABIs[factory.Protocol]
+	eventName := creationEventName(factory.Protocol)
+
+	event, exists := factoryABI.Events[eventName]
+	if !exists {
+		return nil, fmt.Errorf("%s event not found in factory ABI", eventName)
+	}
+	if log.Topics[0] != event.ID {
+		return nil, nil // Some other factory event (fee changes, ownership, etc.)
+	}
+
+	decoded := make(map[string]interface{})
+	if err := factoryABI.UnpackIntoMap(decoded, eventName, log.Data); err != nil {
+		return nil, fmt.Errorf("failed to unpack %s data: %w", eventName, err)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(decoded, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to parse indexed topics: %w", err)
+	}
+
+	pool := &interfaces.PoolInfo{
+		Protocol:     factory.Protocol,
+		Factory:      factory.Address,
+		CreatedBlock: log.BlockNumber,
+		CreatedTx:    log.TxHash,
+		UpdatedAt:    time.Now(),
+	}
+
+	pool.Token0, _ = decoded["token0"].(common.Address)
+	pool.Token1, _ = decoded["token1"].(common.Address)
+
+	switch factory.Protocol {
+	case interfaces.ProtocolUniswapV2:
+		pool.Address, _ = decoded["pair"].(common.Address)
+		pool.Fee = uniswapV2Fee
+	case interfaces.ProtocolUniswapV3:
+		pool.Address, _ = decoded["pool"].(common.Address)
+		if fee, ok := decoded["fee"].(*big.Int); ok {
+			pool.Fee = uint32(fee.Uint64())
+		}
+		if tickSpacing, ok := decoded["tickSpacing"].(*big.Int); ok {
+			pool.TickSpacing = int32(tickSpacing.Int64())
+		}
+	case interfaces.ProtocolAerodrome:
+		pool.Address, _ = decoded["pool"].(common.Address)
+		pool.Stable, _ = decoded["stable"].(bool)
+		pool.Fee = aerodromeVolatileFee
+		if pool.Stable {
+			pool.Fee = aerodromeStableFee
+		}
+	default:
+		return nil, fmt.Errorf("unsupported factory protocol: %s", factory.Protocol.String())
+	}
+
+	if pool.Address == (common.Address{}) {
+		return nil, fmt.Errorf("%s event missing pool address", eventName)
+	}
+
+	return pool, nil
+}
+
+// addPoolLocked adds a pool to the in-memory indexes; caller must hold the write lock
+func (r *registry) addPoolLocked(pool *interfaces.PoolInfo) {
+	if existing, exists := r.byAddress[pool.Address]; exists {
+		// Keep liquidity data already known for the pool
+		if pool.TVL == nil && existing.TVL != nil {
+			pool.TVL = existing.TVL
+		}
+		*existing = *pool
+		return
+	}
+
+	r.byAddress[pool.Address] = pool
+	key := newPairKey(pool.Token0, pool.Token1)
+	r.byPair[key] = append(r.byPair[key], pool)
+}
+
+// loadFromStore populates the in-memory indexes from persisted state
+func (r *registry) loadFromStore(ctx context.Context) error {
+	pools, err := r.store.LoadPools(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkpoints, err := r.store.LoadCheckpoints(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, pool := range pools {
+		r.addPoolLocked(pool)
+	}
+	for factory, block := range checkpoints {
+		r.checkpoints[factory] = block
+	}
+
+	return nil
+}
+
+// loadFactoryABI fetches and parses the factory ABI for a protocol
+func loadFactoryABI(abiManager interfaces.ABIManager, protocol interfaces.Protocol) (*abi.ABI, error) {
+	if err := abiManager.LoadABI(protocol, interfaces.ContractTypeFactory); err != nil {
+		return nil, fmt.Errorf("failed to load %s factory ABI: %w", protocol.String(), err)
+	}
+
+	abiBytes, err := abiManager.GetABI(protocol, interfaces.ContractTypeFactory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s factory ABI: %w", protocol.String(), err)
+	}
+
+	var parsedABI abi.ABI
+	if err := parsedABI.UnmarshalJSON(abiBytes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s factory ABI: %w", protocol.String(), err)
+	}
+
+	return &parsedABI, nil
+}
+
+// creationEventName returns the factory event emitted when a pool is created
+func creationEventName(protocol interfaces.Protocol) string {
+	if protocol == interfaces.ProtocolUniswapV2 {
+		return "PairCreated"
+	}
+	return "PoolCreated"
+}
+
+// newPairKey builds an order-independent key for a token pair
+func newPairKey(tokenA, tokenB common.Address) pairKey {
+	if tokenA.Cmp(tokenB) > 0 {
+		tokenA, tokenB = tokenB, tokenA
+	}
+	return pairKey{token0: tokenA, token1: tokenB}
+}
+
+// meetsTVLFloor reports whether a pool's known TVL is at or above the floor
+func meetsTVLFloor(pool *interfaces.PoolInfo, floor *big.Int) bool {
+	if floor == nil || floor.Sign() == 0 {
+		return true
+	}
+	return pool.TVL != nil && pool.TVL.Cmp(floor) >= 0
+}
+
+// compareTVL compares two TVL values treating nil as zero
+func compareTVL(a, b *big.Int) int {
+	if a == nil {
+		a = big.NewInt(0)
+	}
+	if b == nil {
+		b = big.NewInt(0)
+	}
+	return a.Cmp(b)
+}
+
+// copyPool returns a copy of the pool so callers cannot mutate registry state
+func copyPool(pool *interfaces.PoolInfo) *interfaces.PoolInfo {
+	copied := *pool
+	if pool.TVL != nil {
+		copied.TVL = new(big.Int).Set(pool.TVL)
+	}
+	return &copied
+}
@@ -0,0 +1,369 @@
+package pools
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testWETH = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	testUSDC = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+)
+
+// fakeChain serves canned factory logs for Sync tests
+type fakeChain struct {
+	head    uint64
+	logs    []ethtypes.Log
+	queries []ethereum.FilterQuery
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	c.queries = append(c.queries, q)
+
+	var result []ethtypes.Log
+	for _, log := range c.logs {
+		if log.BlockNumber < q.FromBlock.Uint64() || log.BlockNumber > q.ToBlock.Uint64() {
+			continue
+		}
+		if len(q.Addresses) > 0 && log.Address != q.Addresses[0] {
+			continue
+		}
+		result = append(result, log)
+	}
+	return result, nil
+}
+
+// memoryStore is an in-memory Store used to verify persistence calls
+type memoryStore struct {
+	pools       map[common.Address]*interfaces.PoolInfo
+	checkpoints map[common.Address]uint64
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		pools:       make(map[common.Address]*interfaces.PoolInfo),
+		checkpoints: make(map[common.Address]uint64),
+	}
+}
+
+func (s *memoryStore) SavePools(ctx context.Context, pools []*interfaces.PoolInfo) error {
+	for _, pool := range pools {
+		s.pools[pool.Address] = copyPool(pool)
+	}
+	return nil
+}
+
+func (s *memoryStore) LoadPools(ctx context.Context) ([]*interfaces.PoolInfo, error) {
+	var pools []*interfaces.PoolInfo
+	for _, pool := range s.pools {
+		pools = append(pools, copyPool(pool))
+	}
+	return pools, nil
+}
+
+func (s *memoryStore) UpdateTVL(ctx context.Context, pool common.Address, tvl *big.Int) error {
+	if info, exists := s.pools[pool]; exists {
+		info.TVL = new(big.Int).Set(tvl)
+	}
+	return nil
+}
+
+func (s *memoryStore) SaveCheckpoint(ctx context.Context, factory common.Address, block uint64) error {
+	s.checkpoints[factory] = block
+	return nil
+}
+
+func (s *memoryStore) LoadCheckpoints(ctx context.Context) (map[common.Address]uint64, error) {
+	checkpoints := make(map[common.Address]uint64)
+	for factory, block := range s.checkpoints {
+		checkpoints[factory] = block
+	}
+	return checkpoints, nil
+}
+
+func getFactoryEvent(t *testing.T, protocol interfaces.Protocol) abi.Event {
+	parsedABI, err := loadFactoryABI(events.NewABIManager(), protocol)
+	require.NoError(t, err)
+	event, exists := parsedABI.Events[creationEventName(protocol)]
+	require.True(t, exists)
+	return event
+}
+
+func createV2PairCreatedLog(t *testing.T, pair common.Address, block uint64) ethtypes.Log {
+	event := getFactoryEvent(t, interfaces.ProtocolUniswapV2)
+	data, err := event.Inputs.NonIndexed().Pack(pair, big.NewInt(1))
+	require.NoError(t, err)
+
+	return ethtypes.Log{
+		Address:     BaseUniswapV2Factory,
+		Topics:      []common.Hash{event.ID, common.BytesToHash(testWETH.Bytes()), common.BytesToHash(testUSDC.Bytes())},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.HexToHash("0xaa"),
+	}
+}
+
+func createV3PoolCreatedLog(t *testing.T, pool common.Address, fee int64, tickSpacing int64, block uint64) ethtypes.Log {
+	event := getFactoryEvent(t, interfaces.ProtocolUniswapV3)
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(tickSpacing), pool)
+	require.NoError(t, err)
+
+	return ethtypes.Log{
+		Address: BaseUniswapV3Factory,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(testWETH.Bytes()),
+			common.BytesToHash(testUSDC.Bytes()),
+			common.BigToHash(big.NewInt(fee)),
+		},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.HexToHash("0xbb"),
+	}
+}
+
+func createAerodromePoolCreatedLog(t *testing.T, pool common.Address, stable bool, block uint64) ethtypes.Log {
+	event := getFactoryEvent(t, interfaces.ProtocolAerodrome)
+	data, err := event.Inputs.NonIndexed().Pack(pool, big.NewInt(1))
+	require.NoError(t, err)
+
+	stableTopic := common.Hash{}
+	if stable {
+		stableTopic = common.BigToHash(big.NewInt(1))
+	}
+
+	return ethtypes.Log{
+		Address:     BaseAerodromeFactory,
+		Topics:      []common.Hash{event.ID, common.BytesToHash(testWETH.Bytes()), common.BytesToHash(testUSDC.Bytes()), stableTopic},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.HexToHash("0xcc"),
+	}
+}
+
+func toLogPtrs(logs ...ethtypes.Log) []*ethtypes.Log {
+	ptrs := make([]*ethtypes.Log, len(logs))
+	for i := range logs {
+		ptrs[i] = &logs[i]
+	}
+	return ptrs
+}
+
+func TestNewRegistry(t *testing.T) {
+	_, err := NewRegistry(nil, nil, nil, nil)
+	assert.Error(t, err)
+
+	registry, err := NewRegistry(nil, events.NewABIManager(), nil, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, registry)
+	assert.Equal(t, 0, registry.GetStats().TotalPools)
+
+	// Sync requires a chain reader
+	assert.Error(t, registry.Sync(context.Background()))
+}
+
+func TestRegistry_IndexLogs(t *testing.T) {
+	registry, err := NewRegistry(nil, events.NewABIManager(), nil, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	v2Pair := common.HexToAddress("0x1001")
+	v3Pool := common.HexToAddress("0x1002")
+	aeroPool := common.HexToAddress("0x1003")
+
+	logs := toLogPtrs(
+		createV2PairCreatedLog(t, v2Pair, 100),
+		createV3PoolCreatedLog(t, v3Pool, 500, 10, 101),
+		createAerodromePoolCreatedLog(t, aeroPool, true, 102),
+	)
+
+	// Logs from untracked contracts are ignored
+	unknown := createV2PairCreatedLog(t, common.HexToAddress("0x2001"), 103)
+	unknown.Address = common.HexToAddress("0xdead")
+	logs = append(logs, &unknown)
+
+	discovered, err := registry.IndexLogs(ctx, logs)
+	require.NoError(t, err)
+	assert.Len(t, discovered, 3)
+
+	pool, exists := registry.GetPool(v2Pair)
+	require.True(t, exists)
+	assert.Equal(t, interfaces.ProtocolUniswapV2, pool.Protocol)
+	assert.Equal(t, testWETH, pool.Token0)
+	assert.Equal(t, testUSDC, pool.Token1)
+	assert.Equal(t, uint32(3000), pool.Fee)
+	assert.Equal(t, uint64(100), pool.CreatedBlock)
+
+	pool, exists = registry.GetPool(v3Pool)
+	require.True(t, exists)
+	assert.Equal(t, interfaces.ProtocolUniswapV3, pool.Protocol)
+	assert.Equal(t, uint32(500), pool.Fee)
+	assert.Equal(t, int32(10), pool.TickSpacing)
+
+	pool, exists = registry.GetPool(aeroPool)
+	require.True(t, exists)
+	assert.Equal(t, interfaces.ProtocolAerodrome, pool.Protocol)
+	assert.True(t, pool.Stable)
+	assert.Equal(t, uint32(500), pool.Fee)
+
+	_, exists = registry.GetPool(common.HexToAddress("0x2001"))
+	assert.False(t, exists)
+
+	stats := registry.GetStats()
+	assert.Equal(t, 3, stats.TotalPools)
+	assert.Equal(t, 1, stats.PoolsByProtocol[interfaces.ProtocolUniswapV3])
+}
+
+func TestRegistry_Lookups(t *testing.T) {
+	registry, err := NewRegistry(nil, events.NewABIManager(), nil, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	v2Pair := common.HexToAddress("0x1001")
+	v3Pool500 := common.HexToAddress("0x1002")
+	v3Pool3000 := common.HexToAddress("0x1003")
+
+	_, err = registry.IndexLogs(ctx, toLogPtrs(
+		createV2PairCreatedLog(t, v2Pair, 100),
+		createV3PoolCreatedLog(t, v3Pool500, 500, 10, 101),
+		createV3PoolCreatedLog(t, v3Pool3000, 3000, 60, 102),
+	))
+	require.NoError(t, err)
+
+	// Pair lookups are order independent
+	assert.Len(t, registry.GetPoolsByPair(testWETH, testUSDC), 3)
+	assert.Len(t, registry.GetPoolsByPair(testUSDC, testWETH), 3)
+
+	pool, exists := registry.FindPool(testUSDC, testWETH, interfaces.ProtocolUniswapV3, 3000)
+	require.True(t, exists)
+	assert.Equal(t, v3Pool3000, pool.Address)
+
+	_, exists = registry.FindPool(testWETH, testUSDC, interfaces.ProtocolUniswapV3, 10000)
+	assert.False(t, exists)
+
+	_, exists = registry.FindPool(testWETH, testUSDC, interfaces.ProtocolAerodrome, 3000)
+	assert.False(t, exists)
+
+	// Returned pools are copies
+	pool.Fee = 1
+	pool, _ = registry.GetPool(v3Pool3000)
+	assert.Equal(t, uint32(3000), pool.Fee)
+}
+
+func TestRegistry_LiquidityFiltering(t *testing.T) {
+	config := DefaultRegistryConfig()
+	config.MinTVL = big.NewInt(1000)
+	registry, err := NewRegistry(config, events.NewABIManager(), nil, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	deep := common.HexToAddress("0x1001")
+	deeper := common.HexToAddress("0x1002")
+	shallow := common.HexToAddress("0x1003")
+	unknown := common.HexToAddress("0x1004")
+
+	_, err = registry.IndexLogs(ctx, toLogPtrs(
+		createV3PoolCreatedLog(t, deep, 500, 10, 100),
+		createV3PoolCreatedLog(t, deeper, 3000, 60, 101),
+		createV3PoolCreatedLog(t, shallow, 10000, 200, 102),
+		createV2PairCreatedLog(t, unknown, 103),
+	))
+	require.NoError(t, err)
+
+	require.NoError(t, registry.UpdateLiquidity(ctx, deep, big.NewInt(5000)))
+	require.NoError(t, registry.UpdateLiquidity(ctx, deeper, big.NewInt(9000)))
+	require.NoError(t, registry.UpdateLiquidity(ctx, shallow, big.NewInt(10)))
+
+	assert.Error(t, registry.UpdateLiquidity(ctx, common.HexToAddress("0x9999"), big.NewInt(1)))
+	assert.Error(t, registry.UpdateLiquidity(ctx, deep, big.NewInt(-1)))
+
+	// Registry floor applies by default and excludes pools with unknown TVL
+	pools := registry.FindPools(&interfaces.PoolFilter{Token: &testWETH})
+	require.Len(t, pools, 2)
+	assert.Equal(t, deeper, pools[0].Address) // Sorted by TVL descending
+	assert.Equal(t, deep, pools[1].Address)
+
+	// Explicit floor overrides the registry floor
+	pools = registry.FindPools(&interfaces.PoolFilter{MinTVL: big.NewInt(0)})
+	assert.Len(t, pools, 4)
+
+	// Protocol, fee and exclusion filters
+	pools = registry.FindPools(&interfaces.PoolFilter{Protocol: interfaces.ProtocolUniswapV3, Exclude: []common.Address{deeper}})
+	require.Len(t, pools, 1)
+	assert.Equal(t, deep, pools[0].Address)
+
+	fee := uint32(500)
+	pools = registry.FindPools(&interfaces.PoolFilter{Fee: &fee})
+	require.Len(t, pools, 1)
+	assert.Equal(t, deep, pools[0].Address)
+
+	assert.Equal(t, 2, registry.GetStats().LiquidPools)
+}
+
+func TestRegistry_Sync(t *testing.T) {
+	config := &RegistryConfig{
+		Factories: []FactoryConfig{
+			{Protocol: interfaces.ProtocolUniswapV2, Address: BaseUniswapV2Factory, StartBlock: 100},
+			{Protocol: interfaces.ProtocolUniswapV3, Address: BaseUniswapV3Factory, StartBlock: 150},
+		},
+		BatchSize: 50,
+	}
+
+	chain := &fakeChain{
+		head: 250,
+		logs: []ethtypes.Log{
+			createV2PairCreatedLog(t, common.HexToAddress("0x1001"), 120),
+			createV2PairCreatedLog(t, common.HexToAddress("0x1002"), 90), // Before start block
+			createV3PoolCreatedLog(t, common.HexToAddress("0x1003"), 500, 10, 240),
+		},
+	}
+	store := newMemoryStore()
+
+	registry, err := NewRegistry(config, events.NewABIManager(), chain, store)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, registry.Sync(ctx))
+
+	stats := registry.GetStats()
+	assert.Equal(t, 2, stats.TotalPools)
+	assert.Equal(t, uint64(250), stats.LastSyncedBlock[BaseUniswapV2Factory])
+	assert.Equal(t, uint64(250), stats.LastSyncedBlock[BaseUniswapV3Factory])
+	assert.False(t, stats.LastSyncAt.IsZero())
+
+	// Blocks 100-250 in batches of 50 for V2 (4 requests), 150-250 for V3 (3 requests)
+	assert.Len(t, chain.queries, 7)
+
+	// Pools and checkpoints were persisted
+	assert.Len(t, store.pools, 2)
+	assert.Equal(t, uint64(250), store.checkpoints[BaseUniswapV2Factory])
+
+	// A second sync resumes from the checkpoint
+	chain.queries = nil
+	chain.head = 260
+	chain.logs = append(chain.logs, createV2PairCreatedLog(t, common.HexToAddress("0x1004"), 255))
+	require.NoError(t, registry.Sync(ctx))
+	assert.Len(t, chain.queries, 2)
+	assert.Equal(t, uint64(251), chain.queries[0].FromBlock.Uint64())
+	assert.Equal(t, 3, registry.GetStats().TotalPools)
+
+	// A new registry restores state from the store
+	restored, err := NewRegistry(config, events.NewABIManager(), chain, store)
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored.GetStats().TotalPools)
+	assert.Equal(t, uint64(260), restored.GetStats().LastSyncedBlock[BaseUniswapV2Factory])
+}
@@ -0,0 +1,247 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Ethereum mainnet token deployments bridged to Base
+var (
+	EthereumWETH  = common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	EthereumUSDC  = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	EthereumDAI   = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	EthereumCbETH = common.HexToAddress("0xBe9895146f7AF43049ca1c1AE358B0541Ea49704")
+)
+
+// Ethereum mainnet Uniswap V3 pools pricing the bridged tokens
+var (
+	EthereumUSDCWETHPool  = common.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	EthereumDAIWETHPool   = common.HexToAddress("0xC2e9F25Be6257c210d7Adf0D4Cd6E3E881ba25f8")
+	EthereumCbETHWETHPool = common.HexToAddress("0x840DEEef2f115Cf50DA625F7368C24af6fE74410")
+)
+
+// CrossLayerPriceUnit is the raw token amount GetL1Price and GetL2Price price, so
+// amount × price ÷ CrossLayerPriceUnit is a value in wei whatever the decimals
+var CrossLayerPriceUnit = big.NewInt(1e18)
+
+// poolStateABI covers the getters read to mirror a V2 or V3 pool
+const poolStateABI = `[
+	{"inputs":[],"name":"token0","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"token1","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"fee","outputs":[{"name":"","type":"uint24"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"liquidity","outputs":[{"name":"","type":"uint128"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"slot0","outputs":[
+		{"name":"sqrtPriceX96","type":"uint160"},
+		{"name":"tick","type":"int24"},
+		{"name":"observationIndex","type":"uint16"},
+		{"name":"observationCardinality","type":"uint16"},
+		{"name":"observationCardinalityNext","type":"uint16"},
+		{"name":"feeProtocol","type":"uint8"},
+		{"name":"unlocked","type":"bool"}
+	],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"getReserves","outputs":[
+		{"name":"reserve0","type":"uint112"},
+		{"name":"reserve1","type":"uint112"},
+		{"name":"blockTimestampLast","type":"uint32"}
+	],"stateMutability":"view","type":"function"}
+]`
+
+var poolState = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(poolStateABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid pool state ABI: %v", err))
+	}
+	return parsed
+}()
+
+// DefaultL1Tokens returns metadata for the Ethereum mainnet counterparts of the
+// bridged Base tokens
+func DefaultL1Tokens() []*interfaces.TokenInfo {
+	return []*interfaces.TokenInfo{
+		{Address: EthereumWETH, Symbol: "WETH", Decimals: 18},
+		{Address: EthereumUSDC, Symbol: "USDC", Decimals: 6, Stablecoin: true},
+		{Address: EthereumDAI, Symbol: "DAI", Decimals: 18, Stablecoin: true},
+		{Address: EthereumCbETH, Symbol: "cbETH", Decimals: 18},
+	}
+}
+
+// BaseBridgedTokens maps Base tokens to the Ethereum mainnet tokens the standard
+// bridge locks for them. Native USDC moves over CCTP instead; USDbC is the
+// standard bridge's USDC.
+func BaseBridgedTokens() map[common.Address]common.Address {
+	return map[common.Address]common.Address{
+		BaseWETH:  EthereumWETH,
+		BaseUSDbC: EthereumUSDC,
+		BaseDAI:   EthereumDAI,
+		BaseCbETH: EthereumCbETH,
+	}
+}
+
+// L1Pool is an L1 pool read to price bridged tokens
+type L1Pool struct {
+	Address  common.Address
+	Protocol interfaces.Protocol // Uniswap V2 or V3 style
+}
+
+// CrossLayerPricesConfig configures the L1 pools and the token pairs they price
+type CrossLayerPricesConfig struct {
+	Pools    []L1Pool
+	L1Tokens map[common.Address]common.Address // L2 token to its L1 counterpart
+}
+
+// DefaultCrossLayerPricesConfig returns the Ethereum mainnet pools pricing the
+// bridged Base tokens
+func DefaultCrossLayerPricesConfig() *CrossLayerPricesConfig {
+	return &CrossLayerPricesConfig{
+		Pools: []L1Pool{
+			{Address: EthereumUSDCWETHPool, Protocol: interfaces.ProtocolUniswapV3},
+			{Address: EthereumDAIWETHPool, Protocol: interfaces.ProtocolUniswapV3},
+			{Address: EthereumCbETHWETHPool, Protocol: interfaces.ProtocolUniswapV3},
+		},
+		L1Tokens: BaseBridgedTokens(),
+	}
+}
+
+// CrossLayerPrices prices bridged tokens on both layers in wei of ETH. L1 prices
+// come from a price oracle over L1 pools read with eth_call; L2 prices from the
+// L2 oracle mirroring Base pools. It satisfies the cross-layer detector's
+// PriceOracle, keyed by L2 token address.
+type CrossLayerPrices struct {
+	config   *CrossLayerPricesConfig
+	l1       ContractCaller
+	l1Oracle interfaces.PriceOracle
+	l2Oracle interfaces.PriceOracle
+}
+
+// NewCrossLayerPrices creates cross-layer prices. The L1 oracle must be configured
+// with the L1 WETH as its numeraire and a registry of the L1 tokens.
+func NewCrossLayerPrices(config *CrossLayerPricesConfig, l1 ContractCaller, l1Oracle, l2Oracle interfaces.PriceOracle) (*CrossLayerPrices, error) {
+	if config == nil {
+		config = DefaultCrossLayerPricesConfig()
+	}
+	if l1 == nil || l1Oracle == nil || l2Oracle == nil {
+		return nil, fmt.Errorf("L1 client and both price oracles are required")
+	}
+
+	return &CrossLayerPrices{
+		config:   config,
+		l1:       l1,
+		l1Oracle: l1Oracle,
+		l2Oracle: l2Oracle,
+	}, nil
+}
+
+// Refresh reads every configured L1 pool into the L1 oracle. Pools that fail to
+// read keep their previous state and are reported together.
+func (p *CrossLayerPrices) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, pool := range p.config.Pools {
+		state, err := ReadPoolState(ctx, p.l1, pool.Address, pool.Protocol)
+		if err == nil {
+			err = p.l1Oracle.UpdatePool(state)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: %w", pool.Address.Hex(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetL1Price returns the L1 value in wei of CrossLayerPriceUnit raw units of the
+// L2 token's L1 counterpart
+func (p *CrossLayerPrices) GetL1Price(ctx context.Context, token string) (*big.Int, error) {
+	l2Token, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	l1Token, exists := p.config.L1Tokens[l2Token]
+	if !exists {
+		return nil, fmt.Errorf("token %s has no L1 counterpart", token)
+	}
+	return p.l1Oracle.ValueETH(l1Token, CrossLayerPriceUnit)
+}
+
+// GetL2Price returns the L2 value in wei of CrossLayerPriceUnit raw units of the token
+func (p *CrossLayerPrices) GetL2Price(ctx context.Context, token string) (*big.Int, error) {
+	l2Token, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return p.l2Oracle.ValueETH(l2Token, CrossLayerPriceUnit)
+}
+
+// ReadPoolState reads a Uniswap V2 or V3 style pool's tokens and reserves or
+// price and in-range liquidity
+func ReadPoolState(ctx context.Context, caller ContractCaller, pool common.Address, protocol interfaces.Protocol) (*interfaces.PoolState, error) {
+	call := func(method string) ([]interface{}, error) {
+		data, err := poolState.Pack(method)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+		}
+		result, err := caller.CallContract(ctx, ethereum.CallMsg{To: &pool, Data: data}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call %s: %w", method, err)
+		}
+		out, err := poolState.Unpack(method, result)
+		if err != nil || len(out) == 0 {
+			return nil, fmt.Errorf("failed to decode %s: %v", method, err)
+		}
+		return out, nil
+	}
+
+	state := &interfaces.PoolState{Protocol: protocol, Address: pool}
+	for _, method := range []string{"token0", "token1"} {
+		out, err := call(method)
+		if err != nil {
+			return nil, err
+		}
+		state.Tokens = append(state.Tokens, out[0].(common.Address))
+	}
+
+	switch protocol {
+	case interfaces.ProtocolUniswapV3, interfaces.ProtocolPancakeSwapV3, interfaces.ProtocolSushiSwapV3:
+		slot0, err := call("slot0")
+		if err != nil {
+			return nil, err
+		}
+		liquidity, err := call("liquidity")
+		if err != nil {
+			return nil, err
+		}
+		fee, err := call("fee")
+		if err != nil {
+			return nil, err
+		}
+		state.SqrtPriceX96 = slot0[0].(*big.Int)
+		state.Tick = int32(slot0[1].(*big.Int).Int64())
+		state.Liquidity = liquidity[0].(*big.Int)
+		state.Fee = uint32(fee[0].(*big.Int).Uint64())
+	case interfaces.ProtocolUniswapV2, interfaces.ProtocolSushiSwapV2, interfaces.ProtocolBaseSwap:
+		reserves, err := call("getReserves")
+		if err != nil {
+			return nil, err
+		}
+		state.Reserves = []*big.Int{reserves[0].(*big.Int), reserves[1].(*big.Int)}
+		state.Fee = 3000
+	default:
+		return nil, fmt.Errorf("cannot read %s pools", protocol)
+	}
+
+	return state, nil
+}
+
+// parseToken parses a token address given as hex
+func parseToken(token string) (common.Address, error) {
+	if !common.IsHexAddress(token) {
+		return common.Address{}, fmt.Errorf("invalid token address %q", token)
+	}
+	return common.HexToAddress(token), nil
+}
@@ -0,0 +1,119 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeV3Pool is the state an L1 Uniswap V3 pool returns from its getters
+type fakeV3Pool struct {
+	token0, token1 common.Address
+	sqrtPriceX96   *big.Int
+	liquidity      *big.Int
+	fee            uint32
+}
+
+// fakePoolCaller answers pool getter calls for a set of V3 pools
+type fakePoolCaller struct {
+	pools map[common.Address]*fakeV3Pool
+}
+
+func (f *fakePoolCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	pool, exists := f.pools[*call.To]
+	if !exists {
+		return nil, fmt.Errorf("execution reverted")
+	}
+	method, err := poolState.MethodById(call.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case "token0":
+		return method.Outputs.Pack(pool.token0)
+	case "token1":
+		return method.Outputs.Pack(pool.token1)
+	case "fee":
+		return method.Outputs.Pack(new(big.Int).SetUint64(uint64(pool.fee)))
+	case "liquidity":
+		return method.Outputs.Pack(pool.liquidity)
+	case "slot0":
+		return method.Outputs.Pack(pool.sqrtPriceX96, big.NewInt(0), uint16(0), uint16(1), uint16(1), uint8(0), true)
+	default:
+		return nil, fmt.Errorf("execution reverted")
+	}
+}
+
+// newL1Oracle creates an oracle numeraire'd in the L1 WETH
+func newL1Oracle(t *testing.T) interfaces.PriceOracle {
+	t.Helper()
+
+	tokens, err := NewTokenRegistry(nil, DefaultL1Tokens())
+	require.NoError(t, err)
+	oracle, err := NewPriceOracle(&OracleConfig{WETH: EthereumWETH, MaxHops: 1}, tokens, nil)
+	require.NoError(t, err)
+	return oracle
+}
+
+func TestCrossLayerPrices(t *testing.T) {
+	// L1 USDC at $3100 per ETH and cbETH at 1.05 ETH; the DAI pool can't be read
+	caller := &fakePoolCaller{pools: map[common.Address]*fakeV3Pool{
+		EthereumUSDCWETHPool: {
+			token0:       EthereumUSDC,
+			token1:       EthereumWETH,
+			sqrtPriceX96: sqrtPriceX96(1e12 / 3100.0),
+			liquidity:    mustBigInt(t, "30000000000000000000000"),
+			fee:          500,
+		},
+		EthereumCbETHWETHPool: {
+			token0:       EthereumCbETH,
+			token1:       EthereumWETH,
+			sqrtPriceX96: sqrtPriceX96(1.05),
+			liquidity:    mustBigInt(t, "5000000000000000000000"),
+			fee:          500,
+		},
+	}}
+
+	prices, err := NewCrossLayerPrices(nil, caller, newL1Oracle(t), newTestOracle(t))
+	require.NoError(t, err)
+
+	err = prices.Refresh(context.Background())
+	assert.ErrorContains(t, err, EthereumDAIWETHPool.Hex(), "unreadable pools are reported")
+
+	// 1e18 raw units of USDbC is 1e12 USDC, worth 1e12/3100 ETH on L1
+	l1USDC, err := prices.GetL1Price(context.Background(), BaseUSDbC.Hex())
+	require.NoError(t, err)
+	expected, _ := new(big.Float).Mul(big.NewFloat(1e12/3100.0), big.NewFloat(1e18)).Float64()
+	actual, _ := new(big.Float).SetInt(l1USDC).Float64()
+	assert.InEpsilon(t, expected, actual, 1e-6)
+
+	l1CbETH, err := prices.GetL1Price(context.Background(), BaseCbETH.Hex())
+	require.NoError(t, err)
+	actual, _ = new(big.Float).SetInt(l1CbETH).Float64()
+	assert.InEpsilon(t, 1.05e18, actual, 1e-6)
+
+	// On L2 cbETH trades at 1.1 ETH, so it's cheaper on L1
+	l2CbETH, err := prices.GetL2Price(context.Background(), BaseCbETH.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, 1, l2CbETH.Cmp(l1CbETH))
+
+	_, err = prices.GetL1Price(context.Background(), BaseAERO.Hex())
+	assert.Error(t, err, "AERO isn't bridged")
+	_, err = prices.GetL1Price(context.Background(), BaseDAI.Hex())
+	assert.Error(t, err, "DAI has no readable L1 pool")
+	_, err = prices.GetL2Price(context.Background(), "cbETH")
+	assert.Error(t, err)
+}
+
+func TestNewCrossLayerPrices_RequiresClients(t *testing.T) {
+	_, err := NewCrossLayerPrices(nil, nil, newL1Oracle(t), newTestOracle(t))
+	assert.Error(t, err)
+}
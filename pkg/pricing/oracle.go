@@ -0,0 +1,511 @@
+package pricing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+var (
+	// ErrNoPrice is returned when no sufficiently liquid pool path connects a token to WETH
+	ErrNoPrice = errors.New("no ETH price available for token")
+	// ErrNoUSDPrice is returned when no stablecoin is priced against WETH
+	ErrNoUSDPrice = errors.New("no USD price available for ETH")
+)
+
+var (
+	q96       = new(big.Int).Lsh(big.NewInt(1), 96)
+	q192      = new(big.Int).Lsh(big.NewInt(1), 192)
+	weiPerETH = big.NewInt(1e18)
+)
+
+// OracleConfig holds configuration for the price oracle
+type OracleConfig struct {
+	WETH         common.Address // Numeraire; the zero address is priced as WETH too
+	MinLiquidity *big.Int       // Pools holding less ETH value than this on the priced side are ignored
+	MaxHops      int            // Maximum pools between a token and WETH
+}
+
+// DefaultOracleConfig returns the default configuration for Base mainnet
+func DefaultOracleConfig() *OracleConfig {
+	return &OracleConfig{
+		WETH:         BaseWETH,
+		MinLiquidity: new(big.Int).Set(weiPerETH), // 1 ETH
+		MaxHops:      2,
+	}
+}
+
+// poolKey identifies a mirrored pool; V4 pools share their PoolManager's address
+type poolKey struct {
+	address common.Address
+	poolID  common.Hash
+}
+
+// tokenPrice is the value of a token's smallest unit in wei, with the ETH depth it was derived from
+type tokenPrice struct {
+	wei   *big.Rat
+	depth *big.Rat
+}
+
+// priceOracle implements the PriceOracle interface
+type priceOracle struct {
+	config *OracleConfig
+	tokens interfaces.TokenRegistry
+	pools  interfaces.PoolRegistry
+
+	mu     sync.RWMutex
+	states map[poolKey]*interfaces.PoolState
+	prices map[common.Address]*tokenPrice
+	ethUSD float64 // Zero until a stablecoin is priced
+	stale  bool
+}
+
+// NewPriceOracle creates a price oracle over a mirror of pool state. The pool
+// registry is optional; with it, events from pools that haven't been mirrored
+// yet are attributed to their tokens.
+func NewPriceOracle(config *OracleConfig, tokens interfaces.TokenRegistry, pools interfaces.PoolRegistry) (interfaces.PriceOracle, error) {
+	if config == nil {
+		config = DefaultOracleConfig()
+	}
+	if config.MinLiquidity == nil {
+		config.MinLiquidity = big.NewInt(0)
+	}
+	if config.MaxHops <= 0 {
+		config.MaxHops = 1
+	}
+	if tokens == nil {
+		return nil, fmt.Errorf("token registry is required")
+	}
+
+	return &priceOracle{
+		config: config,
+		tokens: tokens,
+		pools:  pools,
+		states: make(map[poolKey]*interfaces.PoolState),
+		prices: make(map[common.Address]*tokenPrice),
+	}, nil
+}
+
+// UpdatePool stores the latest state of a pool
+func (o *priceOracle) UpdatePool(state *interfaces.PoolState) error {
+	if state == nil {
+		return fmt.Errorf("pool state is required")
+	}
+	if len(state.Tokens) < 2 {
+		return fmt.Errorf("pool %s must have at least 2 tokens", state.Address.Hex())
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.states[poolKey{address: state.Address, poolID: state.PoolID}] = state
+	o.stale = true
+	return nil
+}
+
+// ApplyEvent refreshes a mirrored pool's reserves from a Sync event, or its
+// price and liquidity from a concentrated liquidity Swap event. Events from
+// pools that are neither mirrored nor in the pool registry are ignored.
+func (o *priceOracle) ApplyEvent(event *interfaces.ParsedEvent) error {
+	if event == nil {
+		return fmt.Errorf("event is required")
+	}
+
+	switch {
+	case event.SyncEvent != nil:
+		syncEvent := event.SyncEvent
+		if syncEvent.Reserve0 == nil || syncEvent.Reserve1 == nil {
+			return fmt.Errorf("missing reserves in sync event from %s", syncEvent.Pool.Hex())
+		}
+
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		state := o.mirroredPool(poolKey{address: syncEvent.Pool}, syncEvent.Protocol)
+		if state == nil {
+			return nil
+		}
+		state.Reserves = []*big.Int{syncEvent.Reserve0, syncEvent.Reserve1}
+		o.stale = true
+
+	case event.SwapEvent != nil && event.SwapEvent.SqrtPriceX96 != nil:
+		swap := event.SwapEvent
+
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		state := o.mirroredPool(poolKey{address: swap.Pool, poolID: swap.PoolID}, swap.Protocol)
+		if state == nil {
+			return nil
+		}
+		state.SqrtPriceX96 = swap.SqrtPriceX96
+		if swap.Liquidity != nil {
+			state.Liquidity = swap.Liquidity
+		}
+		if swap.Tick != nil {
+			state.Tick = int32(swap.Tick.Int64())
+		}
+		o.stale = true
+	}
+
+	return nil
+}
+
+// Fork returns a copy of the oracle whose mirror can be updated without
+// affecting this one
+func (o *priceOracle) Fork() interfaces.PriceOracle {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	fork := &priceOracle{
+		config: o.config,
+		tokens: o.tokens,
+		pools:  o.pools,
+		states: make(map[poolKey]*interfaces.PoolState, len(o.states)),
+		prices: make(map[common.Address]*tokenPrice),
+		stale:  true,
+	}
+	// Updates replace state fields rather than mutating them, so shallow copies suffice
+	for key, state := range o.states {
+		copied := *state
+		fork.states[key] = &copied
+	}
+	return fork
+}
+
+// Pools returns copies of the mirrored pools that trade the given token,
+// ordered by address
+func (o *priceOracle) Pools(token common.Address) []*interfaces.PoolState {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var pools []*interfaces.PoolState
+	for _, state := range o.states {
+		if state.TokenIndex(token) >= 0 {
+			copied := *state
+			pools = append(pools, &copied)
+		}
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		if pools[i].Address != pools[j].Address {
+			return bytes.Compare(pools[i].Address.Bytes(), pools[j].Address.Bytes()) < 0
+		}
+		return bytes.Compare(pools[i].PoolID.Bytes(), pools[j].PoolID.Bytes()) < 0
+	})
+	return pools
+}
+
+// PriceETH returns the value of one whole token in wei
+func (o *priceOracle) PriceETH(token common.Address) (*big.Int, error) {
+	unit, err := o.tokenUnit(token)
+	if err != nil {
+		return nil, err
+	}
+	return o.ValueETH(token, unit)
+}
+
+// PriceUSD returns the value of one whole token in USD
+func (o *priceOracle) PriceUSD(token common.Address) (float64, error) {
+	unit, err := o.tokenUnit(token)
+	if err != nil {
+		return 0, err
+	}
+	return o.ValueUSD(token, unit)
+}
+
+// ValueETH converts a raw token amount to wei, rounding down
+func (o *priceOracle) ValueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	if amount == nil {
+		return nil, fmt.Errorf("amount is required")
+	}
+	if o.isETH(token) {
+		return new(big.Int).Set(amount), nil
+	}
+
+	price, err := o.price(token)
+	if err != nil {
+		return nil, err
+	}
+
+	value := new(big.Rat).Mul(new(big.Rat).SetInt(amount), price.wei)
+	return new(big.Int).Quo(value.Num(), value.Denom()), nil
+}
+
+// ValueUSD converts a raw token amount to USD
+func (o *priceOracle) ValueUSD(token common.Address, amount *big.Int) (float64, error) {
+	wei, err := o.ValueETH(token, amount)
+	if err != nil {
+		return 0, err
+	}
+	return o.weiToUSD(wei)
+}
+
+// NormalizeOpportunity sets NetProfitETH to the expected profit's value less gas,
+// and NetProfitUSD to its USD value. NetProfitETH is still set when only the USD
+// price is unavailable.
+func (o *priceOracle) NormalizeOpportunity(opportunity *interfaces.MEVOpportunity) error {
+	if opportunity == nil {
+		return fmt.Errorf("opportunity is required")
+	}
+
+	netProfit, err := o.netProfit(opportunity.ProfitToken, opportunity.ExpectedProfit, opportunity.GasCost)
+	if err != nil {
+		return fmt.Errorf("failed to price opportunity %s: %w", opportunity.ID, err)
+	}
+	opportunity.NetProfitETH = netProfit
+
+	usd, err := o.weiToUSD(netProfit)
+	if err != nil {
+		return fmt.Errorf("failed to price opportunity %s: %w", opportunity.ID, err)
+	}
+	opportunity.NetProfitUSD = usd
+	return nil
+}
+
+// NormalizeTrade sets NetProfitETH to the actual profit's value less gas, and
+// NetProfitUSD to its USD value. A trade without an actual profit is priced as
+// a pure gas loss.
+func (o *priceOracle) NormalizeTrade(trade *interfaces.TradeResult) error {
+	if trade == nil {
+		return fmt.Errorf("trade is required")
+	}
+
+	netProfit, err := o.netProfit(trade.ProfitToken, trade.ActualProfit, trade.GasCost)
+	if err != nil {
+		return fmt.Errorf("failed to price trade %s: %w", trade.ID, err)
+	}
+	trade.NetProfitETH = netProfit
+
+	usd, err := o.weiToUSD(netProfit)
+	if err != nil {
+		return fmt.Errorf("failed to price trade %s: %w", trade.ID, err)
+	}
+	trade.NetProfitUSD = usd
+	return nil
+}
+
+// netProfit converts a gross profit in token to wei and subtracts the gas cost
+func (o *priceOracle) netProfit(token common.Address, grossProfit, gasCost *big.Int) (*big.Int, error) {
+	netProfit := big.NewInt(0)
+	if grossProfit != nil {
+		value, err := o.ValueETH(token, grossProfit)
+		if err != nil {
+			return nil, err
+		}
+		netProfit = value
+	}
+	if gasCost != nil {
+		netProfit.Sub(netProfit, gasCost)
+	}
+	return netProfit, nil
+}
+
+// weiToUSD converts an amount of wei to USD at the current ETH price
+func (o *priceOracle) weiToUSD(wei *big.Int) (float64, error) {
+	o.refresh()
+
+	o.mu.RLock()
+	ethUSD := o.ethUSD
+	o.mu.RUnlock()
+
+	if ethUSD == 0 {
+		return 0, ErrNoUSDPrice
+	}
+
+	eth, _ := new(big.Rat).SetFrac(wei, weiPerETH).Float64()
+	return eth * ethUSD, nil
+}
+
+// price returns the derived price of a token
+func (o *priceOracle) price(token common.Address) (*tokenPrice, error) {
+	o.refresh()
+
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	price, exists := o.prices[token]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNoPrice, token.Hex())
+	}
+	return price, nil
+}
+
+// tokenUnit returns one whole token in raw units
+func (o *priceOracle) tokenUnit(token common.Address) (*big.Int, error) {
+	if o.isETH(token) {
+		return new(big.Int).Set(weiPerETH), nil
+	}
+
+	info, exists := o.tokens.GetToken(token)
+	if !exists {
+		return nil, fmt.Errorf("token %s is not registered", token.Hex())
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(info.Decimals)), nil), nil
+}
+
+// isETH reports whether a token is native ETH or WETH
+func (o *priceOracle) isETH(token common.Address) bool {
+	return token == (common.Address{}) || token == o.config.WETH
+}
+
+// mirroredPool returns the mirrored state of a pool, creating it from the pool
+// registry when possible. Callers must hold o.mu.
+func (o *priceOracle) mirroredPool(key poolKey, protocol interfaces.Protocol) *interfaces.PoolState {
+	if state, exists := o.states[key]; exists {
+		return state
+	}
+	if o.pools == nil || key.poolID != (common.Hash{}) {
+		return nil
+	}
+
+	info, exists := o.pools.GetPool(key.address)
+	if !exists {
+		return nil
+	}
+
+	state := &interfaces.PoolState{
+		Protocol:    protocol,
+		Address:     info.Address,
+		Tokens:      []common.Address{info.Token0, info.Token1},
+		Fee:         info.Fee,
+		TickSpacing: info.TickSpacing,
+	}
+	o.states[key] = state
+	return state
+}
+
+// refresh re-derives prices if the mirror changed since they were last computed.
+// Prices spread outward from WETH one pool per hop; when several pools price a
+// token, the one with the most ETH value on its priced side wins.
+func (o *priceOracle) refresh() {
+	o.mu.RLock()
+	stale := o.stale
+	o.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if !o.stale {
+		return
+	}
+
+	minLiquidity := new(big.Rat).SetInt(o.config.MinLiquidity)
+	prices := map[common.Address]*tokenPrice{
+		o.config.WETH: {wei: big.NewRat(1, 1), depth: new(big.Rat)},
+	}
+
+	for hop := 0; hop < o.config.MaxHops; hop++ {
+		candidates := make(map[common.Address]*tokenPrice)
+		for _, state := range o.states {
+			spot, reserves, ok := spotPrice(state)
+			if !ok {
+				continue
+			}
+
+			for known := 0; known < 2; known++ {
+				other := 1 - known
+				knownPrice, priced := prices[state.Tokens[known]]
+				if !priced {
+					continue
+				}
+				if _, priced := prices[state.Tokens[other]]; priced {
+					continue
+				}
+
+				depth := new(big.Rat).Mul(reserves[known], knownPrice.wei)
+				if depth.Cmp(minLiquidity) < 0 {
+					continue
+				}
+
+				// spot is token1 per token0, so one unit of token0 is worth spot units of token1
+				rate := spot
+				if known == 0 {
+					rate = new(big.Rat).Inv(spot)
+				}
+
+				candidate, exists := candidates[state.Tokens[other]]
+				if !exists || depth.Cmp(candidate.depth) > 0 {
+					candidates[state.Tokens[other]] = &tokenPrice{
+						wei:   new(big.Rat).Mul(knownPrice.wei, rate),
+						depth: depth,
+					}
+				}
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		for token, price := range candidates {
+			prices[token] = price
+		}
+	}
+
+	// ETH/USD comes from the stablecoin priced through the deepest pool
+	ethUSD := 0.0
+	var deepest *big.Rat
+	for token, price := range prices {
+		info, exists := o.tokens.GetToken(token)
+		if !exists || !info.Stablecoin || price.wei.Sign() == 0 {
+			continue
+		}
+		if deepest != nil && price.depth.Cmp(deepest) <= 0 {
+			continue
+		}
+
+		unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(info.Decimals)), nil)
+		weiPerDollar := new(big.Rat).Mul(price.wei, new(big.Rat).SetInt(unit))
+		ethUSD, _ = new(big.Rat).Quo(new(big.Rat).SetInt(weiPerETH), weiPerDollar).Float64()
+		deepest = price.depth
+	}
+
+	o.prices = prices
+	o.ethUSD = ethUSD
+	o.stale = false
+}
+
+// spotPrice returns a two-token pool's price of token0 in token1 raw units and
+// the reserves backing each side. Concentrated liquidity pools report virtual
+// reserves within the current tick range.
+func spotPrice(state *interfaces.PoolState) (*big.Rat, []*big.Rat, bool) {
+	if len(state.Tokens) != 2 || state.Amplification != nil {
+		return nil, nil, false
+	}
+
+	if state.SqrtPriceX96 != nil && state.SqrtPriceX96.Sign() > 0 {
+		if state.Liquidity == nil || state.Liquidity.Sign() <= 0 {
+			return nil, nil, false
+		}
+
+		sqrtPrice := state.SqrtPriceX96
+		spot := new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPrice, sqrtPrice), q192)
+		liquidity := new(big.Rat).SetInt(state.Liquidity)
+		reserves := []*big.Rat{
+			new(big.Rat).Mul(liquidity, new(big.Rat).SetFrac(q96, sqrtPrice)),
+			new(big.Rat).Mul(liquidity, new(big.Rat).SetFrac(sqrtPrice, q96)),
+		}
+		return spot, reserves, true
+	}
+
+	if len(state.Reserves) != 2 || state.Reserves[0] == nil || state.Reserves[1] == nil {
+		return nil, nil, false
+	}
+	if state.Reserves[0].Sign() <= 0 || state.Reserves[1].Sign() <= 0 {
+		return nil, nil, false
+	}
+
+	spot := new(big.Rat).SetFrac(state.Reserves[1], state.Reserves[0])
+	reserves := []*big.Rat{
+		new(big.Rat).SetInt(state.Reserves[0]),
+		new(big.Rat).SetInt(state.Reserves[1]),
+	}
+	return spot, reserves, true
+}
@@ -0,0 +1,304 @@
+package pricing
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	wethUSDCPool     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	thinWETHUSDCPool = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	usdcAEROPool     = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	cbETHWETHPool    = common.HexToAddress("0x1000000000000000000000000000000000000004")
+	thinDAIWETHPool  = common.HexToAddress("0x1000000000000000000000000000000000000005")
+)
+
+// fakePoolRegistry serves GetPool from a fixed set of pools
+type fakePoolRegistry struct {
+	interfaces.PoolRegistry
+	pools map[common.Address]*interfaces.PoolInfo
+}
+
+func (f *fakePoolRegistry) GetPool(address common.Address) (*interfaces.PoolInfo, bool) {
+	pool, exists := f.pools[address]
+	return pool, exists
+}
+
+// mustBigInt parses a decimal integer
+func mustBigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+	n, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok, "invalid integer %s", value)
+	return n
+}
+
+// sqrtPriceX96 returns sqrt(price) * 2^96 for a token1-per-token0 raw price
+func sqrtPriceX96(price float64) *big.Int {
+	sqrt := new(big.Float).SetPrec(256).Sqrt(big.NewFloat(price))
+	sqrt.Mul(sqrt, new(big.Float).SetInt(q96))
+	result, _ := sqrt.Int(nil)
+	return result
+}
+
+// newTestOracle creates an oracle over a small Base token graph:
+// WETH/USDC at $3000, AERO/USDC at $0.50, cbETH/WETH at 1.1 and a thin DAI/WETH pool.
+func newTestOracle(t *testing.T) interfaces.PriceOracle {
+	t.Helper()
+
+	tokens, err := NewTokenRegistry(nil, DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	states := []*interfaces.PoolState{
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  wethUSDCPool,
+			Tokens:   []common.Address{BaseWETH, BaseUSDC},
+			Reserves: []*big.Int{mustBigInt(t, "100000000000000000000"), mustBigInt(t, "300000000000")}, // 100 WETH, 300k USDC
+		},
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  thinWETHUSDCPool,
+			Tokens:   []common.Address{BaseWETH, BaseUSDC},
+			Reserves: []*big.Int{mustBigInt(t, "2000000000000000000"), mustBigInt(t, "4000000000")}, // 2 WETH, 4k USDC
+		},
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  usdcAEROPool,
+			Tokens:   []common.Address{BaseUSDC, BaseAERO},
+			Reserves: []*big.Int{mustBigInt(t, "1000000000000"), mustBigInt(t, "2000000000000000000000000")}, // 1M USDC, 2M AERO
+		},
+		{
+			Protocol:     interfaces.ProtocolUniswapV3,
+			Address:      cbETHWETHPool,
+			Tokens:       []common.Address{BaseCbETH, BaseWETH},
+			SqrtPriceX96: sqrtPriceX96(1.1),
+			Liquidity:    mustBigInt(t, "1000000000000000000000"),
+		},
+		{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Address:  thinDAIWETHPool,
+			Tokens:   []common.Address{BaseDAI, BaseWETH},
+			Reserves: []*big.Int{mustBigInt(t, "1000000000000000000000"), mustBigInt(t, "100000000000000000")}, // 1000 DAI, 0.1 WETH
+		},
+	}
+	for _, state := range states {
+		require.NoError(t, oracle.UpdatePool(state))
+	}
+
+	return oracle
+}
+
+func TestPriceOracle_Prices(t *testing.T) {
+	oracle := newTestOracle(t)
+
+	tests := []struct {
+		name     string
+		token    common.Address
+		priceUSD float64
+	}{
+		{"native ETH", common.Address{}, 3000},
+		{"WETH", BaseWETH, 3000},
+		{"USDC from the deepest WETH pool", BaseUSDC, 1},
+		{"AERO two hops from WETH", BaseAERO, 0.5},
+		{"cbETH from a concentrated liquidity pool", BaseCbETH, 3300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := oracle.PriceUSD(tt.token)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.priceUSD, price, tt.priceUSD*1e-6)
+		})
+	}
+
+	cbETHPrice, err := oracle.PriceETH(BaseCbETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.1e18, float64(cbETHPrice.Int64()), 1e9)
+
+	// 1500 USDC is half an ETH
+	value, err := oracle.ValueETH(BaseUSDC, big.NewInt(1500_000000))
+	require.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "500000000000000000"), value)
+
+	// DAI only trades in a pool below the liquidity floor
+	_, err = oracle.PriceETH(BaseDAI)
+	assert.True(t, errors.Is(err, ErrNoPrice))
+
+	// Tokens missing from the registry have no decimals to scale by
+	_, err = oracle.PriceUSD(common.HexToAddress("0x3333333333333333333333333333333333333333"))
+	assert.Error(t, err)
+}
+
+func TestPriceOracle_ApplyEvent(t *testing.T) {
+	oracle := newTestOracle(t)
+
+	// A Sync from a mirrored pool moves ETH to $3500
+	err := oracle.ApplyEvent(&interfaces.ParsedEvent{
+		SyncEvent: &interfaces.SyncEvent{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Pool:     wethUSDCPool,
+			Reserve0: mustBigInt(t, "100000000000000000000"),
+			Reserve1: mustBigInt(t, "350000000000"),
+		},
+	})
+	require.NoError(t, err)
+
+	price, err := oracle.PriceUSD(BaseWETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 3500, price, 1e-6)
+
+	// A Swap moves the concentrated liquidity pool to 1.2 WETH per cbETH
+	err = oracle.ApplyEvent(&interfaces.ParsedEvent{
+		SwapEvent: &interfaces.SwapEvent{
+			Protocol:     interfaces.ProtocolUniswapV3,
+			Pool:         cbETHWETHPool,
+			SqrtPriceX96: sqrtPriceX96(1.2),
+			Liquidity:    mustBigInt(t, "1000000000000000000000"),
+			Tick:         big.NewInt(1823),
+		},
+	})
+	require.NoError(t, err)
+
+	cbETHPrice, err := oracle.PriceETH(BaseCbETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.2e18, float64(cbETHPrice.Int64()), 1e9)
+
+	// Events from unknown pools are ignored
+	err = oracle.ApplyEvent(&interfaces.ParsedEvent{
+		SyncEvent: &interfaces.SyncEvent{
+			Pool:     common.HexToAddress("0x4444444444444444444444444444444444444444"),
+			Reserve0: big.NewInt(1),
+			Reserve1: big.NewInt(1),
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Error(t, oracle.ApplyEvent(&interfaces.ParsedEvent{SyncEvent: &interfaces.SyncEvent{Pool: wethUSDCPool}}))
+}
+
+func TestPriceOracle_ApplyEventFromPoolRegistry(t *testing.T) {
+	tokens, err := NewTokenRegistry(nil, DefaultTokens())
+	require.NoError(t, err)
+
+	pools := &fakePoolRegistry{pools: map[common.Address]*interfaces.PoolInfo{
+		wethUSDCPool: {Address: wethUSDCPool, Protocol: interfaces.ProtocolUniswapV2, Token0: BaseWETH, Token1: BaseUSDC, Fee: 3000},
+	}}
+	oracle, err := NewPriceOracle(nil, tokens, pools)
+	require.NoError(t, err)
+
+	_, err = oracle.PriceETH(BaseUSDC)
+	assert.True(t, errors.Is(err, ErrNoPrice))
+
+	err = oracle.ApplyEvent(&interfaces.ParsedEvent{
+		SyncEvent: &interfaces.SyncEvent{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Pool:     wethUSDCPool,
+			Reserve0: mustBigInt(t, "100000000000000000000"),
+			Reserve1: mustBigInt(t, "250000000000"),
+		},
+	})
+	require.NoError(t, err)
+
+	price, err := oracle.PriceUSD(BaseWETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 2500, price, 1e-6)
+}
+
+func TestPriceOracle_Normalize(t *testing.T) {
+	oracle := newTestOracle(t)
+
+	// 300 USDC of profit less 0.01 ETH of gas
+	opportunity := &interfaces.MEVOpportunity{
+		ID:             "backrun-1",
+		ExpectedProfit: big.NewInt(300_000000),
+		GasCost:        mustBigInt(t, "10000000000000000"),
+		ProfitToken:    BaseUSDC,
+	}
+	assert.Nil(t, opportunity.NetProfitWei())
+	require.NoError(t, oracle.NormalizeOpportunity(opportunity))
+	assert.Equal(t, mustBigInt(t, "90000000000000000"), opportunity.NetProfitWei())
+	assert.InDelta(t, 270, opportunity.NetProfitUSD, 1e-6)
+
+	// A failed trade in ETH is a pure gas loss
+	trade := &interfaces.TradeResult{
+		ID:      "trade-1",
+		GasCost: mustBigInt(t, "20000000000000000"),
+	}
+	require.NoError(t, oracle.NormalizeTrade(trade))
+	assert.Equal(t, mustBigInt(t, "-20000000000000000"), trade.NetProfitETH)
+	assert.InDelta(t, -60, trade.NetProfitUSD, 1e-6)
+
+	// Profits in unpriced tokens stay unnormalized
+	trade = &interfaces.TradeResult{
+		ID:           "trade-2",
+		ActualProfit: big.NewInt(1000),
+		ProfitToken:  BaseDAI,
+	}
+	assert.Error(t, oracle.NormalizeTrade(trade))
+	assert.Nil(t, trade.NetProfitWei())
+}
+
+func TestPriceOracle_NoUSDPrice(t *testing.T) {
+	tokens, err := NewTokenRegistry(nil, DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	_, err = oracle.PriceUSD(BaseWETH)
+	assert.True(t, errors.Is(err, ErrNoUSDPrice))
+
+	// The ETH value is still reported without a USD price
+	opportunity := &interfaces.MEVOpportunity{ID: "sandwich-1", ExpectedProfit: big.NewInt(1000), GasCost: big.NewInt(400)}
+	err = oracle.NormalizeOpportunity(opportunity)
+	assert.True(t, errors.Is(err, ErrNoUSDPrice))
+	assert.Equal(t, big.NewInt(600), opportunity.NetProfitETH)
+
+	_, err = NewPriceOracle(nil, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestPriceOracle_Fork(t *testing.T) {
+	oracle := newTestOracle(t)
+	fork := oracle.Fork()
+
+	err := fork.ApplyEvent(&interfaces.ParsedEvent{
+		SyncEvent: &interfaces.SyncEvent{
+			Pool:     wethUSDCPool,
+			Reserve0: mustBigInt(t, "100000000000000000000"),
+			Reserve1: mustBigInt(t, "200000000000"),
+		},
+	})
+	require.NoError(t, err)
+
+	forkPrice, err := fork.PriceUSD(BaseWETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 2000, forkPrice, 1e-6)
+
+	// The original mirror is untouched
+	price, err := oracle.PriceUSD(BaseWETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 3000, price, 1e-6)
+}
+
+func TestPriceOracle_Pools(t *testing.T) {
+	oracle := newTestOracle(t)
+
+	pools := oracle.Pools(BaseUSDC)
+	require.Len(t, pools, 3)
+	addresses := []common.Address{pools[0].Address, pools[1].Address, pools[2].Address}
+	assert.ElementsMatch(t, []common.Address{wethUSDCPool, thinWETHUSDCPool, usdcAEROPool}, addresses)
+
+	// Returned states are copies
+	pools[0].Reserves = nil
+	assert.NotNil(t, oracle.Pools(BaseUSDC)[0].Reserves)
+
+	assert.Empty(t, oracle.Pools(common.HexToAddress("0x0000000000000000000000000000000000000bad")))
+}
@@ -0,0 +1,240 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Base mainnet token deployments
+var (
+	BaseWETH    = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	BaseUSDC    = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	BaseUSDbC   = common.HexToAddress("0xd9aAEc86B65D86f6A7B5B1b0c42FFA531710b6CA")
+	BaseDAI     = common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb")
+	BaseCbETH   = common.HexToAddress("0x2Ae3F1Ec7F1F5012CFEab0185bfc7aa3cf0DEc22")
+	BaseAERO    = common.HexToAddress("0x940181a94A35A4569E4529A3CDfB74e38FD98631")
+	BaseUSDPlus = common.HexToAddress("0xB79DD08EA68A908A97220C76d19A6aA9cBDE4376")
+)
+
+// erc20MetadataABI covers the metadata getters used to resolve unknown tokens
+const erc20MetadataABI = `[
+	{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+	{"inputs":[],"name":"symbol","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"}
+]`
+
+// erc20SymbolBytes32ABI covers tokens such as MKR that return symbol as bytes32
+const erc20SymbolBytes32ABI = `[
+	{"inputs":[],"name":"symbol","outputs":[{"name":"","type":"bytes32"}],"stateMutability":"view","type":"function"}
+]`
+
+// ContractCaller is the subset of an Ethereum client needed to read token metadata
+type ContractCaller interface {
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// DefaultTokens returns metadata for the major tokens on Base mainnet
+func DefaultTokens() []*interfaces.TokenInfo {
+	return []*interfaces.TokenInfo{
+		{Address: BaseWETH, Symbol: "WETH", Decimals: 18},
+		{Address: BaseUSDC, Symbol: "USDC", Decimals: 6, Stablecoin: true},
+		{Address: BaseUSDbC, Symbol: "USDbC", Decimals: 6, Stablecoin: true},
+		{Address: BaseDAI, Symbol: "DAI", Decimals: 18, Stablecoin: true},
+		{Address: BaseCbETH, Symbol: "cbETH", Decimals: 18},
+		{Address: BaseAERO, Symbol: "AERO", Decimals: 18},
+		{Address: BaseUSDPlus, Symbol: "USD+", Decimals: 6, Rebasing: true},
+	}
+}
+
+// tokenRegistry implements the TokenRegistry interface
+type tokenRegistry struct {
+	caller      ContractCaller
+	metadataABI abi.ABI
+	symbolABI   abi.ABI
+
+	mu        sync.RWMutex
+	byAddress map[common.Address]*interfaces.TokenInfo
+	bySymbol  map[string]*interfaces.TokenInfo
+}
+
+// NewTokenRegistry creates a token registry seeded with the given tokens. The
+// contract caller is optional; without it Resolve only returns registered tokens.
+func NewTokenRegistry(caller ContractCaller, tokens []*interfaces.TokenInfo) (interfaces.TokenRegistry, error) {
+	metadataABI, err := abi.JSON(strings.NewReader(erc20MetadataABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-20 metadata ABI: %w", err)
+	}
+	symbolABI, err := abi.JSON(strings.NewReader(erc20SymbolBytes32ABI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ERC-20 symbol ABI: %w", err)
+	}
+
+	r := &tokenRegistry{
+		caller:      caller,
+		metadataABI: metadataABI,
+		symbolABI:   symbolABI,
+		byAddress:   make(map[common.Address]*interfaces.TokenInfo),
+		bySymbol:    make(map[string]*interfaces.TokenInfo),
+	}
+
+	for _, token := range tokens {
+		if err := r.Register(token); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Register adds or replaces a token. Symbols are matched case-insensitively and
+// the first token registered under a symbol keeps it.
+func (r *tokenRegistry) Register(token *interfaces.TokenInfo) error {
+	if token == nil {
+		return fmt.Errorf("token is required")
+	}
+	if token.Address == (common.Address{}) {
+		return fmt.Errorf("token address is required")
+	}
+
+	info := *token
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.byAddress[info.Address] = &info
+	if info.Symbol != "" {
+		symbol := strings.ToUpper(info.Symbol)
+		if existing, exists := r.bySymbol[symbol]; !exists || existing.Address == info.Address {
+			r.bySymbol[symbol] = &info
+		}
+	}
+
+	return nil
+}
+
+// GetToken returns a registered token by address
+func (r *tokenRegistry) GetToken(address common.Address) (*interfaces.TokenInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.byAddress[address]
+	return token, exists
+}
+
+// GetTokenBySymbol returns a registered token by symbol
+func (r *tokenRegistry) GetTokenBySymbol(symbol string) (*interfaces.TokenInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	token, exists := r.bySymbol[strings.ToUpper(symbol)]
+	return token, exists
+}
+
+// Resolve returns a registered token, reading and registering its metadata from
+// chain when it is unknown. Fee-on-transfer and rebasing behaviour can't be
+// read from chain and must be registered explicitly.
+func (r *tokenRegistry) Resolve(ctx context.Context, address common.Address) (*interfaces.TokenInfo, error) {
+	if token, exists := r.GetToken(address); exists {
+		return token, nil
+	}
+	if r.caller == nil {
+		return nil, fmt.Errorf("token %s is not registered", address.Hex())
+	}
+
+	decimalsOut, err := r.call(ctx, &r.metadataABI, address, "decimals")
+	if err != nil {
+		return nil, err
+	}
+	decimals, ok := decimalsOut[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected decimals type %T for token %s", decimalsOut[0], address.Hex())
+	}
+
+	symbol, err := r.resolveSymbol(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	token := &interfaces.TokenInfo{
+		Address:  address,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}
+	if err := r.Register(token); err != nil {
+		return nil, err
+	}
+
+	resolved, _ := r.GetToken(address)
+	return resolved, nil
+}
+
+// Tokens returns every registered token ordered by symbol
+func (r *tokenRegistry) Tokens() []*interfaces.TokenInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := make([]*interfaces.TokenInfo, 0, len(r.byAddress))
+	for _, token := range r.byAddress {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Symbol != tokens[j].Symbol {
+			return tokens[i].Symbol < tokens[j].Symbol
+		}
+		return tokens[i].Address.Hex() < tokens[j].Address.Hex()
+	})
+	return tokens
+}
+
+// resolveSymbol reads a token symbol, accepting both string and bytes32 encodings
+func (r *tokenRegistry) resolveSymbol(ctx context.Context, address common.Address) (string, error) {
+	out, err := r.call(ctx, &r.metadataABI, address, "symbol")
+	if err == nil {
+		if symbol, ok := out[0].(string); ok {
+			return symbol, nil
+		}
+	}
+
+	out, bytesErr := r.call(ctx, &r.symbolABI, address, "symbol")
+	if bytesErr != nil {
+		if err != nil {
+			return "", err
+		}
+		return "", bytesErr
+	}
+	symbol, ok := out[0].([32]byte)
+	if !ok {
+		return "", fmt.Errorf("unexpected symbol type %T for token %s", out[0], address.Hex())
+	}
+	return strings.TrimRight(string(symbol[:]), "\x00"), nil
+}
+
+// call invokes a view method on a token and unpacks its outputs
+func (r *tokenRegistry) call(ctx context.Context, contractABI *abi.ABI, address common.Address, method string) ([]interface{}, error) {
+	data, err := contractABI.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s call: %w", method, err)
+	}
+
+	result, err := r.caller.CallContract(ctx, ethereum.CallMsg{To: &address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s on token %s: %w", method, address.Hex(), err)
+	}
+
+	out, err := contractABI.Unpack(method, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s of token %s: %w", method, address.Hex(), err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("empty %s result from token %s", method, address.Hex())
+	}
+	return out, nil
+}
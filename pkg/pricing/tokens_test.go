@@ -0,0 +1,128 @@
+package pricing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTokenCaller answers decimals and symbol calls for a single token
+type fakeTokenCaller struct {
+	token         common.Address
+	decimals      uint8
+	symbol        string
+	bytes32Symbol bool
+	calls         int
+}
+
+func (f *fakeTokenCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	f.calls++
+	if call.To == nil || *call.To != f.token {
+		return nil, fmt.Errorf("execution reverted")
+	}
+
+	metadataABI, _ := abi.JSON(strings.NewReader(erc20MetadataABI))
+	symbolABI, _ := abi.JSON(strings.NewReader(erc20SymbolBytes32ABI))
+
+	switch {
+	case bytes.Equal(call.Data, metadataABI.Methods["decimals"].ID):
+		return metadataABI.Methods["decimals"].Outputs.Pack(f.decimals)
+	case bytes.Equal(call.Data, metadataABI.Methods["symbol"].ID) && f.bytes32Symbol:
+		var symbol [32]byte
+		copy(symbol[:], f.symbol)
+		return symbolABI.Methods["symbol"].Outputs.Pack(symbol)
+	case bytes.Equal(call.Data, metadataABI.Methods["symbol"].ID):
+		return metadataABI.Methods["symbol"].Outputs.Pack(f.symbol)
+	default:
+		return nil, fmt.Errorf("execution reverted")
+	}
+}
+
+func TestTokenRegistry_DefaultTokens(t *testing.T) {
+	registry, err := NewTokenRegistry(nil, DefaultTokens())
+	require.NoError(t, err)
+
+	usdc, exists := registry.GetToken(BaseUSDC)
+	require.True(t, exists)
+	assert.Equal(t, "USDC", usdc.Symbol)
+	assert.Equal(t, uint8(6), usdc.Decimals)
+	assert.True(t, usdc.Stablecoin)
+
+	usdPlus, exists := registry.GetTokenBySymbol("usd+")
+	require.True(t, exists)
+	assert.True(t, usdPlus.Rebasing)
+
+	assert.Len(t, registry.Tokens(), len(DefaultTokens()))
+}
+
+func TestTokenRegistry_Register(t *testing.T) {
+	registry, err := NewTokenRegistry(nil, nil)
+	require.NoError(t, err)
+
+	assert.Error(t, registry.Register(nil))
+	assert.Error(t, registry.Register(&interfaces.TokenInfo{Symbol: "NOADDR"}))
+
+	fot := &interfaces.TokenInfo{Address: common.HexToAddress("0x01"), Symbol: "TAX", Decimals: 9, FeeOnTransfer: true}
+	require.NoError(t, registry.Register(fot))
+
+	// The registry keeps its own copy
+	fot.Decimals = 18
+	token, exists := registry.GetToken(fot.Address)
+	require.True(t, exists)
+	assert.Equal(t, uint8(9), token.Decimals)
+	assert.True(t, token.FeeOnTransfer)
+
+	// A second token can't take over a registered symbol
+	require.NoError(t, registry.Register(&interfaces.TokenInfo{Address: common.HexToAddress("0x02"), Symbol: "tax", Decimals: 18}))
+	token, exists = registry.GetTokenBySymbol("TAX")
+	require.True(t, exists)
+	assert.Equal(t, common.HexToAddress("0x01"), token.Address)
+}
+
+func TestTokenRegistry_Resolve(t *testing.T) {
+	token := common.HexToAddress("0x1111111111111111111111111111111111111111")
+
+	tests := []struct {
+		name   string
+		caller *fakeTokenCaller
+	}{
+		{"string symbol", &fakeTokenCaller{token: token, decimals: 8, symbol: "WBTC"}},
+		{"bytes32 symbol", &fakeTokenCaller{token: token, decimals: 8, symbol: "WBTC", bytes32Symbol: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewTokenRegistry(tt.caller, nil)
+			require.NoError(t, err)
+
+			info, err := registry.Resolve(context.Background(), token)
+			require.NoError(t, err)
+			assert.Equal(t, "WBTC", info.Symbol)
+			assert.Equal(t, uint8(8), info.Decimals)
+
+			// Resolved tokens are cached
+			calls := tt.caller.calls
+			_, err = registry.Resolve(context.Background(), token)
+			require.NoError(t, err)
+			assert.Equal(t, calls, tt.caller.calls)
+
+			_, err = registry.Resolve(context.Background(), common.HexToAddress("0x2222222222222222222222222222222222222222"))
+			assert.Error(t, err)
+		})
+	}
+
+	registry, err := NewTokenRegistry(nil, nil)
+	require.NoError(t, err)
+	_, err = registry.Resolve(context.Background(), token)
+	assert.Error(t, err)
+}
@@ -6,22 +6,22 @@ import (
 	"sync"
 	"time"
 
-	"math/big"
-
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 )
 
-// ConcurrentStrategyProcessor handles parallel strategy detection across multiple opportunities
+// ConcurrentStrategyProcessor handles parallel strategy detection across multiple
+// opportunities. It dispatches each input to the registered strategies accepting it.
 type ConcurrentStrategyProcessor struct {
-	workerPool         interfaces.WorkerPool
-	sandwichDetector   interfaces.SandwichDetector
-	backrunDetector    interfaces.BackrunDetector
-	frontrunDetector   interfaces.FrontrunDetector
-	timeBanditDetector interfaces.TimeBanditDetector
-	latencyMonitor     interfaces.LatencyMonitor
-	mu                 sync.RWMutex
-	running            bool
+	workerPool     interfaces.WorkerPool
+	registry       interfaces.StrategyRegistry
+	resolver       *OpportunityResolver
+	latencyMonitor interfaces.LatencyMonitor
+	priceOracle    interfaces.PriceOracle
+	enabled        map[interfaces.StrategyType]bool
+	mu             sync.RWMutex
+	running        bool
 }
 
 // ConcurrentStrategyConfig holds configuration for concurrent strategy processing
@@ -31,9 +31,11 @@ type ConcurrentStrategyConfig struct {
 	ProcessingTimeout time.Duration             `json:"processing_timeout"`
 	EnabledStrategies []interfaces.StrategyType `json:"enabled_strategies"`
 	MaxConcurrentOps  int                       `json:"max_concurrent_ops"`
+	Resolver          *ResolverConfig           `json:"resolver"`
 }
 
-// DefaultConcurrentStrategyConfig returns default configuration
+// DefaultConcurrentStrategyConfig returns default configuration. Sniping, liquidation
+// and cross-layer arbitrage stay off until enabled.
 func DefaultConcurrentStrategyConfig() *ConcurrentStrategyConfig {
 	return &ConcurrentStrategyConfig{
 		WorkerPoolSize:    15,
@@ -44,18 +46,18 @@ func DefaultConcurrentStrategyConfig() *ConcurrentStrategyConfig {
 			interfaces.StrategyBackrun,
 			interfaces.StrategyFrontrun,
 			interfaces.StrategyTimeBandit,
+			interfaces.StrategyJIT,
 		},
 		MaxConcurrentOps: 100,
+		Resolver:         DefaultResolverConfig(),
 	}
 }
 
-// NewConcurrentStrategyProcessor creates a new concurrent strategy processor
+// NewConcurrentStrategyProcessor creates a new concurrent strategy processor over
+// the strategies in a registry. Strategies registered later are dispatched too.
 func NewConcurrentStrategyProcessor(
 	config *ConcurrentStrategyConfig,
-	sandwichDetector interfaces.SandwichDetector,
-	backrunDetector interfaces.BackrunDetector,
-	frontrunDetector interfaces.FrontrunDetector,
-	timeBanditDetector interfaces.TimeBanditDetector,
+	registry interfaces.StrategyRegistry,
 	latencyMonitor interfaces.LatencyMonitor,
 ) *ConcurrentStrategyProcessor {
 	if config == nil {
@@ -63,11 +65,13 @@ func NewConcurrentStrategyProcessor(
 	}
 
 	csp := &ConcurrentStrategyProcessor{
-		sandwichDetector:   sandwichDetector,
-		backrunDetector:    backrunDetector,
-		frontrunDetector:   frontrunDetector,
-		timeBanditDetector: timeBanditDetector,
-		latencyMonitor:     latencyMonitor,
+		registry:       registry,
+		resolver:       NewOpportunityResolver(config.Resolver),
+		latencyMonitor: latencyMonitor,
+		enabled:        make(map[interfaces.StrategyType]bool, len(config.EnabledStrategies)),
+	}
+	for _, strategy := range config.EnabledStrategies {
+		csp.enabled[strategy] = true
 	}
 
 	// Initialize worker pool for strategy processing
@@ -82,6 +86,118 @@ func NewConcurrentStrategyProcessor(
 	return csp
 }
 
+// SetPriceOracle sets the oracle used to report opportunity profits in ETH and USD
+func (csp *ConcurrentStrategyProcessor) SetPriceOracle(oracle interfaces.PriceOracle) {
+	csp.mu.Lock()
+	defer csp.mu.Unlock()
+
+	csp.priceOracle = oracle
+}
+
+// EnableStrategy turns on detection for a strategy
+func (csp *ConcurrentStrategyProcessor) EnableStrategy(strategy interfaces.StrategyType) error {
+	if _, exists := csp.strategy(strategy); !exists {
+		return fmt.Errorf("no detector for strategy %s", strategy)
+	}
+
+	csp.mu.Lock()
+	defer csp.mu.Unlock()
+
+	csp.enabled[strategy] = true
+	return nil
+}
+
+// DisableStrategy turns off detection for a strategy
+func (csp *ConcurrentStrategyProcessor) DisableStrategy(strategy interfaces.StrategyType) error {
+	if _, exists := csp.strategy(strategy); !exists {
+		return fmt.Errorf("no detector for strategy %s", strategy)
+	}
+
+	csp.mu.Lock()
+	defer csp.mu.Unlock()
+
+	delete(csp.enabled, strategy)
+	return nil
+}
+
+// GetActiveStrategies returns the enabled registered strategies, in registration order
+func (csp *ConcurrentStrategyProcessor) GetActiveStrategies() []interfaces.StrategyType {
+	if csp.registry == nil {
+		return nil
+	}
+
+	var active []interfaces.StrategyType
+	for _, strategy := range csp.registry.List() {
+		if csp.isEnabled(strategy.Name()) {
+			active = append(active, strategy.Name())
+		}
+	}
+	return active
+}
+
+// ConfigSchemas returns the config schema of every registered strategy
+func (csp *ConcurrentStrategyProcessor) ConfigSchemas() map[interfaces.StrategyType]*interfaces.StrategyConfigSchema {
+	schemas := make(map[interfaces.StrategyType]*interfaces.StrategyConfigSchema)
+	if csp.registry == nil {
+		return schemas
+	}
+	for _, strategy := range csp.registry.List() {
+		schemas[strategy.Name()] = strategy.ConfigSchema()
+	}
+	return schemas
+}
+
+// Configure applies per-strategy settings, keyed by strategy name as under the
+// strategies config section. An enabled setting turns a strategy on or off; the
+// settings in the strategy's schema go to the strategy. Other keys, and strategies
+// that aren't registered, are ignored.
+func (csp *ConcurrentStrategyProcessor) Configure(settings map[string]map[string]interface{}) error {
+	for name, strategySettings := range settings {
+		strategy, exists := csp.strategy(interfaces.StrategyType(name))
+		if !exists {
+			continue
+		}
+
+		schema := strategy.ConfigSchema()
+		remaining := make(map[string]interface{}, len(strategySettings))
+		for key, value := range strategySettings {
+			if _, described := schema.Field(key); described {
+				remaining[key] = value
+			}
+		}
+		if err := strategy.Configure(remaining); err != nil {
+			return fmt.Errorf("failed to configure strategy %s: %w", name, err)
+		}
+
+		if enabled, ok := strategySettings["enabled"].(bool); ok {
+			csp.mu.Lock()
+			if enabled {
+				csp.enabled[strategy.Name()] = true
+			} else {
+				delete(csp.enabled, strategy.Name())
+			}
+			csp.mu.Unlock()
+		}
+	}
+	return nil
+}
+
+// strategy returns a registered strategy by name
+func (csp *ConcurrentStrategyProcessor) strategy(name interfaces.StrategyType) (interfaces.Strategy, bool) {
+	if csp.registry == nil {
+		return nil, false
+	}
+	return csp.registry.Get(name)
+}
+
+// isEnabled reports whether detection is turned on for a strategy
+func (csp *ConcurrentStrategyProcessor) isEnabled(strategy interfaces.StrategyType) bool {
+	csp.mu.RLock()
+	defer csp.mu.RUnlock()
+
+	return csp.enabled[strategy]
+}
+
 // Start starts the concurrent strategy processor
 func (csp *ConcurrentStrategyProcessor) Start(ctx context.Context) error {
 	csp.mu.Lock()
@@ -117,10 +233,38 @@ func (csp *ConcurrentStrategyProcessor) Stop(ctx context.Context) error {
 }
 
 // ProcessOpportunities processes multiple transactions concurrently for MEV opportunities
+// and returns the most valuable combination that can be executed together
 func (csp *ConcurrentStrategyProcessor) ProcessOpportunities(
 	ctx context.Context,
 	transactions []*types.Transaction,
 	simResults []*interfaces.SimulationResult,
+) ([]*interfaces.MEVOpportunity, error) {
+	resolution, err := csp.ProcessBatch(ctx, transactions, simResults)
+	if resolution == nil {
+		return nil, err
+	}
+	return resolution.Selected, err
+}
+
+// ProcessBatch detects opportunities across a batch of transactions and resolves
+// conflicts between them, recording why the alternatives were dropped
+func (csp *ConcurrentStrategyProcessor) ProcessBatch(
+	ctx context.Context,
+	transactions []*types.Transaction,
+	simResults []*interfaces.SimulationResult,
+) (*Resolution, error) {
+	opportunities, err := csp.collectOpportunities(ctx, transactions, simResults)
+	if opportunities == nil && err != nil {
+		return nil, err
+	}
+	return csp.resolver.Resolve(opportunities), err
+}
+
+// collectOpportunities runs every enabled strategy over the batch
+func (csp *ConcurrentStrategyProcessor) collectOpportunities(
+	ctx context.Context,
+	transactions []*types.Transaction,
+	simResults []*interfaces.SimulationResult,
 ) ([]*interfaces.MEVOpportunity, error) {
 	if !csp.running {
 		return nil, fmt.Errorf("concurrent strategy processor is not running")
@@ -183,6 +327,17 @@ func (csp *ConcurrentStrategyProcessor) ProcessOpportunities(
 		}
 	}
 
+	// Strategies that consider the transactions together run once over the batch
+	batchOpportunities, err := csp.dispatch(ctx, &interfaces.StrategyInput{
+		Kind:              interfaces.InputBatch,
+		Transactions:      transactions,
+		SimulationResults: simResults,
+	})
+	allOpportunities = append(allOpportunities, batchOpportunities...)
+	if err != nil {
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return allOpportunities, fmt.Errorf("strategy processing completed with %d errors: %v", len(errors), errors[0])
 	}
@@ -203,47 +358,77 @@ func (csp *ConcurrentStrategyProcessor) DetectStrategiesConcurrently(
 		}
 	}()
 
-	// Channel to collect opportunities from all strategies
-	opportunityChan := make(chan *interfaces.MEVOpportunity, 10)
-	errorChan := make(chan error, 4) // Max 4 strategies
-	var wg sync.WaitGroup
+	return csp.dispatch(ctx, &interfaces.StrategyInput{
+		Kind:             interfaces.InputTransaction,
+		Transaction:      tx,
+		SimulationResult: simResult,
+	})
+}
+
+// ProcessBlock dispatches an included block to the enabled strategies analyzing blocks
+func (csp *ConcurrentStrategyProcessor) ProcessBlock(ctx context.Context, block *interfaces.HistoricalBlock) ([]*interfaces.MEVOpportunity, error) {
+	if block == nil {
+		return nil, fmt.Errorf("block is nil")
+	}
+	return csp.dispatch(ctx, &interfaces.StrategyInput{Kind: interfaces.InputBlock, Block: block})
+}
 
-	// Launch goroutines for each strategy type
-	strategies := []struct {
-		name     string
-		detector func(context.Context, *types.Transaction, *interfaces.SimulationResult) (*interfaces.MEVOpportunity, error)
-	}{
-		{"sandwich", csp.detectSandwich},
-		{"backrun", csp.detectBackrun},
-		{"frontrun", csp.detectFrontrun},
+// ProcessEvents dispatches contract logs to the enabled strategies reacting to events
+func (csp *ConcurrentStrategyProcessor) ProcessEvents(ctx context.Context, logs []*ethtypes.Log) ([]*interfaces.MEVOpportunity, error) {
+	if len(logs) == 0 {
+		return nil, nil
+	}
+	return csp.dispatch(ctx, &interfaces.StrategyInput{Kind: interfaces.InputEvent, Logs: logs})
+}
+
+// dispatch runs every enabled strategy accepting the input's kind concurrently and
+// collects their opportunities
+func (csp *ConcurrentStrategyProcessor) dispatch(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	if csp.registry == nil {
+		return nil, nil
+	}
+
+	var strategies []interfaces.Strategy
+	for _, strategy := range csp.registry.Accepting(input.Kind) {
+		if csp.isEnabled(strategy.Name()) {
+			strategies = append(strategies, strategy)
+		}
 	}
 
+	// Channel to collect opportunities from all strategies
+	opportunityChan := make(chan []*interfaces.MEVOpportunity, len(strategies))
+	errorChan := make(chan error, len(strategies))
+	var wg sync.WaitGroup
+
 	for _, strategy := range strategies {
 		wg.Add(1)
-		go func(name string, detector func(context.Context, *types.Transaction, *interfaces.SimulationResult) (*interfaces.MEVOpportunity, error)) {
+		go func(strategy interfaces.Strategy) {
 			defer wg.Done()
 
 			strategyStart := time.Now()
-			opportunity, err := detector(ctx, tx, simResult)
+			opportunities, err := strategy.Detect(ctx, input)
 			if csp.latencyMonitor != nil {
-				csp.latencyMonitor.RecordLatency(fmt.Sprintf("detect_%s", name), time.Since(strategyStart))
+				csp.latencyMonitor.RecordLatency(fmt.Sprintf("detect_%s", strategy.Name()), time.Since(strategyStart))
 			}
 
 			if err != nil {
 				select {
-				case errorChan <- fmt.Errorf("%s detection failed: %w", name, err):
+				case errorChan <- fmt.Errorf("%s detection failed: %w", strategy.Name(), err):
 				case <-ctx.Done():
 				}
 				return
 			}
 
-			if opportunity != nil {
+			if len(opportunities) > 0 {
+				for _, opportunity := range opportunities {
+					csp.normalizeProfit(opportunity)
+				}
 				select {
-				case opportunityChan <- opportunity:
+				case opportunityChan <- opportunities:
 				case <-ctx.Done():
 				}
 			}
-		}(strategy.name, strategy.detector)
+		}(strategy)
 	}
 
 	// Close channels when all strategies complete
@@ -259,19 +444,25 @@ func (csp *ConcurrentStrategyProcessor) DetectStrategiesConcurrently(
 
 	for {
 		select {
-		case opportunity, ok := <-opportunityChan:
+		case found, ok := <-opportunityChan:
 			if !ok {
-				// Channel closed, we're done
+				// Channel closed; drain the errors, which are all sent by now
+				if errorChan != nil {
+					for err := range errorChan {
+						errors = append(errors, err)
+					}
+				}
 				if len(errors) > 0 {
 					return opportunities, fmt.Errorf("strategy detection had %d errors: %v", len(errors), errors[0])
 				}
 				return opportunities, nil
 			}
-			opportunities = append(opportunities, opportunity)
+			opportunities = append(opportunities, found...)
 
 		case err, ok := <-errorChan:
 			if !ok {
 				// Channel closed
+				errorChan = nil
 				continue
 			}
 			errors = append(errors, err)
@@ -282,96 +473,17 @@ func (csp *ConcurrentStrategyProcessor) DetectStrategiesConcurrently(
 	}
 }
 
-// Strategy detection methods
-
-func (csp *ConcurrentStrategyProcessor) detectSandwich(
-	ctx context.Context,
-	tx *types.Transaction,
-	simResult *interfaces.SimulationResult,
-) (*interfaces.MEVOpportunity, error) {
-	if csp.sandwichDetector == nil {
-		return nil, nil
-	}
-
-	opportunity, err := csp.sandwichDetector.DetectOpportunity(ctx, tx, simResult)
-	if err != nil || opportunity == nil {
-		return nil, err
-	}
-
-	return &interfaces.MEVOpportunity{
-		ID:             fmt.Sprintf("sandwich_%s_%d", tx.Hash, time.Now().UnixNano()),
-		Strategy:       interfaces.StrategySandwich,
-		TargetTx:       tx.Hash,
-		ExpectedProfit: opportunity.ExpectedProfit,
-		GasCost:        big.NewInt(0), // Would need to calculate
-		NetProfit:      opportunity.ExpectedProfit,
-		Confidence:     0.8, // Default confidence
-		Status:         interfaces.StatusDetected,
-		CreatedAt:      time.Now(),
-		Metadata: map[string]interface{}{
-			"sandwich_opportunity": opportunity,
-		},
-	}, nil
-}
-
-func (csp *ConcurrentStrategyProcessor) detectBackrun(
-	ctx context.Context,
-	tx *types.Transaction,
-	simResult *interfaces.SimulationResult,
-) (*interfaces.MEVOpportunity, error) {
-	if csp.backrunDetector == nil {
-		return nil, nil
-	}
-
-	opportunity, err := csp.backrunDetector.DetectOpportunity(ctx, tx, simResult)
-	if err != nil || opportunity == nil {
-		return nil, err
-	}
-
-	return &interfaces.MEVOpportunity{
-		ID:             fmt.Sprintf("backrun_%s_%d", tx.Hash, time.Now().UnixNano()),
-		Strategy:       interfaces.StrategyBackrun,
-		TargetTx:       tx.Hash,
-		ExpectedProfit: opportunity.ExpectedProfit,
-		GasCost:        big.NewInt(0), // Would need to calculate
-		NetProfit:      opportunity.ExpectedProfit,
-		Confidence:     0.8, // Default confidence
-		Status:         interfaces.StatusDetected,
-		CreatedAt:      time.Now(),
-		Metadata: map[string]interface{}{
-			"backrun_opportunity": opportunity,
-		},
-	}, nil
-}
-
-func (csp *ConcurrentStrategyProcessor) detectFrontrun(
-	ctx context.Context,
-	tx *types.Transaction,
-	simResult *interfaces.SimulationResult,
-) (*interfaces.MEVOpportunity, error) {
-	if csp.frontrunDetector == nil {
-		return nil, nil
-	}
+// normalizeProfit fills an opportunity's ETH and USD profit when a price oracle is set.
+// Opportunities that can't be priced are kept; NetProfitWei reports them as unpriced.
+func (csp *ConcurrentStrategyProcessor) normalizeProfit(opportunity *interfaces.MEVOpportunity) {
+	csp.mu.RLock()
+	oracle := csp.priceOracle
+	csp.mu.RUnlock()
 
-	opportunity, err := csp.frontrunDetector.DetectOpportunity(ctx, tx, simResult)
-	if err != nil || opportunity == nil {
-		return nil, err
+	if oracle == nil {
+		return
 	}
-
-	return &interfaces.MEVOpportunity{
-		ID:             fmt.Sprintf("frontrun_%s_%d", tx.Hash, time.Now().UnixNano()),
-		Strategy:       interfaces.StrategyFrontrun,
-		TargetTx:       tx.Hash,
-		ExpectedProfit: opportunity.ExpectedProfit,
-		GasCost:        big.NewInt(0), // Would need to calculate
-		NetProfit:      opportunity.ExpectedProfit,
-		Confidence:     0.8, // Default confidence
-		Status:         interfaces.StatusDetected,
-		CreatedAt:      time.Now(),
-		Metadata: map[string]interface{}{
-			"frontrun_opportunity": opportunity,
-		},
-	}, nil
+	_ = oracle.NormalizeOpportunity(opportunity)
 }
 
 // calculatePriority calculates the priority for strategy processing
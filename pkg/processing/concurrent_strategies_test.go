@@ -0,0 +1,303 @@
+package processing
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/strategy"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJITDetector returns a fixed opportunity, bundled around its target
+type fakeJITDetector struct {
+	interfaces.JITDetector
+	opportunity *interfaces.JITOpportunity
+}
+
+func (f *fakeJITDetector) ConstructBundle(ctx context.Context, opportunity *interfaces.JITOpportunity) ([]*types.Transaction, error) {
+	return []*types.Transaction{opportunity.MintTx, opportunity.TargetTx, opportunity.BurnTx, opportunity.CollectTx}, nil
+}
+
+func (f *fakeJITDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) (*interfaces.JITOpportunity, error) {
+	return f.opportunity, nil
+}
+
+func (f *fakeJITDetector) GetConfiguration() *interfaces.JITConfig {
+	return &interfaces.JITConfig{GasLimit: 450000}
+}
+
+// newProcessor creates a processor over a registry of the given strategies
+func newProcessor(t *testing.T, strategies ...interfaces.Strategy) *ConcurrentStrategyProcessor {
+	t.Helper()
+
+	registry, err := strategy.NewStrategyRegistry(strategies...)
+	require.NoError(t, err)
+	return NewConcurrentStrategyProcessor(nil, registry, nil)
+}
+
+func TestConcurrentStrategyProcessor_DetectJIT(t *testing.T) {
+	jit := &fakeJITDetector{opportunity: &interfaces.JITOpportunity{
+		ExpectedProfit: big.NewInt(1e16),
+		MintTx:         &types.Transaction{},
+		BurnTx:         &types.Transaction{},
+		CollectTx:      &types.Transaction{},
+	}}
+	processor := newProcessor(t, strategy.NewJITStrategy(jit))
+	tx := &types.Transaction{Hash: "0xabc", GasPrice: big.NewInt(1e9)}
+	jit.opportunity.TargetTx = tx
+
+	opportunities, err := processor.DetectStrategiesConcurrently(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+
+	opportunity := opportunities[0]
+	assert.Equal(t, interfaces.StrategyJIT, opportunity.Strategy)
+	assert.Equal(t, "0xabc", opportunity.TargetTx)
+	assert.Equal(t, big.NewInt(450000e9), opportunity.GasCost)
+	assert.Equal(t, big.NewInt(1e16-450000e9), opportunity.NetProfit)
+	require.Len(t, opportunity.ExecutionTxs, 4)
+	assert.Same(t, tx, opportunity.ExecutionTxs[1])
+
+	// Disabled strategies are not run
+	require.NoError(t, processor.DisableStrategy(interfaces.StrategyJIT))
+	assert.Empty(t, processor.GetActiveStrategies())
+
+	opportunities, err = processor.DetectStrategiesConcurrently(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+
+	require.NoError(t, processor.EnableStrategy(interfaces.StrategyJIT))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyJIT}, processor.GetActiveStrategies())
+}
+
+func TestConcurrentStrategyProcessor_EnableStrategyWithoutDetector(t *testing.T) {
+	processor := newProcessor(t, strategy.NewJITStrategy(&fakeJITDetector{}))
+
+	assert.Error(t, processor.EnableStrategy(interfaces.StrategySandwich))
+	assert.Error(t, processor.DisableStrategy(interfaces.StrategyFrontrun))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyJIT}, processor.GetActiveStrategies())
+
+	// A processor without a registry has nothing to run
+	processor = NewConcurrentStrategyProcessor(nil, nil, nil)
+	assert.Error(t, processor.EnableStrategy(interfaces.StrategyJIT))
+	assert.Empty(t, processor.GetActiveStrategies())
+}
+
+// fakeOracleBackrunDetector returns fixed opportunities
+type fakeOracleBackrunDetector struct {
+	interfaces.OracleBackrunDetector
+	opportunities []*interfaces.BackrunOpportunity
+}
+
+func (f *fakeOracleBackrunDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) ([]*interfaces.BackrunOpportunity, error) {
+	return f.opportunities, nil
+}
+
+func TestConcurrentStrategyProcessor_DetectOracleBackrun(t *testing.T) {
+	usdc := "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913"
+	processor := newProcessor(t, strategy.NewBackrunStrategy(nil, &fakeOracleBackrunDetector{opportunities: []*interfaces.BackrunOpportunity{
+		{Token: usdc, ExpectedProfit: big.NewInt(70_000000), ArbitrageTx: &types.Transaction{}},
+		{Token: usdc, ExpectedProfit: big.NewInt(10_000000), ArbitrageTx: &types.Transaction{}},
+	}}))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyBackrun}, processor.GetActiveStrategies())
+
+	tx := &types.Transaction{Hash: "0xtransmit", GasPrice: big.NewInt(1e9)}
+	opportunities, err := processor.DetectStrategiesConcurrently(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+
+	// The most profitable oracle backrun is taken
+	assert.Equal(t, interfaces.StrategyBackrun, opportunities[0].Strategy)
+	assert.Equal(t, big.NewInt(70_000000), opportunities[0].ExpectedProfit)
+	assert.Equal(t, usdc, opportunities[0].ProfitToken.Hex())
+}
+
+// fakeSnipingDetector returns a fixed opportunity
+type fakeSnipingDetector struct {
+	interfaces.SnipingDetector
+	opportunity *interfaces.SnipeOpportunity
+}
+
+func (f *fakeSnipingDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) (*interfaces.SnipeOpportunity, error) {
+	return f.opportunity, nil
+}
+
+func (f *fakeSnipingDetector) GetConfiguration() *interfaces.SnipingConfig {
+	return &interfaces.SnipingConfig{}
+}
+
+func TestConcurrentStrategyProcessor_DetectSniping(t *testing.T) {
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	processor := newProcessor(t, strategy.NewSnipingStrategy(&fakeSnipingDetector{opportunity: &interfaces.SnipeOpportunity{
+		QuoteToken:     weth,
+		ExpectedProfit: big.NewInt(5e16),
+		BuyTx:          &types.Transaction{GasPrice: big.NewInt(1e9), GasLimit: 300000},
+	}}))
+
+	// Sniping is off by default
+	assert.Empty(t, processor.GetActiveStrategies())
+	require.NoError(t, processor.EnableStrategy(interfaces.StrategySniping))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategySniping}, processor.GetActiveStrategies())
+
+	tx := &types.Transaction{Hash: "0xlaunch", GasPrice: big.NewInt(1e9)}
+	opportunities, err := processor.DetectStrategiesConcurrently(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+
+	opportunity := opportunities[0]
+	assert.Equal(t, interfaces.StrategySniping, opportunity.Strategy)
+	assert.Equal(t, weth, opportunity.ProfitToken)
+	assert.Equal(t, big.NewInt(300000e9), opportunity.GasCost)
+	assert.Len(t, opportunity.ExecutionTxs, 1)
+}
+
+// fakeStrategy is a strategy outside the built-in set, reacting to events
+type fakeStrategy struct {
+	inputs   []*interfaces.StrategyInput
+	minValue uint64
+}
+
+func (f *fakeStrategy) Name() interfaces.StrategyType { return "custom" }
+
+func (f *fakeStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputEvent, interfaces.InputBatch}
+}
+
+func (f *fakeStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return &interfaces.StrategyConfigSchema{Fields: []interfaces.StrategyConfigField{
+		{Name: "min_value", Type: interfaces.ConfigTypeUint, Default: f.minValue},
+	}}
+}
+
+func (f *fakeStrategy) Configure(settings map[string]interface{}) error {
+	for name, value := range settings {
+		n, ok := value.(int)
+		if name != "min_value" || !ok {
+			return assert.AnError
+		}
+		f.minValue = uint64(n)
+	}
+	return nil
+}
+
+func (f *fakeStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	f.inputs = append(f.inputs, input)
+	if input.Kind != interfaces.InputEvent {
+		return nil, nil
+	}
+	return []*interfaces.MEVOpportunity{{
+		Strategy:       "custom",
+		TargetTx:       input.Logs[0].TxHash.Hex(),
+		ExpectedProfit: big.NewInt(1e16),
+		NetProfit:      big.NewInt(1e16),
+	}}, nil
+}
+
+func TestConcurrentStrategyProcessor_DispatchesRegisteredStrategy(t *testing.T) {
+	custom := &fakeStrategy{}
+	jit := &fakeJITDetector{}
+	processor := newProcessor(t, custom, strategy.NewJITStrategy(jit))
+
+	// Strategies outside the defaults start disabled and are enabled through settings
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyJIT}, processor.GetActiveStrategies())
+	require.NoError(t, processor.Configure(map[string]map[string]interface{}{
+		"custom":  {"enabled": true, "min_value": 5},
+		"unknown": {"enabled": true},
+	}))
+	assert.Equal(t, []interfaces.StrategyType{"custom", interfaces.StrategyJIT}, processor.GetActiveStrategies())
+	assert.Equal(t, uint64(5), custom.minValue)
+
+	err := processor.Configure(map[string]map[string]interface{}{"custom": {"min_value": "five"}})
+	assert.Error(t, err)
+	assert.Equal(t, uint64(5), custom.minValue)
+
+	schemas := processor.ConfigSchemas()
+	require.Contains(t, schemas, interfaces.StrategyType("custom"))
+	_, exists := schemas["custom"].Field("min_value")
+	assert.True(t, exists)
+
+	// Events reach only the strategies declaring them
+	log := &ethtypes.Log{TxHash: common.HexToHash("0x01")}
+	opportunities, err := processor.ProcessEvents(context.Background(), []*ethtypes.Log{log})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+	assert.Equal(t, log.TxHash.Hex(), opportunities[0].TargetTx)
+
+	// Transactions go to the JIT strategy, then the whole batch to the custom one
+	txs := []*types.Transaction{{Hash: "0xa", GasPrice: big.NewInt(1e9)}, {Hash: "0xb", GasPrice: big.NewInt(1e9)}}
+	sims := []*interfaces.SimulationResult{{Success: true}, {Success: true}}
+	require.NoError(t, processor.Start(context.Background()))
+	defer processor.Stop(context.Background())
+	_, err = processor.ProcessOpportunities(context.Background(), txs, sims)
+	require.NoError(t, err)
+
+	require.Len(t, custom.inputs, 2)
+	assert.Equal(t, interfaces.InputBatch, custom.inputs[1].Kind)
+	assert.Equal(t, txs, custom.inputs[1].Transactions)
+
+	// Nothing accepts blocks
+	opportunities, err = processor.ProcessBlock(context.Background(), &interfaces.HistoricalBlock{})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+
+	require.NoError(t, processor.Configure(map[string]map[string]interface{}{"custom": {"enabled": false}}))
+	assert.Equal(t, []interfaces.StrategyType{interfaces.StrategyJIT}, processor.GetActiveStrategies())
+}
+
+// fixedStrategy reports one opportunity on every transaction
+type fixedStrategy struct {
+	name   interfaces.StrategyType
+	profit int64
+}
+
+func (f *fixedStrategy) Name() interfaces.StrategyType { return f.name }
+
+func (f *fixedStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (f *fixedStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return &interfaces.StrategyConfigSchema{}
+}
+
+func (f *fixedStrategy) Configure(settings map[string]interface{}) error { return nil }
+
+func (f *fixedStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	return []*interfaces.MEVOpportunity{{
+		ID:         string(f.name) + "_" + input.Transaction.Hash,
+		Strategy:   f.name,
+		TargetTx:   input.Transaction.Hash,
+		NetProfit:  big.NewInt(f.profit),
+		Confidence: 1,
+	}}, nil
+}
+
+func TestConcurrentStrategyProcessor_ResolvesConflicts(t *testing.T) {
+	processor := newProcessor(t,
+		&fixedStrategy{name: interfaces.StrategySandwich, profit: 5e16},
+		&fixedStrategy{name: interfaces.StrategyFrontrun, profit: 3e16},
+	)
+	require.NoError(t, processor.Start(context.Background()))
+	defer processor.Stop(context.Background())
+
+	txs := []*types.Transaction{{Hash: "0xa", GasPrice: big.NewInt(1e9)}}
+	sims := []*interfaces.SimulationResult{{Success: true}}
+	resolution, err := processor.ProcessBatch(context.Background(), txs, sims)
+	require.NoError(t, err)
+
+	require.Len(t, resolution.Selected, 1)
+	assert.Equal(t, interfaces.StrategySandwich, resolution.Selected[0].Strategy)
+	require.Len(t, resolution.Dropped, 1)
+	assert.Equal(t, "frontrun_0xa", resolution.Dropped[0].Opportunity.ID)
+	assert.Equal(t, ConflictExclusive, resolution.Dropped[0].Conflicts[0].Reason)
+
+	opportunities, err := processor.ProcessOpportunities(context.Background(), txs, sims)
+	require.NoError(t, err)
+	assert.Len(t, opportunities, 1)
+}
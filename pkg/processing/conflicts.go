@@ -0,0 +1,293 @@
+package processing
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// ConflictReason says why two opportunities can't both be executed
+type ConflictReason string
+
+const (
+	ConflictExclusive ConflictReason = "exclusive" // Strategies competing for the same target, like a sandwich and a frontrun of one swap
+	ConflictPool      ConflictReason = "pool"      // Both trade in a pool, so one invalidates the state the other was sized on
+	ConflictNonce     ConflictReason = "nonce"     // Both need the same sender nonce
+)
+
+// ResolverConfig holds configuration for opportunity conflict resolution
+type ResolverConfig struct {
+	// ExclusiveStrategies lists pairs of strategies that can't both target one transaction
+	ExclusiveStrategies [][2]interfaces.StrategyType `json:"exclusive_strategies"`
+	// MaxExactSearch is the largest group of conflicting opportunities searched
+	// exhaustively; larger groups are resolved greedily
+	MaxExactSearch int `json:"max_exact_search"`
+	// NonceConflicts compares execution transaction nonces. Detectors leave nonces
+	// unassigned, so only turn it on where they're assigned before resolution.
+	NonceConflicts bool `json:"nonce_conflicts"`
+}
+
+// DefaultResolverConfig returns the default conflict resolution configuration
+func DefaultResolverConfig() *ResolverConfig {
+	return &ResolverConfig{
+		ExclusiveStrategies: [][2]interfaces.StrategyType{
+			// Both extract the target's slippage allowance
+			{interfaces.StrategySandwich, interfaces.StrategyFrontrun},
+		},
+		MaxExactSearch: 16,
+	}
+}
+
+// Conflict records an opportunity conflicting with a selected one
+type Conflict struct {
+	With   string         `json:"with"` // ID of the selected opportunity
+	Reason ConflictReason `json:"reason"`
+	Detail string         `json:"detail"`
+}
+
+// DroppedOpportunity is an opportunity left out for a more valuable combination
+type DroppedOpportunity struct {
+	Opportunity *interfaces.MEVOpportunity `json:"opportunity"`
+	Conflicts   []Conflict                 `json:"conflicts"`
+}
+
+// Resolution is the compatible combination of opportunities chosen from a batch
+type Resolution struct {
+	Selected []*interfaces.MEVOpportunity `json:"selected"`
+	Dropped  []*DroppedOpportunity        `json:"dropped"`
+	// Bundles groups the selected opportunities sharing a target transaction by target,
+	// for execution together
+	Bundles map[string][]*interfaces.MEVOpportunity `json:"bundles"`
+}
+
+// OpportunityResolver picks the combination of compatible opportunities with the
+// highest expected value
+type OpportunityResolver struct {
+	config    *ResolverConfig
+	exclusive map[[2]interfaces.StrategyType]bool
+}
+
+// NewOpportunityResolver creates a new opportunity resolver
+func NewOpportunityResolver(config *ResolverConfig) *OpportunityResolver {
+	if config == nil {
+		config = DefaultResolverConfig()
+	}
+
+	exclusive := make(map[[2]interfaces.StrategyType]bool, 2*len(config.ExclusiveStrategies))
+	for _, pair := range config.ExclusiveStrategies {
+		exclusive[pair] = true
+		exclusive[[2]interfaces.StrategyType{pair[1], pair[0]}] = true
+	}
+
+	return &OpportunityResolver{
+		config:    config,
+		exclusive: exclusive,
+	}
+}
+
+// Resolve selects the opportunities to execute. Opportunities are grouped by
+// conflicts; each group keeps the compatible subset with the highest expected value.
+func (r *OpportunityResolver) Resolve(opportunities []*interfaces.MEVOpportunity) *Resolution {
+	resolution := &Resolution{Bundles: make(map[string][]*interfaces.MEVOpportunity)}
+
+	var candidates []*interfaces.MEVOpportunity
+	for _, opportunity := range opportunities {
+		if opportunity != nil {
+			candidates = append(candidates, opportunity)
+		}
+	}
+
+	// adjacent[i] and conflicts[i] line up: the opportunities i conflicts with, and why
+	conflicts := make([][]Conflict, len(candidates))
+	adjacent := make([][]int, len(candidates))
+	for i := range candidates {
+		for j := i + 1; j < len(candidates); j++ {
+			reason, detail, ok := r.conflict(candidates[i], candidates[j])
+			if !ok {
+				continue
+			}
+			adjacent[i] = append(adjacent[i], j)
+			adjacent[j] = append(adjacent[j], i)
+			conflicts[i] = append(conflicts[i], Conflict{With: candidates[j].ID, Reason: reason, Detail: detail})
+			conflicts[j] = append(conflicts[j], Conflict{With: candidates[i].ID, Reason: reason, Detail: detail})
+		}
+	}
+
+	selected := make([]bool, len(candidates))
+	for _, component := range components(adjacent) {
+		for _, i := range r.selectCompatible(candidates, adjacent, component) {
+			selected[i] = true
+		}
+	}
+
+	for i, opportunity := range candidates {
+		if selected[i] {
+			resolution.Selected = append(resolution.Selected, opportunity)
+			if opportunity.TargetTx != "" {
+				resolution.Bundles[opportunity.TargetTx] = append(resolution.Bundles[opportunity.TargetTx], opportunity)
+			}
+			continue
+		}
+
+		// Only conflicts with what was kept explain the drop
+		dropped := &DroppedOpportunity{Opportunity: opportunity}
+		for k, j := range adjacent[i] {
+			if selected[j] {
+				dropped.Conflicts = append(dropped.Conflicts, conflicts[i][k])
+			}
+		}
+		resolution.Dropped = append(resolution.Dropped, dropped)
+	}
+
+	for target, bundle := range resolution.Bundles {
+		if len(bundle) < 2 {
+			delete(resolution.Bundles, target)
+		}
+	}
+
+	return resolution
+}
+
+// conflict reports whether two opportunities can't both be executed, and why
+func (r *OpportunityResolver) conflict(a, b *interfaces.MEVOpportunity) (ConflictReason, string, bool) {
+	if a.TargetTx != "" && a.TargetTx == b.TargetTx && r.exclusive[[2]interfaces.StrategyType{a.Strategy, b.Strategy}] {
+		return ConflictExclusive, fmt.Sprintf("%s and %s both target %s", a.Strategy, b.Strategy, a.TargetTx), true
+	}
+
+	for _, poolA := range a.Pools {
+		for _, poolB := range b.Pools {
+			if poolA == poolB {
+				return ConflictPool, fmt.Sprintf("both trade in pool %s", poolA.Hex()), true
+			}
+		}
+	}
+
+	if !r.config.NonceConflicts {
+		return "", "", false
+	}
+
+	type senderNonce struct {
+		sender common.Address
+		nonce  uint64
+	}
+	nonces := make(map[senderNonce]bool, len(a.ExecutionTxs))
+	for _, tx := range a.ExecutionTxs {
+		if tx != nil && tx.From != (common.Address{}) {
+			nonces[senderNonce{tx.From, tx.Nonce}] = true
+		}
+	}
+	for _, tx := range b.ExecutionTxs {
+		if tx != nil && nonces[senderNonce{tx.From, tx.Nonce}] {
+			return ConflictNonce, fmt.Sprintf("both use nonce %d of %s", tx.Nonce, tx.From.Hex()), true
+		}
+	}
+
+	return "", "", false
+}
+
+// selectCompatible returns the subset of a group of conflicting opportunities with
+// no conflicts between them and the highest total expected value
+func (r *OpportunityResolver) selectCompatible(candidates []*interfaces.MEVOpportunity, adjacent [][]int, group []int) []int {
+	if len(group) == 1 {
+		return group
+	}
+
+	values := make(map[int]*big.Float, len(group))
+	for _, i := range group {
+		values[i] = expectedValue(candidates[i])
+	}
+
+	// Most valuable first, so the greedy pass and ties favour them
+	sort.SliceStable(group, func(x, y int) bool {
+		return values[group[x]].Cmp(values[group[y]]) > 0
+	})
+
+	conflicting := func(i int, chosen []int) bool {
+		for _, j := range chosen {
+			for _, k := range adjacent[i] {
+				if k == j {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if len(group) > r.config.MaxExactSearch {
+		var chosen []int
+		for _, i := range group {
+			if !conflicting(i, chosen) {
+				chosen = append(chosen, i)
+			}
+		}
+		return chosen
+	}
+
+	var best []int
+	bestValue := new(big.Float)
+	var search func(position int, chosen []int, value *big.Float)
+	search = func(position int, chosen []int, value *big.Float) {
+		if position == len(group) {
+			if best == nil || value.Cmp(bestValue) > 0 {
+				best = append([]int(nil), chosen...)
+				bestValue = value
+			}
+			return
+		}
+
+		i := group[position]
+		if !conflicting(i, chosen) {
+			search(position+1, append(chosen, i), new(big.Float).Add(value, values[i]))
+		}
+		search(position+1, chosen, value)
+	}
+	search(0, nil, new(big.Float))
+
+	return best
+}
+
+// expectedValue weighs an opportunity's net profit in wei of ETH by its confidence.
+// Profits in other tokens that haven't been normalized count as nothing.
+func expectedValue(opportunity *interfaces.MEVOpportunity) *big.Float {
+	profit := opportunity.NetProfitWei()
+	if profit == nil || profit.Sign() <= 0 {
+		return new(big.Float)
+	}
+	return new(big.Float).Mul(new(big.Float).SetInt(profit), big.NewFloat(opportunity.Confidence))
+}
+
+// components splits a conflict graph into its connected groups
+func components(adjacent [][]int) [][]int {
+	visited := make([]bool, len(adjacent))
+	var groups [][]int
+	for start := range adjacent {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		group := []int{start}
+		for next := 0; next < len(group); next++ {
+			for _, j := range adjacent[group[next]] {
+				if !visited[j] {
+					visited[j] = true
+					group = append(group, j)
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// String summarizes why an opportunity was dropped
+func (d *DroppedOpportunity) String() string {
+	reasons := make([]string, len(d.Conflicts))
+	for i, conflict := range d.Conflicts {
+		reasons[i] = fmt.Sprintf("%s conflict with %s: %s", conflict.Reason, conflict.With, conflict.Detail)
+	}
+	return fmt.Sprintf("%s dropped (%s)", d.Opportunity.ID, strings.Join(reasons, "; "))
+}
@@ -0,0 +1,153 @@
+package processing
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	conflictPoolA = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	conflictPoolB = common.HexToAddress("0x000000000000000000000000000000000000bbbb")
+	conflictPoolC = common.HexToAddress("0x000000000000000000000000000000000000cccc")
+)
+
+// newOpportunity creates an opportunity worth profit wei of ETH at full confidence
+func newOpportunity(id string, strategy interfaces.StrategyType, target string, profit int64, pools ...common.Address) *interfaces.MEVOpportunity {
+	return &interfaces.MEVOpportunity{
+		ID:         id,
+		Strategy:   strategy,
+		TargetTx:   target,
+		NetProfit:  big.NewInt(profit),
+		Confidence: 1,
+		Pools:      pools,
+	}
+}
+
+// ids returns the IDs of opportunities
+func ids(opportunities []*interfaces.MEVOpportunity) []string {
+	result := make([]string, len(opportunities))
+	for i, opportunity := range opportunities {
+		result[i] = opportunity.ID
+	}
+	return result
+}
+
+func TestOpportunityResolver_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []*interfaces.MEVOpportunity
+		selected []string
+		dropped  map[string]ConflictReason
+	}{
+		{
+			name: "sandwich and frontrun of one swap are exclusive",
+			input: []*interfaces.MEVOpportunity{
+				newOpportunity("sandwich", interfaces.StrategySandwich, "0xvictim", 5e16, conflictPoolA),
+				newOpportunity("frontrun", interfaces.StrategyFrontrun, "0xvictim", 7e16),
+			},
+			selected: []string{"frontrun"},
+			dropped:  map[string]ConflictReason{"sandwich": ConflictExclusive},
+		},
+		{
+			name: "backrun in another pool is kept with the sandwich",
+			input: []*interfaces.MEVOpportunity{
+				newOpportunity("sandwich", interfaces.StrategySandwich, "0xvictim", 5e16, conflictPoolA),
+				newOpportunity("backrun", interfaces.StrategyBackrun, "0xvictim", 2e16, conflictPoolB, conflictPoolC),
+			},
+			selected: []string{"sandwich", "backrun"},
+		},
+		{
+			name: "two smaller opportunities beat one larger conflicting with both",
+			input: []*interfaces.MEVOpportunity{
+				newOpportunity("jit", interfaces.StrategyJIT, "0xvictim", 6e16, conflictPoolA, conflictPoolB),
+				newOpportunity("sandwich", interfaces.StrategySandwich, "0xvictim", 4e16, conflictPoolA),
+				newOpportunity("backrun", interfaces.StrategyBackrun, "0xother", 3e16, conflictPoolB),
+			},
+			selected: []string{"sandwich", "backrun"},
+			dropped:  map[string]ConflictReason{"jit": ConflictPool},
+		},
+		{
+			name: "same strategies on different targets don't conflict",
+			input: []*interfaces.MEVOpportunity{
+				newOpportunity("sandwich", interfaces.StrategySandwich, "0xvictim", 5e16, conflictPoolA),
+				newOpportunity("frontrun", interfaces.StrategyFrontrun, "0xother", 7e16),
+			},
+			selected: []string{"sandwich", "frontrun"},
+		},
+	}
+
+	resolver := NewOpportunityResolver(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution := resolver.Resolve(tt.input)
+			assert.ElementsMatch(t, tt.selected, ids(resolution.Selected))
+
+			require.Len(t, resolution.Dropped, len(tt.dropped))
+			for _, dropped := range resolution.Dropped {
+				require.NotEmpty(t, dropped.Conflicts, "drops are explained")
+				assert.Equal(t, tt.dropped[dropped.Opportunity.ID], dropped.Conflicts[0].Reason)
+				assert.Contains(t, tt.selected, dropped.Conflicts[0].With)
+			}
+		})
+	}
+}
+
+func TestOpportunityResolver_ExpectedValue(t *testing.T) {
+	// Confidence weighs profit, and unnormalized token profits count as nothing
+	certain := newOpportunity("certain", interfaces.StrategyBackrun, "0xa", 4e16, conflictPoolA)
+	risky := newOpportunity("risky", interfaces.StrategySniping, "0xb", 6e16, conflictPoolA)
+	risky.Confidence = 0.5
+	token := newOpportunity("token", interfaces.StrategyBackrun, "0xc", 1e18, conflictPoolA)
+	token.ProfitToken = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+
+	resolution := NewOpportunityResolver(nil).Resolve([]*interfaces.MEVOpportunity{risky, token, certain})
+	assert.Equal(t, []string{"certain"}, ids(resolution.Selected))
+	assert.Len(t, resolution.Dropped, 2)
+}
+
+func TestOpportunityResolver_Bundles(t *testing.T) {
+	executor := common.HexToAddress("0x1100000000000000000000000000000000000022")
+	sandwich := newOpportunity("sandwich", interfaces.StrategySandwich, "0xvictim", 5e16, conflictPoolA)
+	sandwich.ExecutionTxs = []*types.Transaction{{From: executor, Nonce: 7}, {From: executor, Nonce: 8}}
+	backrun := newOpportunity("backrun", interfaces.StrategyBackrun, "0xvictim", 2e16, conflictPoolB)
+	backrun.ExecutionTxs = []*types.Transaction{{From: executor, Nonce: 8}}
+
+	// Nonces are only compared when enabled
+	resolution := NewOpportunityResolver(nil).Resolve([]*interfaces.MEVOpportunity{sandwich, backrun})
+	assert.Len(t, resolution.Selected, 2)
+	require.Contains(t, resolution.Bundles, "0xvictim")
+	assert.Equal(t, []string{"sandwich", "backrun"}, ids(resolution.Bundles["0xvictim"]))
+
+	config := DefaultResolverConfig()
+	config.NonceConflicts = true
+	resolution = NewOpportunityResolver(config).Resolve([]*interfaces.MEVOpportunity{sandwich, backrun})
+	assert.Equal(t, []string{"sandwich"}, ids(resolution.Selected))
+	require.Len(t, resolution.Dropped, 1)
+	assert.Equal(t, ConflictNonce, resolution.Dropped[0].Conflicts[0].Reason)
+	assert.Contains(t, resolution.Dropped[0].String(), "nonce 8")
+	assert.Empty(t, resolution.Bundles)
+}
+
+func TestOpportunityResolver_LargeGroupsAreGreedy(t *testing.T) {
+	// A chain of pool conflicts longer than the exhaustive search limit
+	config := DefaultResolverConfig()
+	config.MaxExactSearch = 2
+
+	pools := []common.Address{conflictPoolA, conflictPoolB, conflictPoolC}
+	var opportunities []*interfaces.MEVOpportunity
+	for i := 0; i < 2; i++ {
+		opportunities = append(opportunities, newOpportunity(string(rune('a'+i)), interfaces.StrategyBackrun, "", int64(i+1)*1e16, pools[i], pools[i+1]))
+	}
+	opportunities = append(opportunities, newOpportunity("c", interfaces.StrategyBackrun, "", 1e16, pools[2]))
+
+	// Greedy takes b, worth 2e16, over a and c together, also worth 2e16
+	resolution := NewOpportunityResolver(config).Resolve(opportunities)
+	assert.Equal(t, []string{"b"}, ids(resolution.Selected))
+	assert.Len(t, resolution.Dropped, 2)
+}
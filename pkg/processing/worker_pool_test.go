@@ -286,6 +286,10 @@ func (f *SimpleMockFork) Reset() error {
 	return nil
 }
 
+func (f *SimpleMockFork) ResetToBlock(blockNumber uint64) error {
+	return nil
+}
+
 func (f *SimpleMockFork) Close() error {
 	return nil
 }
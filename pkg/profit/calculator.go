@@ -2,6 +2,7 @@ package profit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -11,12 +12,17 @@ import (
 
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
 )
 
+// ErrUnpriced is returned for opportunities whose profit token has no ETH price
+var ErrUnpriced = errors.New("opportunity profit cannot be priced in ETH")
+
 // Calculator implements the ProfitCalculator interface
 type Calculator struct {
 	gasEstimator      interfaces.GasEstimator
 	slippageCalculator interfaces.SlippageCalculator
+	priceOracle       interfaces.PriceOracle
 	thresholds        map[interfaces.StrategyType]*ProfitThreshold
 	mu                sync.RWMutex
 	rng               *rand.Rand
@@ -54,6 +60,14 @@ func NewCalculator(gasEstimator interfaces.GasEstimator, slippageCalculator inte
 	return calc
 }
 
+// SetPriceOracle sets the oracle used to value profits in tokens other than ETH.
+// Without one, such opportunities can't be priced.
+func (c *Calculator) SetPriceOracle(oracle interfaces.PriceOracle) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.priceOracle = oracle
+}
+
 // initializeDefaultThresholds sets up default profitability thresholds for each strategy
 func (c *Calculator) initializeDefaultThresholds() {
 	c.thresholds[interfaces.StrategySandwich] = &ProfitThreshold{
@@ -83,14 +97,42 @@ func (c *Calculator) initializeDefaultThresholds() {
 		MinSuccessProbability: 0.75,             // 75%
 		MaxRiskScore:          0.35,             // 35%
 	}
+
+	c.thresholds[interfaces.StrategyLiquidation] = &ProfitThreshold{
+		MinNetProfit:          big.NewInt(1e16), // 0.01 ETH
+		MinProfitMargin:       0.01,             // 1%
+		MinSuccessProbability: 0.6,              // 60%
+		MaxRiskScore:          0.3,              // 30%
+	}
+
+	c.thresholds[interfaces.StrategyJIT] = &ProfitThreshold{
+		MinNetProfit:          big.NewInt(5e15), // 0.005 ETH
+		MinProfitMargin:       0.01,             // 1%
+		MinSuccessProbability: 0.7,              // 70%
+		MaxRiskScore:          0.35,             // 35%
+	}
+
+	c.thresholds[interfaces.StrategySniping] = &ProfitThreshold{
+		MinNetProfit:          big.NewInt(2e16), // 0.02 ETH
+		MinProfitMargin:       0.05,             // 5%
+		MinSuccessProbability: 0.5,              // 50%
+		MaxRiskScore:          0.5,              // 50%
+	}
 }
 
-// CalculateProfit calculates expected profitability for an MEV opportunity
+// CalculateProfit calculates expected profitability for an MEV opportunity. The
+// expected profit and slippage are in the opportunity's profit token and are
+// valued in wei of ETH, so every figure in the estimate is in wei.
 func (c *Calculator) CalculateProfit(ctx context.Context, opportunity *interfaces.MEVOpportunity) (*interfaces.ProfitEstimate, error) {
 	if opportunity == nil {
 		return nil, fmt.Errorf("opportunity cannot be nil")
 	}
 
+	grossProfit, err := c.valueETH(opportunity.ProfitToken, opportunity.ExpectedProfit)
+	if err != nil {
+		return nil, err
+	}
+
 	// Calculate gas costs
 	gasCosts, err := c.CalculateGasCosts(ctx, opportunity.ExecutionTxs)
 	if err != nil {
@@ -102,27 +144,31 @@ func (c *Calculator) CalculateProfit(ctx context.Context, opportunity *interface
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate slippage: %w", err)
 	}
+	slippageCosts, err = c.valueETH(opportunity.ProfitToken, slippageCosts)
+	if err != nil {
+		return nil, err
+	}
 
 	// Calculate net profit
-	netProfit := new(big.Int).Sub(opportunity.ExpectedProfit, gasCosts)
+	netProfit := new(big.Int).Sub(grossProfit, gasCosts)
 	netProfit.Sub(netProfit, slippageCosts)
 
 	// Calculate profit margin
 	var profitMargin float64
-	if opportunity.ExpectedProfit.Cmp(big.NewInt(0)) > 0 {
+	if grossProfit.Cmp(big.NewInt(0)) > 0 {
 		netProfitFloat, _ := netProfit.Float64()
-		expectedProfitFloat, _ := opportunity.ExpectedProfit.Float64()
-		profitMargin = netProfitFloat / expectedProfitFloat
+		grossProfitFloat, _ := grossProfit.Float64()
+		profitMargin = netProfitFloat / grossProfitFloat
 	}
 
 	// Run Monte Carlo simulation for risk assessment
-	mcResult, err := c.runMonteCarloSimulation(ctx, opportunity, gasCosts, slippageCosts)
+	mcResult, err := c.runMonteCarloSimulation(ctx, grossProfit, gasCosts, slippageCosts)
 	if err != nil {
 		return nil, fmt.Errorf("Monte Carlo simulation failed: %w", err)
 	}
 
 	estimate := &interfaces.ProfitEstimate{
-		GrossProfit:        new(big.Int).Set(opportunity.ExpectedProfit),
+		GrossProfit:        grossProfit,
 		GasCosts:          gasCosts,
 		SlippageCosts:     slippageCosts,
 		NetProfit:         netProfit,
@@ -135,6 +181,29 @@ func (c *Calculator) CalculateProfit(ctx context.Context, opportunity *interface
 	return estimate, nil
 }
 
+// valueETH converts an amount of the profit token to wei of ETH
+func (c *Calculator) valueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	if amount == nil {
+		return big.NewInt(0), nil
+	}
+	if token == (common.Address{}) {
+		return new(big.Int).Set(amount), nil
+	}
+
+	c.mu.RLock()
+	oracle := c.priceOracle
+	c.mu.RUnlock()
+
+	if oracle == nil {
+		return nil, fmt.Errorf("%w: no price oracle for token %s", ErrUnpriced, token.Hex())
+	}
+	value, err := oracle.ValueETH(token, amount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnpriced, err)
+	}
+	return value, nil
+}
+
 // CalculateGasCosts calculates total gas costs for execution transactions
 func (c *Calculator) CalculateGasCosts(ctx context.Context, txs []*types.Transaction) (*big.Int, error) {
 	if len(txs) == 0 {
@@ -196,9 +265,13 @@ func (c *Calculator) CalculateSlippage(ctx context.Context, opportunity *interfa
 	return slippageEst.ExpectedSlippage, nil
 }
 
-// ValidateProfitability checks if an opportunity meets profitability thresholds
+// ValidateProfitability checks if an opportunity meets profitability thresholds.
+// Opportunities whose profit can't be priced in ETH are rejected.
 func (c *Calculator) ValidateProfitability(ctx context.Context, opportunity *interfaces.MEVOpportunity) (bool, error) {
 	estimate, err := c.CalculateProfit(ctx, opportunity)
+	if errors.Is(err, ErrUnpriced) {
+		return false, nil
+	}
 	if err != nil {
 		return false, fmt.Errorf("failed to calculate profit: %w", err)
 	}
@@ -255,7 +328,7 @@ type MonteCarloResult struct {
 }
 
 // runMonteCarloSimulation performs Monte Carlo simulation for risk assessment
-func (c *Calculator) runMonteCarloSimulation(ctx context.Context, opportunity *interfaces.MEVOpportunity, baseCosts, baseSlippage *big.Int) (*MonteCarloResult, error) {
+func (c *Calculator) runMonteCarloSimulation(ctx context.Context, grossProfit, baseCosts, baseSlippage *big.Int) (*MonteCarloResult, error) {
 	config := &MonteCarloConfig{
 		Iterations:        1000,
 		GasVariance:       0.2,  // 20% variance
@@ -267,7 +340,7 @@ func (c *Calculator) runMonteCarloSimulation(ctx context.Context, opportunity *i
 	profits := make([]float64, config.Iterations)
 	successCount := 0
 
-	baseProfit, _ := opportunity.ExpectedProfit.Float64()
+	baseProfit, _ := grossProfit.Float64()
 	baseCostsFloat, _ := baseCosts.Float64()
 	baseSlippageFloat, _ := baseSlippage.Float64()
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -70,7 +71,7 @@ func TestNewCalculator(t *testing.T) {
 	assert.NotNil(t, calc)
 	assert.Equal(t, gasEstimator, calc.gasEstimator)
 	assert.Equal(t, slippageCalculator, calc.slippageCalculator)
-	assert.Len(t, calc.thresholds, 4) // Should have thresholds for all 4 strategies
+	assert.Len(t, calc.thresholds, 7) // Should have thresholds for all 7 strategies
 }
 
 func TestCalculateProfit_Success(t *testing.T) {
@@ -450,6 +451,9 @@ func TestDefaultThresholds(t *testing.T) {
 		interfaces.StrategyBackrun,
 		interfaces.StrategyFrontrun,
 		interfaces.StrategyTimeBandit,
+		interfaces.StrategyLiquidation,
+		interfaces.StrategyJIT,
+		interfaces.StrategySniping,
 	}
 
 	for _, strategy := range strategies {
@@ -619,6 +623,49 @@ func TestCalculateProfit_ZeroExpectedProfit(t *testing.T) {
 	slippageCalculator.AssertExpectations(t)
 }
 
+func TestCalculateProfit_TokenDenominated(t *testing.T) {
+	calc := NewCalculator(&MockGasEstimator{}, &MockSlippageCalculator{})
+	ctx := context.Background()
+
+	// 300 USDC is 0.1 ETH at $3000
+	opportunity := &interfaces.MEVOpportunity{
+		ID:             "test-1",
+		Strategy:       interfaces.StrategySandwich,
+		ExpectedProfit: big.NewInt(300e6),
+		ProfitToken:    pricing.BaseUSDC,
+	}
+
+	// Without an oracle the USDC profit can't be compared with ETH thresholds
+	_, err := calc.CalculateProfit(ctx, opportunity)
+	assert.ErrorIs(t, err, ErrUnpriced)
+	valid, err := calc.ValidateProfitability(ctx, opportunity)
+	require.NoError(t, err)
+	assert.False(t, valid)
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+	require.NoError(t, oracle.UpdatePool(&interfaces.PoolState{
+		Protocol: interfaces.ProtocolUniswapV2,
+		Address:  common.HexToAddress("0x1001"),
+		Tokens:   []common.Address{pricing.BaseWETH, pricing.BaseUSDC},
+		Reserves: []*big.Int{new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), big.NewInt(300000e6)},
+	}))
+	calc.SetPriceOracle(oracle)
+
+	estimate, err := calc.CalculateProfit(ctx, opportunity)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e17), estimate.GrossProfit)
+	assert.Equal(t, big.NewInt(1e17), estimate.NetProfit)
+
+	// An unknown token is still rejected
+	opportunity.ProfitToken = common.HexToAddress("0xdead")
+	valid, err = calc.ValidateProfitability(ctx, opportunity)
+	require.NoError(t, err)
+	assert.False(t, valid)
+}
+
 func TestCalculateProfit_HighGasCosts(t *testing.T) {
 	gasEstimator := &MockGasEstimator{}
 	slippageCalculator := &MockSlippageCalculator{}
@@ -48,6 +48,8 @@ func (g *GasEstimator) initializeStrategyGasUsage() {
 	g.strategyGasUsage[interfaces.StrategyBackrun] = 150000    // Single arbitrage transaction
 	g.strategyGasUsage[interfaces.StrategyFrontrun] = 100000   // Single front-run transaction
 	g.strategyGasUsage[interfaces.StrategyTimeBandit] = 250000 // Multiple transaction bundle
+	g.strategyGasUsage[interfaces.StrategyJIT] = 450000        // Mint, burn and collect around the swap
+	g.strategyGasUsage[interfaces.StrategySniping] = 300000    // Buy right after the launch
 }
 
 // EstimateGas estimates gas usage for a single transaction
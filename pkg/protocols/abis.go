@@ -0,0 +1,326 @@
+package protocols
+
+// V2-style pair ABI shared by SushiSwap V2 and BaseSwap
+const v2ForkPairABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "amount0In", "type": "uint256"},
+			{"indexed": false, "name": "amount1In", "type": "uint256"},
+			{"indexed": false, "name": "amount0Out", "type": "uint256"},
+			{"indexed": false, "name": "amount1Out", "type": "uint256"},
+			{"indexed": true, "name": "to", "type": "address"}
+		],
+		"name": "Swap",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Mint",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"},
+			{"indexed": true, "name": "to", "type": "address"}
+		],
+		"name": "Burn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "reserve0", "type": "uint112"},
+			{"indexed": false, "name": "reserve1", "type": "uint112"}
+		],
+		"name": "Sync",
+		"type": "event"
+	}
+]`
+
+// V2-style router ABI shared by SushiSwap V2 and BaseSwap
+const v2ForkRouterABI = `[
+	{
+		"inputs": [
+			{"name": "amountIn", "type": "uint256"},
+			{"name": "amountOutMin", "type": "uint256"},
+			{"name": "path", "type": "address[]"},
+			{"name": "to", "type": "address"},
+			{"name": "deadline", "type": "uint256"}
+		],
+		"name": "swapExactTokensForTokens",
+		"outputs": [{"name": "amounts", "type": "uint256[]"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// V3-style pool events shared by SushiSwap V3 and PancakeSwap V3 (Swap is added per venue)
+const v3ForkPoolEvents = `
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": false, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": true, "name": "tickLower", "type": "int24"},
+			{"indexed": true, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "amount", "type": "uint128"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Mint",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": true, "name": "tickLower", "type": "int24"},
+			{"indexed": true, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "amount", "type": "uint128"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"}
+		],
+		"name": "Burn",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "owner", "type": "address"},
+			{"indexed": false, "name": "recipient", "type": "address"},
+			{"indexed": true, "name": "tickLower", "type": "int24"},
+			{"indexed": true, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "amount0", "type": "uint128"},
+			{"indexed": false, "name": "amount1", "type": "uint128"}
+		],
+		"name": "Collect",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "uint256"},
+			{"indexed": false, "name": "amount1", "type": "uint256"},
+			{"indexed": false, "name": "paid0", "type": "uint256"},
+			{"indexed": false, "name": "paid1", "type": "uint256"}
+		],
+		"name": "Flash",
+		"type": "event"
+	}`
+
+// SushiSwap V3 pool ABI (identical to Uniswap V3)
+const sushiSwapV3PoolABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "int256"},
+			{"indexed": false, "name": "amount1", "type": "int256"},
+			{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+			{"indexed": false, "name": "liquidity", "type": "uint128"},
+			{"indexed": false, "name": "tick", "type": "int24"}
+		],
+		"name": "Swap",
+		"type": "event"
+	},` + v3ForkPoolEvents + `
+]`
+
+// PancakeSwap V3 pool ABI; Swap also reports the protocol fee taken on each side
+const pancakeSwapV3PoolABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": true, "name": "recipient", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "int256"},
+			{"indexed": false, "name": "amount1", "type": "int256"},
+			{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+			{"indexed": false, "name": "liquidity", "type": "uint128"},
+			{"indexed": false, "name": "tick", "type": "int24"},
+			{"indexed": false, "name": "protocolFeesToken0", "type": "uint128"},
+			{"indexed": false, "name": "protocolFeesToken1", "type": "uint128"}
+		],
+		"name": "Swap",
+		"type": "event"
+	},` + v3ForkPoolEvents + `
+]`
+
+// SushiSwap V3 SwapRouter ABI (exactInputSingle with deadline)
+const sushiSwapV3RouterABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"name": "tokenIn", "type": "address"},
+					{"name": "tokenOut", "type": "address"},
+					{"name": "fee", "type": "uint24"},
+					{"name": "recipient", "type": "address"},
+					{"name": "deadline", "type": "uint256"},
+					{"name": "amountIn", "type": "uint256"},
+					{"name": "amountOutMinimum", "type": "uint256"},
+					{"name": "sqrtPriceLimitX96", "type": "uint160"}
+				],
+				"name": "params",
+				"type": "tuple"
+			}
+		],
+		"name": "exactInputSingle",
+		"outputs": [{"name": "amountOut", "type": "uint256"}],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+// PancakeSwap V3 SmartRouter ABI (exactInputSingle without deadline)
+const pancakeSwapV3RouterABI = `[
+	{
+		"inputs": [
+			{
+				"components": [
+					{"name": "tokenIn", "type": "address"},
+					{"name": "tokenOut", "type": "address"},
+					{"name": "fee", "type": "uint24"},
+					{"name": "recipient", "type": "address"},
+					{"name": "amountIn", "type": "uint256"},
+					{"name": "amountOutMinimum", "type": "uint256"},
+					{"name": "sqrtPriceLimitX96", "type": "uint160"}
+				],
+				"name": "params",
+				"type": "tuple"
+			}
+		],
+		"name": "exactInputSingle",
+		"outputs": [{"name": "amountOut", "type": "uint256"}],
+		"stateMutability": "payable",
+		"type": "function"
+	}
+]`
+
+// Uniswap V4 PoolManager ABI (pool lifecycle events and swap entry point)
+const uniswapV4PoolManagerABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "id", "type": "bytes32"},
+			{"indexed": true, "name": "currency0", "type": "address"},
+			{"indexed": true, "name": "currency1", "type": "address"},
+			{"indexed": false, "name": "fee", "type": "uint24"},
+			{"indexed": false, "name": "tickSpacing", "type": "int24"},
+			{"indexed": false, "name": "hooks", "type": "address"},
+			{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+			{"indexed": false, "name": "tick", "type": "int24"}
+		],
+		"name": "Initialize",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "id", "type": "bytes32"},
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "amount0", "type": "int128"},
+			{"indexed": false, "name": "amount1", "type": "int128"},
+			{"indexed": false, "name": "sqrtPriceX96", "type": "uint160"},
+			{"indexed": false, "name": "liquidity", "type": "uint128"},
+			{"indexed": false, "name": "tick", "type": "int24"},
+			{"indexed": false, "name": "fee", "type": "uint24"}
+		],
+		"name": "Swap",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "id", "type": "bytes32"},
+			{"indexed": true, "name": "sender", "type": "address"},
+			{"indexed": false, "name": "tickLower", "type": "int24"},
+			{"indexed": false, "name": "tickUpper", "type": "int24"},
+			{"indexed": false, "name": "liquidityDelta", "type": "int256"},
+			{"indexed": false, "name": "salt", "type": "bytes32"}
+		],
+		"name": "ModifyLiquidity",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{
+				"components": [
+					{"name": "currency0", "type": "address"},
+					{"name": "currency1", "type": "address"},
+					{"name": "fee", "type": "uint24"},
+					{"name": "tickSpacing", "type": "int24"},
+					{"name": "hooks", "type": "address"}
+				],
+				"name": "key",
+				"type": "tuple"
+			},
+			{
+				"components": [
+					{"name": "zeroForOne", "type": "bool"},
+					{"name": "amountSpecified", "type": "int256"},
+					{"name": "sqrtPriceLimitX96", "type": "uint160"}
+				],
+				"name": "params",
+				"type": "tuple"
+			},
+			{"name": "hookData", "type": "bytes"}
+		],
+		"name": "swap",
+		"outputs": [{"name": "swapDelta", "type": "int256"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
+
+// Curve stableswap pool ABI (exchange events and entry point)
+const curveStableSwapABI = `[
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "buyer", "type": "address"},
+			{"indexed": false, "name": "sold_id", "type": "int128"},
+			{"indexed": false, "name": "tokens_sold", "type": "uint256"},
+			{"indexed": false, "name": "bought_id", "type": "int128"},
+			{"indexed": false, "name": "tokens_bought", "type": "uint256"}
+		],
+		"name": "TokenExchange",
+		"type": "event"
+	},
+	{
+		"anonymous": false,
+		"inputs": [
+			{"indexed": true, "name": "buyer", "type": "address"},
+			{"indexed": false, "name": "sold_id", "type": "int128"},
+			{"indexed": false, "name": "tokens_sold", "type": "uint256"},
+			{"indexed": false, "name": "bought_id", "type": "int128"},
+			{"indexed": false, "name": "tokens_bought", "type": "uint256"}
+		],
+		"name": "TokenExchangeUnderlying",
+		"type": "event"
+	},
+	{
+		"inputs": [
+			{"name": "i", "type": "int128"},
+			{"name": "j", "type": "int128"},
+			{"name": "dx", "type": "uint256"},
+			{"name": "min_dy", "type": "uint256"}
+		],
+		"name": "exchange",
+		"outputs": [{"name": "", "type": "uint256"}],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}
+]`
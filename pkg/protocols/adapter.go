@@ -0,0 +1,88 @@
+package protocols
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// baseAdapter holds the ABI plumbing shared by every adapter
+type baseAdapter struct {
+	protocol interfaces.Protocol
+	forkOf   interfaces.Protocol
+	abis     map[interfaces.ContractType]string
+	events   *abi.ABI // Contract whose logs the adapter decodes
+}
+
+// Protocol returns the protocol served by the adapter
+func (b *baseAdapter) Protocol() interfaces.Protocol {
+	return b.protocol
+}
+
+// ForkOf returns the protocol whose event layouts the adapter reuses
+func (b *baseAdapter) ForkOf() interfaces.Protocol {
+	return b.forkOf
+}
+
+// ABIs returns the adapter's contract ABIs keyed by contract type
+func (b *baseAdapter) ABIs() map[interfaces.ContractType]string {
+	abis := make(map[interfaces.ContractType]string, len(b.abis))
+	for contractType, abiJSON := range b.abis {
+		abis[contractType] = abiJSON
+	}
+	return abis
+}
+
+// unpackLog finds the event matching the log's signature and decodes it
+func (b *baseAdapter) unpackLog(log *ethtypes.Log) (*abi.Event, map[string]interface{}, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil, fmt.Errorf("log has no topics")
+	}
+
+	event, err := b.events.EventByID(log.Topics[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s event not found for signature %s", b.protocol.String(), log.Topics[0].Hex())
+	}
+
+	decoded, err := events.UnpackEventLog(b.events, *event, log)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode %s %s event: %w", b.protocol.String(), event.Name, err)
+	}
+
+	return event, decoded, nil
+}
+
+// newParsedEvent returns a ParsedEvent carrying the log's metadata
+func (b *baseAdapter) newParsedEvent(log *ethtypes.Log, eventType interfaces.EventType) *interfaces.ParsedEvent {
+	return &interfaces.ParsedEvent{
+		Protocol:    b.protocol,
+		EventType:   eventType,
+		Address:     log.Address,
+		TxHash:      log.TxHash,
+		BlockNumber: log.BlockNumber,
+		LogIndex:    log.Index,
+		RawLog:      log,
+	}
+}
+
+// swapDirection resolves the input and output token indices of a two-token pool
+func swapDirection(pool *interfaces.PoolState, tokenIn, tokenOut common.Address) (int, int, error) {
+	if pool == nil {
+		return 0, 0, fmt.Errorf("pool state is required")
+	}
+	if len(pool.Tokens) != 2 {
+		return 0, 0, fmt.Errorf("expected 2 pool tokens, got %d", len(pool.Tokens))
+	}
+
+	in := pool.TokenIndex(tokenIn)
+	out := pool.TokenIndex(tokenOut)
+	if in < 0 || out < 0 || in == out {
+		return 0, 0, fmt.Errorf("token pair %s/%s is not in pool %s", tokenIn.Hex(), tokenOut.Hex(), pool.Address.Hex())
+	}
+
+	return in, out, nil
+}
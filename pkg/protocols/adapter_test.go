@@ -0,0 +1,76 @@
+package protocols
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures in testdata are eth_getLogs responses encoded with each venue's
+// event layout, so they exercise the same decoding path as live RPC data.
+var (
+	testWETH   = common.HexToAddress("0x4200000000000000000000000000000000000006")
+	testUSDC   = common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	testTrader = common.HexToAddress("0x9a8f92a830a5cB89a3816e3D267CB7791c16b04D")
+)
+
+// loadLogs reads a recorded eth_getLogs response from testdata
+func loadLogs(t *testing.T, name string) []*ethtypes.Log {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	var logs []*ethtypes.Log
+	require.NoError(t, json.Unmarshal(data, &logs))
+	return logs
+}
+
+// mustBigInt parses a base-10 integer for test expectations
+func mustBigInt(t *testing.T, value string) *big.Int {
+	t.Helper()
+
+	parsed, ok := new(big.Int).SetString(value, 10)
+	require.True(t, ok, "invalid integer %s", value)
+	return parsed
+}
+
+func TestSwapDirection(t *testing.T) {
+	pool := &interfaces.PoolState{Tokens: []common.Address{testWETH, testUSDC}}
+
+	in, out, err := swapDirection(pool, testWETH, testUSDC)
+	require.NoError(t, err)
+	assert.Equal(t, 0, in)
+	assert.Equal(t, 1, out)
+
+	in, out, err = swapDirection(pool, testUSDC, testWETH)
+	require.NoError(t, err)
+	assert.Equal(t, 1, in)
+	assert.Equal(t, 0, out)
+
+	_, _, err = swapDirection(pool, testWETH, testWETH)
+	assert.Error(t, err)
+
+	_, _, err = swapDirection(pool, testTrader, testUSDC)
+	assert.Error(t, err)
+
+	_, _, err = swapDirection(nil, testWETH, testUSDC)
+	assert.Error(t, err)
+}
+
+func TestBaseAdapter_ABIsReturnsCopy(t *testing.T) {
+	adapter := NewCurveAdapter()
+
+	abis := adapter.ABIs()
+	delete(abis, interfaces.ContractTypePool)
+
+	assert.Contains(t, adapter.ABIs(), interfaces.ContractTypePool)
+}
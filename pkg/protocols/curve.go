@@ -0,0 +1,112 @@
+package protocols
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+var curveStableSwap = events.MustParseABI(curveStableSwapABI)
+
+// curveAdapter serves Curve stableswap pools, which are swapped against directly
+type curveAdapter struct {
+	baseAdapter
+}
+
+// NewCurveAdapter creates an adapter for Curve stableswap pools
+func NewCurveAdapter() interfaces.ProtocolAdapter {
+	return &curveAdapter{
+		baseAdapter: baseAdapter{
+			protocol: interfaces.ProtocolCurve,
+			forkOf:   interfaces.ProtocolUnknown,
+			abis: map[interfaces.ContractType]string{
+				interfaces.ContractTypePool: curveStableSwapABI,
+			},
+			events: curveStableSwap,
+		},
+	}
+}
+
+// DecodeLog decodes TokenExchange and TokenExchangeUnderlying events
+func (a *curveAdapter) DecodeLog(log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	event, decoded, err := a.unpackLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	soldID := events.DecodedBigInt(decoded, "sold_id")
+	boughtID := events.DecodedBigInt(decoded, "bought_id")
+	tokensSold := events.DecodedBigInt(decoded, "tokens_sold")
+	tokensBought := events.DecodedBigInt(decoded, "tokens_bought")
+	if soldID == nil || boughtID == nil || tokensSold == nil || tokensBought == nil {
+		return nil, fmt.Errorf("missing exchange data in %s %s event", a.protocol.String(), event.Name)
+	}
+
+	buyer := events.DecodedAddress(decoded, "buyer")
+	parsedEvent := a.newParsedEvent(log, interfaces.EventTypeSwap)
+	parsedEvent.SwapEvent = &interfaces.SwapEvent{
+		Protocol:  a.protocol,
+		Pool:      log.Address,
+		Sender:    buyer,
+		Recipient: buyer,
+		AmountIn:  tokensSold,
+		AmountOut: tokensBought,
+		CoinIn:    int(soldID.Int64()),
+		CoinOut:   int(boughtID.Int64()),
+	}
+	return parsedEvent, nil
+}
+
+// GetAmountOut quotes an exchange using the stableswap invariant
+func (a *curveAdapter) GetAmountOut(pool *interfaces.PoolState, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	if pool == nil {
+		return nil, fmt.Errorf("pool state is required")
+	}
+
+	i, j, err := curveCoinIndices(pool, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	if len(pool.Reserves) != len(pool.Tokens) {
+		return nil, fmt.Errorf("expected %d balances, got %d", len(pool.Tokens), len(pool.Reserves))
+	}
+
+	return stableSwapAmountOut(pool.Reserves, pool.Rates, pool.Amplification, pool.Fee, i, j, amountIn)
+}
+
+// EncodeSwap encodes exchange(i, j, dx, min_dy) on the pool itself
+func (a *curveAdapter) EncodeSwap(params *interfaces.SwapParams) (*interfaces.SwapCalldata, error) {
+	if params == nil || params.Pool == nil || params.AmountIn == nil || params.AmountOutMin == nil {
+		return nil, fmt.Errorf("pool, amount in and minimum amount out are required")
+	}
+
+	i, j, err := curveCoinIndices(params.Pool, params.TokenIn, params.TokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := curveStableSwap.Pack("exchange", big.NewInt(int64(i)), big.NewInt(int64(j)), params.AmountIn, params.AmountOutMin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s swap: %w", a.protocol.String(), err)
+	}
+
+	return &interfaces.SwapCalldata{
+		To:    params.Pool.Address,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+// curveCoinIndices resolves the coin indices of a token pair in a Curve pool
+func curveCoinIndices(pool *interfaces.PoolState, tokenIn, tokenOut common.Address) (int, int, error) {
+	i := pool.TokenIndex(tokenIn)
+	j := pool.TokenIndex(tokenOut)
+	if i < 0 || j < 0 || i == j {
+		return 0, 0, fmt.Errorf("token pair %s/%s is not in pool %s", tokenIn.Hex(), tokenOut.Hex(), pool.Address.Hex())
+	}
+	return i, j, nil
+}
@@ -0,0 +1,97 @@
+package protocols
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDAI = common.HexToAddress("0x50c5725949A6F0c72E6C4a641F24049A917DB0Cb")
+
+func TestCurveAdapter_DecodeLog(t *testing.T) {
+	adapter := NewCurveAdapter()
+	logs := loadLogs(t, "curve_logs.json")
+	require.Len(t, logs, 2)
+
+	tests := []struct {
+		log       int
+		coinIn    int
+		coinOut   int
+		amountIn  string
+		amountOut string
+	}{
+		{0, 0, 1, "10000000000", "9996011201"},
+		{1, 1, 2, "2500000000", "2499122041"},
+	}
+
+	for _, tt := range tests {
+		parsedEvent, err := adapter.DecodeLog(logs[tt.log])
+		require.NoError(t, err)
+		assert.Equal(t, interfaces.ProtocolCurve, parsedEvent.Protocol)
+		assert.Equal(t, interfaces.EventTypeSwap, parsedEvent.EventType)
+		require.NotNil(t, parsedEvent.SwapEvent)
+		assert.Equal(t, testTrader, parsedEvent.SwapEvent.Sender)
+		assert.Equal(t, tt.coinIn, parsedEvent.SwapEvent.CoinIn)
+		assert.Equal(t, tt.coinOut, parsedEvent.SwapEvent.CoinOut)
+		assert.Equal(t, mustBigInt(t, tt.amountIn), parsedEvent.SwapEvent.AmountIn)
+		assert.Equal(t, mustBigInt(t, tt.amountOut), parsedEvent.SwapEvent.AmountOut)
+	}
+}
+
+func TestCurveAdapter_GetAmountOut(t *testing.T) {
+	adapter := NewCurveAdapter()
+	pool := &interfaces.PoolState{
+		Tokens: []common.Address{testUSDC, testDAI, testWETH},
+		Reserves: []*big.Int{
+			mustBigInt(t, "1000000000000"),
+			mustBigInt(t, "1000000000000000000000000"),
+			mustBigInt(t, "1000000000000000000000000"),
+		},
+		Rates: []*big.Int{
+			mustBigInt(t, "1000000000000000000000000000000"),
+			big.NewInt(1e18),
+			big.NewInt(1e18),
+		},
+		Fee:           100,
+		Amplification: big.NewInt(200),
+	}
+
+	amountOut, err := adapter.GetAmountOut(pool, testUSDC, testDAI, big.NewInt(1e9))
+	require.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "999895025392958410055"), amountOut)
+
+	_, err = adapter.GetAmountOut(pool, testUSDC, testTrader, big.NewInt(1e9))
+	assert.Error(t, err)
+
+	pool.Reserves = pool.Reserves[:2]
+	_, err = adapter.GetAmountOut(pool, testUSDC, testDAI, big.NewInt(1e9))
+	assert.Error(t, err)
+}
+
+func TestCurveAdapter_EncodeSwap(t *testing.T) {
+	adapter := NewCurveAdapter()
+	pool := &interfaces.PoolState{
+		Address: common.HexToAddress("0xf6C5F01C7F3148891ad0e19DF78743D31E390D1f"),
+		Tokens:  []common.Address{testUSDC, testDAI},
+	}
+
+	call, err := adapter.EncodeSwap(&interfaces.SwapParams{
+		Pool:         pool,
+		TokenIn:      testDAI,
+		TokenOut:     testUSDC,
+		AmountIn:     mustBigInt(t, "1000000000000000000000"),
+		AmountOutMin: big.NewInt(999e6),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pool.Address, call.To)
+	assert.Equal(t, common.FromHex("0x3df02124"), call.Data[:4])
+
+	args, err := curveStableSwap.Methods["exchange"].Inputs.Unpack(call.Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), args[0].(*big.Int).Int64())
+	assert.Equal(t, int64(0), args[1].(*big.Int).Int64())
+}
@@ -0,0 +1,220 @@
+package protocols
+
+import (
+	"fmt"
+	"math/big"
+)
+
+var (
+	// feeDenominator is the denominator of pool fees expressed in hundredths of a bip
+	feeDenominator = big.NewInt(1_000_000)
+	// q96 is the fixed-point scale of sqrtPriceX96
+	q96 = new(big.Int).Lsh(big.NewInt(1), 96)
+	// rateScale is the scale of Curve rate multipliers
+	rateScale = big.NewInt(1e18)
+	// curveFeeScale converts fees from hundredths of a bip to Curve's 1e10 denominator
+	curveFeeScale = big.NewInt(10_000)
+	curveFeeDenom = big.NewInt(10_000_000_000)
+)
+
+// constantProductAmountOut returns the output of an x*y=k swap after the pool fee
+func constantProductAmountOut(amountIn, reserveIn, reserveOut *big.Int, fee uint32) (*big.Int, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("amount in must be positive")
+	}
+	if reserveIn == nil || reserveOut == nil || reserveIn.Sign() <= 0 || reserveOut.Sign() <= 0 {
+		return nil, fmt.Errorf("pool has no liquidity")
+	}
+
+	amountInWithFee := new(big.Int).Mul(amountIn, new(big.Int).Sub(feeDenominator, big.NewInt(int64(fee))))
+	numerator := new(big.Int).Mul(amountInWithFee, reserveOut)
+	denominator := new(big.Int).Add(new(big.Int).Mul(reserveIn, feeDenominator), amountInWithFee)
+
+	return numerator.Div(numerator, denominator), nil
+}
+
+// concentratedAmountOut returns the output of a swap against concentrated
+// liquidity, assuming the swap stays within the current tick range. Quotes
+// for swaps large enough to cross an initialized tick are optimistic.
+func concentratedAmountOut(amountIn, sqrtPriceX96, liquidity *big.Int, fee uint32, zeroForOne bool) (*big.Int, error) {
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("amount in must be positive")
+	}
+	if sqrtPriceX96 == nil || sqrtPriceX96.Sign() <= 0 {
+		return nil, fmt.Errorf("pool price is not initialized")
+	}
+	if liquidity == nil || liquidity.Sign() <= 0 {
+		return nil, fmt.Errorf("pool has no active liquidity")
+	}
+
+	amountInLessFee := new(big.Int).Mul(amountIn, new(big.Int).Sub(feeDenominator, big.NewInt(int64(fee))))
+	amountInLessFee.Div(amountInLessFee, feeDenominator)
+
+	if zeroForOne {
+		// sqrtNext = L * sqrtP * Q96 / (L * Q96 + amountIn * sqrtP)
+		numerator := new(big.Int).Mul(liquidity, sqrtPriceX96)
+		numerator.Mul(numerator, q96)
+		denominator := new(big.Int).Mul(liquidity, q96)
+		denominator.Add(denominator, new(big.Int).Mul(amountInLessFee, sqrtPriceX96))
+		sqrtNext := numerator.Div(numerator, denominator)
+
+		// amountOut = L * (sqrtP - sqrtNext) / Q96
+		amountOut := new(big.Int).Sub(sqrtPriceX96, sqrtNext)
+		amountOut.Mul(amountOut, liquidity)
+		return amountOut.Div(amountOut, q96), nil
+	}
+
+	// sqrtNext = sqrtP + amountIn * Q96 / L
+	sqrtNext := new(big.Int).Mul(amountInLessFee, q96)
+	sqrtNext.Div(sqrtNext, liquidity)
+	sqrtNext.Add(sqrtNext, sqrtPriceX96)
+
+	// amountOut = L * Q96 * (sqrtNext - sqrtP) / (sqrtNext * sqrtP)
+	amountOut := new(big.Int).Sub(sqrtNext, sqrtPriceX96)
+	amountOut.Mul(amountOut, liquidity)
+	amountOut.Mul(amountOut, q96)
+	return amountOut.Div(amountOut, new(big.Int).Mul(sqrtNext, sqrtPriceX96)), nil
+}
+
+// stableSwapAmountOut mirrors Curve's get_dy for a plain stableswap pool
+func stableSwapAmountOut(balances, rates []*big.Int, amplification *big.Int, fee uint32, i, j int, amountIn *big.Int) (*big.Int, error) {
+	n := len(balances)
+	if n < 2 || i == j || i < 0 || j < 0 || i >= n || j >= n {
+		return nil, fmt.Errorf("invalid coin indices %d and %d for %d coins", i, j, n)
+	}
+	if amountIn == nil || amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("amount in must be positive")
+	}
+	if amplification == nil || amplification.Sign() <= 0 {
+		return nil, fmt.Errorf("amplification coefficient is required")
+	}
+	if rates != nil && len(rates) != n {
+		return nil, fmt.Errorf("expected %d rates, got %d", n, len(rates))
+	}
+
+	rate := func(k int) *big.Int {
+		if rates == nil {
+			return rateScale
+		}
+		return rates[k]
+	}
+
+	xp := make([]*big.Int, n)
+	for k, balance := range balances {
+		if balance == nil || balance.Sign() <= 0 {
+			return nil, fmt.Errorf("pool has no balance for coin %d", k)
+		}
+		xp[k] = new(big.Int).Mul(balance, rate(k))
+		xp[k].Div(xp[k], rateScale)
+	}
+
+	x := new(big.Int).Mul(amountIn, rate(i))
+	x.Div(x, rateScale)
+	x.Add(x, xp[i])
+
+	y, err := stableSwapGetY(i, j, x, xp, amplification)
+	if err != nil {
+		return nil, err
+	}
+
+	dy := new(big.Int).Sub(xp[j], y)
+	dy.Sub(dy, big.NewInt(1))
+	if dy.Sign() <= 0 {
+		return big.NewInt(0), nil
+	}
+
+	feeAmount := new(big.Int).Mul(dy, new(big.Int).Mul(big.NewInt(int64(fee)), curveFeeScale))
+	feeAmount.Div(feeAmount, curveFeeDenom)
+	dy.Sub(dy, feeAmount)
+
+	dy.Mul(dy, rateScale)
+	return dy.Div(dy, rate(j)), nil
+}
+
+// stableSwapGetD computes the stableswap invariant D with Newton's method
+func stableSwapGetD(xp []*big.Int, amplification *big.Int) (*big.Int, error) {
+	n := big.NewInt(int64(len(xp)))
+	sum := new(big.Int)
+	for _, x := range xp {
+		sum.Add(sum, x)
+	}
+	if sum.Sign() == 0 {
+		return big.NewInt(0), nil
+	}
+
+	d := new(big.Int).Set(sum)
+	ann := new(big.Int).Mul(amplification, n)
+	nPlusOne := new(big.Int).Add(n, big.NewInt(1))
+	annMinusOne := new(big.Int).Sub(ann, big.NewInt(1))
+
+	for iteration := 0; iteration < 255; iteration++ {
+		dP := new(big.Int).Set(d)
+		for _, x := range xp {
+			dP.Mul(dP, d)
+			dP.Div(dP, new(big.Int).Mul(x, n))
+		}
+
+		previous := new(big.Int).Set(d)
+		numerator := new(big.Int).Mul(ann, sum)
+		numerator.Add(numerator, new(big.Int).Mul(dP, n))
+		numerator.Mul(numerator, d)
+		denominator := new(big.Int).Mul(annMinusOne, d)
+		denominator.Add(denominator, new(big.Int).Mul(nPlusOne, dP))
+		d = numerator.Div(numerator, denominator)
+
+		if new(big.Int).Sub(d, previous).CmpAbs(big.NewInt(1)) <= 0 {
+			return d, nil
+		}
+	}
+
+	return nil, fmt.Errorf("stableswap invariant did not converge")
+}
+
+// stableSwapGetY solves for the balance of coin j after coin i is set to x
+func stableSwapGetY(i, j int, x *big.Int, xp []*big.Int, amplification *big.Int) (*big.Int, error) {
+	d, err := stableSwapGetD(xp, amplification)
+	if err != nil {
+		return nil, err
+	}
+
+	n := big.NewInt(int64(len(xp)))
+	ann := new(big.Int).Mul(amplification, n)
+	c := new(big.Int).Set(d)
+	sum := new(big.Int)
+
+	for k := range xp {
+		var balance *big.Int
+		switch k {
+		case i:
+			balance = x
+		case j:
+			continue
+		default:
+			balance = xp[k]
+		}
+		sum.Add(sum, balance)
+		c.Mul(c, d)
+		c.Div(c, new(big.Int).Mul(balance, n))
+	}
+
+	c.Mul(c, d)
+	c.Div(c, new(big.Int).Mul(ann, n))
+	b := new(big.Int).Add(sum, new(big.Int).Div(d, ann))
+
+	y := new(big.Int).Set(d)
+	for iteration := 0; iteration < 255; iteration++ {
+		previous := new(big.Int).Set(y)
+		numerator := new(big.Int).Mul(y, y)
+		numerator.Add(numerator, c)
+		denominator := new(big.Int).Mul(y, big.NewInt(2))
+		denominator.Add(denominator, b)
+		denominator.Sub(denominator, d)
+		y = numerator.Div(numerator, denominator)
+
+		if new(big.Int).Sub(y, previous).CmpAbs(big.NewInt(1)) <= 0 {
+			return y, nil
+		}
+	}
+
+	return nil, fmt.Errorf("stableswap balance did not converge")
+}
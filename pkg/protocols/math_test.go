@@ -0,0 +1,88 @@
+package protocols
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstantProductAmountOut(t *testing.T) {
+	reserveIn := mustBigInt(t, "100000000000000000000")
+	reserveOut := mustBigInt(t, "200000000000000000000")
+
+	amountOut, err := constantProductAmountOut(big.NewInt(1e18), reserveIn, reserveOut, 3000)
+	require.NoError(t, err)
+	// Matches UniswapV2Library.getAmountOut with the 997/1000 fee
+	assert.Equal(t, mustBigInt(t, "1974316068794122597"), amountOut)
+
+	_, err = constantProductAmountOut(big.NewInt(0), reserveIn, reserveOut, 3000)
+	assert.Error(t, err)
+
+	_, err = constantProductAmountOut(big.NewInt(1e18), big.NewInt(0), reserveOut, 3000)
+	assert.Error(t, err)
+}
+
+func TestConcentratedAmountOut(t *testing.T) {
+	liquidity := mustBigInt(t, "1000000000000000000000000")
+
+	// At a price of 1 both directions are symmetric
+	zeroForOne, err := concentratedAmountOut(big.NewInt(1e18), q96, liquidity, 3000, true)
+	require.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "996999005991991025"), zeroForOne)
+
+	oneForZero, err := concentratedAmountOut(big.NewInt(1e18), q96, liquidity, 3000, false)
+	require.NoError(t, err)
+	assert.Equal(t, zeroForOne, oneForZero)
+
+	_, err = concentratedAmountOut(big.NewInt(1e18), q96, big.NewInt(0), 3000, true)
+	assert.Error(t, err)
+
+	_, err = concentratedAmountOut(big.NewInt(1e18), nil, liquidity, 3000, true)
+	assert.Error(t, err)
+}
+
+func TestStableSwapAmountOut(t *testing.T) {
+	t.Run("balanced pool", func(t *testing.T) {
+		balances := []*big.Int{
+			mustBigInt(t, "1000000000000000000000000"),
+			mustBigInt(t, "1000000000000000000000000"),
+		}
+
+		amountOut, err := stableSwapAmountOut(balances, nil, big.NewInt(100), 400, 0, 1, mustBigInt(t, "1000000000000000000000"))
+		require.NoError(t, err)
+		assert.Equal(t, mustBigInt(t, "999590103058584712249"), amountOut)
+	})
+
+	t.Run("mixed decimals", func(t *testing.T) {
+		// USDC (6 decimals) into an 18 decimal coin
+		balances := []*big.Int{
+			mustBigInt(t, "1000000000000"),
+			mustBigInt(t, "1000000000000000000000000"),
+			mustBigInt(t, "1000000000000000000000000"),
+		}
+		rates := []*big.Int{
+			mustBigInt(t, "1000000000000000000000000000000"),
+			big.NewInt(1e18),
+			big.NewInt(1e18),
+		}
+
+		amountOut, err := stableSwapAmountOut(balances, rates, big.NewInt(200), 100, 0, 1, big.NewInt(1e9))
+		require.NoError(t, err)
+		assert.Equal(t, mustBigInt(t, "999895025392958410055"), amountOut)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		balances := []*big.Int{big.NewInt(1e18), big.NewInt(1e18)}
+
+		_, err := stableSwapAmountOut(balances, nil, big.NewInt(100), 400, 0, 0, big.NewInt(1))
+		assert.Error(t, err)
+
+		_, err = stableSwapAmountOut(balances, nil, nil, 400, 0, 1, big.NewInt(1))
+		assert.Error(t, err)
+
+		_, err = stableSwapAmountOut(balances, []*big.Int{big.NewInt(1e18)}, big.NewInt(100), 400, 0, 1, big.NewInt(1))
+		assert.Error(t, err)
+	})
+}
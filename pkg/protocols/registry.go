@@ -0,0 +1,164 @@
+package protocols
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// ABIRegistrar is the subset of the ABI manager the registry needs to publish adapter ABIs
+type ABIRegistrar interface {
+	IsEventSupported(protocol interfaces.Protocol, eventSignature string) bool
+	RegisterProtocolABIs(protocol interfaces.Protocol, abis map[interfaces.ContractType]string) error
+}
+
+// registry implements interfaces.ProtocolAdapterRegistry
+type registry struct {
+	abiManager ABIRegistrar
+	adapters   map[interfaces.Protocol]interfaces.ProtocolAdapter
+	order      []interfaces.Protocol
+	pools      map[common.Address]interfaces.Protocol
+	signatures map[common.Hash][]interfaces.Protocol // Event signatures that identify an adapter on their own
+	mu         sync.RWMutex
+}
+
+// NewRegistry creates an empty adapter registry. When abiManager is set, adapter
+// ABIs are registered with it so the rest of the engine can look them up.
+func NewRegistry(abiManager ABIRegistrar) interfaces.ProtocolAdapterRegistry {
+	return &registry{
+		abiManager: abiManager,
+		adapters:   make(map[interfaces.Protocol]interfaces.ProtocolAdapter),
+		pools:      make(map[common.Address]interfaces.Protocol),
+		signatures: make(map[common.Hash][]interfaces.Protocol),
+	}
+}
+
+// NewDefaultRegistry creates a registry with every Base adapter registered
+func NewDefaultRegistry(abiManager ABIRegistrar) (interfaces.ProtocolAdapterRegistry, error) {
+	r := NewRegistry(abiManager)
+	for _, adapter := range DefaultAdapters() {
+		if err := r.Register(adapter); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// DefaultAdapters returns the adapters for Base mainnet deployments
+func DefaultAdapters() []interfaces.ProtocolAdapter {
+	return []interfaces.ProtocolAdapter{
+		NewUniswapV4Adapter(BaseUniswapV4PoolManager),
+		NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter),
+		NewSushiSwapV2Adapter(BaseSushiSwapV2Router),
+		NewSushiSwapV3Adapter(BaseSushiSwapV3Router),
+		NewBaseSwapAdapter(BaseBaseSwapRouter),
+		NewCurveAdapter(),
+	}
+}
+
+// Register adds an adapter and indexes the event signatures that identify it
+func (r *registry) Register(adapter interfaces.ProtocolAdapter) error {
+	protocol := adapter.Protocol()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.adapters[protocol]; exists {
+		return fmt.Errorf("adapter for %s already registered", protocol.String())
+	}
+
+	abis := adapter.ABIs()
+	if r.abiManager != nil {
+		if err := r.abiManager.RegisterProtocolABIs(protocol, abis); err != nil {
+			return fmt.Errorf("failed to register %s ABIs: %w", protocol.String(), err)
+		}
+	}
+
+	for contractType, abiJSON := range abis {
+		parsedABI, err := abi.JSON(strings.NewReader(abiJSON))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s %s ABI: %w", protocol.String(), contractType.String(), err)
+		}
+
+		for _, event := range parsedABI.Events {
+			if r.sharedWithFork(adapter, event.Sig) {
+				continue
+			}
+			r.signatures[event.ID] = append(r.signatures[event.ID], protocol)
+		}
+	}
+
+	r.adapters[protocol] = adapter
+	r.order = append(r.order, protocol)
+	return nil
+}
+
+// Adapter returns the adapter registered for a protocol
+func (r *registry) Adapter(protocol interfaces.Protocol) (interfaces.ProtocolAdapter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	adapter, exists := r.adapters[protocol]
+	return adapter, exists
+}
+
+// Adapters returns all adapters in registration order
+func (r *registry) Adapters() []interfaces.ProtocolAdapter {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	adapters := make([]interfaces.ProtocolAdapter, 0, len(r.order))
+	for _, protocol := range r.order {
+		adapters = append(adapters, r.adapters[protocol])
+	}
+	return adapters
+}
+
+// RegisterPool attributes every log emitted by a pool to the given protocol
+func (r *registry) RegisterPool(pool common.Address, protocol interfaces.Protocol) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pools[pool] = protocol
+}
+
+// DecodeLog decodes a log with the adapter of its pool, or with the only
+// adapter whose events carry the log's signature
+func (r *registry) DecodeLog(log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("log has no topics")
+	}
+
+	r.mu.RLock()
+	var adapter interfaces.ProtocolAdapter
+	if protocol, exists := r.pools[log.Address]; exists {
+		adapter = r.adapters[protocol]
+	} else if protocols := r.signatures[log.Topics[0]]; len(protocols) == 1 {
+		adapter = r.adapters[protocols[0]]
+	}
+	r.mu.RUnlock()
+
+	if adapter == nil {
+		return nil, fmt.Errorf("no adapter for log from %s with signature %s", log.Address.Hex(), log.Topics[0].Hex())
+	}
+
+	return adapter.DecodeLog(log)
+}
+
+// sharedWithFork reports whether an event is indistinguishable from one of the
+// protocol the adapter forks. Without an ABI manager to check against, every
+// event of a fork is treated as shared.
+func (r *registry) sharedWithFork(adapter interfaces.ProtocolAdapter, eventSig string) bool {
+	if adapter.ForkOf() == interfaces.ProtocolUnknown {
+		return false
+	}
+	if r.abiManager == nil {
+		return true
+	}
+	return r.abiManager.IsEventSupported(adapter.ForkOf(), eventSig)
+}
@@ -0,0 +1,124 @@
+package protocols
+
+import (
+	"context"
+	"testing"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDefaultRegistry(t *testing.T) {
+	abiManager := events.NewABIManager()
+
+	r, err := NewDefaultRegistry(abiManager)
+	require.NoError(t, err)
+
+	adapters := r.Adapters()
+	require.Len(t, adapters, 6)
+	assert.Equal(t, interfaces.ProtocolUniswapV4, adapters[0].Protocol())
+
+	adapter, exists := r.Adapter(interfaces.ProtocolCurve)
+	require.True(t, exists)
+	assert.Equal(t, interfaces.ProtocolCurve, adapter.Protocol())
+
+	_, exists = r.Adapter(interfaces.ProtocolUniswapV2)
+	assert.False(t, exists)
+
+	// Adapter ABIs are published to the ABI manager
+	abiJSON, err := abiManager.GetABI(interfaces.ProtocolUniswapV4, interfaces.ContractTypePoolManager)
+	require.NoError(t, err)
+	assert.Contains(t, string(abiJSON), "ModifyLiquidity")
+
+	_, err = abiManager.GetEventSignature(interfaces.ProtocolCurve, "TokenExchange")
+	assert.NoError(t, err)
+
+	err = r.Register(NewCurveAdapter())
+	assert.Error(t, err)
+}
+
+func TestRegistry_DecodeLog(t *testing.T) {
+	v2Logs := loadLogs(t, "v2_fork_logs.json")
+	pancakeLogs := loadLogs(t, "pancakeswap_v3_logs.json")
+	v4Logs := loadLogs(t, "uniswap_v4_logs.json")
+	curveLogs := loadLogs(t, "curve_logs.json")
+
+	t.Run("unique signatures", func(t *testing.T) {
+		r, err := NewDefaultRegistry(events.NewABIManager())
+		require.NoError(t, err)
+
+		for _, tt := range []struct {
+			log      *ethtypes.Log
+			protocol interfaces.Protocol
+		}{
+			{v4Logs[2], interfaces.ProtocolUniswapV4},
+			{curveLogs[0], interfaces.ProtocolCurve},
+			{pancakeLogs[0], interfaces.ProtocolPancakeSwapV3}, // Swap carries extra protocol fee fields
+		} {
+			parsedEvent, err := r.DecodeLog(tt.log)
+			require.NoError(t, err)
+			assert.Equal(t, tt.protocol, parsedEvent.Protocol)
+		}
+	})
+
+	t.Run("fork signatures need a known pool", func(t *testing.T) {
+		r, err := NewDefaultRegistry(events.NewABIManager())
+		require.NoError(t, err)
+
+		_, err = r.DecodeLog(v2Logs[1])
+		assert.Error(t, err)
+
+		// PancakeSwap's Burn is identical to Uniswap V3's
+		_, err = r.DecodeLog(pancakeLogs[1])
+		assert.Error(t, err)
+
+		r.RegisterPool(v2Logs[1].Address, interfaces.ProtocolBaseSwap)
+		parsedEvent, err := r.DecodeLog(v2Logs[1])
+		require.NoError(t, err)
+		assert.Equal(t, interfaces.ProtocolBaseSwap, parsedEvent.Protocol)
+	})
+
+	t.Run("without ABI manager", func(t *testing.T) {
+		r := NewRegistry(nil)
+		require.NoError(t, r.Register(NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter)))
+
+		// Fork signatures can't be checked against the original, so none are claimed
+		_, err := r.DecodeLog(pancakeLogs[0])
+		assert.Error(t, err)
+	})
+
+	t.Run("no topics", func(t *testing.T) {
+		r := NewRegistry(nil)
+		_, err := r.DecodeLog(&ethtypes.Log{})
+		assert.Error(t, err)
+	})
+}
+
+func TestEventParserWithAdapters(t *testing.T) {
+	abiManager := events.NewABIManager()
+	r, err := NewDefaultRegistry(abiManager)
+	require.NoError(t, err)
+
+	parser := events.NewEventParserWithAdapters(abiManager, r)
+	assert.Contains(t, parser.GetSupportedProtocols(), interfaces.ProtocolCurve)
+
+	v2Logs := loadLogs(t, "v2_fork_logs.json")
+	logs := append(loadLogs(t, "uniswap_v4_logs.json"), v2Logs[1])
+
+	parsedEvents, err := parser.ParseEventLogs(context.Background(), logs)
+	require.NoError(t, err)
+	require.Len(t, parsedEvents, 5)
+	assert.Equal(t, interfaces.ProtocolUniswapV4, parsedEvents[0].Protocol)
+
+	// An unregistered fork pool falls back to the built-in Uniswap V2 decoder
+	assert.Equal(t, interfaces.ProtocolUniswapV2, parsedEvents[4].Protocol)
+
+	r.RegisterPool(v2Logs[1].Address, interfaces.ProtocolSushiSwapV2)
+	parsedEvents, err = parser.ParseEventLogs(context.Background(), v2Logs[1:2])
+	require.NoError(t, err)
+	require.Len(t, parsedEvents, 1)
+	assert.Equal(t, interfaces.ProtocolSushiSwapV2, parsedEvents[0].Protocol)
+}
@@ -0,0 +1,151 @@
+package protocols
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+const (
+	// MinTick and MaxTick bound the ticks of Uniswap V3 style pools
+	MinTick int32 = -887272
+	MaxTick int32 = 887272
+)
+
+var (
+	// tickRatios are TickMath's 1/sqrt(1.0001)^(2^i) multipliers in Q128.128
+	tickRatios = []*big.Int{
+		mustHexBig("fffcb933bd6fad37aa2d162d1a594001"),
+		mustHexBig("fff97272373d413259a46990580e213a"),
+		mustHexBig("fff2e50f5f656932ef12357cf3c7fdcc"),
+		mustHexBig("ffe5caca7e10e4e61c3624eaa0941cd0"),
+		mustHexBig("ffcb9843d60f6159c9db58835c926644"),
+		mustHexBig("ff973b41fa98c081472e6896dfb254c0"),
+		mustHexBig("ff2ea16466c96a3843ec78b326b52861"),
+		mustHexBig("fe5dee046a99a2a811c461f1969c3053"),
+		mustHexBig("fcbe86c7900a88aedcffc83b479aa3a4"),
+		mustHexBig("f987a7253ac413176f2b074cf7815e54"),
+		mustHexBig("f3392b0822b70005940c7a398e4b70f3"),
+		mustHexBig("e7159475a2c29b7443b29c7fa6e889d9"),
+		mustHexBig("d097f3bdfd2022b8845ad8f792aa5825"),
+		mustHexBig("a9f746462d870fdf8a65dc1f90e061e5"),
+		mustHexBig("70d869a156d2a1b890bb3df62baf32f7"),
+		mustHexBig("31be135f97d08fd981231505542fcfa6"),
+		mustHexBig("9aa508b5b7a84e1c677de54f3e99bc9"),
+		mustHexBig("5d6af8dedb81196699c329225ee604"),
+		mustHexBig("2216e584f5fa1ea926041bedfe98"),
+		mustHexBig("48a170391f7dc42444e8fa2"),
+	}
+	q128       = new(big.Int).Lsh(big.NewInt(1), 128)
+	maxUint256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+func mustHexBig(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex constant " + s)
+	}
+	return n
+}
+
+// SqrtPriceAtTick returns sqrt(1.0001^tick) as a Q64.96, rounded up like
+// TickMath.getSqrtRatioAtTick
+func SqrtPriceAtTick(tick int32) (*big.Int, error) {
+	if tick < MinTick || tick > MaxTick {
+		return nil, fmt.Errorf("tick %d is out of range", tick)
+	}
+
+	absTick := tick
+	if absTick < 0 {
+		absTick = -absTick
+	}
+
+	ratio := new(big.Int).Set(q128)
+	if absTick&1 != 0 {
+		ratio.Set(tickRatios[0])
+	}
+	for i := 1; i < len(tickRatios); i++ {
+		if absTick&(1<<uint(i)) != 0 {
+			ratio.Mul(ratio, tickRatios[i])
+			ratio.Rsh(ratio, 128)
+		}
+	}
+	if tick > 0 {
+		ratio.Div(maxUint256, ratio)
+	}
+
+	// Q128.128 to Q64.96, rounding up
+	sqrtPrice := new(big.Int).Rsh(ratio, 32)
+	if new(big.Int).And(ratio, big.NewInt(0xffffffff)).Sign() != 0 {
+		sqrtPrice.Add(sqrtPrice, big.NewInt(1))
+	}
+	return sqrtPrice, nil
+}
+
+// TickAtSqrtPrice returns the greatest tick whose sqrt price is at most the
+// given one, like TickMath.getTickAtSqrtRatio
+func TickAtSqrtPrice(sqrtPriceX96 *big.Int) (int32, error) {
+	minSqrtPrice, _ := SqrtPriceAtTick(MinTick)
+	maxSqrtPrice, _ := SqrtPriceAtTick(MaxTick)
+	if sqrtPriceX96 == nil || sqrtPriceX96.Cmp(minSqrtPrice) < 0 || sqrtPriceX96.Cmp(maxSqrtPrice) >= 0 {
+		return 0, fmt.Errorf("sqrt price %v is out of range", sqrtPriceX96)
+	}
+
+	// Estimate from the float price, then settle on the exact tick
+	sqrtPrice, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPriceX96), new(big.Float).SetInt(q96)).Float64()
+	tick := int32(math.Floor(2 * math.Log(sqrtPrice) / math.Log(1.0001)))
+	if tick < MinTick {
+		tick = MinTick
+	}
+	if tick > MaxTick-1 {
+		tick = MaxTick - 1
+	}
+
+	for {
+		atTick, _ := SqrtPriceAtTick(tick)
+		if atTick.Cmp(sqrtPriceX96) > 0 {
+			tick--
+			continue
+		}
+		next, _ := SqrtPriceAtTick(tick + 1)
+		if next.Cmp(sqrtPriceX96) <= 0 {
+			tick++
+			continue
+		}
+		return tick, nil
+	}
+}
+
+// AmountsForLiquidity returns the token amounts held by a position of the given
+// liquidity between sqrtPriceA and sqrtPriceB at the current sqrtPriceX96,
+// rounded down
+func AmountsForLiquidity(sqrtPriceX96, sqrtPriceA, sqrtPriceB, liquidity *big.Int) (*big.Int, *big.Int) {
+	if sqrtPriceA.Cmp(sqrtPriceB) > 0 {
+		sqrtPriceA, sqrtPriceB = sqrtPriceB, sqrtPriceA
+	}
+
+	switch {
+	case sqrtPriceX96.Cmp(sqrtPriceA) <= 0:
+		return amount0Delta(sqrtPriceA, sqrtPriceB, liquidity), big.NewInt(0)
+	case sqrtPriceX96.Cmp(sqrtPriceB) < 0:
+		return amount0Delta(sqrtPriceX96, sqrtPriceB, liquidity), amount1Delta(sqrtPriceA, sqrtPriceX96, liquidity)
+	default:
+		return big.NewInt(0), amount1Delta(sqrtPriceA, sqrtPriceB, liquidity)
+	}
+}
+
+// amount0Delta is L * Q96 * (sqrtB - sqrtA) / (sqrtB * sqrtA)
+func amount0Delta(sqrtPriceA, sqrtPriceB, liquidity *big.Int) *big.Int {
+	amount := new(big.Int).Sub(sqrtPriceB, sqrtPriceA)
+	amount.Mul(amount, liquidity)
+	amount.Mul(amount, q96)
+	amount.Div(amount, sqrtPriceB)
+	return amount.Div(amount, sqrtPriceA)
+}
+
+// amount1Delta is L * (sqrtB - sqrtA) / Q96
+func amount1Delta(sqrtPriceA, sqrtPriceB, liquidity *big.Int) *big.Int {
+	amount := new(big.Int).Sub(sqrtPriceB, sqrtPriceA)
+	amount.Mul(amount, liquidity)
+	return amount.Div(amount, q96)
+}
@@ -0,0 +1,80 @@
+package protocols
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSqrtPriceAtTick(t *testing.T) {
+	tests := []struct {
+		tick     int32
+		expected string
+	}{
+		{MinTick, "4295128739"},
+		{0, "79228162514264337593543950336"},
+		{MaxTick, "1461446703485210103287273052203988822378723970342"},
+	}
+
+	for _, tt := range tests {
+		sqrtPrice, err := SqrtPriceAtTick(tt.tick)
+		require.NoError(t, err)
+		assert.Equal(t, mustBigInt(t, tt.expected), sqrtPrice, "tick %d", tt.tick)
+	}
+
+	// Intermediate ticks agree with sqrt(1.0001^tick)
+	for _, tick := range []int32{-200000, -60, -1, 1, 60, 201000} {
+		sqrtPrice, err := SqrtPriceAtTick(tick)
+		require.NoError(t, err)
+		actual, _ := new(big.Float).Quo(new(big.Float).SetInt(sqrtPrice), new(big.Float).SetInt(q96)).Float64()
+		expected := math.Pow(1.0001, float64(tick)/2)
+		assert.InEpsilon(t, expected, actual, 1e-10, "tick %d", tick)
+	}
+
+	_, err := SqrtPriceAtTick(MaxTick + 1)
+	assert.Error(t, err)
+}
+
+func TestTickAtSqrtPrice(t *testing.T) {
+	for _, tick := range []int32{MinTick, -200000, -1, 0, 1, 199999, MaxTick - 1} {
+		sqrtPrice, err := SqrtPriceAtTick(tick)
+		require.NoError(t, err)
+
+		actual, err := TickAtSqrtPrice(sqrtPrice)
+		require.NoError(t, err)
+		assert.Equal(t, tick, actual)
+
+		// Just below a tick's price belongs to the tick before it
+		if tick > MinTick {
+			actual, err = TickAtSqrtPrice(new(big.Int).Sub(sqrtPrice, big.NewInt(1)))
+			require.NoError(t, err)
+			assert.Equal(t, tick-1, actual)
+		}
+	}
+
+	_, err := TickAtSqrtPrice(big.NewInt(1))
+	assert.Error(t, err)
+}
+
+func TestAmountsForLiquidity(t *testing.T) {
+	liquidity := mustBigInt(t, "1000000000000000000")
+	lower, _ := SqrtPriceAtTick(-60)
+	upper, _ := SqrtPriceAtTick(60)
+
+	// In range the position holds both tokens, symmetric around a price of 1
+	amount0, amount1 := AmountsForLiquidity(q96, lower, upper, liquidity)
+	assert.Equal(t, mustBigInt(t, "2995354955910780"), amount0)
+	assert.InDelta(t, 0, new(big.Int).Sub(amount0, amount1).Int64(), 1)
+
+	// Below the range it is all token0, above it all token1
+	amount0, amount1 = AmountsForLiquidity(lower, lower, upper, liquidity)
+	assert.True(t, amount0.Sign() > 0)
+	assert.Zero(t, amount1.Sign())
+
+	amount0, amount1 = AmountsForLiquidity(upper, lower, upper, liquidity)
+	assert.Zero(t, amount0.Sign())
+	assert.True(t, amount1.Sign() > 0)
+}
@@ -0,0 +1,157 @@
+package protocols
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Base mainnet V2-style router deployments
+var (
+	BaseSushiSwapV2Router = common.HexToAddress("0x6BDED42c6DA8FBf0d2bA55B2fa120C5e0c8D7891")
+	BaseBaseSwapRouter    = common.HexToAddress("0x327Df1E6de05895d2ab08513aaDD9313Fe505d86")
+)
+
+// Swap fees of V2-style forks in hundredths of a bip
+const (
+	sushiSwapV2Fee uint32 = 3000 // 0.30%
+	baseSwapFee    uint32 = 2500 // 0.25%
+)
+
+var (
+	v2ForkPair   = events.MustParseABI(v2ForkPairABI)
+	v2ForkRouter = events.MustParseABI(v2ForkRouterABI)
+)
+
+// v2ForkAdapter serves constant-product venues that reuse the Uniswap V2 pair contract
+type v2ForkAdapter struct {
+	baseAdapter
+	router     common.Address
+	defaultFee uint32
+}
+
+// NewSushiSwapV2Adapter creates an adapter for SushiSwap V2 pairs swapped through the given router
+func NewSushiSwapV2Adapter(router common.Address) interfaces.ProtocolAdapter {
+	return newV2ForkAdapter(interfaces.ProtocolSushiSwapV2, router, sushiSwapV2Fee)
+}
+
+// NewBaseSwapAdapter creates an adapter for BaseSwap pairs swapped through the given router
+func NewBaseSwapAdapter(router common.Address) interfaces.ProtocolAdapter {
+	return newV2ForkAdapter(interfaces.ProtocolBaseSwap, router, baseSwapFee)
+}
+
+func newV2ForkAdapter(protocol interfaces.Protocol, router common.Address, defaultFee uint32) *v2ForkAdapter {
+	return &v2ForkAdapter{
+		baseAdapter: baseAdapter{
+			protocol: protocol,
+			forkOf:   interfaces.ProtocolUniswapV2,
+			abis: map[interfaces.ContractType]string{
+				interfaces.ContractTypePair:   v2ForkPairABI,
+				interfaces.ContractTypeRouter: v2ForkRouterABI,
+			},
+			events: v2ForkPair,
+		},
+		router:     router,
+		defaultFee: defaultFee,
+	}
+}
+
+// DecodeLog decodes Swap, Mint, Burn and Sync events from a pair
+func (a *v2ForkAdapter) DecodeLog(log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	event, decoded, err := a.unpackLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Name != "Swap" {
+		parsedEvent := a.newParsedEvent(log, interfaces.EventTypeUnknown)
+		if err := events.BuildPoolEvent(parsedEvent, event.Name, decoded); err != nil {
+			return nil, err
+		}
+		return parsedEvent, nil
+	}
+
+	swapEvent := &interfaces.SwapEvent{
+		Protocol:  a.protocol,
+		Pool:      log.Address,
+		Sender:    events.DecodedAddress(decoded, "sender"),
+		Recipient: events.DecodedAddress(decoded, "to"),
+		Fee:       big.NewInt(int64(a.defaultFee)),
+	}
+
+	amount0In := events.DecodedBigInt(decoded, "amount0In")
+	amount1In := events.DecodedBigInt(decoded, "amount1In")
+	amount0Out := events.DecodedBigInt(decoded, "amount0Out")
+	amount1Out := events.DecodedBigInt(decoded, "amount1Out")
+	if amount0In == nil || amount1In == nil || amount0Out == nil || amount1Out == nil {
+		return nil, fmt.Errorf("missing amount data in %s swap event", a.protocol.String())
+	}
+
+	if amount0In.Sign() > 0 {
+		swapEvent.AmountIn = amount0In
+		swapEvent.AmountOut = amount1Out
+	} else {
+		swapEvent.AmountIn = amount1In
+		swapEvent.AmountOut = amount0Out
+	}
+
+	if swapEvent.AmountIn.Sign() <= 0 || swapEvent.AmountOut.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid swap amounts: in=%v, out=%v", swapEvent.AmountIn, swapEvent.AmountOut)
+	}
+
+	parsedEvent := a.newParsedEvent(log, interfaces.EventTypeSwap)
+	parsedEvent.SwapEvent = swapEvent
+	return parsedEvent, nil
+}
+
+// GetAmountOut quotes an exact-input swap against the pair's reserves
+func (a *v2ForkAdapter) GetAmountOut(pool *interfaces.PoolState, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	in, out, err := swapDirection(pool, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	if len(pool.Reserves) != 2 {
+		return nil, fmt.Errorf("expected 2 reserves, got %d", len(pool.Reserves))
+	}
+
+	return constantProductAmountOut(amountIn, pool.Reserves[in], pool.Reserves[out], a.fee(pool))
+}
+
+// EncodeSwap encodes swapExactTokensForTokens on the venue's router
+func (a *v2ForkAdapter) EncodeSwap(params *interfaces.SwapParams) (*interfaces.SwapCalldata, error) {
+	if params == nil || params.AmountIn == nil || params.AmountOutMin == nil {
+		return nil, fmt.Errorf("amount in and minimum amount out are required")
+	}
+	if params.Deadline == nil {
+		return nil, fmt.Errorf("%s router requires a deadline", a.protocol.String())
+	}
+
+	data, err := v2ForkRouter.Pack("swapExactTokensForTokens",
+		params.AmountIn,
+		params.AmountOutMin,
+		[]common.Address{params.TokenIn, params.TokenOut},
+		params.Recipient,
+		params.Deadline,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s swap: %w", a.protocol.String(), err)
+	}
+
+	return &interfaces.SwapCalldata{
+		To:    a.router,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
+
+// fee returns the pool's fee, falling back to the venue default
+func (a *v2ForkAdapter) fee(pool *interfaces.PoolState) uint32 {
+	if pool.Fee != 0 {
+		return pool.Fee
+	}
+	return a.defaultFee
+}
@@ -0,0 +1,111 @@
+package protocols
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV2ForkAdapter_DecodeLog(t *testing.T) {
+	logs := loadLogs(t, "v2_fork_logs.json")
+	require.Len(t, logs, 4)
+
+	for _, adapter := range []interfaces.ProtocolAdapter{
+		NewSushiSwapV2Adapter(BaseSushiSwapV2Router),
+		NewBaseSwapAdapter(BaseBaseSwapRouter),
+	} {
+		t.Run(adapter.Protocol().String(), func(t *testing.T) {
+			assert.Equal(t, interfaces.ProtocolUniswapV2, adapter.ForkOf())
+
+			syncEvent, err := adapter.DecodeLog(logs[0])
+			require.NoError(t, err)
+			assert.Equal(t, adapter.Protocol(), syncEvent.Protocol)
+			assert.Equal(t, interfaces.EventTypeSync, syncEvent.EventType)
+			require.NotNil(t, syncEvent.SyncEvent)
+			assert.Equal(t, mustBigInt(t, "1843129847561320446721"), syncEvent.SyncEvent.Reserve0)
+			assert.Equal(t, mustBigInt(t, "4601290183374"), syncEvent.SyncEvent.Reserve1)
+
+			swapEvent, err := adapter.DecodeLog(logs[1])
+			require.NoError(t, err)
+			assert.Equal(t, interfaces.EventTypeSwap, swapEvent.EventType)
+			assert.Equal(t, uint64(21504310), swapEvent.BlockNumber)
+			require.NotNil(t, swapEvent.SwapEvent)
+			assert.Equal(t, adapter.Protocol(), swapEvent.SwapEvent.Protocol)
+			assert.Equal(t, BaseSushiSwapV2Router, swapEvent.SwapEvent.Sender)
+			assert.Equal(t, testTrader, swapEvent.SwapEvent.Recipient)
+			assert.Equal(t, mustBigInt(t, "500000000000000000"), swapEvent.SwapEvent.AmountIn)
+			assert.Equal(t, mustBigInt(t, "1244817262"), swapEvent.SwapEvent.AmountOut)
+
+			mintEvent, err := adapter.DecodeLog(logs[2])
+			require.NoError(t, err)
+			assert.Equal(t, interfaces.EventTypeMint, mintEvent.EventType)
+			require.NotNil(t, mintEvent.LiquidityEvent)
+			assert.Equal(t, mustBigInt(t, "4993018227"), mintEvent.LiquidityEvent.Amount1)
+
+			burnEvent, err := adapter.DecodeLog(logs[3])
+			require.NoError(t, err)
+			assert.Equal(t, interfaces.EventTypeBurn, burnEvent.EventType)
+			require.NotNil(t, burnEvent.LiquidityEvent)
+			assert.Equal(t, testTrader, burnEvent.LiquidityEvent.Recipient)
+		})
+	}
+}
+
+func TestV2ForkAdapter_GetAmountOut(t *testing.T) {
+	pool := &interfaces.PoolState{
+		Tokens:   []common.Address{testWETH, testUSDC},
+		Reserves: []*big.Int{mustBigInt(t, "100000000000000000000"), mustBigInt(t, "200000000000000000000")},
+	}
+
+	sushi := NewSushiSwapV2Adapter(BaseSushiSwapV2Router)
+	sushiOut, err := sushi.GetAmountOut(pool, testWETH, testUSDC, big.NewInt(1e18))
+	require.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "1974316068794122597"), sushiOut)
+
+	// BaseSwap's lower default fee yields more output
+	baseSwap := NewBaseSwapAdapter(BaseBaseSwapRouter)
+	baseSwapOut, err := baseSwap.GetAmountOut(pool, testWETH, testUSDC, big.NewInt(1e18))
+	require.NoError(t, err)
+	assert.Equal(t, 1, baseSwapOut.Cmp(sushiOut))
+
+	// An explicit pool fee overrides the default
+	pool.Fee = 3000
+	baseSwapOut, err = baseSwap.GetAmountOut(pool, testWETH, testUSDC, big.NewInt(1e18))
+	require.NoError(t, err)
+	assert.Equal(t, sushiOut, baseSwapOut)
+
+	_, err = sushi.GetAmountOut(&interfaces.PoolState{Tokens: pool.Tokens}, testWETH, testUSDC, big.NewInt(1e18))
+	assert.Error(t, err)
+}
+
+func TestV2ForkAdapter_EncodeSwap(t *testing.T) {
+	adapter := NewSushiSwapV2Adapter(BaseSushiSwapV2Router)
+	params := &interfaces.SwapParams{
+		TokenIn:      testWETH,
+		TokenOut:     testUSDC,
+		AmountIn:     big.NewInt(1e18),
+		AmountOutMin: big.NewInt(2400e6),
+		Recipient:    testTrader,
+		Deadline:     big.NewInt(1760000000),
+	}
+
+	call, err := adapter.EncodeSwap(params)
+	require.NoError(t, err)
+	assert.Equal(t, BaseSushiSwapV2Router, call.To)
+	assert.Equal(t, common.FromHex("0x38ed1739"), call.Data[:4])
+
+	args, err := v2ForkRouter.Methods["swapExactTokensForTokens"].Inputs.Unpack(call.Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, params.AmountIn, args[0])
+	assert.Equal(t, params.AmountOutMin, args[1])
+	assert.Equal(t, []common.Address{testWETH, testUSDC}, args[2])
+	assert.Equal(t, testTrader, args[3])
+
+	params.Deadline = nil
+	_, err = adapter.EncodeSwap(params)
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package protocols
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// Base mainnet V3-style router deployments
+var (
+	BaseSushiSwapV3Router      = common.HexToAddress("0xFB7eF66a7e61224DD6FcD0D7d9C3be5C8B049b9f")
+	BasePancakeSwapSmartRouter = common.HexToAddress("0x678Aa4bF4E210cf2166753e054d5b7c31cc7fa86")
+)
+
+var (
+	sushiSwapV3Pool     = events.MustParseABI(sushiSwapV3PoolABI)
+	sushiSwapV3Router   = events.MustParseABI(sushiSwapV3RouterABI)
+	pancakeSwapV3Pool   = events.MustParseABI(pancakeSwapV3PoolABI)
+	pancakeSwapV3Router = events.MustParseABI(pancakeSwapV3RouterABI)
+)
+
+// exactInputSingleParams matches SwapRouter's ExactInputSingleParams
+type exactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	Deadline          *big.Int
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// smartRouterExactInputSingleParams matches SwapRouter02-style params, which drop the deadline
+type smartRouterExactInputSingleParams struct {
+	TokenIn           common.Address
+	TokenOut          common.Address
+	Fee               *big.Int
+	Recipient         common.Address
+	AmountIn          *big.Int
+	AmountOutMinimum  *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// v3ForkAdapter serves concentrated liquidity venues derived from Uniswap V3
+type v3ForkAdapter struct {
+	baseAdapter
+	router            common.Address
+	routerABI         *abi.ABI
+	routerHasDeadline bool
+}
+
+// NewSushiSwapV3Adapter creates an adapter for SushiSwap V3 pools swapped through the given SwapRouter
+func NewSushiSwapV3Adapter(router common.Address) interfaces.ProtocolAdapter {
+	return &v3ForkAdapter{
+		baseAdapter: baseAdapter{
+			protocol: interfaces.ProtocolSushiSwapV3,
+			forkOf:   interfaces.ProtocolUniswapV3,
+			abis: map[interfaces.ContractType]string{
+				interfaces.ContractTypePool:   sushiSwapV3PoolABI,
+				interfaces.ContractTypeRouter: sushiSwapV3RouterABI,
+			},
+			events: sushiSwapV3Pool,
+		},
+		router:            router,
+		routerABI:         sushiSwapV3Router,
+		routerHasDeadline: true,
+	}
+}
+
+// NewPancakeSwapV3Adapter creates an adapter for PancakeSwap V3 pools swapped through the given SmartRouter
+func NewPancakeSwapV3Adapter(router common.Address) interfaces.ProtocolAdapter {
+	return &v3ForkAdapter{
+		baseAdapter: baseAdapter{
+			protocol: interfaces.ProtocolPancakeSwapV3,
+			forkOf:   interfaces.ProtocolUniswapV3,
+			abis: map[interfaces.ContractType]string{
+				interfaces.ContractTypePool:   pancakeSwapV3PoolABI,
+				interfaces.ContractTypeRouter: pancakeSwapV3RouterABI,
+			},
+			events: pancakeSwapV3Pool,
+		},
+		router:    router,
+		routerABI: pancakeSwapV3Router,
+	}
+}
+
+// DecodeLog decodes Swap, Mint, Burn, Collect and Flash events from a pool
+func (a *v3ForkAdapter) DecodeLog(log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	event, decoded, err := a.unpackLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.Name != "Swap" {
+		parsedEvent := a.newParsedEvent(log, interfaces.EventTypeUnknown)
+		if err := events.BuildPoolEvent(parsedEvent, event.Name, decoded); err != nil {
+			return nil, err
+		}
+		return parsedEvent, nil
+	}
+
+	amount0 := events.DecodedBigInt(decoded, "amount0")
+	amount1 := events.DecodedBigInt(decoded, "amount1")
+	if amount0 == nil || amount1 == nil {
+		return nil, fmt.Errorf("missing amount data in %s swap event", a.protocol.String())
+	}
+
+	swapEvent := &interfaces.SwapEvent{
+		Protocol:     a.protocol,
+		Pool:         log.Address,
+		Sender:       events.DecodedAddress(decoded, "sender"),
+		Recipient:    events.DecodedAddress(decoded, "recipient"),
+		SqrtPriceX96: events.DecodedBigInt(decoded, "sqrtPriceX96"),
+		Liquidity:    events.DecodedBigInt(decoded, "liquidity"),
+		Tick:         events.DecodedBigInt(decoded, "tick"),
+	}
+
+	// Positive amounts flow into the pool
+	if amount0.Sign() > 0 {
+		swapEvent.AmountIn = amount0
+		swapEvent.AmountOut = new(big.Int).Abs(amount1)
+	} else {
+		swapEvent.AmountIn = amount1
+		swapEvent.AmountOut = new(big.Int).Abs(amount0)
+	}
+
+	if swapEvent.AmountIn.Sign() <= 0 || swapEvent.AmountOut.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid swap amounts: in=%v, out=%v", swapEvent.AmountIn, swapEvent.AmountOut)
+	}
+
+	parsedEvent := a.newParsedEvent(log, interfaces.EventTypeSwap)
+	parsedEvent.SwapEvent = swapEvent
+	return parsedEvent, nil
+}
+
+// GetAmountOut quotes an exact-input swap within the pool's current tick range
+func (a *v3ForkAdapter) GetAmountOut(pool *interfaces.PoolState, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	in, _, err := swapDirection(pool, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+
+	return concentratedAmountOut(amountIn, pool.SqrtPriceX96, pool.Liquidity, pool.Fee, in == 0)
+}
+
+// EncodeSwap encodes exactInputSingle on the venue's router
+func (a *v3ForkAdapter) EncodeSwap(params *interfaces.SwapParams) (*interfaces.SwapCalldata, error) {
+	if params == nil || params.Pool == nil || params.AmountIn == nil || params.AmountOutMin == nil {
+		return nil, fmt.Errorf("pool, amount in and minimum amount out are required")
+	}
+	if _, _, err := swapDirection(params.Pool, params.TokenIn, params.TokenOut); err != nil {
+		return nil, err
+	}
+
+	fee := big.NewInt(int64(params.Pool.Fee))
+
+	var (
+		data []byte
+		err  error
+	)
+	if a.routerHasDeadline {
+		if params.Deadline == nil {
+			return nil, fmt.Errorf("%s router requires a deadline", a.protocol.String())
+		}
+		data, err = a.routerABI.Pack("exactInputSingle", exactInputSingleParams{
+			TokenIn:           params.TokenIn,
+			TokenOut:          params.TokenOut,
+			Fee:               fee,
+			Recipient:         params.Recipient,
+			Deadline:          params.Deadline,
+			AmountIn:          params.AmountIn,
+			AmountOutMinimum:  params.AmountOutMin,
+			SqrtPriceLimitX96: big.NewInt(0),
+		})
+	} else {
+		data, err = a.routerABI.Pack("exactInputSingle", smartRouterExactInputSingleParams{
+			TokenIn:           params.TokenIn,
+			TokenOut:          params.TokenOut,
+			Fee:               fee,
+			Recipient:         params.Recipient,
+			AmountIn:          params.AmountIn,
+			AmountOutMinimum:  params.AmountOutMin,
+			SqrtPriceLimitX96: big.NewInt(0),
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s swap: %w", a.protocol.String(), err)
+	}
+
+	return &interfaces.SwapCalldata{
+		To:    a.router,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
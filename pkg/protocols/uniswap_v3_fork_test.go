@@ -0,0 +1,135 @@
+package protocols
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSushiSwapV3Adapter_DecodeLog(t *testing.T) {
+	adapter := NewSushiSwapV3Adapter(BaseSushiSwapV3Router)
+	logs := loadLogs(t, "sushiswap_v3_logs.json")
+	require.Len(t, logs, 4)
+
+	swapEvent, err := adapter.DecodeLog(logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ProtocolSushiSwapV3, swapEvent.Protocol)
+	assert.Equal(t, interfaces.EventTypeSwap, swapEvent.EventType)
+	require.NotNil(t, swapEvent.SwapEvent)
+	assert.Equal(t, mustBigInt(t, "1000000000000000000"), swapEvent.SwapEvent.AmountIn)
+	assert.Equal(t, mustBigInt(t, "2489120551"), swapEvent.SwapEvent.AmountOut)
+	assert.Equal(t, big.NewInt(-197311), swapEvent.SwapEvent.Tick)
+	assert.Equal(t, mustBigInt(t, "18372619883746129"), swapEvent.SwapEvent.Liquidity)
+
+	mintEvent, err := adapter.DecodeLog(logs[1])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeMint, mintEvent.EventType)
+	require.NotNil(t, mintEvent.LiquidityEvent)
+	assert.Equal(t, testTrader, mintEvent.LiquidityEvent.Owner)
+	assert.Equal(t, big.NewInt(-198000), mintEvent.LiquidityEvent.TickLower)
+	assert.Equal(t, big.NewInt(-196200), mintEvent.LiquidityEvent.TickUpper)
+	assert.Equal(t, mustBigInt(t, "731240998177"), mintEvent.LiquidityEvent.Liquidity)
+
+	collectEvent, err := adapter.DecodeLog(logs[2])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeCollect, collectEvent.EventType)
+	require.NotNil(t, collectEvent.CollectEvent)
+	assert.Equal(t, big.NewInt(3004112), collectEvent.CollectEvent.Amount1)
+
+	flashEvent, err := adapter.DecodeLog(logs[3])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeFlash, flashEvent.EventType)
+	require.NotNil(t, flashEvent.FlashEvent)
+	assert.Equal(t, big.NewInt(12500001), flashEvent.FlashEvent.Paid1)
+}
+
+func TestPancakeSwapV3Adapter_DecodeLog(t *testing.T) {
+	adapter := NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter)
+	logs := loadLogs(t, "pancakeswap_v3_logs.json")
+	require.Len(t, logs, 2)
+
+	swapEvent, err := adapter.DecodeLog(logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.ProtocolPancakeSwapV3, swapEvent.Protocol)
+	require.NotNil(t, swapEvent.SwapEvent)
+	assert.Equal(t, mustBigInt(t, "250000000000000000"), swapEvent.SwapEvent.AmountIn)
+	assert.Equal(t, big.NewInt(622518731), swapEvent.SwapEvent.AmountOut)
+	assert.Equal(t, BasePancakeSwapSmartRouter, swapEvent.SwapEvent.Sender)
+
+	burnEvent, err := adapter.DecodeLog(logs[1])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeBurn, burnEvent.EventType)
+	require.NotNil(t, burnEvent.LiquidityEvent)
+	assert.Equal(t, mustBigInt(t, "4410233187"), burnEvent.LiquidityEvent.Liquidity)
+
+	// A Uniswap V3 layout Swap does not match PancakeSwap's extended event
+	sushiLogs := loadLogs(t, "sushiswap_v3_logs.json")
+	_, err = adapter.DecodeLog(sushiLogs[0])
+	assert.Error(t, err)
+}
+
+func TestV3ForkAdapter_GetAmountOut(t *testing.T) {
+	pool := &interfaces.PoolState{
+		Tokens:       []common.Address{testWETH, testUSDC},
+		Fee:          3000,
+		SqrtPriceX96: q96,
+		Liquidity:    mustBigInt(t, "1000000000000000000000000"),
+	}
+
+	for _, adapter := range []interfaces.ProtocolAdapter{
+		NewSushiSwapV3Adapter(BaseSushiSwapV3Router),
+		NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter),
+	} {
+		amountOut, err := adapter.GetAmountOut(pool, testUSDC, testWETH, big.NewInt(1e18))
+		require.NoError(t, err)
+		assert.Equal(t, mustBigInt(t, "996999005991991025"), amountOut)
+	}
+}
+
+func TestV3ForkAdapter_EncodeSwap(t *testing.T) {
+	params := &interfaces.SwapParams{
+		Pool:         &interfaces.PoolState{Tokens: []common.Address{testWETH, testUSDC}, Fee: 500},
+		TokenIn:      testWETH,
+		TokenOut:     testUSDC,
+		AmountIn:     big.NewInt(1e18),
+		AmountOutMin: big.NewInt(2400e6),
+		Recipient:    testTrader,
+		Deadline:     big.NewInt(1760000000),
+	}
+
+	t.Run("SushiSwapV3", func(t *testing.T) {
+		call, err := NewSushiSwapV3Adapter(BaseSushiSwapV3Router).EncodeSwap(params)
+		require.NoError(t, err)
+		assert.Equal(t, BaseSushiSwapV3Router, call.To)
+		assert.Equal(t, common.FromHex("0x414bf389"), call.Data[:4])
+
+		args, err := sushiSwapV3Router.Methods["exactInputSingle"].Inputs.Unpack(call.Data[4:])
+		require.NoError(t, err)
+		require.Len(t, args, 1)
+		assert.Contains(t, fmt.Sprintf("%v", args[0]), "1760000000")
+	})
+
+	t.Run("PancakeSwapV3", func(t *testing.T) {
+		call, err := NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter).EncodeSwap(params)
+		require.NoError(t, err)
+		assert.Equal(t, BasePancakeSwapSmartRouter, call.To)
+		assert.Equal(t, common.FromHex("0x04e45aaf"), call.Data[:4])
+	})
+
+	t.Run("missing deadline", func(t *testing.T) {
+		noDeadline := *params
+		noDeadline.Deadline = nil
+
+		_, err := NewSushiSwapV3Adapter(BaseSushiSwapV3Router).EncodeSwap(&noDeadline)
+		assert.Error(t, err)
+
+		// SmartRouter has no deadline parameter
+		_, err = NewPancakeSwapV3Adapter(BasePancakeSwapSmartRouter).EncodeSwap(&noDeadline)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,246 @@
+package protocols
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/events"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// BaseUniswapV4PoolManager is the Uniswap V4 singleton PoolManager on Base mainnet
+var BaseUniswapV4PoolManager = common.HexToAddress("0x498581fF718922c3f8e6A244956aF099B2652b2b")
+
+// Hook permission flags encoded in the low bits of a V4 hook address
+const (
+	HookBeforeSwap             uint16 = 1 << 7
+	HookAfterSwap              uint16 = 1 << 6
+	HookBeforeSwapReturnsDelta uint16 = 1 << 3
+	HookAfterSwapReturnsDelta  uint16 = 1 << 2
+	hookSwapDeltaFlags                = HookBeforeSwapReturnsDelta | HookAfterSwapReturnsDelta
+	hookPermissionMask         uint16 = 1<<14 - 1
+	uniswapV4DynamicFeeFlag    uint32 = 0x800000
+)
+
+// Price limits one step inside TickMath's bounds, used for unbounded swaps
+var (
+	uniswapV4MinSqrtPriceLimit    = big.NewInt(4295128740)
+	uniswapV4MaxSqrtPriceLimit, _ = new(big.Int).SetString("1461446703485210103287273052203988822378723970341", 10)
+)
+
+var (
+	// ErrHookAltersSwap is returned when a pool's hook can change swap amounts, so local math can't quote it
+	ErrHookAltersSwap = errors.New("pool hook can modify swap deltas")
+	// ErrDynamicFee is returned when a pool's LP fee is set by its hook at swap time
+	ErrDynamicFee = errors.New("pool uses a hook-controlled dynamic fee")
+)
+
+var uniswapV4PoolManager = events.MustParseABI(uniswapV4PoolManagerABI)
+
+// uniswapV4PoolKey matches PoolKey in the PoolManager
+type uniswapV4PoolKey struct {
+	Currency0   common.Address
+	Currency1   common.Address
+	Fee         *big.Int
+	TickSpacing *big.Int
+	Hooks       common.Address
+}
+
+// uniswapV4SwapParams matches IPoolManager.SwapParams
+type uniswapV4SwapParams struct {
+	ZeroForOne        bool
+	AmountSpecified   *big.Int
+	SqrtPriceLimitX96 *big.Int
+}
+
+// uniswapV4Adapter serves pools held by the Uniswap V4 singleton PoolManager
+type uniswapV4Adapter struct {
+	baseAdapter
+	poolManager common.Address
+}
+
+// NewUniswapV4Adapter creates an adapter for pools in the given Uniswap V4 PoolManager
+func NewUniswapV4Adapter(poolManager common.Address) interfaces.ProtocolAdapter {
+	return &uniswapV4Adapter{
+		baseAdapter: baseAdapter{
+			protocol: interfaces.ProtocolUniswapV4,
+			forkOf:   interfaces.ProtocolUnknown,
+			abis: map[interfaces.ContractType]string{
+				interfaces.ContractTypePoolManager: uniswapV4PoolManagerABI,
+			},
+			events: uniswapV4PoolManager,
+		},
+		poolManager: poolManager,
+	}
+}
+
+// HasHookPermission reports whether a V4 hook address carries a permission flag
+func HasHookPermission(hooks common.Address, flag uint16) bool {
+	permissions := uint16(hooks[common.AddressLength-2])<<8 | uint16(hooks[common.AddressLength-1])
+	return permissions&hookPermissionMask&flag != 0
+}
+
+// DecodeLog decodes Initialize, Swap and ModifyLiquidity events from the PoolManager
+func (a *uniswapV4Adapter) DecodeLog(log *ethtypes.Log) (*interfaces.ParsedEvent, error) {
+	event, decoded, err := a.unpackLog(log)
+	if err != nil {
+		return nil, err
+	}
+
+	poolID, _ := decoded["id"].([32]byte)
+
+	switch event.Name {
+	case "Initialize":
+		fee := events.DecodedBigInt(decoded, "fee")
+		tickSpacing := events.DecodedBigInt(decoded, "tickSpacing")
+		if fee == nil || tickSpacing == nil {
+			return nil, fmt.Errorf("missing pool key data in %s Initialize event", a.protocol.String())
+		}
+
+		parsedEvent := a.newParsedEvent(log, interfaces.EventTypeInitialize)
+		parsedEvent.InitializeEvent = &interfaces.InitializeEvent{
+			Protocol:     a.protocol,
+			PoolManager:  log.Address,
+			PoolID:       poolID,
+			Currency0:    events.DecodedAddress(decoded, "currency0"),
+			Currency1:    events.DecodedAddress(decoded, "currency1"),
+			Fee:          uint32(fee.Uint64()),
+			TickSpacing:  int32(tickSpacing.Int64()),
+			Hooks:        events.DecodedAddress(decoded, "hooks"),
+			SqrtPriceX96: events.DecodedBigInt(decoded, "sqrtPriceX96"),
+			Tick:         events.DecodedBigInt(decoded, "tick"),
+		}
+		return parsedEvent, nil
+
+	case "Swap":
+		amount0 := events.DecodedBigInt(decoded, "amount0")
+		amount1 := events.DecodedBigInt(decoded, "amount1")
+		if amount0 == nil || amount1 == nil {
+			return nil, fmt.Errorf("missing amount data in %s swap event", a.protocol.String())
+		}
+
+		swapEvent := &interfaces.SwapEvent{
+			Protocol:     a.protocol,
+			Pool:         log.Address,
+			PoolID:       poolID,
+			Sender:       events.DecodedAddress(decoded, "sender"),
+			Fee:          events.DecodedBigInt(decoded, "fee"),
+			SqrtPriceX96: events.DecodedBigInt(decoded, "sqrtPriceX96"),
+			Liquidity:    events.DecodedBigInt(decoded, "liquidity"),
+			Tick:         events.DecodedBigInt(decoded, "tick"),
+		}
+
+		// Amounts are deltas of the swapper's balance: negative is paid into the pool
+		if amount0.Sign() < 0 {
+			swapEvent.AmountIn = new(big.Int).Neg(amount0)
+			swapEvent.AmountOut = amount1
+		} else {
+			swapEvent.AmountIn = new(big.Int).Neg(amount1)
+			swapEvent.AmountOut = amount0
+		}
+
+		if swapEvent.AmountIn.Sign() <= 0 || swapEvent.AmountOut.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid swap amounts: in=%v, out=%v", swapEvent.AmountIn, swapEvent.AmountOut)
+		}
+
+		parsedEvent := a.newParsedEvent(log, interfaces.EventTypeSwap)
+		parsedEvent.SwapEvent = swapEvent
+		return parsedEvent, nil
+
+	case "ModifyLiquidity":
+		liquidityDelta := events.DecodedBigInt(decoded, "liquidityDelta")
+		if liquidityDelta == nil {
+			return nil, fmt.Errorf("missing liquidity data in %s ModifyLiquidity event", a.protocol.String())
+		}
+
+		eventType := interfaces.EventTypeMint
+		if liquidityDelta.Sign() < 0 {
+			eventType = interfaces.EventTypeBurn
+		}
+
+		parsedEvent := a.newParsedEvent(log, eventType)
+		parsedEvent.LiquidityEvent = &interfaces.LiquidityEvent{
+			Protocol:  a.protocol,
+			Pool:      log.Address,
+			PoolID:    poolID,
+			Sender:    events.DecodedAddress(decoded, "sender"),
+			TickLower: events.DecodedBigInt(decoded, "tickLower"),
+			TickUpper: events.DecodedBigInt(decoded, "tickUpper"),
+			Liquidity: new(big.Int).Abs(liquidityDelta),
+		}
+		return parsedEvent, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported %s event: %s", a.protocol.String(), event.Name)
+	}
+}
+
+// GetAmountOut quotes an exact-input swap within the pool's current tick range.
+// Pools whose hooks can rewrite swap deltas or set the fee are refused.
+func (a *uniswapV4Adapter) GetAmountOut(pool *interfaces.PoolState, tokenIn, tokenOut common.Address, amountIn *big.Int) (*big.Int, error) {
+	in, _, err := swapDirection(pool, tokenIn, tokenOut)
+	if err != nil {
+		return nil, err
+	}
+	if HasHookPermission(pool.Hooks, hookSwapDeltaFlags) {
+		return nil, ErrHookAltersSwap
+	}
+	if pool.Fee&uniswapV4DynamicFeeFlag != 0 {
+		return nil, ErrDynamicFee
+	}
+
+	return concentratedAmountOut(amountIn, pool.SqrtPriceX96, pool.Liquidity, pool.Fee, in == 0)
+}
+
+// EncodeSwap encodes PoolManager.swap for an exact-input swap. The call is only
+// valid from inside the executor contract's unlock callback, which must also
+// settle the resulting deltas.
+func (a *uniswapV4Adapter) EncodeSwap(params *interfaces.SwapParams) (*interfaces.SwapCalldata, error) {
+	if params == nil || params.Pool == nil || params.AmountIn == nil {
+		return nil, fmt.Errorf("pool and amount in are required")
+	}
+
+	in, _, err := swapDirection(params.Pool, params.TokenIn, params.TokenOut)
+	if err != nil {
+		return nil, err
+	}
+	zeroForOne := in == 0
+
+	sqrtPriceLimit := uniswapV4MaxSqrtPriceLimit
+	if zeroForOne {
+		sqrtPriceLimit = uniswapV4MinSqrtPriceLimit
+	}
+
+	hookData := params.HookData
+	if hookData == nil {
+		hookData = []byte{}
+	}
+
+	data, err := uniswapV4PoolManager.Pack("swap",
+		uniswapV4PoolKey{
+			Currency0:   params.Pool.Tokens[0],
+			Currency1:   params.Pool.Tokens[1],
+			Fee:         big.NewInt(int64(params.Pool.Fee)),
+			TickSpacing: big.NewInt(int64(params.Pool.TickSpacing)),
+			Hooks:       params.Pool.Hooks,
+		},
+		uniswapV4SwapParams{
+			ZeroForOne:        zeroForOne,
+			AmountSpecified:   new(big.Int).Neg(params.AmountIn), // Negative means exact input
+			SqrtPriceLimitX96: sqrtPriceLimit,
+		},
+		hookData,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s swap: %w", a.protocol.String(), err)
+	}
+
+	return &interfaces.SwapCalldata{
+		To:    a.poolManager,
+		Data:  data,
+		Value: big.NewInt(0),
+	}, nil
+}
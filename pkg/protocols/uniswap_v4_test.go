@@ -0,0 +1,126 @@
+package protocols
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHooks = common.HexToAddress("0x7Cb8e6b8E2e41bD3D4cF2FD8B7Fb7b1C3aA000cC")
+
+func TestUniswapV4Adapter_DecodeLog(t *testing.T) {
+	adapter := NewUniswapV4Adapter(BaseUniswapV4PoolManager)
+	logs := loadLogs(t, "uniswap_v4_logs.json")
+	require.Len(t, logs, 4)
+
+	initEvent, err := adapter.DecodeLog(logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeInitialize, initEvent.EventType)
+	require.NotNil(t, initEvent.InitializeEvent)
+	assert.Equal(t, common.Address{}, initEvent.InitializeEvent.Currency0) // Native ETH
+	assert.Equal(t, testUSDC, initEvent.InitializeEvent.Currency1)
+	assert.Equal(t, uint32(500), initEvent.InitializeEvent.Fee)
+	assert.Equal(t, int32(10), initEvent.InitializeEvent.TickSpacing)
+	assert.Equal(t, common.Address{}, initEvent.InitializeEvent.Hooks)
+	poolID := initEvent.InitializeEvent.PoolID
+	assert.Equal(t, logs[0].Topics[1], poolID)
+
+	hookedEvent, err := adapter.DecodeLog(logs[1])
+	require.NoError(t, err)
+	require.NotNil(t, hookedEvent.InitializeEvent)
+	assert.Equal(t, testHooks, hookedEvent.InitializeEvent.Hooks)
+	assert.Equal(t, uniswapV4DynamicFeeFlag, hookedEvent.InitializeEvent.Fee)
+
+	swapEvent, err := adapter.DecodeLog(logs[2])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeSwap, swapEvent.EventType)
+	require.NotNil(t, swapEvent.SwapEvent)
+	assert.Equal(t, poolID, swapEvent.SwapEvent.PoolID)
+	assert.Equal(t, BaseUniswapV4PoolManager, swapEvent.SwapEvent.Pool)
+	assert.Equal(t, mustBigInt(t, "1000000000000000000"), swapEvent.SwapEvent.AmountIn)
+	assert.Equal(t, mustBigInt(t, "2498331208"), swapEvent.SwapEvent.AmountOut)
+	assert.Equal(t, big.NewInt(500), swapEvent.SwapEvent.Fee)
+
+	liquidityEvent, err := adapter.DecodeLog(logs[3])
+	require.NoError(t, err)
+	assert.Equal(t, interfaces.EventTypeBurn, liquidityEvent.EventType)
+	require.NotNil(t, liquidityEvent.LiquidityEvent)
+	assert.Equal(t, poolID, liquidityEvent.LiquidityEvent.PoolID)
+	assert.Equal(t, mustBigInt(t, "8813772019"), liquidityEvent.LiquidityEvent.Liquidity)
+	assert.Equal(t, big.NewInt(-197400), liquidityEvent.LiquidityEvent.TickLower)
+}
+
+func TestHasHookPermission(t *testing.T) {
+	assert.True(t, HasHookPermission(testHooks, HookBeforeSwap))
+	assert.True(t, HasHookPermission(testHooks, HookAfterSwapReturnsDelta))
+	assert.False(t, HasHookPermission(common.Address{}, HookBeforeSwap))
+	assert.False(t, HasHookPermission(common.HexToAddress("0x0000000000000000000000000000000000000080"), HookAfterSwap))
+}
+
+func TestUniswapV4Adapter_GetAmountOut(t *testing.T) {
+	adapter := NewUniswapV4Adapter(BaseUniswapV4PoolManager)
+	pool := &interfaces.PoolState{
+		Tokens:       []common.Address{{}, testUSDC},
+		Fee:          3000,
+		SqrtPriceX96: q96,
+		Liquidity:    mustBigInt(t, "1000000000000000000000000"),
+	}
+
+	amountOut, err := adapter.GetAmountOut(pool, common.Address{}, testUSDC, big.NewInt(1e18))
+	require.NoError(t, err)
+	assert.Equal(t, mustBigInt(t, "996999005991991025"), amountOut)
+
+	// Hooks that only observe swaps don't block quoting
+	pool.Hooks = common.HexToAddress("0x00000000000000000000000000000000000000C0")
+	_, err = adapter.GetAmountOut(pool, common.Address{}, testUSDC, big.NewInt(1e18))
+	assert.NoError(t, err)
+
+	pool.Hooks = testHooks
+	_, err = adapter.GetAmountOut(pool, common.Address{}, testUSDC, big.NewInt(1e18))
+	assert.ErrorIs(t, err, ErrHookAltersSwap)
+
+	pool.Hooks = common.Address{}
+	pool.Fee = uniswapV4DynamicFeeFlag
+	_, err = adapter.GetAmountOut(pool, common.Address{}, testUSDC, big.NewInt(1e18))
+	assert.ErrorIs(t, err, ErrDynamicFee)
+}
+
+func TestUniswapV4Adapter_EncodeSwap(t *testing.T) {
+	adapter := NewUniswapV4Adapter(BaseUniswapV4PoolManager)
+	params := &interfaces.SwapParams{
+		Pool: &interfaces.PoolState{
+			Tokens:      []common.Address{{}, testUSDC},
+			Fee:         500,
+			TickSpacing: 10,
+			Hooks:       testHooks,
+		},
+		TokenIn:  testUSDC,
+		TokenOut: common.Address{},
+		AmountIn: big.NewInt(2500e6),
+		HookData: []byte{0x01},
+	}
+
+	call, err := adapter.EncodeSwap(params)
+	require.NoError(t, err)
+	assert.Equal(t, BaseUniswapV4PoolManager, call.To)
+	assert.Equal(t, common.FromHex("0xf3cd914c"), call.Data[:4])
+
+	args, err := uniswapV4PoolManager.Methods["swap"].Inputs.Unpack(call.Data[4:])
+	require.NoError(t, err)
+	require.Len(t, args, 3)
+	assert.Equal(t, []byte{0x01}, args[2])
+
+	swapParams, ok := args[1].(struct {
+		ZeroForOne        bool     `json:"zeroForOne"`
+		AmountSpecified   *big.Int `json:"amountSpecified"`
+		SqrtPriceLimitX96 *big.Int `json:"sqrtPriceLimitX96"`
+	})
+	require.True(t, ok)
+	assert.False(t, swapParams.ZeroForOne)
+	assert.Equal(t, big.NewInt(-2500e6), swapParams.AmountSpecified)
+	assert.Equal(t, uniswapV4MaxSqrtPriceLimit, swapParams.SqrtPriceLimitX96)
+}
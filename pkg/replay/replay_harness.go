@@ -116,17 +116,20 @@ func (rh *ReplayHarnessImpl) SetupReplayEnvironment(ctx context.Context, blockNu
 		return nil, fmt.Errorf("failed to get available fork: %w", err)
 	}
 
-	// Reset the fork to clean state
-	err = fork.Reset()
+	// Reset the fork to clean state, pinned to the logged block when known
+	if blockNumber > 0 {
+		err = fork.ResetToBlock(blockNumber)
+	} else {
+		err = fork.Reset()
+	}
 	if err != nil {
 		rh.forkManager.ReleaseFork(fork)
 		return nil, fmt.Errorf("failed to reset fork: %w", err)
 	}
 
 	// Note: In a full implementation, you would also:
-	// 1. Set the fork to the specific block number for historical accuracy
-	// 2. Apply any necessary state modifications
-	// 3. Set up proper gas price and network conditions
+	// 1. Apply any necessary state modifications
+	// 2. Set up proper gas price and network conditions
 
 	return fork, nil
 }
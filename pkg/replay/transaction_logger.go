@@ -50,9 +50,15 @@ func (tl *TransactionLoggerImpl) LogOpportunity(ctx context.Context, opportunity
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 
+	// Executed opportunities are logged under their trade ID, so the realized
+	// result can be written back once the trade lands
+	logID := generateLogID()
 	var executedAt *time.Time
 	var tradeResultJSON []byte
 	if tradeResult != nil {
+		if tradeResult.ID != "" {
+			logID = tradeResult.ID
+		}
 		executedAt = &tradeResult.ExecutedAt
 		tradeResultJSON, err = json.Marshal(tradeResult)
 		if err != nil {
@@ -61,7 +67,7 @@ func (tl *TransactionLoggerImpl) LogOpportunity(ctx context.Context, opportunity
 	}
 
 	_, err = tl.db.ExecContext(ctx, query,
-		generateLogID(),
+		logID,
 		opportunity.ID,
 		string(opportunity.Strategy),
 		time.Now(),
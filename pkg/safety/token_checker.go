@@ -0,0 +1,376 @@
+// Package safety screens tokens before strategies trade them, by simulating a
+// buy-then-sell round trip on a fork.
+package safety
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// Calls made by the probe account: ERC-20 approvals and transfers, and wrapping ETH
+const tokenABI = `[
+	{"name": "approve", "type": "function", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "spender", "type": "address"}, {"name": "amount", "type": "uint256"}],
+	 "outputs": [{"name": "", "type": "bool"}]},
+	{"name": "transfer", "type": "function", "stateMutability": "nonpayable",
+	 "inputs": [{"name": "to", "type": "address"}, {"name": "amount", "type": "uint256"}],
+	 "outputs": [{"name": "", "type": "bool"}]},
+	{"name": "deposit", "type": "function", "stateMutability": "payable", "inputs": [], "outputs": []}
+]`
+
+var (
+	erc20 = func() *abi.ABI {
+		parsed, err := abi.JSON(strings.NewReader(tokenABI))
+		if err != nil {
+			panic("safety: invalid built-in ABI: " + err.Error())
+		}
+		return &parsed
+	}()
+
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	maxUint256    = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// Anvil's first two default accounts, funded with ETH on every fork
+var (
+	defaultProbe         = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	defaultTransferProbe = common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+)
+
+// blacklistTransferDivisor sizes the blacklist probe transfer as a share of the bought tokens
+const blacklistTransferDivisor = 100
+
+// swapDeadline is how far ahead simulated swaps set their deadline
+const swapDeadline = 10 * time.Minute
+
+// tokenChecker implements the TokenSafetyChecker interface
+type tokenChecker struct {
+	config   *interfaces.TokenSafetyConfig
+	forks    interfaces.ForkManager
+	adapters interfaces.ProtocolAdapterRegistry
+	tokens   interfaces.TokenRegistry
+
+	mu      sync.RWMutex
+	reports map[common.Address]*interfaces.TokenSafetyReport
+}
+
+// roundTrip runs the simulated transactions of one check on a fork
+type roundTrip struct {
+	checker *tokenChecker
+	fork    interfaces.Fork
+	request *interfaces.TokenSafetyRequest
+	adapter interfaces.ProtocolAdapter
+}
+
+// NewTokenChecker creates a token safety checker that simulates on forks from the
+// fork manager and swaps through the pool's protocol adapter. The token registry is
+// optional; IsFeeOnTransfer trusts its fee-on-transfer flags without a simulation.
+func NewTokenChecker(
+	config *interfaces.TokenSafetyConfig,
+	forks interfaces.ForkManager,
+	adapters interfaces.ProtocolAdapterRegistry,
+	tokens interfaces.TokenRegistry,
+) interfaces.TokenSafetyChecker {
+	if config == nil {
+		config = &interfaces.TokenSafetyConfig{
+			Probe:           defaultProbe,
+			TransferProbe:   defaultTransferProbe,
+			ProbeAmount:     big.NewInt(1e16), // 0.01 WETH
+			MaxTxMultiplier: 100,
+			MaxTaxBps:       1000, // 10%
+			GasLimit:        500000,
+			CacheTTL:        10 * time.Minute,
+		}
+	}
+
+	return &tokenChecker{
+		config:   config,
+		forks:    forks,
+		adapters: adapters,
+		tokens:   tokens,
+		reports:  make(map[common.Address]*interfaces.TokenSafetyReport),
+	}
+}
+
+// CheckToken simulates, on a fresh fork: the setup transactions, a probe buy, a transfer
+// of part of the bought tokens to a second account, a sell of the rest and a larger buy.
+// Taxes are measured from Transfer logs rather than quotes, so they hold for any pool.
+func (c *tokenChecker) CheckToken(ctx context.Context, request *interfaces.TokenSafetyRequest) (*interfaces.TokenSafetyReport, error) {
+	if request == nil || request.Pool == nil {
+		return nil, fmt.Errorf("token and pool are required")
+	}
+	if report, exists := c.Report(request.Token); exists {
+		return report, nil
+	}
+
+	adapter, exists := c.adapters.Adapter(request.Pool.Protocol)
+	if !exists {
+		return nil, fmt.Errorf("no adapter for %s", request.Pool.Protocol.String())
+	}
+
+	fork, err := c.forks.GetAvailableFork(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork: %w", err)
+	}
+	defer func() {
+		_ = fork.Reset()
+		_ = c.forks.ReleaseFork(fork)
+	}()
+
+	trip := &roundTrip{checker: c, fork: fork, request: request, adapter: adapter}
+	report, err := trip.run(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.reports[request.Token] = report
+	c.mu.Unlock()
+
+	return report, nil
+}
+
+// Report returns the cached report for a token, if it has not expired
+func (c *tokenChecker) Report(token common.Address) (*interfaces.TokenSafetyReport, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	report, exists := c.reports[token]
+	if !exists || (c.config.CacheTTL > 0 && time.Since(report.CheckedAt) > c.config.CacheTTL) {
+		return nil, false
+	}
+	return report, true
+}
+
+// IsFeeOnTransfer reports whether the token registry or an earlier check found a transfer tax
+func (c *tokenChecker) IsFeeOnTransfer(token common.Address) bool {
+	if c.tokens != nil {
+		if info, exists := c.tokens.GetToken(token); exists && info.FeeOnTransfer {
+			return true
+		}
+	}
+	report, exists := c.Report(token)
+	return exists && report.FeeOnTransfer()
+}
+
+// run executes the round trip and classifies the token
+func (r *roundTrip) run(ctx context.Context) (*interfaces.TokenSafetyReport, error) {
+	config := r.checker.config
+	token := r.request.Token
+	pool := r.request.Pool.Address
+	report := &interfaces.TokenSafetyReport{Token: token, Pool: pool, CheckedAt: time.Now()}
+
+	for _, tx := range r.request.Setup {
+		result, err := r.fork.ExecuteTransaction(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay setup transaction %s: %w", tx.Hash, err)
+		}
+		if !result.Success {
+			return nil, fmt.Errorf("setup transaction %s reverted", tx.Hash)
+		}
+	}
+
+	// Fund and approve the probe buy
+	if r.request.QuoteToken == pricing.BaseWETH {
+		if _, err := r.call(ctx, pricing.BaseWETH, config.ProbeAmount, "deposit"); err != nil {
+			return nil, err
+		}
+	}
+	buy, err := r.swap(r.request.QuoteToken, token, config.ProbeAmount)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.call(ctx, r.request.QuoteToken, nil, "approve", *buy.To, maxUint256); err != nil {
+		return nil, err
+	}
+
+	result, err := r.execute(ctx, buy)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		report.Honeypot = true
+		return r.checker.classify(report, "buy reverted"), nil
+	}
+	sent := sumTransfers(result.Logs, token, &pool, nil)
+	bought := sumTransfers(result.Logs, token, nil, &config.Probe)
+	report.BuyTaxBps = taxBps(sent, bought)
+	if bought.Sign() == 0 {
+		report.Honeypot = true
+		return r.checker.classify(report, "buy delivered no tokens"), nil
+	}
+
+	// Blacklists typically block the buyer's transfers, not only sells
+	transferAmount := new(big.Int).Div(bought, big.NewInt(blacklistTransferDivisor))
+	if transferAmount.Sign() > 0 {
+		result, err := r.call(ctx, token, nil, "transfer", config.TransferProbe, transferAmount)
+		if err != nil {
+			return nil, err
+		}
+		if !result.Success {
+			report.Blacklisted = true
+			return r.checker.classify(report, "transfer from buyer reverted"), nil
+		}
+		bought.Sub(bought, transferAmount)
+	}
+
+	if _, err := r.call(ctx, token, nil, "approve", *buy.To, maxUint256); err != nil {
+		return nil, err
+	}
+	sell, err := r.swap(token, r.request.QuoteToken, bought)
+	if err != nil {
+		return nil, err
+	}
+	result, err = r.execute(ctx, sell)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		report.Honeypot = true
+		return r.checker.classify(report, "sell reverted"), nil
+	}
+	sold := sumTransfers(result.Logs, token, &config.Probe, nil)
+	received := sumTransfers(result.Logs, token, &config.Probe, &pool)
+	report.SellTaxBps = taxBps(sold, received)
+	if sumTransfers(result.Logs, r.request.QuoteToken, nil, &config.Probe).Sign() == 0 {
+		report.Honeypot = true
+		return r.checker.classify(report, "sell returned nothing"), nil
+	}
+
+	// A buy the token refuses at size, after a small one went through, is a max-tx limit
+	if config.MaxTxMultiplier > 1 {
+		largeAmount := new(big.Int).Mul(config.ProbeAmount, big.NewInt(config.MaxTxMultiplier))
+		if r.request.QuoteToken == pricing.BaseWETH {
+			if _, err := r.call(ctx, pricing.BaseWETH, largeAmount, "deposit"); err != nil {
+				return nil, err
+			}
+		}
+		largeBuy, err := r.swap(r.request.QuoteToken, token, largeAmount)
+		if err != nil {
+			return nil, err
+		}
+		result, err = r.execute(ctx, largeBuy)
+		if err != nil {
+			return nil, err
+		}
+		report.MaxTxLimited = !result.Success
+	}
+
+	return r.checker.classify(report, ""), nil
+}
+
+// classify records why a token failed, or applies the tax and max-tx limits to one
+// that completed the round trip
+func (c *tokenChecker) classify(report *interfaces.TokenSafetyReport, reason string) *interfaces.TokenSafetyReport {
+	switch {
+	case reason != "":
+		report.Reason = reason
+	case report.BuyTaxBps > c.config.MaxTaxBps:
+		report.Reason = fmt.Sprintf("buy tax of %d bps", report.BuyTaxBps)
+	case report.SellTaxBps > c.config.MaxTaxBps:
+		report.Reason = fmt.Sprintf("sell tax of %d bps", report.SellTaxBps)
+	case report.MaxTxLimited:
+		report.Reason = "max transaction limit"
+	}
+	report.Safe = report.Reason == ""
+	return report
+}
+
+// swap builds a probe swap through the request's pool with no minimum output
+func (r *roundTrip) swap(tokenIn, tokenOut common.Address, amountIn *big.Int) (*types.Transaction, error) {
+	calldata, err := r.adapter.EncodeSwap(&interfaces.SwapParams{
+		Pool:         r.request.Pool,
+		TokenIn:      tokenIn,
+		TokenOut:     tokenOut,
+		AmountIn:     amountIn,
+		AmountOutMin: big.NewInt(0),
+		Recipient:    r.checker.config.Probe,
+		Deadline:     big.NewInt(time.Now().Add(swapDeadline).Unix()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode probe swap: %w", err)
+	}
+	return r.transaction(calldata.To, calldata.Value, calldata.Data), nil
+}
+
+// call executes a token or WETH call from the probe account
+func (r *roundTrip) call(ctx context.Context, to common.Address, value *big.Int, method string, args ...interface{}) (*interfaces.SimulationResult, error) {
+	data, err := erc20.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	result, err := r.execute(ctx, r.transaction(to, value, data))
+	if err != nil {
+		return nil, err
+	}
+	// Funding and approvals must succeed for the round trip to mean anything;
+	// transfers are judged by the caller
+	if !result.Success && method != "transfer" {
+		return nil, fmt.Errorf("probe %s on %s reverted", method, to.Hex())
+	}
+	return result, nil
+}
+
+func (r *roundTrip) execute(ctx context.Context, tx *types.Transaction) (*interfaces.SimulationResult, error) {
+	result, err := r.fork.ExecuteTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate on fork %s: %w", r.fork.GetID(), err)
+	}
+	return result, nil
+}
+
+func (r *roundTrip) transaction(to common.Address, value *big.Int, data []byte) *types.Transaction {
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	return &types.Transaction{
+		From:     r.checker.config.Probe,
+		To:       &to,
+		Value:    value,
+		GasPrice: big.NewInt(0),
+		GasLimit: r.checker.config.GasLimit,
+		Nonce:    0, // Will be set by the fork
+		Data:     data,
+	}
+}
+
+// sumTransfers adds up a token's Transfer logs, optionally filtered by sender and recipient
+func sumTransfers(logs []*ethtypes.Log, token common.Address, from, to *common.Address) *big.Int {
+	total := new(big.Int)
+	for _, log := range logs {
+		if log.Address != token || len(log.Topics) != 3 || log.Topics[0] != transferTopic || len(log.Data) != 32 {
+			continue
+		}
+		if from != nil && common.BytesToAddress(log.Topics[1].Bytes()) != *from {
+			continue
+		}
+		if to != nil && common.BytesToAddress(log.Topics[2].Bytes()) != *to {
+			continue
+		}
+		total.Add(total, new(big.Int).SetBytes(log.Data))
+	}
+	return total
+}
+
+// taxBps returns the share of sent tokens that did not arrive, in basis points
+func taxBps(sent, arrived *big.Int) uint16 {
+	if sent.Sign() <= 0 || arrived.Cmp(sent) >= 0 {
+		return 0
+	}
+	lost := new(big.Int).Sub(sent, arrived)
+	lost.Mul(lost, big.NewInt(10000))
+	lost.Div(lost, sent)
+	return uint16(lost.Uint64())
+}
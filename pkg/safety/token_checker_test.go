@@ -0,0 +1,255 @@
+package safety
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testToken = common.HexToAddress("0x7e57000000000000000000000000000000000001")
+	testPool  = common.HexToAddress("0x1000000000000000000000000000000000000005")
+)
+
+// fakeToken describes how the simulated token behaves
+type fakeToken struct {
+	buyTaxBps       int64
+	sellTaxBps      int64
+	buyReverts      bool
+	sellReverts     bool
+	transferReverts bool
+	largeBuyReverts bool
+}
+
+// fakeFork plays a round trip through a V2 pool, in the order the checker sends it
+type fakeFork struct {
+	interfaces.Fork
+	token  fakeToken
+	swaps  int
+	txs    []*types.Transaction
+	resets int
+}
+
+func (f *fakeFork) GetID() string { return "fake" }
+
+func (f *fakeFork) Reset() error {
+	f.resets++
+	return nil
+}
+
+func (f *fakeFork) ExecuteTransaction(ctx context.Context, tx *types.Transaction) (*interfaces.SimulationResult, error) {
+	f.txs = append(f.txs, tx)
+	probe := defaultProbe
+
+	if bytes.HasPrefix(tx.Data, erc20.Methods["transfer"].ID) {
+		return &interfaces.SimulationResult{Success: !f.token.transferReverts}, nil
+	}
+	if *tx.To != protocols.BaseSushiSwapV2Router {
+		return &interfaces.SimulationResult{Success: true}, nil // Setup, deposits and approvals
+	}
+
+	f.swaps++
+	amount := big.NewInt(1000e9)
+	switch f.swaps {
+	case 1:
+		if f.token.buyReverts {
+			return &interfaces.SimulationResult{Success: false}, nil
+		}
+		return &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{
+			transferLog(testToken, testPool, probe, taxed(amount, f.token.buyTaxBps)),
+			transferLog(testToken, testPool, testToken, tax(amount, f.token.buyTaxBps)),
+		}}, nil
+	case 2:
+		if f.token.sellReverts {
+			return &interfaces.SimulationResult{Success: false}, nil
+		}
+		return &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{
+			transferLog(testToken, probe, testPool, taxed(amount, f.token.sellTaxBps)),
+			transferLog(testToken, probe, testToken, tax(amount, f.token.sellTaxBps)),
+			transferLog(pricing.BaseWETH, testPool, probe, big.NewInt(9e15)),
+		}}, nil
+	default:
+		return &interfaces.SimulationResult{Success: !f.token.largeBuyReverts}, nil
+	}
+}
+
+// fakeForkManager hands out a single fork
+type fakeForkManager struct {
+	interfaces.ForkManager
+	fork     *fakeFork
+	released int
+}
+
+func (m *fakeForkManager) GetAvailableFork(ctx context.Context) (interfaces.Fork, error) {
+	return m.fork, nil
+}
+
+func (m *fakeForkManager) ReleaseFork(fork interfaces.Fork) error {
+	m.released++
+	return nil
+}
+
+func transferLog(token, from, to common.Address, amount *big.Int) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: token,
+		Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.BigToHash(amount).Bytes(),
+	}
+}
+
+func tax(amount *big.Int, bps int64) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(bps)), big.NewInt(10000))
+}
+
+func taxed(amount *big.Int, bps int64) *big.Int {
+	return new(big.Int).Sub(amount, tax(amount, bps))
+}
+
+func newTestChecker(t *testing.T, token fakeToken) (interfaces.TokenSafetyChecker, *fakeForkManager) {
+	t.Helper()
+
+	adapters := protocols.NewRegistry(nil)
+	require.NoError(t, adapters.Register(protocols.NewSushiSwapV2Adapter(protocols.BaseSushiSwapV2Router)))
+	forks := &fakeForkManager{fork: &fakeFork{token: token}}
+
+	return NewTokenChecker(nil, forks, adapters, nil), forks
+}
+
+func testRequest() *interfaces.TokenSafetyRequest {
+	return &interfaces.TokenSafetyRequest{
+		Token:      testToken,
+		QuoteToken: pricing.BaseWETH,
+		Pool: &interfaces.PoolState{
+			Protocol: interfaces.ProtocolSushiSwapV2,
+			Address:  testPool,
+			Tokens:   []common.Address{testToken, pricing.BaseWETH},
+			Reserves: []*big.Int{big.NewInt(1e18), big.NewInt(1e18)},
+		},
+		Setup: []*types.Transaction{{Hash: "0xlaunch", To: &testPool}},
+	}
+}
+
+func TestTokenChecker_CheckToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         fakeToken
+		safe          bool
+		check         func(t *testing.T, report *interfaces.TokenSafetyReport)
+		feeOnTransfer bool
+	}{
+		{
+			name:  "clean token",
+			token: fakeToken{},
+			safe:  true,
+		},
+		{
+			name:          "small taxes",
+			token:         fakeToken{buyTaxBps: 300, sellTaxBps: 500},
+			safe:          true,
+			feeOnTransfer: true,
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.Equal(t, uint16(300), report.BuyTaxBps)
+				assert.Equal(t, uint16(500), report.SellTaxBps)
+			},
+		},
+		{
+			name:          "sell tax above limit",
+			token:         fakeToken{sellTaxBps: 2500},
+			feeOnTransfer: true,
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.Equal(t, "sell tax of 2500 bps", report.Reason)
+			},
+		},
+		{
+			name:  "sell reverts",
+			token: fakeToken{sellReverts: true},
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.True(t, report.Honeypot)
+			},
+		},
+		{
+			name:  "buy reverts",
+			token: fakeToken{buyReverts: true},
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.True(t, report.Honeypot)
+				assert.Equal(t, "buy reverted", report.Reason)
+			},
+		},
+		{
+			name:  "buyer blacklisted",
+			token: fakeToken{transferReverts: true},
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.True(t, report.Blacklisted)
+				assert.False(t, report.Honeypot)
+			},
+		},
+		{
+			name:  "max transaction limit",
+			token: fakeToken{largeBuyReverts: true},
+			check: func(t *testing.T, report *interfaces.TokenSafetyReport) {
+				assert.True(t, report.MaxTxLimited)
+				assert.Equal(t, "max transaction limit", report.Reason)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, forks := newTestChecker(t, tt.token)
+
+			report, err := checker.CheckToken(context.Background(), testRequest())
+			require.NoError(t, err)
+			assert.Equal(t, tt.safe, report.Safe, report.Reason)
+			assert.Equal(t, tt.feeOnTransfer, checker.IsFeeOnTransfer(testToken))
+			if tt.check != nil {
+				tt.check(t, report)
+			}
+
+			// The setup replays first, and the fork is reset and returned
+			assert.Equal(t, "0xlaunch", forks.fork.txs[0].Hash)
+			assert.Equal(t, 1, forks.fork.resets)
+			assert.Equal(t, 1, forks.released)
+		})
+	}
+}
+
+func TestTokenChecker_CachesReports(t *testing.T) {
+	checker, forks := newTestChecker(t, fakeToken{})
+
+	_, exists := checker.Report(testToken)
+	assert.False(t, exists)
+
+	first, err := checker.CheckToken(context.Background(), testRequest())
+	require.NoError(t, err)
+	simulated := len(forks.fork.txs)
+
+	second, err := checker.CheckToken(context.Background(), testRequest())
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Len(t, forks.fork.txs, simulated)
+
+	report, exists := checker.Report(testToken)
+	assert.True(t, exists)
+	assert.Same(t, first, report)
+}
+
+func TestTokenChecker_TokenRegistryFlags(t *testing.T) {
+	tokens, err := pricing.NewTokenRegistry(nil, []*interfaces.TokenInfo{
+		{Address: testToken, Symbol: "TAX", Decimals: 18, FeeOnTransfer: true},
+	})
+	require.NoError(t, err)
+
+	checker := NewTokenChecker(nil, nil, nil, tokens)
+	assert.True(t, checker.IsFeeOnTransfer(testToken))
+	assert.False(t, checker.IsFeeOnTransfer(pricing.BaseWETH))
+}
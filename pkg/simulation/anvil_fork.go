@@ -20,6 +20,8 @@ type anvilFork struct {
 	id      string
 	port    int
 	rpcURL  string
+	forkURL string
+	pinned  bool // Re-forked at a past block by ResetToBlock
 	client  *ethclient.Client
 	cmd     *exec.Cmd
 	healthy bool
@@ -155,13 +157,50 @@ func (f *anvilFork) Reset() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// A pinned fork is moved back to the upstream head rather than to the pinned block
+	var params []interface{}
+	if f.pinned {
+		params = append(params, map[string]interface{}{
+			"forking": map[string]interface{}{"jsonRpcUrl": f.forkURL},
+		})
+	}
+
 	var result interface{}
-	err := f.client.Client().CallContext(ctx, &result, "anvil_reset")
+	err := f.client.Client().CallContext(ctx, &result, "anvil_reset", params...)
 	if err != nil {
 		f.markUnhealthy()
 		return fmt.Errorf("failed to reset fork: %w", err)
 	}
 
+	f.pinned = false
+	return nil
+}
+
+// ResetToBlock re-forks the upstream chain at the given block
+func (f *anvilFork) ResetToBlock(blockNumber uint64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.healthy {
+		return fmt.Errorf("fork %s is not healthy", f.id)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var result interface{}
+	err := f.client.Client().CallContext(ctx, &result, "anvil_reset", map[string]interface{}{
+		"forking": map[string]interface{}{
+			"jsonRpcUrl":  f.forkURL,
+			"blockNumber": blockNumber,
+		},
+	})
+	if err != nil {
+		f.markUnhealthy()
+		return fmt.Errorf("failed to reset fork to block %d: %w", blockNumber, err)
+	}
+
+	f.pinned = true
 	return nil
 }
 
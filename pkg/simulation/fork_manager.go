@@ -229,6 +229,7 @@ func (fm *forkManager) createAnvilFork(ctx context.Context, forkURL string, port
 		id:      forkID,
 		port:    port,
 		rpcURL:  rpcURL,
+		forkURL: forkURL,
 		client:  client,
 		cmd:     cmd,
 		healthy: true,
@@ -211,6 +211,10 @@ func (m *mockFork) Reset() error {
 	return m.resetErr
 }
 
+func (m *mockFork) ResetToBlock(blockNumber uint64) error {
+	return m.resetErr
+}
+
 func (m *mockFork) Close() error {
 	return nil
 }
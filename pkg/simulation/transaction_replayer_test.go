@@ -52,6 +52,11 @@ func (m *mockReplayerFork) Reset() error {
 	return args.Error(0)
 }
 
+func (m *mockReplayerFork) ResetToBlock(blockNumber uint64) error {
+	args := m.Called(blockNumber)
+	return args.Error(0)
+}
+
 func (m *mockReplayerFork) Close() error {
 	args := m.Called()
 	return args.Error(0)
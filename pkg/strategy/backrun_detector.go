@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -14,11 +15,15 @@ import (
 
 // backrunDetector implements the BackrunDetector interface
 type backrunDetector struct {
-	config *interfaces.BackrunConfig
+	config       atomic.Pointer[interfaces.BackrunConfig]
+	poolRegistry interfaces.PoolRegistry
+	builder      interfaces.TransactionBuilder
+	capital      interfaces.CapitalSource
 }
 
-// NewBackrunDetector creates a new backrun detector with the given configuration
-func NewBackrunDetector(config *interfaces.BackrunConfig) interfaces.BackrunDetector {
+// NewBackrunDetector creates a new backrun detector with the given configuration.
+// Options add a pool registry, a transaction builder and a capital source.
+func NewBackrunDetector(config *interfaces.BackrunConfig, options ...DetectorOption) interfaces.BackrunDetector {
 	if config == nil {
 		config = &interfaces.BackrunConfig{
 			MinPriceGap:        big.NewInt(50),   // 0.5% minimum price gap in basis points
@@ -30,9 +35,14 @@ func NewBackrunDetector(config *interfaces.BackrunConfig) interfaces.BackrunDete
 			},
 		}
 	}
-	return &backrunDetector{
-		config: config,
+	deps := applyDetectorOptions(options)
+	detector := &backrunDetector{
+		poolRegistry: deps.poolRegistry,
+		builder:      deps.builder,
+		capital:      deps.capital,
 	}
+	detector.config.Store(config)
+	return detector
 }
 
 // DetectOpportunity analyzes a transaction to identify backrun arbitrage opportunities
@@ -54,7 +64,7 @@ func (b *backrunDetector) DetectOpportunity(ctx context.Context, tx *types.Trans
 	}
 
 	// Check if price impact creates arbitrage opportunity
-	arbitrageOpportunity, err := b.findArbitrageOpportunity(tx, priceImpact)
+	arbitrageOpportunity, err := b.findArbitrageOpportunity(ctx, tx, priceImpact)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find arbitrage opportunity: %w", err)
 	}
@@ -74,7 +84,13 @@ func (b *backrunDetector) CalculateOptimalTradeSize(ctx context.Context, opportu
 
 	// Binary search parameters
 	minSize := big.NewInt(1000)  // Minimum trade size (0.001 ETH)
-	maxSize := b.config.MaxTradeSize
+	maxSize := b.config.Load().MaxTradeSize
+	if common.IsHexAddress(opportunity.Token) {
+		maxSize = b.maxTradeSize(common.HexToAddress(opportunity.Token))
+	}
+	if maxSize.Cmp(minSize) < 0 {
+		return new(big.Int).Set(maxSize), nil // Too little capital to search
+	}
 	tolerance := big.NewInt(100) // Tolerance for convergence
 
 	// Binary search for optimal trade size
@@ -88,17 +104,18 @@ func (b *backrunDetector) CalculateOptimalTradeSize(ctx context.Context, opportu
 
 // ValidateArbitrage validates that an arbitrage opportunity is still profitable
 func (b *backrunDetector) ValidateArbitrage(ctx context.Context, opportunity *interfaces.BackrunOpportunity) error {
+	config := b.config.Load()
 	if opportunity == nil {
 		return errors.New("opportunity cannot be nil")
 	}
 
 	// Check if price gap meets minimum threshold
-	if opportunity.PriceGap.Cmp(b.config.MinPriceGap) < 0 {
+	if opportunity.PriceGap.Cmp(config.MinPriceGap) < 0 {
 		return errors.New("price gap below minimum threshold")
 	}
 
 	// Check if expected profit meets minimum threshold
-	if opportunity.ExpectedProfit.Cmp(b.config.MinProfitThreshold) < 0 {
+	if opportunity.ExpectedProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return errors.New("expected profit below minimum threshold")
 	}
 
@@ -120,7 +137,12 @@ func (b *backrunDetector) ValidateArbitrage(ctx context.Context, opportunity *in
 
 // GetConfiguration returns the current backrun detector configuration
 func (b *backrunDetector) GetConfiguration() *interfaces.BackrunConfig {
-	return b.config
+	return b.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (b *backrunDetector) SetConfiguration(config *interfaces.BackrunConfig) {
+	b.config.Store(config)
 }
 
 // extractPriceImpact extracts price impact information from simulation results
@@ -176,9 +198,9 @@ func (b *backrunDetector) parseSwapEvent(log *ethtypes.Log) (*interfaces.PriceIm
 }
 
 // findArbitrageOpportunity identifies arbitrage opportunities from price impact
-func (b *backrunDetector) findArbitrageOpportunity(tx *types.Transaction, priceImpact *interfaces.PriceImpact) (*interfaces.BackrunOpportunity, error) {
+func (b *backrunDetector) findArbitrageOpportunity(ctx context.Context, tx *types.Transaction, priceImpact *interfaces.PriceImpact) (*interfaces.BackrunOpportunity, error) {
 	// Check if price impact is significant enough for arbitrage
-	if priceImpact.ImpactBps < b.config.MinPriceGap.Int64() {
+	if priceImpact.ImpactBps < b.config.Load().MinPriceGap.Int64() {
 		return nil, nil // Price impact too small
 	}
 
@@ -194,12 +216,15 @@ func (b *backrunDetector) findArbitrageOpportunity(tx *types.Transaction, priceI
 
 	// Calculate initial trade size estimate
 	initialTradeSize := b.estimateInitialTradeSize(tx, priceImpact)
+	if initialTradeSize.Sign() <= 0 {
+		return nil, nil // No capital to trade with
+	}
 
 	// Estimate profit from arbitrage
 	expectedProfit := b.estimateArbitrageProfit(priceImpact, initialTradeSize)
 
 	// Create arbitrage transaction
-	arbitrageTx, err := b.constructArbitrageTransaction(tx, priceImpact.Token, alternativePool, initialTradeSize)
+	arbitrageTx, err := b.constructArbitrageTransaction(ctx, tx, priceImpact, alternativePool, initialTradeSize)
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct arbitrage transaction: %w", err)
 	}
@@ -220,6 +245,18 @@ func (b *backrunDetector) findArbitrageOpportunity(tx *types.Transaction, priceI
 
 // findAlternativePool finds an alternative pool for arbitrage
 func (b *backrunDetector) findAlternativePool(token common.Address, excludePool common.Address) (string, error) {
+	if b.poolRegistry != nil {
+		// Pools come back sorted by TVL with the liquidity floor already applied
+		pools := b.poolRegistry.FindPools(&interfaces.PoolFilter{
+			Token:   &token,
+			Exclude: []common.Address{excludePool},
+		})
+		if len(pools) == 0 {
+			return "", errors.New("no liquid alternative pools found for token")
+		}
+		return pools[0].Address.Hex(), nil
+	}
+
 	// This is a simplified implementation
 	// In practice, you'd query multiple DEX protocols to find the best alternative pool
 	
@@ -258,18 +295,31 @@ func (b *backrunDetector) estimateInitialTradeSize(tx *types.Transaction, priceI
 	initialSize := new(big.Int).Div(tx.Value, big.NewInt(10)) // 10% of original transaction
 	
 	// Ensure it's within bounds
-	if initialSize.Cmp(b.config.MaxTradeSize) > 0 {
-		return new(big.Int).Set(b.config.MaxTradeSize)
+	maxSize := b.maxTradeSize(priceImpact.Token)
+	if maxSize.Sign() <= 0 {
+		return maxSize
+	}
+	if initialSize.Cmp(maxSize) > 0 {
+		return new(big.Int).Set(maxSize)
 	}
 	
 	minSize := big.NewInt(1000) // Minimum 0.001 ETH
 	if initialSize.Cmp(minSize) < 0 {
+		if maxSize.Cmp(minSize) < 0 {
+			return maxSize
+		}
 		return minSize
 	}
 	
 	return initialSize
 }
 
+// maxTradeSize returns the configured maximum trade size, limited to the capital
+// available in the token sold
+func (b *backrunDetector) maxTradeSize(token common.Address) *big.Int {
+	return capToCapital(b.capital, token, b.config.Load().MaxTradeSize)
+}
+
 // estimateArbitrageProfit estimates the profit from an arbitrage opportunity
 func (b *backrunDetector) estimateArbitrageProfit(priceImpact *interfaces.PriceImpact, tradeSize *big.Int) *big.Int {
 	// Simplified profit calculation
@@ -299,7 +349,12 @@ func (b *backrunDetector) estimateArbitrageProfit(priceImpact *interfaces.PriceI
 }
 
 // constructArbitrageTransaction creates the arbitrage transaction
-func (b *backrunDetector) constructArbitrageTransaction(targetTx *types.Transaction, token common.Address, alternativePool string, tradeSize *big.Int) (*types.Transaction, error) {
+func (b *backrunDetector) constructArbitrageTransaction(ctx context.Context, targetTx *types.Transaction, priceImpact *interfaces.PriceImpact, alternativePool string, tradeSize *big.Int) (*types.Transaction, error) {
+	token := priceImpact.Token
+	if b.builder != nil {
+		return b.buildArbitrageTransaction(ctx, targetTx, priceImpact, common.HexToAddress(alternativePool), tradeSize)
+	}
+
 	// Create arbitrage transaction with same gas price as target (to be included after)
 	toAddr := common.HexToAddress(alternativePool)
 	arbitrageTx := &types.Transaction{
@@ -317,6 +372,25 @@ func (b *backrunDetector) constructArbitrageTransaction(targetTx *types.Transact
 	return arbitrageTx, nil
 }
 
+// buildArbitrageTransaction builds the arbitrage through the transaction builder: the
+// target leaves the token overpriced in its pool, so sell it there and buy it back in
+// the alternative pool, requiring at least the amount sold back
+func (b *backrunDetector) buildArbitrageTransaction(ctx context.Context, targetTx *types.Transaction, priceImpact *interfaces.PriceImpact, alternativePool common.Address, tradeSize *big.Int) (*types.Transaction, error) {
+	transactions, err := b.builder.BuildSwaps(ctx, []*interfaces.SwapRoute{{
+		Hops: []interfaces.SwapHop{
+			{Pool: priceImpact.Pool, TokenIn: priceImpact.Token},
+			{Pool: alternativePool, TokenOut: priceImpact.Token},
+		},
+		AmountIn:     tradeSize,
+		MinAmountOut: tradeSize,
+		GasPrice:     targetTx.GasPrice,
+	}}, targetTx.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build arbitrage: %w", err)
+	}
+	return transactions[0], nil
+}
+
 // constructArbitrageData creates the transaction data for arbitrage
 func (b *backrunDetector) constructArbitrageData(token common.Address, amount *big.Int) []byte {
 	// This is a simplified implementation
@@ -419,7 +493,7 @@ func (b *backrunDetector) calculateTradeEfficiency(size *big.Int) float64 {
 	// Larger trades have lower efficiency due to slippage
 	
 	sizeFloat := new(big.Float).SetInt(size)
-	maxSizeFloat := new(big.Float).SetInt(b.config.MaxTradeSize)
+	maxSizeFloat := new(big.Float).SetInt(b.config.Load().MaxTradeSize)
 	
 	// Calculate ratio (0 to 1)
 	ratio, _ := new(big.Float).Quo(sizeFloat, maxSizeFloat).Float64()
@@ -188,6 +188,51 @@ func TestBackrunDetector_CalculateOptimalTradeSize(t *testing.T) {
 	}
 }
 
+func TestBackrunDetector_CalculateOptimalTradeSizeWithCapital(t *testing.T) {
+	ctx := context.Background()
+	opportunity := createMockBackrunOpportunity()
+	token := common.HexToAddress(opportunity.Token)
+
+	tests := []struct {
+		name    string
+		capital fixedCapital
+		maxSize *big.Int
+	}{
+		{name: "capital above the maximum trade size", capital: fixedCapital{token: big.NewInt(5000000)}, maxSize: big.NewInt(1000000)},
+		{name: "capital below the maximum trade size", capital: fixedCapital{token: big.NewInt(20000)}, maxSize: big.NewInt(20000)},
+		{name: "capital below the minimum trade size", capital: fixedCapital{token: big.NewInt(500)}, maxSize: big.NewInt(500)},
+		{name: "no capital", capital: fixedCapital{}, maxSize: big.NewInt(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewBackrunDetector(nil, WithCapitalSource(tt.capital))
+			optimalSize, err := detector.CalculateOptimalTradeSize(ctx, opportunity)
+			require.NoError(t, err)
+			assert.True(t, optimalSize.Cmp(tt.maxSize) <= 0)
+			if tt.maxSize.Cmp(big.NewInt(1000)) < 0 {
+				assert.Equal(t, tt.maxSize, optimalSize)
+			}
+		})
+	}
+}
+
+func TestBackrunDetector_FindArbitrageOpportunityWithCapital(t *testing.T) {
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	impact := &interfaces.PriceImpact{Token: weth, Pool: common.HexToAddress("0x2222222222222222222222222222222222222222"), ImpactBps: 500}
+	tx := createMockSwapTransaction()
+	tx.Value = big.NewInt(1e11)
+	config := &interfaces.BackrunConfig{MinPriceGap: big.NewInt(50), MaxTradeSize: big.NewInt(1e12), MinProfitThreshold: big.NewInt(100)}
+
+	// The expected profit is priced at the trade size the capital allows
+	detector := NewBackrunDetector(config, WithCapitalSource(fixedCapital{weth: big.NewInt(1e9)})).(*backrunDetector)
+	opportunity, err := detector.findArbitrageOpportunity(context.Background(), tx, impact)
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.Equal(t, big.NewInt(1e9), opportunity.OptimalAmount)
+	assert.Equal(t, big.NewInt(1e9*500/10000-300000), opportunity.ExpectedProfit)
+}
+
 func TestBackrunDetector_ValidateArbitrage(t *testing.T) {
 	config := &interfaces.BackrunConfig{
 		MinPriceGap:        big.NewInt(50),
@@ -478,6 +523,58 @@ func TestBackrunDetector_FindAlternativePool(t *testing.T) {
 	}
 }
 
+func TestBackrunDetector_FindAlternativePoolWithRegistry(t *testing.T) {
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	targetPool := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	liquidPool := common.HexToAddress("0x5555555555555555555555555555555555555555")
+
+	registry := &stubPoolRegistry{
+		pools: []*interfaces.PoolInfo{
+			{Address: targetPool, Token0: weth, TVL: big.NewInt(9000)},
+			{Address: liquidPool, Token0: weth, TVL: big.NewInt(5000)},
+		},
+	}
+
+	detector := NewBackrunDetector(nil, WithPoolRegistry(registry)).(*backrunDetector)
+
+	pool, err := detector.findAlternativePool(weth, targetPool)
+	require.NoError(t, err)
+	assert.Equal(t, liquidPool.Hex(), pool)
+	require.NotNil(t, registry.lastFilter)
+	assert.Equal(t, weth, *registry.lastFilter.Token)
+	assert.Nil(t, registry.lastFilter.MinTVL) // Registry floor applies
+
+	// No liquid pools means no opportunity source
+	registry.pools = nil
+	_, err = detector.findAlternativePool(weth, targetPool)
+	assert.Error(t, err)
+}
+
+// stubPoolRegistry returns canned pools from FindPools
+type stubPoolRegistry struct {
+	interfaces.PoolRegistry
+	pools      []*interfaces.PoolInfo
+	lastFilter *interfaces.PoolFilter
+}
+
+func (s *stubPoolRegistry) FindPools(filter *interfaces.PoolFilter) []*interfaces.PoolInfo {
+	s.lastFilter = filter
+
+	var result []*interfaces.PoolInfo
+	for _, pool := range s.pools {
+		excluded := false
+		for _, address := range filter.Exclude {
+			if pool.Address == address {
+				excluded = true
+			}
+		}
+		if !excluded {
+			result = append(result, pool)
+		}
+	}
+	return result
+}
+
 // Helper functions for creating mock data
 
 func createMockSwapTransaction() *types.Transaction {
@@ -544,4 +641,27 @@ func createMockBackrunOpportunity() *interfaces.BackrunOpportunity {
 		OptimalAmount:  big.NewInt(50000),
 		ExpectedProfit: big.NewInt(500),
 	}
-}
\ No newline at end of file
+}
+func TestBackrunDetector_DetectOpportunityWithBuilder(t *testing.T) {
+	builder := &recordingBuilder{searcher: common.HexToAddress("0x5ea4c4e4"), nonce: 9}
+	detector := NewBackrunDetector(nil, WithTransactionBuilder(builder))
+
+	tx := createMockSwapTransaction()
+	opportunity, err := detector.DetectOpportunity(context.Background(), tx, createMockSimulationResult())
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.Equal(t, builder.searcher, opportunity.ArbitrageTx.From)
+	assert.Equal(t, uint64(9), opportunity.ArbitrageTx.Nonce)
+
+	// Sell WETH into the target's pool and buy back at least as much from the alternative
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	require.Len(t, builder.routes, 1)
+	route := builder.routes[0]
+	assert.Equal(t, []interfaces.SwapHop{
+		{Pool: common.HexToAddress(opportunity.Pool1), TokenIn: weth},
+		{Pool: common.HexToAddress(opportunity.Pool2), TokenOut: weth},
+	}, route.Hops)
+	assert.Equal(t, opportunity.OptimalAmount, route.AmountIn)
+	assert.Equal(t, route.AmountIn, route.MinAmountOut)
+	assert.Equal(t, tx.GasPrice, route.GasPrice)
+}
@@ -0,0 +1,35 @@
+package strategy
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// capToCapital limits a trade's input to the capital available in its token.
+// Without a capital source, or before our inventory is known, the amount is
+// unchanged.
+func capToCapital(capital interfaces.CapitalSource, token common.Address, amount *big.Int) *big.Int {
+	if capital == nil || amount == nil {
+		return amount
+	}
+	available := capital.AvailableCapital(token)
+	if available == nil || available.Cmp(amount) >= 0 {
+		return amount
+	}
+	if available.Sign() < 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(available)
+}
+
+// scaleToCapital scales a trade's expected profit down to the input capToCapital
+// left it, as the profit grows with the amount traded
+func scaleToCapital(profit, capped, amount *big.Int) *big.Int {
+	if profit == nil || capped == nil || amount == nil || amount.Sign() <= 0 || capped.Cmp(amount) >= 0 {
+		return profit
+	}
+	scaled := new(big.Int).Mul(profit, capped)
+	return scaled.Div(scaled, amount)
+}
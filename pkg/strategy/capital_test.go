@@ -0,0 +1,48 @@
+package strategy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixedCapital is a capital source with fixed balances; a nil map means the
+// inventory isn't known yet
+type fixedCapital map[common.Address]*big.Int
+
+func (f fixedCapital) AvailableCapital(token common.Address) *big.Int {
+	if f == nil {
+		return nil
+	}
+	if amount, exists := f[token]; exists {
+		return amount
+	}
+	return new(big.Int)
+}
+
+func TestCapToCapital(t *testing.T) {
+	weth := common.HexToAddress("0x4200000000000000000000000000000000000006")
+
+	tests := []struct {
+		name    string
+		capital fixedCapital
+		amount  *big.Int
+		want    *big.Int
+	}{
+		{name: "unknown inventory", capital: nil, amount: big.NewInt(100), want: big.NewInt(100)},
+		{name: "enough capital", capital: fixedCapital{weth: big.NewInt(500)}, amount: big.NewInt(100), want: big.NewInt(100)},
+		{name: "capped", capital: fixedCapital{weth: big.NewInt(40)}, amount: big.NewInt(100), want: big.NewInt(40)},
+		{name: "untracked token", capital: fixedCapital{}, amount: big.NewInt(100), want: big.NewInt(0)},
+		{name: "overdrawn", capital: fixedCapital{weth: big.NewInt(-5)}, amount: big.NewInt(100), want: big.NewInt(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, capToCapital(tt.capital, weth, tt.amount))
+		})
+	}
+
+	assert.Equal(t, big.NewInt(100), capToCapital(nil, weth, big.NewInt(100)))
+}
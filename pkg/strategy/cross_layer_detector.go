@@ -4,39 +4,79 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/bridge"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 )
 
 // CrossLayerDetectorImpl implements the CrossLayerArbitrageDetector interface
 type CrossLayerDetectorImpl struct {
-	config         *interfaces.CrossLayerConfig
+	config         atomic.Pointer[interfaces.CrossLayerConfig]
 	eventParser    interfaces.EventParser
 	priceOracle    PriceOracle
-	bridgeContract common.Address
+	bridgeConfig   *interfaces.BridgeConfig
+	prover         interfaces.WithdrawalProver
 }
 
+// secondsPerYear annualizes the cost of capital locked in the bridge
+var secondsPerYear = big.NewInt(365 * 24 * 60 * 60)
+
 // PriceOracle interface for getting token prices on different layers
 type PriceOracle interface {
 	GetL1Price(ctx context.Context, token string) (*big.Int, error)
 	GetL2Price(ctx context.Context, token string) (*big.Int, error)
 }
 
-// NewCrossLayerDetector creates a new cross-layer arbitrage detector
+// NewCrossLayerDetector creates a new cross-layer arbitrage detector over the Base
+// bridge contracts. A non-zero bridge contract replaces the L1StandardBridge.
 func NewCrossLayerDetector(config *interfaces.CrossLayerConfig, eventParser interfaces.EventParser, priceOracle PriceOracle, bridgeContract common.Address) *CrossLayerDetectorImpl {
-	return &CrossLayerDetectorImpl{
-		config:         config,
-		eventParser:    eventParser,
-		priceOracle:    priceOracle,
-		bridgeContract: bridgeContract,
+	bridgeConfig := bridge.DefaultBaseConfig()
+	if bridgeContract != (common.Address{}) {
+		bridgeConfig.L1StandardBridge = bridgeContract
 	}
+	return NewCrossLayerDetectorWithBridge(config, eventParser, priceOracle, bridgeConfig, nil)
+}
+
+// NewCrossLayerDetectorWithBridge creates a cross-layer arbitrage detector that
+// bridges through the given contracts and settles withdrawals with the prover.
+// Prices are expected per pricing.CrossLayerPriceUnit unless PriceScale says otherwise.
+func NewCrossLayerDetectorWithBridge(config *interfaces.CrossLayerConfig, eventParser interfaces.EventParser, priceOracle PriceOracle, bridgeConfig *interfaces.BridgeConfig, prover interfaces.WithdrawalProver) *CrossLayerDetectorImpl {
+	if config == nil {
+		config = &interfaces.CrossLayerConfig{
+			MinPriceGap:        big.NewInt(1),
+			MinAmount:          big.NewInt(1),
+			MaxAmount:          new(big.Int).Mul(big.NewInt(1000), big.NewInt(1e18)),
+			BridgeFee:          big.NewInt(0),
+			MinProfitThreshold: big.NewInt(1e16),           // 0.01 ETH
+			PriceScale:         big.NewInt(1e18),           // pricing.CrossLayerPriceUnit
+			DepositDelay:       3 * time.Minute,
+			ProvingDelay:       time.Hour,
+			ChallengeWindow:    7 * 24 * time.Hour,
+			CapitalCostBps:     500,
+			SettlementGasCost:  big.NewInt(14e15),          // ~700k L1 gas at 20 gwei
+		}
+	}
+	if bridgeConfig == nil {
+		bridgeConfig = bridge.DefaultBaseConfig()
+	}
+
+	detector := &CrossLayerDetectorImpl{
+		eventParser:  eventParser,
+		priceOracle:  priceOracle,
+		bridgeConfig: bridgeConfig,
+		prover:       prover,
+	}
+	detector.config.Store(config)
+	return detector
 }
 
 // DetectOpportunity detects cross-layer arbitrage opportunities from bridge events
 func (d *CrossLayerDetectorImpl) DetectOpportunity(ctx context.Context, bridgeEvent *interfaces.BridgeEvent, l1Price, l2Price *big.Int) (*interfaces.CrossLayerOpportunity, error) {
+	config := d.config.Load()
 	if bridgeEvent == nil {
 		return nil, fmt.Errorf("bridge event is nil")
 	}
@@ -46,13 +86,13 @@ func (d *CrossLayerDetectorImpl) DetectOpportunity(ctx context.Context, bridgeEv
 	}
 
 	// Check if the amount meets minimum threshold
-	if bridgeEvent.Amount.Cmp(d.config.MinAmount) < 0 {
-		return nil, fmt.Errorf("bridge amount %s below minimum threshold %s", bridgeEvent.Amount.String(), d.config.MinAmount.String())
+	if bridgeEvent.Amount.Cmp(config.MinAmount) < 0 {
+		return nil, fmt.Errorf("bridge amount %s below minimum threshold %s", bridgeEvent.Amount.String(), config.MinAmount.String())
 	}
 
 	// Check if the amount is within maximum threshold
-	if bridgeEvent.Amount.Cmp(d.config.MaxAmount) > 0 {
-		return nil, fmt.Errorf("bridge amount %s exceeds maximum threshold %s", bridgeEvent.Amount.String(), d.config.MaxAmount.String())
+	if bridgeEvent.Amount.Cmp(config.MaxAmount) > 0 {
+		return nil, fmt.Errorf("bridge amount %s exceeds maximum threshold %s", bridgeEvent.Amount.String(), config.MaxAmount.String())
 	}
 
 	// Calculate price gap
@@ -62,8 +102,8 @@ func (d *CrossLayerDetectorImpl) DetectOpportunity(ctx context.Context, bridgeEv
 	}
 
 	// Check if price gap meets minimum threshold
-	if priceGap.Cmp(d.config.MinPriceGap) < 0 {
-		return nil, fmt.Errorf("price gap %s below minimum threshold %s", priceGap.String(), d.config.MinPriceGap.String())
+	if priceGap.Cmp(config.MinPriceGap) < 0 {
+		return nil, fmt.Errorf("price gap %s below minimum threshold %s", priceGap.String(), config.MinPriceGap.String())
 	}
 
 	// Determine arbitrage direction
@@ -74,12 +114,13 @@ func (d *CrossLayerDetectorImpl) DetectOpportunity(ctx context.Context, bridgeEv
 		direction = interfaces.DirectionL2ToL1
 	}
 
-	// Calculate expected profit
-	expectedProfit := d.calculateExpectedProfit(bridgeEvent.Amount, priceGap, direction)
+	// Calculate expected profit, net of the capital locked up while bridging
+	expectedProfit := d.calculateExpectedProfit(bridgeEvent.Amount, l1Price, l2Price, direction)
+	lockup, capitalCost := d.calculateCapitalCost(bridgeEvent.Amount, l1Price, l2Price, direction)
 
 	// Check if profit meets minimum threshold
-	if expectedProfit.Cmp(d.config.MinProfitThreshold) < 0 {
-		return nil, fmt.Errorf("expected profit %s below minimum threshold %s", expectedProfit.String(), d.config.MinProfitThreshold.String())
+	if expectedProfit.Cmp(config.MinProfitThreshold) < 0 {
+		return nil, fmt.Errorf("expected profit %s below minimum threshold %s", expectedProfit.String(), config.MinProfitThreshold.String())
 	}
 
 	// Get token address from bridge event
@@ -94,6 +135,8 @@ func (d *CrossLayerDetectorImpl) DetectOpportunity(ctx context.Context, bridgeEv
 		Amount:         new(big.Int).Set(bridgeEvent.Amount),
 		ExpectedProfit: expectedProfit,
 		Direction:      direction,
+		Lockup:         lockup,
+		CapitalCost:    capitalCost,
 	}
 
 	return opportunity, nil
@@ -149,21 +192,23 @@ func (d *CrossLayerDetectorImpl) ConstructBridgeTransaction(ctx context.Context,
 	var txData []byte
 	var to common.Address
 	var value *big.Int
+	var err error
 
 	switch opportunity.Direction {
 	case interfaces.DirectionL1ToL2:
-		// Construct deposit transaction
-		txData = d.constructDepositData(opportunity)
-		to = d.bridgeContract
-		value = opportunity.Amount
+		// Deposit through the L1StandardBridge
+		txData, value, err = d.constructDepositData(opportunity)
+		to = d.bridgeConfig.L1StandardBridge
 	case interfaces.DirectionL2ToL1:
-		// Construct withdrawal transaction
-		txData = d.constructWithdrawalData(opportunity)
-		to = d.bridgeContract
-		value = big.NewInt(0) // Withdrawals typically don't send ETH
+		// Withdraw through the L2StandardBridge; proving and finalizing follow on L1
+		txData, value, err = d.constructWithdrawalData(opportunity)
+		to = d.bridgeConfig.L2StandardBridge
 	default:
 		return nil, fmt.Errorf("unknown arbitrage direction: %s", opportunity.Direction)
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Estimate gas limit (simplified)
 	gasLimit := uint64(200000) // Base bridge operations typically use around 150-200k gas
@@ -174,6 +219,7 @@ func (d *CrossLayerDetectorImpl) ConstructBridgeTransaction(ctx context.Context,
 	gasPrice = gasPrice.Add(gasPrice, gasPremium)
 
 	tx := &types.Transaction{
+		From:     d.bridgeConfig.Account,
 		To:       &to,
 		Value:    value,
 		GasLimit: gasLimit,
@@ -189,18 +235,59 @@ func (d *CrossLayerDetectorImpl) ConstructBridgeTransaction(ctx context.Context,
 
 // GetConfiguration returns the current configuration
 func (d *CrossLayerDetectorImpl) GetConfiguration() *interfaces.CrossLayerConfig {
-	return d.config
+	return d.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (d *CrossLayerDetectorImpl) SetConfiguration(config *interfaces.CrossLayerConfig) {
+	d.config.Store(config)
+}
+
+// SettleWithdrawal returns the L1 transaction that moves a withdrawal forward: its
+// proof once a dispute game covers its L2 block, and its finalization once the
+// challenge window has passed. The transaction is nil while the withdrawal waits.
+func (d *CrossLayerDetectorImpl) SettleWithdrawal(ctx context.Context, l2TxHash common.Hash) (*types.Transaction, *interfaces.Withdrawal, error) {
+	if d.prover == nil {
+		return nil, nil, fmt.Errorf("no withdrawal prover configured")
+	}
+
+	withdrawal, err := d.prover.GetWithdrawal(ctx, l2TxHash)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get withdrawal: %w", err)
+	}
+
+	var tx *types.Transaction
+	switch withdrawal.Status {
+	case interfaces.WithdrawalReadyToProve:
+		tx, err = d.prover.BuildProveTransaction(ctx, withdrawal)
+	case interfaces.WithdrawalReadyToFinalize:
+		tx, err = d.prover.BuildFinalizeTransaction(ctx, withdrawal)
+	}
+	if err != nil {
+		return nil, withdrawal, fmt.Errorf("failed to settle withdrawal %s: %w", withdrawal.Hash.Hex(), err)
+	}
+
+	return tx, withdrawal, nil
 }
 
-// calculateExpectedProfit calculates the expected profit from the arbitrage
-func (d *CrossLayerDetectorImpl) calculateExpectedProfit(amount, priceGap *big.Int, direction interfaces.ArbitrageDirection) *big.Int {
-	// Calculate gross profit: (amount * priceGap) / 1000 (simplified calculation)
-	// In a real implementation, this would be more sophisticated
+// calculateExpectedProfit calculates the expected profit from the arbitrage: the
+// price gap on the amount, less the bridge fee, the cost of the capital locked up
+// while bridging and, for withdrawals, the L1 gas to prove and finalize
+func (d *CrossLayerDetectorImpl) calculateExpectedProfit(amount, l1Price, l2Price *big.Int, direction interfaces.ArbitrageDirection) *big.Int {
+	config := d.config.Load()
+	priceGap := new(big.Int).Sub(l1Price, l2Price)
+	priceGap.Abs(priceGap)
+
 	grossProfit := new(big.Int).Mul(amount, priceGap)
-	grossProfit = grossProfit.Div(grossProfit, big.NewInt(1000)) // Simplified normalization
+	grossProfit = grossProfit.Div(grossProfit, d.priceScale())
 
-	// Subtract bridge fees
-	netProfit := new(big.Int).Sub(grossProfit, d.config.BridgeFee)
+	// Subtract bridge fees and the capital cost of the lockup
+	netProfit := new(big.Int).Sub(grossProfit, config.BridgeFee)
+	_, capitalCost := d.calculateCapitalCost(amount, l1Price, l2Price, direction)
+	netProfit.Sub(netProfit, capitalCost)
+	if direction == interfaces.DirectionL2ToL1 && config.SettlementGasCost != nil {
+		netProfit.Sub(netProfit, config.SettlementGasCost)
+	}
 
 	// Ensure profit is not negative
 	if netProfit.Sign() < 0 {
@@ -210,9 +297,41 @@ func (d *CrossLayerDetectorImpl) calculateExpectedProfit(amount, priceGap *big.I
 	return netProfit
 }
 
+// calculateCapitalCost returns how long the bridged amount is locked up and what
+// holding it costs over that time, valued at the cheaper layer's price
+func (d *CrossLayerDetectorImpl) calculateCapitalCost(amount, l1Price, l2Price *big.Int, direction interfaces.ArbitrageDirection) (time.Duration, *big.Int) {
+	config := d.config.Load()
+	lockup := config.DepositDelay
+	if direction == interfaces.DirectionL2ToL1 {
+		// Wait for a dispute game to cover the withdrawal, then out the challenge window
+		lockup = config.ProvingDelay + config.ChallengeWindow
+	}
+
+	price := l1Price
+	if l2Price.Cmp(l1Price) < 0 {
+		price = l2Price
+	}
+	cost := new(big.Int).Mul(amount, price)
+	cost.Div(cost, d.priceScale())
+	cost.Mul(cost, big.NewInt(int64(config.CapitalCostBps)))
+	cost.Mul(cost, big.NewInt(int64(lockup/time.Second)))
+	cost.Div(cost, new(big.Int).Mul(big.NewInt(10000), secondsPerYear))
+
+	return lockup, cost
+}
+
+// priceScale returns the divisor turning amount × price into wei
+func (d *CrossLayerDetectorImpl) priceScale() *big.Int {
+	config := d.config.Load()
+	if config.PriceScale == nil || config.PriceScale.Sign() <= 0 {
+		return big.NewInt(1000)
+	}
+	return config.PriceScale
+}
+
 // isTokenSupported checks if a token is supported for cross-layer arbitrage
 func (d *CrossLayerDetectorImpl) isTokenSupported(token string) bool {
-	for _, supportedToken := range d.config.SupportedTokens {
+	for _, supportedToken := range d.config.Load().SupportedTokens {
 		if supportedToken == token {
 			return true
 		}
@@ -220,34 +339,40 @@ func (d *CrossLayerDetectorImpl) isTokenSupported(token string) bool {
 	return false
 }
 
-// constructDepositData constructs transaction data for a deposit (L1 to L2)
-func (d *CrossLayerDetectorImpl) constructDepositData(opportunity *interfaces.CrossLayerOpportunity) []byte {
-	// TODO: Implement proper ABI encoding for bridge deposit
-	// This would typically encode a function call like:
-	// depositETH(address _to, uint32 _minGasLimit, bytes calldata _extraData)
-	// or
-	// depositERC20(address _l1Token, address _l2Token, address _to, uint256 _amount, uint32 _minGasLimit, bytes calldata _extraData)
-	
-	// For now, return placeholder data that represents a deposit call
-	// In production, this would use the go-ethereum ABI package to encode the function call
-	return []byte("deposit_placeholder")
+// constructDepositData encodes an L1StandardBridge deposit (L1 to L2) to our account,
+// returning the calldata and the ETH to send with it
+func (d *CrossLayerDetectorImpl) constructDepositData(opportunity *interfaces.CrossLayerOpportunity) ([]byte, *big.Int, error) {
+	token, err := d.bridgeToken(opportunity)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bridge.EncodeDeposit(d.bridgeConfig, token, d.bridgeConfig.Account, opportunity.Amount)
 }
 
-// constructWithdrawalData constructs transaction data for a withdrawal (L2 to L1)
-func (d *CrossLayerDetectorImpl) constructWithdrawalData(opportunity *interfaces.CrossLayerOpportunity) []byte {
-	// TODO: Implement proper ABI encoding for bridge withdrawal
-	// This would typically encode a function call like:
-	// withdraw(address _token, uint256 _amount, uint32 _minGasLimit, bytes calldata _extraData)
-	// or for ETH:
-	// withdrawETH(uint256 _amount, uint32 _minGasLimit, bytes calldata _extraData)
-	
-	// For now, return placeholder data that represents a withdrawal call
-	// In production, this would use the go-ethereum ABI package to encode the function call
-	return []byte("withdraw_placeholder")
+// constructWithdrawalData encodes an L2StandardBridge withdrawal (L2 to L1) to our
+// account, returning the calldata and the ETH to send with it
+func (d *CrossLayerDetectorImpl) constructWithdrawalData(opportunity *interfaces.CrossLayerOpportunity) ([]byte, *big.Int, error) {
+	token, err := d.bridgeToken(opportunity)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bridge.EncodeWithdrawal(d.bridgeConfig, token, d.bridgeConfig.Account, opportunity.Amount)
+}
+
+// bridgeToken returns the L2 token an opportunity bridges
+func (d *CrossLayerDetectorImpl) bridgeToken(opportunity *interfaces.CrossLayerOpportunity) (common.Address, error) {
+	if d.bridgeConfig.Account == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("bridge account is not configured")
+	}
+	if !common.IsHexAddress(opportunity.Token) {
+		return common.Address{}, fmt.Errorf("invalid token address %q", opportunity.Token)
+	}
+	return common.HexToAddress(opportunity.Token), nil
 }
 
 // ValidateOpportunity validates a cross-layer arbitrage opportunity
 func (d *CrossLayerDetectorImpl) ValidateOpportunity(ctx context.Context, opportunity *interfaces.CrossLayerOpportunity) error {
+	config := d.config.Load()
 	if opportunity == nil {
 		return fmt.Errorf("opportunity is nil")
 	}
@@ -271,10 +396,10 @@ func (d *CrossLayerDetectorImpl) ValidateOpportunity(ctx context.Context, opport
 	}
 
 	// Recalculate expected profit with current prices
-	currentProfit := d.calculateExpectedProfit(opportunity.Amount, currentComparison.PriceGap, opportunity.Direction)
-	if currentProfit.Cmp(d.config.MinProfitThreshold) < 0 {
+	currentProfit := d.calculateExpectedProfit(opportunity.Amount, currentComparison.L1Price, currentComparison.L2Price, opportunity.Direction)
+	if currentProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return fmt.Errorf("opportunity no longer profitable: current profit %s below threshold %s",
-			currentProfit.String(), d.config.MinProfitThreshold.String())
+			currentProfit.String(), config.MinProfitThreshold.String())
 	}
 
 	return nil
@@ -323,6 +448,7 @@ func (d *CrossLayerDetectorImpl) AnalyzeBridgeEvents(ctx context.Context, bridge
 
 // FilterProfitableBridgeEvents filters bridge events that could lead to profitable arbitrage
 func (d *CrossLayerDetectorImpl) FilterProfitableBridgeEvents(ctx context.Context, bridgeEvents []*interfaces.BridgeEvent) ([]*interfaces.BridgeEvent, error) {
+	config := d.config.Load()
 	if len(bridgeEvents) == 0 {
 		return []*interfaces.BridgeEvent{}, nil
 	}
@@ -335,12 +461,12 @@ func (d *CrossLayerDetectorImpl) FilterProfitableBridgeEvents(ctx context.Contex
 		}
 
 		// Check if amount meets minimum threshold
-		if bridgeEvent.Amount.Cmp(d.config.MinAmount) < 0 {
+		if bridgeEvent.Amount.Cmp(config.MinAmount) < 0 {
 			continue
 		}
 
 		// Check if amount is within maximum threshold
-		if bridgeEvent.Amount.Cmp(d.config.MaxAmount) > 0 {
+		if bridgeEvent.Amount.Cmp(config.MaxAmount) > 0 {
 			continue
 		}
 
@@ -359,7 +485,7 @@ func (d *CrossLayerDetectorImpl) FilterProfitableBridgeEvents(ctx context.Contex
 		}
 
 		// Check if price gap meets minimum threshold
-		if priceComparison.PriceGap.Cmp(d.config.MinPriceGap) < 0 {
+		if priceComparison.PriceGap.Cmp(config.MinPriceGap) < 0 {
 			continue
 		}
 
@@ -392,7 +518,7 @@ func (d *CrossLayerDetectorImpl) EstimateArbitrageProfit(ctx context.Context, br
 	}
 
 	// Calculate expected profit
-	expectedProfit := d.calculateExpectedProfit(bridgeEvent.Amount, priceComparison.PriceGap, direction)
+	expectedProfit := d.calculateExpectedProfit(bridgeEvent.Amount, priceComparison.L1Price, priceComparison.L2Price, direction)
 
 	return expectedProfit, nil
 }
\ No newline at end of file
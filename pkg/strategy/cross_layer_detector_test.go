@@ -2,13 +2,17 @@ package strategy
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/bridge"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -71,10 +75,11 @@ func TestNewCrossLayerDetector(t *testing.T) {
 	detector := NewCrossLayerDetector(config, mockEventParser, mockPriceOracle, bridgeContract)
 
 	assert.NotNil(t, detector)
-	assert.Equal(t, config, detector.config)
+	assert.Equal(t, config, detector.config.Load())
 	assert.Equal(t, mockEventParser, detector.eventParser)
 	assert.Equal(t, mockPriceOracle, detector.priceOracle)
-	assert.Equal(t, bridgeContract, detector.bridgeContract)
+	assert.Equal(t, bridgeContract, detector.bridgeConfig.L1StandardBridge)
+	assert.Equal(t, bridge.L2StandardBridge, detector.bridgeConfig.L2StandardBridge)
 }
 
 func TestDetectOpportunity_Success(t *testing.T) {
@@ -355,11 +360,22 @@ func TestComparePrices_L2PriceError(t *testing.T) {
 	mockPriceOracle.AssertExpectations(t)
 }
 
+// bridgeAccount receives our bridged funds on both layers
+var bridgeAccount = common.HexToAddress("0xacc0000000000000000000000000000000000001")
+
+// newBridgeDetector creates a detector bridging to bridgeAccount through the Base bridge
+func newBridgeDetector(config *interfaces.CrossLayerConfig, prover interfaces.WithdrawalProver) *CrossLayerDetectorImpl {
+	bridgeConfig := bridge.DefaultBaseConfig()
+	bridgeConfig.Account = bridgeAccount
+	return NewCrossLayerDetectorWithBridge(config, nil, nil, bridgeConfig, prover)
+}
+
 func TestConstructBridgeTransaction_L1ToL2(t *testing.T) {
-	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.HexToAddress("0xbridge"))
+	detector := newBridgeDetector(&interfaces.CrossLayerConfig{}, nil)
 
 	opportunity := &interfaces.CrossLayerOpportunity{
 		Direction: interfaces.DirectionL1ToL2,
+		Token:     pricing.BaseWETH.Hex(),
 		Amount:    big.NewInt(1000),
 	}
 
@@ -367,18 +383,20 @@ func TestConstructBridgeTransaction_L1ToL2(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, tx)
-	assert.Equal(t, common.HexToAddress("0xbridge"), *tx.To)
-	assert.Equal(t, big.NewInt(1000), tx.Value)
+	assert.Equal(t, bridge.BaseL1StandardBridge, *tx.To)
+	assert.Equal(t, bridgeAccount, tx.From)
+	assert.Equal(t, big.NewInt(1000), tx.Value) // ETH is deposited as value
 	assert.Equal(t, uint64(200000), tx.GasLimit)
 	assert.True(t, tx.GasPrice.Cmp(big.NewInt(0)) > 0)
 	assert.Equal(t, tx, opportunity.BridgeTx)
 }
 
 func TestConstructBridgeTransaction_L2ToL1(t *testing.T) {
-	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.HexToAddress("0xbridge"))
+	detector := newBridgeDetector(&interfaces.CrossLayerConfig{}, nil)
 
 	opportunity := &interfaces.CrossLayerOpportunity{
 		Direction: interfaces.DirectionL2ToL1,
+		Token:     pricing.BaseCbETH.Hex(),
 		Amount:    big.NewInt(1000),
 	}
 
@@ -386,11 +404,27 @@ func TestConstructBridgeTransaction_L2ToL1(t *testing.T) {
 
 	require.NoError(t, err)
 	assert.NotNil(t, tx)
-	assert.Equal(t, common.HexToAddress("0xbridge"), *tx.To)
-	assert.Equal(t, big.NewInt(0), tx.Value) // Withdrawals don't send ETH
+	assert.Equal(t, bridge.L2StandardBridge, *tx.To)
+	assert.Equal(t, big.NewInt(0), tx.Value) // ERC-20 withdrawals don't send ETH
 	assert.Equal(t, uint64(200000), tx.GasLimit)
 }
 
+func TestConstructBridgeTransaction_RequiresAccount(t *testing.T) {
+	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.Address{})
+
+	opportunity := &interfaces.CrossLayerOpportunity{
+		Direction: interfaces.DirectionL1ToL2,
+		Token:     pricing.BaseWETH.Hex(),
+		Amount:    big.NewInt(1000),
+	}
+
+	tx, err := detector.ConstructBridgeTransaction(context.Background(), opportunity)
+
+	assert.Error(t, err)
+	assert.Nil(t, tx)
+	assert.Contains(t, err.Error(), "bridge account is not configured")
+}
+
 func TestConstructBridgeTransaction_NilOpportunity(t *testing.T) {
 	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.Address{})
 
@@ -524,19 +558,67 @@ func TestValidateOpportunity_NolongerProfitable(t *testing.T) {
 
 func TestCalculateExpectedProfit(t *testing.T) {
 	config := &interfaces.CrossLayerConfig{
-		BridgeFee: big.NewInt(100),
+		BridgeFee:         big.NewInt(1e15),
+		PriceScale:        big.NewInt(1e18),
+		DepositDelay:      3 * time.Minute,
+		ProvingDelay:      time.Hour,
+		ChallengeWindow:   7 * 24 * time.Hour,
+		CapitalCostBps:    1000,
+		SettlementGasCost: big.NewInt(1e16),
 	}
 
 	detector := NewCrossLayerDetector(config, nil, nil, common.Address{})
 
-	amount := big.NewInt(10000)
-	priceGap := big.NewInt(200)
+	// 100 tokens priced at 1 ETH on one layer and 1.01 ETH on the other
+	amount := new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18))
+	low, high := big.NewInt(1e18), big.NewInt(101e16)
+	yearly := new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)) // 10% of 100 ETH
+
+	tests := []struct {
+		name           string
+		l1Price        *big.Int
+		l2Price        *big.Int
+		direction      interfaces.ArbitrageDirection
+		expectedLockup time.Duration
+	}{
+		{
+			name:           "deposits lock capital until credited",
+			l1Price:        high,
+			l2Price:        low,
+			direction:      interfaces.DirectionL1ToL2,
+			expectedLockup: 3 * time.Minute,
+		},
+		{
+			name:           "withdrawals lock capital through proving and the challenge window",
+			l1Price:        low,
+			l2Price:        high,
+			direction:      interfaces.DirectionL2ToL1,
+			expectedLockup: 169 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lockup, capitalCost := detector.calculateCapitalCost(amount, tt.l1Price, tt.l2Price, tt.direction)
+			assert.Equal(t, tt.expectedLockup, lockup)
+
+			expectedCost := new(big.Int).Mul(yearly, big.NewInt(int64(tt.expectedLockup/time.Second)))
+			expectedCost.Div(expectedCost, big.NewInt(365*24*60*60))
+			assert.Equal(t, expectedCost, capitalCost)
 
-	profit := detector.calculateExpectedProfit(amount, priceGap, interfaces.DirectionL1ToL2)
+			// 1 ETH gross, less the bridge fee, the capital cost and any settlement gas
+			expected := new(big.Int).Sub(big.NewInt(1e18), config.BridgeFee)
+			expected.Sub(expected, capitalCost)
+			if tt.direction == interfaces.DirectionL2ToL1 {
+				expected.Sub(expected, config.SettlementGasCost)
+			}
+			assert.Equal(t, expected, detector.calculateExpectedProfit(amount, tt.l1Price, tt.l2Price, tt.direction))
+		})
+	}
 
-	// Expected: (10000 * 200) / 1e18 - 100 = 0 - 100 = 0 (clamped to 0)
-	// Note: This is a simplified calculation for testing
-	assert.True(t, profit.Cmp(big.NewInt(0)) >= 0)
+	// A week's lockup at 10% a year outweighs a 0.1% gap
+	profit := detector.calculateExpectedProfit(amount, low, big.NewInt(1001e15), interfaces.DirectionL2ToL1)
+	assert.Equal(t, 0, profit.Sign())
 }
 
 func TestIsTokenSupported(t *testing.T) {
@@ -709,27 +791,96 @@ func TestEstimateArbitrageProfit_NilEvent(t *testing.T) {
 }
 
 func TestConstructDepositData(t *testing.T) {
-	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.Address{})
+	detector := newBridgeDetector(&interfaces.CrossLayerConfig{}, nil)
 
 	opportunity := &interfaces.CrossLayerOpportunity{
 		Direction: interfaces.DirectionL1ToL2,
+		Token:     pricing.BaseUSDbC.Hex(),
+		Amount:    big.NewInt(5e6),
 	}
 
-	data := detector.constructDepositData(opportunity)
+	data, value, err := detector.constructDepositData(opportunity)
 
-	assert.NotNil(t, data)
-	assert.Equal(t, []byte("deposit_placeholder"), data)
+	require.NoError(t, err)
+	expected, _, err := bridge.EncodeDeposit(detector.bridgeConfig, pricing.BaseUSDbC, bridgeAccount, big.NewInt(5e6))
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+	assert.Equal(t, 0, value.Sign())
+
+	opportunity.Token = "not a token"
+	_, _, err = detector.constructDepositData(opportunity)
+	assert.Error(t, err)
 }
 
 func TestConstructWithdrawalData(t *testing.T) {
-	detector := NewCrossLayerDetector(&interfaces.CrossLayerConfig{}, nil, nil, common.Address{})
+	detector := newBridgeDetector(&interfaces.CrossLayerConfig{}, nil)
 
 	opportunity := &interfaces.CrossLayerOpportunity{
 		Direction: interfaces.DirectionL2ToL1,
+		Token:     pricing.BaseWETH.Hex(),
+		Amount:    big.NewInt(1e18),
 	}
 
-	data := detector.constructWithdrawalData(opportunity)
+	data, value, err := detector.constructWithdrawalData(opportunity)
+
+	require.NoError(t, err)
+	expected, _, err := bridge.EncodeWithdrawal(detector.bridgeConfig, pricing.BaseWETH, bridgeAccount, big.NewInt(1e18))
+	require.NoError(t, err)
+	assert.Equal(t, expected, data)
+	assert.Equal(t, big.NewInt(1e18), value)
+}
+
+// fakeProver serves one withdrawal and records the settlement transactions built for it
+type fakeProver struct {
+	withdrawal *interfaces.Withdrawal
+	built      []string
+}
+
+func (f *fakeProver) GetWithdrawal(ctx context.Context, l2TxHash common.Hash) (*interfaces.Withdrawal, error) {
+	if l2TxHash != f.withdrawal.L2TxHash {
+		return nil, fmt.Errorf("transaction %s not found", l2TxHash.Hex())
+	}
+	return f.withdrawal, nil
+}
 
-	assert.NotNil(t, data)
-	assert.Equal(t, []byte("withdraw_placeholder"), data)
-}
\ No newline at end of file
+func (f *fakeProver) BuildProveTransaction(ctx context.Context, withdrawal *interfaces.Withdrawal) (*types.Transaction, error) {
+	f.built = append(f.built, "prove")
+	return &types.Transaction{To: &bridge.BaseOptimismPortal}, nil
+}
+
+func (f *fakeProver) BuildFinalizeTransaction(ctx context.Context, withdrawal *interfaces.Withdrawal) (*types.Transaction, error) {
+	f.built = append(f.built, "finalize")
+	return &types.Transaction{To: &bridge.BaseOptimismPortal}, nil
+}
+
+func TestSettleWithdrawal(t *testing.T) {
+	l2TxHash := common.HexToHash("0xa1")
+
+	tests := []struct {
+		status   interfaces.WithdrawalStatus
+		expected []string
+	}{
+		{status: interfaces.WithdrawalWaitingToProve},
+		{status: interfaces.WithdrawalReadyToProve, expected: []string{"prove"}},
+		{status: interfaces.WithdrawalProven},
+		{status: interfaces.WithdrawalReadyToFinalize, expected: []string{"finalize"}},
+		{status: interfaces.WithdrawalFinalized},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			prover := &fakeProver{withdrawal: &interfaces.Withdrawal{L2TxHash: l2TxHash, Status: tt.status}}
+			detector := newBridgeDetector(nil, prover)
+
+			tx, withdrawal, err := detector.SettleWithdrawal(context.Background(), l2TxHash)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, withdrawal.Status)
+			assert.Equal(t, tt.expected, prover.built)
+			assert.Equal(t, tt.expected != nil, tx != nil, "only ready withdrawals have a transaction to send")
+		})
+	}
+
+	_, _, err := NewCrossLayerDetector(nil, nil, nil, common.Address{}).SettleWithdrawal(context.Background(), l2TxHash)
+	assert.Error(t, err, "settling requires a prover")
+}
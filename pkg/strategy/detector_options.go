@@ -0,0 +1,67 @@
+package strategy
+
+import (
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// DetectorOption sets an optional dependency of a strategy detector. Each detector
+// uses the dependencies it supports and ignores the rest.
+type DetectorOption func(*detectorDeps)
+
+// detectorDeps are the optional dependencies set by detector options
+type detectorDeps struct {
+	safety       interfaces.TokenSafetyChecker
+	poolRegistry interfaces.PoolRegistry
+	builder      interfaces.TransactionBuilder
+	adapters     interfaces.ProtocolAdapterRegistry
+	capital      interfaces.CapitalSource
+}
+
+// WithSafetyChecker makes the sandwich detector skip swaps through fee-on-transfer
+// or unsafe tokens, as the back-run sell would lose the tax or revert
+func WithSafetyChecker(safety interfaces.TokenSafetyChecker) DetectorOption {
+	return func(deps *detectorDeps) {
+		deps.safety = safety
+	}
+}
+
+// WithPoolRegistry makes the backrun detector source alternative pools from the
+// registry, considering only pools above its TVL floor
+func WithPoolRegistry(poolRegistry interfaces.PoolRegistry) DetectorOption {
+	return func(deps *detectorDeps) {
+		deps.poolRegistry = poolRegistry
+	}
+}
+
+// WithTransactionBuilder makes detectors build their trades as executor contract
+// calls from the builder's searcher account
+func WithTransactionBuilder(builder interfaces.TransactionBuilder) DetectorOption {
+	return func(deps *detectorDeps) {
+		deps.builder = builder
+	}
+}
+
+// WithAdapters sets the protocol adapters the frontrun detector finds the target's
+// swaps with
+func WithAdapters(adapters interfaces.ProtocolAdapterRegistry) DetectorOption {
+	return func(deps *detectorDeps) {
+		deps.adapters = adapters
+	}
+}
+
+// WithCapitalSource makes detectors size trades within the capital available in
+// the token they sell
+func WithCapitalSource(capital interfaces.CapitalSource) DetectorOption {
+	return func(deps *detectorDeps) {
+		deps.capital = capital
+	}
+}
+
+// applyDetectorOptions collects the dependencies the options set
+func applyDetectorOptions(options []DetectorOption) detectorDeps {
+	var deps detectorDeps
+	for _, option := range options {
+		option(&deps)
+	}
+	return deps
+}
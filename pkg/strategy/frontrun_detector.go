@@ -5,20 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 )
 
 // frontrunDetector implements the FrontrunDetector interface
 type frontrunDetector struct {
-	config *interfaces.FrontrunConfig
+	config   atomic.Pointer[interfaces.FrontrunConfig]
+	builder  interfaces.TransactionBuilder
+	adapters interfaces.ProtocolAdapterRegistry
+	capital  interfaces.CapitalSource
 }
 
-// NewFrontrunDetector creates a new frontrun detector with the given configuration
-func NewFrontrunDetector(config *interfaces.FrontrunConfig) interfaces.FrontrunDetector {
+// NewFrontrunDetector creates a new frontrun detector with the given configuration.
+// Options add a transaction builder, the adapters it finds the target's swaps with
+// and a capital source.
+func NewFrontrunDetector(config *interfaces.FrontrunConfig, options ...DetectorOption) interfaces.FrontrunDetector {
 	if config == nil {
 		config = &interfaces.FrontrunConfig{
 			MinTxValue:            big.NewInt(50000),  // $500 minimum transaction value
@@ -27,15 +34,21 @@ func NewFrontrunDetector(config *interfaces.FrontrunConfig) interfaces.FrontrunD
 			MinProfitThreshold:    big.NewInt(100),   // $100 minimum profit
 		}
 	}
-	return &frontrunDetector{
-		config: config,
+	deps := applyDetectorOptions(options)
+	detector := &frontrunDetector{
+		builder:  deps.builder,
+		adapters: deps.adapters,
+		capital:  deps.capital,
 	}
+	detector.config.Store(config)
+	return detector
 }
 
 // DetectOpportunity analyzes a transaction to identify frontrun opportunities
 func (f *frontrunDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) (*interfaces.FrontrunOpportunity, error) {
+	config := f.config.Load()
 	// Check if transaction meets minimum value threshold
-	if !tx.IsHighValue(f.config.MinTxValue) {
+	if !tx.IsHighValue(config.MinTxValue) {
 		return nil, nil // Transaction value too low for profitable frontrun
 	}
 
@@ -68,7 +81,7 @@ func (f *frontrunDetector) DetectOpportunity(ctx context.Context, tx *types.Tran
 
 	// Calculate gas premium
 	gasPremium := new(big.Int).Sub(optimalGasPrice, tx.GasPrice)
-	if gasPremium.Cmp(f.config.MaxGasPremium) > 0 {
+	if gasPremium.Cmp(config.MaxGasPremium) > 0 {
 		return nil, nil // Gas premium too high, not profitable
 	}
 	
@@ -79,21 +92,35 @@ func (f *frontrunDetector) DetectOpportunity(ctx context.Context, tx *types.Tran
 
 	// Estimate profit from frontrunning
 	expectedProfit := f.estimateFrontrunProfit(tx, frontrunPotential, gasPremium)
-	if expectedProfit.Cmp(f.config.MinProfitThreshold) < 0 {
+	if expectedProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return nil, nil // Expected profit below threshold
 	}
 
 	// Calculate success probability
 	successProbability := f.calculateSuccessProbability(tx, optimalGasPrice, frontrunPotential)
-	if successProbability < f.config.MinSuccessProbability {
+	if successProbability < config.MinSuccessProbability {
 		return nil, nil // Success probability too low
 	}
 
 	// Construct frontrun transaction
-	frontrunTx, err := f.constructFrontrunTransaction(tx, optimalGasPrice, frontrunPotential)
+	var (
+		frontrunTx *types.Transaction
+		tokenIn    common.Address
+	)
+	if f.builder != nil {
+		frontrunTx, tokenIn, expectedProfit, err = f.buildFrontrunTransaction(ctx, tx, optimalGasPrice, simResult, expectedProfit)
+	} else {
+		frontrunTx, err = f.constructFrontrunTransaction(tx, optimalGasPrice, frontrunPotential)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to construct frontrun transaction: %w", err)
 	}
+	if frontrunTx == nil {
+		return nil, nil // No swaps to repeat
+	}
+	if expectedProfit.Cmp(config.MinProfitThreshold) < 0 {
+		return nil, nil // Too little capital to clear the threshold
+	}
 
 	opportunity := &interfaces.FrontrunOpportunity{
 		TargetTx:           tx,
@@ -102,12 +129,16 @@ func (f *frontrunDetector) DetectOpportunity(ctx context.Context, tx *types.Tran
 		GasPremium:         gasPremium,
 		SuccessProbability: successProbability,
 	}
+	if tokenIn != (common.Address{}) {
+		opportunity.TokenIn = tokenIn.Hex()
+	}
 
 	return opportunity, nil
 }
 
 // CalculateOptimalGasPrice calculates the optimal gas price for frontrunning
 func (f *frontrunDetector) CalculateOptimalGasPrice(ctx context.Context, targetTx *types.Transaction) (*big.Int, error) {
+	config := f.config.Load()
 	if targetTx == nil {
 		return nil, errors.New("target transaction cannot be nil")
 	}
@@ -123,8 +154,8 @@ func (f *frontrunDetector) CalculateOptimalGasPrice(ctx context.Context, targetT
 	gasPremium = gasPremium.Div(gasPremium, big.NewInt(100))
 
 	// Ensure gas premium doesn't exceed maximum
-	if gasPremium.Cmp(f.config.MaxGasPremium) > 0 {
-		gasPremium = new(big.Int).Set(f.config.MaxGasPremium)
+	if gasPremium.Cmp(config.MaxGasPremium) > 0 {
+		gasPremium = new(big.Int).Set(config.MaxGasPremium)
 	}
 
 	// Calculate optimal gas price
@@ -135,22 +166,23 @@ func (f *frontrunDetector) CalculateOptimalGasPrice(ctx context.Context, targetT
 
 // ValidateProfitability validates that a frontrun opportunity is still profitable
 func (f *frontrunDetector) ValidateProfitability(ctx context.Context, opportunity *interfaces.FrontrunOpportunity) error {
+	config := f.config.Load()
 	if opportunity == nil {
 		return errors.New("opportunity cannot be nil")
 	}
 
 	// Check if expected profit meets minimum threshold
-	if opportunity.ExpectedProfit.Cmp(f.config.MinProfitThreshold) < 0 {
+	if opportunity.ExpectedProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return errors.New("expected profit below minimum threshold")
 	}
 
 	// Check if gas premium is within acceptable range
-	if opportunity.GasPremium.Cmp(f.config.MaxGasPremium) > 0 {
+	if opportunity.GasPremium.Cmp(config.MaxGasPremium) > 0 {
 		return errors.New("gas premium exceeds maximum allowed")
 	}
 
 	// Check if success probability meets minimum threshold
-	if opportunity.SuccessProbability < f.config.MinSuccessProbability {
+	if opportunity.SuccessProbability < config.MinSuccessProbability {
 		return errors.New("success probability below minimum threshold")
 	}
 
@@ -173,7 +205,12 @@ func (f *frontrunDetector) ValidateProfitability(ctx context.Context, opportunit
 
 // GetConfiguration returns the current frontrun detector configuration
 func (f *frontrunDetector) GetConfiguration() *interfaces.FrontrunConfig {
-	return f.config
+	return f.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (f *frontrunDetector) SetConfiguration(config *interfaces.FrontrunConfig) {
+	f.config.Store(config)
 }
 
 // isFrontrunnable checks if a transaction type can be frontrun
@@ -513,6 +550,56 @@ func (f *frontrunDetector) constructFrontrunTransaction(targetTx *types.Transact
 	return frontrunTx, nil
 }
 
+// buildFrontrunTransaction repeats the target's swap route with the same input through
+// the transaction builder, returning it with the token it sells, or returns nil if the
+// target's logs hold no swaps. Pools come from the Swap logs, in order; the token sold
+// is the first one the sender transferred out, or WETH for swaps paid in ETH. An input
+// cut to the capital available scales the expected profit returned down with it.
+func (f *frontrunDetector) buildFrontrunTransaction(ctx context.Context, targetTx *types.Transaction, gasPrice *big.Int, simResult *interfaces.SimulationResult, expectedProfit *big.Int) (*types.Transaction, common.Address, *big.Int, error) {
+	if f.adapters == nil {
+		return nil, common.Address{}, nil, nil
+	}
+
+	var (
+		hops     []interfaces.SwapHop
+		tokenIn  common.Address
+		amountIn *big.Int
+	)
+	for _, log := range simResult.Logs {
+		if tokenIn == (common.Address{}) && len(log.Topics) == 3 && log.Topics[0] == erc20TransferTopic &&
+			common.BytesToAddress(log.Topics[1].Bytes()) == targetTx.From && len(log.Data) == 32 {
+			tokenIn, amountIn = log.Address, new(big.Int).SetBytes(log.Data)
+			continue
+		}
+		event, err := f.adapters.DecodeLog(log)
+		if err != nil || event == nil || event.SwapEvent == nil {
+			continue
+		}
+		hops = append(hops, interfaces.SwapHop{Pool: event.SwapEvent.Pool, Protocol: event.SwapEvent.Protocol})
+	}
+	if tokenIn == (common.Address{}) && targetTx.Value != nil && targetTx.Value.Sign() > 0 {
+		tokenIn, amountIn = pricing.BaseWETH, new(big.Int).Set(targetTx.Value)
+	}
+	if len(hops) == 0 || tokenIn == (common.Address{}) || amountIn.Sign() <= 0 {
+		return nil, common.Address{}, nil, nil
+	}
+	capped := capToCapital(f.capital, tokenIn, amountIn)
+	if capped.Sign() <= 0 {
+		return nil, common.Address{}, nil, nil
+	}
+	hops[0].TokenIn = tokenIn
+
+	transactions, err := f.builder.BuildSwaps(ctx, []*interfaces.SwapRoute{{
+		Hops:     hops,
+		AmountIn: capped,
+		GasPrice: gasPrice,
+	}}, targetTx.ChainID)
+	if err != nil {
+		return nil, common.Address{}, nil, err
+	}
+	return transactions[0], tokenIn, scaleToCapital(expectedProfit, capped, amountIn), nil
+}
+
 // calculateFrontrunAmount calculates the optimal amount for frontrun transaction
 func (f *frontrunDetector) calculateFrontrunAmount(targetTx *types.Transaction, potential *frontrunPotential) *big.Int {
 	// For most frontrun strategies, use a similar or slightly smaller amount
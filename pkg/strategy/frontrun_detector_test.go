@@ -9,6 +9,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -784,4 +786,75 @@ func TestFrontrunDetector_Integration(t *testing.T) {
 	assert.Greater(t, opportunity.GasPremium.Int64(), int64(0))
 	assert.Greater(t, opportunity.SuccessProbability, 0.0)
 	assert.LessOrEqual(t, opportunity.SuccessProbability, 1.0)
-}
\ No newline at end of file
+}
+func TestFrontrunDetector_DetectOpportunityWithBuilder(t *testing.T) {
+	pool := common.HexToAddress("0x000000000000000000000000000000000000a001")
+	adapters := protocols.NewRegistry(nil)
+	require.NoError(t, adapters.Register(protocols.NewSushiSwapV2Adapter(protocols.BaseSushiSwapV2Router)))
+	adapters.RegisterPool(pool, interfaces.ProtocolSushiSwapV2)
+
+	builder := &recordingBuilder{searcher: common.HexToAddress("0x5ea4c4e4"), nonce: 4}
+	detector := NewFrontrunDetector(&interfaces.FrontrunConfig{
+		MinTxValue:            big.NewInt(50000),
+		MaxGasPremium:         big.NewInt(10000000000),
+		MinSuccessProbability: 0.5,
+		MinProfitThreshold:    big.NewInt(10),
+	}, WithTransactionBuilder(builder), WithAdapters(adapters))
+
+	// A Sushi V2 swap of 1e6 wei of WETH paid in ETH
+	amounts := make([]byte, 128)
+	big.NewInt(1000000).FillBytes(amounts[0:32])
+	big.NewInt(3000).FillBytes(amounts[96:128])
+	swapLog := &ethtypes.Log{
+		Address: pool,
+		Topics: []common.Hash{
+			common.HexToHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822"),
+			common.BytesToHash(common.HexToAddress("0x7a7a").Bytes()),
+			common.BytesToHash(common.HexToAddress("0x1").Bytes()),
+		},
+		Data: amounts,
+	}
+	tx := &types.Transaction{
+		From:     common.HexToAddress("0x1"),
+		To:       &common.Address{},
+		Value:    big.NewInt(1000000),
+		GasPrice: big.NewInt(10000000000),
+		GasLimit: 200000,
+		Data:     common.Hex2Bytes("7ff36ab5"), // swapExactETHForTokens
+		ChainID:  big.NewInt(8453),
+	}
+
+	opportunity, err := detector.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{swapLog}})
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.Equal(t, builder.searcher, opportunity.FrontrunTx.From)
+	assert.Equal(t, uint64(4), opportunity.FrontrunTx.Nonce)
+
+	require.Len(t, builder.routes, 1)
+	route := builder.routes[0]
+	assert.Equal(t, []interfaces.SwapHop{{Pool: pool, Protocol: interfaces.ProtocolSushiSwapV2, TokenIn: pricing.BaseWETH}}, route.Hops)
+	assert.Equal(t, big.NewInt(1000000), route.AmountIn)
+	assert.Equal(t, opportunity.FrontrunTx.GasPrice, route.GasPrice)
+	expectedProfit := opportunity.ExpectedProfit
+
+	// Without decodable swaps there's nothing to repeat
+	opportunity, err = detector.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+
+	// With a quarter of the input available, the swap and its profit shrink to a quarter
+	capped := NewFrontrunDetector(detector.(*frontrunDetector).config.Load(), WithTransactionBuilder(builder), WithAdapters(adapters),
+		WithCapitalSource(fixedCapital{pricing.BaseWETH: big.NewInt(250000)}))
+	opportunity, err = capped.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{swapLog}})
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.Equal(t, big.NewInt(250000), builder.routes[0].AmountIn)
+	assert.Equal(t, new(big.Int).Div(expectedProfit, big.NewInt(4)), opportunity.ExpectedProfit)
+
+	// Capital too small to clear the profit threshold leaves nothing to do
+	capped = NewFrontrunDetector(detector.(*frontrunDetector).config.Load(), WithTransactionBuilder(builder), WithAdapters(adapters),
+		WithCapitalSource(fixedCapital{pricing.BaseWETH: big.NewInt(1)}))
+	opportunity, err = capped.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{swapLog}})
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+}
@@ -0,0 +1,515 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// uniswapV3PoolABI covers the pool's Swap event and the calls a JIT position makes
+const uniswapV3PoolABI = `[
+	{"anonymous":false,"inputs":[
+		{"indexed":true,"name":"sender","type":"address"},
+		{"indexed":true,"name":"recipient","type":"address"},
+		{"indexed":false,"name":"amount0","type":"int256"},
+		{"indexed":false,"name":"amount1","type":"int256"},
+		{"indexed":false,"name":"sqrtPriceX96","type":"uint160"},
+		{"indexed":false,"name":"liquidity","type":"uint128"},
+		{"indexed":false,"name":"tick","type":"int24"}
+	],"name":"Swap","type":"event"},
+	{"inputs":[
+		{"name":"recipient","type":"address"},
+		{"name":"tickLower","type":"int24"},
+		{"name":"tickUpper","type":"int24"},
+		{"name":"amount","type":"uint128"},
+		{"name":"data","type":"bytes"}
+	],"name":"mint","outputs":[{"name":"amount0","type":"uint256"},{"name":"amount1","type":"uint256"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[
+		{"name":"tickLower","type":"int24"},
+		{"name":"tickUpper","type":"int24"},
+		{"name":"amount","type":"uint128"}
+	],"name":"burn","outputs":[{"name":"amount0","type":"uint256"},{"name":"amount1","type":"uint256"}],"stateMutability":"nonpayable","type":"function"},
+	{"inputs":[
+		{"name":"recipient","type":"address"},
+		{"name":"tickLower","type":"int24"},
+		{"name":"tickUpper","type":"int24"},
+		{"name":"amount0Requested","type":"uint128"},
+		{"name":"amount1Requested","type":"uint128"}
+	],"name":"collect","outputs":[{"name":"amount0","type":"uint128"},{"name":"amount1","type":"uint128"}],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var (
+	uniswapV3Pool = func() abi.ABI {
+		parsed, err := abi.JSON(strings.NewReader(uniswapV3PoolABI))
+		if err != nil {
+			panic(fmt.Sprintf("invalid Uniswap V3 pool ABI: %v", err))
+		}
+		return parsed
+	}()
+
+	// jitCapitalFractions are the position sizes tried, as fractions of MaxCapital
+	jitCapitalFractions = []*big.Rat{big.NewRat(1, 4), big.NewRat(1, 2), big.NewRat(1, 1)}
+
+	jitFeeDenominator = big.NewInt(1_000_000)
+	jitQ96            = new(big.Int).Lsh(big.NewInt(1), 96)
+	jitQ192           = new(big.Int).Lsh(big.NewInt(1), 192)
+	maxUint128        = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+)
+
+// jitDetector implements the JITDetector interface
+type jitDetector struct {
+	config      atomic.Pointer[interfaces.JITConfig]
+	pools       interfaces.PoolRegistry
+	priceOracle interfaces.PriceOracle
+	builder     interfaces.TransactionBuilder
+}
+
+// jitSwap is a Uniswap V3 swap reconstructed from its Swap event
+type jitSwap struct {
+	pool          *interfaces.PoolInfo
+	zeroForOne    bool
+	amountIn      *big.Int
+	sqrtPricePre  *big.Int // Before the swap, derived from the post-swap state
+	sqrtPricePost *big.Int
+	liquidity     *big.Int
+	tick          int32 // Tick before the swap
+}
+
+// NewJITDetector creates a just-in-time liquidity detector. The pool registry
+// supplies the fee, tokens and tick spacing of swapped pools. The price oracle
+// values pools that don't trade against WETH and is optional. The transaction
+// builder option makes the executor contract's calls; without one no
+// opportunities are reported.
+func NewJITDetector(config *interfaces.JITConfig, pools interfaces.PoolRegistry, priceOracle interfaces.PriceOracle, options ...DetectorOption) interfaces.JITDetector {
+	if config == nil {
+		config = &interfaces.JITConfig{
+			MinSwapAmount:      new(big.Int).Mul(big.NewInt(20), big.NewInt(1e18)),  // 20 ETH
+			MaxCapital:         new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), // 100 ETH
+			TickRangeWidth:     1,
+			InventoryRiskBps:   10,
+			GasLimit:           450000,           // Mint initializes both ticks; burn and collect clear them
+			MinProfitThreshold: big.NewInt(5e15), // 0.005 ETH
+		}
+	}
+	deps := applyDetectorOptions(options)
+	detector := &jitDetector{
+		pools:       pools,
+		priceOracle: priceOracle,
+		builder:     deps.builder,
+	}
+	detector.config.Store(config)
+	return detector
+}
+
+// DetectOpportunity looks for a large swap on a known Uniswap V3 pool in the
+// simulation logs and sizes the most profitable position minted around the
+// pre-swap tick. The pre-swap price is derived from the Swap event assuming the
+// swap stayed within the liquidity it ended in, as for concentrated quotes.
+func (j *jitDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) (*interfaces.JITOpportunity, error) {
+	config := j.config.Load()
+	if simResult == nil || !simResult.Success || j.pools == nil || j.builder == nil {
+		return nil, nil
+	}
+
+	gasPrice := big.NewInt(0)
+	if tx.GasPrice != nil {
+		gasPrice = tx.GasPrice
+	}
+	gasCost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(config.GasLimit))
+
+	var best *interfaces.JITOpportunity
+	for _, log := range simResult.Logs {
+		swap, err := j.decodeSwap(log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode swap on %s: %w", log.Address.Hex(), err)
+		}
+		if swap == nil {
+			continue
+		}
+
+		opportunity, err := j.evaluate(swap)
+		if err != nil {
+			// Pools that can't be valued in ETH are skipped
+			continue
+		}
+		if opportunity == nil {
+			continue
+		}
+
+		netProfit := new(big.Int).Sub(opportunity.ExpectedProfit, gasCost)
+		if netProfit.Cmp(config.MinProfitThreshold) < 0 {
+			continue
+		}
+		if best == nil || opportunity.ExpectedProfit.Cmp(best.ExpectedProfit) > 0 {
+			opportunity.TargetTx = tx
+			best = opportunity
+		}
+	}
+
+	if best == nil {
+		return nil, nil
+	}
+	if err := j.buildTransactions(ctx, best, gasPrice); err != nil {
+		return nil, err
+	}
+	return best, nil
+}
+
+// ConstructBundle orders the mint before the target swap and the burn and
+// collect after it
+func (j *jitDetector) ConstructBundle(ctx context.Context, opportunity *interfaces.JITOpportunity) ([]*types.Transaction, error) {
+	if opportunity == nil {
+		return nil, fmt.Errorf("opportunity cannot be nil")
+	}
+	if opportunity.TargetTx == nil {
+		return nil, fmt.Errorf("target transaction is required")
+	}
+	if opportunity.MintTx == nil || opportunity.BurnTx == nil || opportunity.CollectTx == nil {
+		gasPrice := opportunity.TargetTx.GasPrice
+		if gasPrice == nil {
+			gasPrice = big.NewInt(0)
+		}
+		if err := j.buildTransactions(ctx, opportunity, gasPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*types.Transaction{opportunity.MintTx, opportunity.TargetTx, opportunity.BurnTx, opportunity.CollectTx}, nil
+}
+
+// GetConfiguration returns the current configuration
+func (j *jitDetector) GetConfiguration() *interfaces.JITConfig {
+	return j.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (j *jitDetector) SetConfiguration(config *interfaces.JITConfig) {
+	j.config.Store(config)
+}
+
+// decodeSwap returns the swap of a Uniswap V3 Swap log from a registered pool,
+// or nil for any other log
+func (j *jitDetector) decodeSwap(log *ethtypes.Log) (*jitSwap, error) {
+	swapEvent := uniswapV3Pool.Events["Swap"]
+	if len(log.Topics) == 0 || log.Topics[0] != swapEvent.ID {
+		return nil, nil
+	}
+
+	// SushiSwap V3 pools are Uniswap V3 deployments; PancakeSwap V3 emits a different Swap
+	pool, known := j.pools.GetPool(log.Address)
+	if !known || (pool.Protocol != interfaces.ProtocolUniswapV3 && pool.Protocol != interfaces.ProtocolSushiSwapV3) {
+		return nil, nil
+	}
+
+	decoded := make(map[string]interface{})
+	if err := uniswapV3Pool.UnpackIntoMap(decoded, "Swap", log.Data); err != nil {
+		return nil, err
+	}
+	amount0, _ := decoded["amount0"].(*big.Int)
+	amount1, _ := decoded["amount1"].(*big.Int)
+	sqrtPrice, _ := decoded["sqrtPriceX96"].(*big.Int)
+	liquidity, _ := decoded["liquidity"].(*big.Int)
+	if amount0 == nil || amount1 == nil || sqrtPrice == nil || liquidity == nil || liquidity.Sign() <= 0 {
+		return nil, nil
+	}
+
+	swap := &jitSwap{pool: pool, sqrtPricePost: sqrtPrice, liquidity: liquidity}
+	if amount0.Sign() > 0 && amount1.Sign() < 0 {
+		// Token1 paid out moved the price down: sqrtPre = sqrtPost + out * Q96 / L
+		swap.zeroForOne = true
+		swap.amountIn = amount0
+		delta := new(big.Int).Mul(new(big.Int).Neg(amount1), jitQ96)
+		swap.sqrtPricePre = delta.Div(delta, liquidity).Add(delta, sqrtPrice)
+	} else if amount1.Sign() > 0 && amount0.Sign() < 0 {
+		// Token0 paid out moved the price up: sqrtPre = L * Q96 * sqrtPost / (L * Q96 + out * sqrtPost)
+		swap.amountIn = amount1
+		numerator := new(big.Int).Mul(liquidity, jitQ96)
+		denominator := new(big.Int).Add(numerator, new(big.Int).Mul(new(big.Int).Neg(amount0), sqrtPrice))
+		numerator.Mul(numerator, sqrtPrice)
+		swap.sqrtPricePre = numerator.Div(numerator, denominator)
+	} else {
+		return nil, nil
+	}
+
+	tick, err := protocols.TickAtSqrtPrice(swap.sqrtPricePre)
+	if err != nil {
+		return nil, err
+	}
+	swap.tick = tick
+	return swap, nil
+}
+
+// evaluate sizes a position around the pre-swap tick, trying fractions of the
+// capital budget, and returns the most profitable one before gas
+func (j *jitDetector) evaluate(swap *jitSwap) (*interfaces.JITOpportunity, error) {
+	config := j.config.Load()
+	swapValue, err := j.valueETH(swap.pool, j.tokenInValue(swap.zeroForOne, swap.amountIn, swap.pricePre()), swap.pricePre())
+	if err != nil {
+		return nil, err
+	}
+	if swapValue.Cmp(config.MinSwapAmount) < 0 {
+		return nil, nil
+	}
+
+	tickLower, tickUpper := j.tickRange(swap.pool, swap.tick)
+	sqrtLower, err := protocols.SqrtPriceAtTick(tickLower)
+	if err != nil {
+		return nil, err
+	}
+	sqrtUpper, err := protocols.SqrtPriceAtTick(tickUpper)
+	if err != nil {
+		return nil, err
+	}
+
+	// Capital needed per unit of liquidity sets the largest position
+	unit := big.NewInt(1e18)
+	unit0, unit1 := protocols.AmountsForLiquidity(swap.sqrtPricePre, sqrtLower, sqrtUpper, unit)
+	unitValue, err := j.valueETH(swap.pool, inventoryValue(unit0, unit1, swap.pricePre()), swap.pricePre())
+	if err != nil {
+		return nil, err
+	}
+	if unitValue.Sign() <= 0 {
+		return nil, nil
+	}
+	maxLiquidity := new(big.Int).Mul(unit, config.MaxCapital)
+	maxLiquidity.Div(maxLiquidity, unitValue)
+
+	var best *interfaces.JITOpportunity
+	for _, fraction := range jitCapitalFractions {
+		liquidity := new(big.Int).Mul(maxLiquidity, fraction.Num())
+		liquidity.Div(liquidity, fraction.Denom())
+		if liquidity.Sign() <= 0 {
+			continue
+		}
+
+		opportunity, err := j.simulate(swap, tickLower, tickUpper, sqrtLower, sqrtUpper, liquidity)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || opportunity.ExpectedProfit.Cmp(best.ExpectedProfit) > 0 {
+			best = opportunity
+		}
+	}
+	return best, nil
+}
+
+// simulate replays the swap against the pool's liquidity plus the JIT position
+// and returns the position's fee share and inventory cost. Inventory is marked
+// at the post-swap price without the JIT liquidity, the worse of the two.
+func (j *jitDetector) simulate(swap *jitSwap, tickLower, tickUpper int32, sqrtLower, sqrtUpper, liquidity *big.Int) (*interfaces.JITOpportunity, error) {
+	fee := big.NewInt(int64(swap.pool.Fee))
+	total := new(big.Int).Add(swap.liquidity, liquidity)
+
+	amountInLessFee := new(big.Int).Mul(swap.amountIn, new(big.Int).Sub(jitFeeDenominator, fee))
+	amountInLessFee.Div(amountInLessFee, jitFeeDenominator)
+
+	// Input consumed within the JIT range and the price the range ends at
+	var consumed, sqrtEnd *big.Int
+	if swap.zeroForOne {
+		numerator := new(big.Int).Mul(total, swap.sqrtPricePre)
+		numerator.Mul(numerator, jitQ96)
+		denominator := new(big.Int).Mul(total, jitQ96)
+		denominator.Add(denominator, new(big.Int).Mul(amountInLessFee, swap.sqrtPricePre))
+		sqrtEnd = numerator.Div(numerator, denominator)
+		consumed = amountInLessFee
+		if sqrtEnd.Cmp(sqrtLower) < 0 {
+			sqrtEnd = sqrtLower
+			consumed, _ = protocols.AmountsForLiquidity(sqrtLower, sqrtLower, swap.sqrtPricePre, total)
+		}
+	} else {
+		sqrtEnd = new(big.Int).Mul(amountInLessFee, jitQ96)
+		sqrtEnd.Div(sqrtEnd, total)
+		sqrtEnd.Add(sqrtEnd, swap.sqrtPricePre)
+		consumed = amountInLessFee
+		if sqrtEnd.Cmp(sqrtUpper) > 0 {
+			sqrtEnd = sqrtUpper
+			_, consumed = protocols.AmountsForLiquidity(sqrtUpper, swap.sqrtPricePre, sqrtUpper, total)
+		}
+	}
+
+	// The position earns its share of the fee on input swapped within its range
+	feesIn := new(big.Int).Mul(consumed, fee)
+	feesIn.Div(feesIn, new(big.Int).Sub(jitFeeDenominator, fee))
+	feesIn.Mul(feesIn, liquidity)
+	feesIn.Div(feesIn, total)
+
+	amount0, amount1 := protocols.AmountsForLiquidity(swap.sqrtPricePre, sqrtLower, sqrtUpper, liquidity)
+	after0, after1 := protocols.AmountsForLiquidity(sqrtEnd, sqrtLower, sqrtUpper, liquidity)
+
+	mark := swap.pricePost()
+	feesValue, err := j.valueETH(swap.pool, j.tokenInValue(swap.zeroForOne, feesIn, mark), mark)
+	if err != nil {
+		return nil, err
+	}
+
+	// The swap trades the position's token out for its token in; the haircut
+	// covers moving the price back before the position is unwound
+	loss := new(big.Rat).Sub(inventoryValue(amount0, amount1, mark), inventoryValue(after0, after1, mark))
+	received := new(big.Int).Sub(after0, amount0)
+	if !swap.zeroForOne {
+		received = new(big.Int).Sub(after1, amount1)
+	}
+	haircut := j.tokenInValue(swap.zeroForOne, received, mark)
+	haircut.Mul(haircut, big.NewRat(int64(j.config.Load().InventoryRiskBps), 10000))
+	inventoryCost, err := j.valueETH(swap.pool, loss.Add(loss, haircut), mark)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.JITOpportunity{
+		Pool:           swap.pool.Address,
+		Token0:         swap.pool.Token0,
+		Token1:         swap.pool.Token1,
+		Fee:            swap.pool.Fee,
+		ZeroForOne:     swap.zeroForOne,
+		SwapAmountIn:   swap.amountIn,
+		TickLower:      tickLower,
+		TickUpper:      tickUpper,
+		Liquidity:      liquidity,
+		Amount0:        amount0,
+		Amount1:        amount1,
+		FeesEarned:     feesValue,
+		InventoryCost:  inventoryCost,
+		ExpectedProfit: new(big.Int).Sub(feesValue, inventoryCost),
+	}, nil
+}
+
+// tickRange returns TickRangeWidth tick spacings around the given tick
+func (j *jitDetector) tickRange(pool *interfaces.PoolInfo, tick int32) (int32, int32) {
+	spacing := pool.TickSpacing
+	if spacing <= 0 {
+		spacing = tickSpacingForFee(pool.Fee)
+	}
+	width := j.config.Load().TickRangeWidth
+	if width < 1 {
+		width = 1
+	}
+
+	// Round down to a usable tick, also for negative ticks
+	lower := tick / spacing * spacing
+	if tick < 0 && tick%spacing != 0 {
+		lower -= spacing
+	}
+	lower -= (width - 1) / 2 * spacing
+	return lower, lower + width*spacing
+}
+
+// tickSpacingForFee returns the factory's tick spacing for a fee tier
+func tickSpacingForFee(fee uint32) int32 {
+	switch fee {
+	case 100:
+		return 1
+	case 500:
+		return 10
+	case 10000:
+		return 200
+	default:
+		return 60
+	}
+}
+
+// buildTransactions makes the mint, burn and collect executor contract calls
+// on the pool. The executor owns the position and pays the pool from its mint
+// callback, so none of the calls move tokens in or out of it.
+func (j *jitDetector) buildTransactions(ctx context.Context, opportunity *interfaces.JITOpportunity, gasPrice *big.Int) error {
+	if j.builder == nil {
+		return fmt.Errorf("no transaction builder")
+	}
+	executor := j.builder.Executor()
+	tickLower, tickUpper := big.NewInt(int64(opportunity.TickLower)), big.NewInt(int64(opportunity.TickUpper))
+
+	mintData, err := uniswapV3Pool.Pack("mint", executor, tickLower, tickUpper, opportunity.Liquidity, []byte{})
+	if err != nil {
+		return fmt.Errorf("failed to encode mint: %w", err)
+	}
+	burnData, err := uniswapV3Pool.Pack("burn", tickLower, tickUpper, opportunity.Liquidity)
+	if err != nil {
+		return fmt.Errorf("failed to encode burn: %w", err)
+	}
+	collectData, err := uniswapV3Pool.Pack("collect", executor, tickLower, tickUpper, maxUint128, maxUint128)
+	if err != nil {
+		return fmt.Errorf("failed to encode collect: %w", err)
+	}
+
+	// GasLimit covers the three calls together, so any one of them may use all of it
+	batches := make([]*interfaces.CallBatch, 0, 3)
+	for _, data := range [][]byte{mintData, burnData, collectData} {
+		batches = append(batches, &interfaces.CallBatch{
+			Calls:    []*interfaces.SwapCalldata{{To: opportunity.Pool, Data: data, Value: big.NewInt(0)}},
+			GasLimit: j.config.Load().GasLimit,
+			GasPrice: gasPrice,
+		})
+	}
+
+	chainID := big.NewInt(baseChainID)
+	if opportunity.TargetTx != nil && opportunity.TargetTx.ChainID != nil {
+		chainID = opportunity.TargetTx.ChainID
+	}
+	txs, err := j.builder.BuildCalls(ctx, batches, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to build JIT position calls: %w", err)
+	}
+	if len(txs) != len(batches) {
+		return fmt.Errorf("builder returned %d transactions for %d calls", len(txs), len(batches))
+	}
+
+	opportunity.MintTx, opportunity.BurnTx, opportunity.CollectTx = txs[0], txs[1], txs[2]
+	return nil
+}
+
+// tokenInValue converts an amount of the swap's input token to token1 at the given price
+func (j *jitDetector) tokenInValue(zeroForOne bool, amount *big.Int, priceX192 *big.Rat) *big.Rat {
+	if zeroForOne {
+		return inventoryValue(amount, big.NewInt(0), priceX192)
+	}
+	return new(big.Rat).SetInt(amount)
+}
+
+// valueETH converts a value in raw token1 to wei of ETH, through the pool price
+// when either token is WETH and through the price oracle otherwise
+func (j *jitDetector) valueETH(pool *interfaces.PoolInfo, value *big.Rat, priceX192 *big.Rat) (*big.Int, error) {
+	switch {
+	case pool.Token1 == pricing.BaseWETH:
+		return new(big.Int).Quo(value.Num(), value.Denom()), nil
+	case pool.Token0 == pricing.BaseWETH:
+		if priceX192.Sign() == 0 {
+			return nil, fmt.Errorf("pool %s has no price", pool.Address.Hex())
+		}
+		inToken0 := new(big.Rat).Quo(value, priceX192)
+		return new(big.Int).Quo(inToken0.Num(), inToken0.Denom()), nil
+	case j.priceOracle != nil:
+		return j.priceOracle.ValueETH(pool.Token1, new(big.Int).Quo(value.Num(), value.Denom()))
+	default:
+		return nil, fmt.Errorf("no ETH price for pool %s", pool.Address.Hex())
+	}
+}
+
+// inventoryValue values token amounts in raw token1 at a price of token1 per token0
+func inventoryValue(amount0, amount1 *big.Int, priceX192 *big.Rat) *big.Rat {
+	value := new(big.Rat).Mul(new(big.Rat).SetInt(amount0), priceX192)
+	return value.Add(value, new(big.Rat).SetInt(amount1))
+}
+
+// pricePre is the pre-swap price of token0 in token1
+func (s *jitSwap) pricePre() *big.Rat {
+	return priceFromSqrt(s.sqrtPricePre)
+}
+
+// pricePost is the post-swap price of token0 in token1
+func (s *jitSwap) pricePost() *big.Rat {
+	return priceFromSqrt(s.sqrtPricePost)
+}
+
+// priceFromSqrt squares a sqrtPriceX96 into a price of token0 in token1
+func priceFromSqrt(sqrtPriceX96 *big.Int) *big.Rat {
+	return new(big.Rat).SetFrac(new(big.Int).Mul(sqrtPriceX96, sqrtPriceX96), jitQ192)
+}
@@ -0,0 +1,304 @@
+package strategy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	jitTestPool     = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	jitTestExecutor = common.HexToAddress("0x1100000000000000000000000000000000000022")
+	jitTestSearcher = common.HexToAddress("0x5ea4c4e400000000000000000000000000000022")
+)
+
+// fakePoolRegistry serves a fixed set of pools
+type fakePoolRegistry struct {
+	interfaces.PoolRegistry
+	pools map[common.Address]*interfaces.PoolInfo
+}
+
+func (f *fakePoolRegistry) GetPool(address common.Address) (*interfaces.PoolInfo, bool) {
+	pool, exists := f.pools[address]
+	return pool, exists
+}
+
+func newJITTestRegistry(token0, token1 common.Address) *fakePoolRegistry {
+	return &fakePoolRegistry{pools: map[common.Address]*interfaces.PoolInfo{
+		jitTestPool: {
+			Address:     jitTestPool,
+			Protocol:    interfaces.ProtocolUniswapV3,
+			Token0:      token0,
+			Token1:      token1,
+			Fee:         3000,
+			TickSpacing: 60,
+		},
+	}}
+}
+
+func testJITConfig() *interfaces.JITConfig {
+	return &interfaces.JITConfig{
+		MinSwapAmount:      new(big.Int).Mul(big.NewInt(20), big.NewInt(1e18)),
+		MaxCapital:         new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)),
+		TickRangeWidth:     1,
+		InventoryRiskBps:   5,
+		GasLimit:           450000,
+		MinProfitThreshold: big.NewInt(1e16),
+	}
+}
+
+// newTestJITDetector creates a JIT detector for the test pool that builds its
+// calls with a recording builder
+func newTestJITDetector(config *interfaces.JITConfig, registry interfaces.PoolRegistry) (interfaces.JITDetector, *recordingBuilder) {
+	builder := &recordingBuilder{searcher: jitTestSearcher, executor: jitTestExecutor, nonce: 7}
+	return NewJITDetector(config, registry, nil, WithTransactionBuilder(builder)), builder
+}
+
+// jitSwapLog swaps amountIn of WETH for USDC in a WETH/USDC pool at about
+// $3,000 with the given in-range liquidity and returns the pool's Swap log
+func jitSwapLog(t *testing.T, amountIn, liquidity *big.Int) *ethtypes.Log {
+	t.Helper()
+
+	sqrtPre, err := protocols.SqrtPriceAtTick(-196230)
+	require.NoError(t, err)
+
+	// Same step as the pool: sqrtNext = L * sqrtP * Q96 / (L * Q96 + in * sqrtP)
+	amountInLessFee := new(big.Int).Mul(amountIn, big.NewInt(997000))
+	amountInLessFee.Div(amountInLessFee, big.NewInt(1000000))
+	numerator := new(big.Int).Mul(liquidity, sqrtPre)
+	numerator.Mul(numerator, jitQ96)
+	denominator := new(big.Int).Mul(liquidity, jitQ96)
+	denominator.Add(denominator, new(big.Int).Mul(amountInLessFee, sqrtPre))
+	sqrtPost := numerator.Div(numerator, denominator)
+
+	amountOut := new(big.Int).Sub(sqrtPre, sqrtPost)
+	amountOut.Mul(amountOut, liquidity)
+	amountOut.Div(amountOut, jitQ96)
+
+	tick, err := protocols.TickAtSqrtPrice(sqrtPost)
+	require.NoError(t, err)
+
+	data, err := uniswapV3Pool.Events["Swap"].Inputs.NonIndexed().Pack(
+		amountIn, new(big.Int).Neg(amountOut), sqrtPost, liquidity, big.NewInt(int64(tick)))
+	require.NoError(t, err)
+
+	return &ethtypes.Log{
+		Address: jitTestPool,
+		Topics: []common.Hash{
+			uniswapV3Pool.Events["Swap"].ID,
+			common.BytesToHash(common.HexToAddress("0x2626664c2603336E57B271c5C0b26F421741e481").Bytes()),
+			common.BytesToHash(common.HexToAddress("0xa000000000000000000000000000000000000001").Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func jitTargetTx() *types.Transaction {
+	return &types.Transaction{
+		Hash:     "0xjit",
+		GasPrice: big.NewInt(10_000_000), // 0.01 gwei
+	}
+}
+
+func ether(amount int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(amount), big.NewInt(1e18))
+}
+
+func TestJITDetector_DetectOpportunity(t *testing.T) {
+	detector, builder := newTestJITDetector(testJITConfig(), newJITTestRegistry(pricing.BaseWETH, pricing.BaseUSDC))
+	poolLiquidity := ether(5)
+
+	opportunity, err := detector.DetectOpportunity(context.Background(), jitTargetTx(), &interfaces.SimulationResult{
+		Success: true,
+		Logs:    []*ethtypes.Log{jitSwapLog(t, ether(50), poolLiquidity)},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+
+	assert.Equal(t, jitTestPool, opportunity.Pool)
+	assert.True(t, opportunity.ZeroForOne)
+	assert.Equal(t, ether(50), opportunity.SwapAmountIn)
+	assert.Equal(t, "0xjit", opportunity.TargetTx.Hash)
+
+	// One tick spacing around the pre-swap tick
+	assert.Equal(t, int32(-196260), opportunity.TickLower)
+	assert.Equal(t, int32(-196200), opportunity.TickUpper)
+
+	// At most MaxCapital is deposited: 100 ETH is about 50 WETH plus 150,000 USDC
+	assert.True(t, opportunity.Amount0.Cmp(ether(51)) <= 0)
+	assert.True(t, opportunity.Amount1.Cmp(big.NewInt(152_000_000000)) <= 0)
+
+	// The position takes its share of the 0.15 WETH fee by liquidity
+	expectedFees := new(big.Int).Mul(big.NewInt(15e16), opportunity.Liquidity)
+	expectedFees.Div(expectedFees, new(big.Int).Add(opportunity.Liquidity, poolLiquidity))
+	assert.InDelta(t, expectedFees.Int64(), opportunity.FeesEarned.Int64(), 1e12)
+	assert.True(t, opportunity.InventoryCost.Sign() > 0)
+	assert.Equal(t, new(big.Int).Sub(opportunity.FeesEarned, opportunity.InventoryCost), opportunity.ExpectedProfit)
+	assert.True(t, opportunity.ExpectedProfit.Cmp(testJITConfig().MinProfitThreshold) >= 0)
+
+	// The searcher sends each call through the executor, in nonce order
+	for i, tx := range []*types.Transaction{opportunity.MintTx, opportunity.BurnTx, opportunity.CollectTx} {
+		require.NotNil(t, tx)
+		assert.Equal(t, jitTestSearcher, tx.From)
+		assert.Equal(t, jitTestExecutor, *tx.To)
+		assert.Equal(t, uint64(7+i), tx.Nonce)
+		assert.Equal(t, big.NewInt(baseChainID), tx.ChainID)
+	}
+
+	// Each call targets the pool and moves no tokens in or out of the executor
+	require.Len(t, builder.batches, 3)
+	for _, batch := range builder.batches {
+		require.Len(t, batch.Calls, 1)
+		assert.Equal(t, jitTestPool, batch.Calls[0].To)
+		assert.Nil(t, batch.FlashLoan)
+		assert.Nil(t, batch.AmountIn)
+		assert.Equal(t, uint64(450000), batch.GasLimit)
+	}
+
+	mint, err := uniswapV3Pool.Methods["mint"].Inputs.Unpack(builder.batches[0].Calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, jitTestExecutor, mint[0])
+	assert.Equal(t, big.NewInt(-196260), mint[1])
+	assert.Equal(t, big.NewInt(-196200), mint[2])
+	assert.Equal(t, opportunity.Liquidity, mint[3])
+
+	burn, err := uniswapV3Pool.Methods["burn"].Inputs.Unpack(builder.batches[1].Calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, opportunity.Liquidity, burn[2])
+
+	collect, err := uniswapV3Pool.Methods["collect"].Inputs.Unpack(builder.batches[2].Calls[0].Data[4:])
+	require.NoError(t, err)
+	assert.Equal(t, jitTestExecutor, collect[0])
+}
+
+func TestJITDetector_NoOpportunity(t *testing.T) {
+	registry := newJITTestRegistry(pricing.BaseWETH, pricing.BaseUSDC)
+	largeSwap := jitSwapLog(t, ether(50), ether(5))
+
+	unknownPool := jitSwapLog(t, ether(50), ether(5))
+	unknownPool.Address = common.HexToAddress("0x1000000000000000000000000000000000000009")
+
+	tests := []struct {
+		name      string
+		config    func(*interfaces.JITConfig)
+		registry  *fakePoolRegistry
+		simResult *interfaces.SimulationResult
+	}{
+		{
+			name:      "failed simulation",
+			registry:  registry,
+			simResult: &interfaces.SimulationResult{Success: false, Logs: []*ethtypes.Log{largeSwap}},
+		},
+		{
+			name:      "swap below minimum",
+			registry:  registry,
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{jitSwapLog(t, ether(10), ether(5))}},
+		},
+		{
+			name:      "unknown pool",
+			registry:  registry,
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{unknownPool}},
+		},
+		{
+			name:      "pool without an ETH price",
+			registry:  newJITTestRegistry(pricing.BaseDAI, pricing.BaseUSDC),
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{largeSwap}},
+		},
+		{
+			name:      "inventory risk outweighs fees",
+			config:    func(config *interfaces.JITConfig) { config.InventoryRiskBps = 100 },
+			registry:  registry,
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{largeSwap}},
+		},
+		{
+			name:      "fees below threshold",
+			config:    func(config *interfaces.JITConfig) { config.MinProfitThreshold = ether(1) },
+			registry:  registry,
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{largeSwap}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testJITConfig()
+			if tt.config != nil {
+				tt.config(config)
+			}
+			detector, _ := newTestJITDetector(config, tt.registry)
+
+			opportunity, err := detector.DetectOpportunity(context.Background(), jitTargetTx(), tt.simResult)
+			require.NoError(t, err)
+			assert.Nil(t, opportunity)
+		})
+	}
+
+	// Without a builder the position's calls can't be made
+	detector := NewJITDetector(testJITConfig(), registry, nil)
+	opportunity, err := detector.DetectOpportunity(context.Background(), jitTargetTx(), &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{largeSwap}})
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+}
+
+func TestJITDetector_ConstructBundle(t *testing.T) {
+	detector, _ := newTestJITDetector(testJITConfig(), newJITTestRegistry(pricing.BaseWETH, pricing.BaseUSDC))
+	target := jitTargetTx()
+
+	opportunity, err := detector.DetectOpportunity(context.Background(), target, &interfaces.SimulationResult{
+		Success: true,
+		Logs:    []*ethtypes.Log{jitSwapLog(t, ether(50), ether(5))},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+
+	bundle, err := detector.ConstructBundle(context.Background(), opportunity)
+	require.NoError(t, err)
+	require.Len(t, bundle, 4)
+	assert.Equal(t, opportunity.MintTx, bundle[0])
+	assert.Equal(t, target, bundle[1])
+	assert.Equal(t, opportunity.BurnTx, bundle[2])
+	assert.Equal(t, opportunity.CollectTx, bundle[3])
+
+	_, err = detector.ConstructBundle(context.Background(), nil)
+	assert.Error(t, err)
+}
+
+func TestJITDetector_TickRange(t *testing.T) {
+	pool := &interfaces.PoolInfo{Fee: 3000, TickSpacing: 60}
+
+	tests := []struct {
+		tick  int32
+		width int32
+		lower int32
+		upper int32
+	}{
+		{tick: 30, width: 1, lower: 0, upper: 60},
+		{tick: -30, width: 1, lower: -60, upper: 0},
+		{tick: -60, width: 1, lower: -60, upper: 0},
+		{tick: 30, width: 3, lower: -60, upper: 120},
+	}
+
+	for _, tt := range tests {
+		config := testJITConfig()
+		config.TickRangeWidth = tt.width
+		detector := NewJITDetector(config, nil, nil).(*jitDetector)
+
+		lower, upper := detector.tickRange(pool, tt.tick)
+		assert.Equal(t, tt.lower, lower, "tick %d width %d", tt.tick, tt.width)
+		assert.Equal(t, tt.upper, upper, "tick %d width %d", tt.tick, tt.width)
+	}
+
+	// Pools without a registered spacing use the fee tier's
+	detector := NewJITDetector(testJITConfig(), nil, nil).(*jitDetector)
+	lower, upper := detector.tickRange(&interfaces.PoolInfo{Fee: 500}, 15)
+	assert.Equal(t, int32(10), lower)
+	assert.Equal(t, int32(20), upper)
+}
@@ -0,0 +1,609 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/lending"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// Chainlink USD feeds report 8 decimals
+const defaultPriceFeedDecimals = 8
+
+// Confidence of liquidations on confirmed state and of those that depend on a pending transaction
+const (
+	confirmedLiquidationConfidence = 0.8
+	pendingLiquidationConfidence   = 0.6
+)
+
+// baseChainID is Base mainnet, which liquidations are sent on unless configured
+const baseChainID = 8453
+
+// liquidationDetector implements the LiquidationDetector interface
+type liquidationDetector struct {
+	config       atomic.Pointer[interfaces.LiquidationConfig]
+	markets      []interfaces.LendingMarket
+	priceOracle  interfaces.PriceOracle
+	parser       interfaces.EventParser
+	builder      interfaces.TransactionBuilder
+	poolRegistry interfaces.PoolRegistry
+	feedAssets   map[common.Address][]*interfaces.LendingAsset // Assets priced by each Chainlink aggregator
+
+	mu         sync.RWMutex
+	feedPrices map[common.Address]float64 // Latest USD answer of each aggregator
+}
+
+// liquidationPrices resolves USD prices from Chainlink answers, falling back to the DEX price oracle
+type liquidationPrices struct {
+	feeds  map[common.Address]float64
+	oracle interfaces.PriceOracle
+}
+
+// liquidationCandidate is the most profitable way to liquidate a position
+type liquidationCandidate struct {
+	debt       *interfaces.LendingAsset
+	collateral *interfaces.LendingAsset
+	repayUSD   float64
+	seizeUSD   float64
+	profitUSD  float64
+}
+
+// NewLiquidationDetector creates a liquidation detector over the given lending markets.
+// The price oracle prices assets without a Chainlink feed and, together with the
+// event parser, reprices positions after swaps; both are optional. Options add the
+// transaction builder liquidations are built with and the pool registry seized
+// collateral is sold through; without both, positions are tracked but no
+// liquidations are reported.
+func NewLiquidationDetector(
+	config *interfaces.LiquidationConfig,
+	markets []interfaces.LendingMarket,
+	priceOracle interfaces.PriceOracle,
+	parser interfaces.EventParser,
+	options ...DetectorOption,
+) interfaces.LiquidationDetector {
+	if config == nil {
+		config = &interfaces.LiquidationConfig{
+			MinProfitUSD:    50,
+			MaxSlippageBps:  50,                     // 0.5% selling seized collateral
+			FlashLoanPool:   lending.BaseAaveV3Pool, // Aave V3 flashLoanSimple
+			FlashLoanFeeBps: 5,
+			GasLimit:        800000,
+		}
+	}
+
+	feedAssets := make(map[common.Address][]*interfaces.LendingAsset)
+	for _, market := range markets {
+		for _, asset := range market.Assets() {
+			if asset.PriceFeed != (common.Address{}) {
+				feedAssets[asset.PriceFeed] = append(feedAssets[asset.PriceFeed], asset)
+			}
+		}
+	}
+
+	deps := applyDetectorOptions(options)
+	detector := &liquidationDetector{
+		markets:      markets,
+		priceOracle:  priceOracle,
+		parser:       parser,
+		builder:      deps.builder,
+		poolRegistry: deps.poolRegistry,
+		feedAssets:   feedAssets,
+		feedPrices:   make(map[common.Address]float64),
+	}
+	detector.config.Store(config)
+	return detector
+}
+
+// ProcessLogs applies confirmed logs to the mirrored positions and prices and returns
+// the positions that became liquidatable. Borrowers touched by a position change are
+// rechecked; any oracle or pool price change rechecks every borrower.
+func (l *liquidationDetector) ProcessLogs(ctx context.Context, logs []*ethtypes.Log) ([]*interfaces.MEVOpportunity, error) {
+	touched := make([]map[common.Address]bool, len(l.markets))
+	for i := range touched {
+		touched[i] = make(map[common.Address]bool)
+	}
+	priceMoved := false
+	var poolLogs []*ethtypes.Log
+
+	for _, log := range logs {
+		if price, ok := l.decodeFeedUpdate(log); ok {
+			l.mu.Lock()
+			l.feedPrices[log.Address] = price
+			l.mu.Unlock()
+			priceMoved = true
+			continue
+		}
+
+		applied := false
+		for i, market := range l.markets {
+			borrowers, err := market.ApplyLog(log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply %s log: %w", market.Protocol(), err)
+			}
+			for _, borrower := range borrowers {
+				touched[i][borrower] = true
+				applied = true
+			}
+		}
+		if !applied {
+			poolLogs = append(poolLogs, log)
+		}
+	}
+
+	if l.priceOracle != nil && l.parser != nil && len(poolLogs) > 0 {
+		moved, err := l.applyPoolEvents(ctx, l.priceOracle, poolLogs)
+		if err != nil {
+			return nil, err
+		}
+		priceMoved = priceMoved || moved
+	}
+
+	prices := l.currentPrices()
+	var opportunities []*interfaces.MEVOpportunity
+	for i, market := range l.markets {
+		borrowers := market.Borrowers()
+		if !priceMoved {
+			borrowers = make([]common.Address, 0, len(touched[i]))
+			for borrower := range touched[i] {
+				borrowers = append(borrowers, borrower)
+			}
+		}
+
+		for _, borrower := range borrowers {
+			opportunity, err := l.evaluate(ctx, market, borrower, prices, nil)
+			if err != nil {
+				return nil, err
+			}
+			if opportunity != nil {
+				opportunities = append(opportunities, opportunity)
+			}
+		}
+	}
+
+	return opportunities, nil
+}
+
+// DetectOpportunity reprices every position as if a pending transaction's oracle
+// updates and swaps had landed, and returns the positions it would make liquidatable
+func (l *liquidationDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) ([]*interfaces.MEVOpportunity, error) {
+	if tx == nil || simResult == nil || !simResult.Success || len(simResult.Logs) == 0 {
+		return nil, nil
+	}
+
+	current := l.currentPrices()
+	pending := &liquidationPrices{feeds: make(map[common.Address]float64, len(current.feeds)), oracle: current.oracle}
+	for feed, price := range current.feeds {
+		pending.feeds[feed] = price
+	}
+
+	priceMoved := false
+	for _, log := range simResult.Logs {
+		if price, ok := l.decodeFeedUpdate(log); ok {
+			pending.feeds[log.Address] = price
+			priceMoved = true
+		}
+	}
+
+	if l.priceOracle != nil && l.parser != nil {
+		fork := l.priceOracle.Fork()
+		moved, err := l.applyPoolEvents(ctx, fork, simResult.Logs)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			pending.oracle = fork
+			priceMoved = true
+		}
+	}
+
+	if !priceMoved {
+		return nil, nil
+	}
+
+	var opportunities []*interfaces.MEVOpportunity
+	for _, market := range l.markets {
+		for _, borrower := range market.Borrowers() {
+			// Positions already liquidatable are reported from confirmed logs
+			if healthFactor, err := l.healthFactor(market, borrower, current); err != nil || healthFactor < 1 {
+				continue
+			}
+
+			opportunity, err := l.evaluate(ctx, market, borrower, pending, tx)
+			if err != nil {
+				return nil, err
+			}
+			if opportunity != nil {
+				opportunities = append(opportunities, opportunity)
+			}
+		}
+	}
+
+	return opportunities, nil
+}
+
+// HealthFactor returns a borrower's health factor at current prices
+func (l *liquidationDetector) HealthFactor(marketAddress, borrower common.Address) (float64, error) {
+	for _, market := range l.markets {
+		if market.Address() == marketAddress {
+			return l.healthFactor(market, borrower, l.currentPrices())
+		}
+	}
+	return 0, fmt.Errorf("market %s is not tracked", marketAddress.Hex())
+}
+
+// GetConfiguration returns the current liquidation detector configuration
+func (l *liquidationDetector) GetConfiguration() *interfaces.LiquidationConfig {
+	return l.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (l *liquidationDetector) SetConfiguration(config *interfaces.LiquidationConfig) {
+	l.config.Store(config)
+}
+
+// decodeFeedUpdate returns the USD price of an AnswerUpdated log from a tracked aggregator
+func (l *liquidationDetector) decodeFeedUpdate(log *ethtypes.Log) (float64, bool) {
+	assets, tracked := l.feedAssets[log.Address]
+	if !tracked {
+		return 0, false
+	}
+	answer, ok := lending.DecodeAnswerUpdated(log)
+	if !ok || answer.Sign() <= 0 {
+		return 0, false
+	}
+
+	decimals := assets[0].PriceFeedDecimals
+	if decimals == 0 {
+		decimals = defaultPriceFeedDecimals
+	}
+	return tokenAmount(answer, decimals), true
+}
+
+// applyPoolEvents applies Sync and concentrated liquidity Swap events to an oracle
+// and reports whether any were found
+func (l *liquidationDetector) applyPoolEvents(ctx context.Context, oracle interfaces.PriceOracle, logs []*ethtypes.Log) (bool, error) {
+	parsedEvents, err := l.parser.ParseEventLogs(ctx, logs)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse pool events: %w", err)
+	}
+
+	moved := false
+	for _, event := range parsedEvents {
+		if event.SyncEvent == nil && (event.SwapEvent == nil || event.SwapEvent.SqrtPriceX96 == nil) {
+			continue
+		}
+		if err := oracle.ApplyEvent(event); err == nil {
+			moved = true
+		}
+	}
+	return moved, nil
+}
+
+// currentPrices returns a snapshot of the confirmed prices
+func (l *liquidationDetector) currentPrices() *liquidationPrices {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	feeds := make(map[common.Address]float64, len(l.feedPrices))
+	for feed, price := range l.feedPrices {
+		feeds[feed] = price
+	}
+	return &liquidationPrices{feeds: feeds, oracle: l.priceOracle}
+}
+
+// price returns the USD price of one whole asset
+func (p *liquidationPrices) price(asset *interfaces.LendingAsset) (float64, error) {
+	if asset.PriceFeed != (common.Address{}) {
+		if price, exists := p.feeds[asset.PriceFeed]; exists {
+			return price, nil
+		}
+	}
+	if p.oracle == nil {
+		return 0, fmt.Errorf("no price for %s", asset.Token.Hex())
+	}
+	return p.oracle.PriceUSD(asset.Token)
+}
+
+// positionValues returns the USD value of each collateral and debt balance of a position
+func positionValues(market interfaces.LendingMarket, position *interfaces.BorrowerPosition, prices *liquidationPrices) (map[common.Address]float64, map[common.Address]float64, map[common.Address]*interfaces.LendingAsset, error) {
+	assets := make(map[common.Address]*interfaces.LendingAsset)
+	for _, asset := range market.Assets() {
+		assets[asset.Token] = asset
+	}
+
+	value := func(balances map[common.Address]*big.Int) (map[common.Address]float64, error) {
+		values := make(map[common.Address]float64, len(balances))
+		for token, amount := range balances {
+			asset, listed := assets[token]
+			if !listed {
+				return nil, fmt.Errorf("token %s is not listed on %s", token.Hex(), market.Protocol())
+			}
+			price, err := prices.price(asset)
+			if err != nil {
+				return nil, err
+			}
+			values[token] = tokenAmount(amount, asset.Decimals) * price
+		}
+		return values, nil
+	}
+
+	collateral, err := value(position.Collateral)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	debt, err := value(position.Debt)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return collateral, debt, assets, nil
+}
+
+// healthFactor is collateral value weighted by liquidation thresholds over debt value
+func (l *liquidationDetector) healthFactor(market interfaces.LendingMarket, borrower common.Address, prices *liquidationPrices) (float64, error) {
+	position, exists := market.Position(borrower)
+	if !exists {
+		return 0, fmt.Errorf("borrower %s has no position on %s", borrower.Hex(), market.Protocol())
+	}
+	if position.Incomplete {
+		return 0, fmt.Errorf("position of %s on %s is incomplete", borrower.Hex(), market.Protocol())
+	}
+
+	collateral, debt, assets, err := positionValues(market, position, prices)
+	if err != nil {
+		return 0, err
+	}
+	return weightedHealthFactor(collateral, debt, assets), nil
+}
+
+func weightedHealthFactor(collateral, debt map[common.Address]float64, assets map[common.Address]*interfaces.LendingAsset) float64 {
+	var weighted, owed float64
+	for token, value := range collateral {
+		weighted += value * float64(assets[token].LiquidationThreshold) / 10000
+	}
+	for _, value := range debt {
+		owed += value
+	}
+	if owed == 0 {
+		return math.Inf(1)
+	}
+	return weighted / owed
+}
+
+// evaluate sizes the most profitable liquidation of a position, or returns nil when
+// the position is healthy, can't be priced, isn't worth liquidating or its seized
+// collateral can't be sold
+func (l *liquidationDetector) evaluate(ctx context.Context, market interfaces.LendingMarket, borrower common.Address, prices *liquidationPrices, trigger *types.Transaction) (*interfaces.MEVOpportunity, error) {
+	config := l.config.Load()
+	if l.builder == nil || l.poolRegistry == nil {
+		return nil, nil
+	}
+	position, exists := market.Position(borrower)
+	if !exists || position.Incomplete || len(position.Debt) == 0 {
+		return nil, nil
+	}
+
+	collateral, debt, assets, err := positionValues(market, position, prices)
+	if err != nil {
+		// Positions in assets without a price yet are skipped until one is known
+		return nil, nil
+	}
+	healthFactor := weightedHealthFactor(collateral, debt, assets)
+	if healthFactor >= 1 {
+		return nil, nil
+	}
+
+	best := l.bestCandidate(market.CloseFactor(healthFactor), collateral, debt, assets)
+	if best == nil || best.profitUSD < config.MinProfitUSD {
+		return nil, nil
+	}
+
+	debtPrice, err := prices.price(best.debt)
+	if err != nil {
+		return nil, nil
+	}
+	collateralPrice, err := prices.price(best.collateral)
+	if err != nil {
+		return nil, nil
+	}
+
+	salePool, exists := l.salePool(best.collateral.Token, best.debt.Token)
+	if !exists {
+		return nil, nil
+	}
+
+	repay := minBigInt(usdToAmount(best.repayUSD, debtPrice, best.debt.Decimals), position.Debt[best.debt.Token])
+	seize := minBigInt(usdToAmount(best.seizeUSD, collateralPrice, best.collateral.Decimals), position.Collateral[best.collateral.Token])
+	minSeize := new(big.Int).Mul(seize, big.NewInt(int64(10000-config.MaxSlippageBps)))
+	minSeize.Div(minSeize, big.NewInt(10000))
+	// Lenders round their fee up
+	fee := new(big.Int).Mul(repay, big.NewInt(int64(config.FlashLoanFeeBps)))
+	fee.Add(fee, big.NewInt(9999)).Div(fee, big.NewInt(10000))
+	profit := usdToAmount(best.profitUSD, debtPrice, best.debt.Decimals)
+
+	calls, err := market.EncodeLiquidation(&interfaces.LiquidationParams{
+		Borrower:        borrower,
+		DebtAsset:       best.debt.Token,
+		CollateralAsset: best.collateral.Token,
+		RepayAmount:     repay,
+		MinSeizeAmount:  minSeize,
+		Liquidator:      l.builder.Executor(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s liquidation: %w", market.Protocol(), err)
+	}
+	flashLoan := &interfaces.FlashLoanPlan{
+		Provider: string(interfaces.FlashLoanAaveV3),
+		Pool:     config.FlashLoanPool,
+		Asset:    best.debt.Token,
+		Amount:   repay,
+		Fee:      fee,
+	}
+
+	liquidation := &interfaces.LiquidationOpportunity{
+		Protocol:         market.Protocol(),
+		Market:           market.Address(),
+		Borrower:         borrower,
+		CollateralToken:  best.collateral.Token,
+		DebtToken:        best.debt.Token,
+		CollateralAmount: seize,
+		DebtAmount:       repay,
+		HealthFactor:     healthFactor,
+		LiquidationBonus: best.collateral.LiquidationBonus,
+		ExpectedProfit:   profit,
+		ProfitUSD:        best.profitUSD,
+		Calls:            calls,
+		FlashLoan:        flashLoan,
+	}
+
+	targetTx := ""
+	confidence := confirmedLiquidationConfidence
+	gasPrice := big.NewInt(0)
+	chainID := config.ChainID
+	if trigger != nil {
+		targetTx = trigger.Hash
+		liquidation.TriggerTx = trigger.Hash
+		confidence = pendingLiquidationConfidence
+		if trigger.GasPrice != nil {
+			gasPrice = trigger.GasPrice
+		}
+		if trigger.ChainID != nil {
+			chainID = trigger.ChainID
+		}
+	}
+	if chainID == nil {
+		chainID = big.NewInt(baseChainID)
+	}
+
+	// One transaction borrows the repayment, liquidates, sells the least collateral
+	// seized back to the debt token and repays the loan. It reverts unless the sale
+	// covers the loan and its fee.
+	txs, err := l.builder.BuildCalls(ctx, []*interfaces.CallBatch{{
+		Calls:   calls,
+		Spender: calls[0].To,
+		Sell: &interfaces.SwapRoute{
+			Hops:     []interfaces.SwapHop{{Pool: salePool.Address, Protocol: salePool.Protocol, TokenIn: best.collateral.Token, TokenOut: best.debt.Token}},
+			AmountIn: minSeize,
+		},
+		TokenIn:      best.debt.Token,
+		AmountIn:     repay,
+		TokenOut:     best.debt.Token,
+		MinAmountOut: new(big.Int).Add(repay, fee),
+		FlashLoan:    flashLoan,
+		GasLimit:     config.GasLimit,
+		GasPrice:     gasPrice,
+	}}, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s liquidation of %s: %w", market.Protocol(), borrower.Hex(), err)
+	}
+
+	opportunity := &interfaces.MEVOpportunity{
+		ID:             fmt.Sprintf("liquidation_%s_%s_%d", market.Protocol(), borrower.Hex(), time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyLiquidation,
+		TargetTx:       targetTx,
+		ExpectedProfit: profit,
+		GasCost:        new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(txs[0].GasLimit)),
+		NetProfit:      profit,
+		ProfitToken:    best.debt.Token,
+		Confidence:     confidence,
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   txs,
+		Pools:          []common.Address{salePool.Address},
+		Metadata: map[string]interface{}{
+			"liquidation_opportunity": liquidation,
+		},
+	}
+
+	// Opportunities that can't be priced keep their profit in the debt token
+	if l.priceOracle != nil {
+		_ = l.priceOracle.NormalizeOpportunity(opportunity)
+	}
+
+	return opportunity, nil
+}
+
+// salePool returns the deepest registered pool above the registry's liquidity floor
+// trading the collateral for the debt token
+func (l *liquidationDetector) salePool(collateral, debt common.Address) (*interfaces.PoolInfo, bool) {
+	var best *interfaces.PoolInfo
+	for _, pool := range l.poolRegistry.FindPools(&interfaces.PoolFilter{Token: &collateral}) {
+		if pool.Token0 != debt && pool.Token1 != debt {
+			continue
+		}
+		if best == nil || pool.TVL != nil && (best.TVL == nil || pool.TVL.Cmp(best.TVL) > 0) {
+			best = pool
+		}
+	}
+	return best, best != nil
+}
+
+// bestCandidate picks the debt and collateral pair with the highest profit after
+// the liquidation bonus, slippage selling the collateral and the flash loan fee
+func (l *liquidationDetector) bestCandidate(closeFactor uint16, collateral, debt map[common.Address]float64, assets map[common.Address]*interfaces.LendingAsset) *liquidationCandidate {
+	config := l.config.Load()
+	slippage := float64(config.MaxSlippageBps) / 10000
+	flashFee := float64(config.FlashLoanFeeBps) / 10000
+
+	var best *liquidationCandidate
+	for debtToken, debtUSD := range debt {
+		for collateralToken, collateralUSD := range collateral {
+			if debtToken == collateralToken {
+				continue
+			}
+			bonus := float64(assets[collateralToken].LiquidationBonus) / 10000
+
+			repayUSD := debtUSD * float64(closeFactor) / 10000
+			// The seized collateral, bonus included, can't exceed the balance
+			if repayUSD*(1+bonus) > collateralUSD {
+				repayUSD = collateralUSD / (1 + bonus)
+			}
+			seizeUSD := repayUSD * (1 + bonus)
+			profitUSD := seizeUSD*(1-slippage) - repayUSD - repayUSD*flashFee
+
+			if best == nil || profitUSD > best.profitUSD {
+				best = &liquidationCandidate{
+					debt:       assets[debtToken],
+					collateral: assets[collateralToken],
+					repayUSD:   repayUSD,
+					seizeUSD:   seizeUSD,
+					profitUSD:  profitUSD,
+				}
+			}
+		}
+	}
+	return best
+}
+
+// tokenAmount converts a raw amount to whole tokens
+func tokenAmount(amount *big.Int, decimals uint8) float64 {
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	value, _ := new(big.Float).Quo(new(big.Float).SetInt(amount), scale).Float64()
+	return value
+}
+
+// usdToAmount converts a USD value to a raw token amount
+func usdToAmount(usd, price float64, decimals uint8) *big.Int {
+	if price <= 0 || usd <= 0 {
+		return big.NewInt(0)
+	}
+	scale := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil))
+	amount, _ := new(big.Float).Mul(big.NewFloat(usd/price), scale).Int(nil)
+	return amount
+}
+
+// minBigInt returns the smaller of two integers
+func minBigInt(a, b *big.Int) *big.Int {
+	if b != nil && b.Cmp(a) < 0 {
+		return new(big.Int).Set(b)
+	}
+	return a
+}
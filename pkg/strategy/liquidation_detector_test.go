@@ -0,0 +1,319 @@
+package strategy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/lending"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	wethFeed         = common.HexToAddress("0xfeed000000000000000000000000000000000001")
+	usdcFeed         = common.HexToAddress("0xfeed000000000000000000000000000000000002")
+	liquidationUser  = common.HexToAddress("0xb000000000000000000000000000000000000001")
+	liquidatorBot    = common.HexToAddress("0x1100000000000000000000000000000000000011")
+	liquidationBot   = common.HexToAddress("0x5ea4c4e400000000000000000000000000000011")
+	wethUSDCTestPool = common.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+// fakeEventParser returns a fixed set of parsed events
+type fakeEventParser struct {
+	interfaces.EventParser
+	events []*interfaces.ParsedEvent
+}
+
+func (f *fakeEventParser) ParseEventLogs(ctx context.Context, logs []*ethtypes.Log) ([]*interfaces.ParsedEvent, error) {
+	return f.events, nil
+}
+
+// answerUpdatedLog builds a Chainlink AnswerUpdated log with an 8 decimal USD answer
+func answerUpdatedLog(feed common.Address, priceUSD int64) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: feed,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("AnswerUpdated(int256,uint256,uint256)")),
+			common.BigToHash(new(big.Int).Mul(big.NewInt(priceUSD), big.NewInt(1e8))),
+			common.BigToHash(big.NewInt(1)),
+		},
+		Data: common.BigToHash(big.NewInt(1700000000)).Bytes(),
+	}
+}
+
+// newTestLiquidationMarket creates an Aave V3 market where the borrower has
+// 10 WETH of collateral against 24,000 USDC of debt
+func newTestLiquidationMarket(t *testing.T, withFeeds bool) interfaces.LendingMarket {
+	t.Helper()
+
+	weth := &interfaces.LendingAsset{Token: pricing.BaseWETH, Symbol: "WETH", Decimals: 18, LiquidationThreshold: 8300, LiquidationBonus: 500}
+	usdc := &interfaces.LendingAsset{Token: pricing.BaseUSDC, Symbol: "USDC", Decimals: 6, LiquidationThreshold: 7800, LiquidationBonus: 500}
+	if withFeeds {
+		weth.PriceFeed = wethFeed
+		usdc.PriceFeed = usdcFeed
+	}
+
+	market, err := lending.NewAaveV3Market(lending.BaseAaveV3Pool, []*interfaces.LendingAsset{weth, usdc})
+	require.NoError(t, err)
+
+	require.NoError(t, market.SeedPosition(&interfaces.BorrowerPosition{
+		Borrower:   liquidationUser,
+		Collateral: map[common.Address]*big.Int{pricing.BaseWETH: new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18))},
+		Debt:       map[common.Address]*big.Int{pricing.BaseUSDC: big.NewInt(24000_000000)},
+	}))
+	return market
+}
+
+func testLiquidationConfig() *interfaces.LiquidationConfig {
+	return &interfaces.LiquidationConfig{
+		MinProfitUSD:    50,
+		MaxSlippageBps:  50,
+		FlashLoanPool:   lending.BaseAaveV3Pool,
+		FlashLoanFeeBps: 5,
+		GasLimit:        800000,
+	}
+}
+
+// newTestLiquidationDetector creates a detector that builds liquidations with a
+// recording builder and sells seized WETH through the WETH/USDC test pool
+func newTestLiquidationDetector(config *interfaces.LiquidationConfig, market interfaces.LendingMarket, oracle interfaces.PriceOracle, parser interfaces.EventParser) (interfaces.LiquidationDetector, *recordingBuilder) {
+	builder := &recordingBuilder{searcher: liquidationBot, executor: liquidatorBot, nonce: 3}
+	registry := &stubPoolRegistry{pools: []*interfaces.PoolInfo{{
+		Address:  wethUSDCTestPool,
+		Protocol: interfaces.ProtocolUniswapV2,
+		Token0:   pricing.BaseWETH,
+		Token1:   pricing.BaseUSDC,
+		TVL:      big.NewInt(600000),
+	}}}
+	detector := NewLiquidationDetector(config, []interfaces.LendingMarket{market}, oracle, parser, WithTransactionBuilder(builder), WithPoolRegistry(registry))
+	return detector, builder
+}
+
+// newTestPricingOracle prices WETH at $3000 from a 100 WETH / 300k USDC pool
+func newTestPricingOracle(t *testing.T) interfaces.PriceOracle {
+	t.Helper()
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, oracle.UpdatePool(&interfaces.PoolState{
+		Protocol: interfaces.ProtocolUniswapV2,
+		Address:  wethUSDCTestPool,
+		Tokens:   []common.Address{pricing.BaseWETH, pricing.BaseUSDC},
+		Reserves: []*big.Int{new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)), big.NewInt(300000_000000)},
+	}))
+	return oracle
+}
+
+func TestNewLiquidationDetector(t *testing.T) {
+	detector := NewLiquidationDetector(nil, nil, nil, nil)
+	config := detector.GetConfiguration()
+
+	assert.Equal(t, 50.0, config.MinProfitUSD)
+	assert.Equal(t, lending.BaseAaveV3Pool, config.FlashLoanPool)
+	assert.Equal(t, uint16(5), config.FlashLoanFeeBps)
+}
+
+func TestLiquidationDetector_ProcessLogs(t *testing.T) {
+	market := newTestLiquidationMarket(t, true)
+	detector, builder := newTestLiquidationDetector(testLiquidationConfig(), market, nil, nil)
+	ctx := context.Background()
+
+	// At $3000 the health factor is 30,000 * 0.83 / 24,000 = 1.0375
+	opportunities, err := detector.ProcessLogs(ctx, []*ethtypes.Log{answerUpdatedLog(wethFeed, 3000), answerUpdatedLog(usdcFeed, 1)})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+
+	healthFactor, err := detector.HealthFactor(lending.BaseAaveV3Pool, liquidationUser)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0375, healthFactor, 1e-9)
+
+	// At $2800 it falls to 0.968: half the debt can be repaid for 5% extra collateral
+	opportunities, err = detector.ProcessLogs(ctx, []*ethtypes.Log{answerUpdatedLog(wethFeed, 2800)})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+
+	opportunity := opportunities[0]
+	assert.Equal(t, interfaces.StrategyLiquidation, opportunity.Strategy)
+	assert.Equal(t, pricing.BaseUSDC, opportunity.ProfitToken)
+	assert.Equal(t, confirmedLiquidationConfidence, opportunity.Confidence)
+	assert.Empty(t, opportunity.TargetTx)
+
+	liquidation := opportunity.Metadata["liquidation_opportunity"].(*interfaces.LiquidationOpportunity)
+	assert.Equal(t, interfaces.LendingAaveV3, liquidation.Protocol)
+	assert.Equal(t, liquidationUser, liquidation.Borrower)
+	assert.Equal(t, pricing.BaseWETH, liquidation.CollateralToken)
+	assert.InDelta(t, 0.968, liquidation.HealthFactor, 1e-3)
+	assert.Equal(t, big.NewInt(12000_000000), liquidation.DebtAmount)
+	assert.InDelta(t, 4.5e18, float64(liquidation.CollateralAmount.Int64()), 1e9)
+
+	// 12,600 of collateral less 0.5% slippage, the 12,000 repaid and a 6 USDC flash loan fee
+	assert.InDelta(t, 531, liquidation.ProfitUSD, 1e-6)
+	assert.InDelta(t, 531_000000, float64(opportunity.ExpectedProfit.Int64()), 1)
+
+	require.NotNil(t, liquidation.FlashLoan)
+	assert.Equal(t, pricing.BaseUSDC, liquidation.FlashLoan.Asset)
+	assert.Equal(t, big.NewInt(12000_000000), liquidation.FlashLoan.Amount)
+	assert.Equal(t, big.NewInt(6_000000), liquidation.FlashLoan.Fee)
+
+	// One executor call from the searcher borrows the repayment, liquidates, sells the
+	// seized WETH and repays the loan with its fee
+	require.Len(t, opportunity.ExecutionTxs, 1)
+	tx := opportunity.ExecutionTxs[0]
+	assert.Equal(t, liquidationBot, tx.From)
+	assert.Equal(t, liquidatorBot, *tx.To)
+	assert.Equal(t, uint64(3), tx.Nonce)
+	assert.Equal(t, big.NewInt(baseChainID), tx.ChainID)
+
+	require.Len(t, builder.batches, 1)
+	batch := builder.batches[0]
+	assert.Same(t, liquidation.FlashLoan, batch.FlashLoan)
+	assert.Equal(t, string(interfaces.FlashLoanAaveV3), batch.FlashLoan.Provider)
+	assert.Equal(t, liquidation.Calls, batch.Calls)
+	assert.Equal(t, lending.BaseAaveV3Pool, batch.Spender)
+	assert.Equal(t, pricing.BaseUSDC, batch.TokenIn)
+	assert.Equal(t, big.NewInt(12000_000000), batch.AmountIn)
+	assert.Equal(t, pricing.BaseUSDC, batch.TokenOut)
+	assert.Equal(t, big.NewInt(12006_000000), batch.MinAmountOut)
+	require.NotNil(t, batch.Sell)
+	assert.Equal(t, []interfaces.SwapHop{{Pool: wethUSDCTestPool, Protocol: interfaces.ProtocolUniswapV2, TokenIn: pricing.BaseWETH, TokenOut: pricing.BaseUSDC}}, batch.Sell.Hops)
+	// The least collateral seized after slippage is sold
+	minSeize := new(big.Int).Mul(liquidation.CollateralAmount, big.NewInt(9950))
+	assert.Equal(t, minSeize.Div(minSeize, big.NewInt(10000)), batch.Sell.AmountIn)
+	assert.Equal(t, []common.Address{wethUSDCTestPool}, opportunity.Pools)
+}
+
+func TestLiquidationDetector_NoBuilderOrSalePool(t *testing.T) {
+	ctx := context.Background()
+	logs := []*ethtypes.Log{answerUpdatedLog(wethFeed, 2800), answerUpdatedLog(usdcFeed, 1)}
+
+	// Without a builder, positions are tracked but nothing is reported
+	market := newTestLiquidationMarket(t, true)
+	detector := NewLiquidationDetector(testLiquidationConfig(), []interfaces.LendingMarket{market}, nil, nil)
+	opportunities, err := detector.ProcessLogs(ctx, logs)
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+	healthFactor, err := detector.HealthFactor(lending.BaseAaveV3Pool, liquidationUser)
+	require.NoError(t, err)
+	assert.Less(t, healthFactor, 1.0)
+
+	// Without a pool to sell the seized collateral through, the loan can't be repaid
+	builder := &recordingBuilder{searcher: liquidationBot, executor: liquidatorBot}
+	detector = NewLiquidationDetector(testLiquidationConfig(), []interfaces.LendingMarket{newTestLiquidationMarket(t, true)}, nil, nil,
+		WithTransactionBuilder(builder), WithPoolRegistry(&stubPoolRegistry{}))
+	opportunities, err = detector.ProcessLogs(ctx, logs)
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+	assert.Empty(t, builder.batches)
+}
+
+func TestLiquidationDetector_MinProfit(t *testing.T) {
+	config := testLiquidationConfig()
+	config.MinProfitUSD = 1000
+
+	market := newTestLiquidationMarket(t, true)
+	detector, _ := newTestLiquidationDetector(config, market, nil, nil)
+
+	opportunities, err := detector.ProcessLogs(context.Background(), []*ethtypes.Log{answerUpdatedLog(wethFeed, 2800), answerUpdatedLog(usdcFeed, 1)})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+}
+
+func TestLiquidationDetector_IncompletePosition(t *testing.T) {
+	market := newTestLiquidationMarket(t, true)
+	require.NoError(t, market.SeedPosition(&interfaces.BorrowerPosition{
+		Borrower:   liquidationUser,
+		Collateral: map[common.Address]*big.Int{pricing.BaseWETH: big.NewInt(1e18)},
+		Debt:       map[common.Address]*big.Int{pricing.BaseUSDC: big.NewInt(24000_000000)},
+		Incomplete: true,
+	}))
+	detector, _ := newTestLiquidationDetector(testLiquidationConfig(), market, nil, nil)
+
+	opportunities, err := detector.ProcessLogs(context.Background(), []*ethtypes.Log{answerUpdatedLog(wethFeed, 3000), answerUpdatedLog(usdcFeed, 1)})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+
+	_, err = detector.HealthFactor(lending.BaseAaveV3Pool, liquidationUser)
+	assert.Error(t, err)
+	_, err = detector.HealthFactor(common.HexToAddress("0x01"), liquidationUser)
+	assert.Error(t, err)
+}
+
+func TestLiquidationDetector_DetectOpportunity_OracleUpdate(t *testing.T) {
+	market := newTestLiquidationMarket(t, true)
+	detector, _ := newTestLiquidationDetector(testLiquidationConfig(), market, nil, nil)
+	ctx := context.Background()
+
+	_, err := detector.ProcessLogs(ctx, []*ethtypes.Log{answerUpdatedLog(wethFeed, 3000), answerUpdatedLog(usdcFeed, 1)})
+	require.NoError(t, err)
+
+	tx := &types.Transaction{Hash: "0xfeedupdate", GasPrice: big.NewInt(1000000), ChainID: big.NewInt(84532)}
+	simResult := &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{answerUpdatedLog(wethFeed, 2800)}}
+
+	opportunities, err := detector.DetectOpportunity(ctx, tx, simResult)
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+	assert.Equal(t, "0xfeedupdate", opportunities[0].TargetTx)
+	assert.Equal(t, pendingLiquidationConfidence, opportunities[0].Confidence)
+	assert.Equal(t, big.NewInt(800000*1000000), opportunities[0].GasCost)
+	assert.Equal(t, big.NewInt(84532), opportunities[0].ExecutionTxs[0].ChainID, "sent on the trigger's chain")
+
+	liquidation := opportunities[0].Metadata["liquidation_opportunity"].(*interfaces.LiquidationOpportunity)
+	assert.Equal(t, "0xfeedupdate", liquidation.TriggerTx)
+
+	// The pending update doesn't change confirmed prices
+	healthFactor, err := detector.HealthFactor(lending.BaseAaveV3Pool, liquidationUser)
+	require.NoError(t, err)
+	assert.Greater(t, healthFactor, 1.0)
+
+	// Failed simulations and unrelated logs are ignored
+	opportunities, err = detector.DetectOpportunity(ctx, tx, &interfaces.SimulationResult{Success: false, Logs: simResult.Logs})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+
+	opportunities, err = detector.DetectOpportunity(ctx, tx, &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{{Address: wethUSDCTestPool}}})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+}
+
+func TestLiquidationDetector_DetectOpportunity_Swap(t *testing.T) {
+	oracle := newTestPricingOracle(t)
+	parser := &fakeEventParser{events: []*interfaces.ParsedEvent{{
+		SyncEvent: &interfaces.SyncEvent{
+			Protocol: interfaces.ProtocolUniswapV2,
+			Pool:     wethUSDCTestPool,
+			Reserve0: new(big.Int).Mul(big.NewInt(100), big.NewInt(1e18)),
+			Reserve1: big.NewInt(280000_000000),
+		},
+	}}}
+
+	market := newTestLiquidationMarket(t, false)
+	detector, _ := newTestLiquidationDetector(testLiquidationConfig(), market, oracle, parser)
+
+	// A pending swap that moves WETH to $2800 makes the position liquidatable
+	tx := &types.Transaction{Hash: "0xswap"}
+	simResult := &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{{Address: wethUSDCTestPool, Topics: []common.Hash{{}}}}}
+
+	opportunities, err := detector.DetectOpportunity(context.Background(), tx, simResult)
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+	assert.Equal(t, "0xswap", opportunities[0].TargetTx)
+
+	// Profit is normalized with the confirmed oracle
+	require.NotNil(t, opportunities[0].NetProfitETH)
+	assert.InDelta(t, 531, opportunities[0].NetProfitUSD, 1e-3)
+
+	// The swap is priced on a fork; the confirmed mirror still has WETH at $3000
+	price, err := oracle.PriceUSD(pricing.BaseWETH)
+	require.NoError(t, err)
+	assert.InDelta(t, 3000, price, 1e-6)
+}
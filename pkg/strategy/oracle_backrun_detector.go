@@ -0,0 +1,407 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/oracles"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+const (
+	// oracleBackrunSearchSteps bounds the ternary search for the most profitable trade size
+	oracleBackrunSearchSteps = 80
+	// oracleBackrunProbeDivisor sizes the probe trade that measures the pool's price
+	// as a fraction of the maximum trade
+	oracleBackrunProbeDivisor = 1000
+)
+
+// oracleBackrunDetector implements the OracleBackrunDetector interface
+type oracleBackrunDetector struct {
+	config      atomic.Pointer[interfaces.OracleBackrunConfig]
+	priceOracle interfaces.PriceOracle
+	adapters    interfaces.ProtocolAdapterRegistry
+	tokens      interfaces.TokenRegistry
+	markets     []interfaces.LendingMarket
+	aggregators map[common.Address]*interfaces.OracleFeed
+	pythFeeds   map[common.Hash]*interfaces.OracleFeed
+	builder     interfaces.TransactionBuilder
+	capital     interfaces.CapitalSource
+}
+
+// oracleBackrunTrade is a swap through a pool that moves its price toward an oracle update
+type oracleBackrunTrade struct {
+	feed      *interfaces.OracleFeed
+	pool      *interfaces.PoolState
+	adapter   interfaces.ProtocolAdapter
+	tokenIn   *interfaces.TokenInfo
+	tokenOut  *interfaces.TokenInfo
+	priceIn   float64 // USD per whole token
+	priceOut  float64 // USD per whole token
+	gapBps    int64
+	amountIn  *big.Int
+	amountOut *big.Int
+	profit    *big.Int // In raw units of tokenIn
+	profitETH *big.Int
+}
+
+// NewOracleBackrunDetector creates a detector for the configured feeds. The price oracle
+// supplies the pools trading each feed's token and the USD prices of their other tokens,
+// the adapters quote swaps, and the token registry supplies decimals. Lending markets are
+// only reported as consumers; liquidations are left to the LiquidationDetector. The
+// transaction builder option builds the swaps; without one no opportunities are reported.
+// With a capital source, trades spend at most the capital available in the token sold.
+func NewOracleBackrunDetector(
+	config *interfaces.OracleBackrunConfig,
+	priceOracle interfaces.PriceOracle,
+	adapters interfaces.ProtocolAdapterRegistry,
+	tokens interfaces.TokenRegistry,
+	markets []interfaces.LendingMarket,
+	options ...DetectorOption,
+) interfaces.OracleBackrunDetector {
+	if config == nil {
+		config = &interfaces.OracleBackrunConfig{
+			PythContract:       oracles.BasePyth,
+			MinPriceGapBps:     10,
+			MaxTradeSize:       new(big.Int).Mul(big.NewInt(50), big.NewInt(1e18)), // 50 ETH
+			MinProfitThreshold: big.NewInt(2e15),                                   // 0.002 ETH
+		}
+	}
+
+	aggregators := make(map[common.Address]*interfaces.OracleFeed)
+	pythFeeds := make(map[common.Hash]*interfaces.OracleFeed)
+	for _, feed := range config.Feeds {
+		switch feed.Source {
+		case interfaces.OracleChainlink:
+			aggregators[feed.Address] = feed
+		case interfaces.OraclePyth:
+			pythFeeds[feed.PriceID] = feed
+		}
+	}
+
+	deps := applyDetectorOptions(options)
+	detector := &oracleBackrunDetector{
+		priceOracle: priceOracle,
+		adapters:    adapters,
+		tokens:      tokens,
+		markets:     markets,
+		aggregators: aggregators,
+		pythFeeds:   pythFeeds,
+		builder:     deps.builder,
+		capital:     deps.capital,
+	}
+	detector.config.Store(config)
+	return detector
+}
+
+// DecodeUpdates returns the prices a pending transmit or updatePriceFeeds call pushes
+// to configured feeds. Transactions to other contracts return nothing.
+func (o *oracleBackrunDetector) DecodeUpdates(tx *types.Transaction) ([]*interfaces.OracleUpdate, error) {
+	if tx == nil || tx.To == nil {
+		return nil, nil
+	}
+
+	if feed, exists := o.aggregators[*tx.To]; exists {
+		if !oracles.IsChainlinkTransmit(tx.Data) {
+			return nil, nil
+		}
+		report, err := oracles.DecodeChainlinkTransmit(tx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode transmit to %s: %w", tx.To.Hex(), err)
+		}
+
+		decimals := feed.Decimals
+		if decimals == 0 {
+			decimals = defaultPriceFeedDecimals
+		}
+		return []*interfaces.OracleUpdate{
+			newOracleUpdate(feed, report.Answer, -int32(decimals), uint64(report.ObservationsTimestamp)),
+		}, nil
+	}
+
+	if *tx.To == o.config.Load().PythContract && oracles.IsPythUpdate(tx.Data) {
+		prices, err := oracles.DecodePythUpdate(tx.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode Pyth update: %w", err)
+		}
+
+		var updates []*interfaces.OracleUpdate
+		for _, price := range prices {
+			if feed, exists := o.pythFeeds[price.PriceID]; exists {
+				updates = append(updates, newOracleUpdate(feed, big.NewInt(price.Price), price.Expo, price.PublishTime))
+			}
+		}
+		return updates, nil
+	}
+
+	return nil, nil
+}
+
+// Consumers returns the mirrored pools trading the update's token and the lending
+// markets that read its feed. Pyth feeds are matched to markets by token, since
+// lending assets only record Chainlink aggregators.
+func (o *oracleBackrunDetector) Consumers(update *interfaces.OracleUpdate) *interfaces.OracleConsumers {
+	consumers := &interfaces.OracleConsumers{}
+	if update == nil || update.Feed == nil {
+		return consumers
+	}
+
+	if o.priceOracle != nil {
+		consumers.Pools = o.priceOracle.Pools(update.Feed.Token)
+	}
+
+	for _, market := range o.markets {
+		for _, asset := range market.Assets() {
+			chainlink := update.Feed.Source == interfaces.OracleChainlink && asset.PriceFeed == update.Feed.Address
+			pyth := update.Feed.Source == interfaces.OraclePyth && asset.Token == update.Feed.Token
+			if chainlink || pyth {
+				consumers.LendingMarkets = append(consumers.LendingMarkets, market)
+				break
+			}
+		}
+	}
+
+	return consumers
+}
+
+// DetectOpportunity finds pools whose price, net of fees, is at least MinPriceGapBps
+// away from a pending oracle update and sizes a swap that moves each toward it.
+// Opportunities are sorted by profit, most profitable first.
+func (o *oracleBackrunDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) ([]*interfaces.BackrunOpportunity, error) {
+	// A reverting update leaves the price where it was
+	if simResult == nil || !simResult.Success || o.priceOracle == nil || o.adapters == nil || o.tokens == nil || o.builder == nil {
+		return nil, nil
+	}
+
+	updates, err := o.DecodeUpdates(tx)
+	if err != nil || len(updates) == 0 {
+		return nil, err
+	}
+
+	maxTradeUSD, err := o.priceOracle.ValueUSD(pricing.BaseWETH, o.config.Load().MaxTradeSize)
+	if err != nil || maxTradeUSD <= 0 {
+		return nil, nil
+	}
+
+	// Tokens priced by several updates in one Pyth call take their new prices
+	prices := make(map[common.Address]float64, len(updates))
+	for _, update := range updates {
+		prices[update.Feed.Token] = update.PriceUSD
+	}
+
+	var trades []*oracleBackrunTrade
+	for _, update := range updates {
+		if update.PriceUSD <= 0 {
+			continue
+		}
+		token, exists := o.tokens.GetToken(update.Feed.Token)
+		if !exists {
+			continue
+		}
+
+		for _, pool := range o.Consumers(update).Pools {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			adapter, exists := o.adapters.Adapter(pool.Protocol)
+			if !exists {
+				continue
+			}
+
+			for _, otherAddress := range pool.Tokens {
+				if otherAddress == token.Address {
+					continue
+				}
+				other, exists := o.tokens.GetToken(otherAddress)
+				if !exists {
+					continue
+				}
+				otherPrice, err := o.priceUSD(prices, otherAddress)
+				if err != nil || otherPrice <= 0 {
+					continue
+				}
+
+				// Buy the token below the new price, or sell it above
+				directions := []*oracleBackrunTrade{
+					{feed: update.Feed, pool: pool, adapter: adapter, tokenIn: other, tokenOut: token, priceIn: otherPrice, priceOut: update.PriceUSD},
+					{feed: update.Feed, pool: pool, adapter: adapter, tokenIn: token, tokenOut: other, priceIn: update.PriceUSD, priceOut: otherPrice},
+				}
+				for _, trade := range directions {
+					if o.sizeTrade(trade, maxTradeUSD) {
+						trades = append(trades, trade)
+					}
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].profitETH.Cmp(trades[j].profitETH) > 0
+	})
+
+	opportunities := make([]*interfaces.BackrunOpportunity, 0, len(trades))
+	for _, trade := range trades {
+		opportunity, err := o.buildOpportunity(ctx, tx, trade)
+		if err != nil {
+			return nil, err
+		}
+		opportunities = append(opportunities, opportunity)
+	}
+
+	return opportunities, nil
+}
+
+// GetConfiguration returns the current configuration
+func (o *oracleBackrunDetector) GetConfiguration() *interfaces.OracleBackrunConfig {
+	return o.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (o *oracleBackrunDetector) SetConfiguration(config *interfaces.OracleBackrunConfig) {
+	o.config.Store(config)
+}
+
+// priceUSD returns a token's USD price, preferring prices pushed by the pending transaction
+func (o *oracleBackrunDetector) priceUSD(prices map[common.Address]float64, token common.Address) (float64, error) {
+	if price, exists := prices[token]; exists {
+		return price, nil
+	}
+	return o.priceOracle.PriceUSD(token)
+}
+
+// sizeTrade checks the pool's price against the oracle with a small probe trade and,
+// when the gap is wide enough, searches for the most profitable amount in. It reports
+// whether the trade clears the profit threshold.
+func (o *oracleBackrunDetector) sizeTrade(trade *oracleBackrunTrade, maxTradeUSD float64) bool {
+	config := o.config.Load()
+	maxIn := usdToAmount(maxTradeUSD, trade.priceIn, trade.tokenIn.Decimals)
+	probe := new(big.Int).Div(maxIn, big.NewInt(oracleBackrunProbeDivisor))
+	if probe.Sign() <= 0 {
+		return false
+	}
+	if maxIn = capToCapital(o.capital, trade.tokenIn.Address, maxIn); maxIn.Cmp(probe) < 0 {
+		return false // Too little capital to trade the gap
+	}
+
+	// The probe's output over its input, at oracle prices, measures the gap net of fees
+	probeOut := trade.quote(probe)
+	if probeOut == nil {
+		return false
+	}
+	probeInUSD := tokenAmount(probe, trade.tokenIn.Decimals) * trade.priceIn
+	probeOutUSD := tokenAmount(probeOut, trade.tokenOut.Decimals) * trade.priceOut
+	gapBps := (probeOutUSD/probeInUSD - 1) * 10000
+	if gapBps < float64(config.MinPriceGapBps) || math.IsInf(gapBps, 0) || math.IsNaN(gapBps) {
+		return false
+	}
+	trade.gapBps = int64(gapBps)
+
+	// Profit is concave in the amount in for every supported curve
+	low, high := big.NewInt(0), maxIn
+	for i := 0; i < oracleBackrunSearchSteps && new(big.Int).Sub(high, low).Cmp(big.NewInt(2)) > 0; i++ {
+		third := new(big.Int).Sub(high, low)
+		third.Div(third, big.NewInt(3))
+		m1 := new(big.Int).Add(low, third)
+		m2 := new(big.Int).Sub(high, third)
+		if trade.profitUSD(m1) < trade.profitUSD(m2) {
+			low = m1
+		} else {
+			high = m2
+		}
+	}
+
+	amountIn := new(big.Int).Add(low, high)
+	amountIn.Rsh(amountIn, 1)
+	profitUSD := trade.profitUSD(amountIn)
+	if profitUSD <= 0 {
+		return false
+	}
+
+	trade.amountIn = amountIn
+	trade.amountOut = trade.quote(amountIn)
+	trade.profit = usdToAmount(profitUSD, trade.priceIn, trade.tokenIn.Decimals)
+
+	profitETH, err := o.priceOracle.ValueETH(trade.tokenIn.Address, trade.profit)
+	if err != nil || profitETH.Cmp(config.MinProfitThreshold) < 0 {
+		return false
+	}
+	trade.profitETH = profitETH
+	return true
+}
+
+// buildOpportunity builds the swap from the searcher through the executor contract,
+// requiring at least the break-even output at oracle prices
+func (o *oracleBackrunDetector) buildOpportunity(ctx context.Context, tx *types.Transaction, trade *oracleBackrunTrade) (*interfaces.BackrunOpportunity, error) {
+	inUSD := tokenAmount(trade.amountIn, trade.tokenIn.Decimals) * trade.priceIn
+	amountOutMin := minBigInt(usdToAmount(inUSD, trade.priceOut, trade.tokenOut.Decimals), trade.amountOut)
+
+	chainID := tx.ChainID
+	if chainID == nil {
+		chainID = big.NewInt(baseChainID)
+	}
+	txs, err := o.builder.BuildSwaps(ctx, []*interfaces.SwapRoute{{
+		Hops: []interfaces.SwapHop{{
+			Pool:     trade.pool.Address,
+			Protocol: trade.pool.Protocol,
+			TokenIn:  trade.tokenIn.Address,
+			TokenOut: trade.tokenOut.Address,
+		}},
+		AmountIn:     trade.amountIn,
+		MinAmountOut: amountOutMin,
+		GasPrice:     tx.GasPrice,
+	}}, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build backrun through %s: %w", trade.pool.Address.Hex(), err)
+	}
+
+	return &interfaces.BackrunOpportunity{
+		TargetTx:       tx,
+		ArbitrageTx:    txs[0],
+		Pool1:          trade.pool.Address.Hex(),
+		Pool2:          trade.feed.Address.Hex(), // The oracle is the reference price
+		Token:          trade.tokenIn.Address.Hex(),
+		PriceGap:       big.NewInt(trade.gapBps),
+		OptimalAmount:  trade.amountIn,
+		ExpectedProfit: trade.profit,
+	}, nil
+}
+
+// quote returns the pool's output for an amount in, or nil if the pool can't fill it
+func (t *oracleBackrunTrade) quote(amountIn *big.Int) *big.Int {
+	out, err := t.adapter.GetAmountOut(t.pool, t.tokenIn.Address, t.tokenOut.Address, amountIn)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// profitUSD values a swap's output against its input at oracle prices
+func (t *oracleBackrunTrade) profitUSD(amountIn *big.Int) float64 {
+	if amountIn.Sign() <= 0 {
+		return 0
+	}
+	out := t.quote(amountIn)
+	if out == nil {
+		return math.Inf(-1)
+	}
+	return tokenAmount(out, t.tokenOut.Decimals)*t.priceOut - tokenAmount(amountIn, t.tokenIn.Decimals)*t.priceIn
+}
+
+// newOracleUpdate scales a raw answer by 10^exponent
+func newOracleUpdate(feed *interfaces.OracleFeed, answer *big.Int, exponent int32, publishTime uint64) *interfaces.OracleUpdate {
+	price, _ := new(big.Float).SetInt(answer).Float64()
+	return &interfaces.OracleUpdate{
+		Feed:        feed,
+		Answer:      answer,
+		Exponent:    exponent,
+		PriceUSD:    price * math.Pow10(int(exponent)),
+		PublishTime: publishTime,
+	}
+}
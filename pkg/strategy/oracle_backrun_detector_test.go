@@ -0,0 +1,294 @@
+package strategy
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/oracles"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oracleTestPool     = common.HexToAddress("0x1000000000000000000000000000000000000004")
+	oracleTestExecutor = common.HexToAddress("0x1100000000000000000000000000000000000033")
+	oracleTestSearcher = common.HexToAddress("0x5ea4c4e400000000000000000000000000000033")
+	ethUSDPythID       = common.HexToHash("0xff61491a931112ddf1bd8147cd1b641375f79f5825126d665480874634fd0ace")
+)
+
+const oracleTestABI = `[
+	{"name": "transmit", "type": "function", "stateMutability": "nonpayable", "outputs": [], "inputs": [
+		{"name": "reportContext", "type": "bytes32[3]"},
+		{"name": "report", "type": "bytes"},
+		{"name": "rs", "type": "bytes32[]"},
+		{"name": "ss", "type": "bytes32[]"},
+		{"name": "rawVs", "type": "bytes32"}
+	]},
+	{"name": "updatePriceFeeds", "type": "function", "stateMutability": "payable", "outputs": [], "inputs": [
+		{"name": "updateData", "type": "bytes[]"}
+	]}
+]`
+
+// transmitTx pushes an 8 decimal USD answer to the WETH aggregator
+func transmitTx(t *testing.T, priceUSD int64) *types.Transaction {
+	t.Helper()
+
+	parsed, err := abi.JSON(strings.NewReader(oracleTestABI))
+	require.NoError(t, err)
+	uint32Type, _ := abi.NewType("uint32", "", nil)
+	bytes32Type, _ := abi.NewType("bytes32", "", nil)
+	int192SliceType, _ := abi.NewType("int192[]", "", nil)
+	int192Type, _ := abi.NewType("int192", "", nil)
+	report, err := abi.Arguments{{Type: uint32Type}, {Type: bytes32Type}, {Type: int192SliceType}, {Type: int192Type}}.Pack(
+		uint32(1700000000), [32]byte{}, []*big.Int{big.NewInt(priceUSD * 1e8)}, big.NewInt(0))
+	require.NoError(t, err)
+
+	data, err := parsed.Pack("transmit", [3][32]byte{}, report, [][32]byte{}, [][32]byte{}, [32]byte{})
+	require.NoError(t, err)
+	return &types.Transaction{Hash: "0xtransmit", To: &wethFeed, GasPrice: big.NewInt(1e7), Data: data}
+}
+
+// pythUpdateTx pushes a single ETH/USD price with exponent -8 through the Pyth contract
+func pythUpdateTx(t *testing.T, priceID common.Hash, priceUSD int64) *types.Transaction {
+	t.Helper()
+
+	message := append([]byte{0}, priceID.Bytes()...)
+	message = binary.BigEndian.AppendUint64(message, uint64(priceUSD*1e8))
+	message = binary.BigEndian.AppendUint64(message, 0)
+	message = binary.BigEndian.AppendUint32(message, uint32(0xfffffff8)) // -8
+	message = binary.BigEndian.AppendUint64(message, 1700000000)
+	message = append(message, make([]byte, 24)...) // Previous publish time and EMA
+
+	update := []byte{'P', 'N', 'A', 'U', 1, 0, 0, 0, 0, 0, 1}
+	update = binary.BigEndian.AppendUint16(update, uint16(len(message)))
+	update = append(update, message...)
+	update = append(update, 0) // No proof nodes
+
+	parsed, err := abi.JSON(strings.NewReader(oracleTestABI))
+	require.NoError(t, err)
+	data, err := parsed.Pack("updatePriceFeeds", [][]byte{update})
+	require.NoError(t, err)
+	return &types.Transaction{Hash: "0xpyth", To: &oracles.BasePyth, GasPrice: big.NewInt(1e7), Data: data}
+}
+
+// newOracleBackrunTest mirrors a SushiSwap 100 WETH / 300k USDC pool, pricing WETH at $3000
+func newOracleBackrunTest(t *testing.T, markets ...interfaces.LendingMarket) interfaces.OracleBackrunDetector {
+	t.Helper()
+
+	tokens, err := pricing.NewTokenRegistry(nil, pricing.DefaultTokens())
+	require.NoError(t, err)
+	oracle, err := pricing.NewPriceOracle(nil, tokens, nil)
+	require.NoError(t, err)
+	require.NoError(t, oracle.UpdatePool(&interfaces.PoolState{
+		Protocol: interfaces.ProtocolSushiSwapV2,
+		Address:  oracleTestPool,
+		Tokens:   []common.Address{pricing.BaseWETH, pricing.BaseUSDC},
+		Reserves: []*big.Int{ether(100), big.NewInt(300000_000000)},
+		Fee:      3000,
+	}))
+
+	adapters := protocols.NewRegistry(nil)
+	require.NoError(t, adapters.Register(protocols.NewSushiSwapV2Adapter(protocols.BaseSushiSwapV2Router)))
+
+	builder := &recordingBuilder{searcher: oracleTestSearcher, executor: oracleTestExecutor, nonce: 4}
+	return NewOracleBackrunDetector(testOracleBackrunConfig(), oracle, adapters, tokens, markets, WithTransactionBuilder(builder))
+}
+
+func testOracleBackrunConfig() *interfaces.OracleBackrunConfig {
+	return &interfaces.OracleBackrunConfig{
+		Feeds: []*interfaces.OracleFeed{
+			{Source: interfaces.OracleChainlink, Address: wethFeed, Token: pricing.BaseWETH, Decimals: 8},
+			{Source: interfaces.OraclePyth, Address: oracles.BasePyth, PriceID: ethUSDPythID, Token: pricing.BaseWETH},
+		},
+		PythContract:       oracles.BasePyth,
+		MinPriceGapBps:     10,
+		MaxTradeSize:       ether(50),
+		MinProfitThreshold: big.NewInt(2e15),
+	}
+}
+
+func TestOracleBackrunDetector_DecodeUpdates(t *testing.T) {
+	detector := newOracleBackrunTest(t)
+
+	updates, err := detector.DecodeUpdates(transmitTx(t, 3100))
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, wethFeed, updates[0].Feed.Address)
+	assert.Equal(t, big.NewInt(3100e8), updates[0].Answer)
+	assert.Equal(t, int32(-8), updates[0].Exponent)
+	assert.InDelta(t, 3100.0, updates[0].PriceUSD, 1e-9)
+	assert.Equal(t, uint64(1700000000), updates[0].PublishTime)
+
+	updates, err = detector.DecodeUpdates(pythUpdateTx(t, ethUSDPythID, 2900))
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, interfaces.OraclePyth, updates[0].Feed.Source)
+	assert.InDelta(t, 2900.0, updates[0].PriceUSD, 1e-9)
+
+	// Feeds that aren't configured are ignored
+	updates, err = detector.DecodeUpdates(pythUpdateTx(t, common.HexToHash("0x01"), 2900))
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+
+	other := transmitTx(t, 3100)
+	other.To = &oracleTestPool
+	updates, err = detector.DecodeUpdates(other)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+
+	truncated := transmitTx(t, 3100)
+	truncated.Data = truncated.Data[:100]
+	_, err = detector.DecodeUpdates(truncated)
+	assert.Error(t, err)
+}
+
+func TestOracleBackrunDetector_Consumers(t *testing.T) {
+	detector := newOracleBackrunTest(t, newTestLiquidationMarket(t, true), newTestLiquidationMarket(t, false))
+
+	updates, err := detector.DecodeUpdates(transmitTx(t, 3100))
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+
+	consumers := detector.Consumers(updates[0])
+	require.Len(t, consumers.Pools, 1)
+	assert.Equal(t, oracleTestPool, consumers.Pools[0].Address)
+	// Only the market reading the aggregator consumes a Chainlink update
+	assert.Len(t, consumers.LendingMarkets, 1)
+
+	updates, err = detector.DecodeUpdates(pythUpdateTx(t, ethUSDPythID, 3100))
+	require.NoError(t, err)
+	assert.Len(t, detector.Consumers(updates[0]).LendingMarkets, 2)
+}
+
+func TestOracleBackrunDetector_DetectOpportunity(t *testing.T) {
+	tests := []struct {
+		name     string
+		tx       func(t *testing.T) *types.Transaction
+		tokenIn  common.Address
+		minGap   int64
+		maxInput *big.Int
+	}{
+		{
+			name:     "oracle above pool buys WETH",
+			tx:       func(t *testing.T) *types.Transaction { return transmitTx(t, 3100) },
+			tokenIn:  pricing.BaseUSDC,
+			minGap:   250,
+			maxInput: big.NewInt(10000_000000),
+		},
+		{
+			name:     "oracle below pool sells WETH",
+			tx:       func(t *testing.T) *types.Transaction { return pythUpdateTx(t, ethUSDPythID, 2900) },
+			tokenIn:  pricing.BaseWETH,
+			minGap:   250,
+			maxInput: ether(3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newOracleBackrunTest(t)
+			tx := tt.tx(t)
+
+			opportunities, err := detector.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true})
+			require.NoError(t, err)
+			require.Len(t, opportunities, 1)
+
+			opportunity := opportunities[0]
+			assert.Equal(t, tx, opportunity.TargetTx)
+			assert.Equal(t, oracleTestPool.Hex(), opportunity.Pool1)
+			assert.Equal(t, tt.tokenIn.Hex(), opportunity.Token)
+			assert.True(t, opportunity.PriceGap.Int64() >= tt.minGap, "gap %s", opportunity.PriceGap)
+			assert.True(t, opportunity.OptimalAmount.Sign() > 0)
+			assert.True(t, opportunity.OptimalAmount.Cmp(tt.maxInput) < 0, "amount %s", opportunity.OptimalAmount)
+			assert.True(t, opportunity.ExpectedProfit.Sign() > 0)
+
+			// The searcher swaps through the pool, requiring at least break-even at oracle prices
+			arbitrage := opportunity.ArbitrageTx
+			require.NotNil(t, arbitrage)
+			assert.Equal(t, oracleTestSearcher, arbitrage.From)
+			assert.Equal(t, uint64(4), arbitrage.Nonce)
+			assert.Equal(t, tx.GasPrice, arbitrage.GasPrice)
+
+			builder := detector.(*oracleBackrunDetector).builder.(*recordingBuilder)
+			require.Len(t, builder.routes, 1)
+			route := builder.routes[0]
+			require.Len(t, route.Hops, 1)
+			assert.Equal(t, oracleTestPool, route.Hops[0].Pool)
+			assert.Equal(t, interfaces.ProtocolSushiSwapV2, route.Hops[0].Protocol)
+			assert.Equal(t, tt.tokenIn, route.Hops[0].TokenIn)
+			assert.Equal(t, opportunity.OptimalAmount, route.AmountIn)
+			require.NotNil(t, route.MinAmountOut)
+			assert.True(t, route.MinAmountOut.Sign() > 0)
+		})
+	}
+}
+
+func TestOracleBackrunDetector_CapitalSource(t *testing.T) {
+	capital := fixedCapital{pricing.BaseUSDC: big.NewInt(2000_000000)}
+	detector := NewOracleBackrunDetector(nil, nil, nil, nil, nil, WithCapitalSource(capital)).(*oracleBackrunDetector)
+	assert.Equal(t, capital, detector.capital)
+
+	// Buying WETH spends at most the USDC available
+	detector = newOracleBackrunTest(t).(*oracleBackrunDetector)
+	detector.capital = capital
+	opportunities, err := detector.DetectOpportunity(context.Background(), transmitTx(t, 3100), &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+	assert.True(t, opportunities[0].OptimalAmount.Cmp(big.NewInt(2000_000000)) <= 0, "amount %s", opportunities[0].OptimalAmount)
+
+	// Without any, nothing is traded
+	detector.capital = fixedCapital{}
+	opportunities, err = detector.DetectOpportunity(context.Background(), transmitTx(t, 3100), &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+}
+
+func TestOracleBackrunDetector_NoOpportunity(t *testing.T) {
+	tests := []struct {
+		name      string
+		tx        func(t *testing.T) *types.Transaction
+		simResult *interfaces.SimulationResult
+	}{
+		{
+			name:      "reverted update",
+			tx:        func(t *testing.T) *types.Transaction { return transmitTx(t, 3100) },
+			simResult: &interfaces.SimulationResult{Success: false},
+		},
+		{
+			name:      "gap within the pool fee",
+			tx:        func(t *testing.T) *types.Transaction { return transmitTx(t, 3005) },
+			simResult: &interfaces.SimulationResult{Success: true},
+		},
+		{
+			name:      "not an oracle update",
+			tx:        func(t *testing.T) *types.Transaction { return &types.Transaction{Hash: "0xswap", To: &oracleTestPool} },
+			simResult: &interfaces.SimulationResult{Success: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newOracleBackrunTest(t)
+
+			opportunities, err := detector.DetectOpportunity(context.Background(), tt.tx(t), tt.simResult)
+			require.NoError(t, err)
+			assert.Empty(t, opportunities)
+		})
+	}
+
+	// Without a builder the swap can't be sent
+	detector := newOracleBackrunTest(t)
+	detector.(*oracleBackrunDetector).builder = nil
+	opportunities, err := detector.DetectOpportunity(context.Background(), transmitTx(t, 3100), &interfaces.SimulationResult{Success: true})
+	require.NoError(t, err)
+	assert.Empty(t, opportunities)
+}
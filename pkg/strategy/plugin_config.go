@@ -0,0 +1,342 @@
+package strategy
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+var (
+	bigIntType   = reflect.TypeOf((*big.Int)(nil))
+	durationType = reflect.TypeOf(time.Duration(0))
+	addressType  = reflect.TypeOf(common.Address{})
+)
+
+// configSection exposes a detector's configuration struct as strategy settings.
+// Settings are named after the struct fields in snake case, behind the prefix.
+type configSection struct {
+	prefix string
+	config interface{}              // Pointer to a configuration struct
+	set    func(config interface{}) // Swaps in an updated copy of the struct
+}
+
+// configFields returns the settable fields of a section by setting name. Fields
+// of types settings can't express, like maps, are left out.
+func (s configSection) configFields() map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	value := reflect.ValueOf(s.config)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fields
+	}
+
+	structType := value.Elem().Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.IsExported() && configType(field.Type) != "" {
+			fields[s.prefix+snakeCase(field.Name)] = field
+		}
+	}
+	return fields
+}
+
+// configSchema describes the settings of the sections, with their current values as defaults
+func configSchema(sections ...configSection) *interfaces.StrategyConfigSchema {
+	schema := &interfaces.StrategyConfigSchema{}
+	for _, section := range sections {
+		config := reflect.ValueOf(section.config)
+		fields := section.configFields()
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool { return fields[names[i]].Index[0] < fields[names[j]].Index[0] })
+
+		for _, name := range names {
+			field := fields[name]
+			schema.Fields = append(schema.Fields, interfaces.StrategyConfigField{
+				Name:    name,
+				Type:    configType(field.Type),
+				Default: configValue(config.Elem().FieldByIndex(field.Index)),
+			})
+		}
+	}
+	return schema
+}
+
+// applySettings decodes settings into copies of the sections' configuration structs
+// and swaps the copies in, so detectors reading a configuration never see it change.
+// Every setting is decoded before any is applied, so a bad setting changes nothing.
+func applySettings(settings map[string]interface{}, sections ...configSection) error {
+	updated := make([]reflect.Value, len(sections))
+	for i, section := range sections {
+		config := reflect.ValueOf(section.config)
+		if config.Kind() != reflect.Ptr || config.IsNil() {
+			continue
+		}
+		updated[i] = reflect.New(config.Elem().Type()).Elem()
+		updated[i].Set(config.Elem())
+	}
+
+	for name, raw := range settings {
+		found := false
+		for i, section := range sections {
+			field, exists := section.configFields()[name]
+			if !exists {
+				continue
+			}
+			value, err := decodeSetting(field.Type, raw)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			updated[i].FieldByIndex(field.Index).Set(value)
+			found = true
+			break
+		}
+		if !found {
+			return fmt.Errorf("unknown setting %s", name)
+		}
+	}
+
+	for i, section := range sections {
+		if updated[i].IsValid() && section.set != nil {
+			section.set(updated[i].Addr().Interface())
+		}
+	}
+	return nil
+}
+
+// configType returns the setting type of a struct field type, or "" if settings can't express it
+func configType(t reflect.Type) interfaces.StrategyConfigType {
+	switch t {
+	case bigIntType:
+		return interfaces.ConfigTypeBigInt
+	case durationType:
+		return interfaces.ConfigTypeDuration
+	case addressType:
+		return interfaces.ConfigTypeAddress
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return interfaces.ConfigTypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return interfaces.ConfigTypeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return interfaces.ConfigTypeUint
+	case reflect.Float32, reflect.Float64:
+		return interfaces.ConfigTypeFloat
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.String {
+			return interfaces.ConfigTypeStringList
+		}
+		if t.Elem() == addressType {
+			return interfaces.ConfigTypeAddressList
+		}
+	}
+	return ""
+}
+
+// configValue returns a field's value as it is written in settings
+func configValue(value reflect.Value) interface{} {
+	switch value.Type() {
+	case bigIntType:
+		if value.IsNil() {
+			return nil
+		}
+		return value.Interface().(*big.Int).String()
+	case durationType:
+		return value.Interface().(time.Duration).String()
+	case addressType:
+		return value.Interface().(common.Address).Hex()
+	}
+	if value.Kind() == reflect.Slice && value.Type().Elem() == addressType {
+		addresses := make([]string, value.Len())
+		for i := range addresses {
+			addresses[i] = value.Index(i).Interface().(common.Address).Hex()
+		}
+		return addresses
+	}
+	return value.Interface()
+}
+
+// decodeSetting converts a setting as read from YAML or JSON to a field's type
+func decodeSetting(t reflect.Type, raw interface{}) (reflect.Value, error) {
+	switch t {
+	case bigIntType:
+		n, ok := parseBigInt(raw)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v is not an integer", raw)
+		}
+		return reflect.ValueOf(n), nil
+	case durationType:
+		if d, ok := raw.(time.Duration); ok {
+			return reflect.ValueOf(d), nil
+		}
+		s, ok := raw.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v is not a duration", raw)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(d), nil
+	case addressType:
+		address, err := parseAddress(raw)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(address), nil
+	}
+
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v is not a boolean", raw)
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := parseBigInt(raw)
+		if !ok || !n.IsInt64() || value.OverflowInt(n.Int64()) {
+			return reflect.Value{}, fmt.Errorf("%v is not a %s", raw, t.Kind())
+		}
+		value.SetInt(n.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := parseBigInt(raw)
+		if !ok || !n.IsUint64() || value.OverflowUint(n.Uint64()) {
+			return reflect.Value{}, fmt.Errorf("%v is not a %s", raw, t.Kind())
+		}
+		value.SetUint(n.Uint64())
+	case reflect.Float32, reflect.Float64:
+		f, ok := parseFloat(raw)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v is not a number", raw)
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		items, ok := raw.([]interface{})
+		if list, isList := raw.([]string); isList {
+			items, ok = make([]interface{}, len(list)), true
+			for i, s := range list {
+				items[i] = s
+			}
+		}
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("%v is not a list", raw)
+		}
+		value = reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			if t.Elem() == addressType {
+				address, err := parseAddress(item)
+				if err != nil {
+					return reflect.Value{}, err
+				}
+				value.Index(i).Set(reflect.ValueOf(address))
+				continue
+			}
+			s, ok := item.(string)
+			if !ok {
+				return reflect.Value{}, fmt.Errorf("%v is not a string", item)
+			}
+			value.Index(i).SetString(s)
+		}
+	default:
+		return reflect.Value{}, fmt.Errorf("unsupported type %s", t)
+	}
+	return value, nil
+}
+
+// parseBigInt reads an integer given as a number or a decimal or hex string
+func parseBigInt(raw interface{}) (*big.Int, bool) {
+	switch v := raw.(type) {
+	case string:
+		return new(big.Int).SetString(v, 0)
+	case int:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case int32:
+		return big.NewInt(int64(v)), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), true
+	case float64:
+		// JSON numbers; only exact integers are accepted
+		if v != math.Trunc(v) || math.IsInf(v, 0) {
+			return nil, false
+		}
+		n, _ := big.NewFloat(v).Int(nil)
+		return n, true
+	case *big.Int:
+		if v == nil {
+			return nil, false
+		}
+		return new(big.Int).Set(v), true
+	}
+
+	// Other integer types, as in values read back from a schema
+	value := reflect.ValueOf(raw)
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(value.Uint()), true
+	}
+	return nil, false
+}
+
+// parseFloat reads a number given as a number or a string
+func parseFloat(raw interface{}) (float64, bool) {
+	switch v := raw.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	if n, ok := parseBigInt(raw); ok {
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f, true
+	}
+	return 0, false
+}
+
+// parseAddress reads a hex address
+func parseAddress(raw interface{}) (common.Address, error) {
+	s, ok := raw.(string)
+	if !ok || (s != "" && !common.IsHexAddress(s)) {
+		return common.Address{}, fmt.Errorf("%v is not an address", raw)
+	}
+	return common.HexToAddress(s), nil
+}
+
+// snakeCase converts a Go field name to a setting name, as in MinProfitThreshold to min_profit_threshold
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previousLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if previousLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,85 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeSetting(t *testing.T) {
+	type settings struct {
+		Limit    uint16
+		Offset   int32
+		Window   time.Duration
+		Target   common.Address
+		Pools    []string
+		Tokens   []common.Address
+		Disabled bool
+	}
+	config := &settings{}
+	var applied *settings
+	section := configSection{prefix: "test_", config: config, set: func(updated interface{}) { applied = updated.(*settings) }}
+
+	schema := configSchema(section)
+	require.Len(t, schema.Fields, 7)
+	assert.Equal(t, "test_limit", schema.Fields[0].Name)
+	assert.Equal(t, interfaces.ConfigTypeUint, schema.Fields[0].Type)
+	assert.Equal(t, interfaces.ConfigTypeAddressList, schema.Fields[5].Type)
+
+	weth := "0x4200000000000000000000000000000000000006"
+	require.NoError(t, applySettings(map[string]interface{}{
+		"test_limit":    float64(500), // As decoded from JSON
+		"test_offset":   -3,
+		"test_window":   "90s",
+		"test_target":   weth,
+		"test_pools":    []interface{}{"uniswap_v3"},
+		"test_tokens":   []string{weth},
+		"test_disabled": true,
+	}, section))
+	assert.Equal(t, &settings{
+		Limit:    500,
+		Offset:   -3,
+		Window:   90 * time.Second,
+		Target:   common.HexToAddress(weth),
+		Pools:    []string{"uniswap_v3"},
+		Tokens:   []common.Address{common.HexToAddress(weth)},
+		Disabled: true,
+	}, applied)
+	assert.Equal(t, &settings{}, config, "the configuration in use is replaced, not modified")
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"test_limit", 70000}, // Overflows uint16
+		{"test_limit", 1.5},
+		{"test_offset", "three"},
+		{"test_window", 90},
+		{"test_target", "0x42"},
+		{"test_tokens", []interface{}{"weth"}},
+		{"test_disabled", "yes"},
+	}
+	applied = nil
+	for _, tt := range tests {
+		assert.Error(t, applySettings(map[string]interface{}{tt.name: tt.value}, section), "%s: %v", tt.name, tt.value)
+	}
+	assert.Nil(t, applied)
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"MinProfitThreshold": "min_profit_threshold",
+		"GasLimit":           "gas_limit",
+		"L1StandardBridge":   "l1_standard_bridge",
+		"MinPriceGapBps":     "min_price_gap_bps",
+		"PythContract":       "pyth_contract",
+		"MaxTPS":             "max_tps",
+	}
+	for name, expected := range tests {
+		assert.Equal(t, expected, snakeCase(name), name)
+	}
+}
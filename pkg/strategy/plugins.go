@@ -0,0 +1,605 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// sandwichStrategy plugs a SandwichDetector into the strategy processor
+type sandwichStrategy struct {
+	detector interfaces.SandwichDetector
+}
+
+// NewSandwichStrategy creates the sandwich strategy plugin
+func NewSandwichStrategy(detector interfaces.SandwichDetector) interfaces.Strategy {
+	return &sandwichStrategy{detector: detector}
+}
+
+func (s *sandwichStrategy) Name() interfaces.StrategyType { return interfaces.StrategySandwich }
+
+func (s *sandwichStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (s *sandwichStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: s.detector.GetConfiguration()})
+}
+
+func (s *sandwichStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: s.detector.GetConfiguration(),
+		set:    func(config interface{}) { s.detector.SetConfiguration(config.(*interfaces.SandwichConfig)) },
+	})
+}
+
+func (s *sandwichStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	tx := input.Transaction
+	opportunity, err := s.detector.DetectOpportunity(ctx, tx, input.SimulationResult)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+
+	// The legs go either side of the target; if they can't be built the opportunity
+	// is reported but not executable
+	var executionTxs []*types.Transaction
+	legs, err := s.detector.ConstructTransactions(ctx, opportunity)
+	if err == nil && len(legs) == 0 {
+		return nil, nil // Too little capital to clear the threshold
+	}
+	if err == nil {
+		executionTxs = []*types.Transaction{opportunity.FrontrunTx, tx, opportunity.BackrunTx}
+	}
+
+	// The back leg buys back the token the front leg sold, so the profit accrues in it
+	profitToken := hexAddress(opportunity.Token0)
+	gasCost := ownGasCost(opportunity.FrontrunTx, opportunity.BackrunTx)
+
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("sandwich_%s_%d", tx.Hash, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategySandwich,
+		TargetTx:       tx.Hash,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		NetProfit:      netOfGas(opportunity.ExpectedProfit, gasCost, profitToken),
+		ProfitToken:    profitToken,
+		Confidence:     0.8, // Default confidence
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   executionTxs,
+		Pools:          hexAddresses(opportunity.Pool),
+		Metadata: map[string]interface{}{
+			"sandwich_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// backrunStrategy plugs swap and oracle-update backrun detectors into the strategy
+// processor. Oracle backruns are tried first; either detector may be nil.
+type backrunStrategy struct {
+	detector      interfaces.BackrunDetector
+	oracleBackrun interfaces.OracleBackrunDetector
+}
+
+// NewBackrunStrategy creates the backrun strategy plugin. Oracle backrun settings
+// are prefixed with oracle_.
+func NewBackrunStrategy(detector interfaces.BackrunDetector, oracleBackrun interfaces.OracleBackrunDetector) interfaces.Strategy {
+	return &backrunStrategy{detector: detector, oracleBackrun: oracleBackrun}
+}
+
+func (b *backrunStrategy) Name() interfaces.StrategyType { return interfaces.StrategyBackrun }
+
+func (b *backrunStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (b *backrunStrategy) sections() []configSection {
+	var sections []configSection
+	if b.detector != nil {
+		sections = append(sections, configSection{
+			config: b.detector.GetConfiguration(),
+			set:    func(config interface{}) { b.detector.SetConfiguration(config.(*interfaces.BackrunConfig)) },
+		})
+	}
+	if b.oracleBackrun != nil {
+		sections = append(sections, configSection{
+			prefix: "oracle_",
+			config: b.oracleBackrun.GetConfiguration(),
+			set:    func(config interface{}) { b.oracleBackrun.SetConfiguration(config.(*interfaces.OracleBackrunConfig)) },
+		})
+	}
+	return sections
+}
+
+func (b *backrunStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(b.sections()...)
+}
+
+func (b *backrunStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, b.sections()...)
+}
+
+func (b *backrunStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	tx := input.Transaction
+
+	var opportunity *interfaces.BackrunOpportunity
+	if b.oracleBackrun != nil {
+		opportunities, err := b.oracleBackrun.DetectOpportunity(ctx, tx, input.SimulationResult)
+		if err != nil {
+			return nil, err
+		}
+		if len(opportunities) > 0 {
+			opportunity = opportunities[0]
+		}
+	}
+
+	if opportunity == nil && b.detector != nil {
+		var err error
+		opportunity, err = b.detector.DetectOpportunity(ctx, tx, input.SimulationResult)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if opportunity == nil {
+		return nil, nil
+	}
+
+	// Arbitrage profit accrues in the token cycled through the pools
+	profitToken := hexAddress(opportunity.Token)
+	gasCost := ownGasCost(opportunity.ArbitrageTx)
+
+	// The arbitrage goes right behind the target
+	var executionTxs []*types.Transaction
+	if opportunity.ArbitrageTx != nil {
+		executionTxs = []*types.Transaction{tx, opportunity.ArbitrageTx}
+	}
+
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("backrun_%s_%d", tx.Hash, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyBackrun,
+		TargetTx:       tx.Hash,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		NetProfit:      netOfGas(opportunity.ExpectedProfit, gasCost, profitToken),
+		ProfitToken:    profitToken,
+		Confidence:     0.8, // Default confidence
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   executionTxs,
+		Pools:          hexAddresses(opportunity.Pool1, opportunity.Pool2),
+		Metadata: map[string]interface{}{
+			"backrun_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// frontrunStrategy plugs a FrontrunDetector into the strategy processor
+type frontrunStrategy struct {
+	detector interfaces.FrontrunDetector
+}
+
+// NewFrontrunStrategy creates the frontrun strategy plugin
+func NewFrontrunStrategy(detector interfaces.FrontrunDetector) interfaces.Strategy {
+	return &frontrunStrategy{detector: detector}
+}
+
+func (f *frontrunStrategy) Name() interfaces.StrategyType { return interfaces.StrategyFrontrun }
+
+func (f *frontrunStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (f *frontrunStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: f.detector.GetConfiguration()})
+}
+
+func (f *frontrunStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: f.detector.GetConfiguration(),
+		set:    func(config interface{}) { f.detector.SetConfiguration(config.(*interfaces.FrontrunConfig)) },
+	})
+}
+
+func (f *frontrunStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	tx := input.Transaction
+	opportunity, err := f.detector.DetectOpportunity(ctx, tx, input.SimulationResult)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+
+	// The position is bought ahead of the target and valued in the token it was
+	// bought with
+	profitToken := hexAddress(opportunity.TokenIn)
+	gasCost := ownGasCost(opportunity.FrontrunTx)
+
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("frontrun_%s_%d", tx.Hash, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyFrontrun,
+		TargetTx:       tx.Hash,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		NetProfit:      netOfGas(opportunity.ExpectedProfit, gasCost, profitToken),
+		ProfitToken:    profitToken,
+		Confidence:     0.8, // Default confidence
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   []*types.Transaction{opportunity.FrontrunTx, tx},
+		Metadata: map[string]interface{}{
+			"frontrun_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// timeBanditStrategy plugs a TimeBanditDetector into the strategy processor. Batches
+// of pending transactions are searched for a better order; included blocks are
+// replayed to measure the value a different order would have extracted.
+type timeBanditStrategy struct {
+	detector interfaces.TimeBanditDetector
+}
+
+// NewTimeBanditStrategy creates the time bandit strategy plugin
+func NewTimeBanditStrategy(detector interfaces.TimeBanditDetector) interfaces.Strategy {
+	return &timeBanditStrategy{detector: detector}
+}
+
+func (t *timeBanditStrategy) Name() interfaces.StrategyType { return interfaces.StrategyTimeBandit }
+
+func (t *timeBanditStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputBatch, interfaces.InputBlock}
+}
+
+func (t *timeBanditStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: t.detector.GetConfiguration()})
+}
+
+func (t *timeBanditStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: t.detector.GetConfiguration(),
+		set:    func(config interface{}) { t.detector.SetConfiguration(config.(*interfaces.TimeBanditConfig)) },
+	})
+}
+
+func (t *timeBanditStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	if input.Kind == interfaces.InputBlock {
+		return t.analyzeBlock(ctx, input.Block)
+	}
+
+	opportunity, err := t.detector.DetectOpportunity(ctx, input.Transactions, input.SimulationResults)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+
+	var target string
+	if len(opportunity.OriginalTxs) > 0 {
+		target = opportunity.OriginalTxs[0].Hash
+	}
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("time_bandit_%s_%d", target, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyTimeBandit,
+		TargetTx:       target,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        big.NewInt(0),
+		NetProfit:      opportunity.ExpectedProfit,
+		Confidence:     0.5, // The sequencer decides the final order
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   opportunity.OptimalOrder,
+		Metadata: map[string]interface{}{
+			"time_bandit_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// analyzeBlock reports the value reordering the latest buffered blocks would have
+// extracted. It is a measurement of missed MEV, so nothing is executable.
+func (t *timeBanditStrategy) analyzeBlock(ctx context.Context, block *interfaces.HistoricalBlock) ([]*interfaces.MEVOpportunity, error) {
+	analysis, err := t.detector.AnalyzeHistory(ctx)
+	if err != nil || analysis == nil || analysis.ExtractableValue == nil || analysis.ExtractableValue.Sign() <= 0 {
+		return nil, err
+	}
+
+	var target string
+	if block != nil {
+		target = block.Hash.Hex()
+	}
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("time_bandit_%d_%d_%d", analysis.FromBlock, analysis.ToBlock, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyTimeBandit,
+		TargetTx:       target,
+		ExpectedProfit: analysis.ExtractableValue,
+		GasCost:        big.NewInt(0),
+		NetProfit:      analysis.ExtractableValue,
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		Metadata: map[string]interface{}{
+			"time_bandit_analysis": analysis,
+		},
+	}}, nil
+}
+
+// jitStrategy plugs a JITDetector into the strategy processor
+type jitStrategy struct {
+	detector interfaces.JITDetector
+}
+
+// NewJITStrategy creates the just-in-time liquidity strategy plugin
+func NewJITStrategy(detector interfaces.JITDetector) interfaces.Strategy {
+	return &jitStrategy{detector: detector}
+}
+
+func (j *jitStrategy) Name() interfaces.StrategyType { return interfaces.StrategyJIT }
+
+func (j *jitStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (j *jitStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: j.detector.GetConfiguration()})
+}
+
+func (j *jitStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: j.detector.GetConfiguration(),
+		set:    func(config interface{}) { j.detector.SetConfiguration(config.(*interfaces.JITConfig)) },
+	})
+}
+
+func (j *jitStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	tx := input.Transaction
+	opportunity, err := j.detector.DetectOpportunity(ctx, tx, input.SimulationResult)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+
+	// The mint goes ahead of the target swap, the burn and collect behind it
+	bundle, err := j.detector.ConstructBundle(ctx, opportunity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mint, burn and collect are priced like the target they surround
+	gasCost := big.NewInt(0)
+	if tx.GasPrice != nil {
+		gasCost.Mul(tx.GasPrice, new(big.Int).SetUint64(j.detector.GetConfiguration().GasLimit))
+	}
+
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("jit_%s_%d", tx.Hash, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategyJIT,
+		TargetTx:       tx.Hash,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		NetProfit:      new(big.Int).Sub(opportunity.ExpectedProfit, gasCost),
+		Confidence:     0.7, // The swap can land at another price than simulated
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   bundle,
+		Pools:          []common.Address{opportunity.Pool},
+		Metadata: map[string]interface{}{
+			"jit_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// snipingStrategy plugs a SnipingDetector into the strategy processor
+type snipingStrategy struct {
+	detector interfaces.SnipingDetector
+}
+
+// NewSnipingStrategy creates the new-pool sniping strategy plugin
+func NewSnipingStrategy(detector interfaces.SnipingDetector) interfaces.Strategy {
+	return &snipingStrategy{detector: detector}
+}
+
+func (s *snipingStrategy) Name() interfaces.StrategyType { return interfaces.StrategySniping }
+
+func (s *snipingStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction}
+}
+
+func (s *snipingStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: s.detector.GetConfiguration()})
+}
+
+func (s *snipingStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: s.detector.GetConfiguration(),
+		set:    func(config interface{}) { s.detector.SetConfiguration(config.(*interfaces.SnipingConfig)) },
+	})
+}
+
+func (s *snipingStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	tx := input.Transaction
+	opportunity, err := s.detector.DetectOpportunity(ctx, tx, input.SimulationResult)
+	if err != nil || opportunity == nil {
+		return nil, err
+	}
+
+	// The profit is in the quote token, so gas is only netted out when pricing in ETH
+	gasCost := big.NewInt(0)
+	if buy := opportunity.BuyTx; buy.GasPrice != nil {
+		gasCost.Mul(buy.GasPrice, new(big.Int).SetUint64(buy.GasLimit))
+	}
+
+	return []*interfaces.MEVOpportunity{{
+		ID:             fmt.Sprintf("sniping_%s_%d", tx.Hash, time.Now().UnixNano()),
+		Strategy:       interfaces.StrategySniping,
+		TargetTx:       tx.Hash,
+		ExpectedProfit: opportunity.ExpectedProfit,
+		GasCost:        gasCost,
+		NetProfit:      opportunity.ExpectedProfit,
+		ProfitToken:    opportunity.QuoteToken,
+		Confidence:     0.5, // Depends on buyers arriving after the launch
+		Status:         interfaces.StatusDetected,
+		CreatedAt:      time.Now(),
+		ExecutionTxs:   []*types.Transaction{opportunity.BuyTx},
+		Pools:          []common.Address{opportunity.Pool},
+		Metadata: map[string]interface{}{
+			"sniping_opportunity": opportunity,
+		},
+	}}, nil
+}
+
+// liquidationStrategy plugs a LiquidationDetector into the strategy processor. Logs
+// update the mirrored positions; pending transactions can push them under water.
+type liquidationStrategy struct {
+	detector interfaces.LiquidationDetector
+}
+
+// NewLiquidationStrategy creates the liquidation strategy plugin
+func NewLiquidationStrategy(detector interfaces.LiquidationDetector) interfaces.Strategy {
+	return &liquidationStrategy{detector: detector}
+}
+
+func (l *liquidationStrategy) Name() interfaces.StrategyType { return interfaces.StrategyLiquidation }
+
+func (l *liquidationStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputTransaction, interfaces.InputEvent}
+}
+
+func (l *liquidationStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: l.detector.GetConfiguration()})
+}
+
+func (l *liquidationStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: l.detector.GetConfiguration(),
+		set:    func(config interface{}) { l.detector.SetConfiguration(config.(*interfaces.LiquidationConfig)) },
+	})
+}
+
+func (l *liquidationStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	if input.Kind == interfaces.InputEvent {
+		return l.detector.ProcessLogs(ctx, input.Logs)
+	}
+	return l.detector.DetectOpportunity(ctx, input.Transaction, input.SimulationResult)
+}
+
+// crossLayerStrategy plugs the cross-layer detector into the strategy processor,
+// reading bridge events from logs with the detector's event parser
+type crossLayerStrategy struct {
+	detector *CrossLayerDetectorImpl
+}
+
+// NewCrossLayerStrategy creates the cross-layer arbitrage strategy plugin
+func NewCrossLayerStrategy(detector *CrossLayerDetectorImpl) interfaces.Strategy {
+	return &crossLayerStrategy{detector: detector}
+}
+
+func (c *crossLayerStrategy) Name() interfaces.StrategyType { return interfaces.StrategyCrossLayer }
+
+func (c *crossLayerStrategy) Inputs() []interfaces.StrategyInputKind {
+	return []interfaces.StrategyInputKind{interfaces.InputEvent}
+}
+
+func (c *crossLayerStrategy) ConfigSchema() *interfaces.StrategyConfigSchema {
+	return configSchema(configSection{config: c.detector.GetConfiguration()})
+}
+
+func (c *crossLayerStrategy) Configure(settings map[string]interface{}) error {
+	return applySettings(settings, configSection{
+		config: c.detector.GetConfiguration(),
+		set:    func(config interface{}) { c.detector.SetConfiguration(config.(*interfaces.CrossLayerConfig)) },
+	})
+}
+
+func (c *crossLayerStrategy) Detect(ctx context.Context, input *interfaces.StrategyInput) ([]*interfaces.MEVOpportunity, error) {
+	if c.detector.eventParser == nil {
+		return nil, nil
+	}
+
+	// Logs that aren't bridge events fail to decode and are skipped
+	var bridgeEvents []*interfaces.BridgeEvent
+	for _, log := range input.Logs {
+		bridgeEvent, err := c.detector.eventParser.DecodeBridgeEvent(ctx, log)
+		if err != nil || bridgeEvent == nil {
+			continue
+		}
+		bridgeEvents = append(bridgeEvents, bridgeEvent)
+	}
+
+	found, err := c.detector.AnalyzeBridgeEvents(ctx, bridgeEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	opportunities := make([]*interfaces.MEVOpportunity, 0, len(found))
+	for _, opportunity := range found {
+		target := opportunity.BridgeEvent.L2TxHash
+		if target == (common.Hash{}) {
+			target = opportunity.BridgeEvent.L1TxHash
+		}
+
+		// Without a bridge account the opportunity is reported but not executable
+		var executionTxs []*types.Transaction
+		if bridgeTx, err := c.detector.ConstructBridgeTransaction(ctx, opportunity); err == nil {
+			executionTxs = []*types.Transaction{bridgeTx}
+		}
+
+		opportunities = append(opportunities, &interfaces.MEVOpportunity{
+			ID:             fmt.Sprintf("cross_layer_%s_%d", target.Hex(), time.Now().UnixNano()),
+			Strategy:       interfaces.StrategyCrossLayer,
+			TargetTx:       target.Hex(),
+			ExpectedProfit: opportunity.ExpectedProfit,
+			GasCost:        big.NewInt(0), // Bridge fee and settlement gas are netted out already
+			NetProfit:      opportunity.ExpectedProfit,
+			Confidence:     0.6, // Prices can move over the lockup
+			Status:         interfaces.StatusDetected,
+			CreatedAt:      time.Now(),
+			ExecutionTxs:   executionTxs,
+			Metadata: map[string]interface{}{
+				"cross_layer_opportunity": opportunity,
+			},
+		})
+	}
+	return opportunities, nil
+}
+
+// hexAddresses parses the pool addresses detectors report as hex strings, skipping
+// anything else
+func hexAddresses(values ...string) []common.Address {
+	var addresses []common.Address
+	for _, value := range values {
+		if common.IsHexAddress(value) {
+			addresses = append(addresses, common.HexToAddress(value))
+		}
+	}
+	return addresses
+}
+
+// hexAddress parses a token address detectors report as a hex string, returning the
+// zero address, for ETH, for anything else
+func hexAddress(value string) common.Address {
+	if !common.IsHexAddress(value) {
+		return common.Address{}
+	}
+	return common.HexToAddress(value)
+}
+
+// ownGasCost prices our transactions of a bundle at their gas limits
+func ownGasCost(txs ...*types.Transaction) *big.Int {
+	cost := new(big.Int)
+	for _, tx := range txs {
+		if tx != nil && tx.GasPrice != nil {
+			cost.Add(cost, new(big.Int).Mul(tx.GasPrice, new(big.Int).SetUint64(tx.GasLimit)))
+		}
+	}
+	return cost
+}
+
+// netOfGas nets the gas cost out of a profit in ETH. Profits in other tokens are
+// left gross, for the price oracle to net out once they're valued.
+func netOfGas(profit, gasCost *big.Int, profitToken common.Address) *big.Int {
+	if profit == nil {
+		return nil
+	}
+	if profitToken != (common.Address{}) {
+		return new(big.Int).Set(profit)
+	}
+	return new(big.Int).Sub(profit, gasCost)
+}
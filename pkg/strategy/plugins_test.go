@@ -0,0 +1,162 @@
+package strategy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/execution"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legSimulator simulates each bundle transaction with the logs given by its index
+type legSimulator struct {
+	logs    map[int][]*ethtypes.Log
+	bundles [][]*types.Transaction
+}
+
+func (s *legSimulator) Simulate(ctx context.Context, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	s.bundles = append(s.bundles, bundle)
+	results := make([]*interfaces.SimulationResult, len(bundle))
+	for i := range bundle {
+		results[i] = &interfaces.SimulationResult{Success: true, GasUsed: 100000, Logs: s.logs[i]}
+	}
+	return results, nil
+}
+
+// bundleRecorder accepts every bundle and hashes its transactions by position
+type bundleRecorder struct {
+	bundles [][]*types.Transaction
+}
+
+func (r *bundleRecorder) Submit(ctx context.Context, bundle []*types.Transaction) ([]common.Hash, error) {
+	r.bundles = append(r.bundles, bundle)
+	hashes := make([]common.Hash, len(bundle))
+	for i := range bundle {
+		hashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	return hashes, nil
+}
+
+// parityOracle values every token at par with wei of ETH
+type parityOracle struct {
+	interfaces.PriceOracle
+}
+
+func (parityOracle) ValueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	return new(big.Int).Set(amount), nil
+}
+
+func (parityOracle) NormalizeTrade(trade *interfaces.TradeResult) error {
+	trade.NetProfitETH = new(big.Int).Sub(trade.ActualProfit, trade.GasCost)
+	return nil
+}
+
+func erc20Transfer(token, from, to common.Address, value *big.Int) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: token,
+		Topics:  []common.Hash{erc20TransferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:    common.LeftPadBytes(value.Bytes(), 32),
+	}
+}
+
+func TestSandwichStrategy_ExecutesThroughTradeExecutor(t *testing.T) {
+	ctx := context.Background()
+	searcher := common.HexToAddress("0x5ea4c4e45ea4c4e45ea4c4e45ea4c4e45ea4c4e4")
+	router := common.HexToAddress("0x4752ba5dbc23f44d87826276bf6fd6b1c372ad24")
+	builder := &recordingBuilder{searcher: searcher, nonce: 12}
+	plugin := NewSandwichStrategy(NewSandwichDetector(nil, WithTransactionBuilder(builder)))
+
+	target := &types.Transaction{
+		Hash:     "0x9b2b1f1c6c4b8a5e0e5d1d5bb4c9f6a3f0f3a2b1c0d9e8f7a6b5c4d3e2f1a0b9",
+		From:     common.HexToAddress("0x7ec7ec7ec7ec7ec7ec7ec7ec7ec7ec7ec7ec7ec7"),
+		To:       &router,
+		Value:    big.NewInt(1e18),
+		GasPrice: big.NewInt(1e9),
+		Data:     common.FromHex("0x7ff36ab5"),
+		ChainID:  big.NewInt(8453),
+	}
+	opportunities, err := plugin.Detect(ctx, &interfaces.StrategyInput{
+		Kind:             interfaces.InputTransaction,
+		Transaction:      target,
+		SimulationResult: &interfaces.SimulationResult{Success: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+	opportunity := opportunities[0]
+
+	// The legs surround the target, and the profit is in the token the front leg sells
+	require.Len(t, opportunity.ExecutionTxs, 3)
+	assert.Equal(t, searcher, opportunity.ExecutionTxs[0].From)
+	assert.Same(t, target, opportunity.ExecutionTxs[1])
+	assert.Equal(t, searcher, opportunity.ExecutionTxs[2].From)
+	require.Len(t, builder.routes, 2)
+	token0 := builder.routes[0].Hops[0].TokenIn
+	pool := builder.routes[0].Hops[0].Pool
+	assert.Equal(t, token0, opportunity.ProfitToken)
+	assert.NotNil(t, opportunity.GasCost)
+
+	// The re-simulation shows the back leg returning 0.004 of token0 more than the
+	// front leg sold
+	amountIn := builder.routes[0].AmountIn
+	simulator := &legSimulator{logs: map[int][]*ethtypes.Log{
+		0: {erc20Transfer(token0, searcher, pool, amountIn)},
+		2: {erc20Transfer(token0, pool, searcher, new(big.Int).Add(amountIn, big.NewInt(4e15)))},
+	}}
+	submitter := &bundleRecorder{}
+	config := execution.DefaultExecutionConfig()
+	config.Mode = interfaces.ExecutionModeHybrid
+	config.MinProfitThreshold = big.NewInt(1e15)
+	executor := execution.NewTradeExecutor(config, simulator, submitter, nil, nil)
+	executor.SetPriceOracle(parityOracle{})
+
+	result, err := executor.Execute(ctx, opportunity)
+	require.NoError(t, err)
+	require.True(t, result.Success, result.Error)
+	assert.True(t, result.Submitted)
+
+	// The target was re-simulated and submitted in place, and only our legs' gas
+	// counts, the front leg's at its premium
+	require.Len(t, simulator.bundles, 1)
+	assert.Same(t, target, simulator.bundles[0][1])
+	require.Len(t, submitter.bundles, 1)
+	assert.Equal(t, opportunity.ExecutionTxs, submitter.bundles[0])
+	assert.Equal(t, big.NewInt(100000*11e8+100000*1e9), result.ActualGasCost)
+	assert.Equal(t, result.TxHashes[0].Hex(), result.Trade.TransactionHash)
+
+	// Once the back leg no longer recovers enough, the sandwich isn't sent
+	simulator.logs[2] = []*ethtypes.Log{erc20Transfer(token0, pool, searcher, new(big.Int).Add(amountIn, big.NewInt(1e14)))}
+	result, err = executor.Execute(ctx, opportunity)
+	require.NoError(t, err)
+	assert.False(t, result.Submitted)
+	assert.Contains(t, result.Error, "no longer profitable")
+	assert.Len(t, submitter.bundles, 1)
+}
+
+func TestJITStrategy_BundlesAroundTarget(t *testing.T) {
+	detector, _ := newTestJITDetector(testJITConfig(), newJITTestRegistry(pricing.BaseWETH, pricing.BaseUSDC))
+	plugin := NewJITStrategy(detector)
+	target := jitTargetTx()
+
+	opportunities, err := plugin.Detect(context.Background(), &interfaces.StrategyInput{
+		Kind:        interfaces.InputTransaction,
+		Transaction: target,
+		SimulationResult: &interfaces.SimulationResult{
+			Success: true,
+			Logs:    []*ethtypes.Log{jitSwapLog(t, ether(50), ether(5))},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, opportunities, 1)
+
+	// The mint lands ahead of the swap it earns fees from, the burn and collect behind it
+	jit := opportunities[0].Metadata["jit_opportunity"].(*interfaces.JITOpportunity)
+	assert.Equal(t, []*types.Transaction{jit.MintTx, target, jit.BurnTx, jit.CollectTx}, opportunities[0].ExecutionTxs)
+	assert.Equal(t, target.Hash, opportunities[0].TargetTx)
+}
@@ -0,0 +1,86 @@
+package strategy
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+)
+
+// strategyRegistry implements the StrategyRegistry interface
+type strategyRegistry struct {
+	mu         sync.RWMutex
+	strategies []interfaces.Strategy
+	byName     map[interfaces.StrategyType]interfaces.Strategy
+}
+
+// NewStrategyRegistry creates a registry holding the given strategies
+func NewStrategyRegistry(strategies ...interfaces.Strategy) (interfaces.StrategyRegistry, error) {
+	registry := &strategyRegistry{
+		byName: make(map[interfaces.StrategyType]interfaces.Strategy),
+	}
+	for _, strategy := range strategies {
+		if err := registry.Register(strategy); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Register adds a strategy. Names must be unique.
+func (r *strategyRegistry) Register(strategy interfaces.Strategy) error {
+	if strategy == nil {
+		return fmt.Errorf("strategy is required")
+	}
+	name := strategy.Name()
+	if name == "" {
+		return fmt.Errorf("strategy name is required")
+	}
+	if len(strategy.Inputs()) == 0 {
+		return fmt.Errorf("strategy %s declares no inputs", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byName[name]; exists {
+		return fmt.Errorf("strategy %s is already registered", name)
+	}
+	r.strategies = append(r.strategies, strategy)
+	r.byName[name] = strategy
+	return nil
+}
+
+// Get returns a registered strategy by name
+func (r *strategyRegistry) Get(name interfaces.StrategyType) (interfaces.Strategy, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	strategy, exists := r.byName[name]
+	return strategy, exists
+}
+
+// List returns the registered strategies in registration order
+func (r *strategyRegistry) List() []interfaces.Strategy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]interfaces.Strategy(nil), r.strategies...)
+}
+
+// Accepting returns the strategies dispatched with an input kind
+func (r *strategyRegistry) Accepting(kind interfaces.StrategyInputKind) []interfaces.Strategy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var accepting []interfaces.Strategy
+	for _, strategy := range r.strategies {
+		for _, input := range strategy.Inputs() {
+			if input == kind {
+				accepting = append(accepting, strategy)
+				break
+			}
+		}
+	}
+	return accepting
+}
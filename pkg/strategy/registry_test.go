@@ -0,0 +1,63 @@
+package strategy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrategyRegistry(t *testing.T) {
+	sandwich := NewSandwichStrategy(NewSandwichDetector(nil))
+	liquidation := NewLiquidationStrategy(nil)
+	crossLayer := NewCrossLayerStrategy(NewCrossLayerDetector(nil, nil, nil, common.Address{}))
+
+	registry, err := NewStrategyRegistry(sandwich, liquidation)
+	require.NoError(t, err)
+	require.NoError(t, registry.Register(crossLayer))
+
+	assert.Error(t, registry.Register(NewSandwichStrategy(NewSandwichDetector(nil))), "names are unique")
+	assert.Error(t, registry.Register(nil))
+
+	found, exists := registry.Get(interfaces.StrategyCrossLayer)
+	require.True(t, exists)
+	assert.Equal(t, crossLayer, found)
+	_, exists = registry.Get(interfaces.StrategyJIT)
+	assert.False(t, exists)
+
+	assert.Equal(t, []interfaces.Strategy{sandwich, liquidation, crossLayer}, registry.List())
+	assert.Equal(t, []interfaces.Strategy{sandwich, liquidation}, registry.Accepting(interfaces.InputTransaction))
+	assert.Equal(t, []interfaces.Strategy{liquidation, crossLayer}, registry.Accepting(interfaces.InputEvent))
+	assert.Empty(t, registry.Accepting(interfaces.InputBlock))
+}
+
+func TestStrategyConfigure(t *testing.T) {
+	detector := NewSandwichDetector(nil)
+	sandwich := NewSandwichStrategy(detector)
+
+	field, exists := sandwich.ConfigSchema().Field("min_profit_threshold")
+	require.True(t, exists)
+	assert.Equal(t, interfaces.ConfigTypeBigInt, field.Type)
+	assert.Equal(t, detector.GetConfiguration().MinProfitThreshold.String(), field.Default)
+
+	require.NoError(t, sandwich.Configure(map[string]interface{}{
+		"min_profit_threshold": "100000000000000000",
+		"max_slippage":         0.05,
+	}))
+	assert.Equal(t, big.NewInt(1e17), detector.GetConfiguration().MinProfitThreshold)
+	assert.Equal(t, 0.05, detector.GetConfiguration().MaxSlippage)
+
+	// A bad setting leaves every setting as it was
+	err := sandwich.Configure(map[string]interface{}{
+		"max_slippage":    0.01,
+		"min_swap_amount": "ten",
+	})
+	assert.ErrorContains(t, err, "min_swap_amount")
+	assert.Equal(t, 0.05, detector.GetConfiguration().MaxSlippage)
+
+	err = sandwich.Configure(map[string]interface{}{"max_slipage": 0.01})
+	assert.ErrorContains(t, err, "unknown setting")
+}
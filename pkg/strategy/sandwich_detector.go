@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
@@ -13,11 +14,15 @@ import (
 
 // sandwichDetector implements the SandwichDetector interface
 type sandwichDetector struct {
-	config *interfaces.SandwichConfig
+	config  atomic.Pointer[interfaces.SandwichConfig]
+	safety  interfaces.TokenSafetyChecker
+	builder interfaces.TransactionBuilder
+	capital interfaces.CapitalSource
 }
 
-// NewSandwichDetector creates a new sandwich detector with the given configuration
-func NewSandwichDetector(config *interfaces.SandwichConfig) interfaces.SandwichDetector {
+// NewSandwichDetector creates a new sandwich detector with the given configuration.
+// Options add a token safety checker, a transaction builder and a capital source.
+func NewSandwichDetector(config *interfaces.SandwichConfig, options ...DetectorOption) interfaces.SandwichDetector {
 	if config == nil {
 		config = &interfaces.SandwichConfig{
 			MinSwapAmount:     big.NewInt(10000), // $10k minimum
@@ -26,9 +31,14 @@ func NewSandwichDetector(config *interfaces.SandwichConfig) interfaces.SandwichD
 			MinProfitThreshold: big.NewInt(100),  // $100 minimum profit
 		}
 	}
-	return &sandwichDetector{
-		config: config,
+	deps := applyDetectorOptions(options)
+	detector := &sandwichDetector{
+		safety:  deps.safety,
+		builder: deps.builder,
+		capital: deps.capital,
 	}
+	detector.config.Store(config)
+	return detector
 }
 
 // DetectOpportunity analyzes a transaction to identify sandwich attack opportunities
@@ -49,8 +59,13 @@ func (s *sandwichDetector) DetectOpportunity(ctx context.Context, tx *types.Tran
 		return nil, fmt.Errorf("failed to extract swap details: %w", err)
 	}
 
+	// Skip tokens that tax transfers or failed a safety check
+	if s.isUnsafeToken(swapDetails.Token0) || s.isUnsafeToken(swapDetails.Token1) {
+		return nil, nil
+	}
+
 	// Check slippage tolerance
-	if swapDetails.SlippageTolerance > s.config.MaxSlippage {
+	if swapDetails.SlippageTolerance > s.config.Load().MaxSlippage {
 		return nil, nil // Slippage tolerance too high, not profitable
 	}
 
@@ -76,17 +91,18 @@ func (s *sandwichDetector) DetectOpportunity(ctx context.Context, tx *types.Tran
 
 // ValidateOpportunity validates that a sandwich opportunity is still profitable
 func (s *sandwichDetector) ValidateOpportunity(ctx context.Context, opportunity *interfaces.SandwichOpportunity) error {
+	config := s.config.Load()
 	if opportunity == nil {
 		return errors.New("opportunity cannot be nil")
 	}
 
 	// Check if expected profit meets minimum threshold
-	if opportunity.ExpectedProfit.Cmp(s.config.MinProfitThreshold) < 0 {
+	if opportunity.ExpectedProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return errors.New("expected profit below minimum threshold")
 	}
 
 	// Check slippage tolerance
-	if opportunity.SlippageTolerance > s.config.MaxSlippage {
+	if opportunity.SlippageTolerance > config.MaxSlippage {
 		return errors.New("slippage tolerance exceeds maximum allowed")
 	}
 
@@ -104,6 +120,10 @@ func (s *sandwichDetector) ConstructTransactions(ctx context.Context, opportunit
 		return nil, fmt.Errorf("invalid opportunity: %w", err)
 	}
 
+	if s.builder != nil {
+		return s.buildTransactions(ctx, opportunity)
+	}
+
 	transactions := make([]*types.Transaction, 0, 2)
 
 	// Construct front-run transaction
@@ -127,14 +147,19 @@ func (s *sandwichDetector) ConstructTransactions(ctx context.Context, opportunit
 
 // GetConfiguration returns the current sandwich detector configuration
 func (s *sandwichDetector) GetConfiguration() *interfaces.SandwichConfig {
-	return s.config
+	return s.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (s *sandwichDetector) SetConfiguration(config *interfaces.SandwichConfig) {
+	s.config.Store(config)
 }
 
 // isLargeSwap checks if the swap amount meets the minimum threshold
 func (s *sandwichDetector) isLargeSwap(tx *types.Transaction) bool {
 	// For simplicity, we'll use the transaction value as a proxy for swap amount
 	// In a real implementation, we'd decode the transaction data to get the exact swap amount
-	return tx.Value.Cmp(s.config.MinSwapAmount) >= 0
+	return tx.Value.Cmp(s.config.Load().MinSwapAmount) >= 0
 }
 
 // swapDetails contains extracted information about a swap transaction
@@ -182,6 +207,19 @@ func (s *sandwichDetector) extractSwapDetails(tx *types.Transaction, simResult *
 	return details, nil
 }
 
+// isUnsafeToken reports whether the safety checker flags a token
+func (s *sandwichDetector) isUnsafeToken(token string) bool {
+	if s.safety == nil || !common.IsHexAddress(token) {
+		return false
+	}
+	address := common.HexToAddress(token)
+	if s.safety.IsFeeOnTransfer(address) {
+		return true
+	}
+	report, exists := s.safety.Report(address)
+	return exists && !report.Safe
+}
+
 // calculatePriceImpact calculates the price impact from simulation results
 func (s *sandwichDetector) calculatePriceImpact(simResult *interfaces.SimulationResult) (*big.Int, error) {
 	if simResult == nil || !simResult.Success {
@@ -226,14 +264,65 @@ func (s *sandwichDetector) estimateProfit(details *swapDetails, priceImpact *big
 	return profit
 }
 
+// buildTransactions builds the legs through the transaction builder. The front leg
+// buys token1 with the target; the back leg sells all of it and must at least
+// recover the front leg's input. A front leg cut to the capital available scales
+// the expected profit down with it, and no legs are returned once that falls below
+// the profit threshold.
+func (s *sandwichDetector) buildTransactions(ctx context.Context, opportunity *interfaces.SandwichOpportunity) ([]*types.Transaction, error) {
+	targetTx := opportunity.TargetTx
+	if !common.IsHexAddress(opportunity.Pool) || !common.IsHexAddress(opportunity.Token0) || !common.IsHexAddress(opportunity.Token1) {
+		return nil, errors.New("opportunity pool and tokens must be addresses")
+	}
+	pool := common.HexToAddress(opportunity.Pool)
+	token0 := common.HexToAddress(opportunity.Token0)
+	token1 := common.HexToAddress(opportunity.Token1)
+
+	sized := new(big.Int).Div(targetTx.Value, big.NewInt(10)) // Use 10% of target amount
+	amountIn := capToCapital(s.capital, token0, sized)
+	if amountIn.Sign() <= 0 {
+		return nil, fmt.Errorf("no %s capital available for the front leg", token0.Hex())
+	}
+	expectedProfit := scaleToCapital(opportunity.ExpectedProfit, amountIn, sized)
+	if expectedProfit.Cmp(s.config.Load().MinProfitThreshold) < 0 {
+		return nil, nil // Too little capital to clear the threshold
+	}
+
+	transactions, err := s.builder.BuildSwaps(ctx, []*interfaces.SwapRoute{
+		{
+			Hops:     []interfaces.SwapHop{{Pool: pool, TokenIn: token0, TokenOut: token1}},
+			AmountIn: amountIn,
+			GasPrice: s.frontrunGasPrice(targetTx),
+		},
+		{
+			Hops:         []interfaces.SwapHop{{Pool: pool, TokenIn: token1, TokenOut: token0}},
+			MinAmountOut: amountIn,
+			GasPrice:     targetTx.GasPrice,
+		},
+	}, targetTx.ChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sandwich: %w", err)
+	}
+
+	opportunity.FrontrunTx = transactions[0]
+	opportunity.BackrunTx = transactions[1]
+	opportunity.ExpectedProfit = expectedProfit
+	return transactions, nil
+}
+
+// frontrunGasPrice prices the front-run above the target by the configured premium
+func (s *sandwichDetector) frontrunGasPrice(targetTx *types.Transaction) *big.Int {
+	gasPremium := new(big.Int).Mul(targetTx.GasPrice, big.NewInt(int64(s.config.Load().GasPremiumPercent*100)))
+	gasPremium = gasPremium.Div(gasPremium, big.NewInt(100))
+	return new(big.Int).Add(targetTx.GasPrice, gasPremium)
+}
+
 // constructFrontrunTransaction creates the front-run transaction
 func (s *sandwichDetector) constructFrontrunTransaction(opportunity *interfaces.SandwichOpportunity) (*types.Transaction, error) {
 	targetTx := opportunity.TargetTx
 	
 	// Calculate gas price with premium
-	gasPremium := new(big.Int).Mul(targetTx.GasPrice, big.NewInt(int64(s.config.GasPremiumPercent*100)))
-	gasPremium = gasPremium.Div(gasPremium, big.NewInt(100))
-	frontrunGasPrice := new(big.Int).Add(targetTx.GasPrice, gasPremium)
+	frontrunGasPrice := s.frontrunGasPrice(targetTx)
 	
 	// Create front-run transaction (buy before target transaction)
 	frontrunTx := &types.Transaction{
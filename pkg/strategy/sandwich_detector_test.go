@@ -554,4 +554,210 @@ func BenchmarkSandwichDetector_ConstructTransactions(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, _ = detector.ConstructTransactions(ctx, opportunity)
 	}
-}
\ No newline at end of file
+}
+func TestSandwichDetector_RejectsUnsafeTokens(t *testing.T) {
+	token := common.HexToAddress("0xA0b86a33E6441b8435b662f0E2d0B5B0B5B5B5B5") // token0 of the mock swap details
+	tx := &types.Transaction{
+		Hash:     "0x123",
+		Value:    big.NewInt(50000),
+		Data:     common.Hex2Bytes("38ed1739"),
+		GasPrice: big.NewInt(1000000000),
+		ChainID:  big.NewInt(8453),
+	}
+	simResult := &interfaces.SimulationResult{Success: true}
+
+	tests := []struct {
+		name    string
+		safety  *fakeTokenSafety
+		wantNil bool
+	}{
+		{
+			name:   "unknown token",
+			safety: &fakeTokenSafety{},
+		},
+		{
+			name:    "fee-on-transfer token",
+			safety:  &fakeTokenSafety{feeOnTransfer: map[common.Address]bool{token: true}},
+			wantNil: true,
+		},
+		{
+			name:    "token that failed a safety check",
+			safety:  &fakeTokenSafety{report: &interfaces.TokenSafetyReport{Token: token, Honeypot: true}},
+			wantNil: true,
+		},
+		{
+			name:   "token that passed a safety check",
+			safety: &fakeTokenSafety{report: &interfaces.TokenSafetyReport{Token: token, Safe: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewSandwichDetector(nil, WithSafetyChecker(tt.safety))
+
+			opportunity, err := detector.DetectOpportunity(context.Background(), tx, simResult)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNil, opportunity == nil)
+		})
+	}
+}
+
+func TestNewSandwichDetector_Options(t *testing.T) {
+	safety := &fakeTokenSafety{}
+	builder := &recordingBuilder{searcher: common.HexToAddress("0x5ea4c4e4")}
+	capital := fixedCapital{common.HexToAddress("0x4200000000000000000000000000000000000006"): big.NewInt(8000)}
+
+	// Safety checks, building and capital sizing combine
+	detector := NewSandwichDetector(nil, WithSafetyChecker(safety), WithTransactionBuilder(builder), WithCapitalSource(capital)).(*sandwichDetector)
+	assert.Same(t, safety, detector.safety)
+	assert.Same(t, builder, detector.builder)
+	assert.Equal(t, capital, detector.capital)
+	assert.NotNil(t, detector.config.Load())
+}
+
+// recordingBuilder records the routes and call batches it's asked to build and
+// numbers transactions from a fixed nonce
+type recordingBuilder struct {
+	searcher common.Address
+	executor common.Address
+	nonce    uint64
+	routes   []*interfaces.SwapRoute
+	batches  []*interfaces.CallBatch
+}
+
+func (r *recordingBuilder) Searcher() common.Address {
+	return r.searcher
+}
+
+func (r *recordingBuilder) Executor() common.Address {
+	return r.executor
+}
+
+func (r *recordingBuilder) BuildCalls(ctx context.Context, batches []*interfaces.CallBatch, chainID *big.Int) ([]*types.Transaction, error) {
+	r.batches = batches
+	txs := make([]*types.Transaction, len(batches))
+	for i, batch := range batches {
+		executor := r.executor
+		txs[i] = &types.Transaction{From: r.searcher, To: &executor, Nonce: r.nonce + uint64(i), GasPrice: batch.GasPrice, GasLimit: batch.GasLimit, ChainID: chainID}
+	}
+	return txs, nil
+}
+
+func (r *recordingBuilder) BuildSwaps(ctx context.Context, routes []*interfaces.SwapRoute, chainID *big.Int) ([]*types.Transaction, error) {
+	r.routes = routes
+	txs := make([]*types.Transaction, len(routes))
+	for i, route := range routes {
+		txs[i] = &types.Transaction{From: r.searcher, Nonce: r.nonce + uint64(i), GasPrice: route.GasPrice, ChainID: chainID}
+	}
+	return txs, nil
+}
+
+func (r *recordingBuilder) Simulate(ctx context.Context, bundle []*types.Transaction) ([]*interfaces.SimulationResult, error) {
+	return nil, nil
+}
+
+func TestSandwichDetector_ConstructTransactionsWithBuilder(t *testing.T) {
+	builder := &recordingBuilder{searcher: common.HexToAddress("0x5ea4c4e4"), nonce: 12}
+	detector := NewSandwichDetector(nil, WithTransactionBuilder(builder))
+
+	pool := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	token0 := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	token1 := common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	opportunity := &interfaces.SandwichOpportunity{
+		TargetTx: &types.Transaction{
+			Value:    big.NewInt(50000),
+			GasPrice: big.NewInt(1000000000),
+			ChainID:  big.NewInt(8453),
+		},
+		ExpectedProfit:    big.NewInt(200),
+		SlippageTolerance: 0.01,
+		Pool:              pool.Hex(),
+		Token0:            token0.Hex(),
+		Token1:            token1.Hex(),
+	}
+
+	txs, err := detector.ConstructTransactions(context.Background(), opportunity)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, []uint64{12, 13}, []uint64{txs[0].Nonce, txs[1].Nonce})
+	assert.Equal(t, builder.searcher, opportunity.FrontrunTx.From)
+	assert.Equal(t, txs[1], opportunity.BackrunTx)
+
+	// The front leg buys token1 at a premium; the back leg sells all of it back and
+	// must recover the front leg's input
+	require.Len(t, builder.routes, 2)
+	front, back := builder.routes[0], builder.routes[1]
+	assert.Equal(t, []interfaces.SwapHop{{Pool: pool, TokenIn: token0, TokenOut: token1}}, front.Hops)
+	assert.Equal(t, big.NewInt(5000), front.AmountIn)
+	assert.Equal(t, big.NewInt(1100000000), front.GasPrice)
+	assert.Equal(t, []interfaces.SwapHop{{Pool: pool, TokenIn: token1, TokenOut: token0}}, back.Hops)
+	assert.Nil(t, back.AmountIn)
+	assert.Equal(t, front.AmountIn, back.MinAmountOut)
+	assert.Equal(t, big.NewInt(1000000000), back.GasPrice)
+
+	opportunity.Pool = "mock"
+	_, err = detector.ConstructTransactions(context.Background(), opportunity)
+	assert.Error(t, err)
+}
+
+func TestSandwichDetector_ConstructTransactionsWithCapital(t *testing.T) {
+	pool := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	token0 := common.HexToAddress("0x4200000000000000000000000000000000000006")
+	token1 := common.HexToAddress("0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913")
+	newOpportunity := func() *interfaces.SandwichOpportunity {
+		return &interfaces.SandwichOpportunity{
+			TargetTx: &types.Transaction{
+				Value:    big.NewInt(50000),
+				GasPrice: big.NewInt(1000000000),
+				ChainID:  big.NewInt(8453),
+			},
+			ExpectedProfit:    big.NewInt(200),
+			SlippageTolerance: 0.01,
+			Pool:              pool.Hex(),
+			Token0:            token0.Hex(),
+			Token1:            token1.Hex(),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		capital      fixedCapital
+		wantAmountIn *big.Int
+		wantProfit   *big.Int
+		wantErr      bool
+		wantNoLegs   bool
+	}{
+		{name: "inventory not yet known", capital: nil, wantAmountIn: big.NewInt(5000), wantProfit: big.NewInt(200)},
+		{name: "front leg within capital", capital: fixedCapital{token0: big.NewInt(8000)}, wantAmountIn: big.NewInt(5000), wantProfit: big.NewInt(200)},
+		{name: "front leg capped to capital", capital: fixedCapital{token0: big.NewInt(3000)}, wantAmountIn: big.NewInt(3000), wantProfit: big.NewInt(120)},
+		{name: "capped profit below threshold", capital: fixedCapital{token0: big.NewInt(2000)}, wantNoLegs: true},
+		{name: "no capital in the token sold", capital: fixedCapital{token1: big.NewInt(8000)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := &recordingBuilder{searcher: common.HexToAddress("0x5ea4c4e4")}
+			detector := NewSandwichDetector(nil, WithTransactionBuilder(builder), WithCapitalSource(tt.capital))
+
+			opportunity := newOpportunity()
+			txs, err := detector.ConstructTransactions(context.Background(), opportunity)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, builder.routes)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNoLegs {
+				// 80 of profit is left, under the default threshold of 100
+				assert.Empty(t, txs)
+				assert.Nil(t, builder.routes)
+				assert.Nil(t, opportunity.FrontrunTx)
+				return
+			}
+			require.Len(t, builder.routes, 2)
+			assert.Equal(t, tt.wantAmountIn, builder.routes[0].AmountIn)
+			assert.Equal(t, tt.wantAmountIn, builder.routes[1].MinAmountOut)
+			assert.Equal(t, tt.wantProfit, opportunity.ExpectedProfit)
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// pairCreatedTopic is the V2 factory event PairCreated(token0, token1, pair, allPairsLength)
+var pairCreatedTopic = crypto.Keccak256Hash([]byte("PairCreated(address,address,address,uint256)"))
+
+// Base mainnet V2-style factories with adapters
+var (
+	BaseSushiSwapV2Factory = common.HexToAddress("0x71524B4f93c58fcbF659783284E38825f0622859")
+	BaseBaseSwapFactory    = common.HexToAddress("0xFDa619b6d20975be80A10332cD39b9a4b0FAa8BB")
+)
+
+// snipeSearchSteps bounds the ternary search for the most profitable buy
+const snipeSearchSteps = 80
+
+// snipingDetector implements the SnipingDetector interface
+type snipingDetector struct {
+	config      atomic.Pointer[interfaces.SnipingConfig]
+	safety      interfaces.TokenSafetyChecker
+	adapters    interfaces.ProtocolAdapterRegistry
+	pools       interfaces.PoolRegistry
+	priceOracle interfaces.PriceOracle
+	tokens      interfaces.TokenRegistry
+	quoteTokens map[common.Address]bool
+	builder     interfaces.TransactionBuilder
+	capital     interfaces.CapitalSource
+}
+
+// snipeLaunch is a pool whose first liquidity is added by the target transaction
+type snipeLaunch struct {
+	pool     common.Address
+	protocol interfaces.Protocol
+	tokens   []common.Address
+	fee      uint32
+	created  bool
+	reserves []*big.Int // After the last Sync in the transaction
+	funded   bool       // A Mint found the pool empty
+}
+
+// NewSnipingDetector creates a detector for launches on V2-style pools. Pools are
+// attributed to a protocol by the factory creating them in the same transaction, or
+// by the pool registry for pools created earlier. The safety checker must clear a
+// token before any buy of it is reported; without one nothing is reported, nor without
+// the transaction builder option that builds the buy. With a capital source, buys
+// spend at most the capital available in the quote token. The price oracle and token
+// registry are only needed for quote tokens other than WETH.
+func NewSnipingDetector(
+	config *interfaces.SnipingConfig,
+	safety interfaces.TokenSafetyChecker,
+	adapters interfaces.ProtocolAdapterRegistry,
+	pools interfaces.PoolRegistry,
+	priceOracle interfaces.PriceOracle,
+	tokens interfaces.TokenRegistry,
+	options ...DetectorOption,
+) interfaces.SnipingDetector {
+	if config == nil {
+		config = &interfaces.SnipingConfig{
+			Factories: map[common.Address]interfaces.Protocol{
+				BaseSushiSwapV2Factory: interfaces.ProtocolSushiSwapV2,
+				BaseBaseSwapFactory:    interfaces.ProtocolBaseSwap,
+			},
+			QuoteTokens:        []common.Address{pricing.BaseWETH, pricing.BaseUSDC},
+			MinLiquidity:       big.NewInt(2e18),                                  // 2 ETH
+			MaxBuyAmount:       big.NewInt(5e17),                                  // 0.5 ETH
+			ExpectedBuyVolume:  new(big.Int).Mul(big.NewInt(3), big.NewInt(1e18)), // 3 ETH
+			MaxTaxBps:          500,
+			MinProfitThreshold: big.NewInt(2e16), // 0.02 ETH
+		}
+	}
+
+	quoteTokens := make(map[common.Address]bool, len(config.QuoteTokens))
+	for _, token := range config.QuoteTokens {
+		quoteTokens[token] = true
+	}
+
+	deps := applyDetectorOptions(options)
+	detector := &snipingDetector{
+		safety:      safety,
+		adapters:    adapters,
+		pools:       pools,
+		priceOracle: priceOracle,
+		tokens:      tokens,
+		quoteTokens: quoteTokens,
+		builder:     deps.builder,
+		capital:     deps.capital,
+	}
+	detector.config.Store(config)
+	return detector
+}
+
+// DetectOpportunity finds pools that the transaction creates or funds for the first
+// time, screens the new token on a fork and sizes a buy placed right after the
+// transaction. The buy is valued by selling into the expected follow-on volume.
+func (s *snipingDetector) DetectOpportunity(ctx context.Context, tx *types.Transaction, simResult *interfaces.SimulationResult) (*interfaces.SnipeOpportunity, error) {
+	if tx == nil || simResult == nil || !simResult.Success || s.safety == nil || s.adapters == nil || s.builder == nil {
+		return nil, nil
+	}
+
+	var best *interfaces.SnipeOpportunity
+	var bestProfitETH *big.Int
+	for _, launch := range s.findLaunches(simResult.Logs) {
+		opportunity, profitETH, err := s.evaluate(ctx, tx, launch)
+		if err != nil {
+			return nil, err
+		}
+		if opportunity != nil && (bestProfitETH == nil || profitETH.Cmp(bestProfitETH) > 0) {
+			best, bestProfitETH = opportunity, profitETH
+		}
+	}
+
+	return best, nil
+}
+
+// GetConfiguration returns the current configuration
+func (s *snipingDetector) GetConfiguration() *interfaces.SnipingConfig {
+	return s.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (s *snipingDetector) SetConfiguration(config *interfaces.SnipingConfig) {
+	s.config.Store(config)
+}
+
+// findLaunches tracks pool creations, reserves and mints through the transaction's logs
+func (s *snipingDetector) findLaunches(logs []*ethtypes.Log) []*snipeLaunch {
+	launches := make(map[common.Address]*snipeLaunch)
+	var order []common.Address
+
+	for _, log := range logs {
+		if protocol, exists := s.config.Load().Factories[log.Address]; exists {
+			if len(log.Topics) == 3 && log.Topics[0] == pairCreatedTopic && len(log.Data) >= 32 {
+				pair := common.BytesToAddress(log.Data[:32])
+				launches[pair] = &snipeLaunch{
+					pool:     pair,
+					protocol: protocol,
+					tokens:   []common.Address{common.BytesToAddress(log.Topics[1].Bytes()), common.BytesToAddress(log.Topics[2].Bytes())},
+					created:  true,
+				}
+				order = append(order, pair)
+			}
+			continue
+		}
+
+		launch, exists := launches[log.Address]
+		if !exists {
+			info, known := s.knownPool(log.Address)
+			if !known {
+				continue
+			}
+			launch = &snipeLaunch{
+				pool:     info.Address,
+				protocol: info.Protocol,
+				tokens:   []common.Address{info.Token0, info.Token1},
+				fee:      info.Fee,
+			}
+			launches[log.Address] = launch
+			order = append(order, log.Address)
+		}
+
+		adapter, exists := s.adapters.Adapter(launch.protocol)
+		if !exists {
+			continue
+		}
+		event, err := adapter.DecodeLog(log)
+		if err != nil || event == nil {
+			continue
+		}
+
+		switch {
+		case event.SyncEvent != nil:
+			launch.reserves = []*big.Int{event.SyncEvent.Reserve0, event.SyncEvent.Reserve1}
+		case event.EventType == interfaces.EventTypeMint && event.LiquidityEvent != nil:
+			// Pairs emit Sync before Mint; reserves equal to the deposit mean the pool was empty
+			mint := event.LiquidityEvent
+			if launch.reserves != nil && launch.reserves[0].Cmp(mint.Amount0) == 0 && launch.reserves[1].Cmp(mint.Amount1) == 0 {
+				launch.funded = true
+			}
+		}
+	}
+
+	var funded []*snipeLaunch
+	for _, pool := range order {
+		if launch := launches[pool]; launch.funded {
+			funded = append(funded, launch)
+		}
+	}
+	return funded
+}
+
+// knownPool looks a pool up in the registry
+func (s *snipingDetector) knownPool(address common.Address) (*interfaces.PoolInfo, bool) {
+	if s.pools == nil {
+		return nil, false
+	}
+	return s.pools.GetPool(address)
+}
+
+// evaluate screens a launch's token and sizes the buy, returning the opportunity and
+// its profit in wei of ETH
+func (s *snipingDetector) evaluate(ctx context.Context, tx *types.Transaction, launch *snipeLaunch) (*interfaces.SnipeOpportunity, *big.Int, error) {
+	config := s.config.Load()
+	quoteIndex := -1
+	for i, token := range launch.tokens {
+		if s.quoteTokens[token] {
+			if quoteIndex >= 0 {
+				return nil, nil, nil // Both sides are quote tokens; nothing new is listed
+			}
+			quoteIndex = i
+		}
+	}
+	if quoteIndex < 0 {
+		return nil, nil, nil
+	}
+	quoteToken, token := launch.tokens[quoteIndex], launch.tokens[1-quoteIndex]
+
+	adapter, exists := s.adapters.Adapter(launch.protocol)
+	if !exists {
+		return nil, nil, nil
+	}
+
+	liquidityETH, err := s.valueETH(quoteToken, launch.reserves[quoteIndex])
+	if err != nil || liquidityETH.Cmp(config.MinLiquidity) < 0 {
+		return nil, nil, nil
+	}
+
+	pool := &interfaces.PoolState{
+		Protocol: launch.protocol,
+		Address:  launch.pool,
+		Tokens:   launch.tokens,
+		Reserves: launch.reserves,
+		Fee:      launch.fee,
+	}
+
+	report, err := s.safety.CheckToken(ctx, &interfaces.TokenSafetyRequest{
+		Token:      token,
+		QuoteToken: quoteToken,
+		Pool:       pool,
+		Setup:      []*types.Transaction{tx},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to check %s: %w", token.Hex(), err)
+	}
+	if !report.Safe || report.BuyTaxBps > config.MaxTaxBps || report.SellTaxBps > config.MaxTaxBps {
+		return nil, nil, nil
+	}
+
+	maxIn, err := s.ethToToken(config.MaxBuyAmount, quoteToken)
+	if err != nil {
+		return nil, nil, nil
+	}
+	if maxIn = capToCapital(s.capital, quoteToken, maxIn); maxIn.Sign() <= 0 {
+		return nil, nil, nil
+	}
+	followOn, err := s.ethToToken(config.ExpectedBuyVolume, quoteToken)
+	if err != nil {
+		return nil, nil, nil
+	}
+
+	trade := &snipeTrade{adapter: adapter, pool: pool, quoteToken: quoteToken, token: token, followOn: followOn, report: report}
+	amountIn := trade.optimalAmountIn(maxIn)
+	out, exit := trade.simulate(amountIn)
+	if out == nil || exit == nil || exit.Cmp(amountIn) <= 0 {
+		return nil, nil, nil
+	}
+
+	profit := new(big.Int).Sub(exit, amountIn)
+	profitETH, err := s.valueETH(quoteToken, profit)
+	if err != nil || profitETH.Cmp(config.MinProfitThreshold) < 0 {
+		return nil, nil, nil
+	}
+
+	buyTx, err := s.buildBuy(ctx, tx, pool, quoteToken, token, amountIn, applyTax(out, report.BuyTaxBps))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &interfaces.SnipeOpportunity{
+		TargetTx:       tx,
+		Pool:           launch.pool,
+		Protocol:       launch.protocol,
+		Token:          token,
+		QuoteToken:     quoteToken,
+		Created:        launch.created,
+		Liquidity:      launch.reserves[quoteIndex],
+		AmountIn:       amountIn,
+		AmountOut:      applyTax(out, report.BuyTaxBps),
+		ExitAmount:     exit,
+		ExpectedProfit: profit,
+		Safety:         report,
+		BuyTx:          buyTx,
+	}, profitETH, nil
+}
+
+// buildBuy builds the buy from the searcher through the executor contract, quoted on
+// the pool as the target leaves it, requiring at least the output left after the buy tax
+func (s *snipingDetector) buildBuy(ctx context.Context, target *types.Transaction, pool *interfaces.PoolState, quoteToken, token common.Address, amountIn, minOut *big.Int) (*types.Transaction, error) {
+	chainID := target.ChainID
+	if chainID == nil {
+		chainID = big.NewInt(baseChainID)
+	}
+	txs, err := s.builder.BuildSwaps(ctx, []*interfaces.SwapRoute{{
+		Hops:         []interfaces.SwapHop{{Pool: pool.Address, Protocol: pool.Protocol, TokenIn: quoteToken, TokenOut: token, State: pool}},
+		AmountIn:     amountIn,
+		MinAmountOut: minOut,
+		GasPrice:     target.GasPrice,
+	}}, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build buy of %s: %w", token.Hex(), err)
+	}
+	return txs[0], nil
+}
+
+// valueETH converts a quote token amount to wei of ETH
+func (s *snipingDetector) valueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	if token == pricing.BaseWETH {
+		return amount, nil
+	}
+	if s.priceOracle == nil {
+		return nil, fmt.Errorf("no price oracle to value %s", token.Hex())
+	}
+	return s.priceOracle.ValueETH(token, amount)
+}
+
+// ethToToken converts wei of ETH to a raw quote token amount
+func (s *snipingDetector) ethToToken(amount *big.Int, token common.Address) (*big.Int, error) {
+	if token == pricing.BaseWETH {
+		return amount, nil
+	}
+	if s.priceOracle == nil || s.tokens == nil {
+		return nil, fmt.Errorf("no price oracle to value %s", token.Hex())
+	}
+	info, exists := s.tokens.GetToken(token)
+	if !exists {
+		return nil, fmt.Errorf("unknown token %s", token.Hex())
+	}
+	price, err := s.priceOracle.PriceETH(token)
+	if err != nil || price.Sign() <= 0 {
+		return nil, fmt.Errorf("no ETH price for %s", token.Hex())
+	}
+
+	scaled := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(info.Decimals)), nil)
+	scaled.Mul(scaled, amount)
+	return scaled.Div(scaled, price), nil
+}
+
+// snipeTrade models a buy, the follow-on buys and the exit against V2 reserves
+type snipeTrade struct {
+	adapter    interfaces.ProtocolAdapter
+	pool       *interfaces.PoolState
+	quoteToken common.Address
+	token      common.Address
+	followOn   *big.Int
+	report     *interfaces.TokenSafetyReport
+}
+
+// optimalAmountIn searches for the buy with the highest exit profit
+func (t *snipeTrade) optimalAmountIn(maxIn *big.Int) *big.Int {
+	low, high := big.NewInt(0), new(big.Int).Set(maxIn)
+	for i := 0; i < snipeSearchSteps && new(big.Int).Sub(high, low).Cmp(big.NewInt(2)) > 0; i++ {
+		third := new(big.Int).Sub(high, low)
+		third.Div(third, big.NewInt(3))
+		m1 := new(big.Int).Add(low, third)
+		m2 := new(big.Int).Sub(high, third)
+		if t.profit(m1).Cmp(t.profit(m2)) < 0 {
+			low = m1
+		} else {
+			high = m2
+		}
+	}
+	amountIn := new(big.Int).Add(low, high)
+	return amountIn.Rsh(amountIn, 1)
+}
+
+// profit returns the exit amount less the amount in, or a large loss if the pool can't fill it
+func (t *snipeTrade) profit(amountIn *big.Int) *big.Int {
+	_, exit := t.simulate(amountIn)
+	if exit == nil {
+		return new(big.Int).Neg(amountIn)
+	}
+	return exit.Sub(exit, amountIn)
+}
+
+// simulate returns the pool's output for the buy and the quote tokens from selling
+// the taxed tokens after the follow-on buys
+func (t *snipeTrade) simulate(amountIn *big.Int) (*big.Int, *big.Int) {
+	if amountIn.Sign() <= 0 {
+		return nil, nil
+	}
+	out, err := t.adapter.GetAmountOut(t.pool, t.quoteToken, t.token, amountIn)
+	if err != nil {
+		return nil, nil
+	}
+
+	state := swapReserves(t.pool, t.quoteToken, amountIn, out)
+	if t.followOn.Sign() > 0 {
+		followOut, err := t.adapter.GetAmountOut(state, t.quoteToken, t.token, t.followOn)
+		if err != nil {
+			return nil, nil
+		}
+		state = swapReserves(state, t.quoteToken, t.followOn, followOut)
+	}
+
+	sold := applyTax(applyTax(out, t.report.BuyTaxBps), t.report.SellTaxBps)
+	exit, err := t.adapter.GetAmountOut(state, t.token, t.quoteToken, sold)
+	if err != nil {
+		return out, nil
+	}
+	return out, exit
+}
+
+// swapReserves returns a copy of a V2 pool after a swap; the fee stays in the pool
+func swapReserves(pool *interfaces.PoolState, tokenIn common.Address, amountIn, amountOut *big.Int) *interfaces.PoolState {
+	next := *pool
+	next.Reserves = make([]*big.Int, len(pool.Reserves))
+	for i, reserve := range pool.Reserves {
+		next.Reserves[i] = new(big.Int).Set(reserve)
+		if pool.Tokens[i] == tokenIn {
+			next.Reserves[i].Add(next.Reserves[i], amountIn)
+		} else {
+			next.Reserves[i].Sub(next.Reserves[i], amountOut)
+		}
+	}
+	return &next
+}
+
+// applyTax returns the share of an amount left after a tax in basis points
+func applyTax(amount *big.Int, taxBps uint16) *big.Int {
+	left := new(big.Int).Mul(amount, big.NewInt(int64(10000-int(taxBps))))
+	return left.Div(left, big.NewInt(10000))
+}
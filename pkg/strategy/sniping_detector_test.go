@@ -0,0 +1,311 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/protocols"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	snipeTestPool     = common.HexToAddress("0x1000000000000000000000000000000000000006")
+	snipeTestToken    = common.HexToAddress("0x7e57000000000000000000000000000000000002")
+	snipeTestExecutor = common.HexToAddress("0x1100000000000000000000000000000000000044")
+	snipeTestSearcher = common.HexToAddress("0x5ea4c4e400000000000000000000000000000044")
+)
+
+// fakeTokenSafety returns a fixed report and records the requests it was given
+type fakeTokenSafety struct {
+	report        *interfaces.TokenSafetyReport
+	err           error
+	feeOnTransfer map[common.Address]bool
+	requests      []*interfaces.TokenSafetyRequest
+}
+
+func (f *fakeTokenSafety) CheckToken(ctx context.Context, request *interfaces.TokenSafetyRequest) (*interfaces.TokenSafetyReport, error) {
+	f.requests = append(f.requests, request)
+	return f.report, f.err
+}
+
+func (f *fakeTokenSafety) Report(token common.Address) (*interfaces.TokenSafetyReport, bool) {
+	if f.report == nil || f.report.Token != token {
+		return nil, false
+	}
+	return f.report, true
+}
+
+func (f *fakeTokenSafety) IsFeeOnTransfer(token common.Address) bool {
+	return f.feeOnTransfer[token]
+}
+
+func testSnipingConfig() *interfaces.SnipingConfig {
+	return &interfaces.SnipingConfig{
+		Factories:          map[common.Address]interfaces.Protocol{BaseSushiSwapV2Factory: interfaces.ProtocolSushiSwapV2},
+		QuoteTokens:        []common.Address{pricing.BaseWETH},
+		MinLiquidity:       ether(2),
+		MaxBuyAmount:       big.NewInt(5e17),
+		ExpectedBuyVolume:  ether(3),
+		MaxTaxBps:          500,
+		MinProfitThreshold: big.NewInt(2e16),
+	}
+}
+
+func newSnipingTest(t *testing.T, safety interfaces.TokenSafetyChecker, pools interfaces.PoolRegistry) interfaces.SnipingDetector {
+	t.Helper()
+
+	adapters := protocols.NewRegistry(nil)
+	require.NoError(t, adapters.Register(protocols.NewSushiSwapV2Adapter(protocols.BaseSushiSwapV2Router)))
+	builder := &recordingBuilder{searcher: snipeTestSearcher, executor: snipeTestExecutor, nonce: 2}
+	return NewSnipingDetector(testSnipingConfig(), safety, adapters, pools, nil, nil, WithTransactionBuilder(builder))
+}
+
+func pairCreatedLog(token0, token1, pair common.Address) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: BaseSushiSwapV2Factory,
+		Topics:  []common.Hash{pairCreatedTopic, common.BytesToHash(token0.Bytes()), common.BytesToHash(token1.Bytes())},
+		Data:    append(common.BytesToHash(pair.Bytes()).Bytes(), common.BigToHash(big.NewInt(1)).Bytes()...),
+	}
+}
+
+func syncLog(reserve0, reserve1 *big.Int) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: snipeTestPool,
+		Topics:  []common.Hash{crypto.Keccak256Hash([]byte("Sync(uint112,uint112)"))},
+		Data:    append(common.BigToHash(reserve0).Bytes(), common.BigToHash(reserve1).Bytes()...),
+	}
+}
+
+func mintLog(amount0, amount1 *big.Int) *ethtypes.Log {
+	return &ethtypes.Log{
+		Address: snipeTestPool,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("Mint(address,uint256,uint256)")),
+			common.BytesToHash(protocols.BaseSushiSwapV2Router.Bytes()),
+		},
+		Data: append(common.BigToHash(amount0).Bytes(), common.BigToHash(amount1).Bytes()...),
+	}
+}
+
+// launchLogs creates the WETH pair and adds 5 WETH against a million tokens
+func launchLogs() []*ethtypes.Log {
+	tokens := ether(1000000)
+	return []*ethtypes.Log{
+		pairCreatedLog(pricing.BaseWETH, snipeTestToken, snipeTestPool),
+		syncLog(ether(5), tokens),
+		mintLog(ether(5), tokens),
+	}
+}
+
+func snipeTargetTx() *types.Transaction {
+	return &types.Transaction{Hash: "0xlaunch", To: &protocols.BaseSushiSwapV2Router, GasPrice: big.NewInt(1e7), ChainID: big.NewInt(8453)}
+}
+
+func safeReport(buyTaxBps, sellTaxBps uint16) *interfaces.TokenSafetyReport {
+	return &interfaces.TokenSafetyReport{Token: snipeTestToken, Pool: snipeTestPool, Safe: true, BuyTaxBps: buyTaxBps, SellTaxBps: sellTaxBps}
+}
+
+func TestSnipingDetector_DetectOpportunity(t *testing.T) {
+	tests := []struct {
+		name    string
+		logs    []*ethtypes.Log
+		pools   interfaces.PoolRegistry
+		created bool
+	}{
+		{
+			name:    "new pair with first liquidity",
+			logs:    launchLogs(),
+			created: true,
+		},
+		{
+			name: "first liquidity into a registered pair",
+			logs: launchLogs()[1:],
+			pools: &fakePoolRegistry{pools: map[common.Address]*interfaces.PoolInfo{
+				snipeTestPool: {Address: snipeTestPool, Protocol: interfaces.ProtocolSushiSwapV2, Token0: pricing.BaseWETH, Token1: snipeTestToken},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			safety := &fakeTokenSafety{report: safeReport(0, 0)}
+			detector := newSnipingTest(t, safety, tt.pools)
+			tx := snipeTargetTx()
+
+			opportunity, err := detector.DetectOpportunity(context.Background(), tx, &interfaces.SimulationResult{Success: true, Logs: tt.logs})
+			require.NoError(t, err)
+			require.NotNil(t, opportunity)
+
+			assert.Equal(t, snipeTestPool, opportunity.Pool)
+			assert.Equal(t, snipeTestToken, opportunity.Token)
+			assert.Equal(t, pricing.BaseWETH, opportunity.QuoteToken)
+			assert.Equal(t, tt.created, opportunity.Created)
+			assert.Equal(t, ether(5), opportunity.Liquidity)
+			assert.True(t, opportunity.AmountIn.Sign() > 0)
+			assert.True(t, opportunity.AmountIn.Cmp(big.NewInt(5e17)) <= 0, "amount %s", opportunity.AmountIn)
+			assert.True(t, opportunity.ExpectedProfit.Cmp(big.NewInt(2e16)) >= 0, "profit %s", opportunity.ExpectedProfit)
+			assert.Equal(t, new(big.Int).Sub(opportunity.ExitAmount, opportunity.AmountIn), opportunity.ExpectedProfit)
+			assert.Same(t, safety.report, opportunity.Safety)
+
+			// The launch is replayed on the fork before the round trip
+			require.Len(t, safety.requests, 1)
+			assert.Equal(t, snipeTestToken, safety.requests[0].Token)
+			assert.Equal(t, []*types.Transaction{tx}, safety.requests[0].Setup)
+			assert.Equal(t, []*big.Int{ether(5), ether(1000000)}, safety.requests[0].Pool.Reserves)
+
+			buy := opportunity.BuyTx
+			require.NotNil(t, buy)
+			assert.Equal(t, snipeTestSearcher, buy.From)
+			assert.Equal(t, uint64(2), buy.Nonce)
+			assert.Equal(t, tx.GasPrice, buy.GasPrice)
+
+			// The pool doesn't exist until the target lands, so the buy is quoted on the
+			// state the target leaves
+			builder := detector.(*snipingDetector).builder.(*recordingBuilder)
+			require.Len(t, builder.routes, 1)
+			route := builder.routes[0]
+			require.Len(t, route.Hops, 1)
+			hop := route.Hops[0]
+			assert.Equal(t, snipeTestPool, hop.Pool)
+			assert.Equal(t, interfaces.ProtocolSushiSwapV2, hop.Protocol)
+			assert.Equal(t, pricing.BaseWETH, hop.TokenIn)
+			assert.Equal(t, snipeTestToken, hop.TokenOut)
+			require.NotNil(t, hop.State)
+			assert.Equal(t, []*big.Int{ether(5), ether(1000000)}, hop.State.Reserves)
+			assert.Equal(t, opportunity.AmountIn, route.AmountIn)
+			assert.Equal(t, opportunity.AmountOut, route.MinAmountOut)
+		})
+	}
+}
+
+func TestSnipingDetector_TaxesReduceProfit(t *testing.T) {
+	simResult := &interfaces.SimulationResult{Success: true, Logs: launchLogs()}
+
+	untaxed, err := newSnipingTest(t, &fakeTokenSafety{report: safeReport(0, 0)}, nil).
+		DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	require.NotNil(t, untaxed)
+
+	taxed, err := newSnipingTest(t, &fakeTokenSafety{report: safeReport(300, 300)}, nil).
+		DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	require.NotNil(t, taxed)
+
+	assert.True(t, taxed.ExpectedProfit.Cmp(untaxed.ExpectedProfit) < 0)
+	assert.True(t, taxed.AmountOut.Cmp(untaxed.AmountOut) < 0)
+}
+
+func TestSnipingDetector_CapitalSource(t *testing.T) {
+	simResult := &interfaces.SimulationResult{Success: true, Logs: launchLogs()}
+	capital := fixedCapital{pricing.BaseWETH: big.NewInt(3e17)}
+	detector := NewSnipingDetector(nil, nil, nil, nil, nil, nil, WithCapitalSource(capital)).(*snipingDetector)
+	assert.Equal(t, capital, detector.capital)
+
+	// The buy spends at most the WETH available
+	detector = newSnipingTest(t, &fakeTokenSafety{report: safeReport(0, 0)}, nil).(*snipingDetector)
+	detector.capital = capital
+	opportunity, err := detector.DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.True(t, opportunity.AmountIn.Cmp(big.NewInt(3e17)) <= 0, "amount %s", opportunity.AmountIn)
+
+	// Without any, nothing is bought
+	detector.capital = fixedCapital{}
+	opportunity, err = detector.DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+}
+
+func TestSnipingDetector_NoOpportunity(t *testing.T) {
+	tests := []struct {
+		name      string
+		safety    *fakeTokenSafety
+		simResult *interfaces.SimulationResult
+		checked   bool
+	}{
+		{
+			name:      "reverted launch",
+			safety:    &fakeTokenSafety{report: safeReport(0, 0)},
+			simResult: &interfaces.SimulationResult{Success: false, Logs: launchLogs()},
+		},
+		{
+			name:      "honeypot",
+			safety:    &fakeTokenSafety{report: &interfaces.TokenSafetyReport{Token: snipeTestToken, Honeypot: true, Reason: "sell reverted"}},
+			simResult: &interfaces.SimulationResult{Success: true, Logs: launchLogs()},
+			checked:   true,
+		},
+		{
+			name:      "tax above limit",
+			safety:    &fakeTokenSafety{report: safeReport(0, 800)},
+			simResult: &interfaces.SimulationResult{Success: true, Logs: launchLogs()},
+			checked:   true,
+		},
+		{
+			name:   "liquidity added to a funded pair",
+			safety: &fakeTokenSafety{report: safeReport(0, 0)},
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{
+				pairCreatedLog(pricing.BaseWETH, snipeTestToken, snipeTestPool),
+				syncLog(ether(10), ether(2000000)),
+				mintLog(ether(5), ether(1000000)),
+			}},
+		},
+		{
+			name:   "liquidity below minimum",
+			safety: &fakeTokenSafety{report: safeReport(0, 0)},
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{
+				pairCreatedLog(pricing.BaseWETH, snipeTestToken, snipeTestPool),
+				syncLog(ether(1), ether(1000000)),
+				mintLog(ether(1), ether(1000000)),
+			}},
+		},
+		{
+			name:   "pair without a quote token",
+			safety: &fakeTokenSafety{report: safeReport(0, 0)},
+			simResult: &interfaces.SimulationResult{Success: true, Logs: []*ethtypes.Log{
+				pairCreatedLog(pricing.BaseUSDC, snipeTestToken, snipeTestPool),
+				syncLog(ether(5), ether(1000000)),
+				mintLog(ether(5), ether(1000000)),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newSnipingTest(t, tt.safety, nil)
+
+			opportunity, err := detector.DetectOpportunity(context.Background(), snipeTargetTx(), tt.simResult)
+			require.NoError(t, err)
+			assert.Nil(t, opportunity)
+			assert.Equal(t, tt.checked, len(tt.safety.requests) > 0)
+		})
+	}
+}
+
+func TestSnipingDetector_RequiresSafetyCheck(t *testing.T) {
+	simResult := &interfaces.SimulationResult{Success: true, Logs: launchLogs()}
+
+	// Without a checker nothing can be cleared
+	opportunity, err := newSnipingTest(t, nil, nil).DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+
+	_, err = newSnipingTest(t, &fakeTokenSafety{err: errors.New("no fork available")}, nil).
+		DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	assert.Error(t, err)
+
+	// Nor can the buy be sent without a builder
+	detector := newSnipingTest(t, &fakeTokenSafety{report: safeReport(0, 0)}, nil)
+	detector.(*snipingDetector).builder = nil
+	opportunity, err = detector.DetectOpportunity(context.Background(), snipeTargetTx(), simResult)
+	require.NoError(t, err)
+	assert.Nil(t, opportunity)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
@@ -14,7 +15,10 @@ import (
 
 // timeBanditDetector implements the TimeBanditDetector interface
 type timeBanditDetector struct {
-	config *interfaces.TimeBanditConfig
+	config      atomic.Pointer[interfaces.TimeBanditConfig]
+	history     interfaces.BlockHistory
+	forks       interfaces.ForkManager
+	priceOracle interfaces.PriceOracle
 }
 
 // NewTimeBanditDetector creates a new time bandit detector with the given configuration
@@ -24,23 +28,47 @@ func NewTimeBanditDetector(config *interfaces.TimeBanditConfig) interfaces.TimeB
 			MaxBundleSize:      10,
 			MinProfitThreshold: big.NewInt(50), // $50 minimum profit
 			MaxDependencyDepth: 5,
+			LookbackBlocks:     2,
+			MaxOrderings:       32,
 		}
 	}
-	return &timeBanditDetector{
-		config: config,
-	}
+	detector := &timeBanditDetector{}
+	detector.config.Store(config)
+	return detector
+}
+
+// NewTimeBanditDetectorWithHistory creates a time bandit detector that measures
+// reorderings by replaying them on forks. AnalyzeHistory reorders the latest blocks
+// in the history buffer, and DetectOpportunity prices its ordering by simulation
+// instead of by position. The price oracle values tokens other than WETH; without
+// it only WETH and ETH flows are counted.
+func NewTimeBanditDetectorWithHistory(config *interfaces.TimeBanditConfig, history interfaces.BlockHistory, forks interfaces.ForkManager, priceOracle interfaces.PriceOracle) interfaces.TimeBanditDetector {
+	detector := NewTimeBanditDetector(config).(*timeBanditDetector)
+	updated := *detector.config.Load()
+	if updated.LookbackBlocks <= 0 {
+		updated.LookbackBlocks = 2
+	}
+	if updated.MaxOrderings <= 0 {
+		updated.MaxOrderings = 32
+	}
+	detector.config.Store(&updated)
+	detector.history = history
+	detector.forks = forks
+	detector.priceOracle = priceOracle
+	return detector
 }
 
 // DetectOpportunity analyzes a set of transactions to identify reordering opportunities
 func (t *timeBanditDetector) DetectOpportunity(ctx context.Context, txs []*types.Transaction, simResults []*interfaces.SimulationResult) (*interfaces.TimeBanditOpportunity, error) {
+	config := t.config.Load()
 	if len(txs) < 2 {
 		return nil, nil // Need at least 2 transactions for reordering
 	}
 
-	if len(txs) > t.config.MaxBundleSize {
+	if len(txs) > config.MaxBundleSize {
 		// Limit to max bundle size for performance
-		txs = txs[:t.config.MaxBundleSize]
-		simResults = simResults[:t.config.MaxBundleSize]
+		txs = txs[:config.MaxBundleSize]
+		simResults = simResults[:config.MaxBundleSize]
 	}
 
 	// Filter transactions that are suitable for reordering
@@ -60,14 +88,19 @@ func (t *timeBanditDetector) DetectOpportunity(ctx context.Context, txs []*types
 		return nil, fmt.Errorf("failed to find optimal ordering: %w", err)
 	}
 
-	// Calculate expected profit from reordering
-	expectedProfit, err := t.calculateReorderingProfit(suitableTxs, optimalOrder, suitableResults)
+	// Calculate expected profit from reordering, by simulation when forks are available
+	var expectedProfit *big.Int
+	if t.forks != nil {
+		expectedProfit, err = t.simulateReorderingProfit(ctx, suitableTxs, optimalOrder)
+	} else {
+		expectedProfit, err = t.calculateReorderingProfit(suitableTxs, optimalOrder, suitableResults)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate reordering profit: %w", err)
 	}
 
 	// Check if profit meets minimum threshold
-	if expectedProfit.Cmp(t.config.MinProfitThreshold) < 0 {
+	if expectedProfit.Cmp(config.MinProfitThreshold) < 0 {
 		return nil, nil // Profit below threshold
 	}
 
@@ -91,7 +124,7 @@ func (t *timeBanditDetector) FindOptimalOrdering(ctx context.Context, txs []*typ
 	}
 
 	// Create constraint solver
-	solver := newConstraintSolver(txs, t.config)
+	solver := newConstraintSolver(txs, t.config.Load())
 
 	// Add nonce constraints (transactions from same address must be ordered by nonce)
 	if err := solver.addNonceConstraints(); err != nil {
@@ -135,7 +168,7 @@ func (t *timeBanditDetector) ValidateDependencies(ctx context.Context, txs []*ty
 	}
 
 	// Check dependency depth
-	if t.getDependencyDepth(txs) > t.config.MaxDependencyDepth {
+	if t.getDependencyDepth(txs) > t.config.Load().MaxDependencyDepth {
 		return errors.New("dependency depth exceeds maximum allowed")
 	}
 
@@ -144,7 +177,12 @@ func (t *timeBanditDetector) ValidateDependencies(ctx context.Context, txs []*ty
 
 // GetConfiguration returns the current time bandit detector configuration
 func (t *timeBanditDetector) GetConfiguration() *interfaces.TimeBanditConfig {
-	return t.config
+	return t.config.Load()
+}
+
+// SetConfiguration swaps in a new configuration; the one it replaces isn't modified
+func (t *timeBanditDetector) SetConfiguration(config *interfaces.TimeBanditConfig) {
+	t.config.Store(config)
 }
 
 // filterSuitableTransactions filters transactions that are suitable for reordering
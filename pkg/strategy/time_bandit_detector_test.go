@@ -332,13 +332,11 @@ func TestConstraintSolver_TopologicalSort(t *testing.T) {
 }
 
 func TestTimeBanditDetector_CircularDependencyDetection(t *testing.T) {
-	detector := &timeBanditDetector{
-		config: &interfaces.TimeBanditConfig{
-			MaxBundleSize:      10,
-			MinProfitThreshold: big.NewInt(50),
-			MaxDependencyDepth: 5,
-		},
-	}
+	detector := NewTimeBanditDetector(&interfaces.TimeBanditConfig{
+		MaxBundleSize:      10,
+		MinProfitThreshold: big.NewInt(50),
+		MaxDependencyDepth: 5,
+	}).(*timeBanditDetector)
 
 	// Create transactions that would create a circular dependency
 	// This is a simplified test - in practice, circular dependencies would be more complex
@@ -354,13 +352,11 @@ func TestTimeBanditDetector_CircularDependencyDetection(t *testing.T) {
 }
 
 func TestTimeBanditDetector_DependencyDepthCalculation(t *testing.T) {
-	detector := &timeBanditDetector{
-		config: &interfaces.TimeBanditConfig{
-			MaxBundleSize:      10,
-			MinProfitThreshold: big.NewInt(50),
-			MaxDependencyDepth: 5,
-		},
-	}
+	detector := NewTimeBanditDetector(&interfaces.TimeBanditConfig{
+		MaxBundleSize:      10,
+		MinProfitThreshold: big.NewInt(50),
+		MaxDependencyDepth: 5,
+	}).(*timeBanditDetector)
 
 	txs := []*types.Transaction{
 		createMockTimeBanditSwapTransaction("0x1", 1, big.NewInt(1000000000)),
@@ -0,0 +1,310 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+)
+
+// erc20TransferTopic is the ERC-20 Transfer(from, to, value) event
+var erc20TransferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+
+// AnalyzeHistory reorders the latest buffered blocks as one sequence and replays each
+// order on a fork pinned to the parent of the oldest block. Deposits stay at their
+// positions; every other transaction is tried at the front of the window, taking the
+// sender's earlier nonces with it, along with the constraint solver's order.
+func (t *timeBanditDetector) AnalyzeHistory(ctx context.Context) (*interfaces.TimeBanditAnalysis, error) {
+	if t.history == nil || t.forks == nil {
+		return nil, fmt.Errorf("block history and fork manager are required")
+	}
+
+	blocks := t.history.Recent(t.config.Load().LookbackBlocks)
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("block history is empty")
+	}
+	if blocks[0].Number == 0 {
+		return nil, fmt.Errorf("the genesis block has no parent to fork from")
+	}
+	parent := blocks[0].Number - 1
+
+	window := newReorderWindow(blocks)
+	analysis := &interfaces.TimeBanditAnalysis{
+		FromBlock:        blocks[0].Number,
+		ToBlock:          blocks[len(blocks)-1].Number,
+		OriginalOrder:    window.sequence(window.movable),
+		BestOrder:        window.sequence(window.movable),
+		ExtractableValue: big.NewInt(0),
+		Gains:            make(map[common.Address]*big.Int),
+	}
+	if len(window.movable) < 2 {
+		return analysis, nil
+	}
+
+	fork, err := t.forks.GetAvailableFork(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork: %w", err)
+	}
+	defer func() {
+		_ = fork.Reset()
+		_ = t.forks.ReleaseFork(fork)
+	}()
+	pin := func() error { return fork.ResetToBlock(parent) }
+
+	original, err := t.simulateOrder(ctx, fork, pin, analysis.OriginalOrder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay blocks %d-%d: %w", analysis.FromBlock, analysis.ToBlock, err)
+	}
+
+	for _, order := range t.candidateOrders(ctx, window) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sequence := window.sequence(order)
+		values, err := t.simulateOrder(ctx, fork, pin, sequence)
+		if err != nil {
+			return nil, fmt.Errorf("failed to replay reordered blocks: %w", err)
+		}
+		analysis.OrderingsSimulated++
+
+		gains := valueGains(original, values)
+		beneficiary, gain := largestGain(gains)
+		if gain.Cmp(analysis.ExtractableValue) > 0 {
+			analysis.BestOrder = sequence
+			analysis.Beneficiary = beneficiary
+			analysis.ExtractableValue = gain
+			analysis.Gains = gains
+		}
+	}
+
+	return analysis, nil
+}
+
+// simulateReorderingProfit replays both orders on a fork at the current head and
+// returns the largest gain any sender makes from the new order
+func (t *timeBanditDetector) simulateReorderingProfit(ctx context.Context, originalTxs, reorderedTxs []*types.Transaction) (*big.Int, error) {
+	fork, err := t.forks.GetAvailableFork(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fork: %w", err)
+	}
+	defer func() { _ = t.forks.ReleaseFork(fork) }()
+
+	original, err := t.simulateOrder(ctx, fork, fork.Reset, originalTxs)
+	if err != nil {
+		return nil, err
+	}
+	reordered, err := t.simulateOrder(ctx, fork, fork.Reset, reorderedTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	_, gain := largestGain(valueGains(original, reordered))
+	return gain, nil
+}
+
+// simulateOrder resets the fork and replays the transactions in order, returning what
+// each sender gained
+func (t *timeBanditDetector) simulateOrder(ctx context.Context, fork interfaces.Fork, reset func() error, txs []*types.Transaction) (map[common.Address]*big.Int, error) {
+	if err := reset(); err != nil {
+		return nil, fmt.Errorf("failed to reset fork: %w", err)
+	}
+
+	values := make(map[common.Address]*big.Int)
+	for _, tx := range txs {
+		result, err := fork.ExecuteTransaction(ctx, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute %s: %w", tx.Hash, err)
+		}
+
+		value, exists := values[tx.From]
+		if !exists {
+			value = big.NewInt(0)
+			values[tx.From] = value
+		}
+		value.Add(value, t.transactionValue(tx, result))
+	}
+	return values, nil
+}
+
+// transactionValue is what a transaction moved to its sender or the contract it
+// called, in wei of ETH: token transfers in its logs plus their ETH balance changes,
+// which include the gas paid
+func (t *timeBanditDetector) transactionValue(tx *types.Transaction, result *interfaces.SimulationResult) *big.Int {
+	actors := map[common.Address]bool{tx.From: true}
+	if tx.To != nil {
+		actors[*tx.To] = true
+	}
+
+	value := big.NewInt(0)
+	if result == nil {
+		return value
+	}
+
+	if result.StateChanges != nil {
+		for actor := range actors {
+			if change, exists := result.StateChanges[actor]; exists && change.Balance != nil {
+				value.Add(value, change.Balance)
+			}
+		}
+	} else if result.GasPrice != nil {
+		value.Sub(value, new(big.Int).Mul(result.GasPrice, new(big.Int).SetUint64(result.GasUsed)))
+	}
+
+	for _, log := range result.Logs {
+		if len(log.Topics) != 3 || log.Topics[0] != erc20TransferTopic || len(log.Data) != 32 {
+			continue
+		}
+		from := common.BytesToAddress(log.Topics[1].Bytes())
+		to := common.BytesToAddress(log.Topics[2].Bytes())
+		if actors[from] == actors[to] {
+			continue // Unrelated, or moved between the sender and its contract
+		}
+
+		amount, err := t.valueETH(log.Address, new(big.Int).SetBytes(log.Data))
+		if err != nil {
+			continue // Unpriced tokens are left out
+		}
+		if actors[to] {
+			value.Add(value, amount)
+		} else {
+			value.Sub(value, amount)
+		}
+	}
+	return value
+}
+
+// valueETH converts a token amount to wei of ETH
+func (t *timeBanditDetector) valueETH(token common.Address, amount *big.Int) (*big.Int, error) {
+	if token == pricing.BaseWETH {
+		return amount, nil
+	}
+	if t.priceOracle == nil {
+		return nil, fmt.Errorf("no price oracle to value %s", token.Hex())
+	}
+	return t.priceOracle.ValueETH(token, amount)
+}
+
+// candidateOrders returns the orders of the movable transactions to simulate
+func (t *timeBanditDetector) candidateOrders(ctx context.Context, window *reorderWindow) [][]*types.Transaction {
+	var orders [][]*types.Transaction
+	add := func(order []*types.Transaction) bool {
+		if len(orders) >= t.config.Load().MaxOrderings {
+			return false
+		}
+		if !sameOrder(order, window.movable) {
+			orders = append(orders, order)
+		}
+		return true
+	}
+
+	if optimal, err := t.FindOptimalOrdering(ctx, window.movable); err == nil {
+		add(optimal)
+	}
+	for i, tx := range window.movable {
+		if len(tx.Data) == 0 {
+			continue // Transfers gain nothing from their position
+		}
+		if !add(moveToFront(window.movable, i)) {
+			break
+		}
+	}
+	return orders
+}
+
+// reorderWindow is a run of blocks flattened into one sequence, with deposits pinned
+type reorderWindow struct {
+	size    int
+	pinned  map[int]*types.Transaction // Deposits by position in the sequence
+	movable []*types.Transaction       // Everything else, in the original order
+}
+
+// newReorderWindow flattens blocks, pinning each block's leading deposits
+func newReorderWindow(blocks []*interfaces.HistoricalBlock) *reorderWindow {
+	window := &reorderWindow{pinned: make(map[int]*types.Transaction)}
+	for _, block := range blocks {
+		for i, tx := range block.Transactions {
+			if i < block.Deposits {
+				window.pinned[window.size] = tx
+			} else {
+				window.movable = append(window.movable, tx)
+			}
+			window.size++
+		}
+	}
+	return window
+}
+
+// sequence places an order of the movable transactions around the pinned deposits
+func (w *reorderWindow) sequence(order []*types.Transaction) []*types.Transaction {
+	sequence := make([]*types.Transaction, 0, w.size)
+	next := 0
+	for position := 0; position < w.size; position++ {
+		if deposit, exists := w.pinned[position]; exists {
+			sequence = append(sequence, deposit)
+			continue
+		}
+		sequence = append(sequence, order[next])
+		next++
+	}
+	return sequence
+}
+
+// moveToFront moves a transaction to the front, along with the sender's earlier
+// transactions so nonces stay in order
+func moveToFront(txs []*types.Transaction, index int) []*types.Transaction {
+	target := txs[index]
+	var front, rest []*types.Transaction
+	for i, tx := range txs {
+		if i <= index && tx.From == target.From && tx.Nonce <= target.Nonce {
+			front = append(front, tx)
+		} else {
+			rest = append(rest, tx)
+		}
+	}
+	return append(front, rest...)
+}
+
+// sameOrder reports whether two orders are identical
+func sameOrder(a, b []*types.Transaction) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Hash != b[i].Hash {
+			return false
+		}
+	}
+	return true
+}
+
+// valueGains returns each sender's change in value between two replays
+func valueGains(original, reordered map[common.Address]*big.Int) map[common.Address]*big.Int {
+	gains := make(map[common.Address]*big.Int, len(reordered))
+	for sender, value := range reordered {
+		gain := new(big.Int).Set(value)
+		if before, exists := original[sender]; exists {
+			gain.Sub(gain, before)
+		}
+		gains[sender] = gain
+	}
+	return gains
+}
+
+// largestGain returns the sender with the largest gain; ties go to the lower address
+func largestGain(gains map[common.Address]*big.Int) (common.Address, *big.Int) {
+	var beneficiary common.Address
+	best := big.NewInt(0)
+	for sender, gain := range gains {
+		cmp := gain.Cmp(best)
+		if cmp > 0 || (cmp == 0 && gain.Sign() > 0 && sender.Hex() < beneficiary.Hex()) {
+			beneficiary, best = sender, gain
+		}
+	}
+	return beneficiary, best
+}
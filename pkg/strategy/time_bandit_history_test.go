@@ -0,0 +1,316 @@
+package strategy
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/history"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/interfaces"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/pricing"
+	"github.com/mev-engine/l2-mev-strategy-engine/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testRouter    = common.HexToAddress("0x2626664c2603336E57B271c5C0b26F421741e481")
+	testL1Block   = common.HexToAddress("0x4200000000000000000000000000000000000015")
+	testDepositor = common.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001")
+)
+
+// arbFork pays 1 WETH to whichever router swap runs first after a reset, as an
+// arbitrage that only the first taker can close would
+type arbFork struct {
+	resets   []uint64 // Pinned block numbers; 0 for a reset to the head
+	executed []string
+	paid     bool
+}
+
+func (f *arbFork) GetID() string { return "arb" }
+
+func (f *arbFork) ExecuteTransaction(ctx context.Context, tx *types.Transaction) (*interfaces.SimulationResult, error) {
+	f.executed = append(f.executed, tx.Hash)
+	result := &interfaces.SimulationResult{
+		Success:  true,
+		GasUsed:  100000,
+		GasPrice: big.NewInt(1),
+	}
+	if tx.To != nil && *tx.To == testRouter && !f.paid {
+		f.paid = true
+		result.Logs = []*ethtypes.Log{{
+			Address: pricing.BaseWETH,
+			Topics: []common.Hash{
+				erc20TransferTopic,
+				common.BytesToHash(common.HexToAddress("0xbeef").Bytes()),
+				common.BytesToHash(tx.From.Bytes()),
+			},
+			Data: common.BigToHash(big.NewInt(1e18)).Bytes(),
+		}}
+	}
+	return result, nil
+}
+
+func (f *arbFork) GetBlockNumber() (*big.Int, error) { return big.NewInt(0), nil }
+
+func (f *arbFork) GetBalance(address common.Address) (*big.Int, error) { return big.NewInt(0), nil }
+
+func (f *arbFork) Reset() error {
+	f.resets = append(f.resets, 0)
+	f.paid = false
+	return nil
+}
+
+func (f *arbFork) ResetToBlock(blockNumber uint64) error {
+	f.resets = append(f.resets, blockNumber)
+	f.paid = false
+	return nil
+}
+
+func (f *arbFork) Close() error    { return nil }
+func (f *arbFork) IsHealthy() bool { return true }
+
+// singleForkManager hands out one fork
+type singleForkManager struct {
+	fork     interfaces.Fork
+	released int
+}
+
+func (m *singleForkManager) CreateFork(ctx context.Context, forkURL string) (interfaces.Fork, error) {
+	return m.fork, nil
+}
+
+func (m *singleForkManager) GetAvailableFork(ctx context.Context) (interfaces.Fork, error) {
+	return m.fork, nil
+}
+
+func (m *singleForkManager) ReleaseFork(fork interfaces.Fork) error {
+	m.released++
+	return nil
+}
+
+func (m *singleForkManager) CleanupForks() error { return nil }
+
+func (m *singleForkManager) GetForkPoolStats() interfaces.ForkPoolStats {
+	return interfaces.ForkPoolStats{TotalForks: 1}
+}
+
+func routerSwap(hash string, from common.Address, nonce uint64) *types.Transaction {
+	return &types.Transaction{
+		Hash:     hash,
+		From:     from,
+		To:       &testRouter,
+		Value:    big.NewInt(0),
+		GasPrice: big.NewInt(1),
+		GasLimit: 200000,
+		Nonce:    nonce,
+		Data:     common.Hex2Bytes("38ed1739"),
+	}
+}
+
+func depositTx(hash string) *types.Transaction {
+	return &types.Transaction{
+		Hash:     hash,
+		From:     testDepositor,
+		To:       &testL1Block,
+		Value:    big.NewInt(0),
+		GasPrice: big.NewInt(0),
+		Data:     common.Hex2Bytes("440a5e20"),
+	}
+}
+
+func hashes(txs []*types.Transaction) []string {
+	var result []string
+	for _, tx := range txs {
+		result = append(result, tx.Hash)
+	}
+	return result
+}
+
+// testHistory buffers two linked blocks, each led by a deposit
+func testHistory(t *testing.T, first, second []*types.Transaction) interfaces.BlockHistory {
+	t.Helper()
+
+	buffer := history.NewBlockHistory(nil)
+	parent := common.HexToHash("0xa0")
+	for i, txs := range [][]*types.Transaction{first, second} {
+		block := &interfaces.HistoricalBlock{
+			Number:       uint64(101 + i),
+			Hash:         common.BigToHash(big.NewInt(int64(0xa1 + i))),
+			ParentHash:   parent,
+			Transactions: txs,
+			Deposits:     1,
+		}
+		_, err := buffer.Add(block)
+		require.NoError(t, err)
+		parent = block.Hash
+	}
+	return buffer
+}
+
+func TestTimeBanditDetector_AnalyzeHistory(t *testing.T) {
+	alice := common.HexToAddress("0xa11ce")
+	bob := common.HexToAddress("0xb0b")
+
+	buffer := testHistory(t,
+		[]*types.Transaction{depositTx("d1"), routerSwap("alice", alice, 1)},
+		[]*types.Transaction{depositTx("d2"), routerSwap("bob-0", bob, 7), routerSwap("bob-1", bob, 8)},
+	)
+	fork := &arbFork{}
+	forks := &singleForkManager{fork: fork}
+	detector := NewTimeBanditDetectorWithHistory(nil, buffer, forks, nil)
+
+	analysis, err := detector.AnalyzeHistory(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(101), analysis.FromBlock)
+	assert.Equal(t, uint64(102), analysis.ToBlock)
+	assert.Equal(t, []string{"d1", "alice", "d2", "bob-0", "bob-1"}, hashes(analysis.OriginalOrder))
+
+	// Bob's first swap takes the arbitrage; deposits keep their positions
+	assert.Equal(t, []string{"d1", "bob-0", "d2", "alice", "bob-1"}, hashes(analysis.BestOrder))
+	assert.Equal(t, bob, analysis.Beneficiary)
+	assert.Equal(t, big.NewInt(1e18), analysis.ExtractableValue)
+	assert.Equal(t, big.NewInt(-1e18), analysis.Gains[alice])
+	assert.Positive(t, analysis.OrderingsSimulated)
+
+	// Every replay starts from the parent of the first block, and the fork is
+	// returned at the head
+	require.NotEmpty(t, fork.resets)
+	for _, pinned := range fork.resets[:len(fork.resets)-1] {
+		assert.Equal(t, uint64(100), pinned)
+	}
+	assert.Equal(t, uint64(0), fork.resets[len(fork.resets)-1])
+	assert.Equal(t, 1, forks.released)
+}
+
+func TestTimeBanditDetector_AnalyzeHistoryNothingToGain(t *testing.T) {
+	alice := common.HexToAddress("0xa11ce")
+
+	// A single sender can't be reordered against anyone
+	buffer := testHistory(t,
+		[]*types.Transaction{depositTx("d1"), routerSwap("alice-0", alice, 1)},
+		[]*types.Transaction{depositTx("d2"), routerSwap("alice-1", alice, 2)},
+	)
+	detector := NewTimeBanditDetectorWithHistory(nil, buffer, &singleForkManager{fork: &arbFork{}}, nil)
+
+	analysis, err := detector.AnalyzeHistory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, hashes(analysis.OriginalOrder), hashes(analysis.BestOrder))
+	assert.Zero(t, analysis.ExtractableValue.Sign())
+	assert.Equal(t, common.Address{}, analysis.Beneficiary)
+}
+
+func TestTimeBanditDetector_AnalyzeHistoryErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector interfaces.TimeBanditDetector
+	}{
+		{
+			name:     "no history",
+			detector: NewTimeBanditDetector(nil),
+		},
+		{
+			name:     "empty history",
+			detector: NewTimeBanditDetectorWithHistory(nil, history.NewBlockHistory(nil), &singleForkManager{fork: &arbFork{}}, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.detector.AnalyzeHistory(context.Background())
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTimeBanditDetector_TransactionValue(t *testing.T) {
+	sender := common.HexToAddress("0x5e4de4")
+	token := common.HexToAddress("0x70c3")
+	tx := routerSwap("swap", sender, 0)
+
+	transfer := func(token, from, to common.Address, amount int64) *ethtypes.Log {
+		return &ethtypes.Log{
+			Address: token,
+			Topics: []common.Hash{
+				erc20TransferTopic,
+				common.BytesToHash(from.Bytes()),
+				common.BytesToHash(to.Bytes()),
+			},
+			Data: common.BigToHash(big.NewInt(amount)).Bytes(),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		oracle   interfaces.PriceOracle
+		result   *interfaces.SimulationResult
+		expected int64
+	}{
+		{
+			name:     "gas only",
+			result:   &interfaces.SimulationResult{GasUsed: 100, GasPrice: big.NewInt(3)},
+			expected: -300,
+		},
+		{
+			name: "balance changes replace gas",
+			result: &interfaces.SimulationResult{
+				GasUsed:  100,
+				GasPrice: big.NewInt(3),
+				StateChanges: map[common.Address]*interfaces.AccountState{
+					sender:     {Balance: big.NewInt(-500)},
+					testRouter: {Balance: big.NewInt(200)},
+				},
+			},
+			expected: -300,
+		},
+		{
+			name: "weth in and out",
+			result: &interfaces.SimulationResult{Logs: []*ethtypes.Log{
+				transfer(pricing.BaseWETH, common.HexToAddress("0x1"), sender, 1000),
+				transfer(pricing.BaseWETH, testRouter, common.HexToAddress("0x2"), 400),
+				transfer(pricing.BaseWETH, sender, testRouter, 5000), // Internal
+			}},
+			expected: 600,
+		},
+		{
+			name: "unpriced token skipped",
+			result: &interfaces.SimulationResult{Logs: []*ethtypes.Log{
+				transfer(token, common.HexToAddress("0x1"), sender, 1000),
+			}},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := NewTimeBanditDetectorWithHistory(nil, nil, nil, tt.oracle).(*timeBanditDetector)
+			assert.Equal(t, big.NewInt(tt.expected), detector.transactionValue(tx, tt.result))
+		})
+	}
+}
+
+func TestTimeBanditDetector_DetectOpportunitySimulated(t *testing.T) {
+	alice := common.HexToAddress("0xa11ce")
+	bob := common.HexToAddress("0xb0b")
+	fork := &arbFork{}
+	detector := NewTimeBanditDetectorWithHistory(&interfaces.TimeBanditConfig{
+		MaxBundleSize:      10,
+		MinProfitThreshold: big.NewInt(1),
+		MaxDependencyDepth: 5,
+	}, nil, &singleForkManager{fork: fork}, nil)
+
+	// Bob pays the higher gas price, so the solver moves him ahead of Alice
+	txs := []*types.Transaction{routerSwap("alice", alice, 1), routerSwap("bob", bob, 1)}
+	txs[0].GasPrice = big.NewInt(1e9)
+	txs[1].GasPrice = big.NewInt(10e9)
+	results := []*interfaces.SimulationResult{createMockSimResult(true), createMockSimResult(true)}
+
+	opportunity, err := detector.DetectOpportunity(context.Background(), txs, results)
+	require.NoError(t, err)
+	require.NotNil(t, opportunity)
+	assert.Equal(t, big.NewInt(1e18), opportunity.ExpectedProfit)
+	assert.Equal(t, []uint64{0, 0}, fork.resets)
+}
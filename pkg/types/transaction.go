@@ -21,6 +21,7 @@ type Transaction struct {
 	BlockNumber *big.Int       `json:"blockNumber,omitempty"`
 	TxIndex     uint           `json:"transactionIndex,omitempty"`
 	ChainID     *big.Int       `json:"chainId"`
+	Raw         []byte         `json:"raw,omitempty"` // Signed encoding, for other accounts' transactions we bundle
 }
 
 // TransactionType represents different types of transactions
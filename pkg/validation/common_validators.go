@@ -225,13 +225,13 @@ func (tbv *TimeBanditValidator) runGenericTests(ctx context.Context, testCases [
 
 // CrossLayerValidator implements StrategyDetector for cross layer arbitrage strategies
 type CrossLayerValidator struct {
-	detector strategy.CrossLayerDetectorImpl
+	detector *strategy.CrossLayerDetectorImpl
 	config   *ValidationConfig
 	metrics  *StrategyValidationMetrics
 }
 
 // NewCrossLayerValidator creates a new cross layer strategy validator
-func NewCrossLayerValidator(detector strategy.CrossLayerDetectorImpl, config *ValidationConfig) *CrossLayerValidator {
+func NewCrossLayerValidator(detector *strategy.CrossLayerDetectorImpl, config *ValidationConfig) *CrossLayerValidator {
 	return &CrossLayerValidator{
 		detector: detector,
 		config:   config,
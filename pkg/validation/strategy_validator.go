@@ -231,7 +231,7 @@ func (svf *StrategyValidationFramework) initializeStrategyDetectors() {
 	// Cross layer strategy validator (using concrete type)
 	crossLayerDetector := strategy.NewCrossLayerDetector(nil, nil, nil, common.Address{})
 	svf.strategyDetectors[interfaces.StrategyCrossLayer] = NewCrossLayerValidator(
-		*crossLayerDetector,
+		crossLayerDetector,
 		svf.config,
 	)
 }
@@ -93,4 +93,33 @@ INSERT INTO strategy_configs (strategy_name, config, enabled) VALUES
     "min_profit_threshold": "200000000000000000",
     "max_dependency_depth": 3
 }', false)
-ON CONFLICT (strategy_name) DO NOTHING;
\ No newline at end of file
+ON CONFLICT (strategy_name) DO NOTHING;
+
+-- Create table for pools discovered from factory events
+CREATE TABLE IF NOT EXISTS pools (
+    address VARCHAR(42) PRIMARY KEY,
+    protocol VARCHAR(50) NOT NULL,
+    factory VARCHAR(42) NOT NULL,
+    token0 VARCHAR(42) NOT NULL,
+    token1 VARCHAR(42) NOT NULL,
+    fee INTEGER NOT NULL DEFAULT 0,
+    tick_spacing INTEGER NOT NULL DEFAULT 0,
+    stable BOOLEAN NOT NULL DEFAULT false,
+    created_block BIGINT NOT NULL,
+    created_tx VARCHAR(66) NOT NULL,
+    tvl DECIMAL(78, 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for pool lookups
+CREATE INDEX IF NOT EXISTS idx_pools_token_pair ON pools(token0, token1);
+CREATE INDEX IF NOT EXISTS idx_pools_protocol_fee ON pools(protocol, fee);
+CREATE INDEX IF NOT EXISTS idx_pools_tvl ON pools(tvl);
+
+-- Create table for pool registry sync progress per factory
+CREATE TABLE IF NOT EXISTS pool_registry_checkpoints (
+    factory VARCHAR(42) PRIMARY KEY,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);